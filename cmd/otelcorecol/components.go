@@ -8,9 +8,12 @@ import (
 	otlpexporter "go.opentelemetry.io/collector/exporter/otlpexporter"
 	otlphttpexporter "go.opentelemetry.io/collector/exporter/otlphttpexporter"
 	ballastextension "go.opentelemetry.io/collector/extension/ballastextension"
+	healthextension "go.opentelemetry.io/collector/extension/healthextension"
+	pprofextension "go.opentelemetry.io/collector/extension/pprofextension"
 	zpagesextension "go.opentelemetry.io/collector/extension/zpagesextension"
 	batchprocessor "go.opentelemetry.io/collector/processor/batchprocessor"
 	memorylimiterprocessor "go.opentelemetry.io/collector/processor/memorylimiterprocessor"
+	temporalityprocessor "go.opentelemetry.io/collector/processor/temporalityprocessor"
 	otlpreceiver "go.opentelemetry.io/collector/receiver/otlpreceiver"
 )
 
@@ -20,6 +23,8 @@ func components() (component.Factories, error) {
 
 	factories.Extensions, err = component.MakeExtensionFactoryMap(
 		ballastextension.NewFactory(),
+		healthextension.NewFactory(),
+		pprofextension.NewFactory(),
 		zpagesextension.NewFactory(),
 	)
 	if err != nil {
@@ -45,6 +50,7 @@ func components() (component.Factories, error) {
 	factories.Processors, err = component.MakeProcessorFactoryMap(
 		batchprocessor.NewFactory(),
 		memorylimiterprocessor.NewFactory(),
+		temporalityprocessor.NewFactory(),
 	)
 	if err != nil {
 		return component.Factories{}, err
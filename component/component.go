@@ -93,6 +93,21 @@ const (
 	KindExtension
 )
 
+// String returns the string representation of the Kind, as used in the configuration.
+func (k Kind) String() string {
+	switch k {
+	case KindReceiver:
+		return "receiver"
+	case KindProcessor:
+		return "processor"
+	case KindExporter:
+		return "exporter"
+	case KindExtension:
+		return "extension"
+	}
+	return ""
+}
+
 // Factory is implemented by all component factories.
 //
 // This interface cannot be directly implemented. Implementations must
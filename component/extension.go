@@ -44,6 +44,15 @@ type PipelineWatcher interface {
 	NotReady() error
 }
 
+// StatusWatcher is an extra interface for Extension hosted by the OpenTelemetry
+// Collector that is to be implemented by extensions interested in the status of
+// the components, e.g.: a health check.
+type StatusWatcher interface {
+	// ComponentStatusChanged notifies the Extension that a component reported a new status.
+	// It is called synchronously by the reporting component and must not block.
+	ComponentStatusChanged(source *InstanceID, event *StatusEvent)
+}
+
 // ExtensionCreateSettings is passed to ExtensionFactory.Create* functions.
 type ExtensionCreateSettings struct {
 	TelemetrySettings
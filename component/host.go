@@ -29,6 +29,14 @@ type Host interface {
 	// before Component.Shutdown() begins.
 	ReportFatalError(err error)
 
+	// ReportComponentStatus is used to report the status of the component to the host, e.g. a
+	// recoverable error when an exporter fails to send data, and back to OK once it succeeds again.
+	// The status is made available to the extensions implementing StatusWatcher, e.g. health checks.
+	//
+	// ReportComponentStatus can be called by the component anytime after Component.Start() begins and
+	// until Component.Shutdown() ends.
+	ReportComponentStatus(event *StatusEvent)
+
 	// GetFactory of the specified kind. Returns the factory for a component type.
 	// This allows components to create other components. For example:
 	//   func (r MyReceiver) Start(host component.Host) error {
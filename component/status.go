@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component // import "go.opentelemetry.io/collector/component"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Status represents the status of a component.
+type Status int32
+
+const (
+	// StatusStarting indicates that the component is starting.
+	StatusStarting Status = iota
+	// StatusOK indicates that the component is working as expected.
+	StatusOK
+	// StatusRecoverableError indicates that the component encountered an error it may recover from,
+	// e.g. an exporter failing to send data to an unavailable destination.
+	StatusRecoverableError
+	// StatusPermanentError indicates that the component encountered an error it cannot recover from,
+	// the component will not work until the collector is restarted.
+	StatusPermanentError
+	// StatusStopping indicates that the component is shutting down.
+	StatusStopping
+)
+
+// String returns the string representation of the Status.
+func (s Status) String() string {
+	switch s {
+	case StatusStarting:
+		return "Starting"
+	case StatusOK:
+		return "OK"
+	case StatusRecoverableError:
+		return "RecoverableError"
+	case StatusPermanentError:
+		return "PermanentError"
+	case StatusStopping:
+		return "Stopping"
+	}
+	return ""
+}
+
+// StatusEvent contains a status and, for the error statuses, the error causing it.
+type StatusEvent struct {
+	status    Status
+	err       error
+	timestamp time.Time
+}
+
+// Status returns the Status of the event.
+func (ev *StatusEvent) Status() Status {
+	return ev.status
+}
+
+// Err returns the error causing the status, nil for StatusOK.
+func (ev *StatusEvent) Err() error {
+	return ev.err
+}
+
+// Timestamp returns the time the event was created.
+func (ev *StatusEvent) Timestamp() time.Time {
+	return ev.timestamp
+}
+
+// NewStatusEvent creates a StatusEvent with the given status, not caused by an error: StatusStarting,
+// StatusOK or StatusStopping. Use NewRecoverableErrorEvent and NewPermanentErrorEvent for the error statuses.
+func NewStatusEvent(status Status) *StatusEvent {
+	return &StatusEvent{status: status, timestamp: time.Now()}
+}
+
+// NewRecoverableErrorEvent creates a StatusEvent with the StatusRecoverableError status caused by the error.
+func NewRecoverableErrorEvent(err error) *StatusEvent {
+	return &StatusEvent{status: StatusRecoverableError, err: err, timestamp: time.Now()}
+}
+
+// NewPermanentErrorEvent creates a StatusEvent with the StatusPermanentError status caused by the error.
+func NewPermanentErrorEvent(err error) *StatusEvent {
+	return &StatusEvent{status: StatusPermanentError, err: err, timestamp: time.Now()}
+}
+
+// InstanceID identifies a component instance hosted by the collector.
+type InstanceID struct {
+	ID   config.ComponentID
+	Kind Kind
+	// PipelineID is the pipeline of the processors, as the collector creates a processor instance for
+	// every pipeline it is part of. It is empty for the other kinds of components.
+	PipelineID config.ComponentID
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusEvent(t *testing.T) {
+	ev := NewStatusEvent(StatusOK)
+	assert.Equal(t, StatusOK, ev.Status())
+	assert.NoError(t, ev.Err())
+	assert.False(t, ev.Timestamp().IsZero())
+
+	err := errors.New("error")
+	ev = NewRecoverableErrorEvent(err)
+	assert.Equal(t, StatusRecoverableError, ev.Status())
+	assert.Equal(t, err, ev.Err())
+
+	ev = NewPermanentErrorEvent(err)
+	assert.Equal(t, StatusPermanentError, ev.Status())
+	assert.Equal(t, err, ev.Err())
+}
+
+func TestStatusString(t *testing.T) {
+	assert.Equal(t, "Starting", StatusStarting.String())
+	assert.Equal(t, "OK", StatusOK.String())
+	assert.Equal(t, "RecoverableError", StatusRecoverableError.String())
+	assert.Equal(t, "PermanentError", StatusPermanentError.String())
+	assert.Equal(t, "Stopping", StatusStopping.String())
+	assert.Equal(t, "", Status(-1).String())
+}
+
+func TestKindString(t *testing.T) {
+	assert.Equal(t, "receiver", KindReceiver.String())
+	assert.Equal(t, "processor", KindProcessor.String())
+	assert.Equal(t, "exporter", KindExporter.String())
+	assert.Equal(t, "extension", KindExtension.String())
+	assert.Equal(t, "", Kind(0).String())
+}
@@ -17,6 +17,10 @@ package config // import "go.opentelemetry.io/collector/config"
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"sort"
+
+	"go.uber.org/multierr"
 )
 
 var (
@@ -50,101 +54,177 @@ var _ validatable = (*Config)(nil)
 // invalid cases that we currently don't check for but which we may want to add in
 // the future (e.g. disallowing receiving and exporting on the same endpoint).
 func (cfg *Config) Validate() error {
+	var first error
+	cfg.validate(func(err error) bool {
+		first = err
+		return false
+	})
+	return first
+}
+
+// ValidateAll is similar to Validate, but instead of stopping at the first problem it
+// returns all the errors found in the configuration combined using multierr.
+func (cfg *Config) ValidateAll() error {
+	var errs error
+	cfg.validate(func(err error) bool {
+		errs = multierr.Append(errs, err)
+		return true
+	})
+	return errs
+}
+
+// validate calls report for every error found in the configuration, and stops as soon as report returns false.
+func (cfg *Config) validate(report func(error) bool) {
 	// Currently, there is no default receiver enabled.
 	// The configuration must specify at least one receiver to be valid.
-	if len(cfg.Receivers) == 0 {
-		return errMissingReceivers
+	if len(cfg.Receivers) == 0 && !report(errMissingReceivers) {
+		return
 	}
 
 	// Validate the receiver configuration.
-	for recvID, recvCfg := range cfg.Receivers {
-		if err := recvCfg.Validate(); err != nil {
-			return fmt.Errorf("receiver %q has invalid configuration: %w", recvID, err)
+	for _, recvID := range SortedComponentIDs(cfg.Receivers) {
+		if err := cfg.Receivers[recvID].Validate(); err != nil && !report(fmt.Errorf("receiver %q has invalid configuration: %w", recvID, err)) {
+			return
 		}
 	}
 
 	// Currently, there is no default exporter enabled.
 	// The configuration must specify at least one exporter to be valid.
-	if len(cfg.Exporters) == 0 {
-		return errMissingExporters
+	if len(cfg.Exporters) == 0 && !report(errMissingExporters) {
+		return
 	}
 
 	// Validate the exporter configuration.
-	for expID, expCfg := range cfg.Exporters {
-		if err := expCfg.Validate(); err != nil {
-			return fmt.Errorf("exporter %q has invalid configuration: %w", expID, err)
+	for _, expID := range SortedComponentIDs(cfg.Exporters) {
+		if err := cfg.Exporters[expID].Validate(); err != nil && !report(fmt.Errorf("exporter %q has invalid configuration: %w", expID, err)) {
+			return
 		}
 	}
 
 	// Validate the processor configuration.
-	for procID, procCfg := range cfg.Processors {
-		if err := procCfg.Validate(); err != nil {
-			return fmt.Errorf("processor %q has invalid configuration: %w", procID, err)
+	for _, procID := range SortedComponentIDs(cfg.Processors) {
+		if err := cfg.Processors[procID].Validate(); err != nil && !report(fmt.Errorf("processor %q has invalid configuration: %w", procID, err)) {
+			return
 		}
 	}
 
 	// Validate the extension configuration.
-	for extID, extCfg := range cfg.Extensions {
-		if err := extCfg.Validate(); err != nil {
-			return fmt.Errorf("extension %q has invalid configuration: %w", extID, err)
+	for _, extID := range SortedComponentIDs(cfg.Extensions) {
+		if err := cfg.Extensions[extID].Validate(); err != nil && !report(fmt.Errorf("extension %q has invalid configuration: %w", extID, err)) {
+			return
 		}
 	}
 
-	return cfg.validateService()
+	cfg.validateService(report)
 }
 
-func (cfg *Config) validateService() error {
+func (cfg *Config) validateService(report func(error) bool) {
+	// Check that all the exporters used for the service telemetry are configured.
+	telemetryExporters := []struct {
+		signal string
+		refs   []ComponentID
+	}{
+		{signal: "logs", refs: cfg.Service.Telemetry.Logs.Exporters},
+		{signal: "metrics", refs: cfg.Service.Telemetry.Metrics.Exporters},
+		{signal: "traces", refs: cfg.Service.Telemetry.Traces.Exporters},
+	}
+	for _, te := range telemetryExporters {
+		for _, ref := range te.refs {
+			if cfg.Exporters[ref] == nil && !report(fmt.Errorf("service telemetry %s references exporter %q which does not exist", te.signal, ref)) {
+				return
+			}
+		}
+	}
+
+	if ratio := cfg.Service.Telemetry.Traces.Sampling.Ratio; (ratio < 0 || ratio > 1) &&
+		!report(fmt.Errorf("service telemetry traces sampling ratio must be between 0 and 1, got %v", ratio)) {
+		return
+	}
+
+	for _, propagator := range cfg.Service.Telemetry.Traces.Propagators {
+		if propagator != "tracecontext" && propagator != "baggage" &&
+			!report(fmt.Errorf("service telemetry traces references unsupported propagator %q", propagator)) {
+			return
+		}
+	}
+
+	// Check that all the components with a log level override are configured.
+	for _, ref := range SortedComponentIDs(cfg.Service.Telemetry.Logs.ComponentLevels) {
+		if !cfg.hasComponent(ref) && !report(fmt.Errorf("service telemetry logs component levels references component %q which does not exist", ref)) {
+			return
+		}
+	}
+
 	// Check that all enabled extensions in the service are configured.
 	for _, ref := range cfg.Service.Extensions {
 		// Check that the name referenced in the Service extensions exists in the top-level extensions.
-		if cfg.Extensions[ref] == nil {
-			return fmt.Errorf("service references extension %q which does not exist", ref)
+		if cfg.Extensions[ref] == nil && !report(fmt.Errorf("service references extension %q which does not exist", ref)) {
+			return
 		}
 	}
 
 	// Must have at least one pipeline.
 	if len(cfg.Service.Pipelines) == 0 {
-		return errMissingServicePipelines
+		report(errMissingServicePipelines)
+		return
 	}
 
 	// Check that all pipelines have at least one receiver and one exporter, and they reference
 	// only configured components.
-	for pipelineID, pipeline := range cfg.Service.Pipelines {
+	for _, pipelineID := range SortedComponentIDs(cfg.Service.Pipelines) {
+		pipeline := cfg.Service.Pipelines[pipelineID]
 		// Validate pipeline has at least one receiver.
-		if len(pipeline.Receivers) == 0 {
-			return fmt.Errorf("pipeline %q must have at least one receiver", pipelineID)
+		if len(pipeline.Receivers) == 0 && !report(fmt.Errorf("pipeline %q must have at least one receiver", pipelineID)) {
+			return
 		}
 
 		// Validate pipeline receiver name references.
 		for _, ref := range pipeline.Receivers {
 			// Check that the name referenced in the pipeline's receivers exists in the top-level receivers.
-			if cfg.Receivers[ref] == nil {
-				return fmt.Errorf("pipeline %q references receiver %q which does not exist", pipelineID, ref)
+			if cfg.Receivers[ref] == nil && !report(fmt.Errorf("pipeline %q references receiver %q which does not exist", pipelineID, ref)) {
+				return
 			}
 		}
 
 		// Validate pipeline processor name references.
 		for _, ref := range pipeline.Processors {
 			// Check that the name referenced in the pipeline's processors exists in the top-level processors.
-			if cfg.Processors[ref] == nil {
-				return fmt.Errorf("pipeline %q references processor %q which does not exist", pipelineID, ref)
+			if cfg.Processors[ref] == nil && !report(fmt.Errorf("pipeline %q references processor %q which does not exist", pipelineID, ref)) {
+				return
 			}
 		}
 
 		// Validate pipeline has at least one exporter.
-		if len(pipeline.Exporters) == 0 {
-			return fmt.Errorf("pipeline %q must have at least one exporter", pipelineID)
+		if len(pipeline.Exporters) == 0 && !report(fmt.Errorf("pipeline %q must have at least one exporter", pipelineID)) {
+			return
 		}
 
 		// Validate pipeline exporter name references.
 		for _, ref := range pipeline.Exporters {
 			// Check that the name referenced in the pipeline's Exporters exists in the top-level Exporters.
-			if cfg.Exporters[ref] == nil {
-				return fmt.Errorf("pipeline %q references exporter %q which does not exist", pipelineID, ref)
+			if cfg.Exporters[ref] == nil && !report(fmt.Errorf("pipeline %q references exporter %q which does not exist", pipelineID, ref)) {
+				return
 			}
 		}
 	}
-	return nil
+}
+
+// sortedIDs returns the keys of the given map sorted, so errors are always reported in the same order.
+// hasComponent returns whether a receiver, processor, exporter or extension with the given ID is configured.
+func (cfg *Config) hasComponent(id ComponentID) bool {
+	return cfg.Receivers[id] != nil || cfg.Processors[id] != nil || cfg.Exporters[id] != nil || cfg.Extensions[id] != nil
+}
+
+// SortedComponentIDs returns the keys of the given map keyed by ComponentID, sorted by their string
+// representation, e.g. to report the configuration problems in a stable order.
+func SortedComponentIDs(m interface{}) []ComponentID {
+	keys := reflect.ValueOf(m).MapKeys()
+	ids := make([]ComponentID, 0, len(keys))
+	for _, k := range keys {
+		ids = append(ids, k.Interface().(ComponentID))
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+	return ids
 }
 
 // Type is the component type as it is used in the config.
@@ -163,3 +243,20 @@ type Unmarshallable interface {
 	// The config.Map for this specific component may be nil or empty if no config available.
 	Unmarshal(component *Map) error
 }
+
+// DeprecatedKey describes a configuration key that is deprecated.
+type DeprecatedKey struct {
+	// Key is the deprecated key, relative to the component configuration. Nested keys are
+	// separated by KeyDelimiter.
+	Key string
+
+	// Hint explains how to migrate the configuration, e.g. which key replaces the deprecated one.
+	Hint string
+}
+
+// DeprecatedKeysProvider defines an optional interface for component configurations that deprecate some
+// configuration keys. The strict configuration unmarshaling warns when any of these keys is used.
+type DeprecatedKeysProvider interface {
+	// DeprecatedKeys returns the list of deprecated keys of the component configuration.
+	DeprecatedKeys() []DeprecatedKey
+}
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/multierr"
 	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
@@ -121,6 +122,45 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: errors.New(`service references extension "nop/2" which does not exist`),
 		},
+		{
+			name: "invalid-telemetry-exporter-reference",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Traces.Exporters = []ComponentID{NewComponentIDWithName("nop", "2")}
+				return cfg
+			},
+			expected: errors.New(`service telemetry traces references exporter "nop/2" which does not exist`),
+		},
+		{
+			name: "invalid-telemetry-logs-component-level-reference",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Logs.ComponentLevels = map[ComponentID]zapcore.Level{
+					NewComponentID("nop"):              zapcore.DebugLevel,
+					NewComponentIDWithName("nop", "2"): zapcore.DebugLevel,
+				}
+				return cfg
+			},
+			expected: errors.New(`service telemetry logs component levels references component "nop/2" which does not exist`),
+		},
+		{
+			name: "invalid-telemetry-traces-sampling-ratio",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Traces.Sampling.Ratio = 1.5
+				return cfg
+			},
+			expected: errors.New(`service telemetry traces sampling ratio must be between 0 and 1, got 1.5`),
+		},
+		{
+			name: "invalid-telemetry-traces-propagator",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Traces.Propagators = []string{"tracecontext", "b3"}
+				return cfg
+			},
+			expected: errors.New(`service telemetry traces references unsupported propagator "b3"`),
+		},
 		{
 			name: "invalid-receiver-reference",
 			cfgFn: func() *Config {
@@ -234,6 +274,24 @@ func TestConfigValidate(t *testing.T) {
 	}
 }
 
+func TestConfigValidateAll(t *testing.T) {
+	cfg := generateConfig()
+	cfg.Exporters = nil
+	pipe := cfg.Service.Pipelines[NewComponentID("traces")]
+	pipe.Processors = append(pipe.Processors, NewComponentIDWithName("nop", "2"))
+	cfg.Service.Extensions = append(cfg.Service.Extensions, NewComponentIDWithName("nop", "2"))
+
+	assert.Equal(t, errMissingExporters, cfg.Validate())
+	assert.Equal(t, []error{
+		errMissingExporters,
+		errors.New(`service references extension "nop/2" which does not exist`),
+		errors.New(`pipeline "traces" references processor "nop/2" which does not exist`),
+		errors.New(`pipeline "traces" references exporter "nop" which does not exist`),
+	}, multierr.Errors(cfg.ValidateAll()))
+
+	assert.NoError(t, generateConfig().ValidateAll())
+}
+
 func generateConfig() *Config {
 	return &Config{
 		Receivers: map[ComponentID]Receiver{
@@ -95,6 +95,15 @@ func (l *Map) Set(key string, value interface{}) {
 	_ = l.k.Merge(merged)
 }
 
+// Delete removes the key and all its sub-keys, and returns true if the key was set.
+func (l *Map) Delete(key string) bool {
+	if !l.k.Exists(key) {
+		return false
+	}
+	l.k.Delete(key)
+	return true
+}
+
 // IsSet checks to see if the key has been set in any of the data locations.
 // IsSet is case-insensitive for a key.
 func (l *Map) IsSet(key string) bool {
@@ -33,6 +33,21 @@ func TestToStringMap_WithSet(t *testing.T) {
 	assert.Equal(t, map[string]interface{}{"key": map[string]interface{}{"embedded": int64(123)}}, parser.ToStringMap())
 }
 
+func TestDelete(t *testing.T) {
+	cm := NewMapFromStringMap(map[string]interface{}{
+		"key": map[string]interface{}{
+			"sub1": "value1",
+			"sub2": "value2",
+		},
+		"other": "value",
+	})
+	assert.True(t, cm.Delete("key::sub1"))
+	assert.Equal(t, map[string]interface{}{"key": map[string]interface{}{"sub2": "value2"}, "other": "value"}, cm.ToStringMap())
+	assert.False(t, cm.Delete("key::sub1"))
+	assert.True(t, cm.Delete("key"))
+	assert.Equal(t, map[string]interface{}{"other": "value"}, cm.ToStringMap())
+}
+
 func TestToStringMap(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -31,6 +31,8 @@ import (
 // Properties must follow the Java properties format, key-value list separated by equal sign with a "."
 // as key delimiter.
 //  ["processors.batch.timeout=2s", "processors.batch/foo.timeout=3s"]
+//
+// Deprecated: [v0.51.0] use setmapconverter.New, that supports typed values and list indices.
 func New(properties []string) config.MapConverterFunc {
 	return func(_ context.Context, cfgMap *config.Map) error {
 		return convert(properties, cfgMap)
@@ -0,0 +1,284 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package setmapconverter // import "go.opentelemetry.io/collector/config/mapconverter/setmapconverter"
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// New returns a config.MapConverterFunc, that applies all the given overrides, in order, to the input map.
+//
+// Every override must follow one of the formats:
+//  "<path>=<value>" sets the value at the given path, creating the intermediate maps if needed.
+//  "-<path>" deletes the key, or the list element, at the given path.
+//
+// The path is a list of keys separated by ".", where "\." can be used for keys containing dots, and every
+// key can be followed by one or more "[<index>]" to address list elements. An index equal to the length of
+// the list appends a new element.
+//  ["processors.batch.timeout=2s", "service.pipelines.traces.exporters[1]=otlp/2", "-exporters.logging"]
+//
+// The value is parsed as YAML, so it can be a number, a boolean, a list or a map (e.g. "[otlp, logging]"),
+// quote the value to always set a string (e.g. `"0123"`). A value starting with "@" is replaced by the content
+// of the file at the given path, without the trailing line break, use "@@" to set a value starting with "@".
+//
+// Notice: This API is experimental.
+func New(overrides []string) config.MapConverterFunc {
+	return func(_ context.Context, cfgMap *config.Map) error {
+		if len(overrides) == 0 {
+			return nil
+		}
+
+		root := cfgMap.ToStringMap()
+		for _, override := range overrides {
+			var err error
+			if root, err = apply(root, strings.TrimSpace(override)); err != nil {
+				return fmt.Errorf("invalid override %q: %w", override, err)
+			}
+		}
+
+		for k := range cfgMap.ToStringMap() {
+			cfgMap.Delete(k)
+		}
+		for k, v := range root {
+			cfgMap.Set(k, v)
+		}
+		return nil
+	}
+}
+
+// segment is a key in a map, or an index in a list if key is empty.
+type segment struct {
+	key   string
+	index int
+}
+
+func (s segment) String() string {
+	if s.key == "" {
+		return "[" + strconv.Itoa(s.index) + "]"
+	}
+	return s.key
+}
+
+func apply(root map[string]interface{}, override string) (map[string]interface{}, error) {
+	if strings.HasPrefix(override, "-") {
+		path, err := parsePath(override[1:])
+		if err != nil {
+			return nil, err
+		}
+		ret, err := deleteAt(root, path)
+		if err != nil {
+			return nil, err
+		}
+		return ret.(map[string]interface{}), nil
+	}
+
+	idx := strings.Index(override, "=")
+	if idx == -1 {
+		return nil, fmt.Errorf("missing \"=\", the override must be \"<path>=<value>\" or \"-<path>\"")
+	}
+	path, err := parsePath(override[:idx])
+	if err != nil {
+		return nil, err
+	}
+	value, err := parseValue(override[idx+1:])
+	if err != nil {
+		return nil, err
+	}
+	ret, err := setAt(root, path, value)
+	if err != nil {
+		return nil, err
+	}
+	return ret.(map[string]interface{}), nil
+}
+
+// parsePath parses a path, that must start with a key.
+func parsePath(str string) ([]segment, error) {
+	if str == "" {
+		return nil, fmt.Errorf("empty path")
+	}
+	var path []segment
+	var key strings.Builder
+	for i := 0; i < len(str); i++ {
+		switch c := str[i]; c {
+		case '\\':
+			if i+1 < len(str) && str[i+1] == '.' {
+				key.WriteByte('.')
+				i++
+				continue
+			}
+			key.WriteByte(c)
+		case '.':
+			if key.Len() == 0 {
+				if len(path) == 0 || path[len(path)-1].key != "" {
+					return nil, fmt.Errorf("empty key at position %d", i)
+				}
+				// A "." after an index.
+				continue
+			}
+			path = append(path, segment{key: key.String()})
+			key.Reset()
+		case '[':
+			if key.Len() > 0 {
+				path = append(path, segment{key: key.String()})
+				key.Reset()
+			}
+			if len(path) == 0 {
+				return nil, fmt.Errorf("path must start with a key")
+			}
+			end := strings.IndexByte(str[i:], ']')
+			if end == -1 {
+				return nil, fmt.Errorf("missing \"]\" at position %d", i)
+			}
+			index, err := strconv.Atoi(str[i+1 : i+end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid index %q at position %d", str[i+1:i+end], i)
+			}
+			path = append(path, segment{index: index})
+			i += end
+		default:
+			key.WriteByte(c)
+		}
+	}
+	if key.Len() > 0 {
+		path = append(path, segment{key: key.String()})
+	} else if path[len(path)-1].key != "" {
+		return nil, fmt.Errorf("path cannot end with \".\"")
+	}
+	return path, nil
+}
+
+func parseValue(str string) (interface{}, error) {
+	if strings.HasPrefix(str, "@@") {
+		return str[1:], nil
+	}
+	if strings.HasPrefix(str, "@") {
+		content, err := ioutil.ReadFile(filepath.Clean(str[1:]))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the value from file: %w", err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+	if str == "" {
+		return "", nil
+	}
+	var value interface{}
+	if err := yaml.Unmarshal([]byte(str), &value); err != nil {
+		return nil, fmt.Errorf("unable to parse the value as yaml: %w", err)
+	}
+	return value, nil
+}
+
+// setAt sets the value at the given path inside node, and returns the updated node.
+func setAt(node interface{}, path []segment, value interface{}) (interface{}, error) {
+	seg := path[0]
+	if seg.key != "" {
+		if node == nil {
+			node = map[string]interface{}{}
+		}
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("cannot set key %q, the value is not a map", seg.key)
+		}
+		if len(path) == 1 {
+			m[seg.key] = value
+			return m, nil
+		}
+		child, err := setAt(m[seg.key], path[1:], value)
+		if err != nil {
+			return nil, wrapPathError(seg, err)
+		}
+		m[seg.key] = child
+		return m, nil
+	}
+
+	var l []interface{}
+	if node != nil {
+		var ok bool
+		if l, ok = node.([]interface{}); !ok {
+			return nil, fmt.Errorf("cannot set index %d, the value is not a list", seg.index)
+		}
+	}
+	if seg.index > len(l) {
+		return nil, fmt.Errorf("index %d out of range, the list has %d elements", seg.index, len(l))
+	}
+	if seg.index == len(l) {
+		l = append(l, nil)
+	}
+	if len(path) == 1 {
+		l[seg.index] = value
+		return l, nil
+	}
+	child, err := setAt(l[seg.index], path[1:], value)
+	if err != nil {
+		return nil, wrapPathError(seg, err)
+	}
+	l[seg.index] = child
+	return l, nil
+}
+
+// deleteAt deletes the key or list element at the given path inside node, and returns the updated node.
+func deleteAt(node interface{}, path []segment) (interface{}, error) {
+	seg := path[0]
+	if seg.key != "" {
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("cannot delete key %q, the value is not a map", seg.key)
+		}
+		child, found := m[seg.key]
+		if !found {
+			return nil, fmt.Errorf("cannot delete key %q, the key does not exist", seg.key)
+		}
+		if len(path) == 1 {
+			delete(m, seg.key)
+			return m, nil
+		}
+		child, err := deleteAt(child, path[1:])
+		if err != nil {
+			return nil, wrapPathError(seg, err)
+		}
+		m[seg.key] = child
+		return m, nil
+	}
+
+	l, ok := node.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("cannot delete index %d, the value is not a list", seg.index)
+	}
+	if seg.index >= len(l) {
+		return nil, fmt.Errorf("index %d out of range, the list has %d elements", seg.index, len(l))
+	}
+	if len(path) == 1 {
+		return append(l[:seg.index:seg.index], l[seg.index+1:]...), nil
+	}
+	child, err := deleteAt(l[seg.index], path[1:])
+	if err != nil {
+		return nil, wrapPathError(seg, err)
+	}
+	l[seg.index] = child
+	return l, nil
+}
+
+func wrapPathError(seg segment, err error) error {
+	return fmt.Errorf("%v: %w", seg, err)
+}
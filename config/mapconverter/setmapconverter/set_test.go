@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package setmapconverter
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+func newTestMap() *config.Map {
+	return config.NewMapFromStringMap(map[string]interface{}{
+		"processors": map[string]interface{}{
+			"batch": map[string]interface{}{
+				"timeout": "1s",
+			},
+		},
+		"exporters": map[string]interface{}{
+			"otlp":    map[string]interface{}{"endpoint": "localhost:4317"},
+			"logging": nil,
+		},
+		"service": map[string]interface{}{
+			"pipelines": map[string]interface{}{
+				"traces": map[string]interface{}{
+					"exporters": []interface{}{"otlp", "logging"},
+				},
+			},
+		},
+	})
+}
+
+func TestSetMapConverter_Empty(t *testing.T) {
+	cfgMap := newTestMap()
+	require.NoError(t, New(nil)(context.Background(), cfgMap))
+	assert.Equal(t, newTestMap().ToStringMap(), cfgMap.ToStringMap())
+}
+
+func TestSetMapConverter(t *testing.T) {
+	overrides := []string{
+		"processors.batch.timeout=2s",
+		"processors.batch.send_batch_size=100",
+		"processors.batch/foo.timeout=3s",
+		"exporters.otlp.tls.insecure=true",
+		"exporters.otlp.headers=@" + filepath.Join("testdata", "token.txt"),
+		`exporters.otlp.compression="0123"`,
+		"exporters.otlp.description=@@literal",
+		`exporters.otlp.labels.k8s\.pod=name`,
+		"service.pipelines.traces.exporters[1]=otlp/2",
+		"service.pipelines.traces.exporters[2]=logging",
+		"service.pipelines.metrics.exporters=[otlp, logging]",
+		"service.pipelines.logs.exporters[0]=otlp",
+		"-exporters.logging",
+		"-service.pipelines.metrics.exporters[1]",
+	}
+
+	cfgMap := newTestMap()
+	require.NoError(t, New(overrides)(context.Background(), cfgMap))
+	assert.Equal(t, map[string]interface{}{
+		"processors": map[string]interface{}{
+			"batch": map[string]interface{}{
+				"timeout":         "2s",
+				"send_batch_size": 100,
+			},
+			"batch/foo": map[string]interface{}{
+				"timeout": "3s",
+			},
+		},
+		"exporters": map[string]interface{}{
+			"otlp": map[string]interface{}{
+				"endpoint":    "localhost:4317",
+				"tls":         map[string]interface{}{"insecure": true},
+				"headers":     "secret-token",
+				"compression": "0123",
+				"description": "@literal",
+				"labels":      map[string]interface{}{"k8s.pod": "name"},
+			},
+		},
+		"service": map[string]interface{}{
+			"pipelines": map[string]interface{}{
+				"traces": map[string]interface{}{
+					"exporters": []interface{}{"otlp", "otlp/2", "logging"},
+				},
+				"metrics": map[string]interface{}{
+					"exporters": []interface{}{"otlp"},
+				},
+				"logs": map[string]interface{}{
+					"exporters": []interface{}{"otlp"},
+				},
+			},
+		},
+	}, cfgMap.ToStringMap())
+}
+
+func TestSetMapConverter_Errors(t *testing.T) {
+	tests := []struct {
+		override string
+		err      string
+	}{
+		{override: "processors.batch.timeout", err: `invalid override "processors.batch.timeout": missing "="`},
+		{override: "=2s", err: `invalid override "=2s": empty path`},
+		{override: "processors..timeout=2s", err: `invalid override "processors..timeout=2s": empty key at position 11`},
+		{override: "processors.=2s", err: `invalid override "processors.=2s": path cannot end with "."`},
+		{override: "[0]=2s", err: `invalid override "[0]=2s": path must start with a key`},
+		{override: "service.pipelines.traces.exporters[a]=otlp", err: `invalid override "service.pipelines.traces.exporters[a]=otlp": invalid index "a" at position 34`},
+		{override: "service.pipelines.traces.exporters[1=otlp", err: `invalid override "service.pipelines.traces.exporters[1=otlp": missing "]" at position 34`},
+		{override: "service.pipelines.traces.exporters[5]=otlp", err: `invalid override "service.pipelines.traces.exporters[5]=otlp": service: pipelines: traces: exporters: index 5 out of range, the list has 2 elements`},
+		{override: "service.pipelines.traces.exporters.foo=otlp", err: `invalid override "service.pipelines.traces.exporters.foo=otlp": service: pipelines: traces: exporters: cannot set key "foo", the value is not a map`},
+		{override: "processors.batch[0]=otlp", err: `invalid override "processors.batch[0]=otlp": processors: batch: cannot set index 0, the value is not a list`},
+		{override: "-processors.unknown", err: `invalid override "-processors.unknown": processors: cannot delete key "unknown", the key does not exist`},
+		{override: "-service.pipelines.traces.exporters[2]", err: `invalid override "-service.pipelines.traces.exporters[2]": service: pipelines: traces: exporters: index 2 out of range, the list has 2 elements`},
+		{override: "exporters.otlp=@not_found.txt", err: `invalid override "exporters.otlp=@not_found.txt": unable to read the value from file`},
+		{override: "exporters.otlp=[a", err: `invalid override "exporters.otlp=[a": unable to parse the value as yaml`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.override, func(t *testing.T) {
+			err := New([]string{tt.override})(context.Background(), newTestMap())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
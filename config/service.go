@@ -36,6 +36,7 @@ type Service struct {
 type ServiceTelemetry struct {
 	Logs    ServiceTelemetryLogs    `mapstructure:"logs"`
 	Metrics ServiceTelemetryMetrics `mapstructure:"metrics"`
+	Traces  ServiceTelemetryTraces  `mapstructure:"traces"`
 }
 
 // ServiceTelemetryLogs defines the configurable settings for service telemetry logs.
@@ -94,6 +95,16 @@ type ServiceTelemetryLogs struct {
 	//
 	// By default, there is no initial field.
 	InitialFields map[string]interface{} `mapstructure:"initial_fields"`
+
+	// Exporters is the list of exporters, defined in the exporters section, used to push the
+	// collector's own logs, e.g. an "otlp" exporter to send them to an OTLP endpoint.
+	// By default, logs are not pushed.
+	Exporters []ComponentID `mapstructure:"exporters"`
+
+	// ComponentLevels overrides the Level for the logs of the components with the given IDs, e.g.
+	// "debug" for the "otlp" exporter to debug it without the logs of the other components.
+	// The levels can be changed without restarting the components, by reloading the config.
+	ComponentLevels map[ComponentID]zapcore.Level `mapstructure:"component_levels"`
 }
 
 // ServiceTelemetryMetrics exposes the common Telemetry configuration for one component.
@@ -108,6 +119,43 @@ type ServiceTelemetryMetrics struct {
 
 	// Address is the [address]:port that metrics exposition should be bound to.
 	Address string `mapstructure:"address"`
+
+	// Exporters is the list of exporters, defined in the exporters section, used to periodically push the
+	// collector's own metrics, e.g. an "otlp" exporter to send them to an OTLP endpoint.
+	// By default, metrics are only exposed for scraping on the Address.
+	Exporters []ComponentID `mapstructure:"exporters"`
+}
+
+// ServiceTelemetryTraces defines the configurable settings for service telemetry traces.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type ServiceTelemetryTraces struct {
+	// Exporters is the list of exporters, defined in the exporters section, used to push the
+	// collector's own spans, e.g. an "otlp" exporter to send them to an OTLP endpoint.
+	// By default, spans are only visible in the zpages extension.
+	Exporters []ComponentID `mapstructure:"exporters"`
+
+	// Sampling configures which of the collector's own traces are sampled, and so pushed to the Exporters.
+	// Spans not sampled are still recorded, and visible in the zpages extension. No span is sampled
+	// without Exporters.
+	Sampling ServiceTelemetryTracesSampling `mapstructure:"sampling"`
+
+	// Propagators is the list of context propagators, "tracecontext" (W3C trace context) or "baggage" (W3C
+	// baggage), set as the global propagator of the process, e.g. to trace the data through tiers of collectors
+	// from the incoming requests to the receivers' spans and from the exporters' spans to the outgoing requests.
+	// By default, the global propagator is not changed.
+	Propagators []string `mapstructure:"propagators"`
+}
+
+// ServiceTelemetryTracesSampling defines the sampling of the collector's own traces.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type ServiceTelemetryTracesSampling struct {
+	// Ratio is the ratio, between 0 and 1, of the traces that are sampled. Defaults to 1, all traces are sampled.
+	Ratio float64 `mapstructure:"ratio"`
+
+	// ParentBased, if true, makes the spans with a parent, e.g. propagated by the W3C trace context headers
+	// of an incoming OTLP request, follow the sampling decision of their parent. The Ratio then applies only
+	// to the root spans. Defaults to true.
+	ParentBased bool `mapstructure:"parent_based"`
 }
 
 // DataType is a special Type that represents the data types supported by the collector. We currently support
@@ -50,6 +50,8 @@ type request interface {
 	onError(error) request
 	// Returns the count of spans/metric points or log records.
 	count() int
+	// setReleaseDataFunc sets the function releasing the data of the request once its processing finished.
+	setReleaseDataFunc(func())
 
 	// PersistentRequest provides interface with additional capabilities required by persistent queue
 	internal.PersistentRequest
@@ -64,6 +66,7 @@ type requestSender interface {
 type baseRequest struct {
 	ctx                        context.Context
 	processingFinishedCallback func()
+	releaseDataFunc            func()
 }
 
 func (req *baseRequest) context() context.Context {
@@ -78,10 +81,17 @@ func (req *baseRequest) SetOnProcessingFinished(callback func()) {
 	req.processingFinishedCallback = callback
 }
 
+func (req *baseRequest) setReleaseDataFunc(releaseData func()) {
+	req.releaseDataFunc = releaseData
+}
+
 func (req *baseRequest) OnProcessingFinished() {
 	if req.processingFinishedCallback != nil {
 		req.processingFinishedCallback()
 	}
+	if req.releaseDataFunc != nil {
+		req.releaseDataFunc()
+	}
 }
 
 // baseSettings represents all the options that users can configure.
@@ -89,6 +99,7 @@ type baseSettings struct {
 	component.StartFunc
 	component.ShutdownFunc
 	consumerOptions []consumer.Option
+	releaseData     bool
 	TimeoutSettings
 	QueueSettings
 	RetrySettings
@@ -109,6 +120,11 @@ func fromOptions(options ...Option) *baseSettings {
 		op(opts)
 	}
 
+	if opts.releaseData {
+		// The data is released after being exported, the exporter must be given an exclusive copy.
+		opts.consumerOptions = append(opts.consumerOptions, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+	}
+
 	return opts
 }
 
@@ -164,6 +180,19 @@ func WithCapabilities(capabilities consumer.Capabilities) Option {
 	}
 }
 
+// WithDataRelease releases the data of every request once the exporter finished processing it,
+// after it was sent or dropped, so that its memory is reused by the next Clone of the data.
+// The exporter is declared as mutating the data to be given an exclusive copy of it, the
+// ConsumeFunc must not keep any reference to the data after it returns.
+//
+// The data fanned out to several consumers is shared instead of cloned, see ptrace.Traces.Share,
+// only the memory not shared with other consumers is reused.
+func WithDataRelease() Option {
+	return func(o *baseSettings) {
+		o.releaseData = true
+	}
+}
+
 // baseExporter contains common fields between different exporter types.
 type baseExporter struct {
 	component.StartFunc
@@ -181,7 +210,7 @@ func newBaseExporter(cfg config.Exporter, set component.ExporterCreateSettings,
 		ExporterID:             cfg.ID(),
 		ExporterCreateSettings: set,
 	}, globalInstruments)
-	be.qrSender = newQueuedRetrySender(cfg.ID(), signal, bs.QueueSettings, bs.RetrySettings, reqUnmarshaler, &timeoutSender{cfg: bs.TimeoutSettings}, set.Logger)
+	be.qrSender = newQueuedRetrySender(cfg.ID(), signal, bs.QueueSettings, bs.RetrySettings, reqUnmarshaler, &timeoutSender{cfg: bs.TimeoutSettings}, be.obsrep, set.Logger)
 	be.sender = be.qrSender
 	be.StartFunc = func(ctx context.Context, host component.Host) error {
 		// First start the wrapped exporter.
@@ -29,6 +29,9 @@ import (
 var logsMarshaler = plog.NewProtoMarshaler()
 var logsUnmarshaler = plog.NewProtoUnmarshaler()
 
+// releaseLogs releases the data of the requests, replaced in tests to observe the releases.
+var releaseLogs = plog.Logs.Release
+
 type logsRequest struct {
 	baseRequest
 	ld     plog.Logs
@@ -108,6 +111,9 @@ func NewLogsExporter(
 
 	lc, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
 		req := newLogsRequest(ctx, ld, pusher)
+		if bs.releaseData {
+			req.setReleaseDataFunc(func() { releaseLogs(ld) })
+		}
 		err := be.sender.send(req)
 		if errors.Is(err, errSendingQueueIsFull) {
 			be.obsrep.recordLogsEnqueueFailure(req.context(), int64(req.count()))
@@ -97,6 +97,35 @@ func TestLogsExporter_WithCapabilities(t *testing.T) {
 	assert.Equal(t, capabilities, le.Capabilities())
 }
 
+func TestLogsExporter_WithDataRelease(t *testing.T) {
+	var released []plog.Logs
+	releaseLogs = func(ld plog.Logs) { released = append(released, ld) }
+	t.Cleanup(func() { releaseLogs = plog.Logs.Release })
+
+	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
+	pushed := false
+	exp, err := NewLogsExporter(&fakeLogsExporterConfig, componenttest.NewNopExporterCreateSettings(), func(context.Context, plog.Logs) error {
+		// The data is not released before being exported.
+		assert.Empty(t, released)
+		pushed = true
+		return nil
+	}, WithDataRelease())
+	require.NoError(t, err)
+	assert.Equal(t, consumer.Capabilities{MutatesData: true}, exp.Capabilities())
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), ld))
+	assert.True(t, pushed)
+	// The data was released once exported.
+	assert.Equal(t, []plog.Logs{ld}, released)
+
+	// The data is not released without the option.
+	released = nil
+	exp, err = NewLogsExporter(&fakeLogsExporterConfig, componenttest.NewNopExporterCreateSettings(), newPushLogsData(nil))
+	require.NoError(t, err)
+	require.NoError(t, exp.ConsumeLogs(context.Background(), ld))
+	assert.Empty(t, released)
+}
+
 func TestLogsExporter_Default_ReturnError(t *testing.T) {
 	ld := plog.NewLogs()
 	want := errors.New("my_error")
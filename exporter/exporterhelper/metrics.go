@@ -29,6 +29,9 @@ import (
 var metricsMarshaler = pmetric.NewProtoMarshaler()
 var metricsUnmarshaler = pmetric.NewProtoUnmarshaler()
 
+// releaseMetrics releases the data of the requests, replaced in tests to observe the releases.
+var releaseMetrics = pmetric.Metrics.Release
+
 type metricsRequest struct {
 	baseRequest
 	md     pmetric.Metrics
@@ -109,6 +112,9 @@ func NewMetricsExporter(
 
 	mc, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
 		req := newMetricsRequest(ctx, md, pusher)
+		if bs.releaseData {
+			req.setReleaseDataFunc(func() { releaseMetrics(md) })
+		}
 		err := be.sender.send(req)
 		if errors.Is(err, errSendingQueueIsFull) {
 			be.obsrep.recordMetricsEnqueueFailure(req.context(), int64(req.count()))
@@ -96,6 +96,35 @@ func TestMetricsExporter_WithCapabilities(t *testing.T) {
 	assert.Equal(t, capabilities, me.Capabilities())
 }
 
+func TestMetricsExporter_WithDataRelease(t *testing.T) {
+	var released []pmetric.Metrics
+	releaseMetrics = func(md pmetric.Metrics) { released = append(released, md) }
+	t.Cleanup(func() { releaseMetrics = pmetric.Metrics.Release })
+
+	md := testdata.GenerateMetricsTwoMetrics()
+	pushed := false
+	exp, err := NewMetricsExporter(&fakeMetricsExporterConfig, componenttest.NewNopExporterCreateSettings(), func(context.Context, pmetric.Metrics) error {
+		// The data is not released before being exported.
+		assert.Empty(t, released)
+		pushed = true
+		return nil
+	}, WithDataRelease())
+	require.NoError(t, err)
+	assert.Equal(t, consumer.Capabilities{MutatesData: true}, exp.Capabilities())
+
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+	assert.True(t, pushed)
+	// The data was released once exported.
+	assert.Equal(t, []pmetric.Metrics{md}, released)
+
+	// The data is not released without the option.
+	released = nil
+	exp, err = NewMetricsExporter(&fakeMetricsExporterConfig, componenttest.NewNopExporterCreateSettings(), newPushMetricsData(nil))
+	require.NoError(t, err)
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+	assert.Empty(t, released)
+}
+
 func TestMetricsExporter_Default_ReturnError(t *testing.T) {
 	md := pmetric.NewMetrics()
 	want := errors.New("my_error")
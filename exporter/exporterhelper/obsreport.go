@@ -16,15 +16,39 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 
 import (
 	"context"
+	"time"
 
 	"go.opencensus.io/metric"
 	"go.opencensus.io/metric/metricdata"
 	"go.opencensus.io/metric/metricproducer"
-
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/nonrecording"
+	"go.opentelemetry.io/otel/metric/unit"
+	"go.uber.org/atomic"
+
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/obsreport"
 )
 
+const (
+	scopeName = "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	queueSizeName                   = obsmetrics.ExporterKey + "/queue_size"
+	queueSizeDescription            = "Current size of the retry queue (in batches)"
+	failedToEnqueueSpansName        = obsmetrics.ExporterKey + "/enqueue_failed_spans"
+	failedToEnqueueSpansDescription = "Number of spans failed to be added to the sending queue."
+	failedToEnqueueMetricPointsName = obsmetrics.ExporterKey + "/enqueue_failed_metric_points"
+	failedToEnqueueMetricPointsDesc = "Number of metric points failed to be added to the sending queue."
+	failedToEnqueueLogRecordsName   = obsmetrics.ExporterKey + "/enqueue_failed_log_records"
+	failedToEnqueueLogRecordsDesc   = "Number of log records failed to be added to the sending queue."
+)
+
 // TODO: Incorporate this functionality along with tests from obsreport_test.go
 //       into existing `obsreport` package once its functionally is not exposed
 //       as public API. For now this part is kept private.
@@ -50,26 +74,26 @@ func newInstruments(registry *metric.Registry) *instruments {
 		registry: registry,
 	}
 	insts.queueSize, _ = registry.AddInt64DerivedGauge(
-		obsmetrics.ExporterKey+"/queue_size",
-		metric.WithDescription("Current size of the retry queue (in batches)"),
+		queueSizeName,
+		metric.WithDescription(queueSizeDescription),
 		metric.WithLabelKeys(obsmetrics.ExporterKey),
 		metric.WithUnit(metricdata.UnitDimensionless))
 
 	insts.failedToEnqueueTraceSpans, _ = registry.AddInt64Cumulative(
-		obsmetrics.ExporterKey+"/enqueue_failed_spans",
-		metric.WithDescription("Number of spans failed to be added to the sending queue."),
+		failedToEnqueueSpansName,
+		metric.WithDescription(failedToEnqueueSpansDescription),
 		metric.WithLabelKeys(obsmetrics.ExporterKey),
 		metric.WithUnit(metricdata.UnitDimensionless))
 
 	insts.failedToEnqueueMetricPoints, _ = registry.AddInt64Cumulative(
-		obsmetrics.ExporterKey+"/enqueue_failed_metric_points",
-		metric.WithDescription("Number of metric points failed to be added to the sending queue."),
+		failedToEnqueueMetricPointsName,
+		metric.WithDescription(failedToEnqueueMetricPointsDesc),
 		metric.WithLabelKeys(obsmetrics.ExporterKey),
 		metric.WithUnit(metricdata.UnitDimensionless))
 
 	insts.failedToEnqueueLogRecords, _ = registry.AddInt64Cumulative(
-		obsmetrics.ExporterKey+"/enqueue_failed_log_records",
-		metric.WithDescription("Number of log records failed to be added to the sending queue."),
+		failedToEnqueueLogRecordsName,
+		metric.WithDescription(failedToEnqueueLogRecordsDesc),
 		metric.WithLabelKeys(obsmetrics.ExporterKey),
 		metric.WithUnit(metricdata.UnitDimensionless))
 
@@ -79,9 +103,23 @@ func newInstruments(registry *metric.Registry) *instruments {
 // obsExporter is a helper to add observability to a component.Exporter.
 type obsExporter struct {
 	*obsreport.Exporter
+	insts                            *instruments
 	failedToEnqueueTraceSpansEntry   *metric.Int64CumulativeEntry
 	failedToEnqueueMetricPointsEntry *metric.Int64CumulativeEntry
 	failedToEnqueueLogRecordsEntry   *metric.Int64CumulativeEntry
+
+	// OpenTelemetry instruments, used instead of the OpenCensus ones if obsreportconfig.UseOtelForInternalMetrics.
+	meter                           otelmetric.Meter
+	otelAttrs                       []attribute.KeyValue
+	otelFailedToEnqueueTraceSpans   syncint64.Counter
+	otelFailedToEnqueueMetricPoints syncint64.Counter
+	otelFailedToEnqueueLogRecords   syncint64.Counter
+	otelQueueSize                   *atomic.Value
+
+	// The queue latency is recorded only at the detailed level.
+	level           configtelemetry.Level
+	latencyMutators []tag.Mutator
+	latencyRecorder *obsreportconfig.Recorder
 }
 
 // newObsExporter creates a new observability exporter.
@@ -91,25 +129,112 @@ func newObsExporter(cfg obsreport.ExporterSettings, insts *instruments) *obsExpo
 	failedToEnqueueMetricPointsEntry, _ := insts.failedToEnqueueMetricPoints.GetEntry(labelValue)
 	failedToEnqueueLogRecordsEntry, _ := insts.failedToEnqueueLogRecords.GetEntry(labelValue)
 
+	mp := cfg.ExporterCreateSettings.MeterProvider
+	if mp == nil {
+		mp = nonrecording.NewNoopMeterProvider()
+	}
+	meter := mp.Meter(scopeName)
+	// Errors are ignored, they happen only if instruments with the same names but different kinds exist.
+	otelFailedToEnqueueTraceSpans, _ := meter.SyncInt64().Counter(failedToEnqueueSpansName,
+		instrument.WithDescription(failedToEnqueueSpansDescription), instrument.WithUnit(unit.Dimensionless))
+	otelFailedToEnqueueMetricPoints, _ := meter.SyncInt64().Counter(failedToEnqueueMetricPointsName,
+		instrument.WithDescription(failedToEnqueueMetricPointsDesc), instrument.WithUnit(unit.Dimensionless))
+	otelFailedToEnqueueLogRecords, _ := meter.SyncInt64().Counter(failedToEnqueueLogRecordsName,
+		instrument.WithDescription(failedToEnqueueLogRecordsDesc), instrument.WithUnit(unit.Dimensionless))
+
+	// The OpenCensus entries are reported from their creation, do the same for the OpenTelemetry counters.
+	otelAttrs := []attribute.KeyValue{attribute.String(obsmetrics.ExporterKey, cfg.ExporterID.String())}
+	otelFailedToEnqueueTraceSpans.Add(context.Background(), 0, otelAttrs...)
+	otelFailedToEnqueueMetricPoints.Add(context.Background(), 0, otelAttrs...)
+	otelFailedToEnqueueLogRecords.Add(context.Background(), 0, otelAttrs...)
+
 	return &obsExporter{
 		Exporter:                         obsreport.NewExporter(cfg),
+		insts:                            insts,
 		failedToEnqueueTraceSpansEntry:   failedToEnqueueTraceSpansEntry,
 		failedToEnqueueMetricPointsEntry: failedToEnqueueMetricPointsEntry,
 		failedToEnqueueLogRecordsEntry:   failedToEnqueueLogRecordsEntry,
+
+		meter:                           meter,
+		otelAttrs:                       otelAttrs,
+		otelFailedToEnqueueTraceSpans:   otelFailedToEnqueueTraceSpans,
+		otelFailedToEnqueueMetricPoints: otelFailedToEnqueueMetricPoints,
+		otelFailedToEnqueueLogRecords:   otelFailedToEnqueueLogRecords,
+
+		level:           cfg.Level,
+		latencyMutators: []tag.Mutator{tag.Upsert(obsmetrics.TagKeyExporter, cfg.ExporterID.String(), tag.WithTTL(tag.TTLNoPropagation))},
+		latencyRecorder: obsreportconfig.NewRecorder(mp, scopeName, obsreportconfig.AllViews(), otelAttrs...),
 	}
 }
 
 // recordTracesEnqueueFailure records number of spans that failed to be added to the sending queue.
-func (eor *obsExporter) recordTracesEnqueueFailure(_ context.Context, numSpans int64) {
+func (eor *obsExporter) recordTracesEnqueueFailure(ctx context.Context, numSpans int64) {
+	if obsreportconfig.UseOtelForInternalMetrics() {
+		eor.otelFailedToEnqueueTraceSpans.Add(ctx, numSpans, eor.otelAttrs...)
+		return
+	}
 	eor.failedToEnqueueTraceSpansEntry.Inc(numSpans)
 }
 
 // recordMetricsEnqueueFailure records number of metric points that failed to be added to the sending queue.
-func (eor *obsExporter) recordMetricsEnqueueFailure(_ context.Context, numMetricPoints int64) {
+func (eor *obsExporter) recordMetricsEnqueueFailure(ctx context.Context, numMetricPoints int64) {
+	if obsreportconfig.UseOtelForInternalMetrics() {
+		eor.otelFailedToEnqueueMetricPoints.Add(ctx, numMetricPoints, eor.otelAttrs...)
+		return
+	}
 	eor.failedToEnqueueMetricPointsEntry.Inc(numMetricPoints)
 }
 
 // recordLogsEnqueueFailure records number of log records that failed to be added to the sending queue.
-func (eor *obsExporter) recordLogsEnqueueFailure(_ context.Context, numLogRecords int64) {
+func (eor *obsExporter) recordLogsEnqueueFailure(ctx context.Context, numLogRecords int64) {
+	if obsreportconfig.UseOtelForInternalMetrics() {
+		eor.otelFailedToEnqueueLogRecords.Add(ctx, numLogRecords, eor.otelAttrs...)
+		return
+	}
 	eor.failedToEnqueueLogRecordsEntry.Inc(numLogRecords)
 }
+
+// enqueueTimeKey is the context key of the time a request was added to the sending queue.
+type enqueueTimeKey struct{}
+
+// startQueueLatency returns the context of a request added to the sending queue, holding the time it was added
+// if the queue latency is recorded.
+func (eor *obsExporter) startQueueLatency(ctx context.Context) context.Context {
+	if eor.level != configtelemetry.LevelDetailed {
+		return ctx
+	}
+	return context.WithValue(ctx, enqueueTimeKey{}, time.Now())
+}
+
+// recordQueueLatency records the time spent in the sending queue by the request with the given context.
+func (eor *obsExporter) recordQueueLatency(ctx context.Context) {
+	enqueueTime, ok := ctx.Value(enqueueTimeKey{}).(time.Time)
+	if !ok {
+		return
+	}
+	eor.latencyRecorder.RecordWithAttributes(ctx, eor.latencyMutators, []attribute.KeyValue{obsreportconfig.PipelineAttribute(ctx)},
+		obsmetrics.ExporterQueueLatency.M(time.Since(enqueueTime).Milliseconds()))
+}
+
+// setQueueSizeFunc sets the function that returns the current size of the sending queue, labeled with the
+// given queue name. Calling it again with the same name replaces the function.
+func (eor *obsExporter) setQueueSizeFunc(queueName string, size func() int64) error {
+	if !obsreportconfig.UseOtelForInternalMetrics() {
+		return eor.insts.queueSize.UpsertEntry(size, metricdata.NewLabelValue(queueName))
+	}
+
+	if eor.otelQueueSize != nil {
+		eor.otelQueueSize.Store(size)
+		return nil
+	}
+	gauge, err := eor.meter.AsyncInt64().Gauge(queueSizeName, instrument.WithDescription(queueSizeDescription), instrument.WithUnit(unit.Dimensionless))
+	if err != nil {
+		return err
+	}
+	eor.otelQueueSize = &atomic.Value{}
+	eor.otelQueueSize.Store(size)
+	attr := attribute.String(obsmetrics.ExporterKey, queueName)
+	return eor.meter.RegisterCallback([]instrument.Asynchronous{gauge}, func(ctx context.Context) {
+		gauge.Observe(ctx, eor.otelQueueSize.Load().(func() int64)(), attr)
+	})
+}
@@ -26,6 +26,7 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 )
 
@@ -81,18 +82,20 @@ func createSampledLogger(logger *zap.Logger) *zap.Logger {
 func (qrs *queuedRetrySender) send(req request) error {
 	if !qrs.cfg.Enabled {
 		err := qrs.consumerSender.send(req)
+		qrs.reportStatus(err)
 		if err != nil {
 			qrs.logger.Error(
 				"Exporting failed. Dropping data. Try enabling sending_queue to survive temporary failures.",
 				zap.Int("dropped_items", req.count()),
 			)
 		}
+		req.OnProcessingFinished()
 		return err
 	}
 
 	// Prevent cancellation and deadline to propagate to the context stored in the queue.
 	// The grpc/http based receivers will cancel the request context after this function returns.
-	req.setContext(noCancellationContext{Context: req.context()})
+	req.setContext(qrs.obsrep.startQueueLatency(noCancellationContext{Context: req.context()}))
 
 	span := trace.SpanFromContext(req.context())
 	if !qrs.queue.Produce(req) {
@@ -108,6 +111,22 @@ func (qrs *queuedRetrySender) send(req request) error {
 	return nil
 }
 
+// reportStatus reports the status of the exporter after sending a request: a recoverable error if sending
+// failed, unless because of the data itself, OK once sending succeeds again.
+func (qrs *queuedRetrySender) reportStatus(err error) {
+	if qrs.host == nil || consumererror.IsPermanent(err) {
+		return
+	}
+	if err != nil {
+		qrs.statusOK.Store(false)
+		qrs.host.ReportComponentStatus(component.NewRecoverableErrorEvent(err))
+		return
+	}
+	if !qrs.statusOK.Swap(true) {
+		qrs.host.ReportComponentStatus(component.NewStatusEvent(component.StatusOK))
+	}
+}
+
 // TODO: Clean this by forcing all exporters to return an internal error type that always include the information about retries.
 type throttleRetry struct {
 	err   error
@@ -22,8 +22,8 @@ import (
 	"errors"
 	"fmt"
 
-	"go.opencensus.io/metric/metricdata"
 	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
@@ -88,7 +88,10 @@ type queuedRetrySender struct {
 	queue              internal.ProducerConsumerQueue
 	retryStopCh        chan struct{}
 	traceAttributes    []attribute.KeyValue
+	obsrep             *obsExporter
 	logger             *zap.Logger
+	host               component.Host
+	statusOK           atomic.Bool
 	requeuingEnabled   bool
 	requestUnmarshaler internal.RequestUnmarshaler
 }
@@ -100,7 +103,7 @@ func (qrs *queuedRetrySender) fullName() string {
 	return fmt.Sprintf("%s-%s", qrs.id.String(), qrs.signal)
 }
 
-func newQueuedRetrySender(id config.ComponentID, signal config.DataType, qCfg QueueSettings, rCfg RetrySettings, reqUnmarshaler internal.RequestUnmarshaler, nextSender requestSender, logger *zap.Logger) *queuedRetrySender {
+func newQueuedRetrySender(id config.ComponentID, signal config.DataType, qCfg QueueSettings, rCfg RetrySettings, reqUnmarshaler internal.RequestUnmarshaler, nextSender requestSender, obsrep *obsExporter, logger *zap.Logger) *queuedRetrySender {
 	retryStopCh := make(chan struct{})
 	sampledLogger := createSampledLogger(logger)
 	traceAttr := attribute.String(obsmetrics.ExporterKey, id.String())
@@ -111,6 +114,7 @@ func newQueuedRetrySender(id config.ComponentID, signal config.DataType, qCfg Qu
 		cfg:                qCfg,
 		retryStopCh:        retryStopCh,
 		traceAttributes:    []attribute.KeyValue{traceAttr},
+		obsrep:             obsrep,
 		logger:             sampledLogger,
 		requestUnmarshaler: reqUnmarshaler,
 	}
@@ -200,6 +204,7 @@ func (qrs *queuedRetrySender) onTemporaryFailure(logger *zap.Logger, req request
 
 // start is invoked during service startup.
 func (qrs *queuedRetrySender) start(ctx context.Context, host component.Host) error {
+	qrs.host = host
 	err := qrs.initializePersistentQueue(ctx, host)
 	if err != nil {
 		return err
@@ -207,15 +212,16 @@ func (qrs *queuedRetrySender) start(ctx context.Context, host component.Host) er
 
 	qrs.queue.StartConsumers(qrs.cfg.NumConsumers, func(item interface{}) {
 		req := item.(request)
-		_ = qrs.consumerSender.send(req)
+		qrs.obsrep.recordQueueLatency(req.context())
+		qrs.reportStatus(qrs.consumerSender.send(req))
 		req.OnProcessingFinished()
 	})
 
 	// Start reporting queue length metric
 	if qrs.cfg.Enabled {
-		err := globalInstruments.queueSize.UpsertEntry(func() int64 {
+		err := qrs.obsrep.setQueueSizeFunc(qrs.fullName(), func() int64 {
 			return int64(qrs.queue.Size())
-		}, metricdata.NewLabelValue(qrs.fullName()))
+		})
 		if err != nil {
 			return fmt.Errorf("failed to create retry queue size metric: %v", err)
 		}
@@ -228,9 +234,9 @@ func (qrs *queuedRetrySender) start(ctx context.Context, host component.Host) er
 func (qrs *queuedRetrySender) shutdown() {
 	// Cleanup queue metrics reporting
 	if qrs.cfg.Enabled {
-		_ = globalInstruments.queueSize.UpsertEntry(func() int64 {
+		_ = qrs.obsrep.setQueueSizeFunc(qrs.fullName(), func() int64 {
 			return int64(0)
-		}, metricdata.NewLabelValue(qrs.fullName()))
+		})
 	}
 
 	// First Stop the retry goroutines, so that unblocks the queue numWorkers.
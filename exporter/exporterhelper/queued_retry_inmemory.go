@@ -21,8 +21,8 @@ import (
 	"context"
 	"fmt"
 
-	"go.opencensus.io/metric/metricdata"
 	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
@@ -77,10 +77,15 @@ type queuedRetrySender struct {
 	queue           internal.ProducerConsumerQueue
 	retryStopCh     chan struct{}
 	traceAttributes []attribute.KeyValue
+	obsrep          *obsExporter
 	logger          *zap.Logger
+
+	// host and statusOK are used to report the status of the exporter after sending the requests.
+	host     component.Host
+	statusOK atomic.Bool
 }
 
-func newQueuedRetrySender(id config.ComponentID, _ config.DataType, qCfg QueueSettings, rCfg RetrySettings, _ internal.RequestUnmarshaler, nextSender requestSender, logger *zap.Logger) *queuedRetrySender {
+func newQueuedRetrySender(id config.ComponentID, _ config.DataType, qCfg QueueSettings, rCfg RetrySettings, _ internal.RequestUnmarshaler, nextSender requestSender, obsrep *obsExporter, logger *zap.Logger) *queuedRetrySender {
 	retryStopCh := make(chan struct{})
 	sampledLogger := createSampledLogger(logger)
 	traceAttr := attribute.String(obsmetrics.ExporterKey, id.String())
@@ -98,6 +103,7 @@ func newQueuedRetrySender(id config.ComponentID, _ config.DataType, qCfg QueueSe
 		queue:           internal.NewBoundedMemoryQueue(qCfg.QueueSize, func(item interface{}) {}),
 		retryStopCh:     retryStopCh,
 		traceAttributes: []attribute.KeyValue{traceAttr},
+		obsrep:          obsrep,
 		logger:          sampledLogger,
 	}
 }
@@ -112,18 +118,20 @@ func onTemporaryFailure(logger *zap.Logger, req request, err error) error {
 }
 
 // start is invoked during service startup.
-func (qrs *queuedRetrySender) start(context.Context, component.Host) error {
+func (qrs *queuedRetrySender) start(_ context.Context, host component.Host) error {
+	qrs.host = host
 	qrs.queue.StartConsumers(qrs.cfg.NumConsumers, func(item interface{}) {
 		req := item.(request)
-		_ = qrs.consumerSender.send(req)
+		qrs.obsrep.recordQueueLatency(req.context())
+		qrs.reportStatus(qrs.consumerSender.send(req))
 		req.OnProcessingFinished()
 	})
 
 	// Start reporting queue length metric
 	if qrs.cfg.Enabled {
-		err := globalInstruments.queueSize.UpsertEntry(func() int64 {
+		err := qrs.obsrep.setQueueSizeFunc(qrs.fullName, func() int64 {
 			return int64(qrs.queue.Size())
-		}, metricdata.NewLabelValue(qrs.fullName))
+		})
 		if err != nil {
 			return fmt.Errorf("failed to create retry queue size metric: %v", err)
 		}
@@ -136,9 +144,9 @@ func (qrs *queuedRetrySender) start(context.Context, component.Host) error {
 func (qrs *queuedRetrySender) shutdown() {
 	// Cleanup queue metrics reporting
 	if qrs.cfg.Enabled {
-		_ = globalInstruments.queueSize.UpsertEntry(func() int64 {
+		_ = qrs.obsrep.setQueueSizeFunc(qrs.fullName, func() int64 {
 			return int64(0)
-		}, metricdata.NewLabelValue(qrs.fullName))
+		})
 	}
 
 	// First Stop the retry goroutines, so that unblocks the queue numWorkers.
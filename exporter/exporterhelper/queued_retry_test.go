@@ -26,12 +26,16 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opencensus.io/metric/metricdata"
 	"go.opencensus.io/metric/metricproducer"
+	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
 	"go.uber.org/atomic"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -43,6 +47,35 @@ func mockRequestUnmarshaler(mr *mockRequest) internal.RequestUnmarshaler {
 	}
 }
 
+func TestQueuedRetry_ReleaseData(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = 0
+	mockR := newMockRequest(context.Background(), 2, errors.New("transient error"))
+	released := atomic.NewInt64(0)
+	mockR.setReleaseDataFunc(func() { released.Inc() })
+	be := newBaseExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", mockRequestUnmarshaler(mockR))
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	ocs.run(func() {
+		// This is asynchronous so it should just enqueue, no errors expected.
+		require.NoError(t, be.sender.send(mockR))
+	})
+	ocs.awaitAsyncProcessing()
+
+	// The data is released once, after the request was retried and sent.
+	mockR.checkNumRequests(t, 2)
+	ocs.checkSendItemsCount(t, 2)
+	assert.Eventually(t, func() bool {
+		return released.Load() == 1
+	}, time.Second, 1*time.Millisecond)
+}
+
 func TestQueuedRetry_DropOnPermanentError(t *testing.T) {
 	qCfg := NewDefaultQueueSettings()
 	rCfg := NewDefaultRetrySettings()
@@ -351,6 +384,64 @@ func TestQueuedRetry_QueueMetricsReported(t *testing.T) {
 	checkValueForGlobalManager(t, defaultExporterTags, int64(0), "exporter/queue_size")
 }
 
+func TestQueuedRetry_QueueLatencyReported(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	set := tt.ToExporterCreateSettings()
+	set.MetricsLevel = configtelemetry.LevelDetailed
+	be := newBaseExporter(&defaultExporterCfg, set, fromOptions(WithRetry(NewDefaultRetrySettings()), WithQueue(NewDefaultQueueSettings())), "", nopRequestUnmarshaler())
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	ctx, err := tag.New(context.Background(), tag.Upsert(obsmetrics.TagKeyPipeline, "traces"))
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		ocs.run(func() {
+			require.NoError(t, be.sender.send(newMockRequest(ctx, 2, nil)))
+		})
+	}
+	ocs.awaitAsyncProcessing()
+
+	rows, err := view.RetrieveData("exporter/queue_latency")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, []tag.Tag{
+		{Key: obsmetrics.TagKeyExporter, Value: defaultExporterCfg.ID().String()},
+		{Key: obsmetrics.TagKeyPipeline, Value: "traces"},
+	}, rows[0].Tags)
+	assert.EqualValues(t, 3, rows[0].Data.(*view.DistributionData).Count)
+}
+
+func TestQueuedRetry_ReportsStatus(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.Enabled = false
+	rCfg := NewDefaultRetrySettings()
+	rCfg.Enabled = false
+	be := newBaseExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, be.Start(context.Background(), host))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	assert.Error(t, be.sender.send(newMockRequest(context.Background(), 2, errors.New("transient error"))))
+	assert.Error(t, be.sender.send(newMockRequest(context.Background(), 2, consumererror.NewPermanent(errors.New("bad data")))))
+	assert.NoError(t, be.sender.send(newMockRequest(context.Background(), 2, nil)))
+	assert.NoError(t, be.sender.send(newMockRequest(context.Background(), 2, nil)))
+
+	// The permanent error is caused by the data, not the exporter, and OK is reported only once.
+	require.Len(t, host.events, 2)
+	assert.Equal(t, component.StatusRecoverableError, host.events[0].Status())
+	assert.EqualError(t, host.events[0].Err(), "transient error")
+	assert.Equal(t, component.StatusOK, host.events[1].Status())
+}
+
 func TestNoCancellationContext(t *testing.T) {
 	deadline := time.Now().Add(1 * time.Second)
 	ctx, cancelFunc := context.WithDeadline(context.Background(), deadline)
@@ -379,6 +470,15 @@ func TestQueueSettings_Validate(t *testing.T) {
 	assert.NoError(t, qCfg.Validate())
 }
 
+type statusHost struct {
+	component.Host
+	events []*component.StatusEvent
+}
+
+func (sh *statusHost) ReportComponentStatus(event *component.StatusEvent) {
+	sh.events = append(sh.events, event)
+}
+
 type mockErrorRequest struct {
 	baseRequest
 }
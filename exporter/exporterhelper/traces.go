@@ -29,6 +29,9 @@ import (
 var tracesMarshaler = ptrace.NewProtoMarshaler()
 var tracesUnmarshaler = ptrace.NewProtoUnmarshaler()
 
+// releaseTraces releases the data of the requests, replaced in tests to observe the releases.
+var releaseTraces = ptrace.Traces.Release
+
 type tracesRequest struct {
 	baseRequest
 	td     ptrace.Traces
@@ -110,6 +113,9 @@ func NewTracesExporter(
 
 	tc, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
 		req := newTracesRequest(ctx, td, pusher)
+		if bs.releaseData {
+			req.setReleaseDataFunc(func() { releaseTraces(td) })
+		}
 		err := be.sender.send(req)
 		if errors.Is(err, errSendingQueueIsFull) {
 			be.obsrep.recordTracesEnqueueFailure(req.context(), int64(req.count()))
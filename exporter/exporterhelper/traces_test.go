@@ -92,6 +92,35 @@ func TestTracesExporter_WithCapabilities(t *testing.T) {
 	assert.Equal(t, capabilities, te.Capabilities())
 }
 
+func TestTracesExporter_WithDataRelease(t *testing.T) {
+	var released []ptrace.Traces
+	releaseTraces = func(td ptrace.Traces) { released = append(released, td) }
+	t.Cleanup(func() { releaseTraces = ptrace.Traces.Release })
+
+	td := testdata.GenerateTracesTwoSpansSameResource()
+	pushed := false
+	exp, err := NewTracesExporter(&fakeTracesExporterConfig, componenttest.NewNopExporterCreateSettings(), func(context.Context, ptrace.Traces) error {
+		// The data is not released before being exported.
+		assert.Empty(t, released)
+		pushed = true
+		return nil
+	}, WithDataRelease())
+	require.NoError(t, err)
+	assert.Equal(t, consumer.Capabilities{MutatesData: true}, exp.Capabilities())
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	assert.True(t, pushed)
+	// The data was released once exported.
+	assert.Equal(t, []ptrace.Traces{td}, released)
+
+	// The data is not released without the option.
+	released = nil
+	exp, err = NewTracesExporter(&fakeTracesExporterConfig, componenttest.NewNopExporterCreateSettings(), newTraceDataPusher(nil))
+	require.NoError(t, err)
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	assert.Empty(t, released)
+}
+
 func TestTracesExporter_Default_ReturnError(t *testing.T) {
 	td := ptrace.NewTraces()
 	want := errors.New("my_error")
@@ -15,6 +15,8 @@
 package loggingexporter // import "go.opentelemetry.io/collector/exporter/loggingexporter"
 
 import (
+	"fmt"
+
 	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/config"
@@ -32,11 +34,56 @@ type Config struct {
 
 	// SamplingThereafter defines the sampling rate after the initial samples are logged.
 	SamplingThereafter int `mapstructure:"sampling_thereafter"`
+
+	// Format defines how the data is printed; options are text, json (OTLP JSON),
+	// and summary (one line per span, data point or log record).
+	Format string `mapstructure:"format"`
+
+	// Output defines where the data is printed; options are logger (the exporter logger, at debug level),
+	// stdout, stderr and file.
+	Output string `mapstructure:"output"`
+
+	// Path is the file the data is appended to when Output is file.
+	Path string `mapstructure:"path"`
+
+	// IncludeAttributes, if not empty, are the only attributes printed.
+	IncludeAttributes []string `mapstructure:"include_attributes"`
+
+	// ExcludeAttributes are attributes that are not printed.
+	ExcludeAttributes []string `mapstructure:"exclude_attributes"`
 }
 
+const (
+	formatText    = "text"
+	formatJSON    = "json"
+	formatSummary = "summary"
+
+	outputLogger = "logger"
+	outputStdout = "stdout"
+	outputStderr = "stderr"
+	outputFile   = "file"
+)
+
 var _ config.Exporter = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
+	switch cfg.Format {
+	case formatText, formatJSON, formatSummary:
+	default:
+		return fmt.Errorf("format must be %s, %s or %s, got %q", formatText, formatJSON, formatSummary, cfg.Format)
+	}
+	switch cfg.Output {
+	case outputLogger, outputStdout, outputStderr:
+		if cfg.Path != "" {
+			return fmt.Errorf("path requires output %s", outputFile)
+		}
+	case outputFile:
+		if cfg.Path == "" {
+			return fmt.Errorf("path must be set when output is %s", outputFile)
+		}
+	default:
+		return fmt.Errorf("output must be %s, %s, %s or %s, got %q", outputLogger, outputStdout, outputStderr, outputFile, cfg.Output)
+	}
 	return nil
 }
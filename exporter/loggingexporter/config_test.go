@@ -48,5 +48,69 @@ func TestLoadConfig(t *testing.T) {
 			LogLevel:           zapcore.DebugLevel,
 			SamplingInitial:    10,
 			SamplingThereafter: 50,
+			Format:             formatText,
+			Output:             outputLogger,
 		})
+
+	e2 := cfg.Exporters[config.NewComponentIDWithName(typeStr, "3")]
+	assert.Equal(t, e2,
+		&Config{
+			ExporterSettings:   config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "3")),
+			LogLevel:           zapcore.InfoLevel,
+			SamplingInitial:    defaultSamplingInitial,
+			SamplingThereafter: defaultSamplingThereafter,
+			Format:             formatSummary,
+			Output:             outputFile,
+			Path:               "/var/log/otelcol/data.log",
+			IncludeAttributes:  []string{"service.name", "http.method"},
+		})
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "default",
+			modify: func(*Config) {},
+		},
+		{
+			name:   "json to stderr",
+			modify: func(cfg *Config) { cfg.Format = formatJSON; cfg.Output = outputStderr },
+		},
+		{
+			name:   "unknown format",
+			modify: func(cfg *Config) { cfg.Format = "xml" },
+			err:    `format must be text, json or summary, got "xml"`,
+		},
+		{
+			name:   "unknown output",
+			modify: func(cfg *Config) { cfg.Output = "syslog" },
+			err:    `output must be logger, stdout, stderr or file, got "syslog"`,
+		},
+		{
+			name:   "file without path",
+			modify: func(cfg *Config) { cfg.Output = outputFile },
+			err:    "path must be set when output is file",
+		},
+		{
+			name:   "path without file",
+			modify: func(cfg *Config) { cfg.Path = "data.log" },
+			err:    "path requires output file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
 }
@@ -47,6 +47,8 @@ func createDefaultConfig() config.Exporter {
 		LogLevel:           zapcore.InfoLevel,
 		SamplingInitial:    defaultSamplingInitial,
 		SamplingThereafter: defaultSamplingThereafter,
+		Format:             formatText,
+		Output:             outputLogger,
 	}
 }
 
@@ -58,7 +60,7 @@ func createTracesExporter(_ context.Context, set component.ExporterCreateSetting
 		return nil, err
 	}
 
-	return newTracesExporter(config, exporterLogger, set)
+	return newTracesExporter(cfg, exporterLogger, set)
 }
 
 func createMetricsExporter(_ context.Context, set component.ExporterCreateSettings, config config.Exporter) (component.MetricsExporter, error) {
@@ -69,7 +71,7 @@ func createMetricsExporter(_ context.Context, set component.ExporterCreateSettin
 		return nil, err
 	}
 
-	return newMetricsExporter(config, exporterLogger, set)
+	return newMetricsExporter(cfg, exporterLogger, set)
 }
 
 func createLogsExporter(_ context.Context, set component.ExporterCreateSettings, config config.Exporter) (component.LogsExporter, error) {
@@ -80,7 +82,7 @@ func createLogsExporter(_ context.Context, set component.ExporterCreateSettings,
 		return nil, err
 	}
 
-	return newLogsExporter(config, exporterLogger, set)
+	return newLogsExporter(cfg, exporterLogger, set)
 }
 
 func createLogger(cfg *Config) (*zap.Logger, error) {
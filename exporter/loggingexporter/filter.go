@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loggingexporter // import "go.opentelemetry.io/collector/exporter/loggingexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// attributesFilter removes the attributes that are not included, or that are excluded,
+// from the resources, spans, span events, span links, data points and log records.
+type attributesFilter struct {
+	include map[string]struct{}
+	exclude map[string]struct{}
+}
+
+// newAttributesFilter returns nil if no attribute is included or excluded.
+func newAttributesFilter(include []string, exclude []string) *attributesFilter {
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil
+	}
+	return &attributesFilter{include: toSet(include), exclude: toSet(exclude)}
+}
+
+func toSet(keys []string) map[string]struct{} {
+	if len(keys) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[k] = struct{}{}
+	}
+	return set
+}
+
+func (f *attributesFilter) filter(m pcommon.Map) {
+	m.RemoveIf(func(k string, _ pcommon.Value) bool {
+		if _, ok := f.exclude[k]; ok {
+			return true
+		}
+		if f.include == nil {
+			return false
+		}
+		_, ok := f.include[k]
+		return !ok
+	})
+}
+
+func (f *attributesFilter) filterTraces(td ptrace.Traces) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		f.filter(rss.At(i).Resource().Attributes())
+	}
+	td.ForEachSpan(func(_ pcommon.Resource, _ pcommon.InstrumentationScope, span ptrace.Span) {
+		f.filter(span.Attributes())
+		for i := 0; i < span.Events().Len(); i++ {
+			f.filter(span.Events().At(i).Attributes())
+		}
+		for i := 0; i < span.Links().Len(); i++ {
+			f.filter(span.Links().At(i).Attributes())
+		}
+	})
+}
+
+func (f *attributesFilter) filterMetrics(md pmetric.Metrics) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		f.filter(rms.At(i).Resource().Attributes())
+	}
+	md.ForEachDataPoint(func(_ pcommon.Resource, _ pcommon.InstrumentationScope, _ pmetric.Metric, dp pmetric.DataPoint) {
+		f.filter(dp.Attributes())
+	})
+}
+
+func (f *attributesFilter) filterLogs(ld plog.Logs) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		f.filter(rls.At(i).Resource().Attributes())
+	}
+	ld.ForEachLogRecord(func(_ pcommon.Resource, _ pcommon.InstrumentationScope, lr plog.LogRecord) {
+		f.filter(lr.Attributes())
+	})
+}
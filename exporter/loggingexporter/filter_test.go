@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loggingexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestNewAttributesFilter(t *testing.T) {
+	assert.Nil(t, newAttributesFilter(nil, nil))
+	assert.NotNil(t, newAttributesFilter([]string{"a"}, nil))
+	assert.NotNil(t, newAttributesFilter(nil, []string{"a"}))
+}
+
+func TestAttributesFilter(t *testing.T) {
+	newMap := func() pcommon.Map {
+		m := pcommon.NewMap()
+		m.InsertString("a", "1")
+		m.InsertString("b", "2")
+		m.InsertString("c", "3")
+		return m
+	}
+	m := newMap()
+	newAttributesFilter([]string{"a", "b"}, nil).filter(m)
+	assert.Equal(t, []string{"a", "b"}, attributeKeys(m))
+
+	m = newMap()
+	newAttributesFilter(nil, []string{"a"}).filter(m)
+	assert.Equal(t, []string{"b", "c"}, attributeKeys(m))
+
+	m = newMap()
+	newAttributesFilter([]string{"a", "b"}, []string{"a"}).filter(m)
+	assert.Equal(t, []string{"b"}, attributeKeys(m))
+}
+
+func TestAttributesFilterData(t *testing.T) {
+	f := newAttributesFilter(nil, []string{"resource-attr", "label-1", "app", "span-event-attr", "span-link-attr"})
+
+	td := testdata.GenerateTracesOneSpan()
+	f.filterTraces(td)
+	assert.Equal(t, 0, td.ResourceSpans().At(0).Resource().Attributes().Len())
+
+	md := testdata.GeneratMetricsAllTypesWithSampleDatapoints()
+	f.filterMetrics(md)
+	assert.Equal(t, 0, md.ResourceMetrics().At(0).Resource().Attributes().Len())
+	dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints()
+	assert.Equal(t, 0, dps.At(0).Attributes().Len())
+	assert.Equal(t, 1, dps.At(1).Attributes().Len())
+
+	ld := testdata.GenerateLogsOneLogRecord()
+	f.filterLogs(ld)
+	assert.Equal(t, 0, ld.ResourceLogs().At(0).Resource().Attributes().Len())
+	assert.Equal(t, []string{"instance_num"}, attributeKeys(ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes()))
+}
+
+func attributeKeys(m pcommon.Map) []string {
+	var keys []string
+	m.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
@@ -16,13 +16,15 @@ package loggingexporter // import "go.opentelemetry.io/collector/exporter/loggin
 
 import (
 	"context"
+	"encoding/json"
 	"os"
+	"strings"
 
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/internal/otlptext"
@@ -33,70 +35,135 @@ import (
 
 type loggingExporter struct {
 	logger           *zap.Logger
+	format           string
 	logsMarshaler    plog.Marshaler
 	metricsMarshaler pmetric.Marshaler
 	tracesMarshaler  ptrace.Marshaler
+	// output, if not nil, is where the data is written instead of logging it.
+	output *output
+	// filter, if not nil, removes attributes from the data before it is marshaled.
+	filter *attributesFilter
 }
 
 func (s *loggingExporter) pushTraces(_ context.Context, td ptrace.Traces) error {
 	s.logger.Info("TracesExporter", zap.Int("#spans", td.SpanCount()))
-	if !s.logger.Core().Enabled(zapcore.DebugLevel) {
+	if s.output == nil && !s.logger.Core().Enabled(zapcore.DebugLevel) {
 		return nil
 	}
 
+	if s.filter != nil {
+		td = td.Clone()
+		s.filter.filterTraces(td)
+	}
 	buf, err := s.tracesMarshaler.MarshalTraces(td)
 	if err != nil {
 		return err
 	}
-	s.logger.Debug(string(buf))
-	return nil
+	return s.write(buf)
 }
 
 func (s *loggingExporter) pushMetrics(_ context.Context, md pmetric.Metrics) error {
 	s.logger.Info("MetricsExporter", zap.Int("#metrics", md.MetricCount()))
 
-	if !s.logger.Core().Enabled(zapcore.DebugLevel) {
+	if s.output == nil && !s.logger.Core().Enabled(zapcore.DebugLevel) {
 		return nil
 	}
 
+	if s.filter != nil {
+		md = md.Clone()
+		s.filter.filterMetrics(md)
+	}
 	buf, err := s.metricsMarshaler.MarshalMetrics(md)
 	if err != nil {
 		return err
 	}
-	s.logger.Debug(string(buf))
-	return nil
+	return s.write(buf)
 }
 
 func (s *loggingExporter) pushLogs(_ context.Context, ld plog.Logs) error {
 	s.logger.Info("LogsExporter", zap.Int("#logs", ld.LogRecordCount()))
 
-	if !s.logger.Core().Enabled(zapcore.DebugLevel) {
+	if s.output == nil && !s.logger.Core().Enabled(zapcore.DebugLevel) {
 		return nil
 	}
 
+	if s.filter != nil {
+		ld = ld.Clone()
+		s.filter.filterLogs(ld)
+	}
 	buf, err := s.logsMarshaler.MarshalLogs(ld)
 	if err != nil {
 		return err
 	}
-	s.logger.Debug(string(buf))
+	return s.write(buf)
+}
+
+// write writes the marshaled data to the output or, if there is none, logs it at debug level.
+func (s *loggingExporter) write(buf []byte) error {
+	if s.output != nil {
+		return s.output.write(buf)
+	}
+	switch s.format {
+	case formatJSON:
+		// Embedded as is, so that JSON log pipelines can parse it back.
+		s.logger.Debug("OTLP JSON", zap.Reflect("data", json.RawMessage(buf)))
+	case formatSummary:
+		for _, line := range strings.Split(string(buf), "\n") {
+			if line != "" {
+				s.logger.Debug(line)
+			}
+		}
+	default:
+		s.logger.Debug(string(buf))
+	}
 	return nil
 }
 
-func newLoggingExporter(logger *zap.Logger) *loggingExporter {
-	return &loggingExporter{
-		logger:           logger,
-		logsMarshaler:    otlptext.NewTextLogsMarshaler(),
-		metricsMarshaler: otlptext.NewTextMetricsMarshaler(),
-		tracesMarshaler:  otlptext.NewTextTracesMarshaler(),
+func (s *loggingExporter) start(context.Context, component.Host) error {
+	if s.output == nil {
+		return nil
+	}
+	return s.output.start()
+}
+
+func (s *loggingExporter) shutdown(ctx context.Context) error {
+	err := loggerSync(s.logger)(ctx)
+	if s.output != nil {
+		err = multierr.Append(err, s.output.close())
+	}
+	return err
+}
+
+func newLoggingExporter(cfg *Config, logger *zap.Logger) *loggingExporter {
+	s := &loggingExporter{
+		logger: logger,
+		format: cfg.Format,
+		filter: newAttributesFilter(cfg.IncludeAttributes, cfg.ExcludeAttributes),
+	}
+	switch cfg.Format {
+	case formatJSON:
+		s.logsMarshaler = plog.NewJSONMarshaler()
+		s.metricsMarshaler = pmetric.NewJSONMarshaler()
+		s.tracesMarshaler = ptrace.NewJSONMarshaler()
+	case formatSummary:
+		s.logsMarshaler = otlptext.NewSummaryLogsMarshaler()
+		s.metricsMarshaler = otlptext.NewSummaryMetricsMarshaler()
+		s.tracesMarshaler = otlptext.NewSummaryTracesMarshaler()
+	default:
+		s.logsMarshaler = otlptext.NewTextLogsMarshaler()
+		s.metricsMarshaler = otlptext.NewTextMetricsMarshaler()
+		s.tracesMarshaler = otlptext.NewTextTracesMarshaler()
 	}
+	s.output = newOutput(cfg.Output, cfg.Path)
+	return s
 }
 
 // newTracesExporter creates an exporter.TracesExporter that just drops the
 // received data and logs debugging messages.
-func newTracesExporter(config config.Exporter, logger *zap.Logger, set component.ExporterCreateSettings) (component.TracesExporter, error) {
-	s := newLoggingExporter(logger)
+func newTracesExporter(cfg *Config, logger *zap.Logger, set component.ExporterCreateSettings) (component.TracesExporter, error) {
+	s := newLoggingExporter(cfg, logger)
 	return exporterhelper.NewTracesExporter(
-		config,
+		cfg,
 		set,
 		s.pushTraces,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
@@ -104,16 +171,17 @@ func newTracesExporter(config config.Exporter, logger *zap.Logger, set component
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(exporterhelper.RetrySettings{Enabled: false}),
 		exporterhelper.WithQueue(exporterhelper.QueueSettings{Enabled: false}),
-		exporterhelper.WithShutdown(loggerSync(logger)),
+		exporterhelper.WithStart(s.start),
+		exporterhelper.WithShutdown(s.shutdown),
 	)
 }
 
 // newMetricsExporter creates an exporter.MetricsExporter that just drops the
 // received data and logs debugging messages.
-func newMetricsExporter(config config.Exporter, logger *zap.Logger, set component.ExporterCreateSettings) (component.MetricsExporter, error) {
-	s := newLoggingExporter(logger)
+func newMetricsExporter(cfg *Config, logger *zap.Logger, set component.ExporterCreateSettings) (component.MetricsExporter, error) {
+	s := newLoggingExporter(cfg, logger)
 	return exporterhelper.NewMetricsExporter(
-		config,
+		cfg,
 		set,
 		s.pushMetrics,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
@@ -121,16 +189,17 @@ func newMetricsExporter(config config.Exporter, logger *zap.Logger, set componen
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(exporterhelper.RetrySettings{Enabled: false}),
 		exporterhelper.WithQueue(exporterhelper.QueueSettings{Enabled: false}),
-		exporterhelper.WithShutdown(loggerSync(logger)),
+		exporterhelper.WithStart(s.start),
+		exporterhelper.WithShutdown(s.shutdown),
 	)
 }
 
 // newLogsExporter creates an exporter.LogsExporter that just drops the
 // received data and logs debugging messages.
-func newLogsExporter(config config.Exporter, logger *zap.Logger, set component.ExporterCreateSettings) (component.LogsExporter, error) {
-	s := newLoggingExporter(logger)
+func newLogsExporter(cfg *Config, logger *zap.Logger, set component.ExporterCreateSettings) (component.LogsExporter, error) {
+	s := newLoggingExporter(cfg, logger)
 	return exporterhelper.NewLogsExporter(
-		config,
+		cfg,
 		set,
 		s.pushLogs,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
@@ -138,7 +207,8 @@ func newLogsExporter(config config.Exporter, logger *zap.Logger, set component.E
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(exporterhelper.RetrySettings{Enabled: false}),
 		exporterhelper.WithQueue(exporterhelper.QueueSettings{Enabled: false}),
-		exporterhelper.WithShutdown(loggerSync(logger)),
+		exporterhelper.WithStart(s.start),
+		exporterhelper.WithShutdown(s.shutdown),
 	)
 }
 
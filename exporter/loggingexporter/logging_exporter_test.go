@@ -15,16 +15,21 @@ package loggingexporter
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
 
 	"go.opentelemetry.io/collector/component/componenttest"
-	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -32,7 +37,7 @@ import (
 )
 
 func TestLoggingTracesExporterNoErrors(t *testing.T) {
-	lte, err := newTracesExporter(&config.ExporterSettings{}, zap.NewNop(), componenttest.NewNopExporterCreateSettings())
+	lte, err := newTracesExporter(createDefaultConfig().(*Config), zap.NewNop(), componenttest.NewNopExporterCreateSettings())
 	require.NotNil(t, lte)
 	assert.NoError(t, err)
 
@@ -43,7 +48,7 @@ func TestLoggingTracesExporterNoErrors(t *testing.T) {
 }
 
 func TestLoggingMetricsExporterNoErrors(t *testing.T) {
-	lme, err := newMetricsExporter(&config.ExporterSettings{}, zap.NewNop(), componenttest.NewNopExporterCreateSettings())
+	lme, err := newMetricsExporter(createDefaultConfig().(*Config), zap.NewNop(), componenttest.NewNopExporterCreateSettings())
 	require.NotNil(t, lme)
 	assert.NoError(t, err)
 
@@ -56,7 +61,7 @@ func TestLoggingMetricsExporterNoErrors(t *testing.T) {
 }
 
 func TestLoggingLogsExporterNoErrors(t *testing.T) {
-	lle, err := newLogsExporter(&config.ExporterSettings{}, zap.NewNop(), componenttest.NewNopExporterCreateSettings())
+	lle, err := newLogsExporter(createDefaultConfig().(*Config), zap.NewNop(), componenttest.NewNopExporterCreateSettings())
 	require.NotNil(t, lle)
 	assert.NoError(t, err)
 
@@ -69,8 +74,7 @@ func TestLoggingLogsExporterNoErrors(t *testing.T) {
 }
 
 func TestLoggingExporterErrors(t *testing.T) {
-	le := newLoggingExporter(zaptest.NewLogger(t))
-	require.NotNil(t, le)
+	le := newLoggingExporter(createDefaultConfig().(*Config), zaptest.NewLogger(t))
 
 	errWant := errors.New("my error")
 	le.tracesMarshaler = &errMarshaler{err: errWant}
@@ -81,6 +85,106 @@ func TestLoggingExporterErrors(t *testing.T) {
 	assert.Equal(t, errWant, le.pushLogs(context.Background(), plog.NewLogs()))
 }
 
+func TestLoggingExporterFormats(t *testing.T) {
+	for _, format := range []string{formatText, formatJSON, formatSummary} {
+		t.Run(format, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Format = format
+			core, logs := observer.New(zapcore.DebugLevel)
+			le := newLoggingExporter(cfg, zap.New(core))
+
+			assert.NoError(t, le.pushTraces(context.Background(), testdata.GenerateTracesTwoSpansSameResource()))
+			assert.NoError(t, le.pushMetrics(context.Background(), testdata.GeneratMetricsAllTypesWithSampleDatapoints()))
+			assert.NoError(t, le.pushLogs(context.Background(), testdata.GenerateLogsOneLogRecord()))
+
+			debug := logs.FilterLevelExact(zapcore.DebugLevel).All()
+			switch format {
+			case formatText:
+				assert.Len(t, debug, 3)
+			case formatJSON:
+				require.Len(t, debug, 3)
+				for _, entry := range debug {
+					assert.IsType(t, json.RawMessage{}, entry.ContextMap()["data"])
+				}
+			case formatSummary:
+				assert.Len(t, debug, 2+testdata.GeneratMetricsAllTypesWithSampleDatapoints().DataPointCount()+1)
+			}
+		})
+	}
+}
+
+func TestLoggingExporterFileOutput(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Format = formatJSON
+	cfg.Output = outputFile
+	cfg.Path = filepath.Join(t.TempDir(), "data.json")
+	cfg.ExcludeAttributes = []string{"resource-attr"}
+
+	lte, err := newTracesExporter(cfg, zap.NewNop(), componenttest.NewNopExporterCreateSettings())
+	require.NoError(t, err)
+	// The file is only created on start, e.g. not when validating the config.
+	_, err = os.Stat(cfg.Path)
+	assert.True(t, os.IsNotExist(err))
+	require.NoError(t, lte.Start(context.Background(), componenttest.NewNopHost()))
+	td := testdata.GenerateTracesOneSpan()
+	assert.NoError(t, lte.ConsumeTraces(context.Background(), td))
+	assert.NoError(t, lte.ConsumeTraces(context.Background(), td))
+	assert.NoError(t, lte.Shutdown(context.Background()))
+	assert.Empty(t, writers)
+
+	// The data is not modified by the filter.
+	assert.Equal(t, 1, td.ResourceSpans().At(0).Resource().Attributes().Len())
+
+	buf, err := os.ReadFile(cfg.Path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(buf), "\n"), "\n")
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		got, err := ptrace.NewJSONUnmarshaler().UnmarshalTraces([]byte(line))
+		require.NoError(t, err)
+		assert.Equal(t, 1, got.SpanCount())
+		assert.Equal(t, 0, got.ResourceSpans().At(0).Resource().Attributes().Len())
+	}
+}
+
+func TestLoggingExporterFileOutputError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Output = outputFile
+	cfg.Path = filepath.Join(t.TempDir(), "missing", "data.txt")
+
+	lle, err := newLogsExporter(cfg, zap.NewNop(), componenttest.NewNopExporterCreateSettings())
+	require.NoError(t, err)
+	assert.Error(t, lle.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, lle.Shutdown(context.Background()))
+	assert.Empty(t, writers)
+}
+
+func TestLoggingExporterSharedFileOutput(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Format = formatJSON
+	cfg.Output = outputFile
+	cfg.Path = filepath.Join(t.TempDir(), "data.json")
+
+	lte, err := newTracesExporter(cfg, zap.NewNop(), componenttest.NewNopExporterCreateSettings())
+	require.NoError(t, err)
+	lle, err := newLogsExporter(cfg, zap.NewNop(), componenttest.NewNopExporterCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, lte.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, lle.Start(context.Background(), componenttest.NewNopHost()))
+	require.Len(t, writers, 1)
+
+	// The file stays open until the last exporter writing to it is shut down.
+	assert.NoError(t, lte.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	assert.NoError(t, lte.Shutdown(context.Background()))
+	assert.NoError(t, lle.ConsumeLogs(context.Background(), testdata.GenerateLogsOneLogRecord()))
+	assert.NoError(t, lle.Shutdown(context.Background()))
+	assert.Empty(t, writers)
+
+	buf, err := os.ReadFile(cfg.Path)
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSuffix(string(buf), "\n"), "\n"), 2)
+}
+
 type errMarshaler struct {
 	err error
 }
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loggingexporter // import "go.opentelemetry.io/collector/exporter/loggingexporter"
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+)
+
+var errOutputNotStarted = errors.New("output is not started")
+
+var (
+	writersMu sync.Mutex
+	// writers are the writers in use, by output kind and path, shared by all the exporters
+	// writing to the same stream or file so that their batches are not interleaved.
+	writers = map[writerKey]*writer{}
+)
+
+type writerKey struct {
+	kind string
+	path string
+}
+
+// writer writes to stdout, stderr or a file, one batch at a time.
+type writer struct {
+	mu sync.Mutex
+	w  io.Writer
+	// file is the opened file, nil for stdout and stderr.
+	file *os.File
+	// refs is the number of started outputs using the writer, the file is closed when it drops to 0.
+	refs int
+}
+
+// output writes the marshaled data to stdout, stderr or a file. The file is only opened on start,
+// and closed on shutdown once no other exporter writes to it.
+type output struct {
+	key writerKey
+	// w is the shared writer, set on start and unset on shutdown.
+	w *writer
+}
+
+// newOutput returns the output for the given kind, or nil if the data is logged.
+func newOutput(kind string, path string) *output {
+	switch kind {
+	case outputStdout, outputStderr, outputFile:
+		return &output{key: writerKey{kind: kind, path: path}}
+	}
+	return nil
+}
+
+// start gets the writer of the output, opening the file if no other exporter writes to it.
+func (o *output) start() error {
+	writersMu.Lock()
+	defer writersMu.Unlock()
+	w, ok := writers[o.key]
+	if !ok {
+		switch o.key.kind {
+		case outputStdout:
+			w = &writer{w: os.Stdout}
+		case outputStderr:
+			w = &writer{w: os.Stderr}
+		default:
+			file, err := os.OpenFile(o.key.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+			if err != nil {
+				return err
+			}
+			w = &writer{w: file, file: file}
+		}
+		writers[o.key] = w
+	}
+	w.refs++
+	o.w = w
+	return nil
+}
+
+func (o *output) write(buf []byte) error {
+	if o.w == nil {
+		return errOutputNotStarted
+	}
+	if len(buf) == 0 {
+		return nil
+	}
+	if buf[len(buf)-1] != '\n' {
+		buf = append(buf, '\n')
+	}
+	o.w.mu.Lock()
+	defer o.w.mu.Unlock()
+	_, err := o.w.w.Write(buf)
+	return err
+}
+
+// close releases the writer of the output, closing the file if no other exporter writes to it.
+func (o *output) close() error {
+	writersMu.Lock()
+	defer writersMu.Unlock()
+	w := o.w
+	if w == nil {
+		return nil
+	}
+	o.w = nil
+	w.refs--
+	if w.refs > 0 {
+		return nil
+	}
+	delete(writers, o.key)
+	if w.file == nil {
+		return nil
+	}
+	return w.file.Close()
+}
@@ -0,0 +1,212 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	healthPath = "/health"
+	readyPath  = "/ready"
+)
+
+type healthExtension struct {
+	config    *Config
+	logger    *zap.Logger
+	server    http.Server
+	stopCh    chan struct{}
+	startTime time.Time
+
+	mu sync.RWMutex
+	// ready is true once the pipelines are ready, until they are about to be stopped.
+	ready    bool
+	statuses map[component.InstanceID]*component.StatusEvent
+}
+
+var (
+	_ component.PipelineWatcher = (*healthExtension)(nil)
+	_ component.StatusWatcher   = (*healthExtension)(nil)
+)
+
+// healthResponse is the body of the responses.
+type healthResponse struct {
+	Status     string              `json:"status"`
+	UpSince    time.Time           `json:"upSince"`
+	Uptime     string              `json:"uptime"`
+	Components []componentResponse `json:"components,omitempty"`
+}
+
+// componentResponse is the last status of a component instance. Pipeline is only set for the processors,
+// of which the collector creates an instance per pipeline.
+type componentResponse struct {
+	Kind      string    `json:"kind"`
+	ID        string    `json:"id"`
+	Pipeline  string    `json:"pipeline,omitempty"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (he *healthExtension) Start(_ context.Context, host component.Host) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(healthPath, he.handleHealth)
+	mux.HandleFunc(readyPath, he.handleReady)
+
+	// Start the listener here so we can have earlier failure if port is
+	// already in use.
+	ln, err := he.config.TCPAddr.Listen()
+	if err != nil {
+		return err
+	}
+
+	he.logger.Info("Starting health extension", zap.Any("config", he.config))
+	he.startTime = time.Now()
+	he.server = http.Server{Handler: mux}
+	he.stopCh = make(chan struct{})
+	go func() {
+		defer close(he.stopCh)
+
+		if err := he.server.Serve(ln); err != nil && err != http.ErrServerClosed {
+			host.ReportFatalError(err)
+		}
+	}()
+
+	return nil
+}
+
+func (he *healthExtension) Shutdown(context.Context) error {
+	err := he.server.Close()
+	if he.stopCh != nil {
+		<-he.stopCh
+	}
+	return err
+}
+
+// Ready implements component.PipelineWatcher.
+func (he *healthExtension) Ready() error {
+	he.mu.Lock()
+	defer he.mu.Unlock()
+	he.ready = true
+	return nil
+}
+
+// NotReady implements component.PipelineWatcher.
+func (he *healthExtension) NotReady() error {
+	he.mu.Lock()
+	defer he.mu.Unlock()
+	he.ready = false
+	return nil
+}
+
+// ComponentStatusChanged implements component.StatusWatcher.
+func (he *healthExtension) ComponentStatusChanged(source *component.InstanceID, event *component.StatusEvent) {
+	he.mu.Lock()
+	defer he.mu.Unlock()
+	he.statuses[*source] = event
+}
+
+// handleHealth serves the liveness of the collector: it is alive unless a component reported a permanent error.
+func (he *healthExtension) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	he.respond(w, func(ready bool, sum statusSummary) bool {
+		return !sum.permanentError
+	})
+}
+
+// handleReady serves the readiness of the collector: it is ready once the pipelines are ready, until they are
+// about to be stopped, if all the components are OK.
+func (he *healthExtension) handleReady(w http.ResponseWriter, _ *http.Request) {
+	he.respond(w, func(ready bool, sum statusSummary) bool {
+		return ready && sum.allOK
+	})
+}
+
+// respond writes the response, with the status code given by the check of the pipelines readiness and of
+// the summary of the statuses of the components.
+func (he *healthExtension) respond(w http.ResponseWriter, check func(ready bool, sum statusSummary) bool) {
+	he.mu.RLock()
+	resp := healthResponse{
+		UpSince: he.startTime,
+		Uptime:  time.Since(he.startTime).String(),
+	}
+	sum := statusSummary{allOK: true}
+	for id, event := range he.statuses {
+		comp := componentResponse{
+			Kind:      id.Kind.String(),
+			ID:        id.ID.String(),
+			Status:    event.Status().String(),
+			Timestamp: event.Timestamp(),
+		}
+		if id.PipelineID != (config.ComponentID{}) {
+			comp.Pipeline = id.PipelineID.String()
+		}
+		if event.Err() != nil {
+			comp.Error = event.Err().Error()
+		}
+		resp.Components = append(resp.Components, comp)
+		sum.permanentError = sum.permanentError || event.Status() == component.StatusPermanentError
+		sum.allOK = sum.allOK && event.Status() == component.StatusOK
+	}
+	ok := check(he.ready, sum)
+	he.mu.RUnlock()
+
+	sort.Slice(resp.Components, func(i, j int) bool {
+		if resp.Components[i].Kind != resp.Components[j].Kind {
+			return resp.Components[i].Kind < resp.Components[j].Kind
+		}
+		if resp.Components[i].ID != resp.Components[j].ID {
+			return resp.Components[i].ID < resp.Components[j].ID
+		}
+		return resp.Components[i].Pipeline < resp.Components[j].Pipeline
+	})
+
+	code := http.StatusOK
+	resp.Status = "Server available"
+	if !ok {
+		code = http.StatusServiceUnavailable
+		resp.Status = "Server not available"
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		he.logger.Warn("Failed to write the health response", zap.Error(err))
+	}
+}
+
+// statusSummary summarizes the last statuses reported by the components.
+type statusSummary struct {
+	// permanentError is true if any component reported a permanent error.
+	permanentError bool
+	// allOK is true if every component reported being OK, and so none is starting, stopping or failing.
+	allOK bool
+}
+
+func newServer(config *Config, logger *zap.Logger) *healthExtension {
+	return &healthExtension{
+		config:   config,
+		logger:   logger,
+		statuses: make(map[component.InstanceID]*component.StatusEvent),
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthextension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/internal/testutil"
+)
+
+func TestHealthExtension(t *testing.T) {
+	cfg := &Config{
+		TCPAddr: confignet.TCPAddr{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+	}
+	healthExt := newServer(cfg, zap.NewNop())
+	require.NoError(t, healthExt.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, healthExt.Shutdown(context.Background())) })
+
+	exporter := &component.InstanceID{ID: config.NewComponentID("otlp"), Kind: component.KindExporter}
+	receiver := &component.InstanceID{ID: config.NewComponentIDWithName("otlp", "2"), Kind: component.KindReceiver}
+
+	// Alive but not ready until the pipelines are ready.
+	assertHealth(t, cfg.TCPAddr.Endpoint, healthPath, http.StatusOK)
+	assertHealth(t, cfg.TCPAddr.Endpoint, readyPath, http.StatusServiceUnavailable)
+
+	require.NoError(t, healthExt.Ready())
+	healthExt.ComponentStatusChanged(exporter, component.NewStatusEvent(component.StatusOK))
+	assertHealth(t, cfg.TCPAddr.Endpoint, healthPath, http.StatusOK)
+	assertHealth(t, cfg.TCPAddr.Endpoint, readyPath, http.StatusOK)
+
+	// Not ready while a component is in error.
+	healthExt.ComponentStatusChanged(exporter, component.NewRecoverableErrorEvent(errors.New("unavailable")))
+	assertHealth(t, cfg.TCPAddr.Endpoint, healthPath, http.StatusOK)
+	resp := assertHealth(t, cfg.TCPAddr.Endpoint, readyPath, http.StatusServiceUnavailable)
+	require.Len(t, resp.Components, 1)
+	assert.Equal(t, "exporter", resp.Components[0].Kind)
+	assert.Equal(t, "otlp", resp.Components[0].ID)
+	assert.Equal(t, "RecoverableError", resp.Components[0].Status)
+	assert.Equal(t, "unavailable", resp.Components[0].Error)
+
+	healthExt.ComponentStatusChanged(exporter, component.NewStatusEvent(component.StatusOK))
+	assertHealth(t, cfg.TCPAddr.Endpoint, readyPath, http.StatusOK)
+
+	// Alive but not ready while a component is starting or stopping.
+	healthExt.ComponentStatusChanged(receiver, component.NewStatusEvent(component.StatusStarting))
+	assertHealth(t, cfg.TCPAddr.Endpoint, healthPath, http.StatusOK)
+	assertHealth(t, cfg.TCPAddr.Endpoint, readyPath, http.StatusServiceUnavailable)
+	healthExt.ComponentStatusChanged(receiver, component.NewStatusEvent(component.StatusStopping))
+	assertHealth(t, cfg.TCPAddr.Endpoint, healthPath, http.StatusOK)
+	assertHealth(t, cfg.TCPAddr.Endpoint, readyPath, http.StatusServiceUnavailable)
+
+	// Not alive once a component reports a permanent error.
+	healthExt.ComponentStatusChanged(receiver, component.NewPermanentErrorEvent(errors.New("failed")))
+	resp = assertHealth(t, cfg.TCPAddr.Endpoint, healthPath, http.StatusServiceUnavailable)
+	require.Len(t, resp.Components, 2)
+	assert.Equal(t, "exporter", resp.Components[0].Kind)
+	assert.Equal(t, "receiver", resp.Components[1].Kind)
+	assert.Equal(t, "otlp/2", resp.Components[1].ID)
+	assert.Equal(t, "PermanentError", resp.Components[1].Status)
+	assertHealth(t, cfg.TCPAddr.Endpoint, readyPath, http.StatusServiceUnavailable)
+
+	healthExt.ComponentStatusChanged(receiver, component.NewStatusEvent(component.StatusOK))
+	assertHealth(t, cfg.TCPAddr.Endpoint, readyPath, http.StatusOK)
+
+	// The instances of a processor used in several pipelines are reported separately.
+	for _, pipeline := range []string{"traces", "metrics"} {
+		processor := &component.InstanceID{ID: config.NewComponentID("batch"), Kind: component.KindProcessor, PipelineID: config.NewComponentID(config.Type(pipeline))}
+		healthExt.ComponentStatusChanged(processor, component.NewStatusEvent(component.StatusOK))
+	}
+	tracesProcessor := &component.InstanceID{ID: config.NewComponentID("batch"), Kind: component.KindProcessor, PipelineID: config.NewComponentID("traces")}
+	healthExt.ComponentStatusChanged(tracesProcessor, component.NewRecoverableErrorEvent(errors.New("unavailable")))
+	resp = assertHealth(t, cfg.TCPAddr.Endpoint, readyPath, http.StatusServiceUnavailable)
+	require.Len(t, resp.Components, 4)
+	assert.Equal(t, "processor", resp.Components[1].Kind)
+	assert.Equal(t, "metrics", resp.Components[1].Pipeline)
+	assert.Equal(t, "OK", resp.Components[1].Status)
+	assert.Equal(t, "traces", resp.Components[2].Pipeline)
+	assert.Equal(t, "RecoverableError", resp.Components[2].Status)
+	assert.Empty(t, resp.Components[0].Pipeline)
+
+	healthExt.ComponentStatusChanged(tracesProcessor, component.NewStatusEvent(component.StatusOK))
+	require.NoError(t, healthExt.NotReady())
+	assertHealth(t, cfg.TCPAddr.Endpoint, healthPath, http.StatusOK)
+	assertHealth(t, cfg.TCPAddr.Endpoint, readyPath, http.StatusServiceUnavailable)
+}
+
+func assertHealth(t *testing.T, endpoint, path string, wantCode int) healthResponse {
+	_, port, err := net.SplitHostPort(endpoint)
+	require.NoError(t, err)
+
+	resp, err := http.Get("http://localhost:" + port + path)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, wantCode, resp.StatusCode)
+
+	var body healthResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return body
+}
+
+func TestHealthExtensionPortAlreadyInUse(t *testing.T) {
+	endpoint := testutil.GetAvailableLocalAddress(t)
+	ln, err := net.Listen("tcp", endpoint)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	cfg := &Config{
+		TCPAddr: confignet.TCPAddr{
+			Endpoint: endpoint,
+		},
+	}
+	healthExt := newServer(cfg, zap.NewNop())
+	require.NotNil(t, healthExt)
+
+	require.Error(t, healthExt.Start(context.Background(), componenttest.NewNopHost()))
+}
+
+func TestHealthExtensionMultipleShutdowns(t *testing.T) {
+	cfg := &Config{
+		TCPAddr: confignet.TCPAddr{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+	}
+	healthExt := newServer(cfg, zap.NewNop())
+
+	require.NoError(t, healthExt.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, healthExt.Shutdown(context.Background()))
+	require.NoError(t, healthExt.Shutdown(context.Background()))
+}
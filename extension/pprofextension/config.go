@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pprofextension // import "go.opentelemetry.io/collector/extension/pprofextension"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+)
+
+// Config has the configuration for the extension enabling the golang
+// net/http/pprof (Performance Profiler) extension.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// TCPAddr is the address and port in which the pprof will be listening to.
+	// Use localhost:<port> to make it available only locally, or ":<port>" to
+	// make it available on all network interfaces.
+	TCPAddr confignet.TCPAddr `mapstructure:",squash"`
+
+	// BlockProfileFraction is the fraction of blocking events that are profiled. A value
+	// <= 0 disables profiling. See https://golang.org/pkg/runtime/#SetBlockProfileRate
+	// for details.
+	BlockProfileFraction int `mapstructure:"block_profile_fraction"`
+
+	// MutexProfileFraction is the fraction of mutex contention events that are profiled.
+	// A value <= 0 disables profiling. See
+	// https://golang.org/pkg/runtime/#SetMutexProfileFraction for details.
+	MutexProfileFraction int `mapstructure:"mutex_profile_fraction"`
+
+	// Dump configures the periodic dump of the CPU and heap profiles to files.
+	Dump DumpSettings `mapstructure:"dump"`
+}
+
+// DumpSettings configures the periodic dump of the CPU and heap profiles to files.
+type DumpSettings struct {
+	// Directory is the directory the profiles are written to. The profiles are not
+	// dumped if empty.
+	Directory string `mapstructure:"directory"`
+
+	// Interval is the time between two dumps.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// CPUDuration is the duration of the CPU profiles, shorter than the interval.
+	CPUDuration time.Duration `mapstructure:"cpu_duration"`
+
+	// MaxFiles is the number of profiles of each kind kept in the directory, the oldest
+	// ones are removed. All the profiles are kept if 0.
+	MaxFiles int `mapstructure:"max_files"`
+}
+
+var _ config.Extension = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.TCPAddr.Endpoint == "" {
+		return errors.New("\"endpoint\" is required when using the \"pprof\" extension")
+	}
+	if cfg.Dump.Directory == "" {
+		return nil
+	}
+	if cfg.Dump.Interval <= 0 {
+		return errors.New("\"dump.interval\" must be positive")
+	}
+	if cfg.Dump.CPUDuration <= 0 || cfg.Dump.CPUDuration >= cfg.Dump.Interval {
+		return errors.New("\"dump.cpu_duration\" must be positive and shorter than \"dump.interval\"")
+	}
+	if cfg.Dump.MaxFiles < 0 {
+		return errors.New("\"dump.max_files\" must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pprofextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/service/servicetest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Extensions[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config.yaml"), factories)
+
+	require.Nil(t, err)
+	require.NotNil(t, cfg)
+
+	ext0 := cfg.Extensions[config.NewComponentID(typeStr)]
+	assert.Equal(t, factory.CreateDefaultConfig(), ext0)
+
+	ext1 := cfg.Extensions[config.NewComponentIDWithName(typeStr, "1")]
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings: config.NewExtensionSettings(config.NewComponentIDWithName(typeStr, "1")),
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "0.0.0.0:1778",
+			},
+			BlockProfileFraction: 3,
+			MutexProfileFraction: 5,
+			Dump: DumpSettings{
+				Directory:   "/var/lib/otelcol/profiles",
+				Interval:    5 * time.Minute,
+				CPUDuration: 10 * time.Second,
+				MaxFiles:    3,
+			},
+		},
+		ext1)
+
+	assert.Equal(t, 1, len(cfg.Service.Extensions))
+	assert.Equal(t, config.NewComponentIDWithName(typeStr, "1"), cfg.Service.Extensions[0])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:   "default",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:    "no_endpoint",
+			modify:  func(cfg *Config) { cfg.TCPAddr.Endpoint = "" },
+			wantErr: "\"endpoint\" is required when using the \"pprof\" extension",
+		},
+		{
+			name: "no_dump_interval",
+			modify: func(cfg *Config) {
+				cfg.Dump.Directory = "profiles"
+				cfg.Dump.Interval = 0
+			},
+			wantErr: "\"dump.interval\" must be positive",
+		},
+		{
+			name: "cpu_duration_too_long",
+			modify: func(cfg *Config) {
+				cfg.Dump.Directory = "profiles"
+				cfg.Dump.CPUDuration = cfg.Dump.Interval
+			},
+			wantErr: "\"dump.cpu_duration\" must be positive and shorter than \"dump.interval\"",
+		},
+		{
+			name: "negative_max_files",
+			modify: func(cfg *Config) {
+				cfg.Dump.Directory = "profiles"
+				cfg.Dump.MaxFiles = -1
+			},
+			wantErr: "\"dump.max_files\" must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
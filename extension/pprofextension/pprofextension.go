@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pprofextension // import "go.opentelemetry.io/collector/extension/pprofextension"
+
+import (
+	"context"
+	"net/http"
+	httppprof "net/http/pprof"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	cpuProfilePrefix  = "cpu-"
+	heapProfilePrefix = "heap-"
+	profileSuffix     = ".pprof"
+
+	// profileTimeFormat is the format of the time in the names of the profiles, so they sort chronologically.
+	profileTimeFormat = "20060102T150405.000000000Z"
+)
+
+type pprofExtension struct {
+	config *Config
+	logger *zap.Logger
+	server http.Server
+	stopCh chan struct{}
+
+	// dumpStopCh stops the periodic dump of the profiles, dumpDoneCh is closed once it is stopped.
+	dumpStopCh chan struct{}
+	dumpDoneCh chan struct{}
+}
+
+func (pe *pprofExtension) Start(_ context.Context, host component.Host) error {
+	if pe.config.Dump.Directory != "" {
+		if err := os.MkdirAll(pe.config.Dump.Directory, 0700); err != nil {
+			return err
+		}
+	}
+
+	// Start the listener here so we can have earlier failure if port is
+	// already in use.
+	ln, err := pe.config.TCPAddr.Listen()
+	if err != nil {
+		return err
+	}
+
+	// The fractions are global to the runtime, they are reset on shutdown.
+	runtime.SetBlockProfileRate(pe.config.BlockProfileFraction)
+	runtime.SetMutexProfileFraction(pe.config.MutexProfileFraction)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", httppprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", httppprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", httppprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", httppprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", httppprof.Trace)
+
+	pe.logger.Info("Starting pprof extension", zap.Any("config", pe.config))
+	pe.server = http.Server{Handler: mux}
+	pe.stopCh = make(chan struct{})
+	go func() {
+		defer close(pe.stopCh)
+
+		if err := pe.server.Serve(ln); err != nil && err != http.ErrServerClosed {
+			host.ReportFatalError(err)
+		}
+	}()
+
+	if pe.config.Dump.Directory != "" {
+		pe.dumpStopCh = make(chan struct{})
+		pe.dumpDoneCh = make(chan struct{})
+		go pe.dumpProfiles()
+	}
+
+	return nil
+}
+
+func (pe *pprofExtension) Shutdown(context.Context) error {
+	err := pe.server.Close()
+	if pe.stopCh != nil {
+		<-pe.stopCh
+	}
+	if pe.dumpStopCh != nil {
+		close(pe.dumpStopCh)
+		<-pe.dumpDoneCh
+		pe.dumpStopCh = nil
+	}
+	if pe.config.BlockProfileFraction > 0 {
+		runtime.SetBlockProfileRate(0)
+	}
+	if pe.config.MutexProfileFraction > 0 {
+		runtime.SetMutexProfileFraction(0)
+	}
+	return err
+}
+
+// dumpProfiles periodically writes the CPU and heap profiles to the dump directory, until stopped.
+func (pe *pprofExtension) dumpProfiles() {
+	defer close(pe.dumpDoneCh)
+
+	ticker := time.NewTicker(pe.config.Dump.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := pe.dump(time.Now()); err != nil {
+				pe.logger.Warn("Failed to dump the profiles", zap.Error(err))
+			}
+		case <-pe.dumpStopCh:
+			return
+		}
+	}
+}
+
+// dump writes the CPU and heap profiles started at the given time, and removes the oldest ones.
+func (pe *pprofExtension) dump(now time.Time) error {
+	name := now.UTC().Format(profileTimeFormat) + profileSuffix
+	errs := multierr.Combine(
+		pe.dumpCPUProfile(filepath.Join(pe.config.Dump.Directory, cpuProfilePrefix+name)),
+		dumpHeapProfile(filepath.Join(pe.config.Dump.Directory, heapProfilePrefix+name)),
+	)
+	if pe.config.Dump.MaxFiles == 0 {
+		return errs
+	}
+	return multierr.Combine(errs,
+		removeOldProfiles(pe.config.Dump.Directory, cpuProfilePrefix, pe.config.Dump.MaxFiles),
+		removeOldProfiles(pe.config.Dump.Directory, heapProfilePrefix, pe.config.Dump.MaxFiles),
+	)
+}
+
+// dumpCPUProfile writes the CPU profile of the configured duration, cut short if the dumps are stopped.
+// It fails if a CPU profile is already being collected, e.g. by the /debug/pprof/profile route.
+func (pe *pprofExtension) dumpCPUProfile(path string) error {
+	f, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	if err = pprof.StartCPUProfile(f); err != nil {
+		return multierr.Combine(err, f.Close(), os.Remove(path))
+	}
+
+	timer := time.NewTimer(pe.config.Dump.CPUDuration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-pe.dumpStopCh:
+	}
+	pprof.StopCPUProfile()
+	return f.Close()
+}
+
+func dumpHeapProfile(path string) error {
+	f, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	return multierr.Combine(pprof.WriteHeapProfile(f), f.Close())
+}
+
+// removeOldProfiles removes the profiles with the given prefix in the directory, except the last maxFiles ones.
+func removeOldProfiles(dir, prefix string, maxFiles int) error {
+	paths, err := filepath.Glob(filepath.Join(dir, prefix+"*"+profileSuffix))
+	if err != nil || len(paths) <= maxFiles {
+		return err
+	}
+	sort.Strings(paths)
+	var errs error
+	for _, path := range paths[:len(paths)-maxFiles] {
+		errs = multierr.Append(errs, os.Remove(path))
+	}
+	return errs
+}
+
+func newServer(config *Config, logger *zap.Logger) *pprofExtension {
+	return &pprofExtension{
+		config: config,
+		logger: logger,
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pprofextension
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/internal/testutil"
+)
+
+func TestPprofExtension(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TCPAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.MutexProfileFraction = 5
+
+	pprofExt := newServer(cfg, zap.NewNop())
+	require.NoError(t, pprofExt.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, 5, runtime.SetMutexProfileFraction(-1))
+
+	_, port, err := net.SplitHostPort(cfg.TCPAddr.Endpoint)
+	require.NoError(t, err)
+	for _, path := range []string{"/debug/pprof/", "/debug/pprof/heap", "/debug/pprof/cmdline"} {
+		resp, err := http.Get("http://localhost:" + port + path)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode, path)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	require.NoError(t, pprofExt.Shutdown(context.Background()))
+	assert.Equal(t, 0, runtime.SetMutexProfileFraction(-1))
+}
+
+func TestPprofExtensionPortAlreadyInUse(t *testing.T) {
+	endpoint := testutil.GetAvailableLocalAddress(t)
+	ln, err := net.Listen("tcp", endpoint)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.TCPAddr.Endpoint = endpoint
+	pprofExt := newServer(cfg, zap.NewNop())
+	require.Error(t, pprofExt.Start(context.Background(), componenttest.NewNopHost()))
+}
+
+func TestPprofExtensionMultipleShutdowns(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TCPAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+
+	pprofExt := newServer(cfg, zap.NewNop())
+	require.NoError(t, pprofExt.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, pprofExt.Shutdown(context.Background()))
+	require.NoError(t, pprofExt.Shutdown(context.Background()))
+}
+
+func TestPprofExtensionDump(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TCPAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.Dump.Directory = filepath.Join(t.TempDir(), "profiles")
+	cfg.Dump.Interval = 20 * time.Millisecond
+	cfg.Dump.CPUDuration = 10 * time.Millisecond
+
+	pprofExt := newServer(cfg, zap.NewNop())
+	require.NoError(t, pprofExt.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool {
+		paths, err := filepath.Glob(filepath.Join(cfg.Dump.Directory, heapProfilePrefix+"*"+profileSuffix))
+		return err == nil && len(paths) > 0
+	}, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, pprofExt.Shutdown(context.Background()))
+
+	paths, err := filepath.Glob(filepath.Join(cfg.Dump.Directory, cpuProfilePrefix+"*"+profileSuffix))
+	require.NoError(t, err)
+	assert.NotEmpty(t, paths)
+}
+
+func TestPprofExtensionDumpMaxFiles(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Dump.Directory = t.TempDir()
+	cfg.Dump.CPUDuration = time.Millisecond
+	cfg.Dump.MaxFiles = 2
+
+	pprofExt := newServer(cfg, zap.NewNop())
+	start := time.Date(2022, 4, 1, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, pprofExt.dump(start.Add(time.Duration(i)*time.Minute)))
+	}
+
+	entries, err := os.ReadDir(cfg.Dump.Directory)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+		info, err := entry.Info()
+		require.NoError(t, err)
+		assert.NotZero(t, info.Size(), entry.Name())
+	}
+	assert.Equal(t, []string{
+		"cpu-20220401T100100.000000000Z.pprof",
+		"cpu-20220401T100200.000000000Z.pprof",
+		"heap-20220401T100100.000000000Z.pprof",
+		"heap-20220401T100200.000000000Z.pprof",
+	}, names)
+}
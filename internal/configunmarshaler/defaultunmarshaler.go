@@ -186,6 +186,12 @@ func unmarshalService(srvRaw map[string]interface{}) (config.Service, error) {
 				InitialFields:     map[string]interface{}(nil),
 			},
 			Metrics: defaultServiceTelemetryMetricsSettings(),
+			Traces: config.ServiceTelemetryTraces{
+				Sampling: config.ServiceTelemetryTracesSampling{
+					Ratio:       1,
+					ParentBased: true,
+				},
+			},
 		},
 	}
 
@@ -110,11 +110,20 @@ func TestDecodeConfig(t *testing.T) {
 				OutputPaths:       []string{"stderr", "./output-logs"},
 				ErrorOutputPaths:  []string{"stderr", "./error-output-logs"},
 				InitialFields:     map[string]interface{}{"field_key": "filed_value"},
+				ComponentLevels: map[config.ComponentID]zapcore.Level{
+					config.NewComponentIDWithName("exampleexporter", "myexporter"): zapcore.ErrorLevel,
+				},
 			},
 			Metrics: config.ServiceTelemetryMetrics{
 				Level:   configtelemetry.LevelNormal,
 				Address: ":8081",
 			},
+			Traces: config.ServiceTelemetryTraces{
+				Sampling: config.ServiceTelemetryTracesSampling{
+					Ratio:       1,
+					ParentBased: true,
+				},
+			},
 		}, cfg.Service.Telemetry)
 
 	// Verify Service Extensions
@@ -0,0 +1,230 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configunmarshaler // import "go.opentelemetry.io/collector/internal/configunmarshaler"
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+// serviceKeyName is the configuration key name for service section.
+const serviceKeyName = "service"
+
+// misplacedSectionHints contains hints for the top-level sections that belong to other sections.
+var misplacedSectionHints = map[string]string{
+	pipelinesKeyName: "pipelines must be defined under the \"service\" section",
+	"telemetry":      "telemetry must be defined under the \"service\" section",
+	"receiver":       "did you mean \"receivers\"?",
+	"processor":      "did you mean \"processors\"?",
+	"exporter":       "did you mean \"exporters\"?",
+	"extension":      "did you mean \"extensions\"?",
+	"services":       "did you mean \"service\"?",
+}
+
+type strictUnmarshaler struct {
+	onWarning func(error)
+}
+
+// NewStrict returns a ConfigUnmarshaler that, unlike the default one, does not stop at the first problem.
+// It reports all the unknown and misplaced keys, and all the component configurations that cannot be
+// unmarshalled, combined in a single error using multierr. Every error names the location of the problem
+// as a dot separated key.
+//
+// The following problems do not fail the unmarshalling, instead onWarning is called for each one of them:
+// * Receivers, processors and exporters defined but not used in any pipeline.
+// * Extensions defined but not enabled in the service.
+// * Deprecated keys declared by the component configurations implementing config.DeprecatedKeysProvider.
+func NewStrict(onWarning func(error)) ConfigUnmarshaler {
+	return &strictUnmarshaler{onWarning: onWarning}
+}
+
+// Unmarshal the Config from a config.Map.
+// After the config is unmarshalled, `Validate()` must be called to validate.
+func (su *strictUnmarshaler) Unmarshal(v *config.Map, factories component.Factories) (*config.Config, error) {
+	var errs error
+	known := config.NewMap()
+	raw := v.ToStringMap()
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		switch k {
+		case receiversKeyName, processorsKeyName, exportersKeyName, extensionsKeyName, serviceKeyName:
+			known.Set(k, raw[k])
+		default:
+			errs = multierr.Append(errs, errorUnknownTopLevelSection(k))
+		}
+	}
+
+	rawCfg := configSettings{}
+	if err := known.Unmarshal(&rawCfg); err != nil {
+		return nil, multierr.Append(errs, &configError{
+			error: fmt.Errorf("error reading top level configuration sections: %w", err),
+			code:  errUnmarshalTopLevelStructure,
+		})
+	}
+
+	cfg := &config.Config{
+		Receivers:  make(map[config.ComponentID]config.Receiver),
+		Processors: make(map[config.ComponentID]config.Processor),
+		Exporters:  make(map[config.ComponentID]config.Exporter),
+		Extensions: make(map[config.ComponentID]config.Extension),
+	}
+
+	// Unmarshal every component on its own, to report the problems of all of them.
+	for _, id := range config.SortedComponentIDs(rawCfg.Extensions) {
+		exts, err := unmarshalExtensions(map[config.ComponentID]map[string]interface{}{id: rawCfg.Extensions[id]}, factories.Extensions)
+		if err != nil {
+			errs = multierr.Append(errs, &configError{error: err, code: errUnmarshalExtension})
+			continue
+		}
+		cfg.Extensions[id] = exts[id]
+		su.warnDeprecatedKeys(extensionsKeyName, id, exts[id], rawCfg.Extensions[id])
+	}
+
+	for _, id := range config.SortedComponentIDs(rawCfg.Receivers) {
+		recvs, err := unmarshalReceivers(map[config.ComponentID]map[string]interface{}{id: rawCfg.Receivers[id]}, factories.Receivers)
+		if err != nil {
+			errs = multierr.Append(errs, &configError{error: err, code: errUnmarshalReceiver})
+			continue
+		}
+		cfg.Receivers[id] = recvs[id]
+		su.warnDeprecatedKeys(receiversKeyName, id, recvs[id], rawCfg.Receivers[id])
+	}
+
+	for _, id := range config.SortedComponentIDs(rawCfg.Processors) {
+		procs, err := unmarshalProcessors(map[config.ComponentID]map[string]interface{}{id: rawCfg.Processors[id]}, factories.Processors)
+		if err != nil {
+			errs = multierr.Append(errs, &configError{error: err, code: errUnmarshalProcessor})
+			continue
+		}
+		cfg.Processors[id] = procs[id]
+		su.warnDeprecatedKeys(processorsKeyName, id, procs[id], rawCfg.Processors[id])
+	}
+
+	for _, id := range config.SortedComponentIDs(rawCfg.Exporters) {
+		exps, err := unmarshalExporters(map[config.ComponentID]map[string]interface{}{id: rawCfg.Exporters[id]}, factories.Exporters)
+		if err != nil {
+			errs = multierr.Append(errs, &configError{error: err, code: errUnmarshalExporter})
+			continue
+		}
+		cfg.Exporters[id] = exps[id]
+		su.warnDeprecatedKeys(exportersKeyName, id, exps[id], rawCfg.Exporters[id])
+	}
+
+	var err error
+	if cfg.Service, err = unmarshalService(rawCfg.Service); err != nil {
+		errs = multierr.Append(errs, &configError{error: err, code: errUnmarshalService})
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	su.warnUnused(cfg)
+	return cfg, nil
+}
+
+// warnDeprecatedKeys calls onWarning for every deprecated key set in the raw configuration of the component.
+func (su *strictUnmarshaler) warnDeprecatedKeys(section string, id config.ComponentID, cfg interface{}, raw map[string]interface{}) {
+	dkp, ok := cfg.(config.DeprecatedKeysProvider)
+	if !ok {
+		return
+	}
+	rawMap := config.NewMapFromStringMap(raw)
+	for _, dk := range dkp.DeprecatedKeys() {
+		if rawMap.IsSet(dk.Key) {
+			su.warn(fmt.Errorf("%s: key is deprecated: %s", location(section, id.String(), dk.Key), dk.Hint))
+		}
+	}
+}
+
+// warnUnused calls onWarning for every component that is defined but not used.
+func (su *strictUnmarshaler) warnUnused(cfg *config.Config) {
+	usedReceivers := map[config.ComponentID]bool{}
+	usedProcessors := map[config.ComponentID]bool{}
+	usedExporters := map[config.ComponentID]bool{}
+	for _, pipeline := range cfg.Service.Pipelines {
+		for _, id := range pipeline.Receivers {
+			usedReceivers[id] = true
+		}
+		for _, id := range pipeline.Processors {
+			usedProcessors[id] = true
+		}
+		for _, id := range pipeline.Exporters {
+			usedExporters[id] = true
+		}
+	}
+	// Exporters used only for the service telemetry are not in any pipeline, but they are used.
+	telemetry := cfg.Service.Telemetry
+	for _, ids := range [][]config.ComponentID{telemetry.Logs.Exporters, telemetry.Metrics.Exporters, telemetry.Traces.Exporters} {
+		for _, id := range ids {
+			usedExporters[id] = true
+		}
+	}
+	usedExtensions := map[config.ComponentID]bool{}
+	for _, id := range cfg.Service.Extensions {
+		usedExtensions[id] = true
+	}
+
+	for _, id := range config.SortedComponentIDs(cfg.Receivers) {
+		if !usedReceivers[id] {
+			su.warn(fmt.Errorf("%s: receiver is defined but not used in any pipeline", location(receiversKeyName, id.String())))
+		}
+	}
+	for _, id := range config.SortedComponentIDs(cfg.Processors) {
+		if !usedProcessors[id] {
+			su.warn(fmt.Errorf("%s: processor is defined but not used in any pipeline", location(processorsKeyName, id.String())))
+		}
+	}
+	for _, id := range config.SortedComponentIDs(cfg.Exporters) {
+		if !usedExporters[id] {
+			su.warn(fmt.Errorf("%s: exporter is defined but not used in any pipeline", location(exportersKeyName, id.String())))
+		}
+	}
+	for _, id := range config.SortedComponentIDs(cfg.Extensions) {
+		if !usedExtensions[id] {
+			su.warn(fmt.Errorf("%s: extension is defined but not enabled in the service", location(extensionsKeyName, id.String())))
+		}
+	}
+}
+
+func (su *strictUnmarshaler) warn(err error) {
+	if su.onWarning != nil {
+		su.onWarning(err)
+	}
+}
+
+func errorUnknownTopLevelSection(key string) error {
+	err := fmt.Errorf("%s: unknown top level section", key)
+	if hint, ok := misplacedSectionHints[key]; ok {
+		err = fmt.Errorf("%w, %s", err, hint)
+	}
+	return &configError{error: err, code: errUnmarshalTopLevelStructure}
+}
+
+// location returns the dot separated key of the given path, nested keys separated by config.KeyDelimiter are
+// also converted.
+func location(path ...string) string {
+	return strings.ReplaceAll(strings.Join(path, "."), config.KeyDelimiter, ".")
+}
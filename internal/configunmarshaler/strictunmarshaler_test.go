@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configunmarshaler
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/internal/testcomponents"
+)
+
+type deprecatedExporterConfig struct {
+	config.ExporterSettings `mapstructure:",squash"`
+	Endpoint                string `mapstructure:"endpoint"`
+	OldEndpoint             string `mapstructure:"old_endpoint"`
+}
+
+func (*deprecatedExporterConfig) DeprecatedKeys() []config.DeprecatedKey {
+	return []config.DeprecatedKey{{Key: "old_endpoint", Hint: `use "endpoint" instead`}}
+}
+
+var deprecatedExporterFactory = component.NewExporterFactory(
+	"deprecatedexporter",
+	func() config.Exporter {
+		return &deprecatedExporterConfig{ExporterSettings: config.NewExporterSettings(config.NewComponentID("deprecatedexporter"))}
+	},
+	component.WithTracesExporter(func(context.Context, component.ExporterCreateSettings, config.Exporter) (component.TracesExporter, error) {
+		return nil, errors.New("not implemented")
+	}))
+
+func TestStrictUnmarshalProblems(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+
+	cm, err := configtest.LoadConfigMap(filepath.Join("testdata", "strict-problems.yaml"))
+	require.NoError(t, err)
+
+	var warnings []error
+	_, err = NewStrict(func(err error) { warnings = append(warnings, err) }).Unmarshal(cm, factories)
+	require.Error(t, err)
+	errs := multierr.Errors(err)
+	require.Len(t, errs, 4)
+	assert.EqualError(t, errs[0], `pipelines: unknown top level section, pipelines must be defined under the "service" section`)
+	assert.Contains(t, errs[1].Error(), `error reading receivers configuration for "examplereceiver"`)
+	assert.Contains(t, errs[1].Error(), "unknown_key")
+	assert.Contains(t, errs[2].Error(), `unknown receivers type "nosuchreceiver"`)
+	assert.Contains(t, errs[3].Error(), `error reading exporters configuration for "exampleexporter"`)
+	assert.Contains(t, errs[3].Error(), "also_unknown")
+	for _, e := range errs {
+		var cfgErr *configError
+		assert.True(t, errors.As(e, &cfgErr))
+	}
+	assert.Empty(t, warnings)
+}
+
+func TestStrictUnmarshalWarnings(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+	factories.Exporters[deprecatedExporterFactory.Type()] = deprecatedExporterFactory
+
+	cm, err := configtest.LoadConfigMap(filepath.Join("testdata", "strict-warnings.yaml"))
+	require.NoError(t, err)
+
+	var warnings []string
+	cfg, err := NewStrict(func(err error) { warnings = append(warnings, err.Error()) }).Unmarshal(cm, factories)
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:1234", cfg.Exporters[config.NewComponentID("deprecatedexporter")].(*deprecatedExporterConfig).OldEndpoint)
+	assert.Equal(t, []string{
+		`exporters.deprecatedexporter.old_endpoint: key is deprecated: use "endpoint" instead`,
+		"receivers.examplereceiver/unused: receiver is defined but not used in any pipeline",
+		"processors.exampleprocessor: processor is defined but not used in any pipeline",
+		"extensions.exampleextension: extension is defined but not enabled in the service",
+	}, warnings)
+}
+
+func TestStrictUnmarshalValid(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+
+	cm, err := configtest.LoadConfigMap(filepath.Join("testdata", "valid-config.yaml"))
+	require.NoError(t, err)
+
+	strictCfg, err := NewStrict(nil).Unmarshal(cm, factories)
+	require.NoError(t, err)
+	defaultCfg, err := NewDefault().Unmarshal(cm, factories)
+	require.NoError(t, err)
+	assert.Equal(t, defaultCfg, strictCfg)
+}
@@ -37,6 +37,11 @@ const (
 	SentLogRecordsKey = "sent_log_records"
 	// FailedToSendLogRecordsKey used to track logs that failed to be sent by exporters.
 	FailedToSendLogRecordsKey = "send_failed_log_records"
+
+	// QueueLatencyKey used to track the time the data spent in the sending queue of exporters.
+	QueueLatencyKey = "queue_latency"
+	// SendLatencyKey used to track the time spent by exporters sending the data.
+	SendLatencyKey = "send_latency"
 )
 
 var (
@@ -47,6 +52,17 @@ var (
 	ExportMetricsOperationSuffix   = NameSep + "metrics"
 	ExportLogsOperationSuffix      = NameSep + "logs"
 
+	// ExporterQueueLatency is the time the data spent in the sending queue of an exporter.
+	ExporterQueueLatency = stats.Int64(
+		ExporterPrefix+QueueLatencyKey,
+		"Time the data spent in the sending queue.",
+		stats.UnitMilliseconds)
+	// ExporterSendLatency is the time spent by an exporter in an attempt to send the data.
+	ExporterSendLatency = stats.Int64(
+		ExporterPrefix+SendLatencyKey,
+		"Time spent in attempts to send the data to the destination.",
+		stats.UnitMilliseconds)
+
 	// Exporter metrics. Any count of data items below is in the final format
 	// that they were sent, reasoning: reconciliation is easier if measurements
 	// on backend and exporter are expected to be the same. Translation issues
@@ -38,6 +38,12 @@ var (
 
 	ProcessorPrefix = ProcessorKey + NameSep
 
+	// ProcessorLatency is the time spent by a processor, and the rest of the pipeline, consuming the data.
+	ProcessorLatency = stats.Int64(
+		ProcessorPrefix+LatencyKey,
+		"Time spent by the processor, and the next components in the pipeline, consuming the data.",
+		stats.UnitMilliseconds)
+
 	// Processor metrics. Any count of data items below is in the internal format
 	// of the collector since processors only deal with internal format.
 	ProcessorAcceptedSpans = stats.Int64(
@@ -54,6 +54,12 @@ var (
 	ReceiverMetricsOperationSuffix  = NameSep + "MetricsReceived"
 	ReceiverLogsOperationSuffix     = NameSep + "LogsReceived"
 
+	// ReceiverLatency is the time spent by a pipeline handling the data received by a receiver.
+	ReceiverLatency = stats.Int64(
+		ReceiverPrefix+LatencyKey,
+		"Time spent by the pipeline handling the data received, until it is accepted or refused.",
+		stats.UnitMilliseconds)
+
 	// Receiver metrics. Any count of data items below is in the original format
 	// that they were received, reasoning: reconciliation is easier if measurement
 	// on clients and receiver are expected to be the same. Translation issues
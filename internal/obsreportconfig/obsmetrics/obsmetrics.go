@@ -17,6 +17,19 @@
 // in the future
 package obsmetrics // import "go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 
+import (
+	"go.opencensus.io/tag"
+)
+
 const (
 	NameSep = "/"
+
+	// PipelineKey used to identify pipelines in metrics.
+	PipelineKey = "pipeline"
+	// LatencyKey used to identify the time spent handling the data.
+	LatencyKey = "latency"
+)
+
+var (
+	TagKeyPipeline, _ = tag.NewKey(PipelineKey)
 )
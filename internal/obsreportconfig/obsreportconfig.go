@@ -26,6 +26,9 @@ import (
 
 var (
 	globalLevel = atomic.NewInt32(int32(configtelemetry.LevelBasic))
+
+	// latencyDistribution is the aggregation of the latencies, in milliseconds.
+	latencyDistribution = view.Distribution(1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000)
 )
 
 // ObsMetrics wraps OpenCensus View for Collector observability metrics
@@ -114,6 +117,14 @@ func allViews() *ObsMetrics {
 	tagKeys = []tag.Key{obsmetrics.TagKeyProcessor}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
 
+	// Latency views, recorded only at the detailed level.
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ReceiverLatency},
+		[]tag.Key{obsmetrics.TagKeyReceiver, obsmetrics.TagKeyPipeline}, latencyDistribution)...)
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ProcessorLatency},
+		[]tag.Key{obsmetrics.TagKeyProcessor, obsmetrics.TagKeyPipeline}, latencyDistribution)...)
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ExporterQueueLatency, obsmetrics.ExporterSendLatency},
+		[]tag.Key{obsmetrics.TagKeyExporter, obsmetrics.TagKeyPipeline}, latencyDistribution)...)
+
 	return &ObsMetrics{
 		Views: views,
 	}
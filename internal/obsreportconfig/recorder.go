@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsreportconfig // import "go.opentelemetry.io/collector/internal/obsreportconfig"
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/nonrecording"
+	"go.opentelemetry.io/otel/metric/unit"
+	"go.uber.org/atomic"
+
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+)
+
+// UseOtelForInternalMetricsfeatureGateID is the feature gate ID that controls whether the collector uses
+// OpenTelemetry for internal metrics.
+const UseOtelForInternalMetricsfeatureGateID = "telemetry.useOtelForInternalMetrics"
+
+var useOtel = atomic.NewBool(false)
+
+// SetUseOtelForInternalMetrics controls whether the Recorder instances record the measurements using
+// OpenTelemetry instead of OpenCensus.
+func SetUseOtelForInternalMetrics(enabled bool) {
+	useOtel.Store(enabled)
+}
+
+// UseOtelForInternalMetrics returns true if the internal metrics are recorded using OpenTelemetry.
+func UseOtelForInternalMetrics() bool {
+	return useOtel.Load()
+}
+
+// AllViews returns all the views of the metrics recorded by the obsreport package.
+func AllViews() []*view.View {
+	return allViews().Views
+}
+
+// Recorder records OpenCensus measurements, either using OpenCensus or, if UseOtelForInternalMetrics
+// is enabled, using OpenTelemetry instruments equivalent to the OpenCensus views of the measures:
+// the instruments have the same names as the views, counters for the Sum and Count aggregations,
+// histograms for the Distribution aggregation, and the given attributes take the place of the tags:
+// every instrument is recorded with the attributes having the same keys as the tag keys of its view,
+// and, for the tag keys without such an attribute, with the values of the tags in the context.
+//
+// The OpenTelemetry instruments are created using the given MeterProvider, that may be nil.
+type Recorder struct {
+	instruments map[string][]recorderInstrument
+}
+
+type recorderInstrument struct {
+	counter   syncint64.Counter
+	histogram syncint64.Histogram
+	attrs     []attribute.KeyValue
+	// ctxKeys are the tag keys of the view without an attribute, read from the context.
+	ctxKeys []tag.Key
+	// count is true if the instrument counts the measurements instead of adding their values.
+	count bool
+}
+
+// NewRecorder returns a new Recorder for the measures of the given views.
+func NewRecorder(mp metric.MeterProvider, scope string, views []*view.View, attrs ...attribute.KeyValue) *Recorder {
+	if mp == nil {
+		mp = nonrecording.NewNoopMeterProvider()
+	}
+	meter := mp.Meter(scope)
+
+	rec := &Recorder{
+		instruments: make(map[string][]recorderInstrument, len(views)),
+	}
+	for _, v := range views {
+		opts := []instrument.Option{instrument.WithDescription(v.Description), instrument.WithUnit(unit.Unit(v.Measure.Unit()))}
+		inst := recorderInstrument{}
+		inst.attrs, inst.ctxKeys = viewAttributes(v, attrs)
+		var err error
+		switch v.Aggregation.Type {
+		case view.AggTypeSum:
+			inst.counter, err = meter.SyncInt64().Counter(v.Name, opts...)
+		case view.AggTypeCount:
+			inst.counter, err = meter.SyncInt64().Counter(v.Name, instrument.WithDescription(v.Description), instrument.WithUnit(unit.Dimensionless))
+			inst.count = true
+		case view.AggTypeDistribution:
+			inst.histogram, err = meter.SyncInt64().Histogram(v.Name, opts...)
+		default:
+			// Last value aggregations are recorded using asynchronous instruments, not by the Recorder.
+			continue
+		}
+		// Errors happen only if an instrument with the same name but different kind exists,
+		// in which case the measurement is only recorded with OpenCensus.
+		if err != nil {
+			continue
+		}
+		rec.instruments[v.Measure.Name()] = append(rec.instruments[v.Measure.Name()], inst)
+	}
+	return rec
+}
+
+// Record records the measurements. The mutators are applied to the tags in the context when recording
+// using OpenCensus, and ignored when recording using OpenTelemetry.
+func (rec *Recorder) Record(ctx context.Context, mutators []tag.Mutator, ms ...stats.Measurement) {
+	rec.RecordWithAttributes(ctx, mutators, nil, ms...)
+}
+
+// RecordWithAttributes is like Record, with the given attributes taking the place of the tags in the context
+// having the same keys when recording using OpenTelemetry. The attributes must have the values of the tags
+// recorded using OpenCensus.
+func (rec *Recorder) RecordWithAttributes(ctx context.Context, mutators []tag.Mutator, attrs []attribute.KeyValue, ms ...stats.Measurement) {
+	if !UseOtelForInternalMetrics() {
+		// Ignore the error for now. This should not happen.
+		_ = stats.RecordWithTags(ctx, mutators, ms...)
+		return
+	}
+
+	for _, m := range ms {
+		for _, inst := range rec.instruments[m.Measure().Name()] {
+			instAttrs := inst.attrs
+			if len(inst.ctxKeys) > 0 {
+				instAttrs = contextAttributes(ctx, inst.attrs, inst.ctxKeys, attrs)
+			}
+			switch {
+			case inst.count:
+				inst.counter.Add(ctx, 1, instAttrs...)
+			case inst.counter != nil:
+				inst.counter.Add(ctx, int64(m.Value()), instAttrs...)
+			default:
+				inst.histogram.Record(ctx, int64(m.Value()), instAttrs...)
+			}
+		}
+	}
+}
+
+// contextAttributes returns the attributes with, appended, the values of the given keys: the value of the
+// override attribute with the same key if any, otherwise the value of the tag in the context. As with
+// OpenCensus, the value of a tag missing from the context is empty.
+func contextAttributes(ctx context.Context, attrs []attribute.KeyValue, keys []tag.Key, overrides []attribute.KeyValue) []attribute.KeyValue {
+	tags := tag.FromContext(ctx)
+	ret := make([]attribute.KeyValue, len(attrs), len(attrs)+len(keys))
+	copy(ret, attrs)
+	for _, key := range keys {
+		found := false
+		for _, override := range overrides {
+			if string(override.Key) == key.Name() {
+				ret = append(ret, override)
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		var value string
+		if tags != nil {
+			value, _ = tags.Value(key)
+		}
+		ret = append(ret, attribute.String(key.Name(), value))
+	}
+	return ret
+}
+
+// viewAttributes returns the attributes with the same keys as the tag keys of the view, and the tag keys
+// of the view without such an attribute.
+func viewAttributes(v *view.View, attrs []attribute.KeyValue) ([]attribute.KeyValue, []tag.Key) {
+	var ret []attribute.KeyValue
+	var missing []tag.Key
+	for _, key := range v.TagKeys {
+		found := false
+		for _, attr := range attrs {
+			if string(attr.Key) == key.Name() {
+				ret = append(ret, attr)
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, key)
+		}
+	}
+	return ret, missing
+}
+
+// PipelineAttribute returns the pipeline attribute of the latencies of the data consumed with the given
+// context, whose value is the pipeline tag set by the service.
+func PipelineAttribute(ctx context.Context) attribute.KeyValue {
+	var pipeline string
+	if tags := tag.FromContext(ctx); tags != nil {
+		pipeline, _ = tags.Value(obsmetrics.TagKeyPipeline)
+	}
+	return attribute.String(obsmetrics.PipelineKey, pipeline)
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsreportconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
+
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+)
+
+func TestNewRecorder(t *testing.T) {
+	rec := NewRecorder(nil, "test", AllViews(), attribute.String(obsmetrics.ExporterKey, "nop"))
+
+	// Sent and failed to send spans have a Sum view, failed to send spans also a Count view without tags.
+	require.Len(t, rec.instruments[obsmetrics.ExporterSentSpans.Name()], 1)
+	failed := rec.instruments[obsmetrics.ExporterFailedToSendSpans.Name()]
+	require.Len(t, failed, 2)
+	assert.False(t, failed[0].count)
+	assert.Equal(t, []attribute.KeyValue{attribute.String(obsmetrics.ExporterKey, "nop")}, failed[0].attrs)
+	assert.True(t, failed[1].count)
+	assert.Empty(t, failed[1].attrs)
+
+	m := stats.Int64("test/last_value", "", stats.UnitDimensionless)
+	rec = NewRecorder(nil, "test", []*view.View{{Name: m.Name(), Measure: m, Aggregation: view.LastValue()}})
+	assert.Empty(t, rec.instruments)
+}
+
+func TestRecorderRecord(t *testing.T) {
+	m := stats.Int64("test/recorder_record", "", stats.UnitDimensionless)
+	key := tag.MustNewKey("key")
+	v := &view.View{Name: m.Name(), Measure: m, Aggregation: view.Sum(), TagKeys: []tag.Key{key}}
+	require.NoError(t, view.Register(v))
+	defer view.Unregister(v)
+
+	rec := NewRecorder(nil, "test", []*view.View{v}, attribute.String(key.Name(), "value"))
+	mutators := []tag.Mutator{tag.Upsert(key, "value")}
+	rec.Record(context.Background(), mutators, m.M(2))
+
+	// Recorded using OpenTelemetry, the OpenCensus view does not change.
+	SetUseOtelForInternalMetrics(true)
+	defer SetUseOtelForInternalMetrics(false)
+	rec.Record(context.Background(), mutators, m.M(3))
+
+	rows, err := view.RetrieveData(v.Name)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, []tag.Tag{{Key: key, Value: "value"}}, rows[0].Tags)
+	assert.Equal(t, float64(2), rows[0].Data.(*view.SumData).Value)
+}
+
+func TestContextAttributes(t *testing.T) {
+	key := tag.MustNewKey("key")
+	other := tag.MustNewKey("other")
+	ctx, err := tag.New(context.Background(), tag.Upsert(key, "tag"), tag.Upsert(other, "other"))
+	require.NoError(t, err)
+
+	attrs := []attribute.KeyValue{attribute.String("attr", "value")}
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("attr", "value"),
+		attribute.String("key", "override"),
+		attribute.String("other", "other"),
+		attribute.String("missing", ""),
+	}, contextAttributes(ctx, attrs, []tag.Key{key, other, tag.MustNewKey("missing")}, []attribute.KeyValue{attribute.String("key", "override")}))
+}
+
+func TestPipelineAttribute(t *testing.T) {
+	assert.Equal(t, attribute.String(obsmetrics.PipelineKey, ""), PipelineAttribute(context.Background()))
+
+	ctx, err := tag.New(context.Background(), tag.Upsert(obsmetrics.TagKeyPipeline, "traces"))
+	require.NoError(t, err)
+	assert.Equal(t, attribute.String(obsmetrics.PipelineKey, "traces"), PipelineAttribute(ctx))
+}
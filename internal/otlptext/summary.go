@@ -0,0 +1,270 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptext // import "go.opentelemetry.io/collector/internal/otlptext"
+
+import (
+	"bytes"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// NewSummaryTracesMarshaler returns a ptrace.Marshaler to encode to one line of key=value fields per span.
+func NewSummaryTracesMarshaler() ptrace.Marshaler {
+	return summaryTracesMarshaler{}
+}
+
+// NewSummaryMetricsMarshaler returns a pmetric.Marshaler to encode to one line of key=value fields per data point.
+func NewSummaryMetricsMarshaler() pmetric.Marshaler {
+	return summaryMetricsMarshaler{}
+}
+
+// NewSummaryLogsMarshaler returns a plog.Marshaler to encode to one line of key=value fields per log record.
+func NewSummaryLogsMarshaler() plog.Marshaler {
+	return summaryLogsMarshaler{}
+}
+
+type summaryTracesMarshaler struct{}
+
+// MarshalTraces ptrace.Traces to one line per span.
+func (summaryTracesMarshaler) MarshalTraces(td ptrace.Traces) ([]byte, error) {
+	buf := summaryBuffer{}
+	td.ForEachSpan(func(res pcommon.Resource, scope pcommon.InstrumentationScope, span ptrace.Span) {
+		buf.begin("span", res, scope)
+		buf.field("trace_id", span.TraceID().HexString())
+		buf.field("span_id", span.SpanID().HexString())
+		buf.field("parent_span_id", span.ParentSpanID().HexString())
+		buf.quoted("name", span.Name())
+		buf.field("kind", span.Kind().String())
+		buf.timestamp("start", span.StartTimestamp())
+		buf.timestamp("end", span.EndTimestamp())
+		buf.field("duration", span.EndTimestamp().AsTime().Sub(span.StartTimestamp().AsTime()).String())
+		buf.field("status", span.Status().Code().String())
+		buf.quoted("status_message", span.Status().Message())
+		buf.attributes("attributes", span.Attributes())
+		buf.field("events", strconv.Itoa(span.Events().Len()))
+		buf.field("links", strconv.Itoa(span.Links().Len()))
+		buf.end()
+	})
+	return buf.buf.Bytes(), nil
+}
+
+type summaryMetricsMarshaler struct{}
+
+// MarshalMetrics pmetric.Metrics to one line per data point.
+func (summaryMetricsMarshaler) MarshalMetrics(md pmetric.Metrics) ([]byte, error) {
+	buf := summaryBuffer{}
+	md.ForEachDataPoint(func(res pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric, dp pmetric.DataPoint) {
+		buf.begin("metric", res, scope)
+		buf.quoted("name", m.Name())
+		buf.quoted("unit", m.Unit())
+		buf.field("type", m.DataType().String())
+		switch m.DataType() {
+		case pmetric.MetricDataTypeSum:
+			buf.field("temporality", m.Sum().AggregationTemporality().String())
+			buf.field("monotonic", strconv.FormatBool(m.Sum().IsMonotonic()))
+		case pmetric.MetricDataTypeHistogram:
+			buf.field("temporality", m.Histogram().AggregationTemporality().String())
+		case pmetric.MetricDataTypeExponentialHistogram:
+			buf.field("temporality", m.ExponentialHistogram().AggregationTemporality().String())
+		}
+		buf.timestamp("start", dp.StartTimestamp())
+		buf.timestamp("time", dp.Timestamp())
+		buf.attributes("attributes", dp.Attributes())
+		switch p := dp.(type) {
+		case pmetric.NumberDataPoint:
+			switch p.ValueType() {
+			case pmetric.NumberDataPointValueTypeInt:
+				buf.field("value", strconv.FormatInt(p.IntVal(), 10))
+			case pmetric.NumberDataPointValueTypeDouble:
+				buf.field("value", formatFloat(p.DoubleVal()))
+			}
+		case pmetric.HistogramDataPoint:
+			buf.field("count", strconv.FormatUint(p.Count(), 10))
+			buf.field("sum", formatFloat(p.Sum()))
+			buf.floats("bounds", p.ExplicitBounds())
+			buf.counts("counts", p.BucketCounts())
+		case pmetric.ExponentialHistogramDataPoint:
+			buf.field("count", strconv.FormatUint(p.Count(), 10))
+			buf.field("sum", formatFloat(p.Sum()))
+			buf.field("scale", strconv.Itoa(int(p.Scale())))
+			buf.field("zero_count", strconv.FormatUint(p.ZeroCount(), 10))
+			buf.field("positive_offset", strconv.Itoa(int(p.Positive().Offset())))
+			buf.counts("positive", p.Positive().BucketCounts())
+			buf.field("negative_offset", strconv.Itoa(int(p.Negative().Offset())))
+			buf.counts("negative", p.Negative().BucketCounts())
+		case pmetric.SummaryDataPoint:
+			buf.field("count", strconv.FormatUint(p.Count(), 10))
+			buf.field("sum", formatFloat(p.Sum()))
+			buf.quantiles("quantiles", p.QuantileValues())
+		}
+		buf.end()
+	})
+	return buf.buf.Bytes(), nil
+}
+
+type summaryLogsMarshaler struct{}
+
+// MarshalLogs plog.Logs to one line per log record.
+func (summaryLogsMarshaler) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	buf := summaryBuffer{}
+	ld.ForEachLogRecord(func(res pcommon.Resource, scope pcommon.InstrumentationScope, lr plog.LogRecord) {
+		buf.begin("log", res, scope)
+		buf.timestamp("time", lr.Timestamp())
+		buf.timestamp("observed_time", lr.ObservedTimestamp())
+		buf.quoted("severity", lr.SeverityText())
+		buf.field("severity_number", lr.SeverityNumber().String())
+		buf.value("body", lr.Body())
+		buf.attributes("attributes", lr.Attributes())
+		buf.field("trace_id", lr.TraceID().HexString())
+		buf.field("span_id", lr.SpanID().HexString())
+		buf.end()
+	})
+	return buf.buf.Bytes(), nil
+}
+
+// summaryBuffer writes lines starting with the kind of item and followed by space separated
+// key=value fields, strings being quoted, and attributes written as {key=value, ...}.
+type summaryBuffer struct {
+	buf bytes.Buffer
+}
+
+func (b *summaryBuffer) begin(kind string, res pcommon.Resource, scope pcommon.InstrumentationScope) {
+	b.buf.WriteString(kind)
+	b.attributes("resource", res.Attributes())
+	b.quoted("scope", scope.Name())
+	b.quoted("scope_version", scope.Version())
+}
+
+func (b *summaryBuffer) end() {
+	b.buf.WriteByte('\n')
+}
+
+func (b *summaryBuffer) key(key string) {
+	b.buf.WriteByte(' ')
+	b.buf.WriteString(key)
+	b.buf.WriteByte('=')
+}
+
+func (b *summaryBuffer) field(key string, value string) {
+	b.key(key)
+	b.buf.WriteString(value)
+}
+
+func (b *summaryBuffer) quoted(key string, value string) {
+	b.field(key, strconv.Quote(value))
+}
+
+func (b *summaryBuffer) timestamp(key string, ts pcommon.Timestamp) {
+	b.field(key, ts.AsTime().UTC().Format(time.RFC3339Nano))
+}
+
+func (b *summaryBuffer) value(key string, v pcommon.Value) {
+	b.key(key)
+	b.writeValue(v)
+}
+
+func (b *summaryBuffer) attributes(key string, m pcommon.Map) {
+	b.key(key)
+	b.writeMap(m)
+}
+
+func (b *summaryBuffer) floats(key string, values []float64) {
+	b.key(key)
+	b.buf.WriteByte('[')
+	for i, v := range values {
+		if i > 0 {
+			b.buf.WriteString(", ")
+		}
+		b.buf.WriteString(formatFloat(v))
+	}
+	b.buf.WriteByte(']')
+}
+
+func (b *summaryBuffer) counts(key string, values []uint64) {
+	b.key(key)
+	b.buf.WriteByte('[')
+	for i, v := range values {
+		if i > 0 {
+			b.buf.WriteString(", ")
+		}
+		b.buf.WriteString(strconv.FormatUint(v, 10))
+	}
+	b.buf.WriteByte(']')
+}
+
+func (b *summaryBuffer) quantiles(key string, values pmetric.ValueAtQuantileSlice) {
+	b.key(key)
+	b.buf.WriteByte('{')
+	for i := 0; i < values.Len(); i++ {
+		if i > 0 {
+			b.buf.WriteString(", ")
+		}
+		b.buf.WriteString(formatFloat(values.At(i).Quantile()))
+		b.buf.WriteByte('=')
+		b.buf.WriteString(formatFloat(values.At(i).Value()))
+	}
+	b.buf.WriteByte('}')
+}
+
+func (b *summaryBuffer) writeMap(m pcommon.Map) {
+	b.buf.WriteByte('{')
+	first := true
+	m.Range(func(k string, v pcommon.Value) bool {
+		if !first {
+			b.buf.WriteString(", ")
+		}
+		first = false
+		b.buf.WriteString(k)
+		b.buf.WriteByte('=')
+		b.writeValue(v)
+		return true
+	})
+	b.buf.WriteByte('}')
+}
+
+func (b *summaryBuffer) writeValue(v pcommon.Value) {
+	switch v.Type() {
+	case pcommon.ValueTypeEmpty:
+		b.buf.WriteString("null")
+	case pcommon.ValueTypeBool, pcommon.ValueTypeInt:
+		b.buf.WriteString(attributeValueToString(v))
+	case pcommon.ValueTypeDouble:
+		b.buf.WriteString(formatFloat(v.DoubleVal()))
+	case pcommon.ValueTypeMap:
+		b.writeMap(v.MapVal())
+	case pcommon.ValueTypeSlice:
+		s := v.SliceVal()
+		b.buf.WriteByte('[')
+		for i := 0; i < s.Len(); i++ {
+			if i > 0 {
+				b.buf.WriteString(", ")
+			}
+			b.writeValue(s.At(i))
+		}
+		b.buf.WriteByte(']')
+	default:
+		// Strings, and bytes as base64.
+		b.buf.WriteString(strconv.Quote(v.AsString()))
+	}
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
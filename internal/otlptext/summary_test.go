@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptext
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestTracesSummary(t *testing.T) {
+	buf, err := NewSummaryTracesMarshaler().MarshalTraces(ptrace.NewTraces())
+	require.NoError(t, err)
+	assert.Empty(t, buf)
+
+	buf, err = NewSummaryTracesMarshaler().MarshalTraces(testdata.GenerateTracesOneSpan())
+	require.NoError(t, err)
+	assert.Equal(t, `span resource={resource-attr="resource-attr-val-1"} scope="" scope_version="" `+
+		`trace_id=0102030405060708090a0b0c0d0e0f10 span_id=1112131415161718 parent_span_id= name="operationA" `+
+		`kind=SPAN_KIND_UNSPECIFIED start=2020-02-11T20:26:12.000000321Z end=2020-02-11T20:26:13.000000789Z `+
+		`duration=1.000000468s status=STATUS_CODE_ERROR status_message="status-cancelled" attributes={} events=2 links=0`+"\n",
+		string(buf))
+
+	buf, err = NewSummaryTracesMarshaler().MarshalTraces(testdata.GenerateTracesTwoSpansSameResourceOneDifferent())
+	require.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(string(buf), "\n"))
+}
+
+func TestMetricsSummary(t *testing.T) {
+	buf, err := NewSummaryMetricsMarshaler().MarshalMetrics(pmetric.NewMetrics())
+	require.NoError(t, err)
+	assert.Empty(t, buf)
+
+	md := testdata.GeneratMetricsAllTypesWithSampleDatapoints()
+	buf, err = NewSummaryMetricsMarshaler().MarshalMetrics(md)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(buf), "\n"), "\n")
+	assert.Len(t, lines, md.DataPointCount())
+	assert.Contains(t, lines, `metric resource={resource-attr="resource-attr-val-1"} scope="" scope_version="" `+
+		`name="histogram" unit="1" type=Histogram temporality=AGGREGATION_TEMPORALITY_CUMULATIVE `+
+		`start=2020-02-11T20:26:12.000000321Z time=2020-02-11T20:26:13.000000789Z attributes={label-2="label-value-2"} `+
+		`count=1 sum=15 bounds=[1] counts=[0, 1]`)
+	assert.Contains(t, lines, `metric resource={resource-attr="resource-attr-val-1"} scope="" scope_version="" `+
+		`name="summary" unit="1" type=Summary start=2020-02-11T20:26:12.000000321Z time=2020-02-11T20:26:13.000000789Z `+
+		`attributes={label-2="label-value-2"} count=1 sum=15 quantiles={0.01=15}`)
+
+	buf, err = NewSummaryMetricsMarshaler().MarshalMetrics(testdata.GenerateMetricsAllTypesEmptyDataPoint())
+	require.NoError(t, err)
+	assert.NotEmpty(t, buf)
+}
+
+func TestLogsSummary(t *testing.T) {
+	buf, err := NewSummaryLogsMarshaler().MarshalLogs(plog.NewLogs())
+	require.NoError(t, err)
+	assert.Empty(t, buf)
+
+	buf, err = NewSummaryLogsMarshaler().MarshalLogs(testdata.GenerateLogsOneLogRecord())
+	require.NoError(t, err)
+	assert.Equal(t, `log resource={resource-attr="resource-attr-val-1"} scope="" scope_version="" `+
+		`time=2020-02-11T20:26:13.000000789Z observed_time=1970-01-01T00:00:00Z severity="Info" `+
+		`severity_number=SEVERITY_NUMBER_INFO body="This is a log message" attributes={app="server", instance_num=1} `+
+		`trace_id=08040201000000000000000000000000 span_id=0102040800000000`+"\n",
+		string(buf))
+}
+
+func TestSummaryValues(t *testing.T) {
+	ld := plog.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	body := pcommon.NewValueMap()
+	body.MapVal().UpsertBool("bool", true)
+	body.MapVal().UpsertDouble("double", 1.5)
+	body.MapVal().UpsertBytes("bytes", []byte{1, 2})
+	body.MapVal().InsertNull("empty")
+	slice := pcommon.NewValueSlice()
+	slice.SliceVal().AppendEmpty().SetStringVal("a b")
+	slice.SliceVal().AppendEmpty().SetIntVal(2)
+	body.MapVal().Upsert("slice", slice)
+	body.CopyTo(lr.Body())
+
+	buf, err := NewSummaryLogsMarshaler().MarshalLogs(ld)
+	require.NoError(t, err)
+	assert.Contains(t, string(buf), ` body={bool=true, double=1.5, bytes="AQI=", empty=null, slice=["a b", 2]} `)
+}
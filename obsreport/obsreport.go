@@ -19,6 +19,9 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+// scopeName is the instrumentation scope name used for the OpenTelemetry metrics recorded by this package.
+const scopeName = "go.opentelemetry.io/collector/obsreport"
+
 func recordError(span trace.Span, err error) {
 	if err != nil {
 		span.SetStatus(codes.Error, err.Error())
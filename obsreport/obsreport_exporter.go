@@ -16,6 +16,7 @@ package obsreport // import "go.opentelemetry.io/collector/obsreport"
 
 import (
 	"context"
+	"time"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
@@ -35,6 +36,7 @@ type Exporter struct {
 	spanNamePrefix string
 	mutators       []tag.Mutator
 	tracer         trace.Tracer
+	recorder       *obsreportconfig.Recorder
 }
 
 // ExporterSettings are settings for creating an Exporter.
@@ -51,6 +53,8 @@ func NewExporter(cfg ExporterSettings) *Exporter {
 		spanNamePrefix: obsmetrics.ExporterPrefix + cfg.ExporterID.String(),
 		mutators:       []tag.Mutator{tag.Upsert(obsmetrics.TagKeyExporter, cfg.ExporterID.String(), tag.WithTTL(tag.TTLNoPropagation))},
 		tracer:         cfg.ExporterCreateSettings.TracerProvider.Tracer(cfg.ExporterID.String()),
+		recorder: obsreportconfig.NewRecorder(cfg.ExporterCreateSettings.MeterProvider, scopeName, obsreportconfig.AllViews(),
+			attribute.String(obsmetrics.ExporterKey, cfg.ExporterID.String())),
 	}
 }
 
@@ -97,11 +101,17 @@ func (exp *Exporter) EndLogsOp(ctx context.Context, numLogRecords int, err error
 	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentLogRecordsKey, obsmetrics.FailedToSendLogRecordsKey)
 }
 
+// sendStartTimeKey is the context key of the start time of the export operation.
+type sendStartTimeKey struct{}
+
 // startOp creates the span used to trace the operation. Returning
 // the updated context and the created span.
 func (exp *Exporter) startOp(ctx context.Context, operationSuffix string) context.Context {
 	spanName := exp.spanNamePrefix + operationSuffix
 	ctx, _ = exp.tracer.Start(ctx, spanName)
+	if exp.level == configtelemetry.LevelDetailed {
+		ctx = context.WithValue(ctx, sendStartTimeKey{}, time.Now())
+	}
 	return ctx
 }
 
@@ -109,12 +119,17 @@ func (exp *Exporter) recordMetrics(ctx context.Context, numSent, numFailedToSend
 	if obsreportconfig.Level() == configtelemetry.LevelNone {
 		return
 	}
-	// Ignore the error for now. This should not happen.
+	ms := []stats.Measurement{sentMeasure.M(numSent)}
 	if numFailedToSend > 0 {
-		_ = stats.RecordWithTags(ctx, exp.mutators, sentMeasure.M(numSent), failedToSendMeasure.M(numFailedToSend))
-	} else {
-		_ = stats.RecordWithTags(ctx, exp.mutators, sentMeasure.M(numSent))
+		ms = append(ms, failedToSendMeasure.M(numFailedToSend))
+	}
+	if start, ok := ctx.Value(sendStartTimeKey{}).(time.Time); ok {
+		ms = append(ms, obsmetrics.ExporterSendLatency.M(time.Since(start).Milliseconds()))
+		// The latency is recorded with the pipeline of the data.
+		exp.recorder.RecordWithAttributes(ctx, exp.mutators, []attribute.KeyValue{obsreportconfig.PipelineAttribute(ctx)}, ms...)
+		return
 	}
+	exp.recorder.Record(ctx, exp.mutators, ms...)
 }
 
 func endSpan(ctx context.Context, err error, numSent, numFailedToSend int64, sentItemsKey, failedToSendItemsKey string) {
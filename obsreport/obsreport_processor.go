@@ -18,12 +18,13 @@ import (
 	"context"
 	"strings"
 
-	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
 
@@ -45,6 +46,7 @@ func BuildProcessorCustomMetricName(configType, metric string) string {
 type Processor struct {
 	level    configtelemetry.Level
 	mutators []tag.Mutator
+	recorder *obsreportconfig.Recorder
 }
 
 // ProcessorSettings are settings for creating a Processor.
@@ -59,14 +61,15 @@ func NewProcessor(cfg ProcessorSettings) *Processor {
 	return &Processor{
 		level:    cfg.Level,
 		mutators: []tag.Mutator{tag.Upsert(obsmetrics.TagKeyProcessor, cfg.ProcessorID.String(), tag.WithTTL(tag.TTLNoPropagation))},
+		recorder: obsreportconfig.NewRecorder(cfg.ProcessorCreateSettings.MeterProvider, scopeName, obsreportconfig.AllViews(),
+			attribute.String(obsmetrics.ProcessorKey, cfg.ProcessorID.String())),
 	}
 }
 
 // TracesAccepted reports that the trace data was accepted.
 func (por *Processor) TracesAccepted(ctx context.Context, numSpans int) {
 	if por.level != configtelemetry.LevelNone {
-		// ignore the error for now; should not happen
-		_ = stats.RecordWithTags(
+		por.recorder.Record(
 			ctx,
 			por.mutators,
 			obsmetrics.ProcessorAcceptedSpans.M(int64(numSpans)),
@@ -79,8 +82,7 @@ func (por *Processor) TracesAccepted(ctx context.Context, numSpans int) {
 // TracesRefused reports that the trace data was refused.
 func (por *Processor) TracesRefused(ctx context.Context, numSpans int) {
 	if por.level != configtelemetry.LevelNone {
-		// ignore the error for now; should not happen
-		_ = stats.RecordWithTags(
+		por.recorder.Record(
 			ctx,
 			por.mutators,
 			obsmetrics.ProcessorAcceptedSpans.M(0),
@@ -93,8 +95,7 @@ func (por *Processor) TracesRefused(ctx context.Context, numSpans int) {
 // TracesDropped reports that the trace data was dropped.
 func (por *Processor) TracesDropped(ctx context.Context, numSpans int) {
 	if por.level != configtelemetry.LevelNone {
-		// ignore the error for now; should not happen
-		_ = stats.RecordWithTags(
+		por.recorder.Record(
 			ctx,
 			por.mutators,
 			obsmetrics.ProcessorAcceptedSpans.M(0),
@@ -107,8 +108,7 @@ func (por *Processor) TracesDropped(ctx context.Context, numSpans int) {
 // MetricsAccepted reports that the metrics were accepted.
 func (por *Processor) MetricsAccepted(ctx context.Context, numPoints int) {
 	if por.level != configtelemetry.LevelNone {
-		// ignore the error for now; should not happen
-		_ = stats.RecordWithTags(
+		por.recorder.Record(
 			ctx,
 			por.mutators,
 			obsmetrics.ProcessorAcceptedMetricPoints.M(int64(numPoints)),
@@ -121,8 +121,7 @@ func (por *Processor) MetricsAccepted(ctx context.Context, numPoints int) {
 // MetricsRefused reports that the metrics were refused.
 func (por *Processor) MetricsRefused(ctx context.Context, numPoints int) {
 	if por.level != configtelemetry.LevelNone {
-		// ignore the error for now; should not happen
-		_ = stats.RecordWithTags(
+		por.recorder.Record(
 			ctx,
 			por.mutators,
 			obsmetrics.ProcessorAcceptedMetricPoints.M(0),
@@ -135,8 +134,7 @@ func (por *Processor) MetricsRefused(ctx context.Context, numPoints int) {
 // MetricsDropped reports that the metrics were dropped.
 func (por *Processor) MetricsDropped(ctx context.Context, numPoints int) {
 	if por.level != configtelemetry.LevelNone {
-		// ignore the error for now; should not happen
-		_ = stats.RecordWithTags(
+		por.recorder.Record(
 			ctx,
 			por.mutators,
 			obsmetrics.ProcessorAcceptedMetricPoints.M(0),
@@ -149,8 +147,7 @@ func (por *Processor) MetricsDropped(ctx context.Context, numPoints int) {
 // LogsAccepted reports that the logs were accepted.
 func (por *Processor) LogsAccepted(ctx context.Context, numRecords int) {
 	if por.level != configtelemetry.LevelNone {
-		// ignore the error for now; should not happen
-		_ = stats.RecordWithTags(
+		por.recorder.Record(
 			ctx,
 			por.mutators,
 			obsmetrics.ProcessorAcceptedLogRecords.M(int64(numRecords)),
@@ -163,8 +160,7 @@ func (por *Processor) LogsAccepted(ctx context.Context, numRecords int) {
 // LogsRefused reports that the logs were refused.
 func (por *Processor) LogsRefused(ctx context.Context, numRecords int) {
 	if por.level != configtelemetry.LevelNone {
-		// ignore the error for now; should not happen
-		_ = stats.RecordWithTags(
+		por.recorder.Record(
 			ctx,
 			por.mutators,
 			obsmetrics.ProcessorAcceptedLogRecords.M(0),
@@ -177,8 +173,7 @@ func (por *Processor) LogsRefused(ctx context.Context, numRecords int) {
 // LogsDropped reports that the logs were dropped.
 func (por *Processor) LogsDropped(ctx context.Context, numRecords int) {
 	if por.level != configtelemetry.LevelNone {
-		// ignore the error for now; should not happen
-		_ = stats.RecordWithTags(
+		por.recorder.Record(
 			ctx,
 			por.mutators,
 			obsmetrics.ProcessorAcceptedLogRecords.M(0),
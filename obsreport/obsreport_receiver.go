@@ -36,6 +36,7 @@ type Receiver struct {
 	longLivedCtx   bool
 	mutators       []tag.Mutator
 	tracer         trace.Tracer
+	recorder       *obsreportconfig.Recorder
 }
 
 // ReceiverSettings are settings for creating an Receiver.
@@ -62,6 +63,10 @@ func NewReceiver(cfg ReceiverSettings) *Receiver {
 			tag.Upsert(obsmetrics.TagKeyTransport, cfg.Transport, tag.WithTTL(tag.TTLNoPropagation)),
 		},
 		tracer: cfg.ReceiverCreateSettings.TracerProvider.Tracer(cfg.ReceiverID.String()),
+		recorder: obsreportconfig.NewRecorder(cfg.ReceiverCreateSettings.MeterProvider, scopeName, obsreportconfig.AllViews(),
+			attribute.String(obsmetrics.ReceiverKey, cfg.ReceiverID.String()),
+			attribute.String(obsmetrics.TransportKey, cfg.Transport),
+		),
 	}
 }
 
@@ -175,8 +180,9 @@ func (rec *Receiver) endOp(
 			refusedMeasure = obsmetrics.ReceiverRefusedLogRecords
 		}
 
-		stats.Record(
+		rec.recorder.Record(
 			receiverCtx,
+			nil,
 			acceptedMeasure.M(int64(numAccepted)),
 			refusedMeasure.M(int64(numRefused)))
 	}
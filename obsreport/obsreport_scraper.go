@@ -17,7 +17,6 @@ package obsreport // import "go.opentelemetry.io/collector/obsreport"
 import (
 	"context"
 
-	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -36,6 +35,7 @@ type Scraper struct {
 	scraper    config.ComponentID
 	mutators   []tag.Mutator
 	tracer     trace.Tracer
+	recorder   *obsreportconfig.Recorder
 }
 
 // ScraperSettings are settings for creating a Scraper.
@@ -54,6 +54,10 @@ func NewScraper(cfg ScraperSettings) *Scraper {
 			tag.Upsert(obsmetrics.TagKeyReceiver, cfg.ReceiverID.String(), tag.WithTTL(tag.TTLNoPropagation)),
 			tag.Upsert(obsmetrics.TagKeyScraper, cfg.Scraper.String(), tag.WithTTL(tag.TTLNoPropagation))},
 		tracer: cfg.ReceiverCreateSettings.TracerProvider.Tracer(cfg.Scraper.String()),
+		recorder: obsreportconfig.NewRecorder(cfg.ReceiverCreateSettings.MeterProvider, scopeName, obsreportconfig.AllViews(),
+			attribute.String(obsmetrics.ReceiverKey, cfg.ReceiverID.String()),
+			attribute.String(obsmetrics.ScraperKey, cfg.Scraper.String()),
+		),
 	}
 }
 
@@ -88,8 +92,9 @@ func (s *Scraper) EndMetricsOp(
 	span := trace.SpanFromContext(scraperCtx)
 
 	if obsreportconfig.Level() != configtelemetry.LevelNone {
-		stats.Record(
+		s.recorder.Record(
 			scraperCtx,
+			nil,
 			obsmetrics.ScraperScrapedMetricPoints.M(int64(numScrapedMetrics)),
 			obsmetrics.ScraperErroredMetricPoints.M(int64(numErroredMetrics)))
 	}
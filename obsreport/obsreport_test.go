@@ -21,6 +21,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 
@@ -406,6 +408,48 @@ func TestExportLogsOp(t *testing.T) {
 	require.NoError(t, obsreporttest.CheckExporterLogs(tt, exporter, int64(sentLogRecords), int64(failedToSendLogRecords)))
 }
 
+func TestExportSendLatency(t *testing.T) {
+	tests := []struct {
+		level     configtelemetry.Level
+		wantCount int64
+	}{
+		{level: configtelemetry.LevelNormal, wantCount: 0},
+		{level: configtelemetry.LevelDetailed, wantCount: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			set, err := obsreporttest.SetupTelemetry()
+			require.NoError(t, err)
+			t.Cleanup(func() { require.NoError(t, set.Shutdown(context.Background())) })
+
+			obsrep := NewExporter(ExporterSettings{
+				Level:                  tt.level,
+				ExporterID:             exporter,
+				ExporterCreateSettings: set.ToExporterCreateSettings(),
+			})
+
+			parentCtx, err := tag.New(context.Background(), tag.Upsert(obsmetrics.TagKeyPipeline, "traces"))
+			require.NoError(t, err)
+			obsrep.EndTracesOp(obsrep.StartTracesOp(parentCtx), 1, nil)
+			obsrep.EndMetricsOp(obsrep.StartMetricsOp(parentCtx), 1, errFake)
+			obsrep.EndLogsOp(obsrep.StartLogsOp(parentCtx), 1, nil)
+
+			rows, err := view.RetrieveData(obsmetrics.ExporterPrefix + obsmetrics.SendLatencyKey)
+			require.NoError(t, err)
+			if tt.wantCount == 0 {
+				assert.Empty(t, rows)
+				return
+			}
+			require.Len(t, rows, 1)
+			assert.Equal(t, []tag.Tag{
+				{Key: obsmetrics.TagKeyExporter, Value: exporter.String()},
+				{Key: obsmetrics.TagKeyPipeline, Value: "traces"},
+			}, rows[0].Tags)
+			assert.Equal(t, tt.wantCount, rows[0].Data.(*view.DistributionData).Count)
+		})
+	}
+}
+
 func TestReceiveWithLongLivedCtx(t *testing.T) {
 	tt, err := obsreporttest.SetupTelemetry()
 	require.NoError(t, err)
@@ -21,7 +21,7 @@ import (
 
 const accessorSliceTemplate = `// ${fieldName} returns the ${originFieldName} associated with this ${structName}.
 func (ms ${structName}) ${fieldName}() ${returnType} {
-	return new${returnType}(&(*ms.orig).${originFieldName})
+	return ${returnType}{orig: &ms.getOrig().${originFieldName}, shared: ms.shared}
 }`
 
 const accessorsSliceTestTemplate = `func Test${structName}_${fieldName}(t *testing.T) {
@@ -34,7 +34,7 @@ const accessorsSliceTestTemplate = `func Test${structName}_${fieldName}(t *testi
 
 const accessorsMessageValueTemplate = `// ${fieldName} returns the ${lowerFieldName} associated with this ${structName}.
 func (ms ${structName}) ${fieldName}() ${returnType} {
-	return new${returnType}(&(*ms.orig).${originFieldName})
+	return ${returnType}{orig: &ms.getOrig().${originFieldName}, shared: ms.shared}
 }`
 
 const accessorsMessageValueTestTemplate = `func Test${structName}_${fieldName}(t *testing.T) {
@@ -45,26 +45,26 @@ const accessorsMessageValueTestTemplate = `func Test${structName}_${fieldName}(t
 
 const accessorsPrimitiveTemplate = `// ${fieldName} returns the ${lowerFieldName} associated with this ${structName}.
 ${extraComment}func (ms ${structName}) ${fieldName}() ${returnType} {
-	return (*ms.orig).${originFieldName}
+	return ms.getOrig().${originFieldName}
 }
 
 // Set${fieldName} replaces the ${lowerFieldName} associated with this ${structName}.
 ${extraComment}func (ms ${structName}) Set${fieldName}(v ${returnType}) {
-	(*ms.orig).${originFieldName} = v
+	ms.getMutableOrig().${originFieldName} = v
 }`
 
-const copyToPrimitiveSliceTestTemplate = `	if len(ms.orig.${originFieldName}) == 0 {	
-		dest.orig.${originFieldName} = nil
+const copyToPrimitiveSliceTestTemplate = `	if len(ms.getOrig().${originFieldName}) == 0 {	
+		dest.getMutableOrig().${originFieldName} = nil
 	} else {
-		dest.orig.${originFieldName} = make(${returnType}, len(ms.orig.${originFieldName}))
-		copy(dest.orig.${originFieldName}, ms.orig.${originFieldName})
+		dest.getMutableOrig().${originFieldName} = make(${returnType}, len(ms.getOrig().${originFieldName}))
+		copy(dest.getMutableOrig().${originFieldName}, ms.getOrig().${originFieldName})
 	}
 `
 
 const oneOfTypeAccessorHeaderTemplate = `// ${originFieldName}Type returns the type of the ${lowerOriginFieldName} for this ${structName}.
 // Calling this function on zero-initialized ${structName} will cause a panic.
 func (ms ${structName}) ${originFieldName}Type() ${typeName} {
-	switch ms.orig.${originFieldName}.(type) {`
+	switch ms.getOrig().${originFieldName}.(type) {`
 
 const oneOfTypeAccessorHeaderTestTemplate = `func Test${structName}${originFieldName}Type(t *testing.T) {
 	tv := New${structName}()
@@ -78,11 +78,11 @@ const accessorsOneOfMessageTemplate = `// ${fieldName} returns the ${lowerFieldN
 //
 // Calling this function on zero-initialized ${structName} will cause a panic.
 func (ms ${structName}) ${fieldName}() ${returnType} {
-	v, ok := ms.orig.Get${originOneOfFieldName}().(*${originStructType})
+	v, ok := ms.getOrig().Get${originOneOfFieldName}().(*${originStructType})
 	if !ok {
 		return ${returnType}{}
 	}
-	return new${returnType}(v.${originFieldName})
+	return ${returnType}{orig: v.${originFieldName}, shared: ms.shared}
 }`
 
 const accessorsOneOfMessageTestTemplate = `func Test${structName}_${fieldName}(t *testing.T) {
@@ -102,17 +102,19 @@ func Test${structName}_CopyTo_${fieldName}(t *testing.T) {
 }`
 
 const copyToValueOneOfMessageTemplate = `	case ${typeName}:
-		dest.Set${originOneOfFieldName}Type(${typeName})
+		if dest.${originOneOfFieldName}Type() != ${typeName} {
+			dest.Set${originOneOfFieldName}Type(${typeName})
+		}
 		ms.${fieldName}().CopyTo(dest.${fieldName}())`
 
 const accessorsOneOfPrimitiveTemplate = `// ${fieldName} returns the ${lowerFieldName} associated with this ${structName}.
 func (ms ${structName}) ${fieldName}() ${returnType} {
-	return (*ms.orig).Get${originFieldName}()
+	return ms.getOrig().Get${originFieldName}()
 }
 
 // Set${fieldName} replaces the ${lowerFieldName} associated with this ${structName}.
 func (ms ${structName}) Set${fieldName}(v ${returnType}) {
-	(*ms.orig).${originOneOfFieldName} = &${originStructType}{
+	ms.getMutableOrig().${originOneOfFieldName} = &${originStructType}{
 		${originFieldName}: v,
 	}
 }`
@@ -127,36 +129,36 @@ const accessorsPrimitiveTestTemplate = `func Test${structName}_${fieldName}(t *t
 
 const accessorsPrimitiveTypedTemplate = `// ${fieldName} returns the ${lowerFieldName} associated with this ${structName}.
 func (ms ${structName}) ${fieldName}() ${returnType} {
-	return ${returnType}((*ms.orig).${originFieldName})
+	return ${returnType}(ms.getOrig().${originFieldName})
 }
 
 // Set${fieldName} replaces the ${lowerFieldName} associated with this ${structName}.
 func (ms ${structName}) Set${fieldName}(v ${returnType}) {
-	(*ms.orig).${originFieldName} = ${rawType}(v)
+	ms.getMutableOrig().${originFieldName} = ${rawType}(v)
 }`
 
 const accessorsPrimitiveStructTemplate = `// ${fieldName} returns the ${lowerFieldName} associated with this ${structName}.
 func (ms ${structName}) ${fieldName}() ${returnType} {
-	return ${returnType}{orig: ((*ms.orig).${originFieldName})}
+	return ${returnType}{orig: ms.getOrig().${originFieldName}}
 }
 
 // Set${fieldName} replaces the ${lowerFieldName} associated with this ${structName}.
 func (ms ${structName}) Set${fieldName}(v ${returnType}) {
-	(*ms.orig).${originFieldName} = v.orig
+	ms.getMutableOrig().${originFieldName} = v.orig
 }`
 
 const accessorsOptionalPrimitiveValueTemplate = `// ${fieldName} returns the ${lowerFieldName} associated with this ${structName}.
 func (ms ${structName}) ${fieldName}() ${returnType} {
-	return (*ms.orig).Get${fieldName}()
+	return ms.getOrig().Get${fieldName}()
 }
 // Has${fieldName} returns true if the ${structName} contains a
 // ${fieldName} value, false otherwise.
 func (ms ${structName}) Has${fieldName}() bool {
-	return ms.orig.${fieldName}_ != nil
+	return ms.getOrig().${fieldName}_ != nil
 }
 // Set${fieldName} replaces the ${lowerFieldName} associated with this ${structName}.
 func (ms ${structName}) Set${fieldName}(v ${returnType}) {
-	(*ms.orig).${fieldName}_ = &${originStructType}{${fieldName}: v}
+	ms.getMutableOrig().${fieldName}_ = &${originStructType}{${fieldName}: v}
 }`
 
 type baseField interface {
@@ -600,6 +602,8 @@ func (of *oneOfField) generateSetWithTestValue(sb *strings.Builder) {
 
 func (of *oneOfField) generateCopyToValue(sb *strings.Builder) {
 	sb.WriteString("\tswitch ms." + of.originFieldName + "Type() {\n")
+	sb.WriteString("\tcase " + of.typeName + "None:\n")
+	sb.WriteString("\t\tdest.getMutableOrig()." + of.originFieldName + " = nil\n")
 	for _, v := range of.values {
 		v.generateCopyToValue(of, sb)
 	}
@@ -829,6 +833,8 @@ func (opv *optionalPrimitiveValue) generateSetWithTestValue(sb *strings.Builder)
 func (opv *optionalPrimitiveValue) generateCopyToValue(sb *strings.Builder) {
 	sb.WriteString("if ms.Has" + opv.fieldName + "(){\n")
 	sb.WriteString("\tdest.Set" + opv.fieldName + "(ms." + opv.fieldName + "())\n")
+	sb.WriteString("} else {\n")
+	sb.WriteString("\tdest.getMutableOrig()." + opv.fieldName + "_ = nil\n")
 	sb.WriteString("}\n")
 }
 
@@ -23,33 +23,43 @@ const commonSliceTemplate = `
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ${structName}) MoveAndAppendTo(dest ${structName}) {
-	if *dest.orig == nil {
+	orig := es.getMutableOrig()
+	destOrig := dest.getMutableOrig()
+${moveShared}	if *destOrig == nil {
 		// We can simply move the entire vector and avoid any allocations.
-		*dest.orig = *es.orig
+		*destOrig = *orig
 	} else {
-		*dest.orig = append(*dest.orig, *es.orig...)
+		*destOrig = append(*destOrig, *orig...)
 	}
-	*es.orig = nil
+	*orig = nil
 }
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es ${structName}) RemoveIf(f func(${elementName}) bool) {
 	newLen := 0
-	for i := 0; i < len(*es.orig); i++ {
+	for i := 0; i < es.Len(); i++ {
 		if f(es.At(i)) {
-			continue
+${removeShared}			continue
 		}
 		if newLen == i {
 			// Nothing to move, element is at the right place.
 			newLen++
 			continue
 		}
-		(*es.orig)[newLen] = (*es.orig)[i]
+		orig := es.getMutableOrig()
+		(*orig)[newLen] = (*orig)[i]
 		newLen++
 	}
-	// TODO: Prevent memory leak by erasing truncated values.
-	*es.orig = (*es.orig)[:newLen]
+	if newLen == es.Len() {
+		return
+	}
+	orig := es.getMutableOrig()
+	// Erase truncated values to not keep references to removed elements.
+	for i := newLen; i < len(*orig); i++ {
+		(*orig)[i] = ${emptyOrigin}
+	}
+	*orig = (*orig)[:newLen]
 }`
 
 const commonSliceTestTemplate = `
@@ -135,24 +145,36 @@ type ${structName} struct {
 	// orig points to the slice ${originName} field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
 	orig *[]*${originName}
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource${sharedField}
 }
 
 func new${structName}(orig *[]*${originName}) ${structName} {
-	return ${structName}{orig}
+	return ${structName}{orig: orig}
 }
 
 // New${structName} creates a ${structName} with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func New${structName}() ${structName} {
 	orig := []*${originName}(nil)
-	return ${structName}{&orig}
+	return ${structName}{orig: &orig}
+}
+
+// getOrig returns the orig to read.
+func (es ${structName}) getOrig() *[]*${originName} {
+	return (*[]*${originName})(es.shared.read(unsafe.Pointer(es.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (es ${structName}) getMutableOrig() *[]*${originName} {
+	return (*[]*${originName})(es.shared.write(unsafe.Pointer(es.orig)))
 }
 
 // Len returns the number of elements in the slice.
 //
 // Returns "0" for a newly instance created with "New${structName}()".
 func (es ${structName}) Len() int {
-	return len(*es.orig)
+	return len(*es.getOrig())
 }
 
 // At returns the element at the given index.
@@ -163,27 +185,33 @@ func (es ${structName}) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ${structName}) At(ix int) ${elementName} {
-	return new${elementName}((*es.orig)[ix])
+	orig := (*es.getOrig())[ix]
+	return ${elementName}{orig: orig, shared: ${elementShared}}
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ${structName}) CopyTo(dest ${structName}) {
-	srcLen := es.Len()
-	destCap := cap(*dest.orig)
-	if srcLen <= destCap {
-		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
-		for i := range *es.orig {
-			new${elementName}((*es.orig)[i]).CopyTo(new${elementName}((*dest.orig)[i]))
+	destOrig := dest.getMutableOrig()
+	orig := es.getOrig()
+	srcLen := len(*orig)
+	destCap := cap(*destOrig)
+${dropShared}	if srcLen <= destCap {
+		(*destOrig) = (*destOrig)[:srcLen:destCap]
+		for i := range *orig {
+			if (*destOrig)[i] == nil {
+				(*destOrig)[i] = &${originName}{}
+			}
+			new${elementName}((*orig)[i]).CopyTo(new${elementName}((*destOrig)[i]))
 		}
 		return
 	}
 	origs := make([]${originName}, srcLen)
 	wrappers := make([]*${originName}, srcLen)
-	for i := range *es.orig {
+	for i := range *orig {
 		wrappers[i] = &origs[i]
-		new${elementName}((*es.orig)[i]).CopyTo(new${elementName}(wrappers[i]))
+		new${elementName}((*orig)[i]).CopyTo(new${elementName}(wrappers[i]))
 	}
-	*dest.orig = wrappers
+	*destOrig = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -198,20 +226,22 @@ func (es ${structName}) CopyTo(dest ${structName}) {
 //       // Here should set all the values for e.
 //   }
 func (es ${structName}) EnsureCapacity(newCap int) {
-	oldCap := cap(*es.orig)
+	oldCap := cap(*es.getOrig())
 	if newCap <= oldCap {
 		return
 	}
 
-	newOrig := make([]*${originName}, len(*es.orig), newCap)
-	copy(newOrig, *es.orig)
-	*es.orig = newOrig
+	orig := es.getMutableOrig()
+	newOrig := make([]*${originName}, len(*orig), newCap)
+	copy(newOrig, *orig)
+	*orig = newOrig
 }
 
 // AppendEmpty will append to the end of the slice an empty ${elementName}.
 // It returns the newly added ${elementName}.
 func (es ${structName}) AppendEmpty() ${elementName} {
-	*es.orig = append(*es.orig, &${originName}{})
+	orig := es.getMutableOrig()
+	*orig = append(*orig, &${originName}{})
 	return es.At(es.Len() - 1)
 }
 
@@ -225,7 +255,7 @@ func (es ${structName}) AppendEmpty() ${elementName} {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es ${structName}) Sort(less func(a, b ${elementName}) bool) ${structName} {
-	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	sort.SliceStable(*es.getMutableOrig(), func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
 `
@@ -308,24 +338,36 @@ type ${structName} struct {
 	// orig points to the slice ${originName} field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
 	orig *[]${originName}
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func new${structName}(orig *[]${originName}) ${structName} {
-	return ${structName}{orig}
+	return ${structName}{orig: orig}
 }
 
 // New${structName} creates a ${structName} with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func New${structName}() ${structName} {
 	orig := []${originName}(nil)
-	return ${structName}{&orig}
+	return ${structName}{orig: &orig}
+}
+
+// getOrig returns the orig to read.
+func (es ${structName}) getOrig() *[]${originName} {
+	return (*[]${originName})(es.shared.read(unsafe.Pointer(es.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (es ${structName}) getMutableOrig() *[]${originName} {
+	return (*[]${originName})(es.shared.write(unsafe.Pointer(es.orig)))
 }
 
 // Len returns the number of elements in the slice.
 //
 // Returns "0" for a newly instance created with "New${structName}()".
 func (es ${structName}) Len() int {
-	return len(*es.orig)
+	return len(*es.getOrig())
 }
 
 // At returns the element at the given index.
@@ -336,21 +378,23 @@ func (es ${structName}) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ${structName}) At(ix int) ${elementName} {
-	return new${elementName}(&(*es.orig)[ix])
+	return ${elementName}{orig: &(*es.getOrig())[ix], shared: es.shared}
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ${structName}) CopyTo(dest ${structName}) {
-	srcLen := es.Len()
-	destCap := cap(*dest.orig)
+	destOrig := dest.getMutableOrig()
+	orig := es.getOrig()
+	srcLen := len(*orig)
+	destCap := cap(*destOrig)
 	if srcLen <= destCap {
-		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
+		(*destOrig) = (*destOrig)[:srcLen:destCap]
 	} else {
-		(*dest.orig) = make([]${originName}, srcLen)
+		(*destOrig) = make([]${originName}, srcLen)
 	}
 
-	for i := range *es.orig {
-		new${elementName}(&(*es.orig)[i]).CopyTo(new${elementName}(&(*dest.orig)[i]))
+	for i := range *orig {
+		new${elementName}(&(*orig)[i]).CopyTo(new${elementName}(&(*destOrig)[i]))
 	}
 }
 
@@ -366,20 +410,22 @@ func (es ${structName}) CopyTo(dest ${structName}) {
 //       // Here should set all the values for e.
 //   }
 func (es ${structName}) EnsureCapacity(newCap int) {
-	oldCap := cap(*es.orig)
+	oldCap := cap(*es.getOrig())
 	if newCap <= oldCap {
 		return
 	}
 
-	newOrig := make([]${originName}, len(*es.orig), newCap)
-	copy(newOrig, *es.orig)
-	*es.orig = newOrig
+	orig := es.getMutableOrig()
+	newOrig := make([]${originName}, len(*orig), newCap)
+	copy(newOrig, *orig)
+	*orig = newOrig
 }
 
 // AppendEmpty will append to the end of the slice an empty ${elementName}.
 // It returns the newly added ${elementName}.
 func (es ${structName}) AppendEmpty() ${elementName} {
-	*es.orig = append(*es.orig, ${originName}{})
+	orig := es.getMutableOrig()
+	*orig = append(*orig, ${originName}{})
 	return es.At(es.Len() - 1)
 }`
 
@@ -449,6 +495,8 @@ type baseSlice interface {
 type sliceOfPtrs struct {
 	structName string
 	element    *messageValueStruct
+	// shared elements can share their content with other slices, it is copied when modified.
+	shared bool
 }
 
 func (ss *sliceOfPtrs) getName() string {
@@ -478,12 +526,40 @@ func (ss *sliceOfPtrs) templateFields() func(name string) string {
 			return ss.element.structName
 		case "originName":
 			return ss.element.originFullName
+		case "emptyOrigin":
+			return "nil"
+		case "sharedField", "elementShared", "dropShared", "moveShared", "removeShared":
+			return ss.sharedTemplateField(name)
 		default:
 			panic(name)
 		}
 	}
 }
 
+func (ss *sliceOfPtrs) sharedTemplateField(name string) string {
+	if !ss.shared {
+		if name == "elementShared" {
+			return "es.shared"
+		}
+		return ""
+	}
+	switch name {
+	case "sharedField":
+		return "\n\t// sharedElements, if not nil, tracks the elements sharing their content with other data, see Share.\n" +
+			"\tsharedElements *Shared" + ss.element.structName
+	case "elementShared":
+		return "es.sharedElements.get(orig)"
+	case "dropShared":
+		return "\tdest.sharedElements.drop((*destOrig)[:destCap])\n"
+	case "moveShared":
+		return "\tes.sharedElements.moveTo(dest.sharedElements, *orig)\n"
+	case "removeShared":
+		return "\t\t\tes.sharedElements.remove((*es.getOrig())[i])\n"
+	default:
+		panic(name)
+	}
+}
+
 func (ss *sliceOfPtrs) generateAlias(sb *strings.Builder) {
 	sb.WriteString(os.Expand(commonSliceAliasTemplate, func(name string) string {
 		switch name {
@@ -533,6 +609,10 @@ func (ss *sliceOfValues) templateFields() func(name string) string {
 			return ss.element.structName
 		case "originName":
 			return ss.element.originFullName
+		case "emptyOrigin":
+			return ss.element.originFullName + "{}"
+		case "moveShared", "removeShared":
+			return ""
 		default:
 			panic(name)
 		}
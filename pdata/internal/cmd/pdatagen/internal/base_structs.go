@@ -28,12 +28,24 @@ const messageValueTemplate = `${description}
 // Important: zero-initialized instance is not valid for use.
 type ${structName} struct {
 	orig *${originName}
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func new${structName}(orig *${originName}) ${structName} {
 	return ${structName}{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms ${structName}) getOrig() *${originName} {
+	return (*${originName})(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms ${structName}) getMutableOrig() *${originName} {
+	return (*${originName})(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // New${structName} creates a new empty ${structName}.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -45,8 +57,9 @@ func New${structName}() ${structName} {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms ${structName}) MoveTo(dest ${structName}) {
-	*dest.orig = *ms.orig
-	*ms.orig = ${originName}{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = ${originName}{}
 }`
 
 const messageValueCopyToHeaderTemplate = `// CopyTo copies all properties from the current struct to the dest.
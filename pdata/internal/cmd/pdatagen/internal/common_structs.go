@@ -17,6 +17,8 @@ package internal // import "go.opentelemetry.io/collector/pdata/internal/cmd/pda
 var commonFile = &File{
 	Name: "common",
 	imports: []string{
+		`"unsafe"`,
+		``,
 		`otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"`,
 	},
 	testImports: []string{
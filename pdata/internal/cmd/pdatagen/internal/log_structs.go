@@ -18,6 +18,7 @@ var logFile = &File{
 	Name: "plog",
 	imports: []string{
 		`"sort"`,
+		`"unsafe"`,
 		``,
 		`otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"`,
 	},
@@ -41,6 +42,7 @@ var logFile = &File{
 var resourceLogsSlice = &sliceOfPtrs{
 	structName: "ResourceLogsSlice",
 	element:    resourceLogs,
+	shared:     true,
 }
 
 var resourceLogs = &messageValueStruct{
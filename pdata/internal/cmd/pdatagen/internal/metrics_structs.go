@@ -18,6 +18,7 @@ var metricsFile = &File{
 	Name: "pmetric",
 	imports: []string{
 		`"sort"`,
+		`"unsafe"`,
 		``,
 		`otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"`,
 	},
@@ -59,6 +60,7 @@ var metricsFile = &File{
 var resourceMetricsSlice = &sliceOfPtrs{
 	structName: "ResourceMetricsSlice",
 	element:    resourceMetrics,
+	shared:     true,
 }
 
 var resourceMetrics = &messageValueStruct{
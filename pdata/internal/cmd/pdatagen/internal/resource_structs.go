@@ -17,6 +17,8 @@ package internal // import "go.opentelemetry.io/collector/pdata/internal/cmd/pda
 var resourceFile = &File{
 	Name: "resource",
 	imports: []string{
+		`"unsafe"`,
+		``,
 		`otlpresource "go.opentelemetry.io/collector/pdata/internal/data/protogen/resource/v1"`,
 	},
 	testImports: []string{
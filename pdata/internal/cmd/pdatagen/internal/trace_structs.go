@@ -18,6 +18,7 @@ var traceFile = &File{
 	Name: "ptrace",
 	imports: []string{
 		`"sort"`,
+		`"unsafe"`,
 		``,
 		`otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"`,
 	},
@@ -46,6 +47,7 @@ var traceFile = &File{
 var resourceSpansSlice = &sliceOfPtrs{
 	structName: "ResourceSpansSlice",
 	element:    resourceSpans,
+	shared:     true,
 }
 
 var resourceSpans = &messageValueStruct{
@@ -25,6 +25,7 @@ import (
 	"math"
 	"sort"
 	"strconv"
+	"unsafe"
 
 	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
 )
@@ -84,10 +85,22 @@ func (avt ValueType) String() string {
 // be called only on instances that are created via NewValue+ functions.
 type Value struct {
 	orig *otlpcommon.AnyValue
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newValue(orig *otlpcommon.AnyValue) Value {
-	return Value{orig}
+	return Value{orig: orig}
+}
+
+// getOrig returns the orig to read.
+func (v Value) getOrig() *otlpcommon.AnyValue {
+	return (*otlpcommon.AnyValue)(v.shared.read(unsafe.Pointer(v.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (v Value) getMutableOrig() *otlpcommon.AnyValue {
+	return (*otlpcommon.AnyValue)(v.shared.write(unsafe.Pointer(v.orig)))
 }
 
 // NewValueEmpty creates a new Value with an empty value.
@@ -182,10 +195,11 @@ func newValueFromRaw(iv interface{}) Value {
 // Type returns the type of the value for this Value.
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) Type() ValueType {
-	if v.orig.Value == nil {
+	orig := v.getOrig()
+	if orig.Value == nil {
 		return ValueTypeEmpty
 	}
-	switch v.orig.Value.(type) {
+	switch orig.Value.(type) {
 	case *otlpcommon.AnyValue_StringValue:
 		return ValueTypeString
 	case *otlpcommon.AnyValue_BoolValue:
@@ -208,28 +222,28 @@ func (v Value) Type() ValueType {
 // If the Type() is not ValueTypeString then returns empty string.
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) StringVal() string {
-	return v.orig.GetStringValue()
+	return v.getOrig().GetStringValue()
 }
 
 // IntVal returns the int64 value associated with this Value.
 // If the Type() is not ValueTypeInt then returns int64(0).
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) IntVal() int64 {
-	return v.orig.GetIntValue()
+	return v.getOrig().GetIntValue()
 }
 
 // DoubleVal returns the float64 value associated with this Value.
 // If the Type() is not ValueTypeDouble then returns float64(0).
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) DoubleVal() float64 {
-	return v.orig.GetDoubleValue()
+	return v.getOrig().GetDoubleValue()
 }
 
 // BoolVal returns the bool value associated with this Value.
 // If the Type() is not ValueTypeBool then returns false.
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) BoolVal() bool {
-	return v.orig.GetBoolValue()
+	return v.getOrig().GetBoolValue()
 }
 
 // MapVal returns the map value associated with this Value.
@@ -238,11 +252,11 @@ func (v Value) BoolVal() bool {
 //
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) MapVal() Map {
-	kvlist := v.orig.GetKvlistValue()
+	kvlist := v.getOrig().GetKvlistValue()
 	if kvlist == nil {
 		return Map{}
 	}
-	return newMap(&kvlist.Values)
+	return Map{orig: &kvlist.Values, shared: v.shared}
 }
 
 // SliceVal returns the slice value associated with this Value.
@@ -251,11 +265,11 @@ func (v Value) MapVal() Map {
 //
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) SliceVal() Slice {
-	arr := v.orig.GetArrayValue()
+	arr := v.getOrig().GetArrayValue()
 	if arr == nil {
 		return Slice{}
 	}
-	return newSlice(&arr.Values)
+	return Slice{orig: &arr.Values, shared: v.shared}
 }
 
 // BytesVal returns the []byte value associated with this Value.
@@ -263,35 +277,35 @@ func (v Value) SliceVal() Slice {
 // Calling this function on zero-initialized Value will cause a panic.
 // Modifying the returned []byte in-place is forbidden.
 func (v Value) BytesVal() []byte {
-	return v.orig.GetBytesValue()
+	return v.getOrig().GetBytesValue()
 }
 
 // SetStringVal replaces the string value associated with this Value,
 // it also changes the type to be ValueTypeString.
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) SetStringVal(sv string) {
-	v.orig.Value = &otlpcommon.AnyValue_StringValue{StringValue: sv}
+	v.getMutableOrig().Value = &otlpcommon.AnyValue_StringValue{StringValue: sv}
 }
 
 // SetIntVal replaces the int64 value associated with this Value,
 // it also changes the type to be ValueTypeInt.
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) SetIntVal(iv int64) {
-	v.orig.Value = &otlpcommon.AnyValue_IntValue{IntValue: iv}
+	v.getMutableOrig().Value = &otlpcommon.AnyValue_IntValue{IntValue: iv}
 }
 
 // SetDoubleVal replaces the float64 value associated with this Value,
 // it also changes the type to be ValueTypeDouble.
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) SetDoubleVal(dv float64) {
-	v.orig.Value = &otlpcommon.AnyValue_DoubleValue{DoubleValue: dv}
+	v.getMutableOrig().Value = &otlpcommon.AnyValue_DoubleValue{DoubleValue: dv}
 }
 
 // SetBoolVal replaces the bool value associated with this Value,
 // it also changes the type to be ValueTypeBool.
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) SetBoolVal(bv bool) {
-	v.orig.Value = &otlpcommon.AnyValue_BoolValue{BoolValue: bv}
+	v.getMutableOrig().Value = &otlpcommon.AnyValue_BoolValue{BoolValue: bv}
 }
 
 // SetBytesVal replaces the []byte value associated with this Value,
@@ -300,12 +314,13 @@ func (v Value) SetBoolVal(bv bool) {
 // The caller must ensure the []byte passed in is not modified after the call is made, sharing the data
 // across multiple attributes is forbidden.
 func (v Value) SetBytesVal(bv []byte) {
-	v.orig.Value = &otlpcommon.AnyValue_BytesValue{BytesValue: bv}
+	v.getMutableOrig().Value = &otlpcommon.AnyValue_BytesValue{BytesValue: bv}
 }
 
 // copyTo copies the value to Value. Will panic if dest is nil.
 func (v Value) copyTo(dest *otlpcommon.AnyValue) {
-	switch ov := v.orig.Value.(type) {
+	orig := v.getOrig()
+	switch ov := orig.Value.(type) {
 	case *otlpcommon.AnyValue_KvlistValue:
 		kv, ok := dest.Value.(*otlpcommon.AnyValue_KvlistValue)
 		if !ok {
@@ -340,41 +355,42 @@ func (v Value) copyTo(dest *otlpcommon.AnyValue) {
 		copy(bv.BytesValue, ov.BytesValue)
 	default:
 		// Primitive immutable type, no need for deep copy.
-		dest.Value = v.orig.Value
+		dest.Value = orig.Value
 	}
 }
 
 // CopyTo copies the attribute to a destination.
 func (v Value) CopyTo(dest Value) {
-	v.copyTo(dest.orig)
+	v.copyTo(dest.getMutableOrig())
 }
 
 // Equal checks for equality, it returns true if the objects are equal otherwise false.
 func (v Value) Equal(av Value) bool {
-	if v.orig == av.orig {
+	orig, avOrig := v.getOrig(), av.getOrig()
+	if orig == avOrig {
 		return true
 	}
 
-	if v.orig.Value == nil || av.orig.Value == nil {
-		return v.orig.Value == av.orig.Value
+	if orig.Value == nil || avOrig.Value == nil {
+		return orig.Value == avOrig.Value
 	}
 
 	if v.Type() != av.Type() {
 		return false
 	}
 
-	switch v := v.orig.Value.(type) {
+	switch v := orig.Value.(type) {
 	case *otlpcommon.AnyValue_StringValue:
-		return v.StringValue == av.orig.GetStringValue()
+		return v.StringValue == avOrig.GetStringValue()
 	case *otlpcommon.AnyValue_BoolValue:
-		return v.BoolValue == av.orig.GetBoolValue()
+		return v.BoolValue == avOrig.GetBoolValue()
 	case *otlpcommon.AnyValue_IntValue:
-		return v.IntValue == av.orig.GetIntValue()
+		return v.IntValue == avOrig.GetIntValue()
 	case *otlpcommon.AnyValue_DoubleValue:
-		return v.DoubleValue == av.orig.GetDoubleValue()
+		return v.DoubleValue == avOrig.GetDoubleValue()
 	case *otlpcommon.AnyValue_ArrayValue:
 		vv := v.ArrayValue.GetValues()
-		avv := av.orig.GetArrayValue().GetValues()
+		avv := avOrig.GetArrayValue().GetValues()
 		if len(vv) != len(avv) {
 			return false
 		}
@@ -395,7 +411,7 @@ func (v Value) Equal(av Value) bool {
 		return true
 	case *otlpcommon.AnyValue_KvlistValue:
 		cc := v.KvlistValue.GetValues()
-		avv := av.orig.GetKvlistValue().GetValues()
+		avv := avOrig.GetKvlistValue().GetValues()
 		if len(cc) != len(avv) {
 			return false
 		}
@@ -414,7 +430,7 @@ func (v Value) Equal(av Value) bool {
 		}
 		return true
 	case *otlpcommon.AnyValue_BytesValue:
-		return bytes.Equal(v.BytesValue, av.orig.GetBytesValue())
+		return bytes.Equal(v.BytesValue, avOrig.GetBytesValue())
 	}
 
 	return false
@@ -511,28 +527,28 @@ func (v Value) asRaw() interface{} {
 
 func newAttributeKeyValueString(k string, v string) otlpcommon.KeyValue {
 	orig := otlpcommon.KeyValue{Key: k}
-	akv := Value{&orig.Value}
+	akv := newValue(&orig.Value)
 	akv.SetStringVal(v)
 	return orig
 }
 
 func newAttributeKeyValueInt(k string, v int64) otlpcommon.KeyValue {
 	orig := otlpcommon.KeyValue{Key: k}
-	akv := Value{&orig.Value}
+	akv := newValue(&orig.Value)
 	akv.SetIntVal(v)
 	return orig
 }
 
 func newAttributeKeyValueDouble(k string, v float64) otlpcommon.KeyValue {
 	orig := otlpcommon.KeyValue{Key: k}
-	akv := Value{&orig.Value}
+	akv := newValue(&orig.Value)
 	akv.SetDoubleVal(v)
 	return orig
 }
 
 func newAttributeKeyValueBool(k string, v bool) otlpcommon.KeyValue {
 	orig := otlpcommon.KeyValue{Key: k}
-	akv := Value{&orig.Value}
+	akv := newValue(&orig.Value)
 	akv.SetBoolVal(v)
 	return orig
 }
@@ -550,7 +566,7 @@ func newAttributeKeyValue(k string, av Value) otlpcommon.KeyValue {
 
 func newAttributeKeyValueBytes(k string, v []byte) otlpcommon.KeyValue {
 	orig := otlpcommon.KeyValue{Key: k}
-	akv := Value{&orig.Value}
+	akv := newValue(&orig.Value)
 	akv.SetBytesVal(v)
 	return orig
 }
@@ -558,19 +574,21 @@ func newAttributeKeyValueBytes(k string, v []byte) otlpcommon.KeyValue {
 // Map stores a map of string keys to elements of Value type.
 type Map struct {
 	orig *[]otlpcommon.KeyValue
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 // NewMap creates a Map with 0 elements.
 func NewMap() Map {
 	orig := []otlpcommon.KeyValue(nil)
-	return Map{&orig}
+	return Map{orig: &orig}
 }
 
 // NewMapFromRaw creates a Map with values from the given map[string]interface{}.
 func NewMapFromRaw(rawMap map[string]interface{}) Map {
 	if len(rawMap) == 0 {
 		kv := []otlpcommon.KeyValue(nil)
-		return Map{&kv}
+		return Map{orig: &kv}
 	}
 	origs := make([]otlpcommon.KeyValue, len(rawMap))
 	ix := 0
@@ -579,27 +597,38 @@ func NewMapFromRaw(rawMap map[string]interface{}) Map {
 		newValueFromRaw(iv).copyTo(&origs[ix].Value)
 		ix++
 	}
-	return Map{&origs}
+	return Map{orig: &origs}
 }
 
 func newMap(orig *[]otlpcommon.KeyValue) Map {
-	return Map{orig}
+	return Map{orig: orig}
+}
+
+// getOrig returns the orig to read.
+func (m Map) getOrig() *[]otlpcommon.KeyValue {
+	return (*[]otlpcommon.KeyValue)(m.shared.read(unsafe.Pointer(m.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (m Map) getMutableOrig() *[]otlpcommon.KeyValue {
+	return (*[]otlpcommon.KeyValue)(m.shared.write(unsafe.Pointer(m.orig)))
 }
 
 // Clear erases any existing entries in this Map instance.
 func (m Map) Clear() {
-	*m.orig = nil
+	*m.getMutableOrig() = nil
 }
 
 // EnsureCapacity increases the capacity of this Map instance, if necessary,
 // to ensure that it can hold at least the number of elements specified by the capacity argument.
 func (m Map) EnsureCapacity(capacity int) {
-	if capacity <= cap(*m.orig) {
+	if capacity <= cap(*m.getOrig()) {
 		return
 	}
-	oldOrig := *m.orig
-	*m.orig = make([]otlpcommon.KeyValue, 0, capacity)
-	copy(*m.orig, oldOrig)
+	orig := m.getMutableOrig()
+	oldOrig := *orig
+	*orig = make([]otlpcommon.KeyValue, 0, capacity)
+	copy(*orig, oldOrig)
 }
 
 // Get returns the Value associated with the key and true. Returned
@@ -610,23 +639,27 @@ func (m Map) EnsureCapacity(capacity int) {
 // If the key does not exist returns an invalid instance of the KeyValue and false.
 // Calling any functions on the returned invalid instance will cause a panic.
 func (m Map) Get(key string) (Value, bool) {
-	for i := range *m.orig {
-		akv := &(*m.orig)[i]
+	orig := m.getOrig()
+	for i := range *orig {
+		akv := &(*orig)[i]
 		if akv.Key == key {
-			return Value{&akv.Value}, true
+			return Value{orig: &akv.Value, shared: m.shared}, true
 		}
 	}
-	return Value{nil}, false
+	return Value{}, false
 }
 
 // Remove removes the entry associated with the key and returns true if the key
 // was present in the map, otherwise returns false.
 func (m Map) Remove(key string) bool {
-	for i := range *m.orig {
-		akv := &(*m.orig)[i]
-		if akv.Key == key {
-			*akv = (*m.orig)[len(*m.orig)-1]
-			*m.orig = (*m.orig)[:len(*m.orig)-1]
+	for i := range *m.getOrig() {
+		if (*m.getOrig())[i].Key == key {
+			orig := m.getMutableOrig()
+			last := len(*orig) - 1
+			(*orig)[i] = (*orig)[last]
+			// Erase the moved entry to not keep a copy beyond the length of the map.
+			(*orig)[last] = otlpcommon.KeyValue{}
+			*orig = (*orig)[:last]
 			return true
 		}
 	}
@@ -636,9 +669,9 @@ func (m Map) Remove(key string) bool {
 // RemoveIf removes the entries for which the function in question returns true
 func (m Map) RemoveIf(f func(string, Value) bool) {
 	newLen := 0
-	for i := 0; i < len(*m.orig); i++ {
-		akv := &(*m.orig)[i]
-		if f(akv.Key, Value{&akv.Value}) {
+	for i := 0; i < m.Len(); i++ {
+		akv := &(*m.getOrig())[i]
+		if f(akv.Key, Value{orig: &akv.Value, shared: m.shared}) {
 			continue
 		}
 		if newLen == i {
@@ -646,10 +679,25 @@ func (m Map) RemoveIf(f func(string, Value) bool) {
 			newLen++
 			continue
 		}
-		(*m.orig)[newLen] = (*m.orig)[i]
+		orig := m.getMutableOrig()
+		(*orig)[newLen] = (*orig)[i]
 		newLen++
 	}
-	*m.orig = (*m.orig)[:newLen]
+	if newLen == m.Len() {
+		return
+	}
+	orig := m.getMutableOrig()
+	// Erase truncated entries to not keep copies beyond the length of the map.
+	for i := newLen; i < len(*orig); i++ {
+		(*orig)[i] = otlpcommon.KeyValue{}
+	}
+	*orig = (*orig)[:newLen]
+}
+
+// append adds the entry kv to the map.
+func (m Map) append(kv otlpcommon.KeyValue) {
+	orig := m.getMutableOrig()
+	*orig = append(*orig, kv)
 }
 
 // Insert adds the Value to the map when the key does not exist.
@@ -661,7 +709,7 @@ func (m Map) RemoveIf(f func(string, Value) bool) {
 // the raw value to avoid an extra allocation.
 func (m Map) Insert(k string, v Value) {
 	if _, existing := m.Get(k); !existing {
-		*m.orig = append(*m.orig, newAttributeKeyValue(k, v))
+		m.append(newAttributeKeyValue(k, v))
 	}
 }
 
@@ -669,7 +717,7 @@ func (m Map) Insert(k string, v Value) {
 // No action is applied to the map where the key already exists.
 func (m Map) InsertNull(k string) {
 	if _, existing := m.Get(k); !existing {
-		*m.orig = append(*m.orig, newAttributeKeyValueNull(k))
+		m.append(newAttributeKeyValueNull(k))
 	}
 }
 
@@ -677,7 +725,7 @@ func (m Map) InsertNull(k string) {
 // No action is applied to the map where the key already exists.
 func (m Map) InsertString(k string, v string) {
 	if _, existing := m.Get(k); !existing {
-		*m.orig = append(*m.orig, newAttributeKeyValueString(k, v))
+		m.append(newAttributeKeyValueString(k, v))
 	}
 }
 
@@ -685,7 +733,7 @@ func (m Map) InsertString(k string, v string) {
 // No action is applied to the map where the key already exists.
 func (m Map) InsertInt(k string, v int64) {
 	if _, existing := m.Get(k); !existing {
-		*m.orig = append(*m.orig, newAttributeKeyValueInt(k, v))
+		m.append(newAttributeKeyValueInt(k, v))
 	}
 }
 
@@ -693,7 +741,7 @@ func (m Map) InsertInt(k string, v int64) {
 // No action is applied to the map where the key already exists.
 func (m Map) InsertDouble(k string, v float64) {
 	if _, existing := m.Get(k); !existing {
-		*m.orig = append(*m.orig, newAttributeKeyValueDouble(k, v))
+		m.append(newAttributeKeyValueDouble(k, v))
 	}
 }
 
@@ -701,7 +749,7 @@ func (m Map) InsertDouble(k string, v float64) {
 // No action is applied to the map where the key already exists.
 func (m Map) InsertBool(k string, v bool) {
 	if _, existing := m.Get(k); !existing {
-		*m.orig = append(*m.orig, newAttributeKeyValueBool(k, v))
+		m.append(newAttributeKeyValueBool(k, v))
 	}
 }
 
@@ -711,7 +759,7 @@ func (m Map) InsertBool(k string, v bool) {
 // across multiple attributes is forbidden.
 func (m Map) InsertBytes(k string, v []byte) {
 	if _, existing := m.Get(k); !existing {
-		*m.orig = append(*m.orig, newAttributeKeyValueBytes(k, v))
+		m.append(newAttributeKeyValueBytes(k, v))
 	}
 }
 
@@ -724,7 +772,7 @@ func (m Map) InsertBytes(k string, v []byte) {
 // the raw value to avoid an extra allocation.
 func (m Map) Update(k string, v Value) {
 	if av, existing := m.Get(k); existing {
-		v.copyTo(av.orig)
+		v.copyTo(av.getMutableOrig())
 	}
 }
 
@@ -780,9 +828,9 @@ func (m Map) UpdateBytes(k string, v []byte) {
 // the raw value to avoid an extra allocation.
 func (m Map) Upsert(k string, v Value) {
 	if av, existing := m.Get(k); existing {
-		v.copyTo(av.orig)
+		v.copyTo(av.getMutableOrig())
 	} else {
-		*m.orig = append(*m.orig, newAttributeKeyValue(k, v))
+		m.append(newAttributeKeyValue(k, v))
 	}
 }
 
@@ -793,7 +841,7 @@ func (m Map) UpsertString(k string, v string) {
 	if av, existing := m.Get(k); existing {
 		av.SetStringVal(v)
 	} else {
-		*m.orig = append(*m.orig, newAttributeKeyValueString(k, v))
+		m.append(newAttributeKeyValueString(k, v))
 	}
 }
 
@@ -804,7 +852,7 @@ func (m Map) UpsertInt(k string, v int64) {
 	if av, existing := m.Get(k); existing {
 		av.SetIntVal(v)
 	} else {
-		*m.orig = append(*m.orig, newAttributeKeyValueInt(k, v))
+		m.append(newAttributeKeyValueInt(k, v))
 	}
 }
 
@@ -815,7 +863,7 @@ func (m Map) UpsertDouble(k string, v float64) {
 	if av, existing := m.Get(k); existing {
 		av.SetDoubleVal(v)
 	} else {
-		*m.orig = append(*m.orig, newAttributeKeyValueDouble(k, v))
+		m.append(newAttributeKeyValueDouble(k, v))
 	}
 }
 
@@ -826,7 +874,7 @@ func (m Map) UpsertBool(k string, v bool) {
 	if av, existing := m.Get(k); existing {
 		av.SetBoolVal(v)
 	} else {
-		*m.orig = append(*m.orig, newAttributeKeyValueBool(k, v))
+		m.append(newAttributeKeyValueBool(k, v))
 	}
 }
 
@@ -839,7 +887,7 @@ func (m Map) UpsertBytes(k string, v []byte) {
 	if av, existing := m.Get(k); existing {
 		av.SetBytesVal(v)
 	} else {
-		*m.orig = append(*m.orig, newAttributeKeyValueBytes(k, v))
+		m.append(newAttributeKeyValueBytes(k, v))
 	}
 }
 
@@ -848,8 +896,9 @@ func (m Map) UpsertBytes(k string, v []byte) {
 //   assert.EqualValues(t, expected.Sort(), actual.Sort())
 func (m Map) Sort() Map {
 	// Intention is to move the nil values at the end.
-	sort.SliceStable(*m.orig, func(i, j int) bool {
-		return (*m.orig)[i].Key < (*m.orig)[j].Key
+	orig := m.getMutableOrig()
+	sort.SliceStable(*orig, func(i, j int) bool {
+		return (*orig)[i].Key < (*orig)[j].Key
 	})
 	return m
 }
@@ -859,7 +908,7 @@ func (m Map) Sort() Map {
 // Because the Map is represented internally by a slice of pointers, and the data are comping from the wire,
 // it is possible that when iterating using "Range" to get access to fewer elements because nil elements are skipped.
 func (m Map) Len() int {
-	return len(*m.orig)
+	return len(*m.getOrig())
 }
 
 // Range calls f sequentially for each key and value present in the map. If f returns false, range stops the iteration.
@@ -870,9 +919,10 @@ func (m Map) Len() int {
 //       ...
 //   })
 func (m Map) Range(f func(k string, v Value) bool) {
-	for i := range *m.orig {
-		kv := &(*m.orig)[i]
-		if !f(kv.Key, Value{&kv.Value}) {
+	orig := m.getOrig()
+	for i := range *orig {
+		kv := &(*orig)[i]
+		if !f(kv.Key, Value{orig: &kv.Value, shared: m.shared}) {
 			break
 		}
 	}
@@ -880,28 +930,30 @@ func (m Map) Range(f func(k string, v Value) bool) {
 
 // CopyTo copies all elements from the current map to the dest.
 func (m Map) CopyTo(dest Map) {
-	newLen := len(*m.orig)
-	oldCap := cap(*dest.orig)
+	destOrig := dest.getMutableOrig()
+	orig := m.getOrig()
+	newLen := len(*orig)
+	oldCap := cap(*destOrig)
 	if newLen <= oldCap {
 		// New slice fits in existing slice, no need to reallocate.
-		*dest.orig = (*dest.orig)[:newLen:oldCap]
-		for i := range *m.orig {
-			akv := &(*m.orig)[i]
-			destAkv := &(*dest.orig)[i]
+		*destOrig = (*destOrig)[:newLen:oldCap]
+		for i := range *orig {
+			akv := &(*orig)[i]
+			destAkv := &(*destOrig)[i]
 			destAkv.Key = akv.Key
-			Value{&akv.Value}.copyTo(&destAkv.Value)
+			newValue(&akv.Value).copyTo(&destAkv.Value)
 		}
 		return
 	}
 
 	// New slice is bigger than exist slice. Allocate new space.
-	origs := make([]otlpcommon.KeyValue, len(*m.orig))
-	for i := range *m.orig {
-		akv := &(*m.orig)[i]
+	origs := make([]otlpcommon.KeyValue, len(*orig))
+	for i := range *orig {
+		akv := &(*orig)[i]
 		origs[i].Key = akv.Key
-		Value{&akv.Value}.copyTo(&origs[i].Value)
+		newValue(&akv.Value).copyTo(&origs[i].Value)
 	}
-	*dest.orig = origs
+	*destOrig = origs
 }
 
 // AsRaw converts an OTLP Map to a standard go map
@@ -918,13 +970,13 @@ func (m Map) AsRaw() map[string]interface{} {
 func newSliceFromRaw(rawSlice []interface{}) Slice {
 	if len(rawSlice) == 0 {
 		v := []otlpcommon.AnyValue(nil)
-		return Slice{&v}
+		return Slice{orig: &v}
 	}
 	origs := make([]otlpcommon.AnyValue, len(rawSlice))
 	for ix, iv := range rawSlice {
 		newValueFromRaw(iv).copyTo(&origs[ix])
 	}
-	return Slice{&origs}
+	return Slice{orig: &origs}
 }
 
 // asRaw creates a slice out of a Slice.
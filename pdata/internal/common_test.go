@@ -271,7 +271,7 @@ func TestNilMap(t *testing.T) {
 
 	val, exist := NewMap().Get("test_key")
 	assert.False(t, exist)
-	assert.EqualValues(t, Value{nil}, val)
+	assert.EqualValues(t, Value{}, val)
 
 	insertMap := NewMap()
 	insertMap.Insert("k", NewValueString("v"))
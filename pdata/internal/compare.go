@@ -0,0 +1,334 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/internal/data"
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+)
+
+var (
+	traceIDType       = reflect.TypeOf(data.TraceID{})
+	spanIDType        = reflect.TypeOf(data.SpanID{})
+	bytesType         = reflect.TypeOf([]byte(nil))
+	anyValueType      = reflect.TypeOf(otlpcommon.AnyValue{})
+	keyValueSliceType = reflect.TypeOf([]otlpcommon.KeyValue(nil))
+	anyValueSliceType = reflect.TypeOf([]otlpcommon.AnyValue(nil))
+)
+
+// message is implemented by the generated protobuf messages.
+type message interface {
+	Marshal() ([]byte, error)
+}
+
+// differ compares the generated protobuf messages field by field.
+type differ struct {
+	// first, if true, stops the comparison at the first difference.
+	first bool
+	diffs []string
+}
+
+// equalOrigs returns true if a and b, pointers to messages of the same type, are equal.
+func equalOrigs(a, b interface{}) bool {
+	d := differ{first: true}
+	d.compare("", reflect.ValueOf(a), reflect.ValueOf(b))
+	return len(d.diffs) == 0
+}
+
+// diffOrigs returns the differences between a and b, pointers to messages of the same type,
+// one per line, or an empty string if they are equal.
+func diffOrigs(a, b interface{}) string {
+	d := differ{}
+	d.compare("", reflect.ValueOf(a), reflect.ValueOf(b))
+	return strings.Join(d.diffs, "\n")
+}
+
+func (d *differ) report(path string, a, b string) {
+	path = strings.TrimPrefix(path, ".")
+	if path == "" {
+		path = "."
+	}
+	d.diffs = append(d.diffs, path+": "+a+" != "+b)
+}
+
+func (d *differ) compare(path string, a, b reflect.Value) {
+	if d.first && len(d.diffs) > 0 {
+		return
+	}
+	switch a.Type() {
+	case traceIDType:
+		if !a.Interface().(data.TraceID).Equal(b.Interface().(data.TraceID)) {
+			d.report(path, formatValue(a), formatValue(b))
+		}
+		return
+	case spanIDType:
+		if !a.Interface().(data.SpanID).Equal(b.Interface().(data.SpanID)) {
+			d.report(path, formatValue(a), formatValue(b))
+		}
+		return
+	case bytesType:
+		if !bytes.Equal(a.Bytes(), b.Bytes()) {
+			d.report(path, formatValue(a), formatValue(b))
+		}
+		return
+	case anyValueType:
+		if anyValueOf(a).Type() != anyValueOf(b).Type() {
+			d.report(path, formatValue(a), formatValue(b))
+			return
+		}
+	case keyValueSliceType:
+		d.compareKeyValues(path, a.Interface().([]otlpcommon.KeyValue), b.Interface().([]otlpcommon.KeyValue))
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.report(path, formatValue(a), formatValue(b))
+			}
+			return
+		}
+		// The oneof fields hold different types for different cases.
+		if a.Elem().Type() != b.Elem().Type() {
+			d.report(path, formatValue(a), formatValue(b))
+			return
+		}
+		d.compare(path, a.Elem(), b.Elem())
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			f := a.Type().Field(i)
+			if f.PkgPath != "" || strings.HasPrefix(f.Name, "XXX_") {
+				continue
+			}
+			if _, ok := f.Tag.Lookup("protobuf_oneof"); ok {
+				// The name of the field held by the oneof is added by its wrapper.
+				d.compare(path, a.Field(i), b.Field(i))
+				continue
+			}
+			d.compare(path+"."+fieldName(f), a.Field(i), b.Field(i))
+		}
+	case reflect.Slice:
+		n := a.Len()
+		if b.Len() < n {
+			n = b.Len()
+		}
+		for i := 0; i < n; i++ {
+			d.compare(path+"["+strconv.Itoa(i)+"]", a.Index(i), b.Index(i))
+		}
+		for i := n; i < a.Len(); i++ {
+			d.report(path+"["+strconv.Itoa(i)+"]", formatValue(a.Index(i)), "<none>")
+		}
+		for i := n; i < b.Len(); i++ {
+			d.report(path+"["+strconv.Itoa(i)+"]", "<none>", formatValue(b.Index(i)))
+		}
+	case reflect.Float32, reflect.Float64:
+		// Compare the bits, as the encoding does, so that NaN is equal to itself.
+		if math.Float64bits(a.Float()) != math.Float64bits(b.Float()) {
+			d.report(path, formatValue(a), formatValue(b))
+		}
+	default:
+		if a.Interface() != b.Interface() {
+			d.report(path, formatValue(a), formatValue(b))
+		}
+	}
+}
+
+// compareKeyValues compares the attributes by key, regardless of their order.
+func (d *differ) compareKeyValues(path string, a, b []otlpcommon.KeyValue) {
+	bIndex := make(map[string]int, len(b))
+	for i := range b {
+		bIndex[b[i].Key] = i
+	}
+	aKeys := make(map[string]struct{}, len(a))
+	for i := range a {
+		aKeys[a[i].Key] = struct{}{}
+		kvPath := path + "[" + strconv.Quote(a[i].Key) + "]"
+		j, ok := bIndex[a[i].Key]
+		if !ok {
+			d.report(kvPath, formatValue(reflect.ValueOf(a[i].Value)), "<none>")
+			continue
+		}
+		d.compare(kvPath, reflect.ValueOf(a[i].Value), reflect.ValueOf(b[j].Value))
+	}
+	for i := range b {
+		if _, ok := aKeys[b[i].Key]; !ok {
+			d.report(path+"["+strconv.Quote(b[i].Key)+"]", "<none>", formatValue(reflect.ValueOf(b[i].Value)))
+		}
+	}
+}
+
+// fieldName returns the name of the field in the OTLP/JSON encoding.
+func fieldName(f reflect.StructField) string {
+	for _, opt := range strings.Split(f.Tag.Get("protobuf"), ",") {
+		if strings.HasPrefix(opt, "json=") {
+			return strings.TrimPrefix(opt, "json=")
+		}
+	}
+	for _, opt := range strings.Split(f.Tag.Get("protobuf"), ",") {
+		if strings.HasPrefix(opt, "name=") {
+			return strings.TrimPrefix(opt, "name=")
+		}
+	}
+	return f.Name
+}
+
+// formatValue formats v with the field names of the OTLP/JSON encoding, omitting the empty fields.
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "<none>"
+	}
+	switch v.Type() {
+	case traceIDType:
+		return v.Interface().(data.TraceID).HexString()
+	case spanIDType:
+		return v.Interface().(data.SpanID).HexString()
+	case bytesType:
+		return hex.EncodeToString(v.Bytes())
+	case anyValueType:
+		av := anyValueOf(v)
+		if av.Type() == ValueTypeString {
+			return av.Type().String() + "(" + strconv.Quote(av.AsString()) + ")"
+		}
+		return av.Type().String() + "(" + av.AsString() + ")"
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return "<nil>"
+		}
+		return formatValue(v.Elem())
+	case reflect.Struct:
+		var fields []string
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if f.PkgPath != "" || strings.HasPrefix(f.Name, "XXX_") || v.Field(i).IsZero() {
+				continue
+			}
+			if _, ok := f.Tag.Lookup("protobuf_oneof"); ok {
+				// The oneof wrapper formats the name of the field it holds.
+				fields = append(fields, strings.TrimSuffix(strings.TrimPrefix(formatValue(v.Field(i)), "{"), "}"))
+				continue
+			}
+			fields = append(fields, fieldName(f)+":"+formatValue(v.Field(i)))
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	case reflect.Slice:
+		els := make([]string, v.Len())
+		for i := range els {
+			els[i] = formatValue(v.Index(i))
+		}
+		return "[" + strings.Join(els, ", ") + "]"
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	case reflect.String:
+		return strconv.Quote(v.String())
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+// anyValueOf returns the Value of v, holding an otlpcommon.AnyValue.
+func anyValueOf(v reflect.Value) Value {
+	av := v.Interface().(otlpcommon.AnyValue)
+	return newValue(&av)
+}
+
+// canonicalizeOrig sorts, in place, the repeated fields of orig whose order does not matter.
+func canonicalizeOrig(orig interface{}) {
+	canonicalize(reflect.ValueOf(orig))
+}
+
+// canonicalize sorts, in place, the repeated fields of the message pointed by v whose order
+// does not matter: the attributes and all the repeated messages but the array values.
+func canonicalize(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			canonicalize(v.Elem())
+		}
+	case reflect.Struct:
+		if v.Type() == traceIDType || v.Type() == spanIDType {
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Type().Field(i); f.PkgPath == "" && !strings.HasPrefix(f.Name, "XXX_") {
+				canonicalize(v.Field(i))
+			}
+		}
+	case reflect.Slice:
+		elemKind := v.Type().Elem().Kind()
+		if elemKind != reflect.Ptr && elemKind != reflect.Struct {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			canonicalize(v.Index(i))
+		}
+		if v.Type() == anyValueSliceType {
+			return
+		}
+		keys := make([][]byte, v.Len())
+		for i := range keys {
+			el := v.Index(i)
+			if elemKind == reflect.Struct {
+				el = el.Addr()
+			}
+			keys[i] = marshalMessage(el.Interface().(message))
+		}
+		sort.Sort(&canonicalSorter{keys: keys, swap: reflect.Swapper(v.Interface())})
+	}
+}
+
+// canonicalSorter sorts a slice by the encoding of its elements.
+type canonicalSorter struct {
+	keys [][]byte
+	swap func(i, j int)
+}
+
+func (s *canonicalSorter) Len() int {
+	return len(s.keys)
+}
+
+func (s *canonicalSorter) Less(i, j int) bool {
+	return bytes.Compare(s.keys[i], s.keys[j]) < 0
+}
+
+func (s *canonicalSorter) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.swap(i, j)
+}
+
+func marshalMessage(m message) []byte {
+	// The generated messages never fail to marshal.
+	buf, _ := m.Marshal()
+	return buf
+}
+
+// hashMessage returns the hash of the canonical encoding of m, that it sorts in place.
+func hashMessage(m message) uint64 {
+	canonicalize(reflect.ValueOf(m))
+	h := fnv.New64a()
+	_, _ = h.Write(marshalMessage(m))
+	return h.Sum64()
+}
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracesEqual(t *testing.T) {
+	td := NewTraces()
+	generateTestResourceSpansSlice().CopyTo(td.ResourceSpans())
+	other := td.Clone()
+	assert.True(t, td.Equal(other))
+	assert.Empty(t, td.Diff(other))
+	assert.True(t, NewTraces().Equal(NewTraces()))
+	assert.False(t, td.Equal(NewTraces()))
+
+	span := other.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	span.SetName("changed")
+	span.SetTraceID(NewTraceID([16]byte{1}))
+	span.Attributes().UpsertInt("added", 1)
+	other.ResourceSpans().At(1).Resource().Attributes().Remove("k")
+	other.ResourceSpans().At(1).ScopeSpans().AppendEmpty()
+	assert.False(t, td.Equal(other))
+	assert.Equal(t, `resourceSpans[0].scopeSpans[0].spans[0].traceId: 01020304050607080807060504030201 != 01000000000000000000000000000000
+resourceSpans[0].scopeSpans[0].spans[0].name: "test_name" != "changed"
+resourceSpans[0].scopeSpans[0].spans[0].attributes["added"]: <none> != INT(1)
+resourceSpans[1].resource.attributes["k"]: STRING("v") != <none>
+resourceSpans[1].scopeSpans[7]: <none> != {}`, td.Diff(other))
+}
+
+func TestTracesEqualEncoded(t *testing.T) {
+	td, _ := encodedTraces(t)
+	other, _ := encodedTraces(t)
+	assert.True(t, td.Equal(other))
+	assert.True(t, td.EqualIgnoringOrder(other))
+	other.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).SetName("span3")
+	assert.Equal(t, `resourceSpans[0].scopeSpans[0].spans[1].name: "span2" != "span3"`, td.Diff(other))
+}
+
+func TestTracesEqualIgnoringOrder(t *testing.T) {
+	td := NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("a", "1")
+	rs.Resource().Attributes().InsertString("b", "2")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetName("span1")
+	spans.AppendEmpty().SetName("span2")
+	spans.At(1).Events().AppendEmpty().SetName("event1")
+	spans.At(1).Events().AppendEmpty().SetName("event2")
+	av := NewValueSlice()
+	av.SliceVal().AppendEmpty().SetStringVal("v1")
+	av.SliceVal().AppendEmpty().SetStringVal("v2")
+	spans.At(0).Attributes().Upsert("array", av)
+	td.ResourceSpans().AppendEmpty().Resource().Attributes().InsertString("c", "3")
+
+	other := NewTraces()
+	td.ResourceSpans().At(1).CopyTo(other.ResourceSpans().AppendEmpty())
+	rs = other.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("b", "2")
+	rs.Resource().Attributes().InsertString("a", "1")
+	spans = rs.ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetName("span2")
+	spans.AppendEmpty().SetName("span1")
+	spans.At(0).Events().AppendEmpty().SetName("event2")
+	spans.At(0).Events().AppendEmpty().SetName("event1")
+	spans.At(1).Attributes().Upsert("array", av)
+	arrVal, _ := spans.At(1).Attributes().Get("array")
+	arr := arrVal.SliceVal()
+
+	assert.False(t, td.Equal(other))
+	assert.True(t, td.EqualIgnoringOrder(other))
+	assert.Empty(t, td.DiffIgnoringOrder(other))
+	// The inputs are not modified.
+	assert.Equal(t, "span2", spans.At(0).Name())
+
+	// The order of the array values matters.
+	arr.At(0).SetStringVal("v2")
+	arr.At(1).SetStringVal("v1")
+	assert.False(t, td.EqualIgnoringOrder(other))
+	assert.NotEmpty(t, td.DiffIgnoringOrder(other))
+}
+
+func TestMetricsEqual(t *testing.T) {
+	md := NewMetrics()
+	generateTestResourceMetricsSlice().CopyTo(md.ResourceMetrics())
+	other := md.Clone()
+	assert.True(t, md.Equal(other))
+	assert.True(t, md.EqualIgnoringOrder(other))
+
+	other.ResourceMetrics().At(0).ScopeMetrics().AppendEmpty().CopyTo(other.ResourceMetrics().At(0).ScopeMetrics().At(0))
+	assert.False(t, md.Equal(other))
+	assert.False(t, md.EqualIgnoringOrder(other))
+
+	md.ResourceMetrics().At(0).Resource().Attributes().InsertBool("first", true)
+	other = NewMetrics()
+	for i := md.ResourceMetrics().Len() - 1; i >= 0; i-- {
+		md.ResourceMetrics().At(i).CopyTo(other.ResourceMetrics().AppendEmpty())
+	}
+	assert.False(t, md.Equal(other))
+	assert.True(t, md.EqualIgnoringOrder(other))
+
+	// NaN values are equal to themselves.
+	md = NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetDataType(MetricDataTypeGauge)
+	m.Gauge().DataPoints().AppendEmpty().SetDoubleVal(math.NaN())
+	assert.True(t, md.Equal(md.Clone()))
+	other = md.Clone()
+	other.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).SetDataType(MetricDataTypeSum)
+	assert.Equal(t, `resourceMetrics[0].scopeMetrics[0].metrics[0]: {gauge:{dataPoints:[{asDouble:NaN}]}} != {sum:{}}`, md.Diff(other))
+}
+
+func TestLogsEqual(t *testing.T) {
+	ld := NewLogs()
+	generateTestResourceLogsSlice().CopyTo(ld.ResourceLogs())
+	other := ld.Clone()
+	assert.True(t, ld.Equal(other))
+	assert.True(t, ld.EqualIgnoringOrder(other))
+
+	other.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().SetIntVal(1)
+	assert.False(t, ld.Equal(other))
+	assert.False(t, ld.EqualIgnoringOrder(other))
+	assert.Equal(t, `resourceLogs[0].scopeLogs[0].logRecords[0].body: STRING("v") != INT(1)`, ld.Diff(other))
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// EncodedOrig holds the protobuf bytes of a received OTLP request until they are decoded into
+// the orig struct, the first time the content of the request is accessed. The bytes are kept
+// for the whole request only: once decoded, the request is marshaled again in full.
+// The zero value holds nothing to decode, a nil *EncodedOrig can be used as well.
+type EncodedOrig struct {
+	mu sync.Mutex
+	// pending is 1 while buf was not decoded, it is read without locking on the fast path.
+	pending uint32
+	buf     []byte
+}
+
+// Set stores the bytes to decode later, the orig struct they belong to must be empty.
+// The bytes must be valid and must not be modified afterwards.
+func (e *EncodedOrig) Set(buf []byte) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.buf = buf
+	atomic.StoreUint32(&e.pending, 1)
+}
+
+// Bytes returns the stored bytes, if they were not decoded yet.
+func (e *EncodedOrig) Bytes() ([]byte, bool) {
+	if !e.isPending() {
+		return nil, false
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.buf, e.pending == 1
+}
+
+func (e *EncodedOrig) isPending() bool {
+	return e != nil && atomic.LoadUint32(&e.pending) == 1
+}
+
+// decode calls unmarshal with the stored bytes, unless they were already decoded.
+func (e *EncodedOrig) decode(unmarshal func([]byte) error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.pending == 0 {
+		return
+	}
+	if err := unmarshal(e.buf); err != nil {
+		// The bytes are validated before being stored, this is a bug in the validation.
+		panic("pdata: cannot decode validated OTLP request: " + err.Error())
+	}
+	e.buf = nil
+	atomic.StoreUint32(&e.pending, 0)
+}
+
+// Discard drops the stored bytes without decoding them, when the orig struct is overwritten.
+func (e *EncodedOrig) Discard() {
+	if !e.isPending() {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.buf = nil
+	atomic.StoreUint32(&e.pending, 0)
+}
+
+// clone returns an EncodedOrig holding the same bytes, if they were not decoded yet.
+func (e *EncodedOrig) clone() (*EncodedOrig, bool) {
+	buf, ok := e.Bytes()
+	if !ok {
+		return nil, false
+	}
+	return &EncodedOrig{pending: 1, buf: buf}, true
+}
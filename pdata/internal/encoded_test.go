@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	otlpcollectorlog "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
+	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
+	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
+)
+
+func newEncodedOrig(buf []byte) *EncodedOrig {
+	encoded := &EncodedOrig{}
+	encoded.Set(buf)
+	return encoded
+}
+
+func encodedTraces(t *testing.T) (Traces, []byte) {
+	td := NewTraces()
+	ss := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	ss.Spans().AppendEmpty().SetName("span1")
+	ss.Spans().AppendEmpty().SetName("span2")
+	buf, err := td.orig.Marshal()
+	require.NoError(t, err)
+	return TracesFromEncodedOtlp(&otlpcollectortrace.ExportTraceServiceRequest{}, newEncodedOrig(buf), &SharedResourceSpans{}), buf
+}
+
+func TestEncodedOrig(t *testing.T) {
+	var nilEncoded *EncodedOrig
+	_, ok := nilEncoded.Bytes()
+	assert.False(t, ok)
+	nilEncoded.Discard()
+
+	encoded := &EncodedOrig{}
+	_, ok = encoded.Bytes()
+	assert.False(t, ok)
+	encoded.Set([]byte{1})
+	buf, ok := encoded.Bytes()
+	assert.True(t, ok)
+	assert.Equal(t, []byte{1}, buf)
+	encoded.Discard()
+	_, ok = encoded.Bytes()
+	assert.False(t, ok)
+
+	encoded.Set([]byte{1})
+	assert.Panics(t, func() {
+		encoded.decode((&otlpcollectortrace.ExportTraceServiceRequest{}).Unmarshal)
+	})
+}
+
+func TestTracesFromEncodedOtlp(t *testing.T) {
+	orig := &otlpcollectortrace.ExportTraceServiceRequest{}
+	assert.Equal(t, Traces{orig: orig}, TracesFromEncodedOtlp(orig, nil, nil))
+	assert.Equal(t, Traces{orig: orig}, TracesFromEncodedOtlp(orig, &EncodedOrig{}, nil))
+
+	td, buf := encodedTraces(t)
+	assert.Equal(t, 2, td.SpanCount())
+	gotOrig, encoded, _ := TracesToEncodedOtlp(td)
+	assert.Empty(t, gotOrig.ResourceSpans)
+	gotBuf, ok := encoded.Bytes()
+	assert.True(t, ok)
+	assert.Equal(t, buf, gotBuf)
+
+	assert.Equal(t, "span2", td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Name())
+	_, ok = encoded.Bytes()
+	assert.False(t, ok)
+	assert.Equal(t, 2, td.SpanCount())
+}
+
+func TestEncodedTracesClone(t *testing.T) {
+	td, buf := encodedTraces(t)
+	clone := td.Clone()
+	_, encoded, _ := TracesToEncodedOtlp(clone)
+	gotBuf, ok := encoded.Bytes()
+	assert.True(t, ok)
+	assert.Equal(t, buf, gotBuf)
+
+	td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("changed")
+	assert.Equal(t, "span1", clone.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+	assert.Equal(t, "changed", td.Clone().ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func TestEncodedTracesMoveTo(t *testing.T) {
+	td, _ := encodedTraces(t)
+	dest := NewTraces()
+	td.MoveTo(dest)
+	assert.Equal(t, 2, dest.SpanCount())
+	assert.Equal(t, 0, td.SpanCount())
+
+	src := NewTraces()
+	src.ResourceSpans().AppendEmpty()
+	dest, _ = encodedTraces(t)
+	src.MoveTo(dest)
+	assert.Equal(t, 1, dest.ResourceSpans().Len())
+	assert.Equal(t, 0, dest.SpanCount())
+}
+
+func TestEncodedTracesConcurrentAccess(t *testing.T) {
+	td, _ := encodedTraces(t)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, 2, td.SpanCount())
+			assert.Equal(t, 1, td.ResourceSpans().Len())
+			assert.Equal(t, 2, td.Clone().SpanCount())
+		}()
+	}
+	wg.Wait()
+}
+
+func TestEncodedMetrics(t *testing.T) {
+	md := NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetDataType(MetricDataTypeGauge)
+	m.Gauge().DataPoints().AppendEmpty()
+	m.Gauge().DataPoints().AppendEmpty()
+	buf, err := md.orig.Marshal()
+	require.NoError(t, err)
+
+	md = MetricsFromEncodedOtlp(&otlpcollectormetrics.ExportMetricsServiceRequest{}, newEncodedOrig(buf), nil)
+	assert.Equal(t, 1, md.MetricCount())
+	assert.Equal(t, 2, md.DataPointCount())
+	clone := md.Clone()
+	dest := NewMetrics()
+	md.MoveTo(dest)
+	assert.Equal(t, 2, dest.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().Len())
+	assert.Equal(t, 0, md.DataPointCount())
+	assert.Equal(t, dest.ResourceMetrics(), clone.ResourceMetrics())
+}
+
+func TestEncodedLogs(t *testing.T) {
+	ld := NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	buf, err := ld.orig.Marshal()
+	require.NoError(t, err)
+
+	ld = LogsFromEncodedOtlp(&otlpcollectorlog.ExportLogsServiceRequest{}, newEncodedOrig(buf), nil)
+	assert.Equal(t, 1, ld.LogRecordCount())
+	clone := ld.Clone()
+	dest := NewLogs()
+	ld.MoveTo(dest)
+	assert.Equal(t, 1, dest.LogRecordCount())
+	assert.Equal(t, 0, ld.LogRecordCount())
+	assert.Equal(t, dest.ResourceLogs(), clone.ResourceLogs())
+}
@@ -0,0 +1,409 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+import (
+	"math"
+
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+)
+
+const (
+	// defaultExponentialHistogramMaxSize is the number of buckets used when no maximum is given,
+	// the default of the OpenTelemetry SDKs.
+	defaultExponentialHistogramMaxSize = 160
+	// maxExponentialHistogramScale is the scale at which values are converted before downscaling them.
+	maxExponentialHistogramScale = 20
+	// minExponentialHistogramScale is the lowest scale histograms are downscaled to,
+	// at which any float64 fits in 3 buckets.
+	minExponentialHistogramScale = -10
+)
+
+func exponentialHistogramMaxSize(maxSize int) int {
+	if maxSize <= 0 {
+		return defaultExponentialHistogramMaxSize
+	}
+	return maxSize
+}
+
+// bucketIndex returns the index at the given scale of the bucket holding v, that must be positive and finite.
+func bucketIndex(v float64, scale int32) int32 {
+	frac, exp := math.Frexp(v)
+	// The upper boundary of a bucket is inclusive, so exact powers of two belong to the bucket below.
+	powerOfTwo := frac == 0.5
+	if scale <= 0 {
+		// v is in (2^e, 2^(e+1)].
+		e := int32(exp) - 1
+		if powerOfTwo {
+			e--
+		}
+		return e >> -scale
+	}
+	if powerOfTwo {
+		return (int32(exp-1) << scale) - 1
+	}
+	return int32(math.Ceil(math.Log(v)*math.Ldexp(math.Log2E, int(scale)))) - 1
+}
+
+// bucketLowerBound returns the lower boundary at the given scale of the bucket at index.
+func bucketLowerBound(index, scale int32) float64 {
+	if scale <= 0 {
+		return math.Ldexp(1, int(index)<<-scale)
+	}
+	return math.Exp2(math.Ldexp(float64(index), -int(scale)))
+}
+
+// scaleChange returns by how much the scale must be reduced for the buckets from index low to high
+// to fit in maxSize buckets, without going below minExponentialHistogramScale.
+func scaleChange(low, high int32, maxSize int, scale int32) int32 {
+	change := int32(0)
+	for int(high>>change)-int(low>>change)+1 > maxSize && scale-change > minExponentialHistogramScale {
+		change++
+	}
+	return change
+}
+
+// bucketsRange returns the range of indexes of the buckets, shifted by change, and false if there are none.
+func bucketsRange(b *otlpmetrics.ExponentialHistogramDataPoint_Buckets, change int32) (int32, int32, bool) {
+	if len(b.BucketCounts) == 0 {
+		return 0, 0, false
+	}
+	return b.Offset >> change, (b.Offset + int32(len(b.BucketCounts)) - 1) >> change, true
+}
+
+// downscaleBuckets reduces the scale of the buckets by change, each bucket being merged with its 2^change - 1
+// neighbours. The bucket counts are replaced rather than modified, as they may be shared.
+func downscaleBuckets(b *otlpmetrics.ExponentialHistogramDataPoint_Buckets, change int32) {
+	low, high, ok := bucketsRange(b, change)
+	if change == 0 || !ok {
+		b.Offset >>= change
+		return
+	}
+	counts := make([]uint64, high-low+1)
+	for i, c := range b.BucketCounts {
+		counts[(b.Offset+int32(i))>>change-low] += c
+	}
+	b.Offset = low
+	b.BucketCounts = counts
+}
+
+// addBuckets adds the counts of src to dest, both at the same scale.
+func addBuckets(dest, src *otlpmetrics.ExponentialHistogramDataPoint_Buckets) {
+	srcLow, srcHigh, ok := bucketsRange(src, 0)
+	if !ok {
+		return
+	}
+	low, high, ok := bucketsRange(dest, 0)
+	if !ok {
+		dest.Offset = src.Offset
+		dest.BucketCounts = append([]uint64(nil), src.BucketCounts...)
+		return
+	}
+	if srcLow < low {
+		low = srcLow
+	}
+	if srcHigh > high {
+		high = srcHigh
+	}
+	counts := make([]uint64, high-low+1)
+	for i, c := range dest.BucketCounts {
+		counts[dest.Offset+int32(i)-low] += c
+	}
+	for i, c := range src.BucketCounts {
+		counts[src.Offset+int32(i)-low] += c
+	}
+	dest.Offset = low
+	dest.BucketCounts = counts
+}
+
+// Downscale reduces the scale of ms, merging its buckets, until its positive and its negative buckets
+// each fit in maxSize buckets, or 160 buckets if maxSize is not positive. The scale is not reduced
+// below -10, at which any range of values fits in 3 buckets.
+func (ms ExponentialHistogramDataPoint) Downscale(maxSize int) {
+	maxSize = exponentialHistogramMaxSize(maxSize)
+	orig := ms.getOrig()
+	change := int32(0)
+	for _, b := range []*otlpmetrics.ExponentialHistogramDataPoint_Buckets{&orig.Positive, &orig.Negative} {
+		if low, high, ok := bucketsRange(b, 0); ok {
+			if c := scaleChange(low, high, maxSize, orig.Scale); c > change {
+				change = c
+			}
+		}
+	}
+	ms.downscale(change)
+}
+
+func (ms ExponentialHistogramDataPoint) downscale(change int32) {
+	if change == 0 {
+		return
+	}
+	orig := ms.getMutableOrig()
+	downscaleBuckets(&orig.Positive, change)
+	downscaleBuckets(&orig.Negative, change)
+	orig.Scale -= change
+}
+
+// Merge adds the values of other to ms, at the highest scale at which the positive and the negative
+// buckets of both fit in maxSize buckets, or 160 buckets if maxSize is not positive, downscaling ms
+// if needed. The time range of ms is extended to the one of other and the exemplars of other are
+// appended to the ones of ms, but the attributes and flags of ms are unchanged. other is not modified.
+func (ms ExponentialHistogramDataPoint) Merge(other ExponentialHistogramDataPoint, maxSize int) {
+	maxSize = exponentialHistogramMaxSize(maxSize)
+	orig := ms.getMutableOrig()
+	src := *other.getOrig()
+	scale := orig.Scale
+	if src.Scale < scale {
+		scale = src.Scale
+	}
+	change := int32(0)
+	for _, pair := range [][2]*otlpmetrics.ExponentialHistogramDataPoint_Buckets{
+		{&orig.Positive, &src.Positive},
+		{&orig.Negative, &src.Negative},
+	} {
+		low, high, ok := bucketsRange(pair[0], orig.Scale-scale)
+		srcLow, srcHigh, srcOk := bucketsRange(pair[1], src.Scale-scale)
+		switch {
+		case !srcOk:
+		case !ok:
+			low, high, ok = srcLow, srcHigh, true
+		default:
+			if srcLow < low {
+				low = srcLow
+			}
+			if srcHigh > high {
+				high = srcHigh
+			}
+		}
+		if ok {
+			if c := scaleChange(low, high, maxSize, scale); c > change {
+				change = c
+			}
+		}
+	}
+
+	ms.downscale(orig.Scale - scale + change)
+	downscaleBuckets(&src.Positive, src.Scale-scale+change)
+	downscaleBuckets(&src.Negative, src.Scale-scale+change)
+	addBuckets(&orig.Positive, &src.Positive)
+	addBuckets(&orig.Negative, &src.Negative)
+	orig.Count += src.Count
+	orig.Sum += src.Sum
+	orig.ZeroCount += src.ZeroCount
+	if src.StartTimeUnixNano != 0 && (orig.StartTimeUnixNano == 0 || src.StartTimeUnixNano < orig.StartTimeUnixNano) {
+		orig.StartTimeUnixNano = src.StartTimeUnixNano
+	}
+	if src.TimeUnixNano > orig.TimeUnixNano {
+		orig.TimeUnixNano = src.TimeUnixNano
+	}
+	exemplars := newExemplarSlice(&src.Exemplars)
+	for i, n := 0, exemplars.Len(); i < n; i++ {
+		exemplars.At(i).CopyTo(ms.Exemplars().AppendEmpty())
+	}
+}
+
+// Quantile returns an estimate of the q-quantile of the values of ms, q being between 0 and 1,
+// interpolating linearly within the bucket holding it. It returns NaN if ms holds no values or
+// if q is out of range.
+func (ms ExponentialHistogramDataPoint) Quantile(q float64) float64 {
+	orig := ms.getOrig()
+	total := orig.ZeroCount
+	for _, c := range orig.Positive.BucketCounts {
+		total += c
+	}
+	for _, c := range orig.Negative.BucketCounts {
+		total += c
+	}
+	if total == 0 || !(q >= 0 && q <= 1) {
+		return math.NaN()
+	}
+
+	rank := q * float64(total)
+	seen := 0.0
+	// find returns the estimate if it is in the bucket from lower to upper holding count values.
+	find := func(count uint64, lower, upper float64) (float64, bool) {
+		if count == 0 {
+			return 0, false
+		}
+		if seen+float64(count) >= rank {
+			return lower + (upper-lower)*(rank-seen)/float64(count), true
+		}
+		seen += float64(count)
+		return 0, false
+	}
+	scale := orig.Scale
+	negative := &orig.Negative
+	for i := len(negative.BucketCounts) - 1; i >= 0; i-- {
+		index := negative.Offset + int32(i)
+		if v, ok := find(negative.BucketCounts[i], -bucketLowerBound(index+1, scale), -bucketLowerBound(index, scale)); ok {
+			return v
+		}
+	}
+	if v, ok := find(orig.ZeroCount, 0, 0); ok {
+		return v
+	}
+	positive := &orig.Positive
+	for i, c := range positive.BucketCounts {
+		index := positive.Offset + int32(i)
+		if v, ok := find(c, bucketLowerBound(index, scale), bucketLowerBound(index+1, scale)); ok {
+			return v
+		}
+	}
+	return math.NaN()
+}
+
+// CopyToHistogram converts ms to an explicit bucket histogram stored in dest, with a bucket per bucket
+// of ms bounded by the same boundaries, and a bucket bounded by 0 for its zero count. The bucket
+// boundaries of explicit bucket histograms being inclusive upper bounds, negative values equal to the
+// lower boundary of a bucket of ms are counted in the bucket below in dest.
+func (ms ExponentialHistogramDataPoint) CopyToHistogram(dest HistogramDataPoint) {
+	ms.Attributes().CopyTo(dest.Attributes())
+	dest.SetStartTimestamp(ms.StartTimestamp())
+	dest.SetTimestamp(ms.Timestamp())
+	dest.SetCount(ms.Count())
+	dest.SetSum(ms.Sum())
+	ms.Exemplars().CopyTo(dest.Exemplars())
+	dest.SetFlags(ms.Flags())
+
+	orig := ms.getOrig()
+	scale := orig.Scale
+	negative := &orig.Negative
+	positive := &orig.Positive
+	bounds := make([]float64, 0, len(negative.BucketCounts)+len(positive.BucketCounts)+3)
+	counts := make([]uint64, 0, cap(bounds)+1)
+	if n := len(negative.BucketCounts); n > 0 {
+		// The first bucket, from -Inf, is empty.
+		bounds = append(bounds, -bucketLowerBound(negative.Offset+int32(n), scale))
+		counts = append(counts, 0)
+		for i := n - 1; i >= 0; i-- {
+			bounds = append(bounds, -bucketLowerBound(negative.Offset+int32(i), scale))
+			counts = append(counts, negative.BucketCounts[i])
+		}
+	}
+	bounds = append(bounds, 0)
+	counts = append(counts, orig.ZeroCount)
+	if len(positive.BucketCounts) > 0 {
+		// The bucket from 0 to the first positive bucket is empty.
+		bounds = append(bounds, bucketLowerBound(positive.Offset, scale))
+		counts = append(counts, 0)
+		for i, c := range positive.BucketCounts {
+			bounds = append(bounds, bucketLowerBound(positive.Offset+int32(i)+1, scale))
+			counts = append(counts, c)
+		}
+	}
+	// The last bucket, to +Inf, is empty.
+	counts = append(counts, 0)
+	dest.SetExplicitBounds(bounds)
+	dest.SetBucketCounts(counts)
+}
+
+// indexedBucket is a bucket of an exponential histogram at a given index.
+type indexedBucket struct {
+	index int32
+	count uint64
+}
+
+// indexRange returns the range of indexes of the buckets, shifted by change, and false if there are none.
+func indexRange(buckets []indexedBucket, change int32) (int32, int32, bool) {
+	if len(buckets) == 0 {
+		return 0, 0, false
+	}
+	low, high := buckets[0].index, buckets[0].index
+	for _, b := range buckets[1:] {
+		if b.index < low {
+			low = b.index
+		}
+		if b.index > high {
+			high = b.index
+		}
+	}
+	return low >> change, high >> change, true
+}
+
+// CopyToExponentialHistogram converts ms to an exponential histogram stored in dest, at the highest
+// scale at which its positive and its negative buckets each fit in maxSize buckets, or 160 buckets if
+// maxSize is not positive. The values of a bucket of ms are estimated by its middle, by its finite
+// bound for the first and last buckets, or by 0 for the bucket whose upper bound is 0, that usually holds zeros.
+// The sum of dest is 0 if ms has none.
+func (ms HistogramDataPoint) CopyToExponentialHistogram(dest ExponentialHistogramDataPoint, maxSize int) {
+	maxSize = exponentialHistogramMaxSize(maxSize)
+	ms.Attributes().CopyTo(dest.Attributes())
+	dest.SetStartTimestamp(ms.StartTimestamp())
+	dest.SetTimestamp(ms.Timestamp())
+	dest.SetCount(ms.Count())
+	dest.SetSum(ms.Sum())
+	ms.Exemplars().CopyTo(dest.Exemplars())
+	dest.SetFlags(ms.Flags())
+
+	var positive, negative []indexedBucket
+	zeroCount := uint64(0)
+	orig := ms.getOrig()
+	bounds := orig.ExplicitBounds
+	for i, c := range orig.BucketCounts {
+		if c == 0 {
+			continue
+		}
+		var v float64
+		switch {
+		case len(bounds) == 0:
+			if ms.HasSum() && ms.Count() != 0 {
+				v = ms.Sum() / float64(ms.Count())
+			}
+		case i < len(bounds) && bounds[i] == 0:
+			v = 0
+		case i == 0:
+			v = bounds[0]
+		case i >= len(bounds):
+			v = bounds[len(bounds)-1]
+		default:
+			v = (bounds[i-1] + bounds[i]) / 2
+		}
+		v = math.Max(-math.MaxFloat64, math.Min(math.MaxFloat64, v))
+		switch {
+		case v > 0:
+			positive = append(positive, indexedBucket{index: bucketIndex(v, maxExponentialHistogramScale), count: c})
+		case v < 0:
+			negative = append(negative, indexedBucket{index: bucketIndex(-v, maxExponentialHistogramScale), count: c})
+		default:
+			// Zero and NaN.
+			zeroCount += c
+		}
+	}
+
+	change := int32(0)
+	for _, buckets := range [][]indexedBucket{positive, negative} {
+		if low, high, ok := indexRange(buckets, 0); ok {
+			if c := scaleChange(low, high, maxSize, maxExponentialHistogramScale); c > change {
+				change = c
+			}
+		}
+	}
+	toBuckets := func(buckets []indexedBucket, dest *otlpmetrics.ExponentialHistogramDataPoint_Buckets) {
+		*dest = otlpmetrics.ExponentialHistogramDataPoint_Buckets{}
+		low, high, ok := indexRange(buckets, change)
+		if !ok {
+			return
+		}
+		dest.Offset = low
+		dest.BucketCounts = make([]uint64, high-low+1)
+		for _, b := range buckets {
+			dest.BucketCounts[b.index>>change-low] += b.count
+		}
+	}
+	destOrig := dest.getMutableOrig()
+	toBuckets(positive, &destOrig.Positive)
+	toBuckets(negative, &destOrig.Negative)
+	destOrig.Scale = maxExponentialHistogramScale - change
+	destOrig.ZeroCount = zeroCount
+}
@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestExponentialHistogram(scale int32, zeroCount uint64, negativeOffset int32, negative []uint64, positiveOffset int32, positive []uint64) ExponentialHistogramDataPoint {
+	dp := NewExponentialHistogramDataPoint()
+	dp.SetScale(scale)
+	dp.SetZeroCount(zeroCount)
+	dp.Negative().SetOffset(negativeOffset)
+	dp.Negative().SetBucketCounts(negative)
+	dp.Positive().SetOffset(positiveOffset)
+	dp.Positive().SetBucketCounts(positive)
+	count := zeroCount
+	for _, c := range append(append([]uint64(nil), negative...), positive...) {
+		count += c
+	}
+	dp.SetCount(count)
+	return dp
+}
+
+func TestBucketIndex(t *testing.T) {
+	tests := []struct {
+		value float64
+		scale int32
+		index int32
+	}{
+		{value: 1, scale: 0, index: -1},
+		{value: 1.5, scale: 0, index: 0},
+		{value: 2, scale: 0, index: 0},
+		{value: 3, scale: 0, index: 1},
+		{value: 0.5, scale: -1, index: -1},
+		{value: 4, scale: -1, index: 0},
+		{value: 5, scale: -1, index: 1},
+		{value: 1.5, scale: 1, index: 1},
+		{value: 2, scale: 1, index: 1},
+		{value: math.MaxFloat64, scale: -10, index: 0},
+		{value: math.SmallestNonzeroFloat64, scale: -10, index: -2},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.index, bucketIndex(tt.value, tt.scale), "%v at scale %v", tt.value, tt.scale)
+	}
+
+	for _, v := range []float64{0.001, 0.7, 1, 3.3, 1024, 1e100} {
+		for scale := int32(-3); scale <= 8; scale++ {
+			index := bucketIndex(v, scale)
+			assert.Equal(t, bucketIndex(v, maxExponentialHistogramScale)>>(maxExponentialHistogramScale-scale), index)
+			assert.Less(t, bucketLowerBound(index, scale), v*(1+1e-12))
+			assert.GreaterOrEqual(t, bucketLowerBound(index+1, scale), v*(1-1e-12))
+		}
+	}
+}
+
+func TestExponentialHistogramDataPointDownscale(t *testing.T) {
+	dp := newTestExponentialHistogram(0, 1, -3, []uint64{1, 1, 1, 1}, 0, []uint64{1, 2, 3, 4})
+	dp.Downscale(4)
+	assert.Equal(t, int32(0), dp.Scale())
+
+	dp.Downscale(2)
+	assert.Equal(t, int32(-2), dp.Scale())
+	assert.Equal(t, int32(-1), dp.Negative().Offset())
+	assert.Equal(t, []uint64{3, 1}, dp.Negative().BucketCounts())
+	assert.Equal(t, int32(0), dp.Positive().Offset())
+	assert.Equal(t, []uint64{10}, dp.Positive().BucketCounts())
+	assert.Equal(t, uint64(1), dp.ZeroCount())
+
+	// The scale is not reduced below the minimum scale.
+	dp = newTestExponentialHistogram(-9, 0, 0, nil, -2, []uint64{1, 0, 1})
+	dp.Downscale(1)
+	assert.Equal(t, int32(minExponentialHistogramScale), dp.Scale())
+	assert.Equal(t, []uint64{1, 1}, dp.Positive().BucketCounts())
+}
+
+func TestExponentialHistogramDataPointMerge(t *testing.T) {
+	dp := newTestExponentialHistogram(1, 1, 0, nil, 0, []uint64{1, 1})
+	dp.SetSum(3)
+	dp.SetStartTimestamp(20)
+	dp.SetTimestamp(30)
+	other := newTestExponentialHistogram(0, 2, -1, []uint64{5}, 1, []uint64{2})
+	other.SetSum(4)
+	other.SetStartTimestamp(10)
+	other.SetTimestamp(40)
+	other.Exemplars().AppendEmpty().SetDoubleVal(1)
+	otherCopy := NewExponentialHistogramDataPoint()
+	other.CopyTo(otherCopy)
+
+	dp.Merge(other, 0)
+	assert.Equal(t, otherCopy, other)
+	assert.Equal(t, int32(0), dp.Scale())
+	assert.Equal(t, int32(0), dp.Positive().Offset())
+	assert.Equal(t, []uint64{2, 2}, dp.Positive().BucketCounts())
+	assert.Equal(t, int32(-1), dp.Negative().Offset())
+	assert.Equal(t, []uint64{5}, dp.Negative().BucketCounts())
+	assert.Equal(t, uint64(3), dp.ZeroCount())
+	assert.Equal(t, uint64(12), dp.Count())
+	assert.Equal(t, 7.0, dp.Sum())
+	assert.Equal(t, Timestamp(10), dp.StartTimestamp())
+	assert.Equal(t, Timestamp(40), dp.Timestamp())
+	assert.Equal(t, 1, dp.Exemplars().Len())
+
+	// The buckets of both histograms must fit.
+	dp = newTestExponentialHistogram(0, 0, 0, nil, 0, []uint64{1})
+	dp.Merge(newTestExponentialHistogram(0, 0, 0, nil, 3, []uint64{1}), 2)
+	assert.Equal(t, int32(-1), dp.Scale())
+	assert.Equal(t, int32(0), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 1}, dp.Positive().BucketCounts())
+}
+
+func TestExponentialHistogramDataPointQuantile(t *testing.T) {
+	dp := newTestExponentialHistogram(0, 1, 0, []uint64{1}, 0, []uint64{2, 1})
+	assert.Equal(t, -2.0, dp.Quantile(0))
+	assert.Equal(t, 0.0, dp.Quantile(0.3))
+	assert.Equal(t, 1.25, dp.Quantile(0.5))
+	assert.Equal(t, 4.0, dp.Quantile(1))
+	assert.True(t, math.IsNaN(dp.Quantile(1.5)))
+	assert.True(t, math.IsNaN(dp.Quantile(math.NaN())))
+	assert.True(t, math.IsNaN(NewExponentialHistogramDataPoint().Quantile(0.5)))
+}
+
+func TestExponentialHistogramDataPointCopyToHistogram(t *testing.T) {
+	dp := newTestExponentialHistogram(0, 2, 0, []uint64{1}, 1, []uint64{3, 4})
+	dp.SetSum(20)
+	dp.SetTimestamp(10)
+	dp.Attributes().InsertString("k", "v")
+	hdp := NewHistogramDataPoint()
+	dp.CopyToHistogram(hdp)
+	assert.Equal(t, []float64{-2, -1, 0, 2, 4, 8}, hdp.ExplicitBounds())
+	assert.Equal(t, []uint64{0, 1, 2, 0, 3, 4, 0}, hdp.BucketCounts())
+	assert.Equal(t, uint64(10), hdp.Count())
+	assert.Equal(t, 20.0, hdp.Sum())
+	assert.Equal(t, Timestamp(10), hdp.Timestamp())
+	assert.Equal(t, 1, hdp.Attributes().Len())
+
+	// Converting it back gives the same buckets.
+	back := NewExponentialHistogramDataPoint()
+	hdp.CopyToExponentialHistogram(back, 2)
+	assert.Equal(t, dp, back)
+
+	hdp = NewHistogramDataPoint()
+	NewExponentialHistogramDataPoint().CopyToHistogram(hdp)
+	assert.Equal(t, []float64{0}, hdp.ExplicitBounds())
+	assert.Equal(t, []uint64{0, 0}, hdp.BucketCounts())
+}
+
+func TestHistogramDataPointCopyToExponentialHistogram(t *testing.T) {
+	hdp := NewHistogramDataPoint()
+	hdp.SetExplicitBounds([]float64{-10, 0, 1, 10, 100})
+	hdp.SetBucketCounts([]uint64{1, 2, 3, 4, 5, 6})
+	hdp.SetCount(21)
+	dp := NewExponentialHistogramDataPoint()
+	dp.SetZeroCount(100)
+	hdp.CopyToExponentialHistogram(dp, 0)
+	assert.Equal(t, uint64(21), dp.Count())
+	assert.Equal(t, 0.0, dp.Sum())
+	assert.Equal(t, uint64(2), dp.ZeroCount())
+	assert.LessOrEqual(t, len(dp.Positive().BucketCounts()), defaultExponentialHistogramMaxSize)
+	// The values are estimated at 0.5, 5.5, 55 and 100, and -10.
+	for _, tt := range []struct {
+		q    float64
+		low  float64
+		high float64
+	}{
+		{q: 0, low: -10.5, high: -9.5},
+		{q: 4.0 / 21, low: 0.45, high: 0.55},
+		{q: 1, low: 95, high: 105},
+	} {
+		v := dp.Quantile(tt.q)
+		assert.True(t, v >= tt.low && v <= tt.high, "quantile %v: %v", tt.q, v)
+	}
+
+	hdp.SetExplicitBounds(nil)
+	hdp.SetBucketCounts([]uint64{4})
+	hdp.SetCount(4)
+	hdp.SetSum(8)
+	hdp.CopyToExponentialHistogram(dp, 0)
+	assert.Equal(t, int32(maxExponentialHistogramScale), dp.Scale())
+	assert.Equal(t, []uint64{4}, dp.Positive().BucketCounts())
+	assert.Equal(t, uint64(0), dp.ZeroCount())
+	assert.Equal(t, 8.0, dp.Sum())
+}
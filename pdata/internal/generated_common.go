@@ -18,6 +18,8 @@
 package internal
 
 import (
+	"unsafe"
+
 	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
 )
 
@@ -30,12 +32,24 @@ import (
 // Important: zero-initialized instance is not valid for use.
 type InstrumentationScope struct {
 	orig *otlpcommon.InstrumentationScope
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newInstrumentationScope(orig *otlpcommon.InstrumentationScope) InstrumentationScope {
 	return InstrumentationScope{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms InstrumentationScope) getOrig() *otlpcommon.InstrumentationScope {
+	return (*otlpcommon.InstrumentationScope)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms InstrumentationScope) getMutableOrig() *otlpcommon.InstrumentationScope {
+	return (*otlpcommon.InstrumentationScope)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewInstrumentationScope creates a new empty InstrumentationScope.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -47,28 +61,29 @@ func NewInstrumentationScope() InstrumentationScope {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms InstrumentationScope) MoveTo(dest InstrumentationScope) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlpcommon.InstrumentationScope{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlpcommon.InstrumentationScope{}
 }
 
 // Name returns the name associated with this InstrumentationScope.
 func (ms InstrumentationScope) Name() string {
-	return (*ms.orig).Name
+	return ms.getOrig().Name
 }
 
 // SetName replaces the name associated with this InstrumentationScope.
 func (ms InstrumentationScope) SetName(v string) {
-	(*ms.orig).Name = v
+	ms.getMutableOrig().Name = v
 }
 
 // Version returns the version associated with this InstrumentationScope.
 func (ms InstrumentationScope) Version() string {
-	return (*ms.orig).Version
+	return ms.getOrig().Version
 }
 
 // SetVersion replaces the version associated with this InstrumentationScope.
 func (ms InstrumentationScope) SetVersion(v string) {
-	(*ms.orig).Version = v
+	ms.getMutableOrig().Version = v
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -88,24 +103,36 @@ type Slice struct {
 	// orig points to the slice otlpcommon.AnyValue field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
 	orig *[]otlpcommon.AnyValue
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newSlice(orig *[]otlpcommon.AnyValue) Slice {
-	return Slice{orig}
+	return Slice{orig: orig}
 }
 
 // NewSlice creates a Slice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewSlice() Slice {
 	orig := []otlpcommon.AnyValue(nil)
-	return Slice{&orig}
+	return Slice{orig: &orig}
+}
+
+// getOrig returns the orig to read.
+func (es Slice) getOrig() *[]otlpcommon.AnyValue {
+	return (*[]otlpcommon.AnyValue)(es.shared.read(unsafe.Pointer(es.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (es Slice) getMutableOrig() *[]otlpcommon.AnyValue {
+	return (*[]otlpcommon.AnyValue)(es.shared.write(unsafe.Pointer(es.orig)))
 }
 
 // Len returns the number of elements in the slice.
 //
 // Returns "0" for a newly instance created with "NewSlice()".
 func (es Slice) Len() int {
-	return len(*es.orig)
+	return len(*es.getOrig())
 }
 
 // At returns the element at the given index.
@@ -116,21 +143,23 @@ func (es Slice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es Slice) At(ix int) Value {
-	return newValue(&(*es.orig)[ix])
+	return Value{orig: &(*es.getOrig())[ix], shared: es.shared}
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es Slice) CopyTo(dest Slice) {
-	srcLen := es.Len()
-	destCap := cap(*dest.orig)
+	destOrig := dest.getMutableOrig()
+	orig := es.getOrig()
+	srcLen := len(*orig)
+	destCap := cap(*destOrig)
 	if srcLen <= destCap {
-		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
+		(*destOrig) = (*destOrig)[:srcLen:destCap]
 	} else {
-		(*dest.orig) = make([]otlpcommon.AnyValue, srcLen)
+		(*destOrig) = make([]otlpcommon.AnyValue, srcLen)
 	}
 
-	for i := range *es.orig {
-		newValue(&(*es.orig)[i]).CopyTo(newValue(&(*dest.orig)[i]))
+	for i := range *orig {
+		newValue(&(*orig)[i]).CopyTo(newValue(&(*destOrig)[i]))
 	}
 }
 
@@ -146,40 +175,44 @@ func (es Slice) CopyTo(dest Slice) {
 //       // Here should set all the values for e.
 //   }
 func (es Slice) EnsureCapacity(newCap int) {
-	oldCap := cap(*es.orig)
+	oldCap := cap(*es.getOrig())
 	if newCap <= oldCap {
 		return
 	}
 
-	newOrig := make([]otlpcommon.AnyValue, len(*es.orig), newCap)
-	copy(newOrig, *es.orig)
-	*es.orig = newOrig
+	orig := es.getMutableOrig()
+	newOrig := make([]otlpcommon.AnyValue, len(*orig), newCap)
+	copy(newOrig, *orig)
+	*orig = newOrig
 }
 
 // AppendEmpty will append to the end of the slice an empty Value.
 // It returns the newly added Value.
 func (es Slice) AppendEmpty() Value {
-	*es.orig = append(*es.orig, otlpcommon.AnyValue{})
+	orig := es.getMutableOrig()
+	*orig = append(*orig, otlpcommon.AnyValue{})
 	return es.At(es.Len() - 1)
 }
 
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es Slice) MoveAndAppendTo(dest Slice) {
-	if *dest.orig == nil {
+	orig := es.getMutableOrig()
+	destOrig := dest.getMutableOrig()
+	if *destOrig == nil {
 		// We can simply move the entire vector and avoid any allocations.
-		*dest.orig = *es.orig
+		*destOrig = *orig
 	} else {
-		*dest.orig = append(*dest.orig, *es.orig...)
+		*destOrig = append(*destOrig, *orig...)
 	}
-	*es.orig = nil
+	*orig = nil
 }
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es Slice) RemoveIf(f func(Value) bool) {
 	newLen := 0
-	for i := 0; i < len(*es.orig); i++ {
+	for i := 0; i < es.Len(); i++ {
 		if f(es.At(i)) {
 			continue
 		}
@@ -188,9 +221,17 @@ func (es Slice) RemoveIf(f func(Value) bool) {
 			newLen++
 			continue
 		}
-		(*es.orig)[newLen] = (*es.orig)[i]
+		orig := es.getMutableOrig()
+		(*orig)[newLen] = (*orig)[i]
 		newLen++
 	}
-	// TODO: Prevent memory leak by erasing truncated values.
-	*es.orig = (*es.orig)[:newLen]
+	if newLen == es.Len() {
+		return
+	}
+	orig := es.getMutableOrig()
+	// Erase truncated values to not keep references to removed elements.
+	for i := newLen; i < len(*orig); i++ {
+		(*orig)[i] = otlpcommon.AnyValue{}
+	}
+	*orig = (*orig)[:newLen]
 }
@@ -19,6 +19,7 @@ package internal
 
 import (
 	"sort"
+	"unsafe"
 
 	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
 )
@@ -34,24 +35,38 @@ type ResourceLogsSlice struct {
 	// orig points to the slice otlplogs.ResourceLogs field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
 	orig *[]*otlplogs.ResourceLogs
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
+	// sharedElements, if not nil, tracks the elements sharing their content with other data, see Share.
+	sharedElements *SharedResourceLogs
 }
 
 func newResourceLogsSlice(orig *[]*otlplogs.ResourceLogs) ResourceLogsSlice {
-	return ResourceLogsSlice{orig}
+	return ResourceLogsSlice{orig: orig}
 }
 
 // NewResourceLogsSlice creates a ResourceLogsSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewResourceLogsSlice() ResourceLogsSlice {
 	orig := []*otlplogs.ResourceLogs(nil)
-	return ResourceLogsSlice{&orig}
+	return ResourceLogsSlice{orig: &orig}
+}
+
+// getOrig returns the orig to read.
+func (es ResourceLogsSlice) getOrig() *[]*otlplogs.ResourceLogs {
+	return (*[]*otlplogs.ResourceLogs)(es.shared.read(unsafe.Pointer(es.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (es ResourceLogsSlice) getMutableOrig() *[]*otlplogs.ResourceLogs {
+	return (*[]*otlplogs.ResourceLogs)(es.shared.write(unsafe.Pointer(es.orig)))
 }
 
 // Len returns the number of elements in the slice.
 //
 // Returns "0" for a newly instance created with "NewResourceLogsSlice()".
 func (es ResourceLogsSlice) Len() int {
-	return len(*es.orig)
+	return len(*es.getOrig())
 }
 
 // At returns the element at the given index.
@@ -62,27 +77,34 @@ func (es ResourceLogsSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ResourceLogsSlice) At(ix int) ResourceLogs {
-	return newResourceLogs((*es.orig)[ix])
+	orig := (*es.getOrig())[ix]
+	return ResourceLogs{orig: orig, shared: es.sharedElements.get(orig)}
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ResourceLogsSlice) CopyTo(dest ResourceLogsSlice) {
-	srcLen := es.Len()
-	destCap := cap(*dest.orig)
+	destOrig := dest.getMutableOrig()
+	orig := es.getOrig()
+	srcLen := len(*orig)
+	destCap := cap(*destOrig)
+	dest.sharedElements.drop((*destOrig)[:destCap])
 	if srcLen <= destCap {
-		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
-		for i := range *es.orig {
-			newResourceLogs((*es.orig)[i]).CopyTo(newResourceLogs((*dest.orig)[i]))
+		(*destOrig) = (*destOrig)[:srcLen:destCap]
+		for i := range *orig {
+			if (*destOrig)[i] == nil {
+				(*destOrig)[i] = &otlplogs.ResourceLogs{}
+			}
+			newResourceLogs((*orig)[i]).CopyTo(newResourceLogs((*destOrig)[i]))
 		}
 		return
 	}
 	origs := make([]otlplogs.ResourceLogs, srcLen)
 	wrappers := make([]*otlplogs.ResourceLogs, srcLen)
-	for i := range *es.orig {
+	for i := range *orig {
 		wrappers[i] = &origs[i]
-		newResourceLogs((*es.orig)[i]).CopyTo(newResourceLogs(wrappers[i]))
+		newResourceLogs((*orig)[i]).CopyTo(newResourceLogs(wrappers[i]))
 	}
-	*dest.orig = wrappers
+	*destOrig = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -97,20 +119,22 @@ func (es ResourceLogsSlice) CopyTo(dest ResourceLogsSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es ResourceLogsSlice) EnsureCapacity(newCap int) {
-	oldCap := cap(*es.orig)
+	oldCap := cap(*es.getOrig())
 	if newCap <= oldCap {
 		return
 	}
 
-	newOrig := make([]*otlplogs.ResourceLogs, len(*es.orig), newCap)
-	copy(newOrig, *es.orig)
-	*es.orig = newOrig
+	orig := es.getMutableOrig()
+	newOrig := make([]*otlplogs.ResourceLogs, len(*orig), newCap)
+	copy(newOrig, *orig)
+	*orig = newOrig
 }
 
 // AppendEmpty will append to the end of the slice an empty ResourceLogs.
 // It returns the newly added ResourceLogs.
 func (es ResourceLogsSlice) AppendEmpty() ResourceLogs {
-	*es.orig = append(*es.orig, &otlplogs.ResourceLogs{})
+	orig := es.getMutableOrig()
+	*orig = append(*orig, &otlplogs.ResourceLogs{})
 	return es.At(es.Len() - 1)
 }
 
@@ -124,28 +148,32 @@ func (es ResourceLogsSlice) AppendEmpty() ResourceLogs {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es ResourceLogsSlice) Sort(less func(a, b ResourceLogs) bool) ResourceLogsSlice {
-	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	sort.SliceStable(*es.getMutableOrig(), func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
 
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ResourceLogsSlice) MoveAndAppendTo(dest ResourceLogsSlice) {
-	if *dest.orig == nil {
+	orig := es.getMutableOrig()
+	destOrig := dest.getMutableOrig()
+	es.sharedElements.moveTo(dest.sharedElements, *orig)
+	if *destOrig == nil {
 		// We can simply move the entire vector and avoid any allocations.
-		*dest.orig = *es.orig
+		*destOrig = *orig
 	} else {
-		*dest.orig = append(*dest.orig, *es.orig...)
+		*destOrig = append(*destOrig, *orig...)
 	}
-	*es.orig = nil
+	*orig = nil
 }
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es ResourceLogsSlice) RemoveIf(f func(ResourceLogs) bool) {
 	newLen := 0
-	for i := 0; i < len(*es.orig); i++ {
+	for i := 0; i < es.Len(); i++ {
 		if f(es.At(i)) {
+			es.sharedElements.remove((*es.getOrig())[i])
 			continue
 		}
 		if newLen == i {
@@ -153,11 +181,19 @@ func (es ResourceLogsSlice) RemoveIf(f func(ResourceLogs) bool) {
 			newLen++
 			continue
 		}
-		(*es.orig)[newLen] = (*es.orig)[i]
+		orig := es.getMutableOrig()
+		(*orig)[newLen] = (*orig)[i]
 		newLen++
 	}
-	// TODO: Prevent memory leak by erasing truncated values.
-	*es.orig = (*es.orig)[:newLen]
+	if newLen == es.Len() {
+		return
+	}
+	orig := es.getMutableOrig()
+	// Erase truncated values to not keep references to removed elements.
+	for i := newLen; i < len(*orig); i++ {
+		(*orig)[i] = nil
+	}
+	*orig = (*orig)[:newLen]
 }
 
 // ResourceLogs is a collection of logs from a Resource.
@@ -169,12 +205,24 @@ func (es ResourceLogsSlice) RemoveIf(f func(ResourceLogs) bool) {
 // Important: zero-initialized instance is not valid for use.
 type ResourceLogs struct {
 	orig *otlplogs.ResourceLogs
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newResourceLogs(orig *otlplogs.ResourceLogs) ResourceLogs {
 	return ResourceLogs{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms ResourceLogs) getOrig() *otlplogs.ResourceLogs {
+	return (*otlplogs.ResourceLogs)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms ResourceLogs) getMutableOrig() *otlplogs.ResourceLogs {
+	return (*otlplogs.ResourceLogs)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewResourceLogs creates a new empty ResourceLogs.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -186,28 +234,29 @@ func NewResourceLogs() ResourceLogs {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms ResourceLogs) MoveTo(dest ResourceLogs) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlplogs.ResourceLogs{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlplogs.ResourceLogs{}
 }
 
 // Resource returns the resource associated with this ResourceLogs.
 func (ms ResourceLogs) Resource() Resource {
-	return newResource(&(*ms.orig).Resource)
+	return Resource{orig: &ms.getOrig().Resource, shared: ms.shared}
 }
 
 // SchemaUrl returns the schemaurl associated with this ResourceLogs.
 func (ms ResourceLogs) SchemaUrl() string {
-	return (*ms.orig).SchemaUrl
+	return ms.getOrig().SchemaUrl
 }
 
 // SetSchemaUrl replaces the schemaurl associated with this ResourceLogs.
 func (ms ResourceLogs) SetSchemaUrl(v string) {
-	(*ms.orig).SchemaUrl = v
+	ms.getMutableOrig().SchemaUrl = v
 }
 
 // ScopeLogs returns the ScopeLogs associated with this ResourceLogs.
 func (ms ResourceLogs) ScopeLogs() ScopeLogsSlice {
-	return newScopeLogsSlice(&(*ms.orig).ScopeLogs)
+	return ScopeLogsSlice{orig: &ms.getOrig().ScopeLogs, shared: ms.shared}
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -228,24 +277,36 @@ type ScopeLogsSlice struct {
 	// orig points to the slice otlplogs.ScopeLogs field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
 	orig *[]*otlplogs.ScopeLogs
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newScopeLogsSlice(orig *[]*otlplogs.ScopeLogs) ScopeLogsSlice {
-	return ScopeLogsSlice{orig}
+	return ScopeLogsSlice{orig: orig}
 }
 
 // NewScopeLogsSlice creates a ScopeLogsSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewScopeLogsSlice() ScopeLogsSlice {
 	orig := []*otlplogs.ScopeLogs(nil)
-	return ScopeLogsSlice{&orig}
+	return ScopeLogsSlice{orig: &orig}
+}
+
+// getOrig returns the orig to read.
+func (es ScopeLogsSlice) getOrig() *[]*otlplogs.ScopeLogs {
+	return (*[]*otlplogs.ScopeLogs)(es.shared.read(unsafe.Pointer(es.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (es ScopeLogsSlice) getMutableOrig() *[]*otlplogs.ScopeLogs {
+	return (*[]*otlplogs.ScopeLogs)(es.shared.write(unsafe.Pointer(es.orig)))
 }
 
 // Len returns the number of elements in the slice.
 //
 // Returns "0" for a newly instance created with "NewScopeLogsSlice()".
 func (es ScopeLogsSlice) Len() int {
-	return len(*es.orig)
+	return len(*es.getOrig())
 }
 
 // At returns the element at the given index.
@@ -256,27 +317,33 @@ func (es ScopeLogsSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ScopeLogsSlice) At(ix int) ScopeLogs {
-	return newScopeLogs((*es.orig)[ix])
+	orig := (*es.getOrig())[ix]
+	return ScopeLogs{orig: orig, shared: es.shared}
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ScopeLogsSlice) CopyTo(dest ScopeLogsSlice) {
-	srcLen := es.Len()
-	destCap := cap(*dest.orig)
+	destOrig := dest.getMutableOrig()
+	orig := es.getOrig()
+	srcLen := len(*orig)
+	destCap := cap(*destOrig)
 	if srcLen <= destCap {
-		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
-		for i := range *es.orig {
-			newScopeLogs((*es.orig)[i]).CopyTo(newScopeLogs((*dest.orig)[i]))
+		(*destOrig) = (*destOrig)[:srcLen:destCap]
+		for i := range *orig {
+			if (*destOrig)[i] == nil {
+				(*destOrig)[i] = &otlplogs.ScopeLogs{}
+			}
+			newScopeLogs((*orig)[i]).CopyTo(newScopeLogs((*destOrig)[i]))
 		}
 		return
 	}
 	origs := make([]otlplogs.ScopeLogs, srcLen)
 	wrappers := make([]*otlplogs.ScopeLogs, srcLen)
-	for i := range *es.orig {
+	for i := range *orig {
 		wrappers[i] = &origs[i]
-		newScopeLogs((*es.orig)[i]).CopyTo(newScopeLogs(wrappers[i]))
+		newScopeLogs((*orig)[i]).CopyTo(newScopeLogs(wrappers[i]))
 	}
-	*dest.orig = wrappers
+	*destOrig = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -291,20 +358,22 @@ func (es ScopeLogsSlice) CopyTo(dest ScopeLogsSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es ScopeLogsSlice) EnsureCapacity(newCap int) {
-	oldCap := cap(*es.orig)
+	oldCap := cap(*es.getOrig())
 	if newCap <= oldCap {
 		return
 	}
 
-	newOrig := make([]*otlplogs.ScopeLogs, len(*es.orig), newCap)
-	copy(newOrig, *es.orig)
-	*es.orig = newOrig
+	orig := es.getMutableOrig()
+	newOrig := make([]*otlplogs.ScopeLogs, len(*orig), newCap)
+	copy(newOrig, *orig)
+	*orig = newOrig
 }
 
 // AppendEmpty will append to the end of the slice an empty ScopeLogs.
 // It returns the newly added ScopeLogs.
 func (es ScopeLogsSlice) AppendEmpty() ScopeLogs {
-	*es.orig = append(*es.orig, &otlplogs.ScopeLogs{})
+	orig := es.getMutableOrig()
+	*orig = append(*orig, &otlplogs.ScopeLogs{})
 	return es.At(es.Len() - 1)
 }
 
@@ -318,27 +387,29 @@ func (es ScopeLogsSlice) AppendEmpty() ScopeLogs {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es ScopeLogsSlice) Sort(less func(a, b ScopeLogs) bool) ScopeLogsSlice {
-	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	sort.SliceStable(*es.getMutableOrig(), func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
 
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ScopeLogsSlice) MoveAndAppendTo(dest ScopeLogsSlice) {
-	if *dest.orig == nil {
+	orig := es.getMutableOrig()
+	destOrig := dest.getMutableOrig()
+	if *destOrig == nil {
 		// We can simply move the entire vector and avoid any allocations.
-		*dest.orig = *es.orig
+		*destOrig = *orig
 	} else {
-		*dest.orig = append(*dest.orig, *es.orig...)
+		*destOrig = append(*destOrig, *orig...)
 	}
-	*es.orig = nil
+	*orig = nil
 }
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es ScopeLogsSlice) RemoveIf(f func(ScopeLogs) bool) {
 	newLen := 0
-	for i := 0; i < len(*es.orig); i++ {
+	for i := 0; i < es.Len(); i++ {
 		if f(es.At(i)) {
 			continue
 		}
@@ -347,11 +418,19 @@ func (es ScopeLogsSlice) RemoveIf(f func(ScopeLogs) bool) {
 			newLen++
 			continue
 		}
-		(*es.orig)[newLen] = (*es.orig)[i]
+		orig := es.getMutableOrig()
+		(*orig)[newLen] = (*orig)[i]
 		newLen++
 	}
-	// TODO: Prevent memory leak by erasing truncated values.
-	*es.orig = (*es.orig)[:newLen]
+	if newLen == es.Len() {
+		return
+	}
+	orig := es.getMutableOrig()
+	// Erase truncated values to not keep references to removed elements.
+	for i := newLen; i < len(*orig); i++ {
+		(*orig)[i] = nil
+	}
+	*orig = (*orig)[:newLen]
 }
 
 // ScopeLogs is a collection of logs from a LibraryInstrumentation.
@@ -363,12 +442,24 @@ func (es ScopeLogsSlice) RemoveIf(f func(ScopeLogs) bool) {
 // Important: zero-initialized instance is not valid for use.
 type ScopeLogs struct {
 	orig *otlplogs.ScopeLogs
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newScopeLogs(orig *otlplogs.ScopeLogs) ScopeLogs {
 	return ScopeLogs{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms ScopeLogs) getOrig() *otlplogs.ScopeLogs {
+	return (*otlplogs.ScopeLogs)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms ScopeLogs) getMutableOrig() *otlplogs.ScopeLogs {
+	return (*otlplogs.ScopeLogs)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewScopeLogs creates a new empty ScopeLogs.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -380,28 +471,29 @@ func NewScopeLogs() ScopeLogs {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms ScopeLogs) MoveTo(dest ScopeLogs) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlplogs.ScopeLogs{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlplogs.ScopeLogs{}
 }
 
 // Scope returns the scope associated with this ScopeLogs.
 func (ms ScopeLogs) Scope() InstrumentationScope {
-	return newInstrumentationScope(&(*ms.orig).Scope)
+	return InstrumentationScope{orig: &ms.getOrig().Scope, shared: ms.shared}
 }
 
 // SchemaUrl returns the schemaurl associated with this ScopeLogs.
 func (ms ScopeLogs) SchemaUrl() string {
-	return (*ms.orig).SchemaUrl
+	return ms.getOrig().SchemaUrl
 }
 
 // SetSchemaUrl replaces the schemaurl associated with this ScopeLogs.
 func (ms ScopeLogs) SetSchemaUrl(v string) {
-	(*ms.orig).SchemaUrl = v
+	ms.getMutableOrig().SchemaUrl = v
 }
 
 // LogRecords returns the LogRecords associated with this ScopeLogs.
 func (ms ScopeLogs) LogRecords() LogRecordSlice {
-	return newLogRecordSlice(&(*ms.orig).LogRecords)
+	return LogRecordSlice{orig: &ms.getOrig().LogRecords, shared: ms.shared}
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -422,24 +514,36 @@ type LogRecordSlice struct {
 	// orig points to the slice otlplogs.LogRecord field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
 	orig *[]*otlplogs.LogRecord
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newLogRecordSlice(orig *[]*otlplogs.LogRecord) LogRecordSlice {
-	return LogRecordSlice{orig}
+	return LogRecordSlice{orig: orig}
 }
 
 // NewLogRecordSlice creates a LogRecordSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewLogRecordSlice() LogRecordSlice {
 	orig := []*otlplogs.LogRecord(nil)
-	return LogRecordSlice{&orig}
+	return LogRecordSlice{orig: &orig}
+}
+
+// getOrig returns the orig to read.
+func (es LogRecordSlice) getOrig() *[]*otlplogs.LogRecord {
+	return (*[]*otlplogs.LogRecord)(es.shared.read(unsafe.Pointer(es.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (es LogRecordSlice) getMutableOrig() *[]*otlplogs.LogRecord {
+	return (*[]*otlplogs.LogRecord)(es.shared.write(unsafe.Pointer(es.orig)))
 }
 
 // Len returns the number of elements in the slice.
 //
 // Returns "0" for a newly instance created with "NewLogRecordSlice()".
 func (es LogRecordSlice) Len() int {
-	return len(*es.orig)
+	return len(*es.getOrig())
 }
 
 // At returns the element at the given index.
@@ -450,27 +554,33 @@ func (es LogRecordSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es LogRecordSlice) At(ix int) LogRecord {
-	return newLogRecord((*es.orig)[ix])
+	orig := (*es.getOrig())[ix]
+	return LogRecord{orig: orig, shared: es.shared}
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es LogRecordSlice) CopyTo(dest LogRecordSlice) {
-	srcLen := es.Len()
-	destCap := cap(*dest.orig)
+	destOrig := dest.getMutableOrig()
+	orig := es.getOrig()
+	srcLen := len(*orig)
+	destCap := cap(*destOrig)
 	if srcLen <= destCap {
-		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
-		for i := range *es.orig {
-			newLogRecord((*es.orig)[i]).CopyTo(newLogRecord((*dest.orig)[i]))
+		(*destOrig) = (*destOrig)[:srcLen:destCap]
+		for i := range *orig {
+			if (*destOrig)[i] == nil {
+				(*destOrig)[i] = &otlplogs.LogRecord{}
+			}
+			newLogRecord((*orig)[i]).CopyTo(newLogRecord((*destOrig)[i]))
 		}
 		return
 	}
 	origs := make([]otlplogs.LogRecord, srcLen)
 	wrappers := make([]*otlplogs.LogRecord, srcLen)
-	for i := range *es.orig {
+	for i := range *orig {
 		wrappers[i] = &origs[i]
-		newLogRecord((*es.orig)[i]).CopyTo(newLogRecord(wrappers[i]))
+		newLogRecord((*orig)[i]).CopyTo(newLogRecord(wrappers[i]))
 	}
-	*dest.orig = wrappers
+	*destOrig = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -485,20 +595,22 @@ func (es LogRecordSlice) CopyTo(dest LogRecordSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es LogRecordSlice) EnsureCapacity(newCap int) {
-	oldCap := cap(*es.orig)
+	oldCap := cap(*es.getOrig())
 	if newCap <= oldCap {
 		return
 	}
 
-	newOrig := make([]*otlplogs.LogRecord, len(*es.orig), newCap)
-	copy(newOrig, *es.orig)
-	*es.orig = newOrig
+	orig := es.getMutableOrig()
+	newOrig := make([]*otlplogs.LogRecord, len(*orig), newCap)
+	copy(newOrig, *orig)
+	*orig = newOrig
 }
 
 // AppendEmpty will append to the end of the slice an empty LogRecord.
 // It returns the newly added LogRecord.
 func (es LogRecordSlice) AppendEmpty() LogRecord {
-	*es.orig = append(*es.orig, &otlplogs.LogRecord{})
+	orig := es.getMutableOrig()
+	*orig = append(*orig, &otlplogs.LogRecord{})
 	return es.At(es.Len() - 1)
 }
 
@@ -512,27 +624,29 @@ func (es LogRecordSlice) AppendEmpty() LogRecord {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es LogRecordSlice) Sort(less func(a, b LogRecord) bool) LogRecordSlice {
-	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	sort.SliceStable(*es.getMutableOrig(), func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
 
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es LogRecordSlice) MoveAndAppendTo(dest LogRecordSlice) {
-	if *dest.orig == nil {
+	orig := es.getMutableOrig()
+	destOrig := dest.getMutableOrig()
+	if *destOrig == nil {
 		// We can simply move the entire vector and avoid any allocations.
-		*dest.orig = *es.orig
+		*destOrig = *orig
 	} else {
-		*dest.orig = append(*dest.orig, *es.orig...)
+		*destOrig = append(*destOrig, *orig...)
 	}
-	*es.orig = nil
+	*orig = nil
 }
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es LogRecordSlice) RemoveIf(f func(LogRecord) bool) {
 	newLen := 0
-	for i := 0; i < len(*es.orig); i++ {
+	for i := 0; i < es.Len(); i++ {
 		if f(es.At(i)) {
 			continue
 		}
@@ -541,11 +655,19 @@ func (es LogRecordSlice) RemoveIf(f func(LogRecord) bool) {
 			newLen++
 			continue
 		}
-		(*es.orig)[newLen] = (*es.orig)[i]
+		orig := es.getMutableOrig()
+		(*orig)[newLen] = (*orig)[i]
 		newLen++
 	}
-	// TODO: Prevent memory leak by erasing truncated values.
-	*es.orig = (*es.orig)[:newLen]
+	if newLen == es.Len() {
+		return
+	}
+	orig := es.getMutableOrig()
+	// Erase truncated values to not keep references to removed elements.
+	for i := newLen; i < len(*orig); i++ {
+		(*orig)[i] = nil
+	}
+	*orig = (*orig)[:newLen]
 }
 
 // LogRecord are experimental implementation of OpenTelemetry Log Data Model.
@@ -558,12 +680,24 @@ func (es LogRecordSlice) RemoveIf(f func(LogRecord) bool) {
 // Important: zero-initialized instance is not valid for use.
 type LogRecord struct {
 	orig *otlplogs.LogRecord
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newLogRecord(orig *otlplogs.LogRecord) LogRecord {
 	return LogRecord{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms LogRecord) getOrig() *otlplogs.LogRecord {
+	return (*otlplogs.LogRecord)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms LogRecord) getMutableOrig() *otlplogs.LogRecord {
+	return (*otlplogs.LogRecord)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewLogRecord creates a new empty LogRecord.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -575,98 +709,99 @@ func NewLogRecord() LogRecord {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms LogRecord) MoveTo(dest LogRecord) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlplogs.LogRecord{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlplogs.LogRecord{}
 }
 
 // ObservedTimestamp returns the observedtimestamp associated with this LogRecord.
 func (ms LogRecord) ObservedTimestamp() Timestamp {
-	return Timestamp((*ms.orig).ObservedTimeUnixNano)
+	return Timestamp(ms.getOrig().ObservedTimeUnixNano)
 }
 
 // SetObservedTimestamp replaces the observedtimestamp associated with this LogRecord.
 func (ms LogRecord) SetObservedTimestamp(v Timestamp) {
-	(*ms.orig).ObservedTimeUnixNano = uint64(v)
+	ms.getMutableOrig().ObservedTimeUnixNano = uint64(v)
 }
 
 // Timestamp returns the timestamp associated with this LogRecord.
 func (ms LogRecord) Timestamp() Timestamp {
-	return Timestamp((*ms.orig).TimeUnixNano)
+	return Timestamp(ms.getOrig().TimeUnixNano)
 }
 
 // SetTimestamp replaces the timestamp associated with this LogRecord.
 func (ms LogRecord) SetTimestamp(v Timestamp) {
-	(*ms.orig).TimeUnixNano = uint64(v)
+	ms.getMutableOrig().TimeUnixNano = uint64(v)
 }
 
 // TraceID returns the traceid associated with this LogRecord.
 func (ms LogRecord) TraceID() TraceID {
-	return TraceID{orig: ((*ms.orig).TraceId)}
+	return TraceID{orig: ms.getOrig().TraceId}
 }
 
 // SetTraceID replaces the traceid associated with this LogRecord.
 func (ms LogRecord) SetTraceID(v TraceID) {
-	(*ms.orig).TraceId = v.orig
+	ms.getMutableOrig().TraceId = v.orig
 }
 
 // SpanID returns the spanid associated with this LogRecord.
 func (ms LogRecord) SpanID() SpanID {
-	return SpanID{orig: ((*ms.orig).SpanId)}
+	return SpanID{orig: ms.getOrig().SpanId}
 }
 
 // SetSpanID replaces the spanid associated with this LogRecord.
 func (ms LogRecord) SetSpanID(v SpanID) {
-	(*ms.orig).SpanId = v.orig
+	ms.getMutableOrig().SpanId = v.orig
 }
 
 // Flags returns the flags associated with this LogRecord.
 func (ms LogRecord) Flags() uint32 {
-	return uint32((*ms.orig).Flags)
+	return uint32(ms.getOrig().Flags)
 }
 
 // SetFlags replaces the flags associated with this LogRecord.
 func (ms LogRecord) SetFlags(v uint32) {
-	(*ms.orig).Flags = uint32(v)
+	ms.getMutableOrig().Flags = uint32(v)
 }
 
 // SeverityText returns the severitytext associated with this LogRecord.
 func (ms LogRecord) SeverityText() string {
-	return (*ms.orig).SeverityText
+	return ms.getOrig().SeverityText
 }
 
 // SetSeverityText replaces the severitytext associated with this LogRecord.
 func (ms LogRecord) SetSeverityText(v string) {
-	(*ms.orig).SeverityText = v
+	ms.getMutableOrig().SeverityText = v
 }
 
 // SeverityNumber returns the severitynumber associated with this LogRecord.
 func (ms LogRecord) SeverityNumber() SeverityNumber {
-	return SeverityNumber((*ms.orig).SeverityNumber)
+	return SeverityNumber(ms.getOrig().SeverityNumber)
 }
 
 // SetSeverityNumber replaces the severitynumber associated with this LogRecord.
 func (ms LogRecord) SetSeverityNumber(v SeverityNumber) {
-	(*ms.orig).SeverityNumber = otlplogs.SeverityNumber(v)
+	ms.getMutableOrig().SeverityNumber = otlplogs.SeverityNumber(v)
 }
 
 // Body returns the body associated with this LogRecord.
 func (ms LogRecord) Body() Value {
-	return newValue(&(*ms.orig).Body)
+	return Value{orig: &ms.getOrig().Body, shared: ms.shared}
 }
 
 // Attributes returns the Attributes associated with this LogRecord.
 func (ms LogRecord) Attributes() Map {
-	return newMap(&(*ms.orig).Attributes)
+	return Map{orig: &ms.getOrig().Attributes, shared: ms.shared}
 }
 
 // DroppedAttributesCount returns the droppedattributescount associated with this LogRecord.
 func (ms LogRecord) DroppedAttributesCount() uint32 {
-	return (*ms.orig).DroppedAttributesCount
+	return ms.getOrig().DroppedAttributesCount
 }
 
 // SetDroppedAttributesCount replaces the droppedattributescount associated with this LogRecord.
 func (ms LogRecord) SetDroppedAttributesCount(v uint32) {
-	(*ms.orig).DroppedAttributesCount = v
+	ms.getMutableOrig().DroppedAttributesCount = v
 }
 
 // CopyTo copies all properties from the current struct to the dest.
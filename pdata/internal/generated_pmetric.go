@@ -19,6 +19,7 @@ package internal
 
 import (
 	"sort"
+	"unsafe"
 
 	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
 )
@@ -34,24 +35,38 @@ type ResourceMetricsSlice struct {
 	// orig points to the slice otlpmetrics.ResourceMetrics field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
 	orig *[]*otlpmetrics.ResourceMetrics
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
+	// sharedElements, if not nil, tracks the elements sharing their content with other data, see Share.
+	sharedElements *SharedResourceMetrics
 }
 
 func newResourceMetricsSlice(orig *[]*otlpmetrics.ResourceMetrics) ResourceMetricsSlice {
-	return ResourceMetricsSlice{orig}
+	return ResourceMetricsSlice{orig: orig}
 }
 
 // NewResourceMetricsSlice creates a ResourceMetricsSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewResourceMetricsSlice() ResourceMetricsSlice {
 	orig := []*otlpmetrics.ResourceMetrics(nil)
-	return ResourceMetricsSlice{&orig}
+	return ResourceMetricsSlice{orig: &orig}
+}
+
+// getOrig returns the orig to read.
+func (es ResourceMetricsSlice) getOrig() *[]*otlpmetrics.ResourceMetrics {
+	return (*[]*otlpmetrics.ResourceMetrics)(es.shared.read(unsafe.Pointer(es.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (es ResourceMetricsSlice) getMutableOrig() *[]*otlpmetrics.ResourceMetrics {
+	return (*[]*otlpmetrics.ResourceMetrics)(es.shared.write(unsafe.Pointer(es.orig)))
 }
 
 // Len returns the number of elements in the slice.
 //
 // Returns "0" for a newly instance created with "NewResourceMetricsSlice()".
 func (es ResourceMetricsSlice) Len() int {
-	return len(*es.orig)
+	return len(*es.getOrig())
 }
 
 // At returns the element at the given index.
@@ -62,27 +77,34 @@ func (es ResourceMetricsSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ResourceMetricsSlice) At(ix int) ResourceMetrics {
-	return newResourceMetrics((*es.orig)[ix])
+	orig := (*es.getOrig())[ix]
+	return ResourceMetrics{orig: orig, shared: es.sharedElements.get(orig)}
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ResourceMetricsSlice) CopyTo(dest ResourceMetricsSlice) {
-	srcLen := es.Len()
-	destCap := cap(*dest.orig)
+	destOrig := dest.getMutableOrig()
+	orig := es.getOrig()
+	srcLen := len(*orig)
+	destCap := cap(*destOrig)
+	dest.sharedElements.drop((*destOrig)[:destCap])
 	if srcLen <= destCap {
-		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
-		for i := range *es.orig {
-			newResourceMetrics((*es.orig)[i]).CopyTo(newResourceMetrics((*dest.orig)[i]))
+		(*destOrig) = (*destOrig)[:srcLen:destCap]
+		for i := range *orig {
+			if (*destOrig)[i] == nil {
+				(*destOrig)[i] = &otlpmetrics.ResourceMetrics{}
+			}
+			newResourceMetrics((*orig)[i]).CopyTo(newResourceMetrics((*destOrig)[i]))
 		}
 		return
 	}
 	origs := make([]otlpmetrics.ResourceMetrics, srcLen)
 	wrappers := make([]*otlpmetrics.ResourceMetrics, srcLen)
-	for i := range *es.orig {
+	for i := range *orig {
 		wrappers[i] = &origs[i]
-		newResourceMetrics((*es.orig)[i]).CopyTo(newResourceMetrics(wrappers[i]))
+		newResourceMetrics((*orig)[i]).CopyTo(newResourceMetrics(wrappers[i]))
 	}
-	*dest.orig = wrappers
+	*destOrig = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -97,20 +119,22 @@ func (es ResourceMetricsSlice) CopyTo(dest ResourceMetricsSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es ResourceMetricsSlice) EnsureCapacity(newCap int) {
-	oldCap := cap(*es.orig)
+	oldCap := cap(*es.getOrig())
 	if newCap <= oldCap {
 		return
 	}
 
-	newOrig := make([]*otlpmetrics.ResourceMetrics, len(*es.orig), newCap)
-	copy(newOrig, *es.orig)
-	*es.orig = newOrig
+	orig := es.getMutableOrig()
+	newOrig := make([]*otlpmetrics.ResourceMetrics, len(*orig), newCap)
+	copy(newOrig, *orig)
+	*orig = newOrig
 }
 
 // AppendEmpty will append to the end of the slice an empty ResourceMetrics.
 // It returns the newly added ResourceMetrics.
 func (es ResourceMetricsSlice) AppendEmpty() ResourceMetrics {
-	*es.orig = append(*es.orig, &otlpmetrics.ResourceMetrics{})
+	orig := es.getMutableOrig()
+	*orig = append(*orig, &otlpmetrics.ResourceMetrics{})
 	return es.At(es.Len() - 1)
 }
 
@@ -124,28 +148,32 @@ func (es ResourceMetricsSlice) AppendEmpty() ResourceMetrics {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es ResourceMetricsSlice) Sort(less func(a, b ResourceMetrics) bool) ResourceMetricsSlice {
-	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	sort.SliceStable(*es.getMutableOrig(), func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
 
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ResourceMetricsSlice) MoveAndAppendTo(dest ResourceMetricsSlice) {
-	if *dest.orig == nil {
+	orig := es.getMutableOrig()
+	destOrig := dest.getMutableOrig()
+	es.sharedElements.moveTo(dest.sharedElements, *orig)
+	if *destOrig == nil {
 		// We can simply move the entire vector and avoid any allocations.
-		*dest.orig = *es.orig
+		*destOrig = *orig
 	} else {
-		*dest.orig = append(*dest.orig, *es.orig...)
+		*destOrig = append(*destOrig, *orig...)
 	}
-	*es.orig = nil
+	*orig = nil
 }
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es ResourceMetricsSlice) RemoveIf(f func(ResourceMetrics) bool) {
 	newLen := 0
-	for i := 0; i < len(*es.orig); i++ {
+	for i := 0; i < es.Len(); i++ {
 		if f(es.At(i)) {
+			es.sharedElements.remove((*es.getOrig())[i])
 			continue
 		}
 		if newLen == i {
@@ -153,11 +181,19 @@ func (es ResourceMetricsSlice) RemoveIf(f func(ResourceMetrics) bool) {
 			newLen++
 			continue
 		}
-		(*es.orig)[newLen] = (*es.orig)[i]
+		orig := es.getMutableOrig()
+		(*orig)[newLen] = (*orig)[i]
 		newLen++
 	}
-	// TODO: Prevent memory leak by erasing truncated values.
-	*es.orig = (*es.orig)[:newLen]
+	if newLen == es.Len() {
+		return
+	}
+	orig := es.getMutableOrig()
+	// Erase truncated values to not keep references to removed elements.
+	for i := newLen; i < len(*orig); i++ {
+		(*orig)[i] = nil
+	}
+	*orig = (*orig)[:newLen]
 }
 
 // ResourceMetrics is a collection of metrics from a Resource.
@@ -169,12 +205,24 @@ func (es ResourceMetricsSlice) RemoveIf(f func(ResourceMetrics) bool) {
 // Important: zero-initialized instance is not valid for use.
 type ResourceMetrics struct {
 	orig *otlpmetrics.ResourceMetrics
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newResourceMetrics(orig *otlpmetrics.ResourceMetrics) ResourceMetrics {
 	return ResourceMetrics{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms ResourceMetrics) getOrig() *otlpmetrics.ResourceMetrics {
+	return (*otlpmetrics.ResourceMetrics)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms ResourceMetrics) getMutableOrig() *otlpmetrics.ResourceMetrics {
+	return (*otlpmetrics.ResourceMetrics)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewResourceMetrics creates a new empty ResourceMetrics.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -186,28 +234,29 @@ func NewResourceMetrics() ResourceMetrics {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms ResourceMetrics) MoveTo(dest ResourceMetrics) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlpmetrics.ResourceMetrics{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlpmetrics.ResourceMetrics{}
 }
 
 // Resource returns the resource associated with this ResourceMetrics.
 func (ms ResourceMetrics) Resource() Resource {
-	return newResource(&(*ms.orig).Resource)
+	return Resource{orig: &ms.getOrig().Resource, shared: ms.shared}
 }
 
 // SchemaUrl returns the schemaurl associated with this ResourceMetrics.
 func (ms ResourceMetrics) SchemaUrl() string {
-	return (*ms.orig).SchemaUrl
+	return ms.getOrig().SchemaUrl
 }
 
 // SetSchemaUrl replaces the schemaurl associated with this ResourceMetrics.
 func (ms ResourceMetrics) SetSchemaUrl(v string) {
-	(*ms.orig).SchemaUrl = v
+	ms.getMutableOrig().SchemaUrl = v
 }
 
 // ScopeMetrics returns the ScopeMetrics associated with this ResourceMetrics.
 func (ms ResourceMetrics) ScopeMetrics() ScopeMetricsSlice {
-	return newScopeMetricsSlice(&(*ms.orig).ScopeMetrics)
+	return ScopeMetricsSlice{orig: &ms.getOrig().ScopeMetrics, shared: ms.shared}
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -228,24 +277,36 @@ type ScopeMetricsSlice struct {
 	// orig points to the slice otlpmetrics.ScopeMetrics field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
 	orig *[]*otlpmetrics.ScopeMetrics
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newScopeMetricsSlice(orig *[]*otlpmetrics.ScopeMetrics) ScopeMetricsSlice {
-	return ScopeMetricsSlice{orig}
+	return ScopeMetricsSlice{orig: orig}
 }
 
 // NewScopeMetricsSlice creates a ScopeMetricsSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewScopeMetricsSlice() ScopeMetricsSlice {
 	orig := []*otlpmetrics.ScopeMetrics(nil)
-	return ScopeMetricsSlice{&orig}
+	return ScopeMetricsSlice{orig: &orig}
+}
+
+// getOrig returns the orig to read.
+func (es ScopeMetricsSlice) getOrig() *[]*otlpmetrics.ScopeMetrics {
+	return (*[]*otlpmetrics.ScopeMetrics)(es.shared.read(unsafe.Pointer(es.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (es ScopeMetricsSlice) getMutableOrig() *[]*otlpmetrics.ScopeMetrics {
+	return (*[]*otlpmetrics.ScopeMetrics)(es.shared.write(unsafe.Pointer(es.orig)))
 }
 
 // Len returns the number of elements in the slice.
 //
 // Returns "0" for a newly instance created with "NewScopeMetricsSlice()".
 func (es ScopeMetricsSlice) Len() int {
-	return len(*es.orig)
+	return len(*es.getOrig())
 }
 
 // At returns the element at the given index.
@@ -256,27 +317,33 @@ func (es ScopeMetricsSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ScopeMetricsSlice) At(ix int) ScopeMetrics {
-	return newScopeMetrics((*es.orig)[ix])
+	orig := (*es.getOrig())[ix]
+	return ScopeMetrics{orig: orig, shared: es.shared}
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ScopeMetricsSlice) CopyTo(dest ScopeMetricsSlice) {
-	srcLen := es.Len()
-	destCap := cap(*dest.orig)
+	destOrig := dest.getMutableOrig()
+	orig := es.getOrig()
+	srcLen := len(*orig)
+	destCap := cap(*destOrig)
 	if srcLen <= destCap {
-		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
-		for i := range *es.orig {
-			newScopeMetrics((*es.orig)[i]).CopyTo(newScopeMetrics((*dest.orig)[i]))
+		(*destOrig) = (*destOrig)[:srcLen:destCap]
+		for i := range *orig {
+			if (*destOrig)[i] == nil {
+				(*destOrig)[i] = &otlpmetrics.ScopeMetrics{}
+			}
+			newScopeMetrics((*orig)[i]).CopyTo(newScopeMetrics((*destOrig)[i]))
 		}
 		return
 	}
 	origs := make([]otlpmetrics.ScopeMetrics, srcLen)
 	wrappers := make([]*otlpmetrics.ScopeMetrics, srcLen)
-	for i := range *es.orig {
+	for i := range *orig {
 		wrappers[i] = &origs[i]
-		newScopeMetrics((*es.orig)[i]).CopyTo(newScopeMetrics(wrappers[i]))
+		newScopeMetrics((*orig)[i]).CopyTo(newScopeMetrics(wrappers[i]))
 	}
-	*dest.orig = wrappers
+	*destOrig = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -291,20 +358,22 @@ func (es ScopeMetricsSlice) CopyTo(dest ScopeMetricsSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es ScopeMetricsSlice) EnsureCapacity(newCap int) {
-	oldCap := cap(*es.orig)
+	oldCap := cap(*es.getOrig())
 	if newCap <= oldCap {
 		return
 	}
 
-	newOrig := make([]*otlpmetrics.ScopeMetrics, len(*es.orig), newCap)
-	copy(newOrig, *es.orig)
-	*es.orig = newOrig
+	orig := es.getMutableOrig()
+	newOrig := make([]*otlpmetrics.ScopeMetrics, len(*orig), newCap)
+	copy(newOrig, *orig)
+	*orig = newOrig
 }
 
 // AppendEmpty will append to the end of the slice an empty ScopeMetrics.
 // It returns the newly added ScopeMetrics.
 func (es ScopeMetricsSlice) AppendEmpty() ScopeMetrics {
-	*es.orig = append(*es.orig, &otlpmetrics.ScopeMetrics{})
+	orig := es.getMutableOrig()
+	*orig = append(*orig, &otlpmetrics.ScopeMetrics{})
 	return es.At(es.Len() - 1)
 }
 
@@ -318,27 +387,29 @@ func (es ScopeMetricsSlice) AppendEmpty() ScopeMetrics {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es ScopeMetricsSlice) Sort(less func(a, b ScopeMetrics) bool) ScopeMetricsSlice {
-	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	sort.SliceStable(*es.getMutableOrig(), func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
 
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ScopeMetricsSlice) MoveAndAppendTo(dest ScopeMetricsSlice) {
-	if *dest.orig == nil {
+	orig := es.getMutableOrig()
+	destOrig := dest.getMutableOrig()
+	if *destOrig == nil {
 		// We can simply move the entire vector and avoid any allocations.
-		*dest.orig = *es.orig
+		*destOrig = *orig
 	} else {
-		*dest.orig = append(*dest.orig, *es.orig...)
+		*destOrig = append(*destOrig, *orig...)
 	}
-	*es.orig = nil
+	*orig = nil
 }
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es ScopeMetricsSlice) RemoveIf(f func(ScopeMetrics) bool) {
 	newLen := 0
-	for i := 0; i < len(*es.orig); i++ {
+	for i := 0; i < es.Len(); i++ {
 		if f(es.At(i)) {
 			continue
 		}
@@ -347,11 +418,19 @@ func (es ScopeMetricsSlice) RemoveIf(f func(ScopeMetrics) bool) {
 			newLen++
 			continue
 		}
-		(*es.orig)[newLen] = (*es.orig)[i]
+		orig := es.getMutableOrig()
+		(*orig)[newLen] = (*orig)[i]
 		newLen++
 	}
-	// TODO: Prevent memory leak by erasing truncated values.
-	*es.orig = (*es.orig)[:newLen]
+	if newLen == es.Len() {
+		return
+	}
+	orig := es.getMutableOrig()
+	// Erase truncated values to not keep references to removed elements.
+	for i := newLen; i < len(*orig); i++ {
+		(*orig)[i] = nil
+	}
+	*orig = (*orig)[:newLen]
 }
 
 // ScopeMetrics is a collection of metrics from a LibraryInstrumentation.
@@ -363,12 +442,24 @@ func (es ScopeMetricsSlice) RemoveIf(f func(ScopeMetrics) bool) {
 // Important: zero-initialized instance is not valid for use.
 type ScopeMetrics struct {
 	orig *otlpmetrics.ScopeMetrics
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newScopeMetrics(orig *otlpmetrics.ScopeMetrics) ScopeMetrics {
 	return ScopeMetrics{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms ScopeMetrics) getOrig() *otlpmetrics.ScopeMetrics {
+	return (*otlpmetrics.ScopeMetrics)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms ScopeMetrics) getMutableOrig() *otlpmetrics.ScopeMetrics {
+	return (*otlpmetrics.ScopeMetrics)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewScopeMetrics creates a new empty ScopeMetrics.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -380,28 +471,29 @@ func NewScopeMetrics() ScopeMetrics {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms ScopeMetrics) MoveTo(dest ScopeMetrics) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlpmetrics.ScopeMetrics{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlpmetrics.ScopeMetrics{}
 }
 
 // Scope returns the scope associated with this ScopeMetrics.
 func (ms ScopeMetrics) Scope() InstrumentationScope {
-	return newInstrumentationScope(&(*ms.orig).Scope)
+	return InstrumentationScope{orig: &ms.getOrig().Scope, shared: ms.shared}
 }
 
 // SchemaUrl returns the schemaurl associated with this ScopeMetrics.
 func (ms ScopeMetrics) SchemaUrl() string {
-	return (*ms.orig).SchemaUrl
+	return ms.getOrig().SchemaUrl
 }
 
 // SetSchemaUrl replaces the schemaurl associated with this ScopeMetrics.
 func (ms ScopeMetrics) SetSchemaUrl(v string) {
-	(*ms.orig).SchemaUrl = v
+	ms.getMutableOrig().SchemaUrl = v
 }
 
 // Metrics returns the Metrics associated with this ScopeMetrics.
 func (ms ScopeMetrics) Metrics() MetricSlice {
-	return newMetricSlice(&(*ms.orig).Metrics)
+	return MetricSlice{orig: &ms.getOrig().Metrics, shared: ms.shared}
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -422,24 +514,36 @@ type MetricSlice struct {
 	// orig points to the slice otlpmetrics.Metric field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
 	orig *[]*otlpmetrics.Metric
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newMetricSlice(orig *[]*otlpmetrics.Metric) MetricSlice {
-	return MetricSlice{orig}
+	return MetricSlice{orig: orig}
 }
 
 // NewMetricSlice creates a MetricSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewMetricSlice() MetricSlice {
 	orig := []*otlpmetrics.Metric(nil)
-	return MetricSlice{&orig}
+	return MetricSlice{orig: &orig}
+}
+
+// getOrig returns the orig to read.
+func (es MetricSlice) getOrig() *[]*otlpmetrics.Metric {
+	return (*[]*otlpmetrics.Metric)(es.shared.read(unsafe.Pointer(es.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (es MetricSlice) getMutableOrig() *[]*otlpmetrics.Metric {
+	return (*[]*otlpmetrics.Metric)(es.shared.write(unsafe.Pointer(es.orig)))
 }
 
 // Len returns the number of elements in the slice.
 //
 // Returns "0" for a newly instance created with "NewMetricSlice()".
 func (es MetricSlice) Len() int {
-	return len(*es.orig)
+	return len(*es.getOrig())
 }
 
 // At returns the element at the given index.
@@ -450,27 +554,33 @@ func (es MetricSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es MetricSlice) At(ix int) Metric {
-	return newMetric((*es.orig)[ix])
+	orig := (*es.getOrig())[ix]
+	return Metric{orig: orig, shared: es.shared}
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es MetricSlice) CopyTo(dest MetricSlice) {
-	srcLen := es.Len()
-	destCap := cap(*dest.orig)
+	destOrig := dest.getMutableOrig()
+	orig := es.getOrig()
+	srcLen := len(*orig)
+	destCap := cap(*destOrig)
 	if srcLen <= destCap {
-		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
-		for i := range *es.orig {
-			newMetric((*es.orig)[i]).CopyTo(newMetric((*dest.orig)[i]))
+		(*destOrig) = (*destOrig)[:srcLen:destCap]
+		for i := range *orig {
+			if (*destOrig)[i] == nil {
+				(*destOrig)[i] = &otlpmetrics.Metric{}
+			}
+			newMetric((*orig)[i]).CopyTo(newMetric((*destOrig)[i]))
 		}
 		return
 	}
 	origs := make([]otlpmetrics.Metric, srcLen)
 	wrappers := make([]*otlpmetrics.Metric, srcLen)
-	for i := range *es.orig {
+	for i := range *orig {
 		wrappers[i] = &origs[i]
-		newMetric((*es.orig)[i]).CopyTo(newMetric(wrappers[i]))
+		newMetric((*orig)[i]).CopyTo(newMetric(wrappers[i]))
 	}
-	*dest.orig = wrappers
+	*destOrig = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -485,20 +595,22 @@ func (es MetricSlice) CopyTo(dest MetricSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es MetricSlice) EnsureCapacity(newCap int) {
-	oldCap := cap(*es.orig)
+	oldCap := cap(*es.getOrig())
 	if newCap <= oldCap {
 		return
 	}
 
-	newOrig := make([]*otlpmetrics.Metric, len(*es.orig), newCap)
-	copy(newOrig, *es.orig)
-	*es.orig = newOrig
+	orig := es.getMutableOrig()
+	newOrig := make([]*otlpmetrics.Metric, len(*orig), newCap)
+	copy(newOrig, *orig)
+	*orig = newOrig
 }
 
 // AppendEmpty will append to the end of the slice an empty Metric.
 // It returns the newly added Metric.
 func (es MetricSlice) AppendEmpty() Metric {
-	*es.orig = append(*es.orig, &otlpmetrics.Metric{})
+	orig := es.getMutableOrig()
+	*orig = append(*orig, &otlpmetrics.Metric{})
 	return es.At(es.Len() - 1)
 }
 
@@ -512,27 +624,29 @@ func (es MetricSlice) AppendEmpty() Metric {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es MetricSlice) Sort(less func(a, b Metric) bool) MetricSlice {
-	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	sort.SliceStable(*es.getMutableOrig(), func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
 
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es MetricSlice) MoveAndAppendTo(dest MetricSlice) {
-	if *dest.orig == nil {
+	orig := es.getMutableOrig()
+	destOrig := dest.getMutableOrig()
+	if *destOrig == nil {
 		// We can simply move the entire vector and avoid any allocations.
-		*dest.orig = *es.orig
+		*destOrig = *orig
 	} else {
-		*dest.orig = append(*dest.orig, *es.orig...)
+		*destOrig = append(*destOrig, *orig...)
 	}
-	*es.orig = nil
+	*orig = nil
 }
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es MetricSlice) RemoveIf(f func(Metric) bool) {
 	newLen := 0
-	for i := 0; i < len(*es.orig); i++ {
+	for i := 0; i < es.Len(); i++ {
 		if f(es.At(i)) {
 			continue
 		}
@@ -541,11 +655,19 @@ func (es MetricSlice) RemoveIf(f func(Metric) bool) {
 			newLen++
 			continue
 		}
-		(*es.orig)[newLen] = (*es.orig)[i]
+		orig := es.getMutableOrig()
+		(*orig)[newLen] = (*orig)[i]
 		newLen++
 	}
-	// TODO: Prevent memory leak by erasing truncated values.
-	*es.orig = (*es.orig)[:newLen]
+	if newLen == es.Len() {
+		return
+	}
+	orig := es.getMutableOrig()
+	// Erase truncated values to not keep references to removed elements.
+	for i := newLen; i < len(*orig); i++ {
+		(*orig)[i] = nil
+	}
+	*orig = (*orig)[:newLen]
 }
 
 // Metric represents one metric as a collection of datapoints.
@@ -558,12 +680,24 @@ func (es MetricSlice) RemoveIf(f func(Metric) bool) {
 // Important: zero-initialized instance is not valid for use.
 type Metric struct {
 	orig *otlpmetrics.Metric
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newMetric(orig *otlpmetrics.Metric) Metric {
 	return Metric{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms Metric) getOrig() *otlpmetrics.Metric {
+	return (*otlpmetrics.Metric)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms Metric) getMutableOrig() *otlpmetrics.Metric {
+	return (*otlpmetrics.Metric)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewMetric creates a new empty Metric.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -575,44 +709,45 @@ func NewMetric() Metric {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms Metric) MoveTo(dest Metric) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlpmetrics.Metric{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlpmetrics.Metric{}
 }
 
 // Name returns the name associated with this Metric.
 func (ms Metric) Name() string {
-	return (*ms.orig).Name
+	return ms.getOrig().Name
 }
 
 // SetName replaces the name associated with this Metric.
 func (ms Metric) SetName(v string) {
-	(*ms.orig).Name = v
+	ms.getMutableOrig().Name = v
 }
 
 // Description returns the description associated with this Metric.
 func (ms Metric) Description() string {
-	return (*ms.orig).Description
+	return ms.getOrig().Description
 }
 
 // SetDescription replaces the description associated with this Metric.
 func (ms Metric) SetDescription(v string) {
-	(*ms.orig).Description = v
+	ms.getMutableOrig().Description = v
 }
 
 // Unit returns the unit associated with this Metric.
 func (ms Metric) Unit() string {
-	return (*ms.orig).Unit
+	return ms.getOrig().Unit
 }
 
 // SetUnit replaces the unit associated with this Metric.
 func (ms Metric) SetUnit(v string) {
-	(*ms.orig).Unit = v
+	ms.getMutableOrig().Unit = v
 }
 
 // DataType returns the type of the data for this Metric.
 // Calling this function on zero-initialized Metric will cause a panic.
 func (ms Metric) DataType() MetricDataType {
-	switch ms.orig.Data.(type) {
+	switch ms.getOrig().Data.(type) {
 	case *otlpmetrics.Metric_Gauge:
 		return MetricDataTypeGauge
 	case *otlpmetrics.Metric_Sum:
@@ -634,11 +769,11 @@ func (ms Metric) DataType() MetricDataType {
 //
 // Calling this function on zero-initialized Metric will cause a panic.
 func (ms Metric) Gauge() Gauge {
-	v, ok := ms.orig.GetData().(*otlpmetrics.Metric_Gauge)
+	v, ok := ms.getOrig().GetData().(*otlpmetrics.Metric_Gauge)
 	if !ok {
 		return Gauge{}
 	}
-	return newGauge(v.Gauge)
+	return Gauge{orig: v.Gauge, shared: ms.shared}
 }
 
 // Sum returns the sum associated with this Metric.
@@ -648,11 +783,11 @@ func (ms Metric) Gauge() Gauge {
 //
 // Calling this function on zero-initialized Metric will cause a panic.
 func (ms Metric) Sum() Sum {
-	v, ok := ms.orig.GetData().(*otlpmetrics.Metric_Sum)
+	v, ok := ms.getOrig().GetData().(*otlpmetrics.Metric_Sum)
 	if !ok {
 		return Sum{}
 	}
-	return newSum(v.Sum)
+	return Sum{orig: v.Sum, shared: ms.shared}
 }
 
 // Histogram returns the histogram associated with this Metric.
@@ -662,11 +797,11 @@ func (ms Metric) Sum() Sum {
 //
 // Calling this function on zero-initialized Metric will cause a panic.
 func (ms Metric) Histogram() Histogram {
-	v, ok := ms.orig.GetData().(*otlpmetrics.Metric_Histogram)
+	v, ok := ms.getOrig().GetData().(*otlpmetrics.Metric_Histogram)
 	if !ok {
 		return Histogram{}
 	}
-	return newHistogram(v.Histogram)
+	return Histogram{orig: v.Histogram, shared: ms.shared}
 }
 
 // ExponentialHistogram returns the exponentialhistogram associated with this Metric.
@@ -676,11 +811,11 @@ func (ms Metric) Histogram() Histogram {
 //
 // Calling this function on zero-initialized Metric will cause a panic.
 func (ms Metric) ExponentialHistogram() ExponentialHistogram {
-	v, ok := ms.orig.GetData().(*otlpmetrics.Metric_ExponentialHistogram)
+	v, ok := ms.getOrig().GetData().(*otlpmetrics.Metric_ExponentialHistogram)
 	if !ok {
 		return ExponentialHistogram{}
 	}
-	return newExponentialHistogram(v.ExponentialHistogram)
+	return ExponentialHistogram{orig: v.ExponentialHistogram, shared: ms.shared}
 }
 
 // Summary returns the summary associated with this Metric.
@@ -690,11 +825,11 @@ func (ms Metric) ExponentialHistogram() ExponentialHistogram {
 //
 // Calling this function on zero-initialized Metric will cause a panic.
 func (ms Metric) Summary() Summary {
-	v, ok := ms.orig.GetData().(*otlpmetrics.Metric_Summary)
+	v, ok := ms.getOrig().GetData().(*otlpmetrics.Metric_Summary)
 	if !ok {
 		return Summary{}
 	}
-	return newSummary(v.Summary)
+	return Summary{orig: v.Summary, shared: ms.shared}
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -703,20 +838,32 @@ func (ms Metric) CopyTo(dest Metric) {
 	dest.SetDescription(ms.Description())
 	dest.SetUnit(ms.Unit())
 	switch ms.DataType() {
+	case MetricDataTypeNone:
+		dest.getMutableOrig().Data = nil
 	case MetricDataTypeGauge:
-		dest.SetDataType(MetricDataTypeGauge)
+		if dest.DataType() != MetricDataTypeGauge {
+			dest.SetDataType(MetricDataTypeGauge)
+		}
 		ms.Gauge().CopyTo(dest.Gauge())
 	case MetricDataTypeSum:
-		dest.SetDataType(MetricDataTypeSum)
+		if dest.DataType() != MetricDataTypeSum {
+			dest.SetDataType(MetricDataTypeSum)
+		}
 		ms.Sum().CopyTo(dest.Sum())
 	case MetricDataTypeHistogram:
-		dest.SetDataType(MetricDataTypeHistogram)
+		if dest.DataType() != MetricDataTypeHistogram {
+			dest.SetDataType(MetricDataTypeHistogram)
+		}
 		ms.Histogram().CopyTo(dest.Histogram())
 	case MetricDataTypeExponentialHistogram:
-		dest.SetDataType(MetricDataTypeExponentialHistogram)
+		if dest.DataType() != MetricDataTypeExponentialHistogram {
+			dest.SetDataType(MetricDataTypeExponentialHistogram)
+		}
 		ms.ExponentialHistogram().CopyTo(dest.ExponentialHistogram())
 	case MetricDataTypeSummary:
-		dest.SetDataType(MetricDataTypeSummary)
+		if dest.DataType() != MetricDataTypeSummary {
+			dest.SetDataType(MetricDataTypeSummary)
+		}
 		ms.Summary().CopyTo(dest.Summary())
 	}
 
@@ -731,12 +878,24 @@ func (ms Metric) CopyTo(dest Metric) {
 // Important: zero-initialized instance is not valid for use.
 type Gauge struct {
 	orig *otlpmetrics.Gauge
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newGauge(orig *otlpmetrics.Gauge) Gauge {
 	return Gauge{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms Gauge) getOrig() *otlpmetrics.Gauge {
+	return (*otlpmetrics.Gauge)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms Gauge) getMutableOrig() *otlpmetrics.Gauge {
+	return (*otlpmetrics.Gauge)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewGauge creates a new empty Gauge.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -748,13 +907,14 @@ func NewGauge() Gauge {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms Gauge) MoveTo(dest Gauge) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlpmetrics.Gauge{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlpmetrics.Gauge{}
 }
 
 // DataPoints returns the DataPoints associated with this Gauge.
 func (ms Gauge) DataPoints() NumberDataPointSlice {
-	return newNumberDataPointSlice(&(*ms.orig).DataPoints)
+	return NumberDataPointSlice{orig: &ms.getOrig().DataPoints, shared: ms.shared}
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -771,12 +931,24 @@ func (ms Gauge) CopyTo(dest Gauge) {
 // Important: zero-initialized instance is not valid for use.
 type Sum struct {
 	orig *otlpmetrics.Sum
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newSum(orig *otlpmetrics.Sum) Sum {
 	return Sum{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms Sum) getOrig() *otlpmetrics.Sum {
+	return (*otlpmetrics.Sum)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms Sum) getMutableOrig() *otlpmetrics.Sum {
+	return (*otlpmetrics.Sum)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewSum creates a new empty Sum.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -788,33 +960,34 @@ func NewSum() Sum {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms Sum) MoveTo(dest Sum) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlpmetrics.Sum{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlpmetrics.Sum{}
 }
 
 // AggregationTemporality returns the aggregationtemporality associated with this Sum.
 func (ms Sum) AggregationTemporality() MetricAggregationTemporality {
-	return MetricAggregationTemporality((*ms.orig).AggregationTemporality)
+	return MetricAggregationTemporality(ms.getOrig().AggregationTemporality)
 }
 
 // SetAggregationTemporality replaces the aggregationtemporality associated with this Sum.
 func (ms Sum) SetAggregationTemporality(v MetricAggregationTemporality) {
-	(*ms.orig).AggregationTemporality = otlpmetrics.AggregationTemporality(v)
+	ms.getMutableOrig().AggregationTemporality = otlpmetrics.AggregationTemporality(v)
 }
 
 // IsMonotonic returns the ismonotonic associated with this Sum.
 func (ms Sum) IsMonotonic() bool {
-	return (*ms.orig).IsMonotonic
+	return ms.getOrig().IsMonotonic
 }
 
 // SetIsMonotonic replaces the ismonotonic associated with this Sum.
 func (ms Sum) SetIsMonotonic(v bool) {
-	(*ms.orig).IsMonotonic = v
+	ms.getMutableOrig().IsMonotonic = v
 }
 
 // DataPoints returns the DataPoints associated with this Sum.
 func (ms Sum) DataPoints() NumberDataPointSlice {
-	return newNumberDataPointSlice(&(*ms.orig).DataPoints)
+	return NumberDataPointSlice{orig: &ms.getOrig().DataPoints, shared: ms.shared}
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -833,12 +1006,24 @@ func (ms Sum) CopyTo(dest Sum) {
 // Important: zero-initialized instance is not valid for use.
 type Histogram struct {
 	orig *otlpmetrics.Histogram
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newHistogram(orig *otlpmetrics.Histogram) Histogram {
 	return Histogram{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms Histogram) getOrig() *otlpmetrics.Histogram {
+	return (*otlpmetrics.Histogram)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms Histogram) getMutableOrig() *otlpmetrics.Histogram {
+	return (*otlpmetrics.Histogram)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewHistogram creates a new empty Histogram.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -850,23 +1035,24 @@ func NewHistogram() Histogram {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms Histogram) MoveTo(dest Histogram) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlpmetrics.Histogram{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlpmetrics.Histogram{}
 }
 
 // AggregationTemporality returns the aggregationtemporality associated with this Histogram.
 func (ms Histogram) AggregationTemporality() MetricAggregationTemporality {
-	return MetricAggregationTemporality((*ms.orig).AggregationTemporality)
+	return MetricAggregationTemporality(ms.getOrig().AggregationTemporality)
 }
 
 // SetAggregationTemporality replaces the aggregationtemporality associated with this Histogram.
 func (ms Histogram) SetAggregationTemporality(v MetricAggregationTemporality) {
-	(*ms.orig).AggregationTemporality = otlpmetrics.AggregationTemporality(v)
+	ms.getMutableOrig().AggregationTemporality = otlpmetrics.AggregationTemporality(v)
 }
 
 // DataPoints returns the DataPoints associated with this Histogram.
 func (ms Histogram) DataPoints() HistogramDataPointSlice {
-	return newHistogramDataPointSlice(&(*ms.orig).DataPoints)
+	return HistogramDataPointSlice{orig: &ms.getOrig().DataPoints, shared: ms.shared}
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -885,12 +1071,24 @@ func (ms Histogram) CopyTo(dest Histogram) {
 // Important: zero-initialized instance is not valid for use.
 type ExponentialHistogram struct {
 	orig *otlpmetrics.ExponentialHistogram
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newExponentialHistogram(orig *otlpmetrics.ExponentialHistogram) ExponentialHistogram {
 	return ExponentialHistogram{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms ExponentialHistogram) getOrig() *otlpmetrics.ExponentialHistogram {
+	return (*otlpmetrics.ExponentialHistogram)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms ExponentialHistogram) getMutableOrig() *otlpmetrics.ExponentialHistogram {
+	return (*otlpmetrics.ExponentialHistogram)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewExponentialHistogram creates a new empty ExponentialHistogram.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -902,23 +1100,24 @@ func NewExponentialHistogram() ExponentialHistogram {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms ExponentialHistogram) MoveTo(dest ExponentialHistogram) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlpmetrics.ExponentialHistogram{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlpmetrics.ExponentialHistogram{}
 }
 
 // AggregationTemporality returns the aggregationtemporality associated with this ExponentialHistogram.
 func (ms ExponentialHistogram) AggregationTemporality() MetricAggregationTemporality {
-	return MetricAggregationTemporality((*ms.orig).AggregationTemporality)
+	return MetricAggregationTemporality(ms.getOrig().AggregationTemporality)
 }
 
 // SetAggregationTemporality replaces the aggregationtemporality associated with this ExponentialHistogram.
 func (ms ExponentialHistogram) SetAggregationTemporality(v MetricAggregationTemporality) {
-	(*ms.orig).AggregationTemporality = otlpmetrics.AggregationTemporality(v)
+	ms.getMutableOrig().AggregationTemporality = otlpmetrics.AggregationTemporality(v)
 }
 
 // DataPoints returns the DataPoints associated with this ExponentialHistogram.
 func (ms ExponentialHistogram) DataPoints() ExponentialHistogramDataPointSlice {
-	return newExponentialHistogramDataPointSlice(&(*ms.orig).DataPoints)
+	return ExponentialHistogramDataPointSlice{orig: &ms.getOrig().DataPoints, shared: ms.shared}
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -936,12 +1135,24 @@ func (ms ExponentialHistogram) CopyTo(dest ExponentialHistogram) {
 // Important: zero-initialized instance is not valid for use.
 type Summary struct {
 	orig *otlpmetrics.Summary
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newSummary(orig *otlpmetrics.Summary) Summary {
 	return Summary{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms Summary) getOrig() *otlpmetrics.Summary {
+	return (*otlpmetrics.Summary)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms Summary) getMutableOrig() *otlpmetrics.Summary {
+	return (*otlpmetrics.Summary)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewSummary creates a new empty Summary.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -953,13 +1164,14 @@ func NewSummary() Summary {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms Summary) MoveTo(dest Summary) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlpmetrics.Summary{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlpmetrics.Summary{}
 }
 
 // DataPoints returns the DataPoints associated with this Summary.
 func (ms Summary) DataPoints() SummaryDataPointSlice {
-	return newSummaryDataPointSlice(&(*ms.orig).DataPoints)
+	return SummaryDataPointSlice{orig: &ms.getOrig().DataPoints, shared: ms.shared}
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -978,24 +1190,36 @@ type NumberDataPointSlice struct {
 	// orig points to the slice otlpmetrics.NumberDataPoint field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
 	orig *[]*otlpmetrics.NumberDataPoint
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newNumberDataPointSlice(orig *[]*otlpmetrics.NumberDataPoint) NumberDataPointSlice {
-	return NumberDataPointSlice{orig}
+	return NumberDataPointSlice{orig: orig}
 }
 
 // NewNumberDataPointSlice creates a NumberDataPointSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewNumberDataPointSlice() NumberDataPointSlice {
 	orig := []*otlpmetrics.NumberDataPoint(nil)
-	return NumberDataPointSlice{&orig}
+	return NumberDataPointSlice{orig: &orig}
+}
+
+// getOrig returns the orig to read.
+func (es NumberDataPointSlice) getOrig() *[]*otlpmetrics.NumberDataPoint {
+	return (*[]*otlpmetrics.NumberDataPoint)(es.shared.read(unsafe.Pointer(es.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (es NumberDataPointSlice) getMutableOrig() *[]*otlpmetrics.NumberDataPoint {
+	return (*[]*otlpmetrics.NumberDataPoint)(es.shared.write(unsafe.Pointer(es.orig)))
 }
 
 // Len returns the number of elements in the slice.
 //
 // Returns "0" for a newly instance created with "NewNumberDataPointSlice()".
 func (es NumberDataPointSlice) Len() int {
-	return len(*es.orig)
+	return len(*es.getOrig())
 }
 
 // At returns the element at the given index.
@@ -1006,27 +1230,33 @@ func (es NumberDataPointSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es NumberDataPointSlice) At(ix int) NumberDataPoint {
-	return newNumberDataPoint((*es.orig)[ix])
+	orig := (*es.getOrig())[ix]
+	return NumberDataPoint{orig: orig, shared: es.shared}
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es NumberDataPointSlice) CopyTo(dest NumberDataPointSlice) {
-	srcLen := es.Len()
-	destCap := cap(*dest.orig)
+	destOrig := dest.getMutableOrig()
+	orig := es.getOrig()
+	srcLen := len(*orig)
+	destCap := cap(*destOrig)
 	if srcLen <= destCap {
-		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
-		for i := range *es.orig {
-			newNumberDataPoint((*es.orig)[i]).CopyTo(newNumberDataPoint((*dest.orig)[i]))
+		(*destOrig) = (*destOrig)[:srcLen:destCap]
+		for i := range *orig {
+			if (*destOrig)[i] == nil {
+				(*destOrig)[i] = &otlpmetrics.NumberDataPoint{}
+			}
+			newNumberDataPoint((*orig)[i]).CopyTo(newNumberDataPoint((*destOrig)[i]))
 		}
 		return
 	}
 	origs := make([]otlpmetrics.NumberDataPoint, srcLen)
 	wrappers := make([]*otlpmetrics.NumberDataPoint, srcLen)
-	for i := range *es.orig {
+	for i := range *orig {
 		wrappers[i] = &origs[i]
-		newNumberDataPoint((*es.orig)[i]).CopyTo(newNumberDataPoint(wrappers[i]))
+		newNumberDataPoint((*orig)[i]).CopyTo(newNumberDataPoint(wrappers[i]))
 	}
-	*dest.orig = wrappers
+	*destOrig = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -1041,20 +1271,22 @@ func (es NumberDataPointSlice) CopyTo(dest NumberDataPointSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es NumberDataPointSlice) EnsureCapacity(newCap int) {
-	oldCap := cap(*es.orig)
+	oldCap := cap(*es.getOrig())
 	if newCap <= oldCap {
 		return
 	}
 
-	newOrig := make([]*otlpmetrics.NumberDataPoint, len(*es.orig), newCap)
-	copy(newOrig, *es.orig)
-	*es.orig = newOrig
+	orig := es.getMutableOrig()
+	newOrig := make([]*otlpmetrics.NumberDataPoint, len(*orig), newCap)
+	copy(newOrig, *orig)
+	*orig = newOrig
 }
 
 // AppendEmpty will append to the end of the slice an empty NumberDataPoint.
 // It returns the newly added NumberDataPoint.
 func (es NumberDataPointSlice) AppendEmpty() NumberDataPoint {
-	*es.orig = append(*es.orig, &otlpmetrics.NumberDataPoint{})
+	orig := es.getMutableOrig()
+	*orig = append(*orig, &otlpmetrics.NumberDataPoint{})
 	return es.At(es.Len() - 1)
 }
 
@@ -1068,27 +1300,29 @@ func (es NumberDataPointSlice) AppendEmpty() NumberDataPoint {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es NumberDataPointSlice) Sort(less func(a, b NumberDataPoint) bool) NumberDataPointSlice {
-	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	sort.SliceStable(*es.getMutableOrig(), func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
 
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es NumberDataPointSlice) MoveAndAppendTo(dest NumberDataPointSlice) {
-	if *dest.orig == nil {
+	orig := es.getMutableOrig()
+	destOrig := dest.getMutableOrig()
+	if *destOrig == nil {
 		// We can simply move the entire vector and avoid any allocations.
-		*dest.orig = *es.orig
+		*destOrig = *orig
 	} else {
-		*dest.orig = append(*dest.orig, *es.orig...)
+		*destOrig = append(*destOrig, *orig...)
 	}
-	*es.orig = nil
+	*orig = nil
 }
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es NumberDataPointSlice) RemoveIf(f func(NumberDataPoint) bool) {
 	newLen := 0
-	for i := 0; i < len(*es.orig); i++ {
+	for i := 0; i < es.Len(); i++ {
 		if f(es.At(i)) {
 			continue
 		}
@@ -1097,11 +1331,19 @@ func (es NumberDataPointSlice) RemoveIf(f func(NumberDataPoint) bool) {
 			newLen++
 			continue
 		}
-		(*es.orig)[newLen] = (*es.orig)[i]
+		orig := es.getMutableOrig()
+		(*orig)[newLen] = (*orig)[i]
 		newLen++
 	}
-	// TODO: Prevent memory leak by erasing truncated values.
-	*es.orig = (*es.orig)[:newLen]
+	if newLen == es.Len() {
+		return
+	}
+	orig := es.getMutableOrig()
+	// Erase truncated values to not keep references to removed elements.
+	for i := newLen; i < len(*orig); i++ {
+		(*orig)[i] = nil
+	}
+	*orig = (*orig)[:newLen]
 }
 
 // NumberDataPoint is a single data point in a timeseries that describes the time-varying value of a number metric.
@@ -1113,12 +1355,24 @@ func (es NumberDataPointSlice) RemoveIf(f func(NumberDataPoint) bool) {
 // Important: zero-initialized instance is not valid for use.
 type NumberDataPoint struct {
 	orig *otlpmetrics.NumberDataPoint
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newNumberDataPoint(orig *otlpmetrics.NumberDataPoint) NumberDataPoint {
 	return NumberDataPoint{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms NumberDataPoint) getOrig() *otlpmetrics.NumberDataPoint {
+	return (*otlpmetrics.NumberDataPoint)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms NumberDataPoint) getMutableOrig() *otlpmetrics.NumberDataPoint {
+	return (*otlpmetrics.NumberDataPoint)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewNumberDataPoint creates a new empty NumberDataPoint.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -1130,39 +1384,40 @@ func NewNumberDataPoint() NumberDataPoint {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms NumberDataPoint) MoveTo(dest NumberDataPoint) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlpmetrics.NumberDataPoint{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlpmetrics.NumberDataPoint{}
 }
 
 // Attributes returns the Attributes associated with this NumberDataPoint.
 func (ms NumberDataPoint) Attributes() Map {
-	return newMap(&(*ms.orig).Attributes)
+	return Map{orig: &ms.getOrig().Attributes, shared: ms.shared}
 }
 
 // StartTimestamp returns the starttimestamp associated with this NumberDataPoint.
 func (ms NumberDataPoint) StartTimestamp() Timestamp {
-	return Timestamp((*ms.orig).StartTimeUnixNano)
+	return Timestamp(ms.getOrig().StartTimeUnixNano)
 }
 
 // SetStartTimestamp replaces the starttimestamp associated with this NumberDataPoint.
 func (ms NumberDataPoint) SetStartTimestamp(v Timestamp) {
-	(*ms.orig).StartTimeUnixNano = uint64(v)
+	ms.getMutableOrig().StartTimeUnixNano = uint64(v)
 }
 
 // Timestamp returns the timestamp associated with this NumberDataPoint.
 func (ms NumberDataPoint) Timestamp() Timestamp {
-	return Timestamp((*ms.orig).TimeUnixNano)
+	return Timestamp(ms.getOrig().TimeUnixNano)
 }
 
 // SetTimestamp replaces the timestamp associated with this NumberDataPoint.
 func (ms NumberDataPoint) SetTimestamp(v Timestamp) {
-	(*ms.orig).TimeUnixNano = uint64(v)
+	ms.getMutableOrig().TimeUnixNano = uint64(v)
 }
 
 // ValueType returns the type of the value for this NumberDataPoint.
 // Calling this function on zero-initialized NumberDataPoint will cause a panic.
 func (ms NumberDataPoint) ValueType() NumberDataPointValueType {
-	switch ms.orig.Value.(type) {
+	switch ms.getOrig().Value.(type) {
 	case *otlpmetrics.NumberDataPoint_AsDouble:
 		return NumberDataPointValueTypeDouble
 	case *otlpmetrics.NumberDataPoint_AsInt:
@@ -1173,41 +1428,41 @@ func (ms NumberDataPoint) ValueType() NumberDataPointValueType {
 
 // DoubleVal returns the doubleval associated with this NumberDataPoint.
 func (ms NumberDataPoint) DoubleVal() float64 {
-	return (*ms.orig).GetAsDouble()
+	return ms.getOrig().GetAsDouble()
 }
 
 // SetDoubleVal replaces the doubleval associated with this NumberDataPoint.
 func (ms NumberDataPoint) SetDoubleVal(v float64) {
-	(*ms.orig).Value = &otlpmetrics.NumberDataPoint_AsDouble{
+	ms.getMutableOrig().Value = &otlpmetrics.NumberDataPoint_AsDouble{
 		AsDouble: v,
 	}
 }
 
 // IntVal returns the intval associated with this NumberDataPoint.
 func (ms NumberDataPoint) IntVal() int64 {
-	return (*ms.orig).GetAsInt()
+	return ms.getOrig().GetAsInt()
 }
 
 // SetIntVal replaces the intval associated with this NumberDataPoint.
 func (ms NumberDataPoint) SetIntVal(v int64) {
-	(*ms.orig).Value = &otlpmetrics.NumberDataPoint_AsInt{
+	ms.getMutableOrig().Value = &otlpmetrics.NumberDataPoint_AsInt{
 		AsInt: v,
 	}
 }
 
 // Exemplars returns the Exemplars associated with this NumberDataPoint.
 func (ms NumberDataPoint) Exemplars() ExemplarSlice {
-	return newExemplarSlice(&(*ms.orig).Exemplars)
+	return ExemplarSlice{orig: &ms.getOrig().Exemplars, shared: ms.shared}
 }
 
 // Flags returns the flags associated with this NumberDataPoint.
 func (ms NumberDataPoint) Flags() MetricDataPointFlags {
-	return MetricDataPointFlags((*ms.orig).Flags)
+	return MetricDataPointFlags(ms.getOrig().Flags)
 }
 
 // SetFlags replaces the flags associated with this NumberDataPoint.
 func (ms NumberDataPoint) SetFlags(v MetricDataPointFlags) {
-	(*ms.orig).Flags = uint32(v)
+	ms.getMutableOrig().Flags = uint32(v)
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -1216,6 +1471,8 @@ func (ms NumberDataPoint) CopyTo(dest NumberDataPoint) {
 	dest.SetStartTimestamp(ms.StartTimestamp())
 	dest.SetTimestamp(ms.Timestamp())
 	switch ms.ValueType() {
+	case NumberDataPointValueTypeNone:
+		dest.getMutableOrig().Value = nil
 	case NumberDataPointValueTypeDouble:
 		dest.SetDoubleVal(ms.DoubleVal())
 	case NumberDataPointValueTypeInt:
@@ -1237,24 +1494,36 @@ type HistogramDataPointSlice struct {
 	// orig points to the slice otlpmetrics.HistogramDataPoint field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
 	orig *[]*otlpmetrics.HistogramDataPoint
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newHistogramDataPointSlice(orig *[]*otlpmetrics.HistogramDataPoint) HistogramDataPointSlice {
-	return HistogramDataPointSlice{orig}
+	return HistogramDataPointSlice{orig: orig}
 }
 
 // NewHistogramDataPointSlice creates a HistogramDataPointSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewHistogramDataPointSlice() HistogramDataPointSlice {
 	orig := []*otlpmetrics.HistogramDataPoint(nil)
-	return HistogramDataPointSlice{&orig}
+	return HistogramDataPointSlice{orig: &orig}
+}
+
+// getOrig returns the orig to read.
+func (es HistogramDataPointSlice) getOrig() *[]*otlpmetrics.HistogramDataPoint {
+	return (*[]*otlpmetrics.HistogramDataPoint)(es.shared.read(unsafe.Pointer(es.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (es HistogramDataPointSlice) getMutableOrig() *[]*otlpmetrics.HistogramDataPoint {
+	return (*[]*otlpmetrics.HistogramDataPoint)(es.shared.write(unsafe.Pointer(es.orig)))
 }
 
 // Len returns the number of elements in the slice.
 //
 // Returns "0" for a newly instance created with "NewHistogramDataPointSlice()".
 func (es HistogramDataPointSlice) Len() int {
-	return len(*es.orig)
+	return len(*es.getOrig())
 }
 
 // At returns the element at the given index.
@@ -1265,27 +1534,33 @@ func (es HistogramDataPointSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es HistogramDataPointSlice) At(ix int) HistogramDataPoint {
-	return newHistogramDataPoint((*es.orig)[ix])
+	orig := (*es.getOrig())[ix]
+	return HistogramDataPoint{orig: orig, shared: es.shared}
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es HistogramDataPointSlice) CopyTo(dest HistogramDataPointSlice) {
-	srcLen := es.Len()
-	destCap := cap(*dest.orig)
+	destOrig := dest.getMutableOrig()
+	orig := es.getOrig()
+	srcLen := len(*orig)
+	destCap := cap(*destOrig)
 	if srcLen <= destCap {
-		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
-		for i := range *es.orig {
-			newHistogramDataPoint((*es.orig)[i]).CopyTo(newHistogramDataPoint((*dest.orig)[i]))
+		(*destOrig) = (*destOrig)[:srcLen:destCap]
+		for i := range *orig {
+			if (*destOrig)[i] == nil {
+				(*destOrig)[i] = &otlpmetrics.HistogramDataPoint{}
+			}
+			newHistogramDataPoint((*orig)[i]).CopyTo(newHistogramDataPoint((*destOrig)[i]))
 		}
 		return
 	}
 	origs := make([]otlpmetrics.HistogramDataPoint, srcLen)
 	wrappers := make([]*otlpmetrics.HistogramDataPoint, srcLen)
-	for i := range *es.orig {
+	for i := range *orig {
 		wrappers[i] = &origs[i]
-		newHistogramDataPoint((*es.orig)[i]).CopyTo(newHistogramDataPoint(wrappers[i]))
+		newHistogramDataPoint((*orig)[i]).CopyTo(newHistogramDataPoint(wrappers[i]))
 	}
-	*dest.orig = wrappers
+	*destOrig = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -1300,20 +1575,22 @@ func (es HistogramDataPointSlice) CopyTo(dest HistogramDataPointSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es HistogramDataPointSlice) EnsureCapacity(newCap int) {
-	oldCap := cap(*es.orig)
+	oldCap := cap(*es.getOrig())
 	if newCap <= oldCap {
 		return
 	}
 
-	newOrig := make([]*otlpmetrics.HistogramDataPoint, len(*es.orig), newCap)
-	copy(newOrig, *es.orig)
-	*es.orig = newOrig
+	orig := es.getMutableOrig()
+	newOrig := make([]*otlpmetrics.HistogramDataPoint, len(*orig), newCap)
+	copy(newOrig, *orig)
+	*orig = newOrig
 }
 
 // AppendEmpty will append to the end of the slice an empty HistogramDataPoint.
 // It returns the newly added HistogramDataPoint.
 func (es HistogramDataPointSlice) AppendEmpty() HistogramDataPoint {
-	*es.orig = append(*es.orig, &otlpmetrics.HistogramDataPoint{})
+	orig := es.getMutableOrig()
+	*orig = append(*orig, &otlpmetrics.HistogramDataPoint{})
 	return es.At(es.Len() - 1)
 }
 
@@ -1327,27 +1604,29 @@ func (es HistogramDataPointSlice) AppendEmpty() HistogramDataPoint {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es HistogramDataPointSlice) Sort(less func(a, b HistogramDataPoint) bool) HistogramDataPointSlice {
-	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	sort.SliceStable(*es.getMutableOrig(), func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
 
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es HistogramDataPointSlice) MoveAndAppendTo(dest HistogramDataPointSlice) {
-	if *dest.orig == nil {
+	orig := es.getMutableOrig()
+	destOrig := dest.getMutableOrig()
+	if *destOrig == nil {
 		// We can simply move the entire vector and avoid any allocations.
-		*dest.orig = *es.orig
+		*destOrig = *orig
 	} else {
-		*dest.orig = append(*dest.orig, *es.orig...)
+		*destOrig = append(*destOrig, *orig...)
 	}
-	*es.orig = nil
+	*orig = nil
 }
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es HistogramDataPointSlice) RemoveIf(f func(HistogramDataPoint) bool) {
 	newLen := 0
-	for i := 0; i < len(*es.orig); i++ {
+	for i := 0; i < es.Len(); i++ {
 		if f(es.At(i)) {
 			continue
 		}
@@ -1356,11 +1635,19 @@ func (es HistogramDataPointSlice) RemoveIf(f func(HistogramDataPoint) bool) {
 			newLen++
 			continue
 		}
-		(*es.orig)[newLen] = (*es.orig)[i]
+		orig := es.getMutableOrig()
+		(*orig)[newLen] = (*orig)[i]
 		newLen++
 	}
-	// TODO: Prevent memory leak by erasing truncated values.
-	*es.orig = (*es.orig)[:newLen]
+	if newLen == es.Len() {
+		return
+	}
+	orig := es.getMutableOrig()
+	// Erase truncated values to not keep references to removed elements.
+	for i := newLen; i < len(*orig); i++ {
+		(*orig)[i] = nil
+	}
+	*orig = (*orig)[:newLen]
 }
 
 // HistogramDataPoint is a single data point in a timeseries that describes the time-varying values of a Histogram of values.
@@ -1372,12 +1659,24 @@ func (es HistogramDataPointSlice) RemoveIf(f func(HistogramDataPoint) bool) {
 // Important: zero-initialized instance is not valid for use.
 type HistogramDataPoint struct {
 	orig *otlpmetrics.HistogramDataPoint
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newHistogramDataPoint(orig *otlpmetrics.HistogramDataPoint) HistogramDataPoint {
 	return HistogramDataPoint{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms HistogramDataPoint) getOrig() *otlpmetrics.HistogramDataPoint {
+	return (*otlpmetrics.HistogramDataPoint)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms HistogramDataPoint) getMutableOrig() *otlpmetrics.HistogramDataPoint {
+	return (*otlpmetrics.HistogramDataPoint)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewHistogramDataPoint creates a new empty HistogramDataPoint.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -1389,94 +1688,95 @@ func NewHistogramDataPoint() HistogramDataPoint {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms HistogramDataPoint) MoveTo(dest HistogramDataPoint) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlpmetrics.HistogramDataPoint{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlpmetrics.HistogramDataPoint{}
 }
 
 // Attributes returns the Attributes associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) Attributes() Map {
-	return newMap(&(*ms.orig).Attributes)
+	return Map{orig: &ms.getOrig().Attributes, shared: ms.shared}
 }
 
 // StartTimestamp returns the starttimestamp associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) StartTimestamp() Timestamp {
-	return Timestamp((*ms.orig).StartTimeUnixNano)
+	return Timestamp(ms.getOrig().StartTimeUnixNano)
 }
 
 // SetStartTimestamp replaces the starttimestamp associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) SetStartTimestamp(v Timestamp) {
-	(*ms.orig).StartTimeUnixNano = uint64(v)
+	ms.getMutableOrig().StartTimeUnixNano = uint64(v)
 }
 
 // Timestamp returns the timestamp associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) Timestamp() Timestamp {
-	return Timestamp((*ms.orig).TimeUnixNano)
+	return Timestamp(ms.getOrig().TimeUnixNano)
 }
 
 // SetTimestamp replaces the timestamp associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) SetTimestamp(v Timestamp) {
-	(*ms.orig).TimeUnixNano = uint64(v)
+	ms.getMutableOrig().TimeUnixNano = uint64(v)
 }
 
 // Count returns the count associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) Count() uint64 {
-	return (*ms.orig).Count
+	return ms.getOrig().Count
 }
 
 // SetCount replaces the count associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) SetCount(v uint64) {
-	(*ms.orig).Count = v
+	ms.getMutableOrig().Count = v
 }
 
 // Sum returns the sum associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) Sum() float64 {
-	return (*ms.orig).GetSum()
+	return ms.getOrig().GetSum()
 }
 
 // HasSum returns true if the HistogramDataPoint contains a
 // Sum value, false otherwise.
 func (ms HistogramDataPoint) HasSum() bool {
-	return ms.orig.Sum_ != nil
+	return ms.getOrig().Sum_ != nil
 }
 
 // SetSum replaces the sum associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) SetSum(v float64) {
-	(*ms.orig).Sum_ = &otlpmetrics.HistogramDataPoint_Sum{Sum: v}
+	ms.getMutableOrig().Sum_ = &otlpmetrics.HistogramDataPoint_Sum{Sum: v}
 }
 
 // BucketCounts returns the bucketcounts associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) BucketCounts() []uint64 {
-	return (*ms.orig).BucketCounts
+	return ms.getOrig().BucketCounts
 }
 
 // SetBucketCounts replaces the bucketcounts associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) SetBucketCounts(v []uint64) {
-	(*ms.orig).BucketCounts = v
+	ms.getMutableOrig().BucketCounts = v
 }
 
 // ExplicitBounds returns the explicitbounds associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) ExplicitBounds() []float64 {
-	return (*ms.orig).ExplicitBounds
+	return ms.getOrig().ExplicitBounds
 }
 
 // SetExplicitBounds replaces the explicitbounds associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) SetExplicitBounds(v []float64) {
-	(*ms.orig).ExplicitBounds = v
+	ms.getMutableOrig().ExplicitBounds = v
 }
 
 // Exemplars returns the Exemplars associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) Exemplars() ExemplarSlice {
-	return newExemplarSlice(&(*ms.orig).Exemplars)
+	return ExemplarSlice{orig: &ms.getOrig().Exemplars, shared: ms.shared}
 }
 
 // Flags returns the flags associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) Flags() MetricDataPointFlags {
-	return MetricDataPointFlags((*ms.orig).Flags)
+	return MetricDataPointFlags(ms.getOrig().Flags)
 }
 
 // SetFlags replaces the flags associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) SetFlags(v MetricDataPointFlags) {
-	(*ms.orig).Flags = uint32(v)
+	ms.getMutableOrig().Flags = uint32(v)
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -1487,20 +1787,22 @@ func (ms HistogramDataPoint) CopyTo(dest HistogramDataPoint) {
 	dest.SetCount(ms.Count())
 	if ms.HasSum() {
 		dest.SetSum(ms.Sum())
+	} else {
+		dest.getMutableOrig().Sum_ = nil
 	}
 
-	if len(ms.orig.BucketCounts) == 0 {
-		dest.orig.BucketCounts = nil
+	if len(ms.getOrig().BucketCounts) == 0 {
+		dest.getMutableOrig().BucketCounts = nil
 	} else {
-		dest.orig.BucketCounts = make([]uint64, len(ms.orig.BucketCounts))
-		copy(dest.orig.BucketCounts, ms.orig.BucketCounts)
+		dest.getMutableOrig().BucketCounts = make([]uint64, len(ms.getOrig().BucketCounts))
+		copy(dest.getMutableOrig().BucketCounts, ms.getOrig().BucketCounts)
 	}
 
-	if len(ms.orig.ExplicitBounds) == 0 {
-		dest.orig.ExplicitBounds = nil
+	if len(ms.getOrig().ExplicitBounds) == 0 {
+		dest.getMutableOrig().ExplicitBounds = nil
 	} else {
-		dest.orig.ExplicitBounds = make([]float64, len(ms.orig.ExplicitBounds))
-		copy(dest.orig.ExplicitBounds, ms.orig.ExplicitBounds)
+		dest.getMutableOrig().ExplicitBounds = make([]float64, len(ms.getOrig().ExplicitBounds))
+		copy(dest.getMutableOrig().ExplicitBounds, ms.getOrig().ExplicitBounds)
 	}
 
 	ms.Exemplars().CopyTo(dest.Exemplars())
@@ -1518,24 +1820,36 @@ type ExponentialHistogramDataPointSlice struct {
 	// orig points to the slice otlpmetrics.ExponentialHistogramDataPoint field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
 	orig *[]*otlpmetrics.ExponentialHistogramDataPoint
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newExponentialHistogramDataPointSlice(orig *[]*otlpmetrics.ExponentialHistogramDataPoint) ExponentialHistogramDataPointSlice {
-	return ExponentialHistogramDataPointSlice{orig}
+	return ExponentialHistogramDataPointSlice{orig: orig}
 }
 
 // NewExponentialHistogramDataPointSlice creates a ExponentialHistogramDataPointSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewExponentialHistogramDataPointSlice() ExponentialHistogramDataPointSlice {
 	orig := []*otlpmetrics.ExponentialHistogramDataPoint(nil)
-	return ExponentialHistogramDataPointSlice{&orig}
+	return ExponentialHistogramDataPointSlice{orig: &orig}
+}
+
+// getOrig returns the orig to read.
+func (es ExponentialHistogramDataPointSlice) getOrig() *[]*otlpmetrics.ExponentialHistogramDataPoint {
+	return (*[]*otlpmetrics.ExponentialHistogramDataPoint)(es.shared.read(unsafe.Pointer(es.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (es ExponentialHistogramDataPointSlice) getMutableOrig() *[]*otlpmetrics.ExponentialHistogramDataPoint {
+	return (*[]*otlpmetrics.ExponentialHistogramDataPoint)(es.shared.write(unsafe.Pointer(es.orig)))
 }
 
 // Len returns the number of elements in the slice.
 //
 // Returns "0" for a newly instance created with "NewExponentialHistogramDataPointSlice()".
 func (es ExponentialHistogramDataPointSlice) Len() int {
-	return len(*es.orig)
+	return len(*es.getOrig())
 }
 
 // At returns the element at the given index.
@@ -1546,27 +1860,33 @@ func (es ExponentialHistogramDataPointSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ExponentialHistogramDataPointSlice) At(ix int) ExponentialHistogramDataPoint {
-	return newExponentialHistogramDataPoint((*es.orig)[ix])
+	orig := (*es.getOrig())[ix]
+	return ExponentialHistogramDataPoint{orig: orig, shared: es.shared}
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ExponentialHistogramDataPointSlice) CopyTo(dest ExponentialHistogramDataPointSlice) {
-	srcLen := es.Len()
-	destCap := cap(*dest.orig)
+	destOrig := dest.getMutableOrig()
+	orig := es.getOrig()
+	srcLen := len(*orig)
+	destCap := cap(*destOrig)
 	if srcLen <= destCap {
-		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
-		for i := range *es.orig {
-			newExponentialHistogramDataPoint((*es.orig)[i]).CopyTo(newExponentialHistogramDataPoint((*dest.orig)[i]))
+		(*destOrig) = (*destOrig)[:srcLen:destCap]
+		for i := range *orig {
+			if (*destOrig)[i] == nil {
+				(*destOrig)[i] = &otlpmetrics.ExponentialHistogramDataPoint{}
+			}
+			newExponentialHistogramDataPoint((*orig)[i]).CopyTo(newExponentialHistogramDataPoint((*destOrig)[i]))
 		}
 		return
 	}
 	origs := make([]otlpmetrics.ExponentialHistogramDataPoint, srcLen)
 	wrappers := make([]*otlpmetrics.ExponentialHistogramDataPoint, srcLen)
-	for i := range *es.orig {
+	for i := range *orig {
 		wrappers[i] = &origs[i]
-		newExponentialHistogramDataPoint((*es.orig)[i]).CopyTo(newExponentialHistogramDataPoint(wrappers[i]))
+		newExponentialHistogramDataPoint((*orig)[i]).CopyTo(newExponentialHistogramDataPoint(wrappers[i]))
 	}
-	*dest.orig = wrappers
+	*destOrig = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -1581,20 +1901,22 @@ func (es ExponentialHistogramDataPointSlice) CopyTo(dest ExponentialHistogramDat
 //       // Here should set all the values for e.
 //   }
 func (es ExponentialHistogramDataPointSlice) EnsureCapacity(newCap int) {
-	oldCap := cap(*es.orig)
+	oldCap := cap(*es.getOrig())
 	if newCap <= oldCap {
 		return
 	}
 
-	newOrig := make([]*otlpmetrics.ExponentialHistogramDataPoint, len(*es.orig), newCap)
-	copy(newOrig, *es.orig)
-	*es.orig = newOrig
+	orig := es.getMutableOrig()
+	newOrig := make([]*otlpmetrics.ExponentialHistogramDataPoint, len(*orig), newCap)
+	copy(newOrig, *orig)
+	*orig = newOrig
 }
 
 // AppendEmpty will append to the end of the slice an empty ExponentialHistogramDataPoint.
 // It returns the newly added ExponentialHistogramDataPoint.
 func (es ExponentialHistogramDataPointSlice) AppendEmpty() ExponentialHistogramDataPoint {
-	*es.orig = append(*es.orig, &otlpmetrics.ExponentialHistogramDataPoint{})
+	orig := es.getMutableOrig()
+	*orig = append(*orig, &otlpmetrics.ExponentialHistogramDataPoint{})
 	return es.At(es.Len() - 1)
 }
 
@@ -1608,27 +1930,29 @@ func (es ExponentialHistogramDataPointSlice) AppendEmpty() ExponentialHistogramD
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es ExponentialHistogramDataPointSlice) Sort(less func(a, b ExponentialHistogramDataPoint) bool) ExponentialHistogramDataPointSlice {
-	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	sort.SliceStable(*es.getMutableOrig(), func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
 
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ExponentialHistogramDataPointSlice) MoveAndAppendTo(dest ExponentialHistogramDataPointSlice) {
-	if *dest.orig == nil {
+	orig := es.getMutableOrig()
+	destOrig := dest.getMutableOrig()
+	if *destOrig == nil {
 		// We can simply move the entire vector and avoid any allocations.
-		*dest.orig = *es.orig
+		*destOrig = *orig
 	} else {
-		*dest.orig = append(*dest.orig, *es.orig...)
+		*destOrig = append(*destOrig, *orig...)
 	}
-	*es.orig = nil
+	*orig = nil
 }
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es ExponentialHistogramDataPointSlice) RemoveIf(f func(ExponentialHistogramDataPoint) bool) {
 	newLen := 0
-	for i := 0; i < len(*es.orig); i++ {
+	for i := 0; i < es.Len(); i++ {
 		if f(es.At(i)) {
 			continue
 		}
@@ -1637,11 +1961,19 @@ func (es ExponentialHistogramDataPointSlice) RemoveIf(f func(ExponentialHistogra
 			newLen++
 			continue
 		}
-		(*es.orig)[newLen] = (*es.orig)[i]
+		orig := es.getMutableOrig()
+		(*orig)[newLen] = (*orig)[i]
 		newLen++
 	}
-	// TODO: Prevent memory leak by erasing truncated values.
-	*es.orig = (*es.orig)[:newLen]
+	if newLen == es.Len() {
+		return
+	}
+	orig := es.getMutableOrig()
+	// Erase truncated values to not keep references to removed elements.
+	for i := newLen; i < len(*orig); i++ {
+		(*orig)[i] = nil
+	}
+	*orig = (*orig)[:newLen]
 }
 
 // ExponentialHistogramDataPoint is a single data point in a timeseries that describes the
@@ -1656,12 +1988,24 @@ func (es ExponentialHistogramDataPointSlice) RemoveIf(f func(ExponentialHistogra
 // Important: zero-initialized instance is not valid for use.
 type ExponentialHistogramDataPoint struct {
 	orig *otlpmetrics.ExponentialHistogramDataPoint
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newExponentialHistogramDataPoint(orig *otlpmetrics.ExponentialHistogramDataPoint) ExponentialHistogramDataPoint {
 	return ExponentialHistogramDataPoint{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms ExponentialHistogramDataPoint) getOrig() *otlpmetrics.ExponentialHistogramDataPoint {
+	return (*otlpmetrics.ExponentialHistogramDataPoint)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms ExponentialHistogramDataPoint) getMutableOrig() *otlpmetrics.ExponentialHistogramDataPoint {
+	return (*otlpmetrics.ExponentialHistogramDataPoint)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewExponentialHistogramDataPoint creates a new empty ExponentialHistogramDataPoint.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -1673,98 +2017,99 @@ func NewExponentialHistogramDataPoint() ExponentialHistogramDataPoint {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms ExponentialHistogramDataPoint) MoveTo(dest ExponentialHistogramDataPoint) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlpmetrics.ExponentialHistogramDataPoint{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlpmetrics.ExponentialHistogramDataPoint{}
 }
 
 // Attributes returns the Attributes associated with this ExponentialHistogramDataPoint.
 func (ms ExponentialHistogramDataPoint) Attributes() Map {
-	return newMap(&(*ms.orig).Attributes)
+	return Map{orig: &ms.getOrig().Attributes, shared: ms.shared}
 }
 
 // StartTimestamp returns the starttimestamp associated with this ExponentialHistogramDataPoint.
 func (ms ExponentialHistogramDataPoint) StartTimestamp() Timestamp {
-	return Timestamp((*ms.orig).StartTimeUnixNano)
+	return Timestamp(ms.getOrig().StartTimeUnixNano)
 }
 
 // SetStartTimestamp replaces the starttimestamp associated with this ExponentialHistogramDataPoint.
 func (ms ExponentialHistogramDataPoint) SetStartTimestamp(v Timestamp) {
-	(*ms.orig).StartTimeUnixNano = uint64(v)
+	ms.getMutableOrig().StartTimeUnixNano = uint64(v)
 }
 
 // Timestamp returns the timestamp associated with this ExponentialHistogramDataPoint.
 func (ms ExponentialHistogramDataPoint) Timestamp() Timestamp {
-	return Timestamp((*ms.orig).TimeUnixNano)
+	return Timestamp(ms.getOrig().TimeUnixNano)
 }
 
 // SetTimestamp replaces the timestamp associated with this ExponentialHistogramDataPoint.
 func (ms ExponentialHistogramDataPoint) SetTimestamp(v Timestamp) {
-	(*ms.orig).TimeUnixNano = uint64(v)
+	ms.getMutableOrig().TimeUnixNano = uint64(v)
 }
 
 // Count returns the count associated with this ExponentialHistogramDataPoint.
 func (ms ExponentialHistogramDataPoint) Count() uint64 {
-	return (*ms.orig).Count
+	return ms.getOrig().Count
 }
 
 // SetCount replaces the count associated with this ExponentialHistogramDataPoint.
 func (ms ExponentialHistogramDataPoint) SetCount(v uint64) {
-	(*ms.orig).Count = v
+	ms.getMutableOrig().Count = v
 }
 
 // Sum returns the sum associated with this ExponentialHistogramDataPoint.
 func (ms ExponentialHistogramDataPoint) Sum() float64 {
-	return (*ms.orig).Sum
+	return ms.getOrig().Sum
 }
 
 // SetSum replaces the sum associated with this ExponentialHistogramDataPoint.
 func (ms ExponentialHistogramDataPoint) SetSum(v float64) {
-	(*ms.orig).Sum = v
+	ms.getMutableOrig().Sum = v
 }
 
 // Scale returns the scale associated with this ExponentialHistogramDataPoint.
 func (ms ExponentialHistogramDataPoint) Scale() int32 {
-	return int32((*ms.orig).Scale)
+	return int32(ms.getOrig().Scale)
 }
 
 // SetScale replaces the scale associated with this ExponentialHistogramDataPoint.
 func (ms ExponentialHistogramDataPoint) SetScale(v int32) {
-	(*ms.orig).Scale = int32(v)
+	ms.getMutableOrig().Scale = int32(v)
 }
 
 // ZeroCount returns the zerocount associated with this ExponentialHistogramDataPoint.
 func (ms ExponentialHistogramDataPoint) ZeroCount() uint64 {
-	return uint64((*ms.orig).ZeroCount)
+	return uint64(ms.getOrig().ZeroCount)
 }
 
 // SetZeroCount replaces the zerocount associated with this ExponentialHistogramDataPoint.
 func (ms ExponentialHistogramDataPoint) SetZeroCount(v uint64) {
-	(*ms.orig).ZeroCount = uint64(v)
+	ms.getMutableOrig().ZeroCount = uint64(v)
 }
 
 // Positive returns the positive associated with this ExponentialHistogramDataPoint.
 func (ms ExponentialHistogramDataPoint) Positive() Buckets {
-	return newBuckets(&(*ms.orig).Positive)
+	return Buckets{orig: &ms.getOrig().Positive, shared: ms.shared}
 }
 
 // Negative returns the negative associated with this ExponentialHistogramDataPoint.
 func (ms ExponentialHistogramDataPoint) Negative() Buckets {
-	return newBuckets(&(*ms.orig).Negative)
+	return Buckets{orig: &ms.getOrig().Negative, shared: ms.shared}
 }
 
 // Exemplars returns the Exemplars associated with this ExponentialHistogramDataPoint.
 func (ms ExponentialHistogramDataPoint) Exemplars() ExemplarSlice {
-	return newExemplarSlice(&(*ms.orig).Exemplars)
+	return ExemplarSlice{orig: &ms.getOrig().Exemplars, shared: ms.shared}
 }
 
 // Flags returns the flags associated with this ExponentialHistogramDataPoint.
 func (ms ExponentialHistogramDataPoint) Flags() MetricDataPointFlags {
-	return MetricDataPointFlags((*ms.orig).Flags)
+	return MetricDataPointFlags(ms.getOrig().Flags)
 }
 
 // SetFlags replaces the flags associated with this ExponentialHistogramDataPoint.
 func (ms ExponentialHistogramDataPoint) SetFlags(v MetricDataPointFlags) {
-	(*ms.orig).Flags = uint32(v)
+	ms.getMutableOrig().Flags = uint32(v)
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -1791,12 +2136,24 @@ func (ms ExponentialHistogramDataPoint) CopyTo(dest ExponentialHistogramDataPoin
 // Important: zero-initialized instance is not valid for use.
 type Buckets struct {
 	orig *otlpmetrics.ExponentialHistogramDataPoint_Buckets
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newBuckets(orig *otlpmetrics.ExponentialHistogramDataPoint_Buckets) Buckets {
 	return Buckets{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms Buckets) getOrig() *otlpmetrics.ExponentialHistogramDataPoint_Buckets {
+	return (*otlpmetrics.ExponentialHistogramDataPoint_Buckets)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms Buckets) getMutableOrig() *otlpmetrics.ExponentialHistogramDataPoint_Buckets {
+	return (*otlpmetrics.ExponentialHistogramDataPoint_Buckets)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewBuckets creates a new empty Buckets.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -1808,38 +2165,39 @@ func NewBuckets() Buckets {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms Buckets) MoveTo(dest Buckets) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlpmetrics.ExponentialHistogramDataPoint_Buckets{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlpmetrics.ExponentialHistogramDataPoint_Buckets{}
 }
 
 // Offset returns the offset associated with this Buckets.
 func (ms Buckets) Offset() int32 {
-	return int32((*ms.orig).Offset)
+	return int32(ms.getOrig().Offset)
 }
 
 // SetOffset replaces the offset associated with this Buckets.
 func (ms Buckets) SetOffset(v int32) {
-	(*ms.orig).Offset = int32(v)
+	ms.getMutableOrig().Offset = int32(v)
 }
 
 // BucketCounts returns the bucketcounts associated with this Buckets.
 func (ms Buckets) BucketCounts() []uint64 {
-	return (*ms.orig).BucketCounts
+	return ms.getOrig().BucketCounts
 }
 
 // SetBucketCounts replaces the bucketcounts associated with this Buckets.
 func (ms Buckets) SetBucketCounts(v []uint64) {
-	(*ms.orig).BucketCounts = v
+	ms.getMutableOrig().BucketCounts = v
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms Buckets) CopyTo(dest Buckets) {
 	dest.SetOffset(ms.Offset())
-	if len(ms.orig.BucketCounts) == 0 {
-		dest.orig.BucketCounts = nil
+	if len(ms.getOrig().BucketCounts) == 0 {
+		dest.getMutableOrig().BucketCounts = nil
 	} else {
-		dest.orig.BucketCounts = make([]uint64, len(ms.orig.BucketCounts))
-		copy(dest.orig.BucketCounts, ms.orig.BucketCounts)
+		dest.getMutableOrig().BucketCounts = make([]uint64, len(ms.getOrig().BucketCounts))
+		copy(dest.getMutableOrig().BucketCounts, ms.getOrig().BucketCounts)
 	}
 
 }
@@ -1855,24 +2213,36 @@ type SummaryDataPointSlice struct {
 	// orig points to the slice otlpmetrics.SummaryDataPoint field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
 	orig *[]*otlpmetrics.SummaryDataPoint
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newSummaryDataPointSlice(orig *[]*otlpmetrics.SummaryDataPoint) SummaryDataPointSlice {
-	return SummaryDataPointSlice{orig}
+	return SummaryDataPointSlice{orig: orig}
 }
 
 // NewSummaryDataPointSlice creates a SummaryDataPointSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewSummaryDataPointSlice() SummaryDataPointSlice {
 	orig := []*otlpmetrics.SummaryDataPoint(nil)
-	return SummaryDataPointSlice{&orig}
+	return SummaryDataPointSlice{orig: &orig}
+}
+
+// getOrig returns the orig to read.
+func (es SummaryDataPointSlice) getOrig() *[]*otlpmetrics.SummaryDataPoint {
+	return (*[]*otlpmetrics.SummaryDataPoint)(es.shared.read(unsafe.Pointer(es.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (es SummaryDataPointSlice) getMutableOrig() *[]*otlpmetrics.SummaryDataPoint {
+	return (*[]*otlpmetrics.SummaryDataPoint)(es.shared.write(unsafe.Pointer(es.orig)))
 }
 
 // Len returns the number of elements in the slice.
 //
 // Returns "0" for a newly instance created with "NewSummaryDataPointSlice()".
 func (es SummaryDataPointSlice) Len() int {
-	return len(*es.orig)
+	return len(*es.getOrig())
 }
 
 // At returns the element at the given index.
@@ -1883,27 +2253,33 @@ func (es SummaryDataPointSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es SummaryDataPointSlice) At(ix int) SummaryDataPoint {
-	return newSummaryDataPoint((*es.orig)[ix])
+	orig := (*es.getOrig())[ix]
+	return SummaryDataPoint{orig: orig, shared: es.shared}
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es SummaryDataPointSlice) CopyTo(dest SummaryDataPointSlice) {
-	srcLen := es.Len()
-	destCap := cap(*dest.orig)
+	destOrig := dest.getMutableOrig()
+	orig := es.getOrig()
+	srcLen := len(*orig)
+	destCap := cap(*destOrig)
 	if srcLen <= destCap {
-		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
-		for i := range *es.orig {
-			newSummaryDataPoint((*es.orig)[i]).CopyTo(newSummaryDataPoint((*dest.orig)[i]))
+		(*destOrig) = (*destOrig)[:srcLen:destCap]
+		for i := range *orig {
+			if (*destOrig)[i] == nil {
+				(*destOrig)[i] = &otlpmetrics.SummaryDataPoint{}
+			}
+			newSummaryDataPoint((*orig)[i]).CopyTo(newSummaryDataPoint((*destOrig)[i]))
 		}
 		return
 	}
 	origs := make([]otlpmetrics.SummaryDataPoint, srcLen)
 	wrappers := make([]*otlpmetrics.SummaryDataPoint, srcLen)
-	for i := range *es.orig {
+	for i := range *orig {
 		wrappers[i] = &origs[i]
-		newSummaryDataPoint((*es.orig)[i]).CopyTo(newSummaryDataPoint(wrappers[i]))
+		newSummaryDataPoint((*orig)[i]).CopyTo(newSummaryDataPoint(wrappers[i]))
 	}
-	*dest.orig = wrappers
+	*destOrig = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -1918,20 +2294,22 @@ func (es SummaryDataPointSlice) CopyTo(dest SummaryDataPointSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es SummaryDataPointSlice) EnsureCapacity(newCap int) {
-	oldCap := cap(*es.orig)
+	oldCap := cap(*es.getOrig())
 	if newCap <= oldCap {
 		return
 	}
 
-	newOrig := make([]*otlpmetrics.SummaryDataPoint, len(*es.orig), newCap)
-	copy(newOrig, *es.orig)
-	*es.orig = newOrig
+	orig := es.getMutableOrig()
+	newOrig := make([]*otlpmetrics.SummaryDataPoint, len(*orig), newCap)
+	copy(newOrig, *orig)
+	*orig = newOrig
 }
 
 // AppendEmpty will append to the end of the slice an empty SummaryDataPoint.
 // It returns the newly added SummaryDataPoint.
 func (es SummaryDataPointSlice) AppendEmpty() SummaryDataPoint {
-	*es.orig = append(*es.orig, &otlpmetrics.SummaryDataPoint{})
+	orig := es.getMutableOrig()
+	*orig = append(*orig, &otlpmetrics.SummaryDataPoint{})
 	return es.At(es.Len() - 1)
 }
 
@@ -1945,27 +2323,29 @@ func (es SummaryDataPointSlice) AppendEmpty() SummaryDataPoint {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es SummaryDataPointSlice) Sort(less func(a, b SummaryDataPoint) bool) SummaryDataPointSlice {
-	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	sort.SliceStable(*es.getMutableOrig(), func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
 
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es SummaryDataPointSlice) MoveAndAppendTo(dest SummaryDataPointSlice) {
-	if *dest.orig == nil {
+	orig := es.getMutableOrig()
+	destOrig := dest.getMutableOrig()
+	if *destOrig == nil {
 		// We can simply move the entire vector and avoid any allocations.
-		*dest.orig = *es.orig
+		*destOrig = *orig
 	} else {
-		*dest.orig = append(*dest.orig, *es.orig...)
+		*destOrig = append(*destOrig, *orig...)
 	}
-	*es.orig = nil
+	*orig = nil
 }
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es SummaryDataPointSlice) RemoveIf(f func(SummaryDataPoint) bool) {
 	newLen := 0
-	for i := 0; i < len(*es.orig); i++ {
+	for i := 0; i < es.Len(); i++ {
 		if f(es.At(i)) {
 			continue
 		}
@@ -1974,11 +2354,19 @@ func (es SummaryDataPointSlice) RemoveIf(f func(SummaryDataPoint) bool) {
 			newLen++
 			continue
 		}
-		(*es.orig)[newLen] = (*es.orig)[i]
+		orig := es.getMutableOrig()
+		(*orig)[newLen] = (*orig)[i]
 		newLen++
 	}
-	// TODO: Prevent memory leak by erasing truncated values.
-	*es.orig = (*es.orig)[:newLen]
+	if newLen == es.Len() {
+		return
+	}
+	orig := es.getMutableOrig()
+	// Erase truncated values to not keep references to removed elements.
+	for i := newLen; i < len(*orig); i++ {
+		(*orig)[i] = nil
+	}
+	*orig = (*orig)[:newLen]
 }
 
 // SummaryDataPoint is a single data point in a timeseries that describes the time-varying values of a Summary of double values.
@@ -1990,12 +2378,24 @@ func (es SummaryDataPointSlice) RemoveIf(f func(SummaryDataPoint) bool) {
 // Important: zero-initialized instance is not valid for use.
 type SummaryDataPoint struct {
 	orig *otlpmetrics.SummaryDataPoint
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newSummaryDataPoint(orig *otlpmetrics.SummaryDataPoint) SummaryDataPoint {
 	return SummaryDataPoint{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms SummaryDataPoint) getOrig() *otlpmetrics.SummaryDataPoint {
+	return (*otlpmetrics.SummaryDataPoint)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms SummaryDataPoint) getMutableOrig() *otlpmetrics.SummaryDataPoint {
+	return (*otlpmetrics.SummaryDataPoint)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewSummaryDataPoint creates a new empty SummaryDataPoint.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -2007,68 +2407,69 @@ func NewSummaryDataPoint() SummaryDataPoint {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms SummaryDataPoint) MoveTo(dest SummaryDataPoint) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlpmetrics.SummaryDataPoint{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlpmetrics.SummaryDataPoint{}
 }
 
 // Attributes returns the Attributes associated with this SummaryDataPoint.
 func (ms SummaryDataPoint) Attributes() Map {
-	return newMap(&(*ms.orig).Attributes)
+	return Map{orig: &ms.getOrig().Attributes, shared: ms.shared}
 }
 
 // StartTimestamp returns the starttimestamp associated with this SummaryDataPoint.
 func (ms SummaryDataPoint) StartTimestamp() Timestamp {
-	return Timestamp((*ms.orig).StartTimeUnixNano)
+	return Timestamp(ms.getOrig().StartTimeUnixNano)
 }
 
 // SetStartTimestamp replaces the starttimestamp associated with this SummaryDataPoint.
 func (ms SummaryDataPoint) SetStartTimestamp(v Timestamp) {
-	(*ms.orig).StartTimeUnixNano = uint64(v)
+	ms.getMutableOrig().StartTimeUnixNano = uint64(v)
 }
 
 // Timestamp returns the timestamp associated with this SummaryDataPoint.
 func (ms SummaryDataPoint) Timestamp() Timestamp {
-	return Timestamp((*ms.orig).TimeUnixNano)
+	return Timestamp(ms.getOrig().TimeUnixNano)
 }
 
 // SetTimestamp replaces the timestamp associated with this SummaryDataPoint.
 func (ms SummaryDataPoint) SetTimestamp(v Timestamp) {
-	(*ms.orig).TimeUnixNano = uint64(v)
+	ms.getMutableOrig().TimeUnixNano = uint64(v)
 }
 
 // Count returns the count associated with this SummaryDataPoint.
 func (ms SummaryDataPoint) Count() uint64 {
-	return (*ms.orig).Count
+	return ms.getOrig().Count
 }
 
 // SetCount replaces the count associated with this SummaryDataPoint.
 func (ms SummaryDataPoint) SetCount(v uint64) {
-	(*ms.orig).Count = v
+	ms.getMutableOrig().Count = v
 }
 
 // Sum returns the sum associated with this SummaryDataPoint.
 func (ms SummaryDataPoint) Sum() float64 {
-	return (*ms.orig).Sum
+	return ms.getOrig().Sum
 }
 
 // SetSum replaces the sum associated with this SummaryDataPoint.
 func (ms SummaryDataPoint) SetSum(v float64) {
-	(*ms.orig).Sum = v
+	ms.getMutableOrig().Sum = v
 }
 
 // QuantileValues returns the QuantileValues associated with this SummaryDataPoint.
 func (ms SummaryDataPoint) QuantileValues() ValueAtQuantileSlice {
-	return newValueAtQuantileSlice(&(*ms.orig).QuantileValues)
+	return ValueAtQuantileSlice{orig: &ms.getOrig().QuantileValues, shared: ms.shared}
 }
 
 // Flags returns the flags associated with this SummaryDataPoint.
 func (ms SummaryDataPoint) Flags() MetricDataPointFlags {
-	return MetricDataPointFlags((*ms.orig).Flags)
+	return MetricDataPointFlags(ms.getOrig().Flags)
 }
 
 // SetFlags replaces the flags associated with this SummaryDataPoint.
 func (ms SummaryDataPoint) SetFlags(v MetricDataPointFlags) {
-	(*ms.orig).Flags = uint32(v)
+	ms.getMutableOrig().Flags = uint32(v)
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -2093,24 +2494,36 @@ type ValueAtQuantileSlice struct {
 	// orig points to the slice otlpmetrics.SummaryDataPoint_ValueAtQuantile field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
 	orig *[]*otlpmetrics.SummaryDataPoint_ValueAtQuantile
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newValueAtQuantileSlice(orig *[]*otlpmetrics.SummaryDataPoint_ValueAtQuantile) ValueAtQuantileSlice {
-	return ValueAtQuantileSlice{orig}
+	return ValueAtQuantileSlice{orig: orig}
 }
 
 // NewValueAtQuantileSlice creates a ValueAtQuantileSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewValueAtQuantileSlice() ValueAtQuantileSlice {
 	orig := []*otlpmetrics.SummaryDataPoint_ValueAtQuantile(nil)
-	return ValueAtQuantileSlice{&orig}
+	return ValueAtQuantileSlice{orig: &orig}
+}
+
+// getOrig returns the orig to read.
+func (es ValueAtQuantileSlice) getOrig() *[]*otlpmetrics.SummaryDataPoint_ValueAtQuantile {
+	return (*[]*otlpmetrics.SummaryDataPoint_ValueAtQuantile)(es.shared.read(unsafe.Pointer(es.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (es ValueAtQuantileSlice) getMutableOrig() *[]*otlpmetrics.SummaryDataPoint_ValueAtQuantile {
+	return (*[]*otlpmetrics.SummaryDataPoint_ValueAtQuantile)(es.shared.write(unsafe.Pointer(es.orig)))
 }
 
 // Len returns the number of elements in the slice.
 //
 // Returns "0" for a newly instance created with "NewValueAtQuantileSlice()".
 func (es ValueAtQuantileSlice) Len() int {
-	return len(*es.orig)
+	return len(*es.getOrig())
 }
 
 // At returns the element at the given index.
@@ -2121,27 +2534,33 @@ func (es ValueAtQuantileSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ValueAtQuantileSlice) At(ix int) ValueAtQuantile {
-	return newValueAtQuantile((*es.orig)[ix])
+	orig := (*es.getOrig())[ix]
+	return ValueAtQuantile{orig: orig, shared: es.shared}
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ValueAtQuantileSlice) CopyTo(dest ValueAtQuantileSlice) {
-	srcLen := es.Len()
-	destCap := cap(*dest.orig)
+	destOrig := dest.getMutableOrig()
+	orig := es.getOrig()
+	srcLen := len(*orig)
+	destCap := cap(*destOrig)
 	if srcLen <= destCap {
-		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
-		for i := range *es.orig {
-			newValueAtQuantile((*es.orig)[i]).CopyTo(newValueAtQuantile((*dest.orig)[i]))
+		(*destOrig) = (*destOrig)[:srcLen:destCap]
+		for i := range *orig {
+			if (*destOrig)[i] == nil {
+				(*destOrig)[i] = &otlpmetrics.SummaryDataPoint_ValueAtQuantile{}
+			}
+			newValueAtQuantile((*orig)[i]).CopyTo(newValueAtQuantile((*destOrig)[i]))
 		}
 		return
 	}
 	origs := make([]otlpmetrics.SummaryDataPoint_ValueAtQuantile, srcLen)
 	wrappers := make([]*otlpmetrics.SummaryDataPoint_ValueAtQuantile, srcLen)
-	for i := range *es.orig {
+	for i := range *orig {
 		wrappers[i] = &origs[i]
-		newValueAtQuantile((*es.orig)[i]).CopyTo(newValueAtQuantile(wrappers[i]))
+		newValueAtQuantile((*orig)[i]).CopyTo(newValueAtQuantile(wrappers[i]))
 	}
-	*dest.orig = wrappers
+	*destOrig = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -2156,20 +2575,22 @@ func (es ValueAtQuantileSlice) CopyTo(dest ValueAtQuantileSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es ValueAtQuantileSlice) EnsureCapacity(newCap int) {
-	oldCap := cap(*es.orig)
+	oldCap := cap(*es.getOrig())
 	if newCap <= oldCap {
 		return
 	}
 
-	newOrig := make([]*otlpmetrics.SummaryDataPoint_ValueAtQuantile, len(*es.orig), newCap)
-	copy(newOrig, *es.orig)
-	*es.orig = newOrig
+	orig := es.getMutableOrig()
+	newOrig := make([]*otlpmetrics.SummaryDataPoint_ValueAtQuantile, len(*orig), newCap)
+	copy(newOrig, *orig)
+	*orig = newOrig
 }
 
 // AppendEmpty will append to the end of the slice an empty ValueAtQuantile.
 // It returns the newly added ValueAtQuantile.
 func (es ValueAtQuantileSlice) AppendEmpty() ValueAtQuantile {
-	*es.orig = append(*es.orig, &otlpmetrics.SummaryDataPoint_ValueAtQuantile{})
+	orig := es.getMutableOrig()
+	*orig = append(*orig, &otlpmetrics.SummaryDataPoint_ValueAtQuantile{})
 	return es.At(es.Len() - 1)
 }
 
@@ -2183,27 +2604,29 @@ func (es ValueAtQuantileSlice) AppendEmpty() ValueAtQuantile {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es ValueAtQuantileSlice) Sort(less func(a, b ValueAtQuantile) bool) ValueAtQuantileSlice {
-	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	sort.SliceStable(*es.getMutableOrig(), func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
 
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ValueAtQuantileSlice) MoveAndAppendTo(dest ValueAtQuantileSlice) {
-	if *dest.orig == nil {
+	orig := es.getMutableOrig()
+	destOrig := dest.getMutableOrig()
+	if *destOrig == nil {
 		// We can simply move the entire vector and avoid any allocations.
-		*dest.orig = *es.orig
+		*destOrig = *orig
 	} else {
-		*dest.orig = append(*dest.orig, *es.orig...)
+		*destOrig = append(*destOrig, *orig...)
 	}
-	*es.orig = nil
+	*orig = nil
 }
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es ValueAtQuantileSlice) RemoveIf(f func(ValueAtQuantile) bool) {
 	newLen := 0
-	for i := 0; i < len(*es.orig); i++ {
+	for i := 0; i < es.Len(); i++ {
 		if f(es.At(i)) {
 			continue
 		}
@@ -2212,11 +2635,19 @@ func (es ValueAtQuantileSlice) RemoveIf(f func(ValueAtQuantile) bool) {
 			newLen++
 			continue
 		}
-		(*es.orig)[newLen] = (*es.orig)[i]
+		orig := es.getMutableOrig()
+		(*orig)[newLen] = (*orig)[i]
 		newLen++
 	}
-	// TODO: Prevent memory leak by erasing truncated values.
-	*es.orig = (*es.orig)[:newLen]
+	if newLen == es.Len() {
+		return
+	}
+	orig := es.getMutableOrig()
+	// Erase truncated values to not keep references to removed elements.
+	for i := newLen; i < len(*orig); i++ {
+		(*orig)[i] = nil
+	}
+	*orig = (*orig)[:newLen]
 }
 
 // ValueAtQuantile is a quantile value within a Summary data point.
@@ -2228,12 +2659,24 @@ func (es ValueAtQuantileSlice) RemoveIf(f func(ValueAtQuantile) bool) {
 // Important: zero-initialized instance is not valid for use.
 type ValueAtQuantile struct {
 	orig *otlpmetrics.SummaryDataPoint_ValueAtQuantile
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newValueAtQuantile(orig *otlpmetrics.SummaryDataPoint_ValueAtQuantile) ValueAtQuantile {
 	return ValueAtQuantile{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms ValueAtQuantile) getOrig() *otlpmetrics.SummaryDataPoint_ValueAtQuantile {
+	return (*otlpmetrics.SummaryDataPoint_ValueAtQuantile)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms ValueAtQuantile) getMutableOrig() *otlpmetrics.SummaryDataPoint_ValueAtQuantile {
+	return (*otlpmetrics.SummaryDataPoint_ValueAtQuantile)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewValueAtQuantile creates a new empty ValueAtQuantile.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -2245,28 +2688,29 @@ func NewValueAtQuantile() ValueAtQuantile {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms ValueAtQuantile) MoveTo(dest ValueAtQuantile) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlpmetrics.SummaryDataPoint_ValueAtQuantile{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlpmetrics.SummaryDataPoint_ValueAtQuantile{}
 }
 
 // Quantile returns the quantile associated with this ValueAtQuantile.
 func (ms ValueAtQuantile) Quantile() float64 {
-	return (*ms.orig).Quantile
+	return ms.getOrig().Quantile
 }
 
 // SetQuantile replaces the quantile associated with this ValueAtQuantile.
 func (ms ValueAtQuantile) SetQuantile(v float64) {
-	(*ms.orig).Quantile = v
+	ms.getMutableOrig().Quantile = v
 }
 
 // Value returns the value associated with this ValueAtQuantile.
 func (ms ValueAtQuantile) Value() float64 {
-	return (*ms.orig).Value
+	return ms.getOrig().Value
 }
 
 // SetValue replaces the value associated with this ValueAtQuantile.
 func (ms ValueAtQuantile) SetValue(v float64) {
-	(*ms.orig).Value = v
+	ms.getMutableOrig().Value = v
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -2286,24 +2730,36 @@ type ExemplarSlice struct {
 	// orig points to the slice otlpmetrics.Exemplar field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
 	orig *[]otlpmetrics.Exemplar
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newExemplarSlice(orig *[]otlpmetrics.Exemplar) ExemplarSlice {
-	return ExemplarSlice{orig}
+	return ExemplarSlice{orig: orig}
 }
 
 // NewExemplarSlice creates a ExemplarSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewExemplarSlice() ExemplarSlice {
 	orig := []otlpmetrics.Exemplar(nil)
-	return ExemplarSlice{&orig}
+	return ExemplarSlice{orig: &orig}
+}
+
+// getOrig returns the orig to read.
+func (es ExemplarSlice) getOrig() *[]otlpmetrics.Exemplar {
+	return (*[]otlpmetrics.Exemplar)(es.shared.read(unsafe.Pointer(es.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (es ExemplarSlice) getMutableOrig() *[]otlpmetrics.Exemplar {
+	return (*[]otlpmetrics.Exemplar)(es.shared.write(unsafe.Pointer(es.orig)))
 }
 
 // Len returns the number of elements in the slice.
 //
 // Returns "0" for a newly instance created with "NewExemplarSlice()".
 func (es ExemplarSlice) Len() int {
-	return len(*es.orig)
+	return len(*es.getOrig())
 }
 
 // At returns the element at the given index.
@@ -2314,21 +2770,23 @@ func (es ExemplarSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ExemplarSlice) At(ix int) Exemplar {
-	return newExemplar(&(*es.orig)[ix])
+	return Exemplar{orig: &(*es.getOrig())[ix], shared: es.shared}
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ExemplarSlice) CopyTo(dest ExemplarSlice) {
-	srcLen := es.Len()
-	destCap := cap(*dest.orig)
+	destOrig := dest.getMutableOrig()
+	orig := es.getOrig()
+	srcLen := len(*orig)
+	destCap := cap(*destOrig)
 	if srcLen <= destCap {
-		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
+		(*destOrig) = (*destOrig)[:srcLen:destCap]
 	} else {
-		(*dest.orig) = make([]otlpmetrics.Exemplar, srcLen)
+		(*destOrig) = make([]otlpmetrics.Exemplar, srcLen)
 	}
 
-	for i := range *es.orig {
-		newExemplar(&(*es.orig)[i]).CopyTo(newExemplar(&(*dest.orig)[i]))
+	for i := range *orig {
+		newExemplar(&(*orig)[i]).CopyTo(newExemplar(&(*destOrig)[i]))
 	}
 }
 
@@ -2344,40 +2802,44 @@ func (es ExemplarSlice) CopyTo(dest ExemplarSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es ExemplarSlice) EnsureCapacity(newCap int) {
-	oldCap := cap(*es.orig)
+	oldCap := cap(*es.getOrig())
 	if newCap <= oldCap {
 		return
 	}
 
-	newOrig := make([]otlpmetrics.Exemplar, len(*es.orig), newCap)
-	copy(newOrig, *es.orig)
-	*es.orig = newOrig
+	orig := es.getMutableOrig()
+	newOrig := make([]otlpmetrics.Exemplar, len(*orig), newCap)
+	copy(newOrig, *orig)
+	*orig = newOrig
 }
 
 // AppendEmpty will append to the end of the slice an empty Exemplar.
 // It returns the newly added Exemplar.
 func (es ExemplarSlice) AppendEmpty() Exemplar {
-	*es.orig = append(*es.orig, otlpmetrics.Exemplar{})
+	orig := es.getMutableOrig()
+	*orig = append(*orig, otlpmetrics.Exemplar{})
 	return es.At(es.Len() - 1)
 }
 
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ExemplarSlice) MoveAndAppendTo(dest ExemplarSlice) {
-	if *dest.orig == nil {
+	orig := es.getMutableOrig()
+	destOrig := dest.getMutableOrig()
+	if *destOrig == nil {
 		// We can simply move the entire vector and avoid any allocations.
-		*dest.orig = *es.orig
+		*destOrig = *orig
 	} else {
-		*dest.orig = append(*dest.orig, *es.orig...)
+		*destOrig = append(*destOrig, *orig...)
 	}
-	*es.orig = nil
+	*orig = nil
 }
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es ExemplarSlice) RemoveIf(f func(Exemplar) bool) {
 	newLen := 0
-	for i := 0; i < len(*es.orig); i++ {
+	for i := 0; i < es.Len(); i++ {
 		if f(es.At(i)) {
 			continue
 		}
@@ -2386,11 +2848,19 @@ func (es ExemplarSlice) RemoveIf(f func(Exemplar) bool) {
 			newLen++
 			continue
 		}
-		(*es.orig)[newLen] = (*es.orig)[i]
+		orig := es.getMutableOrig()
+		(*orig)[newLen] = (*orig)[i]
 		newLen++
 	}
-	// TODO: Prevent memory leak by erasing truncated values.
-	*es.orig = (*es.orig)[:newLen]
+	if newLen == es.Len() {
+		return
+	}
+	orig := es.getMutableOrig()
+	// Erase truncated values to not keep references to removed elements.
+	for i := newLen; i < len(*orig); i++ {
+		(*orig)[i] = otlpmetrics.Exemplar{}
+	}
+	*orig = (*orig)[:newLen]
 }
 
 // Exemplar is a sample input double measurement.
@@ -2405,12 +2875,24 @@ func (es ExemplarSlice) RemoveIf(f func(Exemplar) bool) {
 // Important: zero-initialized instance is not valid for use.
 type Exemplar struct {
 	orig *otlpmetrics.Exemplar
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newExemplar(orig *otlpmetrics.Exemplar) Exemplar {
 	return Exemplar{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms Exemplar) getOrig() *otlpmetrics.Exemplar {
+	return (*otlpmetrics.Exemplar)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms Exemplar) getMutableOrig() *otlpmetrics.Exemplar {
+	return (*otlpmetrics.Exemplar)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewExemplar creates a new empty Exemplar.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -2422,24 +2904,25 @@ func NewExemplar() Exemplar {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms Exemplar) MoveTo(dest Exemplar) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlpmetrics.Exemplar{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlpmetrics.Exemplar{}
 }
 
 // Timestamp returns the timestamp associated with this Exemplar.
 func (ms Exemplar) Timestamp() Timestamp {
-	return Timestamp((*ms.orig).TimeUnixNano)
+	return Timestamp(ms.getOrig().TimeUnixNano)
 }
 
 // SetTimestamp replaces the timestamp associated with this Exemplar.
 func (ms Exemplar) SetTimestamp(v Timestamp) {
-	(*ms.orig).TimeUnixNano = uint64(v)
+	ms.getMutableOrig().TimeUnixNano = uint64(v)
 }
 
 // ValueType returns the type of the value for this Exemplar.
 // Calling this function on zero-initialized Exemplar will cause a panic.
 func (ms Exemplar) ValueType() ExemplarValueType {
-	switch ms.orig.Value.(type) {
+	switch ms.getOrig().Value.(type) {
 	case *otlpmetrics.Exemplar_AsDouble:
 		return ExemplarValueTypeDouble
 	case *otlpmetrics.Exemplar_AsInt:
@@ -2450,57 +2933,59 @@ func (ms Exemplar) ValueType() ExemplarValueType {
 
 // DoubleVal returns the doubleval associated with this Exemplar.
 func (ms Exemplar) DoubleVal() float64 {
-	return (*ms.orig).GetAsDouble()
+	return ms.getOrig().GetAsDouble()
 }
 
 // SetDoubleVal replaces the doubleval associated with this Exemplar.
 func (ms Exemplar) SetDoubleVal(v float64) {
-	(*ms.orig).Value = &otlpmetrics.Exemplar_AsDouble{
+	ms.getMutableOrig().Value = &otlpmetrics.Exemplar_AsDouble{
 		AsDouble: v,
 	}
 }
 
 // IntVal returns the intval associated with this Exemplar.
 func (ms Exemplar) IntVal() int64 {
-	return (*ms.orig).GetAsInt()
+	return ms.getOrig().GetAsInt()
 }
 
 // SetIntVal replaces the intval associated with this Exemplar.
 func (ms Exemplar) SetIntVal(v int64) {
-	(*ms.orig).Value = &otlpmetrics.Exemplar_AsInt{
+	ms.getMutableOrig().Value = &otlpmetrics.Exemplar_AsInt{
 		AsInt: v,
 	}
 }
 
 // FilteredAttributes returns the FilteredAttributes associated with this Exemplar.
 func (ms Exemplar) FilteredAttributes() Map {
-	return newMap(&(*ms.orig).FilteredAttributes)
+	return Map{orig: &ms.getOrig().FilteredAttributes, shared: ms.shared}
 }
 
 // TraceID returns the traceid associated with this Exemplar.
 func (ms Exemplar) TraceID() TraceID {
-	return TraceID{orig: ((*ms.orig).TraceId)}
+	return TraceID{orig: ms.getOrig().TraceId}
 }
 
 // SetTraceID replaces the traceid associated with this Exemplar.
 func (ms Exemplar) SetTraceID(v TraceID) {
-	(*ms.orig).TraceId = v.orig
+	ms.getMutableOrig().TraceId = v.orig
 }
 
 // SpanID returns the spanid associated with this Exemplar.
 func (ms Exemplar) SpanID() SpanID {
-	return SpanID{orig: ((*ms.orig).SpanId)}
+	return SpanID{orig: ms.getOrig().SpanId}
 }
 
 // SetSpanID replaces the spanid associated with this Exemplar.
 func (ms Exemplar) SetSpanID(v SpanID) {
-	(*ms.orig).SpanId = v.orig
+	ms.getMutableOrig().SpanId = v.orig
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms Exemplar) CopyTo(dest Exemplar) {
 	dest.SetTimestamp(ms.Timestamp())
 	switch ms.ValueType() {
+	case ExemplarValueTypeNone:
+		dest.getMutableOrig().Value = nil
 	case ExemplarValueTypeDouble:
 		dest.SetDoubleVal(ms.DoubleVal())
 	case ExemplarValueTypeInt:
@@ -19,6 +19,7 @@ package internal
 
 import (
 	"sort"
+	"unsafe"
 
 	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
 )
@@ -34,24 +35,38 @@ type ResourceSpansSlice struct {
 	// orig points to the slice otlptrace.ResourceSpans field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
 	orig *[]*otlptrace.ResourceSpans
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
+	// sharedElements, if not nil, tracks the elements sharing their content with other data, see Share.
+	sharedElements *SharedResourceSpans
 }
 
 func newResourceSpansSlice(orig *[]*otlptrace.ResourceSpans) ResourceSpansSlice {
-	return ResourceSpansSlice{orig}
+	return ResourceSpansSlice{orig: orig}
 }
 
 // NewResourceSpansSlice creates a ResourceSpansSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewResourceSpansSlice() ResourceSpansSlice {
 	orig := []*otlptrace.ResourceSpans(nil)
-	return ResourceSpansSlice{&orig}
+	return ResourceSpansSlice{orig: &orig}
+}
+
+// getOrig returns the orig to read.
+func (es ResourceSpansSlice) getOrig() *[]*otlptrace.ResourceSpans {
+	return (*[]*otlptrace.ResourceSpans)(es.shared.read(unsafe.Pointer(es.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (es ResourceSpansSlice) getMutableOrig() *[]*otlptrace.ResourceSpans {
+	return (*[]*otlptrace.ResourceSpans)(es.shared.write(unsafe.Pointer(es.orig)))
 }
 
 // Len returns the number of elements in the slice.
 //
 // Returns "0" for a newly instance created with "NewResourceSpansSlice()".
 func (es ResourceSpansSlice) Len() int {
-	return len(*es.orig)
+	return len(*es.getOrig())
 }
 
 // At returns the element at the given index.
@@ -62,27 +77,34 @@ func (es ResourceSpansSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ResourceSpansSlice) At(ix int) ResourceSpans {
-	return newResourceSpans((*es.orig)[ix])
+	orig := (*es.getOrig())[ix]
+	return ResourceSpans{orig: orig, shared: es.sharedElements.get(orig)}
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ResourceSpansSlice) CopyTo(dest ResourceSpansSlice) {
-	srcLen := es.Len()
-	destCap := cap(*dest.orig)
+	destOrig := dest.getMutableOrig()
+	orig := es.getOrig()
+	srcLen := len(*orig)
+	destCap := cap(*destOrig)
+	dest.sharedElements.drop((*destOrig)[:destCap])
 	if srcLen <= destCap {
-		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
-		for i := range *es.orig {
-			newResourceSpans((*es.orig)[i]).CopyTo(newResourceSpans((*dest.orig)[i]))
+		(*destOrig) = (*destOrig)[:srcLen:destCap]
+		for i := range *orig {
+			if (*destOrig)[i] == nil {
+				(*destOrig)[i] = &otlptrace.ResourceSpans{}
+			}
+			newResourceSpans((*orig)[i]).CopyTo(newResourceSpans((*destOrig)[i]))
 		}
 		return
 	}
 	origs := make([]otlptrace.ResourceSpans, srcLen)
 	wrappers := make([]*otlptrace.ResourceSpans, srcLen)
-	for i := range *es.orig {
+	for i := range *orig {
 		wrappers[i] = &origs[i]
-		newResourceSpans((*es.orig)[i]).CopyTo(newResourceSpans(wrappers[i]))
+		newResourceSpans((*orig)[i]).CopyTo(newResourceSpans(wrappers[i]))
 	}
-	*dest.orig = wrappers
+	*destOrig = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -97,20 +119,22 @@ func (es ResourceSpansSlice) CopyTo(dest ResourceSpansSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es ResourceSpansSlice) EnsureCapacity(newCap int) {
-	oldCap := cap(*es.orig)
+	oldCap := cap(*es.getOrig())
 	if newCap <= oldCap {
 		return
 	}
 
-	newOrig := make([]*otlptrace.ResourceSpans, len(*es.orig), newCap)
-	copy(newOrig, *es.orig)
-	*es.orig = newOrig
+	orig := es.getMutableOrig()
+	newOrig := make([]*otlptrace.ResourceSpans, len(*orig), newCap)
+	copy(newOrig, *orig)
+	*orig = newOrig
 }
 
 // AppendEmpty will append to the end of the slice an empty ResourceSpans.
 // It returns the newly added ResourceSpans.
 func (es ResourceSpansSlice) AppendEmpty() ResourceSpans {
-	*es.orig = append(*es.orig, &otlptrace.ResourceSpans{})
+	orig := es.getMutableOrig()
+	*orig = append(*orig, &otlptrace.ResourceSpans{})
 	return es.At(es.Len() - 1)
 }
 
@@ -124,28 +148,32 @@ func (es ResourceSpansSlice) AppendEmpty() ResourceSpans {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es ResourceSpansSlice) Sort(less func(a, b ResourceSpans) bool) ResourceSpansSlice {
-	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	sort.SliceStable(*es.getMutableOrig(), func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
 
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ResourceSpansSlice) MoveAndAppendTo(dest ResourceSpansSlice) {
-	if *dest.orig == nil {
+	orig := es.getMutableOrig()
+	destOrig := dest.getMutableOrig()
+	es.sharedElements.moveTo(dest.sharedElements, *orig)
+	if *destOrig == nil {
 		// We can simply move the entire vector and avoid any allocations.
-		*dest.orig = *es.orig
+		*destOrig = *orig
 	} else {
-		*dest.orig = append(*dest.orig, *es.orig...)
+		*destOrig = append(*destOrig, *orig...)
 	}
-	*es.orig = nil
+	*orig = nil
 }
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es ResourceSpansSlice) RemoveIf(f func(ResourceSpans) bool) {
 	newLen := 0
-	for i := 0; i < len(*es.orig); i++ {
+	for i := 0; i < es.Len(); i++ {
 		if f(es.At(i)) {
+			es.sharedElements.remove((*es.getOrig())[i])
 			continue
 		}
 		if newLen == i {
@@ -153,11 +181,19 @@ func (es ResourceSpansSlice) RemoveIf(f func(ResourceSpans) bool) {
 			newLen++
 			continue
 		}
-		(*es.orig)[newLen] = (*es.orig)[i]
+		orig := es.getMutableOrig()
+		(*orig)[newLen] = (*orig)[i]
 		newLen++
 	}
-	// TODO: Prevent memory leak by erasing truncated values.
-	*es.orig = (*es.orig)[:newLen]
+	if newLen == es.Len() {
+		return
+	}
+	orig := es.getMutableOrig()
+	// Erase truncated values to not keep references to removed elements.
+	for i := newLen; i < len(*orig); i++ {
+		(*orig)[i] = nil
+	}
+	*orig = (*orig)[:newLen]
 }
 
 // ResourceSpans is a collection of spans from a Resource.
@@ -169,12 +205,24 @@ func (es ResourceSpansSlice) RemoveIf(f func(ResourceSpans) bool) {
 // Important: zero-initialized instance is not valid for use.
 type ResourceSpans struct {
 	orig *otlptrace.ResourceSpans
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newResourceSpans(orig *otlptrace.ResourceSpans) ResourceSpans {
 	return ResourceSpans{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms ResourceSpans) getOrig() *otlptrace.ResourceSpans {
+	return (*otlptrace.ResourceSpans)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms ResourceSpans) getMutableOrig() *otlptrace.ResourceSpans {
+	return (*otlptrace.ResourceSpans)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewResourceSpans creates a new empty ResourceSpans.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -186,28 +234,29 @@ func NewResourceSpans() ResourceSpans {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms ResourceSpans) MoveTo(dest ResourceSpans) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlptrace.ResourceSpans{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlptrace.ResourceSpans{}
 }
 
 // Resource returns the resource associated with this ResourceSpans.
 func (ms ResourceSpans) Resource() Resource {
-	return newResource(&(*ms.orig).Resource)
+	return Resource{orig: &ms.getOrig().Resource, shared: ms.shared}
 }
 
 // SchemaUrl returns the schemaurl associated with this ResourceSpans.
 func (ms ResourceSpans) SchemaUrl() string {
-	return (*ms.orig).SchemaUrl
+	return ms.getOrig().SchemaUrl
 }
 
 // SetSchemaUrl replaces the schemaurl associated with this ResourceSpans.
 func (ms ResourceSpans) SetSchemaUrl(v string) {
-	(*ms.orig).SchemaUrl = v
+	ms.getMutableOrig().SchemaUrl = v
 }
 
 // ScopeSpans returns the ScopeSpans associated with this ResourceSpans.
 func (ms ResourceSpans) ScopeSpans() ScopeSpansSlice {
-	return newScopeSpansSlice(&(*ms.orig).ScopeSpans)
+	return ScopeSpansSlice{orig: &ms.getOrig().ScopeSpans, shared: ms.shared}
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -228,24 +277,36 @@ type ScopeSpansSlice struct {
 	// orig points to the slice otlptrace.ScopeSpans field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
 	orig *[]*otlptrace.ScopeSpans
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newScopeSpansSlice(orig *[]*otlptrace.ScopeSpans) ScopeSpansSlice {
-	return ScopeSpansSlice{orig}
+	return ScopeSpansSlice{orig: orig}
 }
 
 // NewScopeSpansSlice creates a ScopeSpansSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewScopeSpansSlice() ScopeSpansSlice {
 	orig := []*otlptrace.ScopeSpans(nil)
-	return ScopeSpansSlice{&orig}
+	return ScopeSpansSlice{orig: &orig}
+}
+
+// getOrig returns the orig to read.
+func (es ScopeSpansSlice) getOrig() *[]*otlptrace.ScopeSpans {
+	return (*[]*otlptrace.ScopeSpans)(es.shared.read(unsafe.Pointer(es.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (es ScopeSpansSlice) getMutableOrig() *[]*otlptrace.ScopeSpans {
+	return (*[]*otlptrace.ScopeSpans)(es.shared.write(unsafe.Pointer(es.orig)))
 }
 
 // Len returns the number of elements in the slice.
 //
 // Returns "0" for a newly instance created with "NewScopeSpansSlice()".
 func (es ScopeSpansSlice) Len() int {
-	return len(*es.orig)
+	return len(*es.getOrig())
 }
 
 // At returns the element at the given index.
@@ -256,27 +317,33 @@ func (es ScopeSpansSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ScopeSpansSlice) At(ix int) ScopeSpans {
-	return newScopeSpans((*es.orig)[ix])
+	orig := (*es.getOrig())[ix]
+	return ScopeSpans{orig: orig, shared: es.shared}
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ScopeSpansSlice) CopyTo(dest ScopeSpansSlice) {
-	srcLen := es.Len()
-	destCap := cap(*dest.orig)
+	destOrig := dest.getMutableOrig()
+	orig := es.getOrig()
+	srcLen := len(*orig)
+	destCap := cap(*destOrig)
 	if srcLen <= destCap {
-		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
-		for i := range *es.orig {
-			newScopeSpans((*es.orig)[i]).CopyTo(newScopeSpans((*dest.orig)[i]))
+		(*destOrig) = (*destOrig)[:srcLen:destCap]
+		for i := range *orig {
+			if (*destOrig)[i] == nil {
+				(*destOrig)[i] = &otlptrace.ScopeSpans{}
+			}
+			newScopeSpans((*orig)[i]).CopyTo(newScopeSpans((*destOrig)[i]))
 		}
 		return
 	}
 	origs := make([]otlptrace.ScopeSpans, srcLen)
 	wrappers := make([]*otlptrace.ScopeSpans, srcLen)
-	for i := range *es.orig {
+	for i := range *orig {
 		wrappers[i] = &origs[i]
-		newScopeSpans((*es.orig)[i]).CopyTo(newScopeSpans(wrappers[i]))
+		newScopeSpans((*orig)[i]).CopyTo(newScopeSpans(wrappers[i]))
 	}
-	*dest.orig = wrappers
+	*destOrig = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -291,20 +358,22 @@ func (es ScopeSpansSlice) CopyTo(dest ScopeSpansSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es ScopeSpansSlice) EnsureCapacity(newCap int) {
-	oldCap := cap(*es.orig)
+	oldCap := cap(*es.getOrig())
 	if newCap <= oldCap {
 		return
 	}
 
-	newOrig := make([]*otlptrace.ScopeSpans, len(*es.orig), newCap)
-	copy(newOrig, *es.orig)
-	*es.orig = newOrig
+	orig := es.getMutableOrig()
+	newOrig := make([]*otlptrace.ScopeSpans, len(*orig), newCap)
+	copy(newOrig, *orig)
+	*orig = newOrig
 }
 
 // AppendEmpty will append to the end of the slice an empty ScopeSpans.
 // It returns the newly added ScopeSpans.
 func (es ScopeSpansSlice) AppendEmpty() ScopeSpans {
-	*es.orig = append(*es.orig, &otlptrace.ScopeSpans{})
+	orig := es.getMutableOrig()
+	*orig = append(*orig, &otlptrace.ScopeSpans{})
 	return es.At(es.Len() - 1)
 }
 
@@ -318,27 +387,29 @@ func (es ScopeSpansSlice) AppendEmpty() ScopeSpans {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es ScopeSpansSlice) Sort(less func(a, b ScopeSpans) bool) ScopeSpansSlice {
-	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	sort.SliceStable(*es.getMutableOrig(), func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
 
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ScopeSpansSlice) MoveAndAppendTo(dest ScopeSpansSlice) {
-	if *dest.orig == nil {
+	orig := es.getMutableOrig()
+	destOrig := dest.getMutableOrig()
+	if *destOrig == nil {
 		// We can simply move the entire vector and avoid any allocations.
-		*dest.orig = *es.orig
+		*destOrig = *orig
 	} else {
-		*dest.orig = append(*dest.orig, *es.orig...)
+		*destOrig = append(*destOrig, *orig...)
 	}
-	*es.orig = nil
+	*orig = nil
 }
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es ScopeSpansSlice) RemoveIf(f func(ScopeSpans) bool) {
 	newLen := 0
-	for i := 0; i < len(*es.orig); i++ {
+	for i := 0; i < es.Len(); i++ {
 		if f(es.At(i)) {
 			continue
 		}
@@ -347,11 +418,19 @@ func (es ScopeSpansSlice) RemoveIf(f func(ScopeSpans) bool) {
 			newLen++
 			continue
 		}
-		(*es.orig)[newLen] = (*es.orig)[i]
+		orig := es.getMutableOrig()
+		(*orig)[newLen] = (*orig)[i]
 		newLen++
 	}
-	// TODO: Prevent memory leak by erasing truncated values.
-	*es.orig = (*es.orig)[:newLen]
+	if newLen == es.Len() {
+		return
+	}
+	orig := es.getMutableOrig()
+	// Erase truncated values to not keep references to removed elements.
+	for i := newLen; i < len(*orig); i++ {
+		(*orig)[i] = nil
+	}
+	*orig = (*orig)[:newLen]
 }
 
 // ScopeSpans is a collection of spans from a LibraryInstrumentation.
@@ -363,12 +442,24 @@ func (es ScopeSpansSlice) RemoveIf(f func(ScopeSpans) bool) {
 // Important: zero-initialized instance is not valid for use.
 type ScopeSpans struct {
 	orig *otlptrace.ScopeSpans
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newScopeSpans(orig *otlptrace.ScopeSpans) ScopeSpans {
 	return ScopeSpans{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms ScopeSpans) getOrig() *otlptrace.ScopeSpans {
+	return (*otlptrace.ScopeSpans)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms ScopeSpans) getMutableOrig() *otlptrace.ScopeSpans {
+	return (*otlptrace.ScopeSpans)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewScopeSpans creates a new empty ScopeSpans.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -380,28 +471,29 @@ func NewScopeSpans() ScopeSpans {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms ScopeSpans) MoveTo(dest ScopeSpans) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlptrace.ScopeSpans{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlptrace.ScopeSpans{}
 }
 
 // Scope returns the scope associated with this ScopeSpans.
 func (ms ScopeSpans) Scope() InstrumentationScope {
-	return newInstrumentationScope(&(*ms.orig).Scope)
+	return InstrumentationScope{orig: &ms.getOrig().Scope, shared: ms.shared}
 }
 
 // SchemaUrl returns the schemaurl associated with this ScopeSpans.
 func (ms ScopeSpans) SchemaUrl() string {
-	return (*ms.orig).SchemaUrl
+	return ms.getOrig().SchemaUrl
 }
 
 // SetSchemaUrl replaces the schemaurl associated with this ScopeSpans.
 func (ms ScopeSpans) SetSchemaUrl(v string) {
-	(*ms.orig).SchemaUrl = v
+	ms.getMutableOrig().SchemaUrl = v
 }
 
 // Spans returns the Spans associated with this ScopeSpans.
 func (ms ScopeSpans) Spans() SpanSlice {
-	return newSpanSlice(&(*ms.orig).Spans)
+	return SpanSlice{orig: &ms.getOrig().Spans, shared: ms.shared}
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -422,24 +514,36 @@ type SpanSlice struct {
 	// orig points to the slice otlptrace.Span field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
 	orig *[]*otlptrace.Span
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newSpanSlice(orig *[]*otlptrace.Span) SpanSlice {
-	return SpanSlice{orig}
+	return SpanSlice{orig: orig}
 }
 
 // NewSpanSlice creates a SpanSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewSpanSlice() SpanSlice {
 	orig := []*otlptrace.Span(nil)
-	return SpanSlice{&orig}
+	return SpanSlice{orig: &orig}
+}
+
+// getOrig returns the orig to read.
+func (es SpanSlice) getOrig() *[]*otlptrace.Span {
+	return (*[]*otlptrace.Span)(es.shared.read(unsafe.Pointer(es.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (es SpanSlice) getMutableOrig() *[]*otlptrace.Span {
+	return (*[]*otlptrace.Span)(es.shared.write(unsafe.Pointer(es.orig)))
 }
 
 // Len returns the number of elements in the slice.
 //
 // Returns "0" for a newly instance created with "NewSpanSlice()".
 func (es SpanSlice) Len() int {
-	return len(*es.orig)
+	return len(*es.getOrig())
 }
 
 // At returns the element at the given index.
@@ -450,27 +554,33 @@ func (es SpanSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es SpanSlice) At(ix int) Span {
-	return newSpan((*es.orig)[ix])
+	orig := (*es.getOrig())[ix]
+	return Span{orig: orig, shared: es.shared}
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es SpanSlice) CopyTo(dest SpanSlice) {
-	srcLen := es.Len()
-	destCap := cap(*dest.orig)
+	destOrig := dest.getMutableOrig()
+	orig := es.getOrig()
+	srcLen := len(*orig)
+	destCap := cap(*destOrig)
 	if srcLen <= destCap {
-		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
-		for i := range *es.orig {
-			newSpan((*es.orig)[i]).CopyTo(newSpan((*dest.orig)[i]))
+		(*destOrig) = (*destOrig)[:srcLen:destCap]
+		for i := range *orig {
+			if (*destOrig)[i] == nil {
+				(*destOrig)[i] = &otlptrace.Span{}
+			}
+			newSpan((*orig)[i]).CopyTo(newSpan((*destOrig)[i]))
 		}
 		return
 	}
 	origs := make([]otlptrace.Span, srcLen)
 	wrappers := make([]*otlptrace.Span, srcLen)
-	for i := range *es.orig {
+	for i := range *orig {
 		wrappers[i] = &origs[i]
-		newSpan((*es.orig)[i]).CopyTo(newSpan(wrappers[i]))
+		newSpan((*orig)[i]).CopyTo(newSpan(wrappers[i]))
 	}
-	*dest.orig = wrappers
+	*destOrig = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -485,20 +595,22 @@ func (es SpanSlice) CopyTo(dest SpanSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es SpanSlice) EnsureCapacity(newCap int) {
-	oldCap := cap(*es.orig)
+	oldCap := cap(*es.getOrig())
 	if newCap <= oldCap {
 		return
 	}
 
-	newOrig := make([]*otlptrace.Span, len(*es.orig), newCap)
-	copy(newOrig, *es.orig)
-	*es.orig = newOrig
+	orig := es.getMutableOrig()
+	newOrig := make([]*otlptrace.Span, len(*orig), newCap)
+	copy(newOrig, *orig)
+	*orig = newOrig
 }
 
 // AppendEmpty will append to the end of the slice an empty Span.
 // It returns the newly added Span.
 func (es SpanSlice) AppendEmpty() Span {
-	*es.orig = append(*es.orig, &otlptrace.Span{})
+	orig := es.getMutableOrig()
+	*orig = append(*orig, &otlptrace.Span{})
 	return es.At(es.Len() - 1)
 }
 
@@ -512,27 +624,29 @@ func (es SpanSlice) AppendEmpty() Span {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es SpanSlice) Sort(less func(a, b Span) bool) SpanSlice {
-	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	sort.SliceStable(*es.getMutableOrig(), func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
 
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es SpanSlice) MoveAndAppendTo(dest SpanSlice) {
-	if *dest.orig == nil {
+	orig := es.getMutableOrig()
+	destOrig := dest.getMutableOrig()
+	if *destOrig == nil {
 		// We can simply move the entire vector and avoid any allocations.
-		*dest.orig = *es.orig
+		*destOrig = *orig
 	} else {
-		*dest.orig = append(*dest.orig, *es.orig...)
+		*destOrig = append(*destOrig, *orig...)
 	}
-	*es.orig = nil
+	*orig = nil
 }
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es SpanSlice) RemoveIf(f func(Span) bool) {
 	newLen := 0
-	for i := 0; i < len(*es.orig); i++ {
+	for i := 0; i < es.Len(); i++ {
 		if f(es.At(i)) {
 			continue
 		}
@@ -541,11 +655,19 @@ func (es SpanSlice) RemoveIf(f func(Span) bool) {
 			newLen++
 			continue
 		}
-		(*es.orig)[newLen] = (*es.orig)[i]
+		orig := es.getMutableOrig()
+		(*orig)[newLen] = (*orig)[i]
 		newLen++
 	}
-	// TODO: Prevent memory leak by erasing truncated values.
-	*es.orig = (*es.orig)[:newLen]
+	if newLen == es.Len() {
+		return
+	}
+	orig := es.getMutableOrig()
+	// Erase truncated values to not keep references to removed elements.
+	for i := newLen; i < len(*orig); i++ {
+		(*orig)[i] = nil
+	}
+	*orig = (*orig)[:newLen]
 }
 
 // Span represents a single operation within a trace.
@@ -558,12 +680,24 @@ func (es SpanSlice) RemoveIf(f func(Span) bool) {
 // Important: zero-initialized instance is not valid for use.
 type Span struct {
 	orig *otlptrace.Span
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newSpan(orig *otlptrace.Span) Span {
 	return Span{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms Span) getOrig() *otlptrace.Span {
+	return (*otlptrace.Span)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms Span) getMutableOrig() *otlptrace.Span {
+	return (*otlptrace.Span)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewSpan creates a new empty Span.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -575,138 +709,139 @@ func NewSpan() Span {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms Span) MoveTo(dest Span) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlptrace.Span{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlptrace.Span{}
 }
 
 // TraceID returns the traceid associated with this Span.
 func (ms Span) TraceID() TraceID {
-	return TraceID{orig: ((*ms.orig).TraceId)}
+	return TraceID{orig: ms.getOrig().TraceId}
 }
 
 // SetTraceID replaces the traceid associated with this Span.
 func (ms Span) SetTraceID(v TraceID) {
-	(*ms.orig).TraceId = v.orig
+	ms.getMutableOrig().TraceId = v.orig
 }
 
 // SpanID returns the spanid associated with this Span.
 func (ms Span) SpanID() SpanID {
-	return SpanID{orig: ((*ms.orig).SpanId)}
+	return SpanID{orig: ms.getOrig().SpanId}
 }
 
 // SetSpanID replaces the spanid associated with this Span.
 func (ms Span) SetSpanID(v SpanID) {
-	(*ms.orig).SpanId = v.orig
+	ms.getMutableOrig().SpanId = v.orig
 }
 
 // TraceState returns the tracestate associated with this Span.
 func (ms Span) TraceState() TraceState {
-	return TraceState((*ms.orig).TraceState)
+	return TraceState(ms.getOrig().TraceState)
 }
 
 // SetTraceState replaces the tracestate associated with this Span.
 func (ms Span) SetTraceState(v TraceState) {
-	(*ms.orig).TraceState = string(v)
+	ms.getMutableOrig().TraceState = string(v)
 }
 
 // ParentSpanID returns the parentspanid associated with this Span.
 func (ms Span) ParentSpanID() SpanID {
-	return SpanID{orig: ((*ms.orig).ParentSpanId)}
+	return SpanID{orig: ms.getOrig().ParentSpanId}
 }
 
 // SetParentSpanID replaces the parentspanid associated with this Span.
 func (ms Span) SetParentSpanID(v SpanID) {
-	(*ms.orig).ParentSpanId = v.orig
+	ms.getMutableOrig().ParentSpanId = v.orig
 }
 
 // Name returns the name associated with this Span.
 func (ms Span) Name() string {
-	return (*ms.orig).Name
+	return ms.getOrig().Name
 }
 
 // SetName replaces the name associated with this Span.
 func (ms Span) SetName(v string) {
-	(*ms.orig).Name = v
+	ms.getMutableOrig().Name = v
 }
 
 // Kind returns the kind associated with this Span.
 func (ms Span) Kind() SpanKind {
-	return SpanKind((*ms.orig).Kind)
+	return SpanKind(ms.getOrig().Kind)
 }
 
 // SetKind replaces the kind associated with this Span.
 func (ms Span) SetKind(v SpanKind) {
-	(*ms.orig).Kind = otlptrace.Span_SpanKind(v)
+	ms.getMutableOrig().Kind = otlptrace.Span_SpanKind(v)
 }
 
 // StartTimestamp returns the starttimestamp associated with this Span.
 func (ms Span) StartTimestamp() Timestamp {
-	return Timestamp((*ms.orig).StartTimeUnixNano)
+	return Timestamp(ms.getOrig().StartTimeUnixNano)
 }
 
 // SetStartTimestamp replaces the starttimestamp associated with this Span.
 func (ms Span) SetStartTimestamp(v Timestamp) {
-	(*ms.orig).StartTimeUnixNano = uint64(v)
+	ms.getMutableOrig().StartTimeUnixNano = uint64(v)
 }
 
 // EndTimestamp returns the endtimestamp associated with this Span.
 func (ms Span) EndTimestamp() Timestamp {
-	return Timestamp((*ms.orig).EndTimeUnixNano)
+	return Timestamp(ms.getOrig().EndTimeUnixNano)
 }
 
 // SetEndTimestamp replaces the endtimestamp associated with this Span.
 func (ms Span) SetEndTimestamp(v Timestamp) {
-	(*ms.orig).EndTimeUnixNano = uint64(v)
+	ms.getMutableOrig().EndTimeUnixNano = uint64(v)
 }
 
 // Attributes returns the Attributes associated with this Span.
 func (ms Span) Attributes() Map {
-	return newMap(&(*ms.orig).Attributes)
+	return Map{orig: &ms.getOrig().Attributes, shared: ms.shared}
 }
 
 // DroppedAttributesCount returns the droppedattributescount associated with this Span.
 func (ms Span) DroppedAttributesCount() uint32 {
-	return (*ms.orig).DroppedAttributesCount
+	return ms.getOrig().DroppedAttributesCount
 }
 
 // SetDroppedAttributesCount replaces the droppedattributescount associated with this Span.
 func (ms Span) SetDroppedAttributesCount(v uint32) {
-	(*ms.orig).DroppedAttributesCount = v
+	ms.getMutableOrig().DroppedAttributesCount = v
 }
 
 // Events returns the Events associated with this Span.
 func (ms Span) Events() SpanEventSlice {
-	return newSpanEventSlice(&(*ms.orig).Events)
+	return SpanEventSlice{orig: &ms.getOrig().Events, shared: ms.shared}
 }
 
 // DroppedEventsCount returns the droppedeventscount associated with this Span.
 func (ms Span) DroppedEventsCount() uint32 {
-	return (*ms.orig).DroppedEventsCount
+	return ms.getOrig().DroppedEventsCount
 }
 
 // SetDroppedEventsCount replaces the droppedeventscount associated with this Span.
 func (ms Span) SetDroppedEventsCount(v uint32) {
-	(*ms.orig).DroppedEventsCount = v
+	ms.getMutableOrig().DroppedEventsCount = v
 }
 
 // Links returns the Links associated with this Span.
 func (ms Span) Links() SpanLinkSlice {
-	return newSpanLinkSlice(&(*ms.orig).Links)
+	return SpanLinkSlice{orig: &ms.getOrig().Links, shared: ms.shared}
 }
 
 // DroppedLinksCount returns the droppedlinkscount associated with this Span.
 func (ms Span) DroppedLinksCount() uint32 {
-	return (*ms.orig).DroppedLinksCount
+	return ms.getOrig().DroppedLinksCount
 }
 
 // SetDroppedLinksCount replaces the droppedlinkscount associated with this Span.
 func (ms Span) SetDroppedLinksCount(v uint32) {
-	(*ms.orig).DroppedLinksCount = v
+	ms.getMutableOrig().DroppedLinksCount = v
 }
 
 // Status returns the status associated with this Span.
 func (ms Span) Status() SpanStatus {
-	return newSpanStatus(&(*ms.orig).Status)
+	return SpanStatus{orig: &ms.getOrig().Status, shared: ms.shared}
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -739,24 +874,36 @@ type SpanEventSlice struct {
 	// orig points to the slice otlptrace.Span_Event field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
 	orig *[]*otlptrace.Span_Event
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newSpanEventSlice(orig *[]*otlptrace.Span_Event) SpanEventSlice {
-	return SpanEventSlice{orig}
+	return SpanEventSlice{orig: orig}
 }
 
 // NewSpanEventSlice creates a SpanEventSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewSpanEventSlice() SpanEventSlice {
 	orig := []*otlptrace.Span_Event(nil)
-	return SpanEventSlice{&orig}
+	return SpanEventSlice{orig: &orig}
+}
+
+// getOrig returns the orig to read.
+func (es SpanEventSlice) getOrig() *[]*otlptrace.Span_Event {
+	return (*[]*otlptrace.Span_Event)(es.shared.read(unsafe.Pointer(es.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (es SpanEventSlice) getMutableOrig() *[]*otlptrace.Span_Event {
+	return (*[]*otlptrace.Span_Event)(es.shared.write(unsafe.Pointer(es.orig)))
 }
 
 // Len returns the number of elements in the slice.
 //
 // Returns "0" for a newly instance created with "NewSpanEventSlice()".
 func (es SpanEventSlice) Len() int {
-	return len(*es.orig)
+	return len(*es.getOrig())
 }
 
 // At returns the element at the given index.
@@ -767,27 +914,33 @@ func (es SpanEventSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es SpanEventSlice) At(ix int) SpanEvent {
-	return newSpanEvent((*es.orig)[ix])
+	orig := (*es.getOrig())[ix]
+	return SpanEvent{orig: orig, shared: es.shared}
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es SpanEventSlice) CopyTo(dest SpanEventSlice) {
-	srcLen := es.Len()
-	destCap := cap(*dest.orig)
+	destOrig := dest.getMutableOrig()
+	orig := es.getOrig()
+	srcLen := len(*orig)
+	destCap := cap(*destOrig)
 	if srcLen <= destCap {
-		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
-		for i := range *es.orig {
-			newSpanEvent((*es.orig)[i]).CopyTo(newSpanEvent((*dest.orig)[i]))
+		(*destOrig) = (*destOrig)[:srcLen:destCap]
+		for i := range *orig {
+			if (*destOrig)[i] == nil {
+				(*destOrig)[i] = &otlptrace.Span_Event{}
+			}
+			newSpanEvent((*orig)[i]).CopyTo(newSpanEvent((*destOrig)[i]))
 		}
 		return
 	}
 	origs := make([]otlptrace.Span_Event, srcLen)
 	wrappers := make([]*otlptrace.Span_Event, srcLen)
-	for i := range *es.orig {
+	for i := range *orig {
 		wrappers[i] = &origs[i]
-		newSpanEvent((*es.orig)[i]).CopyTo(newSpanEvent(wrappers[i]))
+		newSpanEvent((*orig)[i]).CopyTo(newSpanEvent(wrappers[i]))
 	}
-	*dest.orig = wrappers
+	*destOrig = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -802,20 +955,22 @@ func (es SpanEventSlice) CopyTo(dest SpanEventSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es SpanEventSlice) EnsureCapacity(newCap int) {
-	oldCap := cap(*es.orig)
+	oldCap := cap(*es.getOrig())
 	if newCap <= oldCap {
 		return
 	}
 
-	newOrig := make([]*otlptrace.Span_Event, len(*es.orig), newCap)
-	copy(newOrig, *es.orig)
-	*es.orig = newOrig
+	orig := es.getMutableOrig()
+	newOrig := make([]*otlptrace.Span_Event, len(*orig), newCap)
+	copy(newOrig, *orig)
+	*orig = newOrig
 }
 
 // AppendEmpty will append to the end of the slice an empty SpanEvent.
 // It returns the newly added SpanEvent.
 func (es SpanEventSlice) AppendEmpty() SpanEvent {
-	*es.orig = append(*es.orig, &otlptrace.Span_Event{})
+	orig := es.getMutableOrig()
+	*orig = append(*orig, &otlptrace.Span_Event{})
 	return es.At(es.Len() - 1)
 }
 
@@ -829,27 +984,29 @@ func (es SpanEventSlice) AppendEmpty() SpanEvent {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es SpanEventSlice) Sort(less func(a, b SpanEvent) bool) SpanEventSlice {
-	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	sort.SliceStable(*es.getMutableOrig(), func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
 
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es SpanEventSlice) MoveAndAppendTo(dest SpanEventSlice) {
-	if *dest.orig == nil {
+	orig := es.getMutableOrig()
+	destOrig := dest.getMutableOrig()
+	if *destOrig == nil {
 		// We can simply move the entire vector and avoid any allocations.
-		*dest.orig = *es.orig
+		*destOrig = *orig
 	} else {
-		*dest.orig = append(*dest.orig, *es.orig...)
+		*destOrig = append(*destOrig, *orig...)
 	}
-	*es.orig = nil
+	*orig = nil
 }
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es SpanEventSlice) RemoveIf(f func(SpanEvent) bool) {
 	newLen := 0
-	for i := 0; i < len(*es.orig); i++ {
+	for i := 0; i < es.Len(); i++ {
 		if f(es.At(i)) {
 			continue
 		}
@@ -858,11 +1015,19 @@ func (es SpanEventSlice) RemoveIf(f func(SpanEvent) bool) {
 			newLen++
 			continue
 		}
-		(*es.orig)[newLen] = (*es.orig)[i]
+		orig := es.getMutableOrig()
+		(*orig)[newLen] = (*orig)[i]
 		newLen++
 	}
-	// TODO: Prevent memory leak by erasing truncated values.
-	*es.orig = (*es.orig)[:newLen]
+	if newLen == es.Len() {
+		return
+	}
+	orig := es.getMutableOrig()
+	// Erase truncated values to not keep references to removed elements.
+	for i := newLen; i < len(*orig); i++ {
+		(*orig)[i] = nil
+	}
+	*orig = (*orig)[:newLen]
 }
 
 // SpanEvent is a time-stamped annotation of the span, consisting of user-supplied
@@ -875,12 +1040,24 @@ func (es SpanEventSlice) RemoveIf(f func(SpanEvent) bool) {
 // Important: zero-initialized instance is not valid for use.
 type SpanEvent struct {
 	orig *otlptrace.Span_Event
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newSpanEvent(orig *otlptrace.Span_Event) SpanEvent {
 	return SpanEvent{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms SpanEvent) getOrig() *otlptrace.Span_Event {
+	return (*otlptrace.Span_Event)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms SpanEvent) getMutableOrig() *otlptrace.Span_Event {
+	return (*otlptrace.Span_Event)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewSpanEvent creates a new empty SpanEvent.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -892,43 +1069,44 @@ func NewSpanEvent() SpanEvent {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms SpanEvent) MoveTo(dest SpanEvent) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlptrace.Span_Event{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlptrace.Span_Event{}
 }
 
 // Timestamp returns the timestamp associated with this SpanEvent.
 func (ms SpanEvent) Timestamp() Timestamp {
-	return Timestamp((*ms.orig).TimeUnixNano)
+	return Timestamp(ms.getOrig().TimeUnixNano)
 }
 
 // SetTimestamp replaces the timestamp associated with this SpanEvent.
 func (ms SpanEvent) SetTimestamp(v Timestamp) {
-	(*ms.orig).TimeUnixNano = uint64(v)
+	ms.getMutableOrig().TimeUnixNano = uint64(v)
 }
 
 // Name returns the name associated with this SpanEvent.
 func (ms SpanEvent) Name() string {
-	return (*ms.orig).Name
+	return ms.getOrig().Name
 }
 
 // SetName replaces the name associated with this SpanEvent.
 func (ms SpanEvent) SetName(v string) {
-	(*ms.orig).Name = v
+	ms.getMutableOrig().Name = v
 }
 
 // Attributes returns the Attributes associated with this SpanEvent.
 func (ms SpanEvent) Attributes() Map {
-	return newMap(&(*ms.orig).Attributes)
+	return Map{orig: &ms.getOrig().Attributes, shared: ms.shared}
 }
 
 // DroppedAttributesCount returns the droppedattributescount associated with this SpanEvent.
 func (ms SpanEvent) DroppedAttributesCount() uint32 {
-	return (*ms.orig).DroppedAttributesCount
+	return ms.getOrig().DroppedAttributesCount
 }
 
 // SetDroppedAttributesCount replaces the droppedattributescount associated with this SpanEvent.
 func (ms SpanEvent) SetDroppedAttributesCount(v uint32) {
-	(*ms.orig).DroppedAttributesCount = v
+	ms.getMutableOrig().DroppedAttributesCount = v
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -950,24 +1128,36 @@ type SpanLinkSlice struct {
 	// orig points to the slice otlptrace.Span_Link field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
 	orig *[]*otlptrace.Span_Link
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newSpanLinkSlice(orig *[]*otlptrace.Span_Link) SpanLinkSlice {
-	return SpanLinkSlice{orig}
+	return SpanLinkSlice{orig: orig}
 }
 
 // NewSpanLinkSlice creates a SpanLinkSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewSpanLinkSlice() SpanLinkSlice {
 	orig := []*otlptrace.Span_Link(nil)
-	return SpanLinkSlice{&orig}
+	return SpanLinkSlice{orig: &orig}
+}
+
+// getOrig returns the orig to read.
+func (es SpanLinkSlice) getOrig() *[]*otlptrace.Span_Link {
+	return (*[]*otlptrace.Span_Link)(es.shared.read(unsafe.Pointer(es.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (es SpanLinkSlice) getMutableOrig() *[]*otlptrace.Span_Link {
+	return (*[]*otlptrace.Span_Link)(es.shared.write(unsafe.Pointer(es.orig)))
 }
 
 // Len returns the number of elements in the slice.
 //
 // Returns "0" for a newly instance created with "NewSpanLinkSlice()".
 func (es SpanLinkSlice) Len() int {
-	return len(*es.orig)
+	return len(*es.getOrig())
 }
 
 // At returns the element at the given index.
@@ -978,27 +1168,33 @@ func (es SpanLinkSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es SpanLinkSlice) At(ix int) SpanLink {
-	return newSpanLink((*es.orig)[ix])
+	orig := (*es.getOrig())[ix]
+	return SpanLink{orig: orig, shared: es.shared}
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es SpanLinkSlice) CopyTo(dest SpanLinkSlice) {
-	srcLen := es.Len()
-	destCap := cap(*dest.orig)
+	destOrig := dest.getMutableOrig()
+	orig := es.getOrig()
+	srcLen := len(*orig)
+	destCap := cap(*destOrig)
 	if srcLen <= destCap {
-		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
-		for i := range *es.orig {
-			newSpanLink((*es.orig)[i]).CopyTo(newSpanLink((*dest.orig)[i]))
+		(*destOrig) = (*destOrig)[:srcLen:destCap]
+		for i := range *orig {
+			if (*destOrig)[i] == nil {
+				(*destOrig)[i] = &otlptrace.Span_Link{}
+			}
+			newSpanLink((*orig)[i]).CopyTo(newSpanLink((*destOrig)[i]))
 		}
 		return
 	}
 	origs := make([]otlptrace.Span_Link, srcLen)
 	wrappers := make([]*otlptrace.Span_Link, srcLen)
-	for i := range *es.orig {
+	for i := range *orig {
 		wrappers[i] = &origs[i]
-		newSpanLink((*es.orig)[i]).CopyTo(newSpanLink(wrappers[i]))
+		newSpanLink((*orig)[i]).CopyTo(newSpanLink(wrappers[i]))
 	}
-	*dest.orig = wrappers
+	*destOrig = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -1013,20 +1209,22 @@ func (es SpanLinkSlice) CopyTo(dest SpanLinkSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es SpanLinkSlice) EnsureCapacity(newCap int) {
-	oldCap := cap(*es.orig)
+	oldCap := cap(*es.getOrig())
 	if newCap <= oldCap {
 		return
 	}
 
-	newOrig := make([]*otlptrace.Span_Link, len(*es.orig), newCap)
-	copy(newOrig, *es.orig)
-	*es.orig = newOrig
+	orig := es.getMutableOrig()
+	newOrig := make([]*otlptrace.Span_Link, len(*orig), newCap)
+	copy(newOrig, *orig)
+	*orig = newOrig
 }
 
 // AppendEmpty will append to the end of the slice an empty SpanLink.
 // It returns the newly added SpanLink.
 func (es SpanLinkSlice) AppendEmpty() SpanLink {
-	*es.orig = append(*es.orig, &otlptrace.Span_Link{})
+	orig := es.getMutableOrig()
+	*orig = append(*orig, &otlptrace.Span_Link{})
 	return es.At(es.Len() - 1)
 }
 
@@ -1040,27 +1238,29 @@ func (es SpanLinkSlice) AppendEmpty() SpanLink {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es SpanLinkSlice) Sort(less func(a, b SpanLink) bool) SpanLinkSlice {
-	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	sort.SliceStable(*es.getMutableOrig(), func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
 
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es SpanLinkSlice) MoveAndAppendTo(dest SpanLinkSlice) {
-	if *dest.orig == nil {
+	orig := es.getMutableOrig()
+	destOrig := dest.getMutableOrig()
+	if *destOrig == nil {
 		// We can simply move the entire vector and avoid any allocations.
-		*dest.orig = *es.orig
+		*destOrig = *orig
 	} else {
-		*dest.orig = append(*dest.orig, *es.orig...)
+		*destOrig = append(*destOrig, *orig...)
 	}
-	*es.orig = nil
+	*orig = nil
 }
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es SpanLinkSlice) RemoveIf(f func(SpanLink) bool) {
 	newLen := 0
-	for i := 0; i < len(*es.orig); i++ {
+	for i := 0; i < es.Len(); i++ {
 		if f(es.At(i)) {
 			continue
 		}
@@ -1069,11 +1269,19 @@ func (es SpanLinkSlice) RemoveIf(f func(SpanLink) bool) {
 			newLen++
 			continue
 		}
-		(*es.orig)[newLen] = (*es.orig)[i]
+		orig := es.getMutableOrig()
+		(*orig)[newLen] = (*orig)[i]
 		newLen++
 	}
-	// TODO: Prevent memory leak by erasing truncated values.
-	*es.orig = (*es.orig)[:newLen]
+	if newLen == es.Len() {
+		return
+	}
+	orig := es.getMutableOrig()
+	// Erase truncated values to not keep references to removed elements.
+	for i := newLen; i < len(*orig); i++ {
+		(*orig)[i] = nil
+	}
+	*orig = (*orig)[:newLen]
 }
 
 // SpanLink is a pointer from the current span to another span in the same trace or in a
@@ -1087,12 +1295,24 @@ func (es SpanLinkSlice) RemoveIf(f func(SpanLink) bool) {
 // Important: zero-initialized instance is not valid for use.
 type SpanLink struct {
 	orig *otlptrace.Span_Link
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newSpanLink(orig *otlptrace.Span_Link) SpanLink {
 	return SpanLink{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms SpanLink) getOrig() *otlptrace.Span_Link {
+	return (*otlptrace.Span_Link)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms SpanLink) getMutableOrig() *otlptrace.Span_Link {
+	return (*otlptrace.Span_Link)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewSpanLink creates a new empty SpanLink.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -1104,53 +1324,54 @@ func NewSpanLink() SpanLink {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms SpanLink) MoveTo(dest SpanLink) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlptrace.Span_Link{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlptrace.Span_Link{}
 }
 
 // TraceID returns the traceid associated with this SpanLink.
 func (ms SpanLink) TraceID() TraceID {
-	return TraceID{orig: ((*ms.orig).TraceId)}
+	return TraceID{orig: ms.getOrig().TraceId}
 }
 
 // SetTraceID replaces the traceid associated with this SpanLink.
 func (ms SpanLink) SetTraceID(v TraceID) {
-	(*ms.orig).TraceId = v.orig
+	ms.getMutableOrig().TraceId = v.orig
 }
 
 // SpanID returns the spanid associated with this SpanLink.
 func (ms SpanLink) SpanID() SpanID {
-	return SpanID{orig: ((*ms.orig).SpanId)}
+	return SpanID{orig: ms.getOrig().SpanId}
 }
 
 // SetSpanID replaces the spanid associated with this SpanLink.
 func (ms SpanLink) SetSpanID(v SpanID) {
-	(*ms.orig).SpanId = v.orig
+	ms.getMutableOrig().SpanId = v.orig
 }
 
 // TraceState returns the tracestate associated with this SpanLink.
 func (ms SpanLink) TraceState() TraceState {
-	return TraceState((*ms.orig).TraceState)
+	return TraceState(ms.getOrig().TraceState)
 }
 
 // SetTraceState replaces the tracestate associated with this SpanLink.
 func (ms SpanLink) SetTraceState(v TraceState) {
-	(*ms.orig).TraceState = string(v)
+	ms.getMutableOrig().TraceState = string(v)
 }
 
 // Attributes returns the Attributes associated with this SpanLink.
 func (ms SpanLink) Attributes() Map {
-	return newMap(&(*ms.orig).Attributes)
+	return Map{orig: &ms.getOrig().Attributes, shared: ms.shared}
 }
 
 // DroppedAttributesCount returns the droppedattributescount associated with this SpanLink.
 func (ms SpanLink) DroppedAttributesCount() uint32 {
-	return (*ms.orig).DroppedAttributesCount
+	return ms.getOrig().DroppedAttributesCount
 }
 
 // SetDroppedAttributesCount replaces the droppedattributescount associated with this SpanLink.
 func (ms SpanLink) SetDroppedAttributesCount(v uint32) {
-	(*ms.orig).DroppedAttributesCount = v
+	ms.getMutableOrig().DroppedAttributesCount = v
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -1172,12 +1393,24 @@ func (ms SpanLink) CopyTo(dest SpanLink) {
 // Important: zero-initialized instance is not valid for use.
 type SpanStatus struct {
 	orig *otlptrace.Status
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newSpanStatus(orig *otlptrace.Status) SpanStatus {
 	return SpanStatus{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms SpanStatus) getOrig() *otlptrace.Status {
+	return (*otlptrace.Status)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms SpanStatus) getMutableOrig() *otlptrace.Status {
+	return (*otlptrace.Status)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewSpanStatus creates a new empty SpanStatus.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -1189,28 +1422,29 @@ func NewSpanStatus() SpanStatus {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms SpanStatus) MoveTo(dest SpanStatus) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlptrace.Status{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlptrace.Status{}
 }
 
 // Code returns the code associated with this SpanStatus.
 func (ms SpanStatus) Code() StatusCode {
-	return StatusCode((*ms.orig).Code)
+	return StatusCode(ms.getOrig().Code)
 }
 
 // SetCode replaces the code associated with this SpanStatus.
 func (ms SpanStatus) SetCode(v StatusCode) {
-	(*ms.orig).Code = otlptrace.Status_StatusCode(v)
+	ms.getMutableOrig().Code = otlptrace.Status_StatusCode(v)
 }
 
 // Message returns the message associated with this SpanStatus.
 func (ms SpanStatus) Message() string {
-	return (*ms.orig).Message
+	return ms.getOrig().Message
 }
 
 // SetMessage replaces the message associated with this SpanStatus.
 func (ms SpanStatus) SetMessage(v string) {
-	(*ms.orig).Message = v
+	ms.getMutableOrig().Message = v
 }
 
 // CopyTo copies all properties from the current struct to the dest.
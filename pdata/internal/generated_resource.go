@@ -18,6 +18,8 @@
 package internal
 
 import (
+	"unsafe"
+
 	otlpresource "go.opentelemetry.io/collector/pdata/internal/data/protogen/resource/v1"
 )
 
@@ -30,12 +32,24 @@ import (
 // Important: zero-initialized instance is not valid for use.
 type Resource struct {
 	orig *otlpresource.Resource
+	// shared, if not nil, is the resource shared with other data that orig belongs to.
+	shared *sharedResource
 }
 
 func newResource(orig *otlpresource.Resource) Resource {
 	return Resource{orig: orig}
 }
 
+// getOrig returns the orig to read.
+func (ms Resource) getOrig() *otlpresource.Resource {
+	return (*otlpresource.Resource)(ms.shared.read(unsafe.Pointer(ms.orig)))
+}
+
+// getMutableOrig returns the orig to modify, copying the shared resource it belongs to first.
+func (ms Resource) getMutableOrig() *otlpresource.Resource {
+	return (*otlpresource.Resource)(ms.shared.write(unsafe.Pointer(ms.orig)))
+}
+
 // NewResource creates a new empty Resource.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -47,23 +61,24 @@ func NewResource() Resource {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms Resource) MoveTo(dest Resource) {
-	*dest.orig = *ms.orig
-	*ms.orig = otlpresource.Resource{}
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlpresource.Resource{}
 }
 
 // Attributes returns the Attributes associated with this Resource.
 func (ms Resource) Attributes() Map {
-	return newMap(&(*ms.orig).Attributes)
+	return Map{orig: &ms.getOrig().Attributes, shared: ms.shared}
 }
 
 // DroppedAttributesCount returns the droppedattributescount associated with this Resource.
 func (ms Resource) DroppedAttributesCount() uint32 {
-	return (*ms.orig).DroppedAttributesCount
+	return ms.getOrig().DroppedAttributesCount
 }
 
 // SetDroppedAttributesCount replaces the droppedattributescount associated with this Resource.
 func (ms Resource) SetDroppedAttributesCount(v uint32) {
-	(*ms.orig).DroppedAttributesCount = v
+	ms.getMutableOrig().DroppedAttributesCount = v
 }
 
 // CopyTo copies all properties from the current struct to the dest.
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+import (
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	otlpresource "go.opentelemetry.io/collector/pdata/internal/data/protogen/resource/v1"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+)
+
+// The hashes are computed on the encoding of a copy of the elements whose attributes and repeated
+// elements are sorted, they are stable across processes and equal for elements that only differ
+// by the order of their attributes, as for the EqualIgnoringOrder methods.
+
+// Hash returns a stable hash of the Map, regardless of the order of its keys.
+func (m Map) Hash() uint64 {
+	orig := &otlpcommon.KeyValueList{}
+	m.CopyTo(newMap(&orig.Values))
+	return hashMessage(orig)
+}
+
+// Hash returns a stable hash of the Resource, regardless of the order of its attributes.
+func (ms Resource) Hash() uint64 {
+	orig := &otlpresource.Resource{}
+	ms.CopyTo(newResource(orig))
+	return hashMessage(orig)
+}
+
+// Hash returns a stable hash of the InstrumentationScope.
+func (ms InstrumentationScope) Hash() uint64 {
+	orig := &otlpcommon.InstrumentationScope{}
+	ms.CopyTo(newInstrumentationScope(orig))
+	return hashMessage(orig)
+}
+
+// Hash returns a stable hash of the Span, regardless of the order of its attributes, events and links.
+func (ms Span) Hash() uint64 {
+	orig := &otlptrace.Span{}
+	ms.CopyTo(newSpan(orig))
+	return hashMessage(orig)
+}
+
+// Hash returns a stable hash of the LogRecord, regardless of the order of its attributes.
+func (ms LogRecord) Hash() uint64 {
+	orig := &otlplogs.LogRecord{}
+	ms.CopyTo(newLogRecord(orig))
+	return hashMessage(orig)
+}
+
+// Hash returns a stable hash of the NumberDataPoint, regardless of the order of its attributes and exemplars.
+func (ms NumberDataPoint) Hash() uint64 {
+	orig := &otlpmetrics.NumberDataPoint{}
+	ms.CopyTo(newNumberDataPoint(orig))
+	return hashMessage(orig)
+}
+
+// Hash returns a stable hash of the HistogramDataPoint, regardless of the order of its attributes and exemplars.
+func (ms HistogramDataPoint) Hash() uint64 {
+	orig := &otlpmetrics.HistogramDataPoint{}
+	ms.CopyTo(newHistogramDataPoint(orig))
+	return hashMessage(orig)
+}
+
+// Hash returns a stable hash of the ExponentialHistogramDataPoint, regardless of the order of its attributes and exemplars.
+func (ms ExponentialHistogramDataPoint) Hash() uint64 {
+	orig := &otlpmetrics.ExponentialHistogramDataPoint{}
+	ms.CopyTo(newExponentialHistogramDataPoint(orig))
+	return hashMessage(orig)
+}
+
+// Hash returns a stable hash of the SummaryDataPoint, regardless of the order of its attributes and quantile values.
+func (ms SummaryDataPoint) Hash() uint64 {
+	orig := &otlpmetrics.SummaryDataPoint{}
+	ms.CopyTo(newSummaryDataPoint(orig))
+	return hashMessage(orig)
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type hashable interface {
+	Hash() uint64
+}
+
+func TestHash(t *testing.T) {
+	tests := []struct {
+		name     string
+		generate func() (hashable, Map)
+	}{
+		{
+			name: "Map",
+			generate: func() (hashable, Map) {
+				ms := generateTestMap()
+				return ms, ms
+			},
+		},
+		{
+			name: "Resource",
+			generate: func() (hashable, Map) {
+				ms := generateTestResource()
+				return ms, ms.Attributes()
+			},
+		},
+		{
+			name: "Span",
+			generate: func() (hashable, Map) {
+				ms := generateTestSpan()
+				return ms, ms.Attributes()
+			},
+		},
+		{
+			name: "LogRecord",
+			generate: func() (hashable, Map) {
+				ms := generateTestLogRecord()
+				return ms, ms.Attributes()
+			},
+		},
+		{
+			name: "NumberDataPoint",
+			generate: func() (hashable, Map) {
+				ms := generateTestNumberDataPoint()
+				return ms, ms.Attributes()
+			},
+		},
+		{
+			name: "HistogramDataPoint",
+			generate: func() (hashable, Map) {
+				ms := generateTestHistogramDataPoint()
+				return ms, ms.Attributes()
+			},
+		},
+		{
+			name: "ExponentialHistogramDataPoint",
+			generate: func() (hashable, Map) {
+				ms := generateTestExponentialHistogramDataPoint()
+				return ms, ms.Attributes()
+			},
+		},
+		{
+			name: "SummaryDataPoint",
+			generate: func() (hashable, Map) {
+				ms := generateTestSummaryDataPoint()
+				return ms, ms.Attributes()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms, _ := tt.generate()
+			hash := ms.Hash()
+			other, _ := tt.generate()
+			assert.Equal(t, hash, other.Hash())
+
+			// The order of the attributes does not matter.
+			ms, attrs := tt.generate()
+			attrs.InsertString("a", "1")
+			attrs.InsertString("b", "2")
+			other, otherAttrs := tt.generate()
+			otherAttrs.InsertString("b", "2")
+			otherAttrs.InsertString("a", "1")
+			assert.NotEqual(t, hash, ms.Hash())
+			assert.Equal(t, ms.Hash(), other.Hash())
+			// Hashing does not sort the attributes.
+			assert.Equal(t, "b", (*otherAttrs.orig)[otherAttrs.Len()-2].Key)
+		})
+	}
+}
+
+func TestSpanHash(t *testing.T) {
+	// The hash only depends on the content.
+	assert.Equal(t, uint64(0x2d8956710b55bcb5), NewSpan().Hash())
+
+	span := NewSpan()
+	span.Events().AppendEmpty().SetName("event1")
+	span.Events().AppendEmpty().SetName("event2")
+	other := NewSpan()
+	other.Events().AppendEmpty().SetName("event2")
+	other.Events().AppendEmpty().SetName("event1")
+	assert.Equal(t, span.Hash(), other.Hash())
+	assert.Equal(t, "event2", other.Events().At(0).Name())
+
+	span.SetName("name")
+	assert.NotEqual(t, span.Hash(), other.Hash())
+}
+
+func TestInstrumentationScopeHash(t *testing.T) {
+	ms := generateTestInstrumentationScope()
+	assert.Equal(t, ms.Hash(), generateTestInstrumentationScope().Hash())
+	ms.SetVersion("changed")
+	assert.NotEqual(t, ms.Hash(), generateTestInstrumentationScope().Hash())
+}
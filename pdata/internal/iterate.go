@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+// The ForEach helpers call a function for each element of a kind, handing the enclosing resource
+// and scope, and the Remove*If helpers remove the elements for which the function returns true.
+// The Remove*If helpers also remove the enclosing elements they leave empty, but keep those
+// that were already empty.
+
+// ForEachSpan calls f for each Span, with its Resource and InstrumentationScope.
+func (td Traces) ForEachSpan(f func(Resource, InstrumentationScope, Span)) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				f(rs.Resource(), ss.Scope(), spans.At(k))
+			}
+		}
+	}
+}
+
+// RemoveSpansIf removes the Span for which f, called with its Resource and InstrumentationScope,
+// returns true, and the ScopeSpans and ResourceSpans left without Span.
+func (td Traces) RemoveSpansIf(f func(Resource, InstrumentationScope, Span) bool) {
+	td.ResourceSpans().RemoveIf(func(rs ResourceSpans) bool {
+		if rs.ScopeSpans().Len() == 0 {
+			return false
+		}
+		rs.ScopeSpans().RemoveIf(func(ss ScopeSpans) bool {
+			if ss.Spans().Len() == 0 {
+				return false
+			}
+			ss.Spans().RemoveIf(func(span Span) bool {
+				return f(rs.Resource(), ss.Scope(), span)
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+}
+
+// ForEachLogRecord calls f for each LogRecord, with its Resource and InstrumentationScope.
+func (ld Logs) ForEachLogRecord(f func(Resource, InstrumentationScope, LogRecord)) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				f(rl.Resource(), sl.Scope(), lrs.At(k))
+			}
+		}
+	}
+}
+
+// RemoveLogRecordsIf removes the LogRecord for which f, called with its Resource and InstrumentationScope,
+// returns true, and the ScopeLogs and ResourceLogs left without LogRecord.
+func (ld Logs) RemoveLogRecordsIf(f func(Resource, InstrumentationScope, LogRecord) bool) {
+	ld.ResourceLogs().RemoveIf(func(rl ResourceLogs) bool {
+		if rl.ScopeLogs().Len() == 0 {
+			return false
+		}
+		rl.ScopeLogs().RemoveIf(func(sl ScopeLogs) bool {
+			if sl.LogRecords().Len() == 0 {
+				return false
+			}
+			sl.LogRecords().RemoveIf(func(lr LogRecord) bool {
+				return f(rl.Resource(), sl.Scope(), lr)
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+}
+
+// DataPoint is implemented by NumberDataPoint, HistogramDataPoint, ExponentialHistogramDataPoint
+// and SummaryDataPoint, the type of the data points of a Metric. Use a type switch to access
+// the fields specific to a type.
+type DataPoint interface {
+	Attributes() Map
+	StartTimestamp() Timestamp
+	SetStartTimestamp(Timestamp)
+	Timestamp() Timestamp
+	SetTimestamp(Timestamp)
+	Flags() MetricDataPointFlags
+	SetFlags(MetricDataPointFlags)
+}
+
+// ForEachMetric calls f for each Metric, with its Resource and InstrumentationScope.
+func (md Metrics) ForEachMetric(f func(Resource, InstrumentationScope, Metric)) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			ms := sm.Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				f(rm.Resource(), sm.Scope(), ms.At(k))
+			}
+		}
+	}
+}
+
+// RemoveMetricsIf removes the Metric for which f, called with its Resource and InstrumentationScope,
+// returns true, and the ScopeMetrics and ResourceMetrics left without Metric.
+func (md Metrics) RemoveMetricsIf(f func(Resource, InstrumentationScope, Metric) bool) {
+	md.ResourceMetrics().RemoveIf(func(rm ResourceMetrics) bool {
+		if rm.ScopeMetrics().Len() == 0 {
+			return false
+		}
+		rm.ScopeMetrics().RemoveIf(func(sm ScopeMetrics) bool {
+			if sm.Metrics().Len() == 0 {
+				return false
+			}
+			sm.Metrics().RemoveIf(func(m Metric) bool {
+				return f(rm.Resource(), sm.Scope(), m)
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+}
+
+// ForEachDataPoint calls f for each data point, with its Resource, InstrumentationScope and Metric.
+func (md Metrics) ForEachDataPoint(f func(Resource, InstrumentationScope, Metric, DataPoint)) {
+	md.ForEachMetric(func(res Resource, scope InstrumentationScope, m Metric) {
+		switch m.DataType() {
+		case MetricDataTypeGauge:
+			dps := m.Gauge().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				f(res, scope, m, dps.At(i))
+			}
+		case MetricDataTypeSum:
+			dps := m.Sum().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				f(res, scope, m, dps.At(i))
+			}
+		case MetricDataTypeHistogram:
+			dps := m.Histogram().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				f(res, scope, m, dps.At(i))
+			}
+		case MetricDataTypeExponentialHistogram:
+			dps := m.ExponentialHistogram().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				f(res, scope, m, dps.At(i))
+			}
+		case MetricDataTypeSummary:
+			dps := m.Summary().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				f(res, scope, m, dps.At(i))
+			}
+		}
+	})
+}
+
+// RemoveDataPointsIf removes the data points for which f, called with its Resource, InstrumentationScope
+// and Metric, returns true, and the Metric, ScopeMetrics and ResourceMetrics left without data point.
+func (md Metrics) RemoveDataPointsIf(f func(Resource, InstrumentationScope, Metric, DataPoint) bool) {
+	md.RemoveMetricsIf(func(res Resource, scope InstrumentationScope, m Metric) bool {
+		switch m.DataType() {
+		case MetricDataTypeGauge:
+			dps := m.Gauge().DataPoints()
+			if dps.Len() == 0 {
+				return false
+			}
+			dps.RemoveIf(func(dp NumberDataPoint) bool {
+				return f(res, scope, m, dp)
+			})
+			return dps.Len() == 0
+		case MetricDataTypeSum:
+			dps := m.Sum().DataPoints()
+			if dps.Len() == 0 {
+				return false
+			}
+			dps.RemoveIf(func(dp NumberDataPoint) bool {
+				return f(res, scope, m, dp)
+			})
+			return dps.Len() == 0
+		case MetricDataTypeHistogram:
+			dps := m.Histogram().DataPoints()
+			if dps.Len() == 0 {
+				return false
+			}
+			dps.RemoveIf(func(dp HistogramDataPoint) bool {
+				return f(res, scope, m, dp)
+			})
+			return dps.Len() == 0
+		case MetricDataTypeExponentialHistogram:
+			dps := m.ExponentialHistogram().DataPoints()
+			if dps.Len() == 0 {
+				return false
+			}
+			dps.RemoveIf(func(dp ExponentialHistogramDataPoint) bool {
+				return f(res, scope, m, dp)
+			})
+			return dps.Len() == 0
+		case MetricDataTypeSummary:
+			dps := m.Summary().DataPoints()
+			if dps.Len() == 0 {
+				return false
+			}
+			dps.RemoveIf(func(dp SummaryDataPoint) bool {
+				return f(res, scope, m, dp)
+			})
+			return dps.Len() == 0
+		}
+		return false
+	})
+}
@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracesForEachSpan(t *testing.T) {
+	td := NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "svc")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("scope")
+	ss.Spans().AppendEmpty().SetName("span1")
+	ss.Spans().AppendEmpty().SetName("span2")
+	rs.ScopeSpans().AppendEmpty()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span3")
+
+	var names []string
+	td.ForEachSpan(func(res Resource, scope InstrumentationScope, span Span) {
+		if span.Name() != "span3" {
+			assert.Equal(t, 1, res.Attributes().Len())
+			assert.Equal(t, "scope", scope.Name())
+		}
+		names = append(names, span.Name())
+		span.SetName(span.Name() + "-visited")
+	})
+	assert.Equal(t, []string{"span1", "span2", "span3"}, names)
+	assert.Equal(t, "span2-visited", ss.Spans().At(1).Name())
+}
+
+func TestTracesRemoveSpansIf(t *testing.T) {
+	td := NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Spans().AppendEmpty().SetName("keep")
+	ss.Spans().AppendEmpty().SetName("remove")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("remove")
+	// Already empty elements are kept.
+	rs.ScopeSpans().AppendEmpty()
+	td.ResourceSpans().AppendEmpty()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("remove")
+
+	td.RemoveSpansIf(func(_ Resource, _ InstrumentationScope, span Span) bool {
+		return span.Name() == "remove"
+	})
+	assert.Equal(t, 2, td.ResourceSpans().Len())
+	assert.Equal(t, 2, td.ResourceSpans().At(0).ScopeSpans().Len())
+	assert.Equal(t, 0, td.ResourceSpans().At(0).ScopeSpans().At(1).Spans().Len())
+	assert.Equal(t, 0, td.ResourceSpans().At(1).ScopeSpans().Len())
+	assert.Equal(t, 1, td.SpanCount())
+	assert.Equal(t, "keep", td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func TestLogsForEachLogRecord(t *testing.T) {
+	ld := NewLogs()
+	generateTestResourceLogsSlice().CopyTo(ld.ResourceLogs())
+	count := 0
+	ld.ForEachLogRecord(func(res Resource, _ InstrumentationScope, lr LogRecord) {
+		assert.Equal(t, generateTestResource(), res)
+		lr.SetSeverityText("visited")
+		count++
+	})
+	assert.Equal(t, ld.LogRecordCount(), count)
+	assert.Equal(t, "visited", ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SeverityText())
+}
+
+func TestLogsRemoveLogRecordsIf(t *testing.T) {
+	ld := NewLogs()
+	sl := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	sl.LogRecords().AppendEmpty().SetSeverityText("INFO")
+	sl.LogRecords().AppendEmpty().SetSeverityText("DEBUG")
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().SetSeverityText("DEBUG")
+	ld.ResourceLogs().AppendEmpty()
+
+	ld.RemoveLogRecordsIf(func(_ Resource, _ InstrumentationScope, lr LogRecord) bool {
+		return lr.SeverityText() == "DEBUG"
+	})
+	assert.Equal(t, 2, ld.ResourceLogs().Len())
+	assert.Equal(t, 1, ld.LogRecordCount())
+	assert.Equal(t, "INFO", ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SeverityText())
+}
+
+func generateMetricsAllTypes() Metrics {
+	md := NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	for _, ty := range []MetricDataType{MetricDataTypeGauge, MetricDataTypeSum, MetricDataTypeHistogram, MetricDataTypeExponentialHistogram, MetricDataTypeSummary} {
+		m := ms.AppendEmpty()
+		m.SetName(ty.String())
+		m.SetDataType(ty)
+		for i := 0; i < 2; i++ {
+			var dp DataPoint
+			switch ty {
+			case MetricDataTypeGauge:
+				dp = m.Gauge().DataPoints().AppendEmpty()
+			case MetricDataTypeSum:
+				dp = m.Sum().DataPoints().AppendEmpty()
+			case MetricDataTypeHistogram:
+				dp = m.Histogram().DataPoints().AppendEmpty()
+			case MetricDataTypeExponentialHistogram:
+				dp = m.ExponentialHistogram().DataPoints().AppendEmpty()
+			case MetricDataTypeSummary:
+				dp = m.Summary().DataPoints().AppendEmpty()
+			}
+			dp.SetTimestamp(Timestamp(i))
+		}
+	}
+	ms.AppendEmpty().SetName("None")
+	return md
+}
+
+func TestMetricsForEachDataPoint(t *testing.T) {
+	md := generateMetricsAllTypes()
+	var names []string
+	md.ForEachDataPoint(func(_ Resource, _ InstrumentationScope, m Metric, dp DataPoint) {
+		names = append(names, m.Name())
+		dp.Attributes().InsertBool("visited", true)
+	})
+	assert.Equal(t, []string{"Gauge", "Gauge", "Sum", "Sum", "Histogram", "Histogram",
+		"ExponentialHistogram", "ExponentialHistogram", "Summary", "Summary"}, names)
+	_, ok := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(4).Summary().DataPoints().At(1).Attributes().Get("visited")
+	assert.True(t, ok)
+
+	count := 0
+	md.ForEachMetric(func(_ Resource, _ InstrumentationScope, m Metric) {
+		count++
+	})
+	assert.Equal(t, md.MetricCount(), count)
+}
+
+func TestMetricsRemoveDataPointsIf(t *testing.T) {
+	md := generateMetricsAllTypes()
+	md.RemoveDataPointsIf(func(_ Resource, _ InstrumentationScope, _ Metric, dp DataPoint) bool {
+		return dp.Timestamp() == 0
+	})
+	assert.Equal(t, 6, md.MetricCount())
+	assert.Equal(t, 5, md.DataPointCount())
+
+	// The metrics left without data points are removed, but not the metric without data.
+	md.RemoveDataPointsIf(func(_ Resource, _ InstrumentationScope, _ Metric, dp DataPoint) bool {
+		_, ok := dp.(SummaryDataPoint)
+		return !ok
+	})
+	assert.Equal(t, 2, md.MetricCount())
+	assert.Equal(t, 1, md.DataPointCount())
+
+	md.RemoveMetricsIf(func(_ Resource, _ InstrumentationScope, _ Metric) bool {
+		return true
+	})
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+}
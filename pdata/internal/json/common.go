@@ -0,0 +1,251 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json // import "go.opentelemetry.io/collector/pdata/internal/json"
+
+import (
+	"go.opentelemetry.io/collector/pdata/internal/data"
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+	otlpresource "go.opentelemetry.io/collector/pdata/internal/data/protogen/resource/v1"
+)
+
+// WriteResource writes the resource field, omitted if the resource is empty.
+func WriteResource(s *Stream, r *otlpresource.Resource) {
+	if len(r.Attributes) == 0 && r.DroppedAttributesCount == 0 {
+		return
+	}
+	s.WriteObjectField("resource")
+	s.WriteObjectStart()
+	WriteAttributes(s, "attributes", r.Attributes)
+	s.WriteUint32Field("droppedAttributesCount", r.DroppedAttributesCount)
+	s.WriteObjectEnd()
+}
+
+// ReadResource reads a resource.
+func ReadResource(it *Iterator, r *otlpresource.Resource) {
+	it.ReadObject(func(it *Iterator, field string) {
+		switch field {
+		case "attributes":
+			r.Attributes = ReadAttributes(it, r.Attributes)
+		case "droppedAttributesCount", "dropped_attributes_count":
+			r.DroppedAttributesCount = it.ReadUint32()
+		default:
+			it.Skip()
+		}
+	})
+}
+
+// WriteScope writes the scope field, omitted if the scope is empty.
+func WriteScope(s *Stream, sc *otlpcommon.InstrumentationScope) {
+	if sc.Name == "" && sc.Version == "" {
+		return
+	}
+	s.WriteObjectField("scope")
+	s.WriteObjectStart()
+	s.WriteStringField("name", sc.Name)
+	s.WriteStringField("version", sc.Version)
+	s.WriteObjectEnd()
+}
+
+// ReadScope reads a scope.
+func ReadScope(it *Iterator, sc *otlpcommon.InstrumentationScope) {
+	it.ReadObject(func(it *Iterator, field string) {
+		switch field {
+		case "name":
+			sc.Name = it.ReadString()
+		case "version":
+			sc.Version = it.ReadString()
+		default:
+			it.Skip()
+		}
+	})
+}
+
+// ReadInstrumentationLibrary reads the deprecated instrumentation library.
+func ReadInstrumentationLibrary(it *Iterator, il *otlpcommon.InstrumentationLibrary) {
+	it.ReadObject(func(it *Iterator, field string) {
+		switch field {
+		case "name":
+			il.Name = it.ReadString()
+		case "version":
+			il.Version = it.ReadString()
+		default:
+			it.Skip()
+		}
+	})
+}
+
+// WriteAttributes writes a field holding key values, omitted if there are none.
+func WriteAttributes(s *Stream, name string, kvs []otlpcommon.KeyValue) {
+	if len(kvs) == 0 {
+		return
+	}
+	s.WriteObjectField(name)
+	writeKeyValues(s, kvs)
+}
+
+func writeKeyValues(s *Stream, kvs []otlpcommon.KeyValue) {
+	s.WriteArrayStart()
+	for i := range kvs {
+		s.WriteArrayElement()
+		s.WriteObjectStart()
+		s.WriteStringField("key", kvs[i].Key)
+		if kvs[i].Value.Value != nil {
+			s.WriteObjectField("value")
+			WriteAnyValue(s, &kvs[i].Value)
+		}
+		s.WriteObjectEnd()
+	}
+	s.WriteArrayEnd()
+}
+
+// ReadAttributes reads key values, appended to kvs.
+func ReadAttributes(it *Iterator, kvs []otlpcommon.KeyValue) []otlpcommon.KeyValue {
+	it.ReadArray(func(it *Iterator) {
+		kvs = append(kvs, otlpcommon.KeyValue{})
+		kv := &kvs[len(kvs)-1]
+		it.ReadObject(func(it *Iterator, field string) {
+			switch field {
+			case "key":
+				kv.Key = it.ReadString()
+			case "value":
+				ReadAnyValue(it, &kv.Value)
+			default:
+				it.Skip()
+			}
+		})
+	})
+	return kvs
+}
+
+// WriteAnyValue writes a value. An empty value is written as an empty object.
+func WriteAnyValue(s *Stream, v *otlpcommon.AnyValue) {
+	s.WriteObjectStart()
+	switch val := v.Value.(type) {
+	case *otlpcommon.AnyValue_StringValue:
+		s.WriteObjectField("stringValue")
+		s.WriteString(val.StringValue)
+	case *otlpcommon.AnyValue_BoolValue:
+		s.WriteObjectField("boolValue")
+		s.WriteBool(val.BoolValue)
+	case *otlpcommon.AnyValue_IntValue:
+		s.WriteObjectField("intValue")
+		s.WriteInt64(val.IntValue)
+	case *otlpcommon.AnyValue_DoubleValue:
+		s.WriteObjectField("doubleValue")
+		s.WriteFloat64(val.DoubleValue)
+	case *otlpcommon.AnyValue_BytesValue:
+		s.WriteObjectField("bytesValue")
+		s.WriteBytes(val.BytesValue)
+	case *otlpcommon.AnyValue_ArrayValue:
+		s.WriteObjectField("arrayValue")
+		s.WriteObjectStart()
+		if val.ArrayValue != nil && len(val.ArrayValue.Values) > 0 {
+			s.WriteObjectField("values")
+			s.WriteArrayStart()
+			for i := range val.ArrayValue.Values {
+				s.WriteArrayElement()
+				WriteAnyValue(s, &val.ArrayValue.Values[i])
+			}
+			s.WriteArrayEnd()
+		}
+		s.WriteObjectEnd()
+	case *otlpcommon.AnyValue_KvlistValue:
+		s.WriteObjectField("kvlistValue")
+		s.WriteObjectStart()
+		if val.KvlistValue != nil && len(val.KvlistValue.Values) > 0 {
+			s.WriteObjectField("values")
+			writeKeyValues(s, val.KvlistValue.Values)
+		}
+		s.WriteObjectEnd()
+	}
+	s.WriteObjectEnd()
+}
+
+// ReadAnyValue reads a value.
+func ReadAnyValue(it *Iterator, v *otlpcommon.AnyValue) {
+	it.ReadObject(func(it *Iterator, field string) {
+		switch field {
+		case "stringValue", "string_value":
+			v.Value = &otlpcommon.AnyValue_StringValue{StringValue: it.ReadString()}
+		case "boolValue", "bool_value":
+			v.Value = &otlpcommon.AnyValue_BoolValue{BoolValue: it.ReadBool()}
+		case "intValue", "int_value":
+			v.Value = &otlpcommon.AnyValue_IntValue{IntValue: it.ReadInt64()}
+		case "doubleValue", "double_value":
+			v.Value = &otlpcommon.AnyValue_DoubleValue{DoubleValue: it.ReadFloat64()}
+		case "bytesValue", "bytes_value":
+			v.Value = &otlpcommon.AnyValue_BytesValue{BytesValue: it.ReadBytes()}
+		case "arrayValue", "array_value":
+			av := &otlpcommon.ArrayValue{}
+			it.ReadObject(func(it *Iterator, field string) {
+				if field != "values" {
+					it.Skip()
+					return
+				}
+				it.ReadArray(func(it *Iterator) {
+					av.Values = append(av.Values, otlpcommon.AnyValue{})
+					ReadAnyValue(it, &av.Values[len(av.Values)-1])
+				})
+			})
+			v.Value = &otlpcommon.AnyValue_ArrayValue{ArrayValue: av}
+		case "kvlistValue", "kvlist_value":
+			kvl := &otlpcommon.KeyValueList{}
+			it.ReadObject(func(it *Iterator, field string) {
+				if field != "values" {
+					it.Skip()
+					return
+				}
+				kvl.Values = ReadAttributes(it, kvl.Values)
+			})
+			v.Value = &otlpcommon.AnyValue_KvlistValue{KvlistValue: kvl}
+		default:
+			it.Skip()
+		}
+	})
+}
+
+// WriteTraceID writes a trace ID field, omitted if the ID is empty.
+func WriteTraceID(s *Stream, name string, id data.TraceID) {
+	if id.IsEmpty() {
+		return
+	}
+	b := id.Bytes()
+	s.WriteObjectField(name)
+	s.WriteHex(b[:])
+}
+
+// ReadTraceID reads a trace ID.
+func ReadTraceID(it *Iterator) data.TraceID {
+	var b [16]byte
+	it.ReadHex(b[:])
+	return data.NewTraceID(b)
+}
+
+// WriteSpanID writes a span ID field, omitted if the ID is empty.
+func WriteSpanID(s *Stream, name string, id data.SpanID) {
+	if id.IsEmpty() {
+		return
+	}
+	b := id.Bytes()
+	s.WriteObjectField(name)
+	s.WriteHex(b[:])
+}
+
+// ReadSpanID reads a span ID.
+func ReadSpanID(it *Iterator) data.SpanID {
+	var b [8]byte
+	it.ReadHex(b[:])
+	return data.NewSpanID(b)
+}
@@ -0,0 +1,21 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package json implements the OTLP/JSON encoding of the pdata structs without reflection.
+//
+// The encoding follows https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/protocol/otlp.md#json-protobuf-encoding:
+// field names are lowerCamelCase, trace and span IDs are hex strings, enums are integers and 64 bits
+// integers are decimal strings. The decoding also accepts the original snake_case field names,
+// enum names and 64 bits integers as numbers.
+package json // import "go.opentelemetry.io/collector/pdata/internal/json"
@@ -0,0 +1,451 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json // import "go.opentelemetry.io/collector/pdata/internal/json"
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Iterator reads JSON from a buffer. The first error stops the reading: the following reads
+// return zero values and the error is returned by Error.
+type Iterator struct {
+	buf []byte
+	pos int
+	err error
+}
+
+// NewIterator returns an Iterator reading buf.
+func NewIterator(buf []byte) *Iterator {
+	return &Iterator{buf: buf}
+}
+
+// Error returns the first error encountered while reading, nil if none.
+func (it *Iterator) Error() error {
+	return it.err
+}
+
+// ReportError stops the reading with an error about the value being read.
+func (it *Iterator) ReportError(op, msg string) {
+	if it.err == nil {
+		it.err = fmt.Errorf("%s: %s, at offset %d", op, msg, it.pos)
+	}
+}
+
+// ReadEOF checks that nothing but whitespaces is left to read.
+func (it *Iterator) ReadEOF() {
+	if it.peek() != 0 {
+		it.ReportError("ReadEOF", "unexpected data after the top level value")
+	}
+}
+
+// peek skips the whitespaces and returns the next byte without consuming it,
+// 0 at the end of the buffer or after an error.
+func (it *Iterator) peek() byte {
+	if it.err != nil {
+		return 0
+	}
+	for ; it.pos < len(it.buf); it.pos++ {
+		switch c := it.buf[it.pos]; c {
+		case ' ', '\t', '\n', '\r':
+		default:
+			return c
+		}
+	}
+	return 0
+}
+
+// consume skips the whitespaces and consumes the next byte if it is c.
+func (it *Iterator) consume(c byte) bool {
+	if it.peek() == c {
+		it.pos++
+		return true
+	}
+	return false
+}
+
+// readNull consumes null if it is the next value.
+func (it *Iterator) readNull() bool {
+	if it.peek() != 'n' {
+		return false
+	}
+	it.readLiteral("null")
+	return true
+}
+
+func (it *Iterator) readLiteral(lit string) {
+	if len(it.buf)-it.pos < len(lit) || string(it.buf[it.pos:it.pos+len(lit)]) != lit {
+		it.ReportError("readLiteral", "expected "+lit)
+		return
+	}
+	it.pos += len(lit)
+}
+
+// ReadObject reads an object, calling fn for each field. fn must read the value of the field,
+// or skip it with Skip. A null object has no fields.
+func (it *Iterator) ReadObject(fn func(it *Iterator, field string)) {
+	if it.readNull() {
+		return
+	}
+	if !it.consume('{') {
+		it.ReportError("ReadObject", "expected {")
+		return
+	}
+	if it.consume('}') {
+		return
+	}
+	for it.err == nil {
+		if it.peek() != '"' {
+			it.ReportError("ReadObject", "expected field name")
+			return
+		}
+		field := it.ReadString()
+		if !it.consume(':') {
+			it.ReportError("ReadObject", "expected :")
+			return
+		}
+		fn(it, field)
+		if it.consume(',') {
+			continue
+		}
+		if !it.consume('}') {
+			it.ReportError("ReadObject", "expected , or }")
+		}
+		return
+	}
+}
+
+// ReadArray reads an array, calling fn for each element. fn must read the element.
+// A null array has no elements.
+func (it *Iterator) ReadArray(fn func(it *Iterator)) {
+	if it.readNull() {
+		return
+	}
+	if !it.consume('[') {
+		it.ReportError("ReadArray", "expected [")
+		return
+	}
+	if it.consume(']') {
+		return
+	}
+	for it.err == nil {
+		fn(it)
+		if it.consume(',') {
+			continue
+		}
+		if !it.consume(']') {
+			it.ReportError("ReadArray", "expected , or ]")
+		}
+		return
+	}
+}
+
+// Skip reads and ignores the next value.
+func (it *Iterator) Skip() {
+	switch c := it.peek(); {
+	case c == '{':
+		it.ReadObject(func(it *Iterator, _ string) { it.Skip() })
+	case c == '[':
+		it.ReadArray(func(it *Iterator) { it.Skip() })
+	case c == '"':
+		it.readStringBytes()
+	case c == 't':
+		it.readLiteral("true")
+	case c == 'f':
+		it.readLiteral("false")
+	case c == 'n':
+		it.readLiteral("null")
+	case c == '-' || (c >= '0' && c <= '9'):
+		it.readNumber()
+	default:
+		it.ReportError("Skip", "expected a value")
+	}
+}
+
+// ReadString reads a string. null is read as an empty string.
+func (it *Iterator) ReadString() string {
+	if it.readNull() {
+		return ""
+	}
+	return string(it.readStringBytes())
+}
+
+// readStringBytes reads a string, the returned bytes are only valid until the next read.
+func (it *Iterator) readStringBytes() []byte {
+	if !it.consume('"') {
+		it.ReportError("ReadString", "expected \"")
+		return nil
+	}
+	start := it.pos
+	for i := start; i < len(it.buf); i++ {
+		switch c := it.buf[i]; {
+		case c == '"':
+			it.pos = i + 1
+			return it.buf[start:i]
+		case c == '\\':
+			it.pos = i
+			return it.readEscapedString(it.buf[start:i:i])
+		case c < 0x20:
+			it.pos = i
+			it.ReportError("ReadString", "invalid control character in string")
+			return nil
+		}
+	}
+	it.pos = len(it.buf)
+	it.ReportError("ReadString", "unterminated string")
+	return nil
+}
+
+// readEscapedString reads the rest of a string containing escape sequences, appended to str.
+func (it *Iterator) readEscapedString(str []byte) []byte {
+	for it.pos < len(it.buf) {
+		c := it.buf[it.pos]
+		switch {
+		case c == '"':
+			it.pos++
+			return str
+		case c < 0x20:
+			it.ReportError("ReadString", "invalid control character in string")
+			return nil
+		case c != '\\':
+			str = append(str, c)
+			it.pos++
+			continue
+		}
+		if it.pos+1 >= len(it.buf) {
+			break
+		}
+		it.pos += 2
+		switch esc := it.buf[it.pos-1]; esc {
+		case '"', '\\', '/':
+			str = append(str, esc)
+		case 'b':
+			str = append(str, '\b')
+		case 'f':
+			str = append(str, '\f')
+		case 'n':
+			str = append(str, '\n')
+		case 'r':
+			str = append(str, '\r')
+		case 't':
+			str = append(str, '\t')
+		case 'u':
+			r := it.readRune()
+			if utf16.IsSurrogate(r) {
+				r2 := rune(-1)
+				if it.pos+1 < len(it.buf) && it.buf[it.pos] == '\\' && it.buf[it.pos+1] == 'u' {
+					it.pos += 2
+					r2 = it.readRune()
+				}
+				r = utf16.DecodeRune(r, r2)
+			}
+			var b [utf8.UTFMax]byte
+			str = append(str, b[:utf8.EncodeRune(b[:], r)]...)
+		default:
+			it.ReportError("ReadString", "invalid escape sequence")
+			return nil
+		}
+	}
+	it.ReportError("ReadString", "unterminated string")
+	return nil
+}
+
+// readRune reads the 4 hex digits of a \u escape sequence.
+func (it *Iterator) readRune() rune {
+	if len(it.buf)-it.pos < 4 {
+		it.ReportError("ReadString", "invalid unicode escape sequence")
+		return utf8.RuneError
+	}
+	r, err := strconv.ParseUint(string(it.buf[it.pos:it.pos+4]), 16, 16)
+	if err != nil {
+		it.ReportError("ReadString", "invalid unicode escape sequence")
+		return utf8.RuneError
+	}
+	it.pos += 4
+	return rune(r)
+}
+
+// ReadBool reads a boolean. null is read as false.
+func (it *Iterator) ReadBool() bool {
+	switch it.peek() {
+	case 't':
+		it.readLiteral("true")
+		return true
+	case 'f':
+		it.readLiteral("false")
+	case 'n':
+		it.readLiteral("null")
+	default:
+		it.ReportError("ReadBool", "expected true or false")
+	}
+	return false
+}
+
+// readNumber reads a number, or the content of a string holding a number.
+// It returns nil for null.
+func (it *Iterator) readNumber() []byte {
+	switch c := it.peek(); {
+	case c == '"':
+		return it.readStringBytes()
+	case c == 'n':
+		it.readLiteral("null")
+		return nil
+	case c == '-' || (c >= '0' && c <= '9'):
+		start := it.pos
+		for ; it.pos < len(it.buf); it.pos++ {
+			switch c := it.buf[it.pos]; {
+			case c >= '0' && c <= '9', c == '-', c == '+', c == '.', c == 'e', c == 'E':
+			default:
+				return it.buf[start:it.pos]
+			}
+		}
+		return it.buf[start:]
+	default:
+		it.ReportError("readNumber", "expected a number")
+		return nil
+	}
+}
+
+// ReadInt32 reads a 32 bits integer, from a number or a string.
+func (it *Iterator) ReadInt32() int32 {
+	return int32(it.readInt("ReadInt32", 32))
+}
+
+// ReadInt64 reads a 64 bits integer, from a number or a string.
+func (it *Iterator) ReadInt64() int64 {
+	return it.readInt("ReadInt64", 64)
+}
+
+func (it *Iterator) readInt(op string, bitSize int) int64 {
+	num := it.readNumber()
+	if len(num) == 0 {
+		return 0
+	}
+	v, err := strconv.ParseInt(string(num), 10, bitSize)
+	if err != nil {
+		it.ReportError(op, "invalid integer "+strconv.Quote(string(num)))
+		return 0
+	}
+	return v
+}
+
+// ReadUint32 reads a 32 bits unsigned integer, from a number or a string.
+func (it *Iterator) ReadUint32() uint32 {
+	return uint32(it.readUint("ReadUint32", 32))
+}
+
+// ReadUint64 reads a 64 bits unsigned integer, from a number or a string.
+func (it *Iterator) ReadUint64() uint64 {
+	return it.readUint("ReadUint64", 64)
+}
+
+func (it *Iterator) readUint(op string, bitSize int) uint64 {
+	num := it.readNumber()
+	if len(num) == 0 {
+		return 0
+	}
+	v, err := strconv.ParseUint(string(num), 10, bitSize)
+	if err != nil {
+		it.ReportError(op, "invalid unsigned integer "+strconv.Quote(string(num)))
+		return 0
+	}
+	return v
+}
+
+// ReadFloat64 reads a float, from a number or a string, including "NaN", "Infinity" and "-Infinity".
+func (it *Iterator) ReadFloat64() float64 {
+	num := it.readNumber()
+	switch string(num) {
+	case "":
+		return 0
+	case "NaN":
+		return math.NaN()
+	case "Infinity":
+		return math.Inf(1)
+	case "-Infinity":
+		return math.Inf(-1)
+	}
+	v, err := strconv.ParseFloat(string(num), 64)
+	if err != nil {
+		it.ReportError("ReadFloat64", "invalid number "+strconv.Quote(string(num)))
+		return 0
+	}
+	return v
+}
+
+// ReadEnum reads an enum, from its number or its name in names.
+func (it *Iterator) ReadEnum(names map[string]int32) int32 {
+	if it.peek() != '"' {
+		return it.ReadInt32()
+	}
+	name := it.readStringBytes()
+	if v, ok := names[string(name)]; ok {
+		return v
+	}
+	it.ReportError("ReadEnum", "unknown value "+strconv.Quote(string(name)))
+	return 0
+}
+
+// ReadBytes reads bytes from a base64 string, standard or URL encoded, with or without padding.
+func (it *Iterator) ReadBytes() []byte {
+	if it.readNull() {
+		return nil
+	}
+	str := it.readStringBytes()
+	if len(str) == 0 {
+		return nil
+	}
+	enc := base64.StdEncoding
+	for _, c := range str {
+		if c == '-' || c == '_' {
+			enc = base64.URLEncoding
+			break
+		}
+	}
+	if len(str)%4 != 0 {
+		enc = enc.WithPadding(base64.NoPadding)
+	}
+	v := make([]byte, enc.DecodedLen(len(str)))
+	n, err := enc.Decode(v, str)
+	if err != nil {
+		it.ReportError("ReadBytes", "invalid base64 string")
+		return nil
+	}
+	return v[:n]
+}
+
+// ReadHex reads dst from a hex string, used for the trace and span IDs.
+// An empty string leaves dst unchanged.
+func (it *Iterator) ReadHex(dst []byte) {
+	if it.readNull() {
+		return
+	}
+	str := it.readStringBytes()
+	if len(str) == 0 {
+		return
+	}
+	if hex.DecodedLen(len(str)) != len(dst) {
+		it.ReportError("ReadHex", fmt.Sprintf("expected %d hex characters", hex.EncodedLen(len(dst))))
+		return
+	}
+	if _, err := hex.Decode(dst, str); err != nil {
+		it.ReportError("ReadHex", "invalid hex string")
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIterator_ReadObject(t *testing.T) {
+	it := NewIterator([]byte(` { "a" : 1 , "b":{"c":[true,null,"d",{},[1.5e3]]}, "e":null, "f" : "g" } `))
+	var fields []string
+	it.ReadObject(func(it *Iterator, field string) {
+		fields = append(fields, field)
+		if field == "a" {
+			assert.EqualValues(t, 1, it.ReadInt32())
+			return
+		}
+		it.Skip()
+	})
+	it.ReadEOF()
+	require.NoError(t, it.Error())
+	assert.Equal(t, []string{"a", "b", "e", "f"}, fields)
+}
+
+func TestIterator_ReadArray(t *testing.T) {
+	it := NewIterator([]byte(`[1, "2", null, 3]`))
+	var values []uint64
+	it.ReadArray(func(it *Iterator) {
+		values = append(values, it.ReadUint64())
+	})
+	require.NoError(t, it.Error())
+	assert.Equal(t, []uint64{1, 2, 0, 3}, values)
+
+	it = NewIterator([]byte(`null`))
+	it.ReadArray(func(it *Iterator) { t.Fail() })
+	require.NoError(t, it.Error())
+}
+
+func TestIterator_ReadString(t *testing.T) {
+	tests := map[string]string{
+		`""`:                      "",
+		`null`:                    "",
+		`"plain"`:                 "plain",
+		`"\"\\\/\b\f\n\r\t"`:      "\"\\/\b\f\n\r\t",
+		`"\u00e9\ud83d\ude00 é😀"`: "é😀 é😀",
+		`"\ud83d"`:                "�",
+	}
+	for jsonBuf, want := range tests {
+		it := NewIterator([]byte(jsonBuf))
+		assert.Equal(t, want, it.ReadString(), jsonBuf)
+		assert.NoError(t, it.Error(), jsonBuf)
+	}
+}
+
+func TestIterator_ReadNumbers(t *testing.T) {
+	it := NewIterator([]byte(`-2147483648 "4294967295" "-9223372036854775808" 18446744073709551615 1.5 "NaN" "-Infinity" "1e3"`))
+	assert.EqualValues(t, math.MinInt32, it.ReadInt32())
+	assert.EqualValues(t, uint32(math.MaxUint32), it.ReadUint32())
+	assert.EqualValues(t, int64(math.MinInt64), it.ReadInt64())
+	assert.EqualValues(t, uint64(math.MaxUint64), it.ReadUint64())
+	assert.EqualValues(t, 1.5, it.ReadFloat64())
+	assert.True(t, math.IsNaN(it.ReadFloat64()))
+	assert.EqualValues(t, math.Inf(-1), it.ReadFloat64())
+	assert.EqualValues(t, 1000, it.ReadFloat64())
+	it.ReadEOF()
+	assert.NoError(t, it.Error())
+}
+
+func TestIterator_ReadBytes(t *testing.T) {
+	for _, jsonBuf := range []string{`"-_8="`, `"+/8="`, `"+/8"`} {
+		it := NewIterator([]byte(jsonBuf))
+		assert.Equal(t, []byte{0xfb, 0xff}, it.ReadBytes(), jsonBuf)
+		assert.NoError(t, it.Error(), jsonBuf)
+	}
+}
+
+func TestIterator_ReadEnum(t *testing.T) {
+	names := map[string]int32{"ONE": 1}
+	it := NewIterator([]byte(`"ONE" 2`))
+	assert.EqualValues(t, 1, it.ReadEnum(names))
+	assert.EqualValues(t, 2, it.ReadEnum(names))
+	assert.NoError(t, it.Error())
+}
+
+func TestIterator_Errors(t *testing.T) {
+	tests := map[string]func(it *Iterator){
+		`{"a" 1}`:    func(it *Iterator) { it.Skip() },
+		`{"a":1,}`:   func(it *Iterator) { it.Skip() },
+		`{1:1}`:      func(it *Iterator) { it.Skip() },
+		`[1 2]`:      func(it *Iterator) { it.Skip() },
+		`tru`:        func(it *Iterator) { it.Skip() },
+		`}`:          func(it *Iterator) { it.Skip() },
+		`"a`:         func(it *Iterator) { it.ReadString() },
+		`"a\q"`:      func(it *Iterator) { it.ReadString() },
+		`"\u12"`:     func(it *Iterator) { it.ReadString() },
+		"\"\n\"":     func(it *Iterator) { it.ReadString() },
+		`1`:          func(it *Iterator) { it.ReadString() },
+		`"1"`:        func(it *Iterator) { it.ReadBool() },
+		`2147483648`: func(it *Iterator) { it.ReadInt32() },
+		`-1`:         func(it *Iterator) { it.ReadUint64() },
+		`1.0`:        func(it *Iterator) { it.ReadInt64() },
+		`"x"`:        func(it *Iterator) { it.ReadFloat64() },
+		`"TWO"`:      func(it *Iterator) { it.ReadEnum(map[string]int32{}) },
+		`"***"`:      func(it *Iterator) { it.ReadBytes() },
+		`"0102"`:     func(it *Iterator) { it.ReadHex(make([]byte, 8)) },
+		`"zz"`:       func(it *Iterator) { it.ReadHex(make([]byte, 1)) },
+		`1 2`:        func(it *Iterator) { it.ReadInt32(); it.ReadEOF() },
+	}
+	for jsonBuf, read := range tests {
+		it := NewIterator([]byte(jsonBuf))
+		read(it)
+		assert.Error(t, it.Error(), jsonBuf)
+	}
+}
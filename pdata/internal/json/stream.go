@@ -0,0 +1,257 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json // import "go.opentelemetry.io/collector/pdata/internal/json"
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"math"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Stream writes JSON to a buffer. Fields and array elements are separated automatically.
+type Stream struct {
+	buf []byte
+	// more records, for each object or array being written, whether a separator is needed
+	// before the next field or element.
+	more []bool
+}
+
+// NewStream returns a Stream writing to a buffer of the given initial capacity.
+func NewStream(capacity int) *Stream {
+	return &Stream{buf: make([]byte, 0, capacity)}
+}
+
+// Buffer returns the written JSON.
+func (s *Stream) Buffer() []byte {
+	return s.buf
+}
+
+// WriteObjectStart starts an object.
+func (s *Stream) WriteObjectStart() {
+	s.buf = append(s.buf, '{')
+	s.more = append(s.more, false)
+}
+
+// WriteObjectEnd ends the current object.
+func (s *Stream) WriteObjectEnd() {
+	s.buf = append(s.buf, '}')
+	s.more = s.more[:len(s.more)-1]
+}
+
+// WriteArrayStart starts an array.
+func (s *Stream) WriteArrayStart() {
+	s.buf = append(s.buf, '[')
+	s.more = append(s.more, false)
+}
+
+// WriteArrayEnd ends the current array.
+func (s *Stream) WriteArrayEnd() {
+	s.buf = append(s.buf, ']')
+	s.more = s.more[:len(s.more)-1]
+}
+
+// WriteObjectField writes the name of the next field of the current object.
+// The name is written as is, it must not need escaping.
+func (s *Stream) WriteObjectField(name string) {
+	s.separate()
+	s.buf = append(s.buf, '"')
+	s.buf = append(s.buf, name...)
+	s.buf = append(s.buf, '"', ':')
+}
+
+// WriteArrayElement prepares the writing of the next element of the current array.
+func (s *Stream) WriteArrayElement() {
+	s.separate()
+}
+
+func (s *Stream) separate() {
+	last := len(s.more) - 1
+	if s.more[last] {
+		s.buf = append(s.buf, ',')
+	}
+	s.more[last] = true
+}
+
+// WriteString writes a string, escaped as needed.
+func (s *Stream) WriteString(str string) {
+	s.buf = append(s.buf, '"')
+	start := 0
+	for i := 0; i < len(str); {
+		c := str[i]
+		if c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' {
+				i++
+				continue
+			}
+			s.buf = append(s.buf, str[start:i]...)
+			switch c {
+			case '"', '\\':
+				s.buf = append(s.buf, '\\', c)
+			case '\n':
+				s.buf = append(s.buf, '\\', 'n')
+			case '\r':
+				s.buf = append(s.buf, '\\', 'r')
+			case '\t':
+				s.buf = append(s.buf, '\\', 't')
+			default:
+				s.buf = append(s.buf, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(str[i:])
+		if r == utf8.RuneError && size == 1 {
+			s.buf = append(s.buf, str[start:i]...)
+			s.buf = append(s.buf, `\ufffd`...)
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	s.buf = append(s.buf, str[start:]...)
+	s.buf = append(s.buf, '"')
+}
+
+const hexDigits = "0123456789abcdef"
+
+// WriteBool writes a boolean.
+func (s *Stream) WriteBool(v bool) {
+	s.buf = strconv.AppendBool(s.buf, v)
+}
+
+// WriteInt32 writes a 32 bits integer as a number.
+func (s *Stream) WriteInt32(v int32) {
+	s.buf = strconv.AppendInt(s.buf, int64(v), 10)
+}
+
+// WriteUint32 writes a 32 bits unsigned integer as a number.
+func (s *Stream) WriteUint32(v uint32) {
+	s.buf = strconv.AppendUint(s.buf, uint64(v), 10)
+}
+
+// WriteInt64 writes a 64 bits integer as a decimal string.
+func (s *Stream) WriteInt64(v int64) {
+	s.buf = append(s.buf, '"')
+	s.buf = strconv.AppendInt(s.buf, v, 10)
+	s.buf = append(s.buf, '"')
+}
+
+// WriteUint64 writes a 64 bits unsigned integer as a decimal string.
+func (s *Stream) WriteUint64(v uint64) {
+	s.buf = append(s.buf, '"')
+	s.buf = strconv.AppendUint(s.buf, v, 10)
+	s.buf = append(s.buf, '"')
+}
+
+// WriteFloat64 writes a float as a number, or as "NaN", "Infinity" or "-Infinity".
+func (s *Stream) WriteFloat64(v float64) {
+	switch {
+	case math.IsNaN(v):
+		s.buf = append(s.buf, `"NaN"`...)
+	case math.IsInf(v, 1):
+		s.buf = append(s.buf, `"Infinity"`...)
+	case math.IsInf(v, -1):
+		s.buf = append(s.buf, `"-Infinity"`...)
+	default:
+		// Same format as encoding/json.
+		abs := math.Abs(v)
+		format := byte('f')
+		if abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+			format = 'e'
+		}
+		s.buf = strconv.AppendFloat(s.buf, v, format, -1, 64)
+		if format == 'e' {
+			// Clean up e-09 to e-9.
+			n := len(s.buf)
+			if n >= 4 && s.buf[n-4] == 'e' && s.buf[n-3] == '-' && s.buf[n-2] == '0' {
+				s.buf[n-2] = s.buf[n-1]
+				s.buf = s.buf[:n-1]
+			}
+		}
+	}
+}
+
+// WriteBytes writes bytes as a base64 string.
+func (s *Stream) WriteBytes(v []byte) {
+	s.buf = append(s.buf, '"')
+	n := len(s.buf)
+	s.buf = append(s.buf, make([]byte, base64.StdEncoding.EncodedLen(len(v)))...)
+	base64.StdEncoding.Encode(s.buf[n:], v)
+	s.buf = append(s.buf, '"')
+}
+
+// WriteHex writes bytes as a hex string, used for the trace and span IDs.
+func (s *Stream) WriteHex(v []byte) {
+	s.buf = append(s.buf, '"')
+	n := len(s.buf)
+	s.buf = append(s.buf, make([]byte, hex.EncodedLen(len(v)))...)
+	hex.Encode(s.buf[n:], v)
+	s.buf = append(s.buf, '"')
+}
+
+// The following methods write a field of the current object, omitted if the value is the
+// default one, as OTLP/JSON does not require it.
+
+// WriteStringField writes a string field, omitted if empty.
+func (s *Stream) WriteStringField(name, v string) {
+	if v != "" {
+		s.WriteObjectField(name)
+		s.WriteString(v)
+	}
+}
+
+// WriteBoolField writes a boolean field, omitted if false.
+func (s *Stream) WriteBoolField(name string, v bool) {
+	if v {
+		s.WriteObjectField(name)
+		s.WriteBool(v)
+	}
+}
+
+// WriteInt32Field writes a 32 bits integer field, omitted if zero.
+func (s *Stream) WriteInt32Field(name string, v int32) {
+	if v != 0 {
+		s.WriteObjectField(name)
+		s.WriteInt32(v)
+	}
+}
+
+// WriteUint32Field writes a 32 bits unsigned integer field, omitted if zero.
+func (s *Stream) WriteUint32Field(name string, v uint32) {
+	if v != 0 {
+		s.WriteObjectField(name)
+		s.WriteUint32(v)
+	}
+}
+
+// WriteUint64Field writes a 64 bits unsigned integer field, omitted if zero.
+func (s *Stream) WriteUint64Field(name string, v uint64) {
+	if v != 0 {
+		s.WriteObjectField(name)
+		s.WriteUint64(v)
+	}
+}
+
+// WriteFloat64Field writes a float field, omitted if zero.
+func (s *Stream) WriteFloat64Field(name string, v float64) {
+	if v != 0 {
+		s.WriteObjectField(name)
+		s.WriteFloat64(v)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream(t *testing.T) {
+	s := NewStream(0)
+	s.WriteObjectStart()
+	s.WriteObjectField("array")
+	s.WriteArrayStart()
+	s.WriteArrayElement()
+	s.WriteInt32(-1)
+	s.WriteArrayElement()
+	s.WriteObjectStart()
+	s.WriteObjectEnd()
+	s.WriteArrayElement()
+	s.WriteArrayStart()
+	s.WriteArrayEnd()
+	s.WriteArrayEnd()
+	s.WriteStringField("empty", "")
+	s.WriteUint32Field("zero", 0)
+	s.WriteBoolField("bool", true)
+	s.WriteObjectField("int64")
+	s.WriteInt64(math.MinInt64)
+	s.WriteUint64Field("uint64", math.MaxUint64)
+	s.WriteObjectField("bytes")
+	s.WriteBytes([]byte("bytes"))
+	s.WriteObjectField("hex")
+	s.WriteHex([]byte{0xab, 0x01})
+	s.WriteObjectEnd()
+	assert.Equal(t, `{"array":[-1,{},[]],"bool":true,"int64":"-9223372036854775808","uint64":"18446744073709551615","bytes":"Ynl0ZXM=","hex":"ab01"}`, string(s.Buffer()))
+}
+
+func TestStream_WriteString(t *testing.T) {
+	tests := map[string]string{
+		"":                   `""`,
+		"plain":              `"plain"`,
+		"\"\\\n\r\t":         `"\"\\\n\r\t"`,
+		"\x00\x1f":           `"\u0000\u001f"`,
+		"é😀":                 `"é😀"`,
+		"invalid \xff utf-8": `"invalid \ufffd utf-8"`,
+	}
+	for str, want := range tests {
+		s := NewStream(0)
+		s.WriteString(str)
+		assert.Equal(t, want, string(s.Buffer()))
+	}
+}
+
+func TestStream_WriteFloat64(t *testing.T) {
+	tests := map[float64]string{
+		0:            `0`,
+		-1.5:         `-1.5`,
+		1e20:         `100000000000000000000`,
+		1e21:         `1e+21`,
+		1e-7:         `1e-7`,
+		1.5e-300:     `1.5e-300`,
+		math.Inf(1):  `"Infinity"`,
+		math.Inf(-1): `"-Infinity"`,
+		math.NaN():   `"NaN"`,
+	}
+	for v, want := range tests {
+		s := NewStream(0)
+		s.WriteFloat64(v)
+		assert.Equal(t, want, string(s.Buffer()))
+	}
+}
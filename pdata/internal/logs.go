@@ -17,59 +17,160 @@ package internal // import "go.opentelemetry.io/collector/pdata/internal"
 import (
 	otlpcollectorlog "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
 	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	"go.opentelemetry.io/collector/pdata/internal/otlpwire"
 )
 
 // LogsToOtlp internal helper to convert Logs to otlp request representation.
+// The resources shared with other Logs are copied first, the returned request owns all its content.
 func LogsToOtlp(mw Logs) *otlpcollectorlog.ExportLogsServiceRequest {
-	return mw.orig
+	orig := mw.getOrig()
+	mw.shared.unshareAll(orig.ResourceLogs)
+	return orig
 }
 
 // LogsFromOtlp internal helper to convert otlp request representation to Logs.
 func LogsFromOtlp(orig *otlpcollectorlog.ExportLogsServiceRequest) Logs {
-	return Logs{orig: orig}
+	return Logs{orig: orig, shared: &SharedResourceLogs{}}
+}
+
+// LogsToEncodedOtlp internal helper to get the otlp request representation of Logs,
+// its encoded bytes, if not decoded yet, and its shared resources, without decoding or copying them.
+func LogsToEncodedOtlp(mw Logs) (*otlpcollectorlog.ExportLogsServiceRequest, *EncodedOrig, *SharedResourceLogs) {
+	return mw.orig, mw.encoded, mw.shared
+}
+
+// LogsFromEncodedOtlp internal helper to convert an otlp request representation,
+// whose content may still be held encoded by encoded and whose resources may be shared, to Logs.
+func LogsFromEncodedOtlp(orig *otlpcollectorlog.ExportLogsServiceRequest, encoded *EncodedOrig, shared *SharedResourceLogs) Logs {
+	if !encoded.isPending() {
+		return Logs{orig: orig, shared: shared}
+	}
+	return Logs{orig: orig, encoded: encoded, shared: shared}
 }
 
 // LogsToProto internal helper to convert Logs to protobuf representation.
 func LogsToProto(l Logs) otlplogs.LogsData {
 	return otlplogs.LogsData{
-		ResourceLogs: l.orig.ResourceLogs,
+		ResourceLogs: l.getOrig().ResourceLogs,
 	}
 }
 
 // LogsFromProto internal helper to convert protobuf representation to Logs.
 func LogsFromProto(orig otlplogs.LogsData) Logs {
-	return Logs{orig: &otlpcollectorlog.ExportLogsServiceRequest{
-		ResourceLogs: orig.ResourceLogs,
-	}}
+	return Logs{
+		orig:   &otlpcollectorlog.ExportLogsServiceRequest{ResourceLogs: orig.ResourceLogs},
+		shared: &SharedResourceLogs{},
+	}
 }
 
 // Logs is the top-level struct that is propagated through the logs pipeline.
 // Use NewLogs to create new instance, zero-initialized instance is not valid for use.
 type Logs struct {
 	orig *otlpcollectorlog.ExportLogsServiceRequest
+	// encoded, if not nil, may hold the encoded orig until it is accessed.
+	encoded *EncodedOrig
+	// shared, if not nil, tracks the resources shared with other Logs.
+	shared *SharedResourceLogs
+}
+
+// getOrig returns orig, decoding it first if needed.
+func (ld Logs) getOrig() *otlpcollectorlog.ExportLogsServiceRequest {
+	if ld.encoded.isPending() {
+		ld.encoded.decode(ld.orig.Unmarshal)
+	}
+	return ld.orig
 }
 
 // NewLogs creates a new Logs struct.
 func NewLogs() Logs {
-	return Logs{orig: &otlpcollectorlog.ExportLogsServiceRequest{}}
+	return Logs{orig: &otlpcollectorlog.ExportLogsServiceRequest{}, shared: &SharedResourceLogs{}}
 }
 
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value.
 func (ld Logs) MoveTo(dest Logs) {
-	*dest.orig = *ld.orig
+	dest.encoded.Discard()
+	orig := ld.getOrig()
+	dest.shared.drop(dest.orig.ResourceLogs)
+	ld.shared.moveTo(dest.shared, orig.ResourceLogs)
+	*dest.orig = *orig
 	*ld.orig = otlpcollectorlog.ExportLogsServiceRequest{}
 }
 
-// Clone returns a copy of Logs.
+// Clone returns a copy of Logs, reusing the memory of a released Logs if any.
 func (ld Logs) Clone() Logs {
-	cloneLd := NewLogs()
+	if encoded, ok := ld.encoded.clone(); ok {
+		return Logs{orig: &otlpcollectorlog.ExportLogsServiceRequest{}, encoded: encoded, shared: &SharedResourceLogs{}}
+	}
+	cloneLd := newLogsFromPool()
 	ld.ResourceLogs().CopyTo(cloneLd.ResourceLogs())
 	return cloneLd
 }
 
+// Share returns a copy of Logs sharing the content of the resources of ld instead of copying it.
+// A shared resource is copied the first time it is modified from the returned Logs, so that
+// it does not affect ld. Reading the returned Logs, including marshaling, counting the items
+// or cloning it, does not copy nor modify anything, so that both Logs can be read concurrently.
+//
+// ld itself is not tracked: it must not be modified while the returned Logs is in use.
+func (ld Logs) Share() Logs {
+	if encoded, ok := ld.encoded.clone(); ok {
+		return Logs{orig: &otlpcollectorlog.ExportLogsServiceRequest{}, encoded: encoded, shared: &SharedResourceLogs{}}
+	}
+	rs, shared := shareResourceLogs(ld.getOrig().ResourceLogs)
+	return Logs{
+		orig:   &otlpcollectorlog.ExportLogsServiceRequest{ResourceLogs: rs},
+		shared: shared,
+	}
+}
+
+// Equal returns true if ld and other hold the same content, in the same order.
+func (ld Logs) Equal(other Logs) bool {
+	return equalOrigs(ld.getOrig(), other.getOrig())
+}
+
+// EqualIgnoringOrder returns true if ld and other hold the same content, regardless of the order
+// of the attributes and of the repeated elements. The order of the values of array attributes matters.
+func (ld Logs) EqualIgnoringOrder(other Logs) bool {
+	a, b := ld.canonicalClone(), other.canonicalClone()
+	defer a.Release()
+	defer b.Release()
+	return equalOrigs(a.orig, b.orig)
+}
+
+// Diff returns the differences between ld and other, in the same order, one per line formatted
+// as "path: value in ld != value in other", or an empty string if they are equal.
+func (ld Logs) Diff(other Logs) string {
+	return diffOrigs(ld.getOrig(), other.getOrig())
+}
+
+// DiffIgnoringOrder is like Diff, but regardless of the order as for EqualIgnoringOrder. The elements
+// are compared after sorting them, so that the paths of the differences may not match their position.
+func (ld Logs) DiffIgnoringOrder(other Logs) string {
+	a, b := ld.canonicalClone(), other.canonicalClone()
+	defer a.Release()
+	defer b.Release()
+	return diffOrigs(a.orig, b.orig)
+}
+
+// canonicalClone returns a decoded copy of ld whose repeated elements are sorted in a canonical order.
+func (ld Logs) canonicalClone() Logs {
+	clone := ld.Clone()
+	canonicalizeOrig(clone.getOrig())
+	return clone
+}
+
+// IsShared returns true if ld holds resources obtained from Share whose content is still shared
+// with other Logs, that are copied the first time they are modified.
+func (ld Logs) IsShared() bool {
+	return ld.shared.len() > 0
+}
+
 // LogRecordCount calculates the total number of log records.
 func (ld Logs) LogRecordCount() int {
+	if buf, ok := ld.encoded.Bytes(); ok {
+		return otlpwire.LogRecordCount(buf)
+	}
 	logCount := 0
 	rss := ld.ResourceLogs()
 	for i := 0; i < rss.Len(); i++ {
@@ -85,7 +186,7 @@ func (ld Logs) LogRecordCount() int {
 
 // ResourceLogs returns the ResourceLogsSlice associated with this Logs.
 func (ld Logs) ResourceLogs() ResourceLogsSlice {
-	return newResourceLogsSlice(&ld.orig.ResourceLogs)
+	return ResourceLogsSlice{orig: &ld.getOrig().ResourceLogs, sharedElements: ld.shared}
 }
 
 // SeverityNumber represents severity number of a log record.
@@ -121,7 +121,7 @@ func TestLogsMoveTo(t *testing.T) {
 	dest := NewLogs()
 	logs.MoveTo(dest)
 	assert.EqualValues(t, NewLogs(), logs)
-	assert.EqualValues(t, generateTestResourceLogsSlice(), dest.ResourceLogs())
+	assert.EqualValues(t, generateTestResourceLogsSlice().orig, dest.ResourceLogs().orig)
 }
 
 func TestLogsClone(t *testing.T) {
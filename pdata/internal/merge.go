@@ -0,0 +1,240 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+import (
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+)
+
+// The merge functions identify the resources, scopes and metrics by the canonical encoding of their
+// header, without their repeated elements, so that the order of their attributes does not matter.
+
+// headerKey returns the canonical encoding of header, that it sorts in place.
+func headerKey(header message) string {
+	canonicalizeOrig(header)
+	return string(marshalMessage(header))
+}
+
+// mergeIndex maps the key of the elements of a slice to the index of the first one.
+type mergeIndex map[string]int
+
+// Merge moves all the spans of src to td, leaving src empty. The spans of a resource and scope
+// equal to one of td, with the same schema URL, are appended to it instead of duplicating it.
+func (td Traces) Merge(src Traces) {
+	dest := td.ResourceSpans()
+	index := make(mergeIndex, len(*dest.getOrig()))
+	for i, rs := range *dest.getOrig() {
+		key := resourceSpansKey(rs)
+		if _, ok := index[key]; !ok {
+			index[key] = i
+		}
+	}
+	src.ResourceSpans().RemoveIf(func(srcRs ResourceSpans) bool {
+		key := resourceSpansKey(srcRs.getOrig())
+		if i, ok := index[key]; ok {
+			mergeScopeSpans(srcRs.ScopeSpans(), dest.At(i).ScopeSpans())
+			return true
+		}
+		index[key] = dest.Len()
+		srcRs.MoveTo(dest.AppendEmpty())
+		return true
+	})
+}
+
+func resourceSpansKey(rs *otlptrace.ResourceSpans) string {
+	header := &otlptrace.ResourceSpans{SchemaUrl: rs.SchemaUrl}
+	newResource(&rs.Resource).CopyTo(newResource(&header.Resource))
+	return headerKey(header)
+}
+
+func mergeScopeSpans(src, dest ScopeSpansSlice) {
+	index := make(mergeIndex, dest.Len())
+	for i := 0; i < dest.Len(); i++ {
+		key := scopeSpansKey(dest.At(i).getOrig())
+		if _, ok := index[key]; !ok {
+			index[key] = i
+		}
+	}
+	src.RemoveIf(func(srcSs ScopeSpans) bool {
+		key := scopeSpansKey(srcSs.getOrig())
+		if i, ok := index[key]; ok {
+			srcSs.Spans().MoveAndAppendTo(dest.At(i).Spans())
+			return true
+		}
+		index[key] = dest.Len()
+		srcSs.MoveTo(dest.AppendEmpty())
+		return true
+	})
+}
+
+func scopeSpansKey(ss *otlptrace.ScopeSpans) string {
+	header := &otlptrace.ScopeSpans{SchemaUrl: ss.SchemaUrl}
+	newInstrumentationScope(&ss.Scope).CopyTo(newInstrumentationScope(&header.Scope))
+	return headerKey(header)
+}
+
+// Merge moves all the log records of src to ld, leaving src empty. The log records of a resource and scope
+// equal to one of ld, with the same schema URL, are appended to it instead of duplicating it.
+func (ld Logs) Merge(src Logs) {
+	dest := ld.ResourceLogs()
+	index := make(mergeIndex, len(*dest.getOrig()))
+	for i, rl := range *dest.getOrig() {
+		key := resourceLogsKey(rl)
+		if _, ok := index[key]; !ok {
+			index[key] = i
+		}
+	}
+	src.ResourceLogs().RemoveIf(func(srcRl ResourceLogs) bool {
+		key := resourceLogsKey(srcRl.getOrig())
+		if i, ok := index[key]; ok {
+			mergeScopeLogs(srcRl.ScopeLogs(), dest.At(i).ScopeLogs())
+			return true
+		}
+		index[key] = dest.Len()
+		srcRl.MoveTo(dest.AppendEmpty())
+		return true
+	})
+}
+
+func resourceLogsKey(rl *otlplogs.ResourceLogs) string {
+	header := &otlplogs.ResourceLogs{SchemaUrl: rl.SchemaUrl}
+	newResource(&rl.Resource).CopyTo(newResource(&header.Resource))
+	return headerKey(header)
+}
+
+func mergeScopeLogs(src, dest ScopeLogsSlice) {
+	index := make(mergeIndex, dest.Len())
+	for i := 0; i < dest.Len(); i++ {
+		key := scopeLogsKey(dest.At(i).getOrig())
+		if _, ok := index[key]; !ok {
+			index[key] = i
+		}
+	}
+	src.RemoveIf(func(srcSl ScopeLogs) bool {
+		key := scopeLogsKey(srcSl.getOrig())
+		if i, ok := index[key]; ok {
+			srcSl.LogRecords().MoveAndAppendTo(dest.At(i).LogRecords())
+			return true
+		}
+		index[key] = dest.Len()
+		srcSl.MoveTo(dest.AppendEmpty())
+		return true
+	})
+}
+
+func scopeLogsKey(sl *otlplogs.ScopeLogs) string {
+	header := &otlplogs.ScopeLogs{SchemaUrl: sl.SchemaUrl}
+	newInstrumentationScope(&sl.Scope).CopyTo(newInstrumentationScope(&header.Scope))
+	return headerKey(header)
+}
+
+// Merge moves all the data points of src to md, leaving src empty. The metrics of a resource and scope
+// equal to one of md, with the same schema URL, are appended to it instead of duplicating it, and
+// the data points of a metric equal to one of md, but for its data points, are appended to it.
+func (md Metrics) Merge(src Metrics) {
+	dest := md.ResourceMetrics()
+	index := make(mergeIndex, len(*dest.getOrig()))
+	for i, rm := range *dest.getOrig() {
+		key := resourceMetricsKey(rm)
+		if _, ok := index[key]; !ok {
+			index[key] = i
+		}
+	}
+	src.ResourceMetrics().RemoveIf(func(srcRm ResourceMetrics) bool {
+		key := resourceMetricsKey(srcRm.getOrig())
+		if i, ok := index[key]; ok {
+			mergeScopeMetrics(srcRm.ScopeMetrics(), dest.At(i).ScopeMetrics())
+			return true
+		}
+		index[key] = dest.Len()
+		srcRm.MoveTo(dest.AppendEmpty())
+		return true
+	})
+}
+
+func resourceMetricsKey(rm *otlpmetrics.ResourceMetrics) string {
+	header := &otlpmetrics.ResourceMetrics{SchemaUrl: rm.SchemaUrl}
+	newResource(&rm.Resource).CopyTo(newResource(&header.Resource))
+	return headerKey(header)
+}
+
+func mergeScopeMetrics(src, dest ScopeMetricsSlice) {
+	index := make(mergeIndex, dest.Len())
+	for i := 0; i < dest.Len(); i++ {
+		key := scopeMetricsKey(dest.At(i).getOrig())
+		if _, ok := index[key]; !ok {
+			index[key] = i
+		}
+	}
+	src.RemoveIf(func(srcSm ScopeMetrics) bool {
+		key := scopeMetricsKey(srcSm.getOrig())
+		if i, ok := index[key]; ok {
+			mergeMetrics(srcSm.Metrics(), dest.At(i).Metrics())
+			return true
+		}
+		index[key] = dest.Len()
+		srcSm.MoveTo(dest.AppendEmpty())
+		return true
+	})
+}
+
+func scopeMetricsKey(sm *otlpmetrics.ScopeMetrics) string {
+	header := &otlpmetrics.ScopeMetrics{SchemaUrl: sm.SchemaUrl}
+	newInstrumentationScope(&sm.Scope).CopyTo(newInstrumentationScope(&header.Scope))
+	return headerKey(header)
+}
+
+func mergeMetrics(src, dest MetricSlice) {
+	index := make(mergeIndex, dest.Len())
+	for i := 0; i < dest.Len(); i++ {
+		key := metricKey(dest.At(i).getOrig())
+		if _, ok := index[key]; !ok {
+			index[key] = i
+		}
+	}
+	src.RemoveIf(func(srcM Metric) bool {
+		if srcM.DataType() == MetricDataTypeNone {
+			srcM.MoveTo(dest.AppendEmpty())
+			return true
+		}
+		key := metricKey(srcM.getOrig())
+		i, ok := index[key]
+		if !ok {
+			index[key] = dest.Len()
+			srcM.MoveTo(dest.AppendEmpty())
+			return true
+		}
+		destM := dest.At(i)
+		switch srcM.DataType() {
+		case MetricDataTypeGauge:
+			srcM.Gauge().DataPoints().MoveAndAppendTo(destM.Gauge().DataPoints())
+		case MetricDataTypeSum:
+			srcM.Sum().DataPoints().MoveAndAppendTo(destM.Sum().DataPoints())
+		case MetricDataTypeHistogram:
+			srcM.Histogram().DataPoints().MoveAndAppendTo(destM.Histogram().DataPoints())
+		case MetricDataTypeExponentialHistogram:
+			srcM.ExponentialHistogram().DataPoints().MoveAndAppendTo(destM.ExponentialHistogram().DataPoints())
+		case MetricDataTypeSummary:
+			srcM.Summary().DataPoints().MoveAndAppendTo(destM.Summary().DataPoints())
+		}
+		return true
+	})
+}
+
+func metricKey(m *otlpmetrics.Metric) string {
+	return headerKey(metricHeader(m))
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracesMerge(t *testing.T) {
+	td := NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("a", "1")
+	rs.Resource().Attributes().InsertString("b", "2")
+	rs.ScopeSpans().AppendEmpty().Scope().SetName("scope")
+	rs.ScopeSpans().At(0).Spans().AppendEmpty().SetName("span1")
+
+	src := NewTraces()
+	// Same resource and scope, with the attributes in another order.
+	rs = src.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("b", "2")
+	rs.Resource().Attributes().InsertString("a", "1")
+	rs.ScopeSpans().AppendEmpty().Scope().SetName("scope")
+	rs.ScopeSpans().At(0).Spans().AppendEmpty().SetName("span2")
+	rs.ScopeSpans().AppendEmpty().Scope().SetName("other")
+	rs.ScopeSpans().At(1).Spans().AppendEmpty().SetName("span3")
+	// Same resource, with another schema URL.
+	rs = src.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl("schema")
+	rs.Resource().Attributes().InsertString("a", "1")
+	rs.Resource().Attributes().InsertString("b", "2")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span4")
+
+	td.Merge(src)
+	assert.Equal(t, 0, src.ResourceSpans().Len())
+	assert.Equal(t, 4, td.SpanCount())
+	assert.Equal(t, 2, td.ResourceSpans().Len())
+	scopes := td.ResourceSpans().At(0).ScopeSpans()
+	assert.Equal(t, 2, scopes.Len())
+	assert.Equal(t, 2, scopes.At(0).Spans().Len())
+	assert.Equal(t, "span1", scopes.At(0).Spans().At(0).Name())
+	assert.Equal(t, "span2", scopes.At(0).Spans().At(1).Name())
+	assert.Equal(t, "other", scopes.At(1).Scope().Name())
+	assert.Equal(t, "schema", td.ResourceSpans().At(1).SchemaUrl())
+
+	// Merging to an empty Traces moves everything.
+	expected := td.Clone()
+	dest := NewTraces()
+	dest.Merge(td)
+	assert.Equal(t, 0, td.ResourceSpans().Len())
+	assert.Empty(t, expected.Diff(dest))
+}
+
+func TestLogsMerge(t *testing.T) {
+	ld := NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("a", "1")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStringVal("log1")
+
+	src := NewLogs()
+	rl = src.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("a", "1")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStringVal("log2")
+	rl = src.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("a", "2")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStringVal("log3")
+
+	ld.Merge(src)
+	assert.Equal(t, 0, src.ResourceLogs().Len())
+	assert.Equal(t, 2, ld.ResourceLogs().Len())
+	records := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	assert.Equal(t, 2, records.Len())
+	assert.Equal(t, "log2", records.At(1).Body().StringVal())
+	assert.Equal(t, 1, ld.ResourceLogs().At(1).ScopeLogs().At(0).LogRecords().Len())
+}
+
+func TestMetricsMerge(t *testing.T) {
+	newSum := func(ms MetricSlice, temporality MetricAggregationTemporality, value int64) {
+		m := ms.AppendEmpty()
+		m.SetName("sum")
+		m.SetDataType(MetricDataTypeSum)
+		m.Sum().SetAggregationTemporality(temporality)
+		m.Sum().DataPoints().AppendEmpty().SetIntVal(value)
+	}
+	md := NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("a", "1")
+	newSum(rm.ScopeMetrics().AppendEmpty().Metrics(), MetricAggregationTemporalityCumulative, 1)
+
+	src := NewMetrics()
+	rm = src.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("a", "1")
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	newSum(ms, MetricAggregationTemporalityCumulative, 2)
+	newSum(ms, MetricAggregationTemporalityDelta, 3)
+	ms.AppendEmpty().SetName("none")
+
+	md.Merge(src)
+	assert.Equal(t, 0, src.ResourceMetrics().Len())
+	assert.Equal(t, 1, md.ResourceMetrics().Len())
+	ms = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	assert.Equal(t, 3, ms.Len())
+	assert.Equal(t, 2, ms.At(0).Sum().DataPoints().Len())
+	assert.Equal(t, int64(2), ms.At(0).Sum().DataPoints().At(1).IntVal())
+	assert.Equal(t, MetricAggregationTemporalityDelta, ms.At(1).Sum().AggregationTemporality())
+	assert.Equal(t, 1, ms.At(1).Sum().DataPoints().Len())
+	assert.Equal(t, "none", ms.At(2).Name())
+}
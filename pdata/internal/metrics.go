@@ -17,46 +17,81 @@ package internal // import "go.opentelemetry.io/collector/pdata/internal"
 import (
 	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
 	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/internal/otlpwire"
 )
 
 // MetricsToOtlp internal helper to convert Metrics to otlp request representation.
+// The resources shared with other Metrics are copied first, the returned request owns all its content.
 func MetricsToOtlp(mw Metrics) *otlpcollectormetrics.ExportMetricsServiceRequest {
-	return mw.orig
+	orig := mw.getOrig()
+	mw.shared.unshareAll(orig.ResourceMetrics)
+	return orig
 }
 
 // MetricsFromOtlp internal helper to convert otlp request representation to Metrics.
 func MetricsFromOtlp(orig *otlpcollectormetrics.ExportMetricsServiceRequest) Metrics {
-	return Metrics{orig: orig}
+	return Metrics{orig: orig, shared: &SharedResourceMetrics{}}
+}
+
+// MetricsToEncodedOtlp internal helper to get the otlp request representation of Metrics,
+// its encoded bytes, if not decoded yet, and its shared resources, without decoding or copying them.
+func MetricsToEncodedOtlp(mw Metrics) (*otlpcollectormetrics.ExportMetricsServiceRequest, *EncodedOrig, *SharedResourceMetrics) {
+	return mw.orig, mw.encoded, mw.shared
+}
+
+// MetricsFromEncodedOtlp internal helper to convert an otlp request representation,
+// whose content may still be held encoded by encoded and whose resources may be shared, to Metrics.
+func MetricsFromEncodedOtlp(orig *otlpcollectormetrics.ExportMetricsServiceRequest, encoded *EncodedOrig, shared *SharedResourceMetrics) Metrics {
+	if !encoded.isPending() {
+		return Metrics{orig: orig, shared: shared}
+	}
+	return Metrics{orig: orig, encoded: encoded, shared: shared}
 }
 
 // MetricsToProto internal helper to convert Metrics to protobuf representation.
 func MetricsToProto(l Metrics) otlpmetrics.MetricsData {
 	return otlpmetrics.MetricsData{
-		ResourceMetrics: l.orig.ResourceMetrics,
+		ResourceMetrics: l.getOrig().ResourceMetrics,
 	}
 }
 
 // MetricsFromProto internal helper to convert protobuf representation to Metrics.
 func MetricsFromProto(orig otlpmetrics.MetricsData) Metrics {
-	return Metrics{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{
-		ResourceMetrics: orig.ResourceMetrics,
-	}}
+	return Metrics{
+		orig:   &otlpcollectormetrics.ExportMetricsServiceRequest{ResourceMetrics: orig.ResourceMetrics},
+		shared: &SharedResourceMetrics{},
+	}
 }
 
 // Metrics is the top-level struct that is propagated through the metrics pipeline.
 // Use NewMetrics to create new instance, zero-initialized instance is not valid for use.
 type Metrics struct {
 	orig *otlpcollectormetrics.ExportMetricsServiceRequest
+	// encoded, if not nil, may hold the encoded orig until it is accessed.
+	encoded *EncodedOrig
+	// shared, if not nil, tracks the resources shared with other Metrics.
+	shared *SharedResourceMetrics
+}
+
+// getOrig returns orig, decoding it first if needed.
+func (md Metrics) getOrig() *otlpcollectormetrics.ExportMetricsServiceRequest {
+	if md.encoded.isPending() {
+		md.encoded.decode(md.orig.Unmarshal)
+	}
+	return md.orig
 }
 
 // NewMetrics creates a new Metrics struct.
 func NewMetrics() Metrics {
-	return Metrics{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{}}
+	return Metrics{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{}, shared: &SharedResourceMetrics{}}
 }
 
-// Clone returns a copy of MetricData.
+// Clone returns a copy of MetricData, reusing the memory of a released Metrics if any.
 func (md Metrics) Clone() Metrics {
-	cloneMd := NewMetrics()
+	if encoded, ok := md.encoded.clone(); ok {
+		return Metrics{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{}, encoded: encoded, shared: &SharedResourceMetrics{}}
+	}
+	cloneMd := newMetricsFromPool()
 	md.ResourceMetrics().CopyTo(cloneMd.ResourceMetrics())
 	return cloneMd
 }
@@ -64,17 +99,83 @@ func (md Metrics) Clone() Metrics {
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value.
 func (md Metrics) MoveTo(dest Metrics) {
-	*dest.orig = *md.orig
+	dest.encoded.Discard()
+	orig := md.getOrig()
+	dest.shared.drop(dest.orig.ResourceMetrics)
+	md.shared.moveTo(dest.shared, orig.ResourceMetrics)
+	*dest.orig = *orig
 	*md.orig = otlpcollectormetrics.ExportMetricsServiceRequest{}
 }
 
 // ResourceMetrics returns the ResourceMetricsSlice associated with this Metrics.
 func (md Metrics) ResourceMetrics() ResourceMetricsSlice {
-	return newResourceMetricsSlice(&md.orig.ResourceMetrics)
+	return ResourceMetricsSlice{orig: &md.getOrig().ResourceMetrics, sharedElements: md.shared}
+}
+
+// Share returns a copy of Metrics sharing the content of the resources of md instead of copying it.
+// A shared resource is copied the first time it is modified from the returned Metrics, so that
+// it does not affect md. Reading the returned Metrics, including marshaling, counting the items
+// or cloning it, does not copy nor modify anything, so that both Metrics can be read concurrently.
+//
+// md itself is not tracked: it must not be modified while the returned Metrics is in use.
+func (md Metrics) Share() Metrics {
+	if encoded, ok := md.encoded.clone(); ok {
+		return Metrics{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{}, encoded: encoded, shared: &SharedResourceMetrics{}}
+	}
+	rs, shared := shareResourceMetrics(md.getOrig().ResourceMetrics)
+	return Metrics{
+		orig:   &otlpcollectormetrics.ExportMetricsServiceRequest{ResourceMetrics: rs},
+		shared: shared,
+	}
+}
+
+// Equal returns true if md and other hold the same content, in the same order.
+func (md Metrics) Equal(other Metrics) bool {
+	return equalOrigs(md.getOrig(), other.getOrig())
+}
+
+// EqualIgnoringOrder returns true if md and other hold the same content, regardless of the order
+// of the attributes and of the repeated elements. The order of the values of array attributes matters.
+func (md Metrics) EqualIgnoringOrder(other Metrics) bool {
+	a, b := md.canonicalClone(), other.canonicalClone()
+	defer a.Release()
+	defer b.Release()
+	return equalOrigs(a.orig, b.orig)
+}
+
+// Diff returns the differences between md and other, in the same order, one per line formatted
+// as "path: value in md != value in other", or an empty string if they are equal.
+func (md Metrics) Diff(other Metrics) string {
+	return diffOrigs(md.getOrig(), other.getOrig())
+}
+
+// DiffIgnoringOrder is like Diff, but regardless of the order as for EqualIgnoringOrder. The elements
+// are compared after sorting them, so that the paths of the differences may not match their position.
+func (md Metrics) DiffIgnoringOrder(other Metrics) string {
+	a, b := md.canonicalClone(), other.canonicalClone()
+	defer a.Release()
+	defer b.Release()
+	return diffOrigs(a.orig, b.orig)
+}
+
+// canonicalClone returns a decoded copy of md whose repeated elements are sorted in a canonical order.
+func (md Metrics) canonicalClone() Metrics {
+	clone := md.Clone()
+	canonicalizeOrig(clone.getOrig())
+	return clone
+}
+
+// IsShared returns true if md holds resources obtained from Share whose content is still shared
+// with other Metrics, that are copied the first time they are modified.
+func (md Metrics) IsShared() bool {
+	return md.shared.len() > 0
 }
 
 // MetricCount calculates the total number of metrics.
 func (md Metrics) MetricCount() int {
+	if buf, ok := md.encoded.Bytes(); ok {
+		return otlpwire.MetricCount(buf)
+	}
 	metricCount := 0
 	rms := md.ResourceMetrics()
 	for i := 0; i < rms.Len(); i++ {
@@ -90,6 +191,9 @@ func (md Metrics) MetricCount() int {
 
 // DataPointCount calculates the total number of data points.
 func (md Metrics) DataPointCount() (dataPointCount int) {
+	if buf, ok := md.encoded.Bytes(); ok {
+		return otlpwire.DataPointCount(buf)
+	}
 	rms := md.ResourceMetrics()
 	for i := 0; i < rms.Len(); i++ {
 		rm := rms.At(i)
@@ -151,17 +255,18 @@ func (mdt MetricDataType) String() string {
 // SetDataType clears any existing data and initialize it with an empty data of the given type.
 // Calling this function on zero-initialized Metric will cause a panic.
 func (ms Metric) SetDataType(ty MetricDataType) {
+	orig := ms.getMutableOrig()
 	switch ty {
 	case MetricDataTypeGauge:
-		ms.orig.Data = &otlpmetrics.Metric_Gauge{Gauge: &otlpmetrics.Gauge{}}
+		orig.Data = &otlpmetrics.Metric_Gauge{Gauge: &otlpmetrics.Gauge{}}
 	case MetricDataTypeSum:
-		ms.orig.Data = &otlpmetrics.Metric_Sum{Sum: &otlpmetrics.Sum{}}
+		orig.Data = &otlpmetrics.Metric_Sum{Sum: &otlpmetrics.Sum{}}
 	case MetricDataTypeHistogram:
-		ms.orig.Data = &otlpmetrics.Metric_Histogram{Histogram: &otlpmetrics.Histogram{}}
+		orig.Data = &otlpmetrics.Metric_Histogram{Histogram: &otlpmetrics.Histogram{}}
 	case MetricDataTypeExponentialHistogram:
-		ms.orig.Data = &otlpmetrics.Metric_ExponentialHistogram{ExponentialHistogram: &otlpmetrics.ExponentialHistogram{}}
+		orig.Data = &otlpmetrics.Metric_ExponentialHistogram{ExponentialHistogram: &otlpmetrics.ExponentialHistogram{}}
 	case MetricDataTypeSummary:
-		ms.orig.Data = &otlpmetrics.Metric_Summary{Summary: &otlpmetrics.Summary{}}
+		orig.Data = &otlpmetrics.Metric_Summary{Summary: &otlpmetrics.Summary{}}
 	}
 }
 
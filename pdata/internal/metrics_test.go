@@ -230,7 +230,7 @@ func TestMetricsMoveTo(t *testing.T) {
 	dest := NewMetrics()
 	metrics.MoveTo(dest)
 	assert.EqualValues(t, NewMetrics(), metrics)
-	assert.EqualValues(t, generateTestResourceMetricsSlice(), dest.ResourceMetrics())
+	assert.EqualValues(t, generateTestResourceMetricsSlice().orig, dest.ResourceMetrics().orig)
 }
 
 func TestOtlpToInternalReadOnly(t *testing.T) {
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpwire // import "go.opentelemetry.io/collector/pdata/internal/otlpwire"
+
+import (
+	"strconv"
+)
+
+// RawMessage is a protobuf message holding its encoded bytes, it sends or receives
+// OTLP requests with gRPC without encoding or decoding them.
+type RawMessage struct {
+	Buf []byte
+}
+
+// Reset implements proto.Message.
+func (m *RawMessage) Reset() {
+	m.Buf = nil
+}
+
+// String implements proto.Message.
+func (m *RawMessage) String() string {
+	return "RawMessage(" + strconv.Itoa(len(m.Buf)) + " bytes)"
+}
+
+// ProtoMessage implements proto.Message.
+func (*RawMessage) ProtoMessage() {}
+
+// Marshal returns the encoded bytes.
+func (m *RawMessage) Marshal() ([]byte, error) {
+	return m.Buf, nil
+}
+
+// Size returns the size of the encoded bytes.
+func (m *RawMessage) Size() int {
+	return len(m.Buf)
+}
+
+// Unmarshal keeps a copy of data, that may be reused by the transport.
+func (m *RawMessage) Unmarshal(data []byte) error {
+	m.Buf = append([]byte(nil), data...)
+	return nil
+}
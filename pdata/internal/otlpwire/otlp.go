@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpwire // import "go.opentelemetry.io/collector/pdata/internal/otlpwire"
+
+import (
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Messages of the OTLP protocol, see the generated code in internal/data/protogen.
+var (
+	anyValue     = &message{}
+	arrayValue   = &message{}
+	keyValueList = &message{}
+	keyValue     = &message{}
+	scope        = &message{}
+	resource     = &message{}
+
+	tracesData    = &message{}
+	resourceSpans = &message{deprecated: 1000}
+	scopeSpans    = &message{}
+	span          = &message{}
+	spanEvent     = &message{}
+	spanLink      = &message{}
+	spanStatus    = &message{}
+
+	metricsData                   = &message{}
+	resourceMetrics               = &message{deprecated: 1000}
+	scopeMetrics                  = &message{}
+	metric                        = &message{}
+	gauge                         = &message{}
+	sum                           = &message{}
+	histogram                     = &message{}
+	exponentialHistogram          = &message{}
+	summary                       = &message{}
+	numberDataPoint               = &message{}
+	histogramDataPoint            = &message{}
+	exponentialHistogramDataPoint = &message{}
+	exponentialHistogramBuckets   = &message{}
+	summaryDataPoint              = &message{}
+	valueAtQuantile               = &message{}
+	exemplar                      = &message{}
+
+	logsData     = &message{}
+	resourceLogs = &message{deprecated: 1000}
+	scopeLogs    = &message{}
+	logRecord    = &message{}
+)
+
+var (
+	varintField        = field{kind: kindVarint}
+	fixed32Field       = field{kind: kindFixed32}
+	fixed64Field       = field{kind: kindFixed64}
+	bytesField         = field{kind: kindBytes}
+	traceIDField       = field{kind: kindTraceID}
+	spanIDField        = field{kind: kindSpanID}
+	packedVarintField  = field{kind: kindPackedVarint}
+	packedFixed64Field = field{kind: kindPackedFixed64}
+)
+
+func messageField(m *message) field {
+	return field{kind: kindMessage, msg: m}
+}
+
+// Metric fields holding the data, only one of them is decoded.
+const (
+	metricGauge                protowire.Number = 5
+	metricSum                  protowire.Number = 7
+	metricHistogram            protowire.Number = 9
+	metricExponentialHistogram protowire.Number = 10
+	metricSummary              protowire.Number = 11
+)
+
+func init() {
+	anyValue.set(1, bytesField)
+	anyValue.set(2, varintField)
+	anyValue.set(3, varintField)
+	anyValue.set(4, fixed64Field)
+	anyValue.set(5, messageField(arrayValue))
+	anyValue.set(6, messageField(keyValueList))
+	anyValue.set(7, bytesField)
+	arrayValue.set(1, messageField(anyValue))
+	keyValueList.set(1, messageField(keyValue))
+	keyValue.set(1, bytesField)
+	keyValue.set(2, messageField(anyValue))
+	scope.set(1, bytesField)
+	scope.set(2, bytesField)
+	resource.set(1, messageField(keyValue))
+	resource.set(2, varintField)
+
+	tracesData.set(1, messageField(resourceSpans))
+	resourceSpans.set(1, messageField(resource))
+	resourceSpans.set(2, messageField(scopeSpans))
+	resourceSpans.set(3, bytesField)
+	scopeSpans.set(1, messageField(scope))
+	scopeSpans.set(2, messageField(span))
+	scopeSpans.set(3, bytesField)
+	span.set(1, traceIDField)
+	span.set(2, spanIDField)
+	span.set(3, bytesField)
+	span.set(4, spanIDField)
+	span.set(5, bytesField)
+	span.set(6, varintField)
+	span.set(7, fixed64Field)
+	span.set(8, fixed64Field)
+	span.set(9, messageField(keyValue))
+	span.set(10, varintField)
+	span.set(11, messageField(spanEvent))
+	span.set(12, varintField)
+	span.set(13, messageField(spanLink))
+	span.set(14, varintField)
+	span.set(15, messageField(spanStatus))
+	spanEvent.set(1, fixed64Field)
+	spanEvent.set(2, bytesField)
+	spanEvent.set(3, messageField(keyValue))
+	spanEvent.set(4, varintField)
+	spanLink.set(1, traceIDField)
+	spanLink.set(2, spanIDField)
+	spanLink.set(3, bytesField)
+	spanLink.set(4, messageField(keyValue))
+	spanLink.set(5, varintField)
+	spanStatus.set(2, bytesField)
+	spanStatus.set(3, varintField)
+
+	metricsData.set(1, messageField(resourceMetrics))
+	resourceMetrics.set(1, messageField(resource))
+	resourceMetrics.set(2, messageField(scopeMetrics))
+	resourceMetrics.set(3, bytesField)
+	scopeMetrics.set(1, messageField(scope))
+	scopeMetrics.set(2, messageField(metric))
+	scopeMetrics.set(3, bytesField)
+	metric.set(1, bytesField)
+	metric.set(2, bytesField)
+	metric.set(3, bytesField)
+	metric.set(metricGauge, messageField(gauge))
+	metric.set(metricSum, messageField(sum))
+	metric.set(metricHistogram, messageField(histogram))
+	metric.set(metricExponentialHistogram, messageField(exponentialHistogram))
+	metric.set(metricSummary, messageField(summary))
+	gauge.set(1, messageField(numberDataPoint))
+	sum.set(1, messageField(numberDataPoint))
+	sum.set(2, varintField)
+	sum.set(3, varintField)
+	histogram.set(1, messageField(histogramDataPoint))
+	histogram.set(2, varintField)
+	exponentialHistogram.set(1, messageField(exponentialHistogramDataPoint))
+	exponentialHistogram.set(2, varintField)
+	summary.set(1, messageField(summaryDataPoint))
+	numberDataPoint.set(2, fixed64Field)
+	numberDataPoint.set(3, fixed64Field)
+	numberDataPoint.set(4, fixed64Field)
+	numberDataPoint.set(5, messageField(exemplar))
+	numberDataPoint.set(6, fixed64Field)
+	numberDataPoint.set(7, messageField(keyValue))
+	numberDataPoint.set(8, varintField)
+	histogramDataPoint.set(2, fixed64Field)
+	histogramDataPoint.set(3, fixed64Field)
+	histogramDataPoint.set(4, fixed64Field)
+	histogramDataPoint.set(5, fixed64Field)
+	histogramDataPoint.set(6, packedFixed64Field)
+	histogramDataPoint.set(7, packedFixed64Field)
+	histogramDataPoint.set(8, messageField(exemplar))
+	histogramDataPoint.set(9, messageField(keyValue))
+	histogramDataPoint.set(10, varintField)
+	exponentialHistogramDataPoint.set(1, messageField(keyValue))
+	exponentialHistogramDataPoint.set(2, fixed64Field)
+	exponentialHistogramDataPoint.set(3, fixed64Field)
+	exponentialHistogramDataPoint.set(4, fixed64Field)
+	exponentialHistogramDataPoint.set(5, fixed64Field)
+	exponentialHistogramDataPoint.set(6, varintField)
+	exponentialHistogramDataPoint.set(7, fixed64Field)
+	exponentialHistogramDataPoint.set(8, messageField(exponentialHistogramBuckets))
+	exponentialHistogramDataPoint.set(9, messageField(exponentialHistogramBuckets))
+	exponentialHistogramDataPoint.set(10, varintField)
+	exponentialHistogramDataPoint.set(11, messageField(exemplar))
+	exponentialHistogramBuckets.set(1, varintField)
+	exponentialHistogramBuckets.set(2, packedVarintField)
+	summaryDataPoint.set(2, fixed64Field)
+	summaryDataPoint.set(3, fixed64Field)
+	summaryDataPoint.set(4, fixed64Field)
+	summaryDataPoint.set(5, fixed64Field)
+	summaryDataPoint.set(6, messageField(valueAtQuantile))
+	summaryDataPoint.set(7, messageField(keyValue))
+	summaryDataPoint.set(8, varintField)
+	valueAtQuantile.set(1, fixed64Field)
+	valueAtQuantile.set(2, fixed64Field)
+	exemplar.set(2, fixed64Field)
+	exemplar.set(3, fixed64Field)
+	exemplar.set(4, spanIDField)
+	exemplar.set(5, traceIDField)
+	exemplar.set(6, fixed64Field)
+	exemplar.set(7, messageField(keyValue))
+
+	logsData.set(1, messageField(resourceLogs))
+	resourceLogs.set(1, messageField(resource))
+	resourceLogs.set(2, messageField(scopeLogs))
+	resourceLogs.set(3, bytesField)
+	scopeLogs.set(1, messageField(scope))
+	scopeLogs.set(2, messageField(logRecord))
+	scopeLogs.set(3, bytesField)
+	logRecord.set(1, fixed64Field)
+	logRecord.set(2, varintField)
+	logRecord.set(3, bytesField)
+	logRecord.set(5, messageField(anyValue))
+	logRecord.set(6, messageField(keyValue))
+	logRecord.set(7, varintField)
+	logRecord.set(8, fixed32Field)
+	logRecord.set(9, traceIDField)
+	logRecord.set(10, spanIDField)
+	logRecord.set(11, fixed64Field)
+}
+
+// ValidTraces reports whether buf is an encoded TracesData, or ExportTraceServiceRequest,
+// that can be decoded without error and without any deprecated field to convert.
+func ValidTraces(buf []byte) bool {
+	return valid(buf, tracesData)
+}
+
+// SpanCount returns the number of spans of a valid encoded TracesData.
+func SpanCount(buf []byte) int {
+	return countNested(buf, 1, 2, 2)
+}
+
+// ValidMetrics reports whether buf is an encoded MetricsData, or ExportMetricsServiceRequest,
+// that can be decoded without error and without any deprecated field to convert.
+func ValidMetrics(buf []byte) bool {
+	return valid(buf, metricsData)
+}
+
+// MetricCount returns the number of metrics of a valid encoded MetricsData.
+func MetricCount(buf []byte) int {
+	return countNested(buf, 1, 2, 2)
+}
+
+// DataPointCount returns the number of data points of a valid encoded MetricsData.
+func DataPointCount(buf []byte) int {
+	c := 0
+	forEach(buf, 1, func(rm []byte) {
+		forEach(rm, 2, func(sm []byte) {
+			forEach(sm, 2, func(m []byte) {
+				c += metricDataPointCount(m)
+			})
+		})
+	})
+	return c
+}
+
+// metricDataPointCount counts the data points of the last data field of the metric,
+// the one kept by the generated unmarshaler.
+func metricDataPointCount(buf []byte) int {
+	var data []byte
+	for len(buf) > 0 {
+		num, typ, n := protowire.ConsumeTag(buf)
+		buf = buf[n:]
+		switch num {
+		case metricGauge, metricSum, metricHistogram, metricExponentialHistogram, metricSummary:
+			data, n = protowire.ConsumeBytes(buf)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, buf)
+		}
+		buf = buf[n:]
+	}
+	return count(data, 1)
+}
+
+// ValidLogs reports whether buf is an encoded LogsData, or ExportLogsServiceRequest,
+// that can be decoded without error and without any deprecated field to convert.
+func ValidLogs(buf []byte) bool {
+	return valid(buf, logsData)
+}
+
+// LogRecordCount returns the number of log records of a valid encoded LogsData.
+func LogRecordCount(buf []byte) int {
+	return countNested(buf, 1, 2, 2)
+}
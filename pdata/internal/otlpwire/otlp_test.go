@@ -0,0 +1,325 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpwire_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlpcollectorlog "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
+	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
+	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
+	"go.opentelemetry.io/collector/pdata/internal/otlpwire"
+)
+
+var (
+	testTraceID = internal.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	testSpanID  = internal.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+)
+
+func fillAttributes(attrs internal.Map) {
+	attrs.InsertString("string", "value")
+	attrs.InsertInt("int", -1)
+	attrs.InsertDouble("double", 1.5)
+	attrs.InsertBool("bool", true)
+	attrs.InsertBytes("bytes", []byte{1, 2})
+	kvs := internal.NewValueMap()
+	kvs.MapVal().InsertString("key", "value")
+	attrs.Insert("map", kvs)
+	arr := internal.NewValueSlice()
+	arr.SliceVal().AppendEmpty().SetIntVal(1)
+	arr.SliceVal().AppendEmpty().SetStringVal("two")
+	attrs.Insert("slice", arr)
+}
+
+func generateTraces() internal.Traces {
+	td := internal.NewTraces()
+	for i := 0; i < 2; i++ {
+		rs := td.ResourceSpans().AppendEmpty()
+		fillAttributes(rs.Resource().Attributes())
+		rs.SetSchemaUrl("schema")
+		for j := 0; j < 2; j++ {
+			ss := rs.ScopeSpans().AppendEmpty()
+			ss.Scope().SetName("scope")
+			ss.Scope().SetVersion("1.0")
+			for k := 0; k < 3; k++ {
+				span := ss.Spans().AppendEmpty()
+				span.SetTraceID(testTraceID)
+				span.SetSpanID(testSpanID)
+				span.SetParentSpanID(testSpanID)
+				span.SetTraceState("state")
+				span.SetName("span")
+				span.SetKind(internal.SpanKindClient)
+				span.SetStartTimestamp(1)
+				span.SetEndTimestamp(2)
+				fillAttributes(span.Attributes())
+				span.SetDroppedAttributesCount(1)
+				event := span.Events().AppendEmpty()
+				event.SetTimestamp(3)
+				event.SetName("event")
+				event.Attributes().InsertString("key", "value")
+				link := span.Links().AppendEmpty()
+				link.SetTraceID(testTraceID)
+				link.SetSpanID(testSpanID)
+				link.Attributes().InsertInt("key", 1)
+				span.Status().SetCode(internal.StatusCodeError)
+				span.Status().SetMessage("error")
+			}
+		}
+	}
+	return td
+}
+
+func generateMetrics() internal.Metrics {
+	md := internal.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	fillAttributes(rm.Resource().Attributes())
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	m := ms.AppendEmpty()
+	m.SetName("gauge")
+	m.SetDataType(internal.MetricDataTypeGauge)
+	dp := m.Gauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(1)
+	dp.SetDoubleVal(1.5)
+	fillAttributes(dp.Attributes())
+	ex := dp.Exemplars().AppendEmpty()
+	ex.SetIntVal(1)
+	ex.SetTraceID(testTraceID)
+	ex.SetSpanID(testSpanID)
+	ex.FilteredAttributes().InsertString("key", "value")
+	m.Gauge().DataPoints().AppendEmpty().SetIntVal(2)
+
+	m = ms.AppendEmpty()
+	m.SetName("sum")
+	m.SetDataType(internal.MetricDataTypeSum)
+	m.Sum().SetIsMonotonic(true)
+	m.Sum().SetAggregationTemporality(internal.MetricAggregationTemporalityCumulative)
+	m.Sum().DataPoints().AppendEmpty().SetIntVal(3)
+
+	m = ms.AppendEmpty()
+	m.SetName("histogram")
+	m.SetDataType(internal.MetricDataTypeHistogram)
+	hdp := m.Histogram().DataPoints().AppendEmpty()
+	hdp.SetCount(3)
+	hdp.SetSum(4.5)
+	hdp.SetBucketCounts([]uint64{1, 2})
+	hdp.SetExplicitBounds([]float64{1})
+	hdp.Exemplars().AppendEmpty().SetDoubleVal(1)
+	m.Histogram().DataPoints().AppendEmpty()
+
+	m = ms.AppendEmpty()
+	m.SetName("exponential_histogram")
+	m.SetDataType(internal.MetricDataTypeExponentialHistogram)
+	edp := m.ExponentialHistogram().DataPoints().AppendEmpty()
+	edp.SetScale(-2)
+	edp.SetZeroCount(1)
+	edp.Positive().SetOffset(-1)
+	edp.Positive().SetBucketCounts([]uint64{1, 300})
+	edp.Negative().SetBucketCounts([]uint64{2})
+
+	m = ms.AppendEmpty()
+	m.SetName("summary")
+	m.SetDataType(internal.MetricDataTypeSummary)
+	sdp := m.Summary().DataPoints().AppendEmpty()
+	sdp.SetCount(1)
+	q := sdp.QuantileValues().AppendEmpty()
+	q.SetQuantile(0.5)
+	q.SetValue(1)
+
+	ms.AppendEmpty().SetName("empty")
+	return md
+}
+
+func generateLogs() internal.Logs {
+	ld := internal.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	fillAttributes(rl.Resource().Attributes())
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("scope")
+	for i := 0; i < 3; i++ {
+		lr := sl.LogRecords().AppendEmpty()
+		lr.SetTimestamp(1)
+		lr.SetObservedTimestamp(2)
+		lr.SetSeverityNumber(internal.SeverityNumberINFO)
+		lr.SetSeverityText("info")
+		lr.Body().SetStringVal("body")
+		fillAttributes(lr.Attributes())
+		lr.SetFlags(1)
+		lr.SetTraceID(testTraceID)
+		lr.SetSpanID(testSpanID)
+	}
+	rl.ScopeLogs().AppendEmpty()
+	return ld
+}
+
+func TestTraces(t *testing.T) {
+	td := generateTraces()
+	buf, err := internal.TracesToOtlp(td).Marshal()
+	require.NoError(t, err)
+	assert.True(t, otlpwire.ValidTraces(buf))
+	assert.Equal(t, td.SpanCount(), otlpwire.SpanCount(buf))
+	assert.True(t, otlpwire.ValidTraces(nil))
+	assert.Equal(t, 0, otlpwire.SpanCount(nil))
+}
+
+func TestMetrics(t *testing.T) {
+	md := generateMetrics()
+	buf, err := internal.MetricsToOtlp(md).Marshal()
+	require.NoError(t, err)
+	assert.True(t, otlpwire.ValidMetrics(buf))
+	assert.Equal(t, md.MetricCount(), otlpwire.MetricCount(buf))
+	assert.Equal(t, md.DataPointCount(), otlpwire.DataPointCount(buf))
+}
+
+func TestLogs(t *testing.T) {
+	ld := generateLogs()
+	buf, err := internal.LogsToOtlp(ld).Marshal()
+	require.NoError(t, err)
+	assert.True(t, otlpwire.ValidLogs(buf))
+	assert.Equal(t, ld.LogRecordCount(), otlpwire.LogRecordCount(buf))
+}
+
+func appendMessage(buf []byte, num protowire.Number, msg []byte) []byte {
+	buf = protowire.AppendTag(buf, num, protowire.BytesType)
+	return protowire.AppendBytes(buf, msg)
+}
+
+func appendSpan(span []byte) []byte {
+	return appendMessage(nil, 1, appendMessage(nil, 2, appendMessage(nil, 2, span)))
+}
+
+func TestValidTraces(t *testing.T) {
+	name := protowire.AppendString(protowire.AppendTag(nil, 5, protowire.BytesType), "name")
+	tests := []struct {
+		name  string
+		buf   []byte
+		valid bool
+	}{
+		{
+			name:  "span",
+			buf:   appendSpan(name),
+			valid: true,
+		},
+		{
+			name:  "unknown field",
+			buf:   appendSpan(protowire.AppendVarint(protowire.AppendTag(name, 100, protowire.VarintType), 1)),
+			valid: true,
+		},
+		{
+			name: "unknown group",
+			buf:  appendSpan(protowire.AppendTag(protowire.AppendTag(name, 100, protowire.StartGroupType), 100, protowire.EndGroupType)),
+		},
+		{
+			name: "wrong wire type",
+			buf:  appendSpan(protowire.AppendVarint(protowire.AppendTag(nil, 5, protowire.VarintType), 1)),
+		},
+		{
+			name: "invalid trace id",
+			buf:  appendSpan(appendMessage(nil, 1, []byte{1, 2, 3})),
+		},
+		{
+			name: "truncated",
+			buf:  appendSpan(name)[:5],
+		},
+		{
+			name: "field number zero",
+			buf:  protowire.AppendVarint(protowire.AppendTag(nil, 0, protowire.VarintType), 1),
+		},
+		{
+			name: "deprecated instrumentation library spans",
+			buf:  appendMessage(nil, 1, appendMessage(nil, 1000, nil)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.valid, otlpwire.ValidTraces(tt.buf))
+			if tt.valid {
+				assert.NoError(t, (&otlpcollectortrace.ExportTraceServiceRequest{}).Unmarshal(tt.buf))
+			}
+		})
+	}
+}
+
+// mutations calls fn with copies of buf where one byte is changed, and with truncated copies.
+func mutations(buf []byte, fn func([]byte)) {
+	for i := range buf {
+		for _, b := range []byte{0x00, 0x01, 0x08, 0x0a, 0x7f, 0x80, 0xff, buf[i] + 1, buf[i] - 1} {
+			mutated := append([]byte(nil), buf...)
+			mutated[i] = b
+			fn(mutated)
+		}
+		fn(buf[:i])
+	}
+}
+
+func TestValidTracesMutations(t *testing.T) {
+	buf, err := internal.TracesToOtlp(generateTraces()).Marshal()
+	require.NoError(t, err)
+	mutations(buf, func(mutated []byte) {
+		if !otlpwire.ValidTraces(mutated) {
+			return
+		}
+		orig := &otlpcollectortrace.ExportTraceServiceRequest{}
+		require.NoError(t, orig.Unmarshal(mutated), "%x", mutated)
+		assert.Equal(t, internal.TracesFromOtlp(orig).SpanCount(), otlpwire.SpanCount(mutated))
+	})
+}
+
+func TestValidMetricsMutations(t *testing.T) {
+	buf, err := internal.MetricsToOtlp(generateMetrics()).Marshal()
+	require.NoError(t, err)
+	mutations(buf, func(mutated []byte) {
+		if !otlpwire.ValidMetrics(mutated) {
+			return
+		}
+		orig := &otlpcollectormetrics.ExportMetricsServiceRequest{}
+		require.NoError(t, orig.Unmarshal(mutated), "%x", mutated)
+		md := internal.MetricsFromOtlp(orig)
+		assert.Equal(t, md.MetricCount(), otlpwire.MetricCount(mutated))
+		assert.Equal(t, md.DataPointCount(), otlpwire.DataPointCount(mutated))
+	})
+}
+
+func TestValidLogsMutations(t *testing.T) {
+	buf, err := internal.LogsToOtlp(generateLogs()).Marshal()
+	require.NoError(t, err)
+	mutations(buf, func(mutated []byte) {
+		if !otlpwire.ValidLogs(mutated) {
+			return
+		}
+		orig := &otlpcollectorlog.ExportLogsServiceRequest{}
+		require.NoError(t, orig.Unmarshal(mutated), "%x", mutated)
+		assert.Equal(t, internal.LogsFromOtlp(orig).LogRecordCount(), otlpwire.LogRecordCount(mutated))
+	})
+}
+
+func TestRawMessage(t *testing.T) {
+	msg := &otlpwire.RawMessage{}
+	data := []byte{1, 2, 3}
+	require.NoError(t, msg.Unmarshal(data))
+	data[0] = 0
+	assert.Equal(t, 3, msg.Size())
+	buf, err := msg.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, buf)
+	assert.Equal(t, "RawMessage(3 bytes)", msg.String())
+	msg.Reset()
+	assert.Equal(t, 0, msg.Size())
+}
@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlpwire inspects OTLP payloads in the protobuf wire format without decoding them.
+//
+// It is used to keep received requests encoded until their content is accessed: Valid* functions
+// check that the generated unmarshalers will accept a payload, and the count functions compute
+// the number of items from the encoded bytes.
+package otlpwire // import "go.opentelemetry.io/collector/pdata/internal/otlpwire"
+
+import (
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+type kind uint8
+
+const (
+	// kindUnknown fields are skipped by the generated unmarshalers, any wire type but groups is accepted.
+	kindUnknown kind = iota
+	kindVarint
+	kindFixed32
+	kindFixed64
+	kindBytes
+	kindMessage
+	kindTraceID
+	kindSpanID
+	kindPackedVarint
+	kindPackedFixed64
+)
+
+const (
+	traceIDSize = 16
+	spanIDSize  = 8
+)
+
+type field struct {
+	kind kind
+	msg  *message
+}
+
+// message describes the fields of a protobuf message, indexed by field number.
+type message struct {
+	fields []field
+	// deprecated is the number of a deprecated field that needs to be converted after decoding,
+	// payloads containing it are reported as invalid so that they are decoded right away.
+	deprecated protowire.Number
+}
+
+func (m *message) set(num protowire.Number, f field) {
+	for protowire.Number(len(m.fields)) <= num {
+		m.fields = append(m.fields, field{})
+	}
+	m.fields[num] = f
+}
+
+func (m *message) field(num protowire.Number) field {
+	if num < protowire.Number(len(m.fields)) {
+		return m.fields[num]
+	}
+	return field{}
+}
+
+// valid reports whether buf is a valid encoding of m, with stricter rules than the generated
+// unmarshalers: groups, non-canonical packed fields and overflowing varints are rejected.
+func valid(buf []byte, m *message) bool {
+	for len(buf) > 0 {
+		num, typ, n := consumeTag(buf)
+		if n < 0 || num == m.deprecated {
+			return false
+		}
+		buf = buf[n:]
+		f := m.field(num)
+		switch f.kind {
+		case kindUnknown:
+			if typ == protowire.StartGroupType || typ == protowire.EndGroupType {
+				return false
+			}
+			n = protowire.ConsumeFieldValue(num, typ, buf)
+		case kindVarint:
+			if typ != protowire.VarintType {
+				return false
+			}
+			_, n = protowire.ConsumeVarint(buf)
+		case kindFixed32:
+			if typ != protowire.Fixed32Type {
+				return false
+			}
+			_, n = protowire.ConsumeFixed32(buf)
+		case kindFixed64:
+			if typ != protowire.Fixed64Type {
+				return false
+			}
+			_, n = protowire.ConsumeFixed64(buf)
+		case kindPackedVarint:
+			if typ == protowire.VarintType {
+				_, n = protowire.ConsumeVarint(buf)
+				break
+			}
+			if typ != protowire.BytesType {
+				return false
+			}
+			var v []byte
+			if v, n = protowire.ConsumeBytes(buf); n >= 0 && !validPackedVarint(v) {
+				return false
+			}
+		case kindPackedFixed64:
+			if typ == protowire.Fixed64Type {
+				_, n = protowire.ConsumeFixed64(buf)
+				break
+			}
+			if typ != protowire.BytesType {
+				return false
+			}
+			var v []byte
+			if v, n = protowire.ConsumeBytes(buf); n >= 0 && len(v)%8 != 0 {
+				return false
+			}
+		default:
+			if typ != protowire.BytesType {
+				return false
+			}
+			var v []byte
+			if v, n = consumeBytes(buf); n >= 0 && !validBytes(v, f) {
+				return false
+			}
+		}
+		if n < 0 {
+			return false
+		}
+		buf = buf[n:]
+	}
+	return true
+}
+
+// consumeTag is protowire.ConsumeTag with a fast path for the single byte tags used by OTLP.
+func consumeTag(buf []byte) (protowire.Number, protowire.Type, int) {
+	if len(buf) > 0 && buf[0] < 0x80 && buf[0] >= 1<<3 {
+		return protowire.Number(buf[0] >> 3), protowire.Type(buf[0] & 7), 1
+	}
+	return protowire.ConsumeTag(buf)
+}
+
+// consumeBytes is protowire.ConsumeBytes with a fast path for single byte lengths.
+func consumeBytes(buf []byte) ([]byte, int) {
+	if len(buf) > 0 && buf[0] < 0x80 {
+		l := int(buf[0]) + 1
+		if l > len(buf) {
+			return nil, -1
+		}
+		return buf[1:l], l
+	}
+	return protowire.ConsumeBytes(buf)
+}
+
+func validBytes(v []byte, f field) bool {
+	switch f.kind {
+	case kindMessage:
+		return valid(v, f.msg)
+	case kindTraceID:
+		return len(v) == 0 || len(v) == traceIDSize
+	case kindSpanID:
+		return len(v) == 0 || len(v) == spanIDSize
+	}
+	return true
+}
+
+func validPackedVarint(buf []byte) bool {
+	for len(buf) > 0 {
+		_, n := protowire.ConsumeVarint(buf)
+		if n < 0 {
+			return false
+		}
+		buf = buf[n:]
+	}
+	return true
+}
+
+// forEach calls fn with the content of every length-delimited field num of a valid payload.
+func forEach(buf []byte, num protowire.Number, fn func([]byte)) {
+	for len(buf) > 0 {
+		n, typ, l := consumeTag(buf)
+		buf = buf[l:]
+		if n == num && typ == protowire.BytesType {
+			v, l := consumeBytes(buf)
+			fn(v)
+			buf = buf[l:]
+			continue
+		}
+		buf = buf[protowire.ConsumeFieldValue(n, typ, buf):]
+	}
+}
+
+// count returns the number of occurrences of the field num in a valid payload.
+func count(buf []byte, num protowire.Number) int {
+	c := 0
+	for len(buf) > 0 {
+		n, typ, l := consumeTag(buf)
+		buf = buf[l:]
+		if n == num {
+			c++
+		}
+		buf = buf[protowire.ConsumeFieldValue(n, typ, buf):]
+	}
+	return c
+}
+
+// countNested returns the number of fields found by following the path of field numbers, the last
+// one being counted, in a valid payload.
+func countNested(buf []byte, path ...protowire.Number) int {
+	if len(path) == 1 {
+		return count(buf, path[0])
+	}
+	c := 0
+	forEach(buf, path[0], func(v []byte) {
+		c += countNested(v, path[1:]...)
+	})
+	return c
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+import (
+	"sync"
+
+	otlpcollectorlog "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
+	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
+	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
+)
+
+// Released orig structs are kept with their elements beyond the length of the slices,
+// CopyTo reuses these elements instead of allocating new ones when cloning into them.
+var (
+	tracesPool  sync.Pool
+	metricsPool sync.Pool
+	logsPool    sync.Pool
+)
+
+// newTracesFromPool returns an empty Traces, reusing a released one if available.
+func newTracesFromPool() Traces {
+	if orig, ok := tracesPool.Get().(*otlpcollectortrace.ExportTraceServiceRequest); ok {
+		return Traces{orig: orig, shared: &SharedResourceSpans{}}
+	}
+	return NewTraces()
+}
+
+// Release returns the memory of the Traces to a pool, to be reused by the next calls to Clone.
+//
+// Releasing is optional, it must only be done by the exclusive owner of the Traces once it
+// finished using it: the Traces and any value obtained from it must not be accessed anymore,
+// and the Traces must not be released twice.
+func (td Traces) Release() {
+	td.encoded.Discard()
+	orig := td.orig
+	// The shared resources are not reused, they are still used by other Traces.
+	td.shared.drop(orig.ResourceSpans)
+	orig.ResourceSpans = orig.ResourceSpans[:0]
+	tracesPool.Put(orig)
+}
+
+// newMetricsFromPool returns an empty Metrics, reusing a released one if available.
+func newMetricsFromPool() Metrics {
+	if orig, ok := metricsPool.Get().(*otlpcollectormetrics.ExportMetricsServiceRequest); ok {
+		return Metrics{orig: orig, shared: &SharedResourceMetrics{}}
+	}
+	return NewMetrics()
+}
+
+// Release returns the memory of the Metrics to a pool, to be reused by the next calls to Clone.
+//
+// Releasing is optional, it must only be done by the exclusive owner of the Metrics once it
+// finished using it: the Metrics and any value obtained from it must not be accessed anymore,
+// and the Metrics must not be released twice.
+func (md Metrics) Release() {
+	md.encoded.Discard()
+	orig := md.orig
+	// The shared resources are not reused, they are still used by other Metrics.
+	md.shared.drop(orig.ResourceMetrics)
+	orig.ResourceMetrics = orig.ResourceMetrics[:0]
+	metricsPool.Put(orig)
+}
+
+// newLogsFromPool returns an empty Logs, reusing a released one if available.
+func newLogsFromPool() Logs {
+	if orig, ok := logsPool.Get().(*otlpcollectorlog.ExportLogsServiceRequest); ok {
+		return Logs{orig: orig, shared: &SharedResourceLogs{}}
+	}
+	return NewLogs()
+}
+
+// Release returns the memory of the Logs to a pool, to be reused by the next calls to Clone.
+//
+// Releasing is optional, it must only be done by the exclusive owner of the Logs once it
+// finished using it: the Logs and any value obtained from it must not be accessed anymore,
+// and the Logs must not be released twice.
+func (ld Logs) Release() {
+	ld.encoded.Discard()
+	orig := ld.orig
+	// The shared resources are not reused, they are still used by other Logs.
+	ld.shared.drop(orig.ResourceLogs)
+	orig.ResourceLogs = orig.ResourceLogs[:0]
+	logsPool.Put(orig)
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracesCloneReleased(t *testing.T) {
+	td := NewTraces()
+	generateTestResourceSpansSlice().CopyTo(td.ResourceSpans())
+
+	// Release a larger Traces, the clone reuses part of its elements.
+	large := NewTraces()
+	generateTestResourceSpansSlice().MoveAndAppendTo(large.ResourceSpans())
+	generateTestResourceSpansSlice().MoveAndAppendTo(large.ResourceSpans())
+	large.Release()
+	clone := td.Clone()
+	assert.Equal(t, td.ResourceSpans(), clone.ResourceSpans())
+
+	// Release a smaller Traces, the clone allocates the missing elements.
+	clone.ResourceSpans().RemoveIf(func(rs ResourceSpans) bool {
+		return rs.ScopeSpans().Len() > 0
+	})
+	assert.Equal(t, 0, clone.ResourceSpans().Len())
+	clone.Release()
+	clone = td.Clone()
+	assert.Equal(t, td.ResourceSpans(), clone.ResourceSpans())
+
+	// Modifying the clone does not modify the original.
+	clone.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("changed")
+	assert.Equal(t, generateTestResourceSpansSlice().orig, td.ResourceSpans().orig)
+}
+
+func TestTracesReleaseEncoded(t *testing.T) {
+	td, _ := encodedTraces(t)
+	encoded := td.encoded
+	td.Release()
+	_, ok := encoded.Bytes()
+	assert.False(t, ok)
+
+	src := NewTraces()
+	generateTestResourceSpansSlice().CopyTo(src.ResourceSpans())
+	assert.Equal(t, src.ResourceSpans(), src.Clone().ResourceSpans())
+}
+
+func TestMetricsCloneReleased(t *testing.T) {
+	md := NewMetrics()
+	generateTestResourceMetricsSlice().CopyTo(md.ResourceMetrics())
+
+	large := NewMetrics()
+	generateTestResourceMetricsSlice().MoveAndAppendTo(large.ResourceMetrics())
+	generateTestResourceMetricsSlice().MoveAndAppendTo(large.ResourceMetrics())
+	large.Release()
+	clone := md.Clone()
+	assert.Equal(t, md.ResourceMetrics(), clone.ResourceMetrics())
+
+	clone.Release()
+	clone = md.Clone()
+	clone.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).SetName("changed")
+	assert.Equal(t, generateTestResourceMetricsSlice().orig, md.ResourceMetrics().orig)
+}
+
+func TestLogsCloneReleased(t *testing.T) {
+	ld := NewLogs()
+	generateTestResourceLogsSlice().CopyTo(ld.ResourceLogs())
+
+	large := NewLogs()
+	generateTestResourceLogsSlice().MoveAndAppendTo(large.ResourceLogs())
+	generateTestResourceLogsSlice().MoveAndAppendTo(large.ResourceLogs())
+	large.Release()
+	clone := ld.Clone()
+	assert.Equal(t, ld.ResourceLogs(), clone.ResourceLogs())
+
+	clone.Release()
+	clone = ld.Clone()
+	clone.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SetSeverityText("changed")
+	assert.Equal(t, generateTestResourceLogsSlice().orig, ld.ResourceLogs().orig)
+}
+
+func TestCopyToSpareCapacity(t *testing.T) {
+	dest := NewSpanEventSlice()
+	dest.EnsureCapacity(10)
+	dest.AppendEmpty()
+	generateTestSpanEventSlice().CopyTo(dest)
+	assert.Equal(t, generateTestSpanEventSlice(), dest)
+}
+
+func TestCopyToResetsUnsetFields(t *testing.T) {
+	dest := generateTestMetric()
+	NewMetric().CopyTo(dest)
+	assert.Equal(t, NewMetric(), dest)
+
+	hdp := generateTestHistogramDataPoint()
+	src := generateTestHistogramDataPoint()
+	src.orig.Sum_ = nil
+	src.CopyTo(hdp)
+	assert.False(t, hdp.HasSum())
+
+	ndp := generateTestNumberDataPoint()
+	emptyNdp := NewNumberDataPoint()
+	emptyNdp.CopyTo(ndp)
+	assert.Equal(t, NumberDataPointValueTypeNone, ndp.ValueType())
+}
+
+func TestRemoveIfErasesTruncated(t *testing.T) {
+	es := generateTestSpanEventSlice()
+	es.RemoveIf(func(SpanEvent) bool { return true })
+	for _, e := range (*es.orig)[:cap(*es.orig)] {
+		assert.Nil(t, e)
+	}
+
+	m := NewMapFromRaw(map[string]interface{}{"k1": "v1", "k2": "v2"})
+	m.Remove("k1")
+	assert.Equal(t, 1, m.Len())
+	assert.Empty(t, (*m.orig)[1:cap(*m.orig)][0].Key)
+}
+
+func BenchmarkTracesCloneRelease(b *testing.B) {
+	td := NewTraces()
+	generateTestResourceSpansSlice().CopyTo(td.ResourceSpans())
+
+	b.Run("NoRelease", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			_ = td.Clone()
+		}
+	})
+
+	b.Run("Release", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			td.Clone().Release()
+		}
+	})
+}
+
+func BenchmarkMetricsCloneRelease(b *testing.B) {
+	md := NewMetrics()
+	generateTestResourceMetricsSlice().CopyTo(md.ResourceMetrics())
+
+	b.Run("NoRelease", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			_ = md.Clone()
+		}
+	})
+
+	b.Run("Release", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			md.Clone().Release()
+		}
+	})
+}
+
+func BenchmarkLogsCloneRelease(b *testing.B) {
+	ld := NewLogs()
+	generateTestResourceLogsSlice().CopyTo(ld.ResourceLogs())
+
+	b.Run("NoRelease", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			_ = ld.Clone()
+		}
+	})
+
+	b.Run("Release", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			ld.Clone().Release()
+		}
+	})
+}
@@ -0,0 +1,446 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+import (
+	"reflect"
+	"sort"
+	"unsafe"
+
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+)
+
+// sharedResource is a resource, e.g. a ResourceSpans, whose content is shared with other data, see
+// Traces.Share. The data owns the resource struct, but not the memory referenced by its fields until
+// it is copied, the first time the content of the resource is modified.
+//
+// The wrappers of the content of the resource hold its sharedResource, they read their orig through
+// read, and modify it through write, which copies the resource first. Since the wrappers obtained
+// before the copy still point to the shared memory, both return the address of the copy of their orig.
+// A nil *sharedResource is not shared, read and write return the given address.
+type sharedResource struct {
+	// orig is the resource struct.
+	orig reflect.Value
+	// copied is true once the memory referenced by orig was copied.
+	copied bool
+	// blocks are the copied memory blocks, sorted by address.
+	blocks []copiedBlock
+}
+
+// copiedBlock is a block of shared memory and its copy.
+type copiedBlock struct {
+	// from keeps the shared block referenced, so that its address is not reused while it is looked up.
+	from unsafe.Pointer
+	to   unsafe.Pointer
+	size uintptr
+}
+
+// newSharedResource returns the sharedResource of the resource struct orig points to.
+func newSharedResource(orig interface{}) *sharedResource {
+	return &sharedResource{orig: reflect.ValueOf(orig).Elem()}
+}
+
+// read returns the address to read the orig at p from.
+func (s *sharedResource) read(p unsafe.Pointer) unsafe.Pointer {
+	if s == nil || !s.copied {
+		return p
+	}
+	return s.relocate(p)
+}
+
+// write returns the address to modify the orig at p, copying the resource first if not done yet.
+func (s *sharedResource) write(p unsafe.Pointer) unsafe.Pointer {
+	if s == nil {
+		return p
+	}
+	if !s.copied {
+		s.copy()
+	}
+	return s.relocate(p)
+}
+
+// relocate returns the address of the copy of p, or p if it does not point to a copied block.
+func (s *sharedResource) relocate(p unsafe.Pointer) unsafe.Pointer {
+	addr := uintptr(p)
+	i := sort.Search(len(s.blocks), func(i int) bool {
+		return uintptr(s.blocks[i].from)+s.blocks[i].size > addr
+	})
+	if i == len(s.blocks) || uintptr(s.blocks[i].from) > addr {
+		return p
+	}
+	return unsafe.Add(s.blocks[i].to, addr-uintptr(s.blocks[i].from))
+}
+
+// copy replaces the memory referenced by the resource struct by a copy.
+func (s *sharedResource) copy() {
+	s.copied = true
+	copyReferenced(s.orig, &s.blocks)
+	sort.Slice(s.blocks, func(i, j int) bool {
+		return uintptr(s.blocks[i].from) < uintptr(s.blocks[j].from)
+	})
+}
+
+// copyReferenced replaces the memory referenced by v, recursively, by copies, appending the copied
+// blocks to blocks. The strings are immutable, they are not copied.
+func copyReferenced(v reflect.Value, blocks *[]copiedBlock) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			copyReferenced(v.Field(i), blocks)
+		}
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		cp := reflect.New(v.Type().Elem())
+		cp.Elem().Set(v.Elem())
+		*blocks = append(*blocks, copiedBlock{
+			from: unsafe.Pointer(v.Pointer()),
+			to:   unsafe.Pointer(cp.Pointer()),
+			size: v.Type().Elem().Size(),
+		})
+		copyReferenced(cp.Elem(), blocks)
+		v.Set(cp)
+	case reflect.Interface:
+		// The oneof fields hold a pointer to a struct wrapping the value.
+		if v.IsNil() {
+			return
+		}
+		cp := reflect.New(v.Elem().Type()).Elem()
+		cp.Set(v.Elem())
+		copyReferenced(cp, blocks)
+		v.Set(cp)
+	case reflect.Slice:
+		// Slices with a spare capacity are copied as well, so that appending does not write to it.
+		if v.Cap() == 0 {
+			return
+		}
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(cp, v)
+		if v.Len() > 0 {
+			*blocks = append(*blocks, copiedBlock{
+				from: unsafe.Pointer(v.Pointer()),
+				to:   unsafe.Pointer(cp.Pointer()),
+				size: uintptr(v.Len()) * v.Type().Elem().Size(),
+			})
+		}
+		switch v.Type().Elem().Kind() {
+		case reflect.Struct, reflect.Ptr, reflect.Interface, reflect.Slice:
+			for i := 0; i < cp.Len(); i++ {
+				copyReferenced(cp.Index(i), blocks)
+			}
+		}
+		v.Set(cp)
+	}
+}
+
+// SharedResourceSpans tracks the ResourceSpans of a Traces whose content is shared with other Traces, see Share.
+// A shared ResourceSpans is copied the first time its content is modified, so that it does not affect
+// the other Traces. A nil *SharedResourceSpans tracks nothing.
+type SharedResourceSpans struct {
+	elements map[*otlptrace.ResourceSpans]*sharedResource
+}
+
+// shareResourceSpans returns copies of the structs of rs, tracked as sharing their content with rs.
+func shareResourceSpans(rs []*otlptrace.ResourceSpans) ([]*otlptrace.ResourceSpans, *SharedResourceSpans) {
+	els := make([]*otlptrace.ResourceSpans, len(rs))
+	s := &SharedResourceSpans{elements: make(map[*otlptrace.ResourceSpans]*sharedResource, len(rs))}
+	for i, r := range rs {
+		if r == nil {
+			continue
+		}
+		el := *r
+		els[i] = &el
+		s.elements[&el] = newSharedResource(&el)
+	}
+	return els, s
+}
+
+// len returns the number of elements whose content is still shared.
+func (s *SharedResourceSpans) len() int {
+	if s == nil {
+		return 0
+	}
+	n := 0
+	for _, r := range s.elements {
+		if !r.copied {
+			n++
+		}
+	}
+	return n
+}
+
+// get returns the sharedResource of el, or nil if its content is not shared.
+func (s *SharedResourceSpans) get(el *otlptrace.ResourceSpans) *sharedResource {
+	if s == nil || len(s.elements) == 0 {
+		return nil
+	}
+	if r := s.elements[el]; r != nil && !r.copied {
+		return r
+	}
+	return nil
+}
+
+// unshareAll copies the shared content of els.
+func (s *SharedResourceSpans) unshareAll(els []*otlptrace.ResourceSpans) {
+	for _, el := range els {
+		if r := s.get(el); r != nil {
+			r.copy()
+		}
+		s.remove(el)
+	}
+}
+
+// remove stops tracking el.
+func (s *SharedResourceSpans) remove(el *otlptrace.ResourceSpans) {
+	if s == nil || len(s.elements) == 0 {
+		return
+	}
+	delete(s.elements, el)
+}
+
+// drop sets the elements of els whose content is shared to nil, so that they are not reused.
+func (s *SharedResourceSpans) drop(els []*otlptrace.ResourceSpans) {
+	if s == nil || len(s.elements) == 0 {
+		return
+	}
+	for i, el := range els {
+		if s.get(el) != nil {
+			els[i] = nil
+		}
+		s.remove(el)
+	}
+}
+
+// moveTo hands over the tracking of els to dest, their shared content is copied if dest is nil.
+func (s *SharedResourceSpans) moveTo(dest *SharedResourceSpans, els []*otlptrace.ResourceSpans) {
+	if s == nil || len(s.elements) == 0 || s == dest {
+		return
+	}
+	for _, el := range els {
+		r := s.get(el)
+		s.remove(el)
+		switch {
+		case r == nil:
+		case dest == nil:
+			r.copy()
+		default:
+			if dest.elements == nil {
+				dest.elements = make(map[*otlptrace.ResourceSpans]*sharedResource)
+			}
+			dest.elements[el] = r
+		}
+	}
+}
+
+// SharedResourceMetrics tracks the ResourceMetrics of a Metrics whose content is shared with other Metrics, see Share.
+// A shared ResourceMetrics is copied the first time its content is modified, so that it does not affect
+// the other Metrics. A nil *SharedResourceMetrics tracks nothing.
+type SharedResourceMetrics struct {
+	elements map[*otlpmetrics.ResourceMetrics]*sharedResource
+}
+
+// shareResourceMetrics returns copies of the structs of rs, tracked as sharing their content with rs.
+func shareResourceMetrics(rs []*otlpmetrics.ResourceMetrics) ([]*otlpmetrics.ResourceMetrics, *SharedResourceMetrics) {
+	els := make([]*otlpmetrics.ResourceMetrics, len(rs))
+	s := &SharedResourceMetrics{elements: make(map[*otlpmetrics.ResourceMetrics]*sharedResource, len(rs))}
+	for i, r := range rs {
+		if r == nil {
+			continue
+		}
+		el := *r
+		els[i] = &el
+		s.elements[&el] = newSharedResource(&el)
+	}
+	return els, s
+}
+
+// len returns the number of elements whose content is still shared.
+func (s *SharedResourceMetrics) len() int {
+	if s == nil {
+		return 0
+	}
+	n := 0
+	for _, r := range s.elements {
+		if !r.copied {
+			n++
+		}
+	}
+	return n
+}
+
+// get returns the sharedResource of el, or nil if its content is not shared.
+func (s *SharedResourceMetrics) get(el *otlpmetrics.ResourceMetrics) *sharedResource {
+	if s == nil || len(s.elements) == 0 {
+		return nil
+	}
+	if r := s.elements[el]; r != nil && !r.copied {
+		return r
+	}
+	return nil
+}
+
+// unshareAll copies the shared content of els.
+func (s *SharedResourceMetrics) unshareAll(els []*otlpmetrics.ResourceMetrics) {
+	for _, el := range els {
+		if r := s.get(el); r != nil {
+			r.copy()
+		}
+		s.remove(el)
+	}
+}
+
+// remove stops tracking el.
+func (s *SharedResourceMetrics) remove(el *otlpmetrics.ResourceMetrics) {
+	if s == nil || len(s.elements) == 0 {
+		return
+	}
+	delete(s.elements, el)
+}
+
+// drop sets the elements of els whose content is shared to nil, so that they are not reused.
+func (s *SharedResourceMetrics) drop(els []*otlpmetrics.ResourceMetrics) {
+	if s == nil || len(s.elements) == 0 {
+		return
+	}
+	for i, el := range els {
+		if s.get(el) != nil {
+			els[i] = nil
+		}
+		s.remove(el)
+	}
+}
+
+// moveTo hands over the tracking of els to dest, their shared content is copied if dest is nil.
+func (s *SharedResourceMetrics) moveTo(dest *SharedResourceMetrics, els []*otlpmetrics.ResourceMetrics) {
+	if s == nil || len(s.elements) == 0 || s == dest {
+		return
+	}
+	for _, el := range els {
+		r := s.get(el)
+		s.remove(el)
+		switch {
+		case r == nil:
+		case dest == nil:
+			r.copy()
+		default:
+			if dest.elements == nil {
+				dest.elements = make(map[*otlpmetrics.ResourceMetrics]*sharedResource)
+			}
+			dest.elements[el] = r
+		}
+	}
+}
+
+// SharedResourceLogs tracks the ResourceLogs of a Logs whose content is shared with other Logs, see Share.
+// A shared ResourceLogs is copied the first time its content is modified, so that it does not affect
+// the other Logs. A nil *SharedResourceLogs tracks nothing.
+type SharedResourceLogs struct {
+	elements map[*otlplogs.ResourceLogs]*sharedResource
+}
+
+// shareResourceLogs returns copies of the structs of rs, tracked as sharing their content with rs.
+func shareResourceLogs(rs []*otlplogs.ResourceLogs) ([]*otlplogs.ResourceLogs, *SharedResourceLogs) {
+	els := make([]*otlplogs.ResourceLogs, len(rs))
+	s := &SharedResourceLogs{elements: make(map[*otlplogs.ResourceLogs]*sharedResource, len(rs))}
+	for i, r := range rs {
+		if r == nil {
+			continue
+		}
+		el := *r
+		els[i] = &el
+		s.elements[&el] = newSharedResource(&el)
+	}
+	return els, s
+}
+
+// len returns the number of elements whose content is still shared.
+func (s *SharedResourceLogs) len() int {
+	if s == nil {
+		return 0
+	}
+	n := 0
+	for _, r := range s.elements {
+		if !r.copied {
+			n++
+		}
+	}
+	return n
+}
+
+// get returns the sharedResource of el, or nil if its content is not shared.
+func (s *SharedResourceLogs) get(el *otlplogs.ResourceLogs) *sharedResource {
+	if s == nil || len(s.elements) == 0 {
+		return nil
+	}
+	if r := s.elements[el]; r != nil && !r.copied {
+		return r
+	}
+	return nil
+}
+
+// unshareAll copies the shared content of els.
+func (s *SharedResourceLogs) unshareAll(els []*otlplogs.ResourceLogs) {
+	for _, el := range els {
+		if r := s.get(el); r != nil {
+			r.copy()
+		}
+		s.remove(el)
+	}
+}
+
+// remove stops tracking el.
+func (s *SharedResourceLogs) remove(el *otlplogs.ResourceLogs) {
+	if s == nil || len(s.elements) == 0 {
+		return
+	}
+	delete(s.elements, el)
+}
+
+// drop sets the elements of els whose content is shared to nil, so that they are not reused.
+func (s *SharedResourceLogs) drop(els []*otlplogs.ResourceLogs) {
+	if s == nil || len(s.elements) == 0 {
+		return
+	}
+	for i, el := range els {
+		if s.get(el) != nil {
+			els[i] = nil
+		}
+		s.remove(el)
+	}
+}
+
+// moveTo hands over the tracking of els to dest, their shared content is copied if dest is nil.
+func (s *SharedResourceLogs) moveTo(dest *SharedResourceLogs, els []*otlplogs.ResourceLogs) {
+	if s == nil || len(s.elements) == 0 || s == dest {
+		return
+	}
+	for _, el := range els {
+		r := s.get(el)
+		s.remove(el)
+		switch {
+		case r == nil:
+		case dest == nil:
+			r.copy()
+		default:
+			if dest.elements == nil {
+				dest.elements = make(map[*otlplogs.ResourceLogs]*sharedResource)
+			}
+			dest.elements[el] = r
+		}
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracesShare(t *testing.T) {
+	td := NewTraces()
+	generateTestResourceSpansSlice().CopyTo(td.ResourceSpans())
+	rss := td.orig.ResourceSpans
+	view := td.Share()
+	assert.True(t, view.IsShared())
+	assert.False(t, td.IsShared())
+	assert.Equal(t, rss, td.orig.ResourceSpans)
+	assert.Same(t, td.orig.ResourceSpans[0].ScopeSpans[0], view.orig.ResourceSpans[0].ScopeSpans[0])
+
+	// Reading does not copy the shared resources.
+	n := td.ResourceSpans().Len()
+	assert.Equal(t, td.SpanCount(), view.SpanCount())
+	assert.Equal(t, td.Clone().orig, view.Clone().orig)
+	assert.Equal(t, TracesToProto(td), TracesToProto(view))
+	assert.Equal(t, "test_name", view.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+	v, _ := view.ResourceSpans().At(0).Resource().Attributes().Get("k")
+	assert.Equal(t, "v", v.StringVal())
+	assert.Equal(t, n, view.shared.len())
+
+	// Modifying a resource copies it, without affecting the original.
+	view.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("changed")
+	assert.Equal(t, n-1, view.shared.len())
+	assert.Equal(t, generateTestResourceSpansSlice().orig, td.ResourceSpans().orig)
+	assert.Equal(t, "changed", view.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+	assert.NotSame(t, td.orig.ResourceSpans[0].ScopeSpans[0], view.orig.ResourceSpans[0].ScopeSpans[0])
+	assert.Same(t, td.orig.ResourceSpans[1].ScopeSpans[0], view.orig.ResourceSpans[1].ScopeSpans[0])
+
+	// The requests representation owns all its content.
+	orig := TracesToOtlp(view)
+	assert.False(t, view.IsShared())
+	assert.NotSame(t, td.orig.ResourceSpans[1].ScopeSpans[0], orig.ResourceSpans[1].ScopeSpans[0])
+	assert.Equal(t, td.orig.ResourceSpans[1], orig.ResourceSpans[1])
+}
+
+func TestTracesShareObtainedBeforeCopy(t *testing.T) {
+	td := NewTraces()
+	generateTestResourceSpansSlice().CopyTo(td.ResourceSpans())
+	view := td.Share()
+
+	// The wrappers obtained before the resource is copied modify the copy.
+	rs := view.ResourceSpans().At(0)
+	span := rs.ScopeSpans().At(0).Spans().At(0)
+	attrs := span.Attributes()
+	v, _ := attrs.Get("k")
+	events := span.Events()
+	rs.Resource().Attributes().UpsertString("view", "true")
+	span.SetName("changed")
+	v.SetStringVal("changed")
+	attrs.UpsertString("other", "true")
+	events.AppendEmpty()
+	events.RemoveIf(func(SpanEvent) bool { return false })
+
+	assert.Equal(t, generateTestResourceSpansSlice().orig, td.ResourceSpans().orig)
+	span = view.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, "changed", span.Name())
+	v, _ = span.Attributes().Get("k")
+	assert.Equal(t, "changed", v.StringVal())
+	_, ok := span.Attributes().Get("other")
+	assert.True(t, ok)
+	assert.Equal(t, generateTestSpanEventSlice().Len()+1, span.Events().Len())
+}
+
+func TestTracesShareConcurrentReads(t *testing.T) {
+	td := NewTraces()
+	generateTestResourceSpansSlice().CopyTo(td.ResourceSpans())
+	views := []Traces{td.Share(), td.Share(), td.Share()}
+
+	read := func(td Traces) {
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			rss.At(i).Resource().Attributes().Range(func(string, Value) bool { return true })
+			sss := rss.At(i).ScopeSpans()
+			for j := 0; j < sss.Len(); j++ {
+				spans := sss.At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					_ = spans.At(k).Name()
+					_, _ = spans.At(k).Attributes().Get("k")
+				}
+			}
+		}
+		_, err := td.orig.Marshal()
+		assert.NoError(t, err)
+	}
+	// Reading the original and the views concurrently, while one of the views is modified, is safe.
+	var wg sync.WaitGroup
+	for _, td := range append([]Traces{td}, views[1:]...) {
+		wg.Add(1)
+		go func(td Traces) {
+			defer wg.Done()
+			read(td)
+		}(td)
+	}
+	rss := views[0].ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rss.At(i).ScopeSpans().At(0).Spans().At(0).SetName("view" + strconv.Itoa(i))
+	}
+	wg.Wait()
+
+	assert.Equal(t, generateTestResourceSpansSlice().orig, td.ResourceSpans().orig)
+	assert.Equal(t, td.ResourceSpans().Len(), views[1].shared.len())
+}
+
+func TestTracesShareOfShare(t *testing.T) {
+	view := NewTraces()
+	generateTestResourceSpansSlice().CopyTo(view.ResourceSpans())
+	view = view.Share()
+	other := view.Share()
+
+	// Both views copy the resources before modifying them.
+	view.ResourceSpans().At(0).Resource().Attributes().UpsertString("view", "true")
+	other.ResourceSpans().At(0).Resource().Attributes().UpsertString("other", "true")
+	_, ok := view.ResourceSpans().At(0).Resource().Attributes().Get("other")
+	assert.False(t, ok)
+	_, ok = other.ResourceSpans().At(0).Resource().Attributes().Get("view")
+	assert.False(t, ok)
+}
+
+func TestTracesShareMoveTo(t *testing.T) {
+	td := NewTraces()
+	generateTestResourceSpansSlice().CopyTo(td.ResourceSpans())
+	n := td.ResourceSpans().Len()
+
+	// Moving to a view hands over the shared resources.
+	view := td.Share()
+	rs := view.orig.ResourceSpans[0]
+	dest := NewTraces().Share()
+	view.ResourceSpans().MoveAndAppendTo(dest.ResourceSpans())
+	assert.Equal(t, n, dest.shared.len())
+	assert.Same(t, rs, dest.orig.ResourceSpans[0])
+	view = td.Share()
+	view.MoveTo(dest)
+	assert.Equal(t, n, dest.shared.len())
+	dest.ResourceSpans().At(0).Resource().Attributes().UpsertString("dest", "true")
+	assert.Equal(t, generateTestResourceSpansSlice().orig, td.ResourceSpans().orig)
+
+	// Moving to a slice that does not belong to a Traces copies the shared resources.
+	view = td.Share()
+	other := NewResourceSpansSlice()
+	view.ResourceSpans().MoveAndAppendTo(other)
+	assert.NotSame(t, td.orig.ResourceSpans[0].ScopeSpans[0], (*other.orig)[0].ScopeSpans[0])
+	assert.Equal(t, td.orig.ResourceSpans, *other.orig)
+}
+
+func TestTracesShareReuse(t *testing.T) {
+	td := NewTraces()
+	generateTestResourceSpansSlice().CopyTo(td.ResourceSpans())
+
+	// Copying to a view does not reuse the shared resources.
+	view := td.Share()
+	NewTraces().ResourceSpans().CopyTo(view.ResourceSpans())
+	generateTestResourceSpansSlice().CopyTo(view.ResourceSpans())
+	assert.False(t, view.IsShared())
+	assert.Equal(t, generateTestResourceSpansSlice().orig, td.ResourceSpans().orig)
+
+	// Releasing a view does not reuse the shared resources.
+	view = td.Share()
+	view.Release()
+	clone := NewTraces().Clone()
+	NewTraces().ResourceSpans().CopyTo(clone.ResourceSpans())
+	generateTestResourceSpansSlice().CopyTo(clone.ResourceSpans())
+	assert.Equal(t, generateTestResourceSpansSlice().orig, td.ResourceSpans().orig)
+}
+
+func TestTracesShareEncoded(t *testing.T) {
+	td, _ := encodedTraces(t)
+	view := td.Share()
+	assert.False(t, view.IsShared())
+	assert.Equal(t, td.ResourceSpans(), view.ResourceSpans())
+}
+
+func TestMetricsShare(t *testing.T) {
+	md := NewMetrics()
+	generateTestResourceMetricsSlice().CopyTo(md.ResourceMetrics())
+	view := md.Share()
+	assert.True(t, view.IsShared())
+	assert.False(t, md.IsShared())
+	assert.Equal(t, md.MetricCount(), view.MetricCount())
+	assert.Equal(t, md.DataPointCount(), view.DataPointCount())
+	assert.Equal(t, md.ResourceMetrics().Len(), view.shared.len())
+
+	view.ResourceMetrics().At(0).Resource().Attributes().UpsertString("view", "true")
+	assert.Equal(t, md.ResourceMetrics().Len()-1, view.shared.len())
+	assert.Equal(t, generateTestResourceMetricsSlice().orig, md.ResourceMetrics().orig)
+
+	// The data type of a metric is changed on the copy.
+	m := view.ResourceMetrics().At(1).ScopeMetrics().At(0).Metrics().At(0)
+	require.NotEqual(t, MetricDataTypeSummary, m.DataType())
+	m.SetDataType(MetricDataTypeSummary)
+	assert.Equal(t, generateTestResourceMetricsSlice().orig, md.ResourceMetrics().orig)
+
+	dest := NewMetrics()
+	view.MoveTo(dest)
+	assert.True(t, dest.IsShared())
+	assert.Same(t, md.orig.ResourceMetrics[2].ScopeMetrics[0], dest.orig.ResourceMetrics[2].ScopeMetrics[0])
+}
+
+func TestLogsShare(t *testing.T) {
+	ld := NewLogs()
+	generateTestResourceLogsSlice().CopyTo(ld.ResourceLogs())
+	view := ld.Share()
+	assert.True(t, view.IsShared())
+	assert.False(t, ld.IsShared())
+	assert.Equal(t, ld.LogRecordCount(), view.LogRecordCount())
+	assert.Equal(t, ld.ResourceLogs().Len(), view.shared.len())
+
+	view.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().SetStringVal("changed")
+	assert.Equal(t, ld.ResourceLogs().Len()-1, view.shared.len())
+	assert.Equal(t, generateTestResourceLogsSlice().orig, ld.ResourceLogs().orig)
+
+	dest := NewLogs()
+	view.MoveTo(dest)
+	assert.True(t, dest.IsShared())
+	assert.Same(t, ld.orig.ResourceLogs[1].ScopeLogs[0], dest.orig.ResourceLogs[1].ScopeLogs[0])
+}
@@ -0,0 +1,582 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+import (
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+)
+
+// The split functions move the first elements of the data to a new one, up to a limit computed by
+// a splitSizer, either in number of items or in bytes of the protobuf encoding. An element is moved
+// as a whole if it fits, otherwise it is split, copying its resource, scope or metric to the destination.
+// At least one item is moved, even if it does not fit, so that splitting the data repeatedly ends.
+
+// maxLenSize is the maximum size of the encoded length of a message, a varint.
+const maxLenSize = 5
+
+// fieldSize returns the encoded size of a message field whose content size is n, the field numbers
+// of the OTLP messages taking a single byte.
+func fieldSize(n int) int {
+	return 1 + varintSize(uint64(n)) + n
+}
+
+func varintSize(x uint64) int {
+	n := 1
+	for x >= 0x80 {
+		x >>= 7
+		n++
+	}
+	return n
+}
+
+// headerSize returns an upper bound of the encoded size of a message field whose content size is n
+// without its repeated elements, as the size of its encoded length grows with them.
+func headerSize(n int) int {
+	return 1 + maxLenSize + n
+}
+
+// splitLimit tracks the room left to split the data.
+type splitLimit struct {
+	left  int
+	moved bool
+	full  bool
+}
+
+// fits returns true if an element of the given size, that holds other elements, fits.
+func (l *splitLimit) fits(size int) bool {
+	return !l.full && size <= l.left
+}
+
+// fitsItem returns true if an item of the given size fits, the first item always fitting.
+// Once an item does not fit, the destination is full, so that only the first items are moved.
+func (l *splitLimit) fitsItem(size int) bool {
+	if l.full {
+		return false
+	}
+	if size <= l.left || !l.moved {
+		return true
+	}
+	l.full = true
+	return false
+}
+
+// take takes the room used by an element moved or copied to the destination.
+func (l *splitLimit) take(size int) {
+	l.left -= size
+	l.moved = true
+}
+
+// tracesSizer computes the size of the elements of a Traces, and of their header, without their repeated elements.
+type tracesSizer interface {
+	resourceSpans(*otlptrace.ResourceSpans) int
+	resourceSpansHeader(*otlptrace.ResourceSpans) int
+	scopeSpans(*otlptrace.ScopeSpans) int
+	scopeSpansHeader(*otlptrace.ScopeSpans) int
+	span(*otlptrace.Span) int
+}
+
+type tracesCountSizer struct{}
+
+func (tracesCountSizer) resourceSpans(rs *otlptrace.ResourceSpans) int {
+	count := 0
+	for _, ss := range rs.ScopeSpans {
+		count += len(ss.Spans)
+	}
+	return count
+}
+
+func (tracesCountSizer) resourceSpansHeader(*otlptrace.ResourceSpans) int {
+	return 0
+}
+
+func (tracesCountSizer) scopeSpans(ss *otlptrace.ScopeSpans) int {
+	return len(ss.Spans)
+}
+
+func (tracesCountSizer) scopeSpansHeader(*otlptrace.ScopeSpans) int {
+	return 0
+}
+
+func (tracesCountSizer) span(*otlptrace.Span) int {
+	return 1
+}
+
+type tracesBytesSizer struct{}
+
+func (tracesBytesSizer) resourceSpans(rs *otlptrace.ResourceSpans) int {
+	return fieldSize(rs.Size())
+}
+
+func (tracesBytesSizer) resourceSpansHeader(rs *otlptrace.ResourceSpans) int {
+	return headerSize((&otlptrace.ResourceSpans{Resource: rs.Resource, SchemaUrl: rs.SchemaUrl}).Size())
+}
+
+func (tracesBytesSizer) scopeSpans(ss *otlptrace.ScopeSpans) int {
+	return fieldSize(ss.Size())
+}
+
+func (tracesBytesSizer) scopeSpansHeader(ss *otlptrace.ScopeSpans) int {
+	return headerSize((&otlptrace.ScopeSpans{Scope: ss.Scope, SchemaUrl: ss.SchemaUrl}).Size())
+}
+
+func (tracesBytesSizer) span(span *otlptrace.Span) int {
+	return fieldSize(span.Size())
+}
+
+// Split moves the first n spans of td, or all of them if td holds n spans or less, to a new Traces
+// that it returns, grouped by resource and scope as in td. At least one span is moved, if td holds any.
+func (td Traces) Split(n int) Traces {
+	return td.split(n, tracesCountSizer{})
+}
+
+// SplitBySize is like Split, but moves the first spans whose protobuf encoding, with their resource
+// and scope, takes at most size bytes.
+func (td Traces) SplitBySize(size int) Traces {
+	return td.split(size, tracesBytesSizer{})
+}
+
+func (td Traces) split(limit int, sizer tracesSizer) Traces {
+	dest := NewTraces()
+	l := splitLimit{left: limit}
+	td.ResourceSpans().RemoveIf(func(srcRs ResourceSpans) bool {
+		if size := sizer.resourceSpans(srcRs.getOrig()); l.fits(size) {
+			l.take(size)
+			srcRs.MoveTo(dest.ResourceSpans().AppendEmpty())
+			return true
+		}
+		// The resource is copied with the first element moved.
+		var destRs ResourceSpans
+		rsHeader := sizer.resourceSpansHeader(srcRs.getOrig())
+		pendingRs := func() int {
+			if destRs.orig == nil {
+				return rsHeader
+			}
+			return 0
+		}
+		ensureRs := func() {
+			if destRs.orig == nil {
+				destRs = dest.ResourceSpans().AppendEmpty()
+				srcRs.Resource().CopyTo(destRs.Resource())
+				destRs.SetSchemaUrl(srcRs.SchemaUrl())
+			}
+		}
+		srcRs.ScopeSpans().RemoveIf(func(srcSs ScopeSpans) bool {
+			if size := pendingRs() + sizer.scopeSpans(srcSs.getOrig()); l.fits(size) {
+				l.take(size)
+				ensureRs()
+				srcSs.MoveTo(destRs.ScopeSpans().AppendEmpty())
+				return true
+			}
+			var destSs ScopeSpans
+			ssHeader := sizer.scopeSpansHeader(srcSs.getOrig())
+			srcSs.Spans().RemoveIf(func(srcSpan Span) bool {
+				size := sizer.span(srcSpan.getOrig())
+				if destSs.orig == nil {
+					size += pendingRs() + ssHeader
+				}
+				if !l.fitsItem(size) {
+					return false
+				}
+				l.take(size)
+				if destSs.orig == nil {
+					ensureRs()
+					destSs = destRs.ScopeSpans().AppendEmpty()
+					srcSs.Scope().CopyTo(destSs.Scope())
+					destSs.SetSchemaUrl(srcSs.SchemaUrl())
+				}
+				srcSpan.MoveTo(destSs.Spans().AppendEmpty())
+				return true
+			})
+			return destSs.orig != nil && srcSs.Spans().Len() == 0
+		})
+		return destRs.orig != nil && srcRs.ScopeSpans().Len() == 0
+	})
+	return dest
+}
+
+// logsSizer computes the size of the elements of a Logs, and of their header, without their repeated elements.
+type logsSizer interface {
+	resourceLogs(*otlplogs.ResourceLogs) int
+	resourceLogsHeader(*otlplogs.ResourceLogs) int
+	scopeLogs(*otlplogs.ScopeLogs) int
+	scopeLogsHeader(*otlplogs.ScopeLogs) int
+	logRecord(*otlplogs.LogRecord) int
+}
+
+type logsCountSizer struct{}
+
+func (logsCountSizer) resourceLogs(rl *otlplogs.ResourceLogs) int {
+	count := 0
+	for _, sl := range rl.ScopeLogs {
+		count += len(sl.LogRecords)
+	}
+	return count
+}
+
+func (logsCountSizer) resourceLogsHeader(*otlplogs.ResourceLogs) int {
+	return 0
+}
+
+func (logsCountSizer) scopeLogs(sl *otlplogs.ScopeLogs) int {
+	return len(sl.LogRecords)
+}
+
+func (logsCountSizer) scopeLogsHeader(*otlplogs.ScopeLogs) int {
+	return 0
+}
+
+func (logsCountSizer) logRecord(*otlplogs.LogRecord) int {
+	return 1
+}
+
+type logsBytesSizer struct{}
+
+func (logsBytesSizer) resourceLogs(rl *otlplogs.ResourceLogs) int {
+	return fieldSize(rl.Size())
+}
+
+func (logsBytesSizer) resourceLogsHeader(rl *otlplogs.ResourceLogs) int {
+	return headerSize((&otlplogs.ResourceLogs{Resource: rl.Resource, SchemaUrl: rl.SchemaUrl}).Size())
+}
+
+func (logsBytesSizer) scopeLogs(sl *otlplogs.ScopeLogs) int {
+	return fieldSize(sl.Size())
+}
+
+func (logsBytesSizer) scopeLogsHeader(sl *otlplogs.ScopeLogs) int {
+	return headerSize((&otlplogs.ScopeLogs{Scope: sl.Scope, SchemaUrl: sl.SchemaUrl}).Size())
+}
+
+func (logsBytesSizer) logRecord(lr *otlplogs.LogRecord) int {
+	return fieldSize(lr.Size())
+}
+
+// Split moves the first n log records of ld, or all of them if ld holds n log records or less, to a new Logs
+// that it returns, grouped by resource and scope as in ld. At least one log record is moved, if ld holds any.
+func (ld Logs) Split(n int) Logs {
+	return ld.split(n, logsCountSizer{})
+}
+
+// SplitBySize is like Split, but moves the first log records whose protobuf encoding, with their resource
+// and scope, takes at most size bytes.
+func (ld Logs) SplitBySize(size int) Logs {
+	return ld.split(size, logsBytesSizer{})
+}
+
+func (ld Logs) split(limit int, sizer logsSizer) Logs {
+	dest := NewLogs()
+	l := splitLimit{left: limit}
+	ld.ResourceLogs().RemoveIf(func(srcRl ResourceLogs) bool {
+		if size := sizer.resourceLogs(srcRl.getOrig()); l.fits(size) {
+			l.take(size)
+			srcRl.MoveTo(dest.ResourceLogs().AppendEmpty())
+			return true
+		}
+		// The resource is copied with the first element moved.
+		var destRl ResourceLogs
+		rlHeader := sizer.resourceLogsHeader(srcRl.getOrig())
+		pendingRl := func() int {
+			if destRl.orig == nil {
+				return rlHeader
+			}
+			return 0
+		}
+		ensureRl := func() {
+			if destRl.orig == nil {
+				destRl = dest.ResourceLogs().AppendEmpty()
+				srcRl.Resource().CopyTo(destRl.Resource())
+				destRl.SetSchemaUrl(srcRl.SchemaUrl())
+			}
+		}
+		srcRl.ScopeLogs().RemoveIf(func(srcSl ScopeLogs) bool {
+			if size := pendingRl() + sizer.scopeLogs(srcSl.getOrig()); l.fits(size) {
+				l.take(size)
+				ensureRl()
+				srcSl.MoveTo(destRl.ScopeLogs().AppendEmpty())
+				return true
+			}
+			var destSl ScopeLogs
+			slHeader := sizer.scopeLogsHeader(srcSl.getOrig())
+			srcSl.LogRecords().RemoveIf(func(srcLr LogRecord) bool {
+				size := sizer.logRecord(srcLr.getOrig())
+				if destSl.orig == nil {
+					size += pendingRl() + slHeader
+				}
+				if !l.fitsItem(size) {
+					return false
+				}
+				l.take(size)
+				if destSl.orig == nil {
+					ensureRl()
+					destSl = destRl.ScopeLogs().AppendEmpty()
+					srcSl.Scope().CopyTo(destSl.Scope())
+					destSl.SetSchemaUrl(srcSl.SchemaUrl())
+				}
+				srcLr.MoveTo(destSl.LogRecords().AppendEmpty())
+				return true
+			})
+			return destSl.orig != nil && srcSl.LogRecords().Len() == 0
+		})
+		return destRl.orig != nil && srcRl.ScopeLogs().Len() == 0
+	})
+	return dest
+}
+
+// metricsSizer computes the size of the elements of a Metrics, and of their header, without their repeated elements.
+type metricsSizer interface {
+	resourceMetrics(*otlpmetrics.ResourceMetrics) int
+	resourceMetricsHeader(*otlpmetrics.ResourceMetrics) int
+	scopeMetrics(*otlpmetrics.ScopeMetrics) int
+	scopeMetricsHeader(*otlpmetrics.ScopeMetrics) int
+	metric(*otlpmetrics.Metric) int
+	metricHeader(*otlpmetrics.Metric) int
+	dataPoint(dataPointOrig) int
+}
+
+// dataPointOrig is implemented by the protobuf messages of the data points.
+type dataPointOrig interface {
+	Size() int
+}
+
+type metricsCountSizer struct{}
+
+func (s metricsCountSizer) resourceMetrics(rm *otlpmetrics.ResourceMetrics) int {
+	count := 0
+	for _, sm := range rm.ScopeMetrics {
+		count += s.scopeMetrics(sm)
+	}
+	return count
+}
+
+func (metricsCountSizer) resourceMetricsHeader(*otlpmetrics.ResourceMetrics) int {
+	return 0
+}
+
+func (s metricsCountSizer) scopeMetrics(sm *otlpmetrics.ScopeMetrics) int {
+	count := 0
+	for _, m := range sm.Metrics {
+		count += s.metric(m)
+	}
+	return count
+}
+
+func (metricsCountSizer) scopeMetricsHeader(*otlpmetrics.ScopeMetrics) int {
+	return 0
+}
+
+func (metricsCountSizer) metric(m *otlpmetrics.Metric) int {
+	return metricDataPointCount(m)
+}
+
+func (metricsCountSizer) metricHeader(*otlpmetrics.Metric) int {
+	return 0
+}
+
+func (metricsCountSizer) dataPoint(dataPointOrig) int {
+	return 1
+}
+
+func metricDataPointCount(m *otlpmetrics.Metric) int {
+	switch data := m.Data.(type) {
+	case *otlpmetrics.Metric_Gauge:
+		return len(data.Gauge.DataPoints)
+	case *otlpmetrics.Metric_Sum:
+		return len(data.Sum.DataPoints)
+	case *otlpmetrics.Metric_Histogram:
+		return len(data.Histogram.DataPoints)
+	case *otlpmetrics.Metric_ExponentialHistogram:
+		return len(data.ExponentialHistogram.DataPoints)
+	case *otlpmetrics.Metric_Summary:
+		return len(data.Summary.DataPoints)
+	}
+	return 0
+}
+
+type metricsBytesSizer struct{}
+
+func (metricsBytesSizer) resourceMetrics(rm *otlpmetrics.ResourceMetrics) int {
+	return fieldSize(rm.Size())
+}
+
+func (metricsBytesSizer) resourceMetricsHeader(rm *otlpmetrics.ResourceMetrics) int {
+	return headerSize((&otlpmetrics.ResourceMetrics{Resource: rm.Resource, SchemaUrl: rm.SchemaUrl}).Size())
+}
+
+func (metricsBytesSizer) scopeMetrics(sm *otlpmetrics.ScopeMetrics) int {
+	return fieldSize(sm.Size())
+}
+
+func (metricsBytesSizer) scopeMetricsHeader(sm *otlpmetrics.ScopeMetrics) int {
+	return headerSize((&otlpmetrics.ScopeMetrics{Scope: sm.Scope, SchemaUrl: sm.SchemaUrl}).Size())
+}
+
+func (metricsBytesSizer) metric(m *otlpmetrics.Metric) int {
+	return fieldSize(m.Size())
+}
+
+func (metricsBytesSizer) metricHeader(m *otlpmetrics.Metric) int {
+	// The size of the encoded length of the data grows with the data points too.
+	return headerSize(metricHeader(m).Size()) + maxLenSize
+}
+
+func (metricsBytesSizer) dataPoint(dp dataPointOrig) int {
+	return fieldSize(dp.Size())
+}
+
+// metricHeader returns a copy of m without its data points.
+func metricHeader(m *otlpmetrics.Metric) *otlpmetrics.Metric {
+	header := &otlpmetrics.Metric{Name: m.Name, Description: m.Description, Unit: m.Unit}
+	switch data := m.Data.(type) {
+	case *otlpmetrics.Metric_Gauge:
+		header.Data = &otlpmetrics.Metric_Gauge{Gauge: &otlpmetrics.Gauge{}}
+	case *otlpmetrics.Metric_Sum:
+		header.Data = &otlpmetrics.Metric_Sum{Sum: &otlpmetrics.Sum{
+			AggregationTemporality: data.Sum.AggregationTemporality,
+			IsMonotonic:            data.Sum.IsMonotonic,
+		}}
+	case *otlpmetrics.Metric_Histogram:
+		header.Data = &otlpmetrics.Metric_Histogram{Histogram: &otlpmetrics.Histogram{
+			AggregationTemporality: data.Histogram.AggregationTemporality,
+		}}
+	case *otlpmetrics.Metric_ExponentialHistogram:
+		header.Data = &otlpmetrics.Metric_ExponentialHistogram{ExponentialHistogram: &otlpmetrics.ExponentialHistogram{
+			AggregationTemporality: data.ExponentialHistogram.AggregationTemporality,
+		}}
+	case *otlpmetrics.Metric_Summary:
+		header.Data = &otlpmetrics.Metric_Summary{Summary: &otlpmetrics.Summary{}}
+	}
+	return header
+}
+
+// Split moves the first n data points of md, or all of them if md holds n data points or less, to a new
+// Metrics that it returns, grouped by resource, scope and metric as in md. At least one data point is moved,
+// if md holds any.
+func (md Metrics) Split(n int) Metrics {
+	return md.split(n, metricsCountSizer{})
+}
+
+// SplitBySize is like Split, but moves the first data points whose protobuf encoding, with their resource,
+// scope and metric, takes at most size bytes.
+func (md Metrics) SplitBySize(size int) Metrics {
+	return md.split(size, metricsBytesSizer{})
+}
+
+func (md Metrics) split(limit int, sizer metricsSizer) Metrics {
+	dest := NewMetrics()
+	l := splitLimit{left: limit}
+	md.ResourceMetrics().RemoveIf(func(srcRm ResourceMetrics) bool {
+		if size := sizer.resourceMetrics(srcRm.getOrig()); l.fits(size) {
+			l.take(size)
+			srcRm.MoveTo(dest.ResourceMetrics().AppendEmpty())
+			return true
+		}
+		// The resource is copied with the first element moved.
+		var destRm ResourceMetrics
+		rmHeader := sizer.resourceMetricsHeader(srcRm.getOrig())
+		pendingRm := func() int {
+			if destRm.orig == nil {
+				return rmHeader
+			}
+			return 0
+		}
+		ensureRm := func() {
+			if destRm.orig == nil {
+				destRm = dest.ResourceMetrics().AppendEmpty()
+				srcRm.Resource().CopyTo(destRm.Resource())
+				destRm.SetSchemaUrl(srcRm.SchemaUrl())
+			}
+		}
+		srcRm.ScopeMetrics().RemoveIf(func(srcSm ScopeMetrics) bool {
+			if size := pendingRm() + sizer.scopeMetrics(srcSm.getOrig()); l.fits(size) {
+				l.take(size)
+				ensureRm()
+				srcSm.MoveTo(destRm.ScopeMetrics().AppendEmpty())
+				return true
+			}
+			// The scope is copied with the first element moved.
+			var destSm ScopeMetrics
+			smHeader := sizer.scopeMetricsHeader(srcSm.getOrig())
+			pendingSm := func() int {
+				if destSm.orig == nil {
+					return pendingRm() + smHeader
+				}
+				return 0
+			}
+			ensureSm := func() {
+				if destSm.orig == nil {
+					ensureRm()
+					destSm = destRm.ScopeMetrics().AppendEmpty()
+					srcSm.Scope().CopyTo(destSm.Scope())
+					destSm.SetSchemaUrl(srcSm.SchemaUrl())
+				}
+			}
+			srcSm.Metrics().RemoveIf(func(srcM Metric) bool {
+				if size := pendingSm() + sizer.metric(srcM.getOrig()); l.fits(size) {
+					l.take(size)
+					ensureSm()
+					srcM.MoveTo(destSm.Metrics().AppendEmpty())
+					return true
+				}
+				var destM Metric
+				mHeader := sizer.metricHeader(srcM.getOrig())
+				moveDataPoint := func(dp dataPointOrig, move func(dest Metric)) bool {
+					size := sizer.dataPoint(dp)
+					if destM.orig == nil {
+						size += pendingSm() + mHeader
+					}
+					if !l.fitsItem(size) {
+						return false
+					}
+					l.take(size)
+					if destM.orig == nil {
+						ensureSm()
+						destM = destSm.Metrics().AppendEmpty()
+						*destM.getMutableOrig() = *metricHeader(srcM.getOrig())
+					}
+					move(destM)
+					return true
+				}
+				switch srcM.DataType() {
+				case MetricDataTypeGauge:
+					srcM.Gauge().DataPoints().RemoveIf(func(dp NumberDataPoint) bool {
+						return moveDataPoint(dp.getOrig(), func(dest Metric) { dp.MoveTo(dest.Gauge().DataPoints().AppendEmpty()) })
+					})
+				case MetricDataTypeSum:
+					srcM.Sum().DataPoints().RemoveIf(func(dp NumberDataPoint) bool {
+						return moveDataPoint(dp.getOrig(), func(dest Metric) { dp.MoveTo(dest.Sum().DataPoints().AppendEmpty()) })
+					})
+				case MetricDataTypeHistogram:
+					srcM.Histogram().DataPoints().RemoveIf(func(dp HistogramDataPoint) bool {
+						return moveDataPoint(dp.getOrig(), func(dest Metric) { dp.MoveTo(dest.Histogram().DataPoints().AppendEmpty()) })
+					})
+				case MetricDataTypeExponentialHistogram:
+					srcM.ExponentialHistogram().DataPoints().RemoveIf(func(dp ExponentialHistogramDataPoint) bool {
+						return moveDataPoint(dp.getOrig(), func(dest Metric) { dp.MoveTo(dest.ExponentialHistogram().DataPoints().AppendEmpty()) })
+					})
+				case MetricDataTypeSummary:
+					srcM.Summary().DataPoints().RemoveIf(func(dp SummaryDataPoint) bool {
+						return moveDataPoint(dp.getOrig(), func(dest Metric) { dp.MoveTo(dest.Summary().DataPoints().AppendEmpty()) })
+					})
+				}
+				return destM.orig != nil && metricDataPointCount(srcM.getOrig()) == 0
+			})
+			return destSm.orig != nil && srcSm.Metrics().Len() == 0
+		})
+		return destRm.orig != nil && srcRm.ScopeMetrics().Len() == 0
+	})
+	return dest
+}
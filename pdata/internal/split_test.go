@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func generateSplitTraces() Traces {
+	td := NewTraces()
+	for i := 0; i < 3; i++ {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.SetSchemaUrl("schema")
+		rs.Resource().Attributes().InsertInt("resource", int64(i))
+		for j := 0; j < 2; j++ {
+			ss := rs.ScopeSpans().AppendEmpty()
+			ss.Scope().SetName("scope" + strconv.Itoa(j))
+			for k := 0; k < 5; k++ {
+				ss.Spans().AppendEmpty().SetName(strconv.Itoa(i) + "-" + strconv.Itoa(j) + "-" + strconv.Itoa(k))
+			}
+		}
+	}
+	return td
+}
+
+func TestTracesSplit(t *testing.T) {
+	td := generateSplitTraces()
+	expected := td.Clone()
+
+	split := td.Split(7)
+	assert.Equal(t, 7, split.SpanCount())
+	assert.Equal(t, 23, td.SpanCount())
+	assert.Equal(t, 1, split.ResourceSpans().Len())
+	assert.Equal(t, 2, split.ResourceSpans().At(0).ScopeSpans().Len())
+	assert.Equal(t, "scope1", split.ResourceSpans().At(0).ScopeSpans().At(1).Scope().Name())
+	assert.Equal(t, "schema", td.ResourceSpans().At(0).SchemaUrl())
+	assert.Equal(t, "0-1-2", td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+
+	// Splitting in parts of any size and merging them back gives the original data.
+	merged := split
+	for td.SpanCount() > 0 {
+		merged.Merge(td.Split(4))
+	}
+	assert.Equal(t, 0, td.ResourceSpans().Len())
+	assert.Empty(t, expected.Diff(merged))
+
+	// At least one span is moved.
+	td = generateSplitTraces()
+	assert.Equal(t, 1, td.Split(0).SpanCount())
+	assert.Equal(t, td.SpanCount(), td.Split(100).SpanCount())
+	assert.Equal(t, 0, td.ResourceSpans().Len())
+}
+
+func TestTracesSplitBySize(t *testing.T) {
+	td := generateSplitTraces()
+	expected := td.Clone()
+	limit := expected.orig.Size() / 4
+	merged := NewTraces()
+	for td.ResourceSpans().Len() > 0 {
+		split := td.SplitBySize(limit)
+		assert.LessOrEqual(t, split.orig.Size(), limit)
+		assert.Greater(t, split.SpanCount(), 0)
+		merged.Merge(split)
+	}
+	assert.Empty(t, expected.Diff(merged))
+
+	// At least one span is moved.
+	td = generateSplitTraces()
+	split := td.SplitBySize(1)
+	assert.Equal(t, 1, split.SpanCount())
+	assert.Equal(t, "0-0-0", split.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func generateSplitLogs() Logs {
+	ld := NewLogs()
+	for i := 0; i < 3; i++ {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().InsertInt("resource", int64(i))
+		for j := 0; j < 2; j++ {
+			sl := rl.ScopeLogs().AppendEmpty()
+			sl.Scope().SetName("scope" + strconv.Itoa(j))
+			for k := 0; k < 5; k++ {
+				sl.LogRecords().AppendEmpty().Body().SetStringVal(strconv.Itoa(i) + "-" + strconv.Itoa(j) + "-" + strconv.Itoa(k))
+			}
+		}
+	}
+	return ld
+}
+
+func TestLogsSplit(t *testing.T) {
+	ld := generateSplitLogs()
+	expected := ld.Clone()
+	split := ld.Split(12)
+	assert.Equal(t, 12, split.LogRecordCount())
+	assert.Equal(t, 2, split.ResourceLogs().Len())
+	assert.Equal(t, 18, ld.LogRecordCount())
+
+	merged := split
+	for ld.LogRecordCount() > 0 {
+		merged.Merge(ld.Split(5))
+	}
+	assert.Empty(t, expected.Diff(merged))
+
+	ld = expected.Clone()
+	limit := expected.orig.Size() / 3
+	merged = NewLogs()
+	for ld.ResourceLogs().Len() > 0 {
+		split := ld.SplitBySize(limit)
+		assert.LessOrEqual(t, split.orig.Size(), limit)
+		merged.Merge(split)
+	}
+	assert.Empty(t, expected.Diff(merged))
+}
+
+func generateSplitMetrics() Metrics {
+	md := NewMetrics()
+	for i := 0; i < 2; i++ {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().InsertInt("resource", int64(i))
+		ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+		for _, ty := range []MetricDataType{MetricDataTypeGauge, MetricDataTypeSum, MetricDataTypeHistogram, MetricDataTypeExponentialHistogram, MetricDataTypeSummary} {
+			m := ms.AppendEmpty()
+			m.SetName(ty.String())
+			m.SetUnit("1")
+			m.SetDataType(ty)
+			for k := 0; k < 4; k++ {
+				switch ty {
+				case MetricDataTypeGauge:
+					m.Gauge().DataPoints().AppendEmpty().SetIntVal(int64(k))
+				case MetricDataTypeSum:
+					m.Sum().SetAggregationTemporality(MetricAggregationTemporalityCumulative)
+					m.Sum().SetIsMonotonic(true)
+					m.Sum().DataPoints().AppendEmpty().SetIntVal(int64(k))
+				case MetricDataTypeHistogram:
+					m.Histogram().SetAggregationTemporality(MetricAggregationTemporalityDelta)
+					m.Histogram().DataPoints().AppendEmpty().SetCount(uint64(k))
+				case MetricDataTypeExponentialHistogram:
+					m.ExponentialHistogram().SetAggregationTemporality(MetricAggregationTemporalityDelta)
+					m.ExponentialHistogram().DataPoints().AppendEmpty().SetCount(uint64(k))
+				case MetricDataTypeSummary:
+					m.Summary().DataPoints().AppendEmpty().SetCount(uint64(k))
+				}
+			}
+		}
+		ms.AppendEmpty().SetName("none")
+	}
+	return md
+}
+
+func TestMetricsSplit(t *testing.T) {
+	md := generateSplitMetrics()
+	expected := md.Clone()
+
+	split := md.Split(6)
+	assert.Equal(t, 6, split.DataPointCount())
+	assert.Equal(t, 2, split.MetricCount())
+	sum := split.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(1)
+	assert.Equal(t, "Sum", sum.Name())
+	assert.Equal(t, "1", sum.Unit())
+	assert.Equal(t, MetricAggregationTemporalityCumulative, sum.Sum().AggregationTemporality())
+	assert.True(t, sum.Sum().IsMonotonic())
+	assert.Equal(t, 2, sum.Sum().DataPoints().Len())
+	assert.Equal(t, int64(2), md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).IntVal())
+
+	merged := split
+	for md.ResourceMetrics().Len() > 0 {
+		merged.Merge(md.Split(3))
+	}
+	assert.Empty(t, expected.Diff(merged))
+
+	md = expected.Clone()
+	limit := expected.orig.Size() / 5
+	merged = NewMetrics()
+	for md.ResourceMetrics().Len() > 0 {
+		split := md.SplitBySize(limit)
+		assert.LessOrEqual(t, split.orig.Size(), limit)
+		merged.Merge(split)
+	}
+	assert.Empty(t, expected.Diff(merged))
+}
@@ -17,30 +17,50 @@ package internal // import "go.opentelemetry.io/collector/pdata/internal"
 import (
 	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
 	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+	"go.opentelemetry.io/collector/pdata/internal/otlpwire"
 )
 
 // TracesToOtlp internal helper to convert Traces to otlp request representation.
+// The resources shared with other Traces are copied first, the returned request owns all its content.
 func TracesToOtlp(mw Traces) *otlpcollectortrace.ExportTraceServiceRequest {
-	return mw.orig
+	orig := mw.getOrig()
+	mw.shared.unshareAll(orig.ResourceSpans)
+	return orig
 }
 
 // TracesFromOtlp internal helper to convert otlp request representation to Traces.
 func TracesFromOtlp(orig *otlpcollectortrace.ExportTraceServiceRequest) Traces {
-	return Traces{orig: orig}
+	return Traces{orig: orig, shared: &SharedResourceSpans{}}
+}
+
+// TracesToEncodedOtlp internal helper to get the otlp request representation of Traces,
+// its encoded bytes, if not decoded yet, and its shared resources, without decoding or copying them.
+func TracesToEncodedOtlp(mw Traces) (*otlpcollectortrace.ExportTraceServiceRequest, *EncodedOrig, *SharedResourceSpans) {
+	return mw.orig, mw.encoded, mw.shared
+}
+
+// TracesFromEncodedOtlp internal helper to convert an otlp request representation,
+// whose content may still be held encoded by encoded and whose resources may be shared, to Traces.
+func TracesFromEncodedOtlp(orig *otlpcollectortrace.ExportTraceServiceRequest, encoded *EncodedOrig, shared *SharedResourceSpans) Traces {
+	if !encoded.isPending() {
+		return Traces{orig: orig, shared: shared}
+	}
+	return Traces{orig: orig, encoded: encoded, shared: shared}
 }
 
 // TracesToProto internal helper to convert Traces to protobuf representation.
 func TracesToProto(mw Traces) otlptrace.TracesData {
 	return otlptrace.TracesData{
-		ResourceSpans: mw.orig.ResourceSpans,
+		ResourceSpans: mw.getOrig().ResourceSpans,
 	}
 }
 
 // TracesFromProto internal helper to convert protobuf representation to Traces.
 func TracesFromProto(orig otlptrace.TracesData) Traces {
-	return Traces{orig: &otlpcollectortrace.ExportTraceServiceRequest{
-		ResourceSpans: orig.ResourceSpans,
-	}}
+	return Traces{
+		orig:   &otlpcollectortrace.ExportTraceServiceRequest{ResourceSpans: orig.ResourceSpans},
+		shared: &SharedResourceSpans{},
+	}
 }
 
 // Traces is the top-level struct that is propagated through the traces pipeline.
@@ -48,29 +68,110 @@ func TracesFromProto(orig otlptrace.TracesData) Traces {
 type Traces struct {
 	// When marhsal/unmarshal unless it is in the request for otlp protocol, convert to otlptrace.TracesData.
 	orig *otlpcollectortrace.ExportTraceServiceRequest
+	// encoded, if not nil, may hold the encoded orig until it is accessed.
+	encoded *EncodedOrig
+	// shared, if not nil, tracks the resources shared with other Traces.
+	shared *SharedResourceSpans
+}
+
+// getOrig returns orig, decoding it first if needed.
+func (td Traces) getOrig() *otlpcollectortrace.ExportTraceServiceRequest {
+	if td.encoded.isPending() {
+		td.encoded.decode(td.orig.Unmarshal)
+	}
+	return td.orig
 }
 
 // NewTraces creates a new Traces struct.
 func NewTraces() Traces {
-	return Traces{orig: &otlpcollectortrace.ExportTraceServiceRequest{}}
+	return Traces{orig: &otlpcollectortrace.ExportTraceServiceRequest{}, shared: &SharedResourceSpans{}}
 }
 
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value.
 func (td Traces) MoveTo(dest Traces) {
-	*dest.orig = *td.orig
+	dest.encoded.Discard()
+	orig := td.getOrig()
+	dest.shared.drop(dest.orig.ResourceSpans)
+	td.shared.moveTo(dest.shared, orig.ResourceSpans)
+	*dest.orig = *orig
 	*td.orig = otlpcollectortrace.ExportTraceServiceRequest{}
 }
 
-// Clone returns a copy of Traces.
+// Clone returns a copy of Traces, reusing the memory of a released Traces if any.
 func (td Traces) Clone() Traces {
-	cloneTd := NewTraces()
+	if encoded, ok := td.encoded.clone(); ok {
+		return Traces{orig: &otlpcollectortrace.ExportTraceServiceRequest{}, encoded: encoded, shared: &SharedResourceSpans{}}
+	}
+	cloneTd := newTracesFromPool()
 	td.ResourceSpans().CopyTo(cloneTd.ResourceSpans())
 	return cloneTd
 }
 
+// Share returns a copy of Traces sharing the content of the resources of td instead of copying it.
+// A shared resource is copied the first time it is modified from the returned Traces, so that
+// it does not affect td. Reading the returned Traces, including marshaling, counting the items
+// or cloning it, does not copy nor modify anything, so that both Traces can be read concurrently.
+//
+// td itself is not tracked: it must not be modified while the returned Traces is in use.
+func (td Traces) Share() Traces {
+	if encoded, ok := td.encoded.clone(); ok {
+		return Traces{orig: &otlpcollectortrace.ExportTraceServiceRequest{}, encoded: encoded, shared: &SharedResourceSpans{}}
+	}
+	rss, shared := shareResourceSpans(td.getOrig().ResourceSpans)
+	return Traces{
+		orig:   &otlpcollectortrace.ExportTraceServiceRequest{ResourceSpans: rss},
+		shared: shared,
+	}
+}
+
+// Equal returns true if td and other hold the same content, in the same order.
+func (td Traces) Equal(other Traces) bool {
+	return equalOrigs(td.getOrig(), other.getOrig())
+}
+
+// EqualIgnoringOrder returns true if td and other hold the same content, regardless of the order
+// of the attributes and of the repeated elements. The order of the values of array attributes matters.
+func (td Traces) EqualIgnoringOrder(other Traces) bool {
+	a, b := td.canonicalClone(), other.canonicalClone()
+	defer a.Release()
+	defer b.Release()
+	return equalOrigs(a.orig, b.orig)
+}
+
+// Diff returns the differences between td and other, in the same order, one per line formatted
+// as "path: value in td != value in other", or an empty string if they are equal.
+func (td Traces) Diff(other Traces) string {
+	return diffOrigs(td.getOrig(), other.getOrig())
+}
+
+// DiffIgnoringOrder is like Diff, but regardless of the order as for EqualIgnoringOrder. The elements
+// are compared after sorting them, so that the paths of the differences may not match their position.
+func (td Traces) DiffIgnoringOrder(other Traces) string {
+	a, b := td.canonicalClone(), other.canonicalClone()
+	defer a.Release()
+	defer b.Release()
+	return diffOrigs(a.orig, b.orig)
+}
+
+// canonicalClone returns a decoded copy of td whose repeated elements are sorted in a canonical order.
+func (td Traces) canonicalClone() Traces {
+	clone := td.Clone()
+	canonicalizeOrig(clone.getOrig())
+	return clone
+}
+
+// IsShared returns true if td holds resources obtained from Share whose content is still shared
+// with other Traces, that are copied the first time they are modified.
+func (td Traces) IsShared() bool {
+	return td.shared.len() > 0
+}
+
 // SpanCount calculates the total number of spans.
 func (td Traces) SpanCount() int {
+	if buf, ok := td.encoded.Bytes(); ok {
+		return otlpwire.SpanCount(buf)
+	}
 	spanCount := 0
 	rss := td.ResourceSpans()
 	for i := 0; i < rss.Len(); i++ {
@@ -85,7 +186,7 @@ func (td Traces) SpanCount() int {
 
 // ResourceSpans returns the ResourceSpansSlice associated with this Metrics.
 func (td Traces) ResourceSpans() ResourceSpansSlice {
-	return newResourceSpansSlice(&td.orig.ResourceSpans)
+	return ResourceSpansSlice{orig: &td.getOrig().ResourceSpans, sharedElements: td.shared}
 }
 
 // TraceState is a string representing the tracestate in w3c-trace-context format: https://www.w3.org/TR/trace-context/#tracestate-header
@@ -122,7 +122,7 @@ func TestTracesMoveTo(t *testing.T) {
 	dest := NewTraces()
 	traces.MoveTo(dest)
 	assert.EqualValues(t, NewTraces(), traces)
-	assert.EqualValues(t, generateTestResourceSpansSlice(), dest.ResourceSpans())
+	assert.EqualValues(t, generateTestResourceSpansSlice().orig, dest.ResourceSpans().orig)
 }
 
 func TestTracesClone(t *testing.T) {
@@ -15,53 +15,205 @@
 package plog // import "go.opentelemetry.io/collector/pdata/plog"
 
 import (
-	"bytes"
-
-	"github.com/gogo/protobuf/jsonpb"
-
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	"go.opentelemetry.io/collector/pdata/internal/json"
 	"go.opentelemetry.io/collector/pdata/internal/otlp"
 )
 
-// NewJSONMarshaler returns a Marshaler. Marshals to OTLP json bytes.
+// NewJSONMarshaler returns a model.Marshaler. Marshals to OTLP json bytes.
 func NewJSONMarshaler() Marshaler {
 	return newJSONMarshaler()
 }
 
-type jsonMarshaler struct {
-	delegate jsonpb.Marshaler
-}
+type jsonMarshaler struct{}
 
 func newJSONMarshaler() *jsonMarshaler {
-	return &jsonMarshaler{delegate: jsonpb.Marshaler{}}
+	return &jsonMarshaler{}
 }
 
 func (e *jsonMarshaler) MarshalLogs(ld Logs) ([]byte, error) {
-	buf := bytes.Buffer{}
 	pb := internal.LogsToProto(ld)
-	err := e.delegate.Marshal(&buf, &pb)
-	return buf.Bytes(), err
+	s := json.NewStream(256 * ld.LogRecordCount())
+	s.WriteObjectStart()
+	if len(pb.ResourceLogs) > 0 {
+		s.WriteObjectField("resourceLogs")
+		s.WriteArrayStart()
+		for _, rl := range pb.ResourceLogs {
+			s.WriteArrayElement()
+			writeResourceLogs(s, rl)
+		}
+		s.WriteArrayEnd()
+	}
+	s.WriteObjectEnd()
+	return s.Buffer(), nil
+}
+
+func writeResourceLogs(s *json.Stream, rl *otlplogs.ResourceLogs) {
+	s.WriteObjectStart()
+	json.WriteResource(s, &rl.Resource)
+	if len(rl.ScopeLogs) > 0 {
+		s.WriteObjectField("scopeLogs")
+		s.WriteArrayStart()
+		for _, sl := range rl.ScopeLogs {
+			s.WriteArrayElement()
+			writeScopeLogs(s, sl)
+		}
+		s.WriteArrayEnd()
+	}
+	s.WriteStringField("schemaUrl", rl.SchemaUrl)
+	s.WriteObjectEnd()
+}
+
+func writeScopeLogs(s *json.Stream, sl *otlplogs.ScopeLogs) {
+	s.WriteObjectStart()
+	json.WriteScope(s, &sl.Scope)
+	if len(sl.LogRecords) > 0 {
+		s.WriteObjectField("logRecords")
+		s.WriteArrayStart()
+		for _, lr := range sl.LogRecords {
+			s.WriteArrayElement()
+			writeLogRecord(s, lr)
+		}
+		s.WriteArrayEnd()
+	}
+	s.WriteStringField("schemaUrl", sl.SchemaUrl)
+	s.WriteObjectEnd()
 }
 
-type jsonUnmarshaler struct {
-	delegate jsonpb.Unmarshaler
+func writeLogRecord(s *json.Stream, lr *otlplogs.LogRecord) {
+	s.WriteObjectStart()
+	s.WriteUint64Field("timeUnixNano", lr.TimeUnixNano)
+	s.WriteUint64Field("observedTimeUnixNano", lr.ObservedTimeUnixNano)
+	s.WriteInt32Field("severityNumber", int32(lr.SeverityNumber))
+	s.WriteStringField("severityText", lr.SeverityText)
+	if lr.Body.Value != nil {
+		s.WriteObjectField("body")
+		json.WriteAnyValue(s, &lr.Body)
+	}
+	json.WriteAttributes(s, "attributes", lr.Attributes)
+	s.WriteUint32Field("droppedAttributesCount", lr.DroppedAttributesCount)
+	s.WriteUint32Field("flags", lr.Flags)
+	json.WriteTraceID(s, "traceId", lr.TraceId)
+	json.WriteSpanID(s, "spanId", lr.SpanId)
+	s.WriteObjectEnd()
 }
 
+type jsonUnmarshaler struct{}
+
 // NewJSONUnmarshaler returns a model.Unmarshaler. Unmarshals from OTLP json bytes.
 func NewJSONUnmarshaler() Unmarshaler {
 	return newJSONUnmarshaler()
 }
 
 func newJSONUnmarshaler() *jsonUnmarshaler {
-	return &jsonUnmarshaler{delegate: jsonpb.Unmarshaler{}}
+	return &jsonUnmarshaler{}
 }
 
 func (d *jsonUnmarshaler) UnmarshalLogs(buf []byte) (Logs, error) {
+	it := json.NewIterator(buf)
 	ld := otlplogs.LogsData{}
-	if err := d.delegate.Unmarshal(bytes.NewReader(buf), &ld); err != nil {
+	it.ReadObject(func(it *json.Iterator, field string) {
+		switch field {
+		case "resourceLogs", "resource_logs":
+			it.ReadArray(func(it *json.Iterator) {
+				rl := &otlplogs.ResourceLogs{}
+				readResourceLogs(it, rl)
+				ld.ResourceLogs = append(ld.ResourceLogs, rl)
+			})
+		default:
+			it.Skip()
+		}
+	})
+	it.ReadEOF()
+	if err := it.Error(); err != nil {
 		return Logs{}, err
 	}
 	otlp.InstrumentationLibraryLogsToScope(ld.ResourceLogs)
 	return internal.LogsFromProto(ld), nil
 }
+
+func readResourceLogs(it *json.Iterator, rl *otlplogs.ResourceLogs) {
+	it.ReadObject(func(it *json.Iterator, field string) {
+		switch field {
+		case "resource":
+			json.ReadResource(it, &rl.Resource)
+		case "scopeLogs", "scope_logs":
+			it.ReadArray(func(it *json.Iterator) {
+				sl := &otlplogs.ScopeLogs{}
+				it.ReadObject(func(it *json.Iterator, field string) {
+					switch field {
+					case "scope":
+						json.ReadScope(it, &sl.Scope)
+					case "logRecords", "log_records":
+						sl.LogRecords = readLogRecords(it, sl.LogRecords)
+					case "schemaUrl", "schema_url":
+						sl.SchemaUrl = it.ReadString()
+					default:
+						it.Skip()
+					}
+				})
+				rl.ScopeLogs = append(rl.ScopeLogs, sl)
+			})
+		case "instrumentationLibraryLogs", "instrumentation_library_logs":
+			it.ReadArray(func(it *json.Iterator) {
+				ill := &otlplogs.InstrumentationLibraryLogs{}
+				it.ReadObject(func(it *json.Iterator, field string) {
+					switch field {
+					case "instrumentationLibrary", "instrumentation_library":
+						json.ReadInstrumentationLibrary(it, &ill.InstrumentationLibrary)
+					case "logRecords", "log_records":
+						ill.LogRecords = readLogRecords(it, ill.LogRecords)
+					case "schemaUrl", "schema_url":
+						ill.SchemaUrl = it.ReadString()
+					default:
+						it.Skip()
+					}
+				})
+				rl.InstrumentationLibraryLogs = append(rl.InstrumentationLibraryLogs, ill)
+			})
+		case "schemaUrl", "schema_url":
+			rl.SchemaUrl = it.ReadString()
+		default:
+			it.Skip()
+		}
+	})
+}
+
+func readLogRecords(it *json.Iterator, lrs []*otlplogs.LogRecord) []*otlplogs.LogRecord {
+	it.ReadArray(func(it *json.Iterator) {
+		lr := &otlplogs.LogRecord{}
+		readLogRecord(it, lr)
+		lrs = append(lrs, lr)
+	})
+	return lrs
+}
+
+func readLogRecord(it *json.Iterator, lr *otlplogs.LogRecord) {
+	it.ReadObject(func(it *json.Iterator, field string) {
+		switch field {
+		case "timeUnixNano", "time_unix_nano":
+			lr.TimeUnixNano = it.ReadUint64()
+		case "observedTimeUnixNano", "observed_time_unix_nano":
+			lr.ObservedTimeUnixNano = it.ReadUint64()
+		case "severityNumber", "severity_number":
+			lr.SeverityNumber = otlplogs.SeverityNumber(it.ReadEnum(otlplogs.SeverityNumber_value))
+		case "severityText", "severity_text":
+			lr.SeverityText = it.ReadString()
+		case "body":
+			json.ReadAnyValue(it, &lr.Body)
+		case "attributes":
+			lr.Attributes = json.ReadAttributes(it, lr.Attributes)
+		case "droppedAttributesCount", "dropped_attributes_count":
+			lr.DroppedAttributesCount = it.ReadUint32()
+		case "flags":
+			lr.Flags = it.ReadUint32()
+		case "traceId", "trace_id":
+			lr.TraceId = json.ReadTraceID(it)
+		case "spanId", "span_id":
+			lr.SpanId = json.ReadSpanID(it)
+		default:
+			it.Skip()
+		}
+	})
+}
@@ -15,9 +15,16 @@
 package plog
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/gogo/protobuf/jsonpb"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 var logsOTLP = func() Logs {
@@ -31,19 +38,46 @@ var logsOTLP = func() Logs {
 	return ld
 }()
 
-var logsJSON = `{"resourceLogs":[{"resource":{"attributes":[{"key":"host.name","value":{"stringValue":"testHost"}}]},"scopeLogs":[{"scope":{"name":"name","version":"version"},"logRecords":[{"severityText":"Error","body":{},"traceId":"","spanId":""}]}]}]}`
+var logsJSON = `{"resourceLogs":[{"resource":{"attributes":[{"key":"host.name","value":{"stringValue":"testHost"}}]},"scopeLogs":[{"scope":{"name":"name","version":"version"},"logRecords":[{"severityText":"Error"}]}]}]}`
+
+// logsFull sets all the fields of the logs.
+var logsFull = func() Logs {
+	ld := NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl("https://opentelemetry.io/schemas/1.0.0")
+	rl.Resource().Attributes().UpsertString("host.name", "testHost")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.SetSchemaUrl("https://opentelemetry.io/schemas/1.1.0")
+	sl.Scope().SetName("name")
+	sl.Scope().SetVersion("version")
+	lr := sl.LogRecords().AppendEmpty()
+	lr.SetTimestamp(1650000000000000000)
+	lr.SetObservedTimestamp(1650000000000000001)
+	lr.SetSeverityNumber(SeverityNumberWARN)
+	lr.SetSeverityText("Warning")
+	lr.Body().SetStringVal("body \\ \u0000 \U0001F600")
+	lr.Attributes().InsertInt("key", 1)
+	lr.SetDroppedAttributesCount(1)
+	lr.SetFlags(1)
+	lr.SetTraceID(pcommon.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	lr.SetSpanID(pcommon.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	sl.LogRecords().AppendEmpty()
+	return ld
+}()
 
 func TestLogsJSON(t *testing.T) {
-	encoder := NewJSONMarshaler()
-	jsonBuf, err := encoder.MarshalLogs(logsOTLP)
-	assert.NoError(t, err)
+	for _, ld := range []Logs{logsOTLP, logsFull, NewLogs()} {
+		encoder := NewJSONMarshaler()
+		jsonBuf, err := encoder.MarshalLogs(ld)
+		assert.NoError(t, err)
 
-	decoder := NewJSONUnmarshaler()
-	var got interface{}
-	got, err = decoder.UnmarshalLogs(jsonBuf)
-	assert.NoError(t, err)
+		decoder := NewJSONUnmarshaler()
+		var got interface{}
+		got, err = decoder.UnmarshalLogs(jsonBuf)
+		assert.NoError(t, err)
 
-	assert.EqualValues(t, logsOTLP, got)
+		assert.EqualValues(t, ld, got)
+	}
 }
 
 func TestLogsJSON_Marshal(t *testing.T) {
@@ -52,3 +86,93 @@ func TestLogsJSON_Marshal(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, logsJSON, string(jsonBuf))
 }
+
+func TestLogsJSON_Full(t *testing.T) {
+	jsonBuf, err := NewJSONMarshaler().MarshalLogs(logsFull)
+	require.NoError(t, err)
+	assert.Contains(t, string(jsonBuf), `{"timeUnixNano":"1650000000000000000","observedTimeUnixNano":"1650000000000000001","severityNumber":13,"severityText":"Warning","body":{"stringValue":"body \\ \u0000 😀"},"attributes":[{"key":"key","value":{"intValue":"1"}}],"droppedAttributesCount":1,"flags":1,"traceId":"0102030405060708090a0b0c0d0e0f10","spanId":"0102030405060708"}`)
+
+	// The output can be read by jsonpb, and the output of jsonpb can be read back.
+	var pb otlplogs.LogsData
+	require.NoError(t, jsonpb.Unmarshal(bytes.NewReader(jsonBuf), &pb))
+	assert.EqualValues(t, logsFull, internal.LogsFromProto(pb))
+
+	var buf bytes.Buffer
+	want := internal.LogsToProto(logsFull)
+	require.NoError(t, (&jsonpb.Marshaler{}).Marshal(&buf, &want))
+	got, err := NewJSONUnmarshaler().UnmarshalLogs(buf.Bytes())
+	require.NoError(t, err)
+	assert.EqualValues(t, logsFull, got)
+}
+
+func TestLogsJSON_Unmarshal(t *testing.T) {
+	jsonBuf := `{"resource_logs":[{"scope_logs":[{"log_records":[{
+		"severity_number": "SEVERITY_NUMBER_ERROR",
+		"body": {"kvlist_value": {"values": [{"key": "escaped", "value": {"string_value": "\"\u00e9\ud83d\ude00\n"}}]}},
+		"time_unix_nano": "1650000000000000000"
+	}]}]}]}`
+	ld, err := NewJSONUnmarshaler().UnmarshalLogs([]byte(jsonBuf))
+	require.NoError(t, err)
+
+	want := NewLogs()
+	lr := want.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetSeverityNumber(SeverityNumberERROR)
+	body := pcommon.NewValueMap()
+	body.MapVal().InsertString("escaped", "\"é😀\n")
+	body.CopyTo(lr.Body())
+	lr.SetTimestamp(1650000000000000000)
+	assert.EqualValues(t, want, ld)
+}
+
+func TestLogsJSON_UnmarshalError(t *testing.T) {
+	for _, jsonBuf := range []string{
+		`{"resourceLogs":[{"scopeLogs":[{"logRecords":[{"severityNumber":"ERROR"}]}]}]}`,
+		`{"resourceLogs":[{"scopeLogs":[{"logRecords":[{"body":{"stringValue":"\x"}}]}]}]}`,
+		`{"resourceLogs":[{"scopeLogs":[{"logRecords":[{"body":{"bytesValue":"!"}}]}]}]}`,
+		`{"resourceLogs":[{"scopeLogs":[{"logRecords":[{"flags":true}]}]}]}`,
+	} {
+		_, err := NewJSONUnmarshaler().UnmarshalLogs([]byte(jsonBuf))
+		assert.Error(t, err, jsonBuf)
+	}
+}
+
+func BenchmarkLogsJSON_Marshal(b *testing.B) {
+	b.Run("pdata", func(b *testing.B) {
+		encoder := NewJSONMarshaler()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, err := encoder.MarshalLogs(logsFull)
+			require.NoError(b, err)
+		}
+	})
+	b.Run("jsonpb", func(b *testing.B) {
+		encoder := &jsonpb.Marshaler{}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var buf bytes.Buffer
+			pb := internal.LogsToProto(logsFull)
+			require.NoError(b, encoder.Marshal(&buf, &pb))
+		}
+	})
+}
+
+func BenchmarkLogsJSON_Unmarshal(b *testing.B) {
+	jsonBuf, err := NewJSONMarshaler().MarshalLogs(logsFull)
+	require.NoError(b, err)
+	b.Run("pdata", func(b *testing.B) {
+		decoder := NewJSONUnmarshaler()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, err := decoder.UnmarshalLogs(jsonBuf)
+			require.NoError(b, err)
+		}
+	})
+	b.Run("jsonpb", func(b *testing.B) {
+		decoder := &jsonpb.Unmarshaler{}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var pb otlplogs.LogsData
+			require.NoError(b, decoder.Unmarshal(bytes.NewReader(jsonBuf), &pb))
+		}
+	})
+}
@@ -34,11 +34,17 @@ func newPbMarshaler() *pbMarshaler {
 var _ Sizer = (*pbMarshaler)(nil)
 
 func (e *pbMarshaler) MarshalLogs(ld Logs) ([]byte, error) {
+	if buf, ok := encodedBytes(ld); ok {
+		return buf, nil
+	}
 	pb := internal.LogsToProto(ld)
 	return pb.Marshal()
 }
 
 func (e *pbMarshaler) LogsSize(ld Logs) int {
+	if buf, ok := encodedBytes(ld); ok {
+		return len(buf)
+	}
 	pb := internal.LogsToProto(ld)
 	return pb.Size()
 }
@@ -59,3 +65,10 @@ func (d *pbUnmarshaler) UnmarshalLogs(buf []byte) (Logs, error) {
 	err := pb.Unmarshal(buf)
 	return internal.LogsFromProto(pb), err
 }
+
+// encodedBytes returns the received bytes of ld that were not decoded yet,
+// the encoding of a request being the same as the one of LogsData.
+func encodedBytes(ld Logs) ([]byte, bool) {
+	_, encoded, _ := internal.LogsToEncodedOtlp(ld)
+	return encoded.Bytes()
+}
@@ -20,15 +20,20 @@ import (
 
 	"github.com/gogo/protobuf/jsonpb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectorlog "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
 	"go.opentelemetry.io/collector/pdata/internal/otlp"
+	"go.opentelemetry.io/collector/pdata/internal/otlpwire"
 	"go.opentelemetry.io/collector/pdata/plog"
 )
 
 var jsonMarshaler = &jsonpb.Marshaler{}
 var jsonUnmarshaler = &jsonpb.Unmarshaler{}
+var logsJSONMarshaler = plog.NewJSONMarshaler()
+var logsJSONUnmarshaler = plog.NewJSONUnmarshaler()
 
 // Response represents the response for gRPC/HTTP client/server.
 type Response struct {
@@ -68,27 +73,55 @@ func (lr Response) UnmarshalJSON(data []byte) error {
 // It's a wrapper for plog.Logs data.
 type Request struct {
 	orig *otlpcollectorlog.ExportLogsServiceRequest
+	// encoded, if not nil, holds the received bytes of lazily decoded requests.
+	encoded *internal.EncodedOrig
+	// shared tracks the resources shared with other plog.Logs.
+	shared *internal.SharedResourceLogs
 }
 
 // NewRequest returns an empty Request.
 func NewRequest() Request {
-	return Request{orig: &otlpcollectorlog.ExportLogsServiceRequest{}}
+	return Request{orig: &otlpcollectorlog.ExportLogsServiceRequest{}, shared: &internal.SharedResourceLogs{}}
+}
+
+// NewLazyRequest returns an empty Request that decodes the logs lazily:
+// UnmarshalProto only validates and keeps the given bytes, which must not be modified afterwards,
+// and they are decoded the first time the content of the Logs is accessed.
+// Until then, counting items and cloning the Logs do not decode them, and marshaling them
+// to protobuf, including with Client, returns the received bytes.
+// The request is decoded as a whole: once any of its content is accessed, marshaling re-encodes
+// all of it, even the resources that were not modified.
+func NewLazyRequest() Request {
+	return Request{orig: &otlpcollectorlog.ExportLogsServiceRequest{}, encoded: &internal.EncodedOrig{}, shared: &internal.SharedResourceLogs{}}
 }
 
 // NewRequestFromLogs returns a Request from plog.Logs.
 // Because Request is a wrapper for plog.Logs,
 // any changes to the provided Logs struct will be reflected in the Request and vice versa.
 func NewRequestFromLogs(l plog.Logs) Request {
-	return Request{orig: internal.LogsToOtlp(l)}
+	orig, encoded, shared := internal.LogsToEncodedOtlp(l)
+	return Request{orig: orig, encoded: encoded, shared: shared}
 }
 
 // MarshalProto marshals Request into proto bytes.
 func (lr Request) MarshalProto() ([]byte, error) {
+	if buf, ok := lr.encoded.Bytes(); ok {
+		return buf, nil
+	}
 	return lr.orig.Marshal()
 }
 
 // UnmarshalProto unmarshalls Request from proto bytes.
 func (lr Request) UnmarshalProto(data []byte) error {
+	if lr.encoded != nil {
+		lr.encoded.Discard()
+		*lr.orig = otlpcollectorlog.ExportLogsServiceRequest{}
+		// Invalid data is decoded right away to return the error.
+		if otlpwire.ValidLogs(data) {
+			lr.encoded.Set(data)
+			return nil
+		}
+	}
 	if err := lr.orig.Unmarshal(data); err != nil {
 		return err
 	}
@@ -98,29 +131,28 @@ func (lr Request) UnmarshalProto(data []byte) error {
 
 // MarshalJSON marshals Request into JSON bytes.
 func (lr Request) MarshalJSON() ([]byte, error) {
-	var buf bytes.Buffer
-	if err := jsonMarshaler.Marshal(&buf, lr.orig); err != nil {
-		return nil, err
-	}
-	return buf.Bytes(), nil
+	return logsJSONMarshaler.MarshalLogs(lr.Logs())
 }
 
 // UnmarshalJSON unmarshalls Request from JSON bytes.
 func (lr Request) UnmarshalJSON(data []byte) error {
-	if err := jsonUnmarshaler.Unmarshal(bytes.NewReader(data), lr.orig); err != nil {
+	ld, err := logsJSONUnmarshaler.UnmarshalLogs(data)
+	if err != nil {
 		return err
 	}
-	otlp.InstrumentationLibraryLogsToScope(lr.orig.ResourceLogs)
+	lr.encoded.Discard()
+	*lr.orig = *internal.LogsToOtlp(ld)
 	return nil
 }
 
 // Deprecated: [v0.50.0] Use NewRequestFromLogs instead.
 func (lr Request) SetLogs(ld plog.Logs) {
+	lr.encoded.Discard()
 	*lr.orig = *internal.LogsToOtlp(ld)
 }
 
 func (lr Request) Logs() plog.Logs {
-	return internal.LogsFromOtlp(lr.orig)
+	return internal.LogsFromEncodedOtlp(lr.orig, lr.encoded, lr.shared)
 }
 
 // Client is the client API for OTLP-GRPC Logs service.
@@ -136,14 +168,22 @@ type Client interface {
 
 type logsClient struct {
 	rawClient otlpcollectorlog.LogsServiceClient
+	cc        *grpc.ClientConn
 }
 
 // NewClient returns a new Client connected using the given connection.
 func NewClient(cc *grpc.ClientConn) Client {
-	return &logsClient{rawClient: otlpcollectorlog.NewLogsServiceClient(cc)}
+	return &logsClient{rawClient: otlpcollectorlog.NewLogsServiceClient(cc), cc: cc}
 }
 
 func (c *logsClient) Export(ctx context.Context, request Request, opts ...grpc.CallOption) (Response, error) {
+	if buf, ok := request.encoded.Bytes(); ok {
+		rsp := &otlpcollectorlog.ExportLogsServiceResponse{}
+		if err := c.cc.Invoke(ctx, exportMethod, &otlpwire.RawMessage{Buf: buf}, rsp, opts...); err != nil {
+			return Response{}, err
+		}
+		return Response{orig: rsp}, nil
+	}
 	rsp, err := c.rawClient.Export(ctx, request.orig, opts...)
 	return Response{orig: rsp}, err
 }
@@ -162,12 +202,57 @@ func RegisterServer(s *grpc.Server, srv Server) {
 	otlpcollectorlog.RegisterLogsServiceServer(s, &rawLogsServer{srv: srv})
 }
 
+// RegisterLazyServer registers the Server to the grpc.Server, like RegisterServer,
+// but the requests given to the Server are lazily decoded, see NewLazyRequest.
+func RegisterLazyServer(s *grpc.Server, srv Server) {
+	s.RegisterService(&lazyServiceDesc, srv)
+}
+
+const exportMethod = "/opentelemetry.proto.collector.logs.v1.LogsService/Export"
+
+var lazyServiceDesc = grpc.ServiceDesc{
+	ServiceName: "opentelemetry.proto.collector.logs.v1.LogsService",
+	HandlerType: (*Server)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Export",
+			Handler:    lazyExportHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "opentelemetry/proto/collector/logs/v1/logs_service.proto",
+}
+
+func lazyExportHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := &otlpwire.RawMessage{}
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	request := NewLazyRequest()
+	if err := request.UnmarshalProto(in.Buf); err != nil {
+		// Same error as the one returned by grpc when the generated code fails to decode.
+		return nil, status.Errorf(codes.Internal, "grpc: error unmarshalling request: %v", err)
+	}
+	export := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		rsp, err := srv.(Server).Export(ctx, request)
+		return rsp.orig, err
+	}
+	if interceptor == nil {
+		return export(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: exportMethod,
+	}
+	return interceptor(ctx, in, info, export)
+}
+
 type rawLogsServer struct {
 	srv Server
 }
 
 func (s rawLogsServer) Export(ctx context.Context, request *otlpcollectorlog.ExportLogsServiceRequest) (*otlpcollectorlog.ExportLogsServiceResponse, error) {
 	otlp.InstrumentationLibraryLogsToScope(request.ResourceLogs)
-	rsp, err := s.srv.Export(ctx, Request{orig: request})
+	rsp, err := s.srv.Export(ctx, Request{orig: request, shared: &internal.SharedResourceLogs{}})
 	return rsp.orig, err
 }
@@ -33,6 +33,8 @@ import (
 
 	v1 "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
 	"go.opentelemetry.io/collector/pdata/internal/otlp"
+	"go.opentelemetry.io/collector/pdata/internal/otlpwire"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 )
 
@@ -46,17 +48,13 @@ var logsRequestJSON = []byte(`
 	{
 		"resourceLogs": [
 		{
-			"resource": {},
 			"scopeLogs": [
 				{
-					"scope": {},
 					"logRecords": [
 						{
 							"body": {
 								"stringValue": "test_log_record"
-							},
-							"traceId": "",
-							"spanId": ""
+							}
 						}
 					]
 				}
@@ -327,3 +325,183 @@ func generateLogsRequestWithInstrumentationLibrary() Request {
 	lr.orig.ResourceLogs[0].ScopeLogs = []*v1.ScopeLogs{}
 	return lr
 }
+
+func TestLazyRequest(t *testing.T) {
+	buf, err := generateLogsRequest().MarshalProto()
+	require.NoError(t, err)
+
+	req := NewLazyRequest()
+	require.NoError(t, req.UnmarshalProto(buf))
+	td := req.Logs()
+	assert.Equal(t, 1, td.LogRecordCount())
+	clone := td.Clone()
+	got, err := req.MarshalProto()
+	require.NoError(t, err)
+	assert.Equal(t, buf, got)
+	got, err = plog.NewProtoMarshaler().MarshalLogs(clone)
+	require.NoError(t, err)
+	assert.Equal(t, buf, got)
+	_, pending := req.encoded.Bytes()
+	assert.True(t, pending)
+
+	assert.Equal(t, "test_log_record", td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().StringVal())
+	_, pending = req.encoded.Bytes()
+	assert.False(t, pending)
+	assert.Equal(t, generateLogsRequest().Logs(), req.Logs())
+	assert.Equal(t, generateLogsRequest().Logs().ResourceLogs(), clone.ResourceLogs())
+	td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().SetStringVal("changed")
+	got, err = req.MarshalProto()
+	require.NoError(t, err)
+	assert.NotEqual(t, buf, got)
+}
+
+func TestLazyRequestDecodedOnError(t *testing.T) {
+	req := NewLazyRequest()
+	assert.Equal(t, NewRequest().UnmarshalProto([]byte{0xff}), req.UnmarshalProto([]byte{0xff}))
+
+	buf, err := generateLogsRequestWithInstrumentationLibrary().MarshalProto()
+	require.NoError(t, err)
+	require.NoError(t, req.UnmarshalProto(buf))
+	_, pending := req.encoded.Bytes()
+	assert.False(t, pending)
+	assert.Equal(t, generateLogsRequest().Logs(), req.Logs())
+}
+
+type fakeLazyServer struct {
+	t *testing.T
+}
+
+func (s fakeLazyServer) Export(_ context.Context, req Request) (Response, error) {
+	td := req.Logs()
+	assert.Equal(s.t, 1, td.LogRecordCount())
+	_, pending := req.encoded.Bytes()
+	assert.True(s.t, pending)
+	td.ResourceLogs()
+	assert.Equal(s.t, generateLogsRequest().Logs(), req.Logs())
+	return NewResponse(), nil
+}
+
+func TestGrpcLazy(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	RegisterLazyServer(s, &fakeLazyServer{t: t})
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, s.Serve(lis))
+	}()
+	t.Cleanup(func() {
+		s.Stop()
+		wg.Wait()
+	})
+
+	cc, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock())
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, cc.Close())
+	})
+
+	logClient := NewClient(cc)
+	resp, err := logClient.Export(context.Background(), generateLogsRequest())
+	assert.NoError(t, err)
+	assert.Equal(t, NewResponse(), resp)
+
+	err = cc.Invoke(context.Background(), exportMethod, &otlpwire.RawMessage{Buf: []byte{0xff}}, NewResponse().orig)
+	st, okSt := status.FromError(err)
+	require.True(t, okSt)
+	assert.Equal(t, codes.Internal, st.Code())
+}
+
+func TestGrpcLazyClient(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	RegisterServer(s, &fakeLogsServer{t: t})
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, s.Serve(lis))
+	}()
+	t.Cleanup(func() {
+		s.Stop()
+		wg.Wait()
+	})
+
+	cc, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock())
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, cc.Close())
+	})
+
+	buf, err := generateLogsRequest().MarshalProto()
+	require.NoError(t, err)
+	req := NewLazyRequest()
+	require.NoError(t, req.UnmarshalProto(buf))
+
+	logClient := NewClient(cc)
+	resp, err := logClient.Export(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, NewResponse(), resp)
+	_, pending := req.encoded.Bytes()
+	assert.True(t, pending)
+}
+
+func BenchmarkRequestPassThrough(b *testing.B) {
+	buf, err := generateLargeLogsRequest().MarshalProto()
+	require.NoError(b, err)
+
+	b.Run("eager", func(b *testing.B) {
+		benchmarkPassThrough(b, buf, NewRequest)
+	})
+	b.Run("lazy", func(b *testing.B) {
+		benchmarkPassThrough(b, buf, NewLazyRequest)
+	})
+}
+
+func benchmarkPassThrough(b *testing.B, buf []byte, newRequest func() Request) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		req := newRequest()
+		if err := req.UnmarshalProto(buf); err != nil {
+			b.Fatal(err)
+		}
+		if req.Logs().LogRecordCount() == 0 {
+			b.Fatal("no log records")
+		}
+		if _, err := req.MarshalProto(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func generateLargeLogsRequest() Request {
+	ld := plog.NewLogs()
+	for i := 0; i < 10; i++ {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().InsertString("service.name", "service")
+		rl.Resource().Attributes().InsertInt("resource.index", int64(i))
+		lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+		for j := 0; j < 10; j++ {
+			lr := lrs.AppendEmpty()
+			lr.SetTimestamp(pcommon.Timestamp(1000))
+			lr.SetSeverityNumber(plog.SeverityNumberINFO)
+			lr.SetSeverityText("INFO")
+			lr.Body().SetStringVal("log message")
+			lr.Attributes().InsertString("http.method", "GET")
+			lr.Attributes().InsertInt("http.status_code", 200)
+		}
+	}
+	return NewRequestFromLogs(ld)
+}
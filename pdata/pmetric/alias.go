@@ -23,6 +23,10 @@ type Metrics = internal.Metrics
 // NewMetrics creates a new Metrics struct.
 var NewMetrics = internal.NewMetrics
 
+// DataPoint is implemented by NumberDataPoint, HistogramDataPoint, ExponentialHistogramDataPoint
+// and SummaryDataPoint, the type of the data points of a Metric.
+type DataPoint = internal.DataPoint
+
 // MetricDataType specifies the type of data in a Metric.
 type MetricDataType = internal.MetricDataType
 
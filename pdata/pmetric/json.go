@@ -15,12 +15,9 @@
 package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
 
 import (
-	"bytes"
-
-	"github.com/gogo/protobuf/jsonpb"
-
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/internal/json"
 	"go.opentelemetry.io/collector/pdata/internal/otlp"
 )
 
@@ -29,38 +26,616 @@ func NewJSONMarshaler() Marshaler {
 	return newJSONMarshaler()
 }
 
-type jsonMarshaler struct {
-	delegate jsonpb.Marshaler
-}
+type jsonMarshaler struct{}
 
 func newJSONMarshaler() *jsonMarshaler {
-	return &jsonMarshaler{delegate: jsonpb.Marshaler{}}
+	return &jsonMarshaler{}
 }
 
 func (e *jsonMarshaler) MarshalMetrics(md Metrics) ([]byte, error) {
-	buf := bytes.Buffer{}
-	err := e.delegate.Marshal(&buf, internal.MetricsToOtlp(md))
-	return buf.Bytes(), err
+	pb := internal.MetricsToProto(md)
+	s := json.NewStream(128 * md.DataPointCount())
+	s.WriteObjectStart()
+	if len(pb.ResourceMetrics) > 0 {
+		s.WriteObjectField("resourceMetrics")
+		s.WriteArrayStart()
+		for _, rm := range pb.ResourceMetrics {
+			s.WriteArrayElement()
+			writeResourceMetrics(s, rm)
+		}
+		s.WriteArrayEnd()
+	}
+	s.WriteObjectEnd()
+	return s.Buffer(), nil
+}
+
+func writeResourceMetrics(s *json.Stream, rm *otlpmetrics.ResourceMetrics) {
+	s.WriteObjectStart()
+	json.WriteResource(s, &rm.Resource)
+	if len(rm.ScopeMetrics) > 0 {
+		s.WriteObjectField("scopeMetrics")
+		s.WriteArrayStart()
+		for _, sm := range rm.ScopeMetrics {
+			s.WriteArrayElement()
+			writeScopeMetrics(s, sm)
+		}
+		s.WriteArrayEnd()
+	}
+	s.WriteStringField("schemaUrl", rm.SchemaUrl)
+	s.WriteObjectEnd()
+}
+
+func writeScopeMetrics(s *json.Stream, sm *otlpmetrics.ScopeMetrics) {
+	s.WriteObjectStart()
+	json.WriteScope(s, &sm.Scope)
+	if len(sm.Metrics) > 0 {
+		s.WriteObjectField("metrics")
+		s.WriteArrayStart()
+		for _, m := range sm.Metrics {
+			s.WriteArrayElement()
+			writeMetric(s, m)
+		}
+		s.WriteArrayEnd()
+	}
+	s.WriteStringField("schemaUrl", sm.SchemaUrl)
+	s.WriteObjectEnd()
+}
+
+func writeMetric(s *json.Stream, m *otlpmetrics.Metric) {
+	s.WriteObjectStart()
+	s.WriteStringField("name", m.Name)
+	s.WriteStringField("description", m.Description)
+	s.WriteStringField("unit", m.Unit)
+	switch data := m.Data.(type) {
+	case *otlpmetrics.Metric_Gauge:
+		s.WriteObjectField("gauge")
+		s.WriteObjectStart()
+		writeNumberDataPoints(s, data.Gauge.DataPoints)
+		s.WriteObjectEnd()
+	case *otlpmetrics.Metric_Sum:
+		s.WriteObjectField("sum")
+		s.WriteObjectStart()
+		writeNumberDataPoints(s, data.Sum.DataPoints)
+		s.WriteInt32Field("aggregationTemporality", int32(data.Sum.AggregationTemporality))
+		s.WriteBoolField("isMonotonic", data.Sum.IsMonotonic)
+		s.WriteObjectEnd()
+	case *otlpmetrics.Metric_Histogram:
+		s.WriteObjectField("histogram")
+		s.WriteObjectStart()
+		if len(data.Histogram.DataPoints) > 0 {
+			s.WriteObjectField("dataPoints")
+			s.WriteArrayStart()
+			for _, dp := range data.Histogram.DataPoints {
+				s.WriteArrayElement()
+				writeHistogramDataPoint(s, dp)
+			}
+			s.WriteArrayEnd()
+		}
+		s.WriteInt32Field("aggregationTemporality", int32(data.Histogram.AggregationTemporality))
+		s.WriteObjectEnd()
+	case *otlpmetrics.Metric_ExponentialHistogram:
+		s.WriteObjectField("exponentialHistogram")
+		s.WriteObjectStart()
+		if len(data.ExponentialHistogram.DataPoints) > 0 {
+			s.WriteObjectField("dataPoints")
+			s.WriteArrayStart()
+			for _, dp := range data.ExponentialHistogram.DataPoints {
+				s.WriteArrayElement()
+				writeExponentialHistogramDataPoint(s, dp)
+			}
+			s.WriteArrayEnd()
+		}
+		s.WriteInt32Field("aggregationTemporality", int32(data.ExponentialHistogram.AggregationTemporality))
+		s.WriteObjectEnd()
+	case *otlpmetrics.Metric_Summary:
+		s.WriteObjectField("summary")
+		s.WriteObjectStart()
+		if len(data.Summary.DataPoints) > 0 {
+			s.WriteObjectField("dataPoints")
+			s.WriteArrayStart()
+			for _, dp := range data.Summary.DataPoints {
+				s.WriteArrayElement()
+				writeSummaryDataPoint(s, dp)
+			}
+			s.WriteArrayEnd()
+		}
+		s.WriteObjectEnd()
+	}
+	s.WriteObjectEnd()
+}
+
+func writeNumberDataPoints(s *json.Stream, dps []*otlpmetrics.NumberDataPoint) {
+	if len(dps) == 0 {
+		return
+	}
+	s.WriteObjectField("dataPoints")
+	s.WriteArrayStart()
+	for _, dp := range dps {
+		s.WriteArrayElement()
+		s.WriteObjectStart()
+		json.WriteAttributes(s, "attributes", dp.Attributes)
+		s.WriteUint64Field("startTimeUnixNano", dp.StartTimeUnixNano)
+		s.WriteUint64Field("timeUnixNano", dp.TimeUnixNano)
+		switch v := dp.Value.(type) {
+		case *otlpmetrics.NumberDataPoint_AsDouble:
+			s.WriteObjectField("asDouble")
+			s.WriteFloat64(v.AsDouble)
+		case *otlpmetrics.NumberDataPoint_AsInt:
+			s.WriteObjectField("asInt")
+			s.WriteInt64(v.AsInt)
+		}
+		writeExemplars(s, dp.Exemplars)
+		s.WriteUint32Field("flags", dp.Flags)
+		s.WriteObjectEnd()
+	}
+	s.WriteArrayEnd()
+}
+
+func writeHistogramDataPoint(s *json.Stream, dp *otlpmetrics.HistogramDataPoint) {
+	s.WriteObjectStart()
+	json.WriteAttributes(s, "attributes", dp.Attributes)
+	s.WriteUint64Field("startTimeUnixNano", dp.StartTimeUnixNano)
+	s.WriteUint64Field("timeUnixNano", dp.TimeUnixNano)
+	s.WriteUint64Field("count", dp.Count)
+	if sum, ok := dp.Sum_.(*otlpmetrics.HistogramDataPoint_Sum); ok {
+		s.WriteObjectField("sum")
+		s.WriteFloat64(sum.Sum)
+	}
+	writeUint64s(s, "bucketCounts", dp.BucketCounts)
+	if len(dp.ExplicitBounds) > 0 {
+		s.WriteObjectField("explicitBounds")
+		s.WriteArrayStart()
+		for _, b := range dp.ExplicitBounds {
+			s.WriteArrayElement()
+			s.WriteFloat64(b)
+		}
+		s.WriteArrayEnd()
+	}
+	writeExemplars(s, dp.Exemplars)
+	s.WriteUint32Field("flags", dp.Flags)
+	s.WriteObjectEnd()
+}
+
+func writeExponentialHistogramDataPoint(s *json.Stream, dp *otlpmetrics.ExponentialHistogramDataPoint) {
+	s.WriteObjectStart()
+	json.WriteAttributes(s, "attributes", dp.Attributes)
+	s.WriteUint64Field("startTimeUnixNano", dp.StartTimeUnixNano)
+	s.WriteUint64Field("timeUnixNano", dp.TimeUnixNano)
+	s.WriteUint64Field("count", dp.Count)
+	s.WriteFloat64Field("sum", dp.Sum)
+	s.WriteInt32Field("scale", dp.Scale)
+	s.WriteUint64Field("zeroCount", dp.ZeroCount)
+	writeBuckets(s, "positive", &dp.Positive)
+	writeBuckets(s, "negative", &dp.Negative)
+	s.WriteUint32Field("flags", dp.Flags)
+	writeExemplars(s, dp.Exemplars)
+	s.WriteObjectEnd()
+}
+
+func writeBuckets(s *json.Stream, name string, b *otlpmetrics.ExponentialHistogramDataPoint_Buckets) {
+	if b.Offset == 0 && len(b.BucketCounts) == 0 {
+		return
+	}
+	s.WriteObjectField(name)
+	s.WriteObjectStart()
+	s.WriteInt32Field("offset", b.Offset)
+	writeUint64s(s, "bucketCounts", b.BucketCounts)
+	s.WriteObjectEnd()
+}
+
+func writeSummaryDataPoint(s *json.Stream, dp *otlpmetrics.SummaryDataPoint) {
+	s.WriteObjectStart()
+	json.WriteAttributes(s, "attributes", dp.Attributes)
+	s.WriteUint64Field("startTimeUnixNano", dp.StartTimeUnixNano)
+	s.WriteUint64Field("timeUnixNano", dp.TimeUnixNano)
+	s.WriteUint64Field("count", dp.Count)
+	s.WriteFloat64Field("sum", dp.Sum)
+	if len(dp.QuantileValues) > 0 {
+		s.WriteObjectField("quantileValues")
+		s.WriteArrayStart()
+		for _, qv := range dp.QuantileValues {
+			s.WriteArrayElement()
+			s.WriteObjectStart()
+			s.WriteFloat64Field("quantile", qv.Quantile)
+			s.WriteFloat64Field("value", qv.Value)
+			s.WriteObjectEnd()
+		}
+		s.WriteArrayEnd()
+	}
+	s.WriteUint32Field("flags", dp.Flags)
+	s.WriteObjectEnd()
 }
 
-type jsonUnmarshaler struct {
-	delegate jsonpb.Unmarshaler
+func writeExemplars(s *json.Stream, exemplars []otlpmetrics.Exemplar) {
+	if len(exemplars) == 0 {
+		return
+	}
+	s.WriteObjectField("exemplars")
+	s.WriteArrayStart()
+	for i := range exemplars {
+		e := &exemplars[i]
+		s.WriteArrayElement()
+		s.WriteObjectStart()
+		json.WriteAttributes(s, "filteredAttributes", e.FilteredAttributes)
+		s.WriteUint64Field("timeUnixNano", e.TimeUnixNano)
+		switch v := e.Value.(type) {
+		case *otlpmetrics.Exemplar_AsDouble:
+			s.WriteObjectField("asDouble")
+			s.WriteFloat64(v.AsDouble)
+		case *otlpmetrics.Exemplar_AsInt:
+			s.WriteObjectField("asInt")
+			s.WriteInt64(v.AsInt)
+		}
+		json.WriteSpanID(s, "spanId", e.SpanId)
+		json.WriteTraceID(s, "traceId", e.TraceId)
+		s.WriteObjectEnd()
+	}
+	s.WriteArrayEnd()
+}
+
+func writeUint64s(s *json.Stream, name string, vs []uint64) {
+	if len(vs) == 0 {
+		return
+	}
+	s.WriteObjectField(name)
+	s.WriteArrayStart()
+	for _, v := range vs {
+		s.WriteArrayElement()
+		s.WriteUint64(v)
+	}
+	s.WriteArrayEnd()
 }
 
+type jsonUnmarshaler struct{}
+
 // NewJSONUnmarshaler returns a model.Unmarshaler. Unmarshals from OTLP json bytes.
 func NewJSONUnmarshaler() Unmarshaler {
 	return newJSONUnmarshaler()
 }
 
 func newJSONUnmarshaler() *jsonUnmarshaler {
-	return &jsonUnmarshaler{delegate: jsonpb.Unmarshaler{}}
+	return &jsonUnmarshaler{}
 }
 
 func (d *jsonUnmarshaler) UnmarshalMetrics(buf []byte) (Metrics, error) {
+	it := json.NewIterator(buf)
 	md := otlpmetrics.MetricsData{}
-	if err := d.delegate.Unmarshal(bytes.NewReader(buf), &md); err != nil {
+	it.ReadObject(func(it *json.Iterator, field string) {
+		switch field {
+		case "resourceMetrics", "resource_metrics":
+			it.ReadArray(func(it *json.Iterator) {
+				rm := &otlpmetrics.ResourceMetrics{}
+				readResourceMetrics(it, rm)
+				md.ResourceMetrics = append(md.ResourceMetrics, rm)
+			})
+		default:
+			it.Skip()
+		}
+	})
+	it.ReadEOF()
+	if err := it.Error(); err != nil {
 		return Metrics{}, err
 	}
 	otlp.InstrumentationLibraryMetricsToScope(md.ResourceMetrics)
 	return internal.MetricsFromProto(md), nil
 }
+
+func readResourceMetrics(it *json.Iterator, rm *otlpmetrics.ResourceMetrics) {
+	it.ReadObject(func(it *json.Iterator, field string) {
+		switch field {
+		case "resource":
+			json.ReadResource(it, &rm.Resource)
+		case "scopeMetrics", "scope_metrics":
+			it.ReadArray(func(it *json.Iterator) {
+				sm := &otlpmetrics.ScopeMetrics{}
+				it.ReadObject(func(it *json.Iterator, field string) {
+					switch field {
+					case "scope":
+						json.ReadScope(it, &sm.Scope)
+					case "metrics":
+						sm.Metrics = readMetrics(it, sm.Metrics)
+					case "schemaUrl", "schema_url":
+						sm.SchemaUrl = it.ReadString()
+					default:
+						it.Skip()
+					}
+				})
+				rm.ScopeMetrics = append(rm.ScopeMetrics, sm)
+			})
+		case "instrumentationLibraryMetrics", "instrumentation_library_metrics":
+			it.ReadArray(func(it *json.Iterator) {
+				ilm := &otlpmetrics.InstrumentationLibraryMetrics{}
+				it.ReadObject(func(it *json.Iterator, field string) {
+					switch field {
+					case "instrumentationLibrary", "instrumentation_library":
+						json.ReadInstrumentationLibrary(it, &ilm.InstrumentationLibrary)
+					case "metrics":
+						ilm.Metrics = readMetrics(it, ilm.Metrics)
+					case "schemaUrl", "schema_url":
+						ilm.SchemaUrl = it.ReadString()
+					default:
+						it.Skip()
+					}
+				})
+				rm.InstrumentationLibraryMetrics = append(rm.InstrumentationLibraryMetrics, ilm)
+			})
+		case "schemaUrl", "schema_url":
+			rm.SchemaUrl = it.ReadString()
+		default:
+			it.Skip()
+		}
+	})
+}
+
+func readMetrics(it *json.Iterator, metrics []*otlpmetrics.Metric) []*otlpmetrics.Metric {
+	it.ReadArray(func(it *json.Iterator) {
+		m := &otlpmetrics.Metric{}
+		readMetric(it, m)
+		metrics = append(metrics, m)
+	})
+	return metrics
+}
+
+func readMetric(it *json.Iterator, m *otlpmetrics.Metric) {
+	it.ReadObject(func(it *json.Iterator, field string) {
+		switch field {
+		case "name":
+			m.Name = it.ReadString()
+		case "description":
+			m.Description = it.ReadString()
+		case "unit":
+			m.Unit = it.ReadString()
+		case "gauge":
+			gauge := &otlpmetrics.Gauge{}
+			it.ReadObject(func(it *json.Iterator, field string) {
+				switch field {
+				case "dataPoints", "data_points":
+					gauge.DataPoints = readNumberDataPoints(it, gauge.DataPoints)
+				default:
+					it.Skip()
+				}
+			})
+			m.Data = &otlpmetrics.Metric_Gauge{Gauge: gauge}
+		case "sum":
+			sum := &otlpmetrics.Sum{}
+			it.ReadObject(func(it *json.Iterator, field string) {
+				switch field {
+				case "dataPoints", "data_points":
+					sum.DataPoints = readNumberDataPoints(it, sum.DataPoints)
+				case "aggregationTemporality", "aggregation_temporality":
+					sum.AggregationTemporality = readAggregationTemporality(it)
+				case "isMonotonic", "is_monotonic":
+					sum.IsMonotonic = it.ReadBool()
+				default:
+					it.Skip()
+				}
+			})
+			m.Data = &otlpmetrics.Metric_Sum{Sum: sum}
+		case "histogram":
+			histogram := &otlpmetrics.Histogram{}
+			it.ReadObject(func(it *json.Iterator, field string) {
+				switch field {
+				case "dataPoints", "data_points":
+					it.ReadArray(func(it *json.Iterator) {
+						dp := &otlpmetrics.HistogramDataPoint{}
+						readHistogramDataPoint(it, dp)
+						histogram.DataPoints = append(histogram.DataPoints, dp)
+					})
+				case "aggregationTemporality", "aggregation_temporality":
+					histogram.AggregationTemporality = readAggregationTemporality(it)
+				default:
+					it.Skip()
+				}
+			})
+			m.Data = &otlpmetrics.Metric_Histogram{Histogram: histogram}
+		case "exponentialHistogram", "exponential_histogram":
+			histogram := &otlpmetrics.ExponentialHistogram{}
+			it.ReadObject(func(it *json.Iterator, field string) {
+				switch field {
+				case "dataPoints", "data_points":
+					it.ReadArray(func(it *json.Iterator) {
+						dp := &otlpmetrics.ExponentialHistogramDataPoint{}
+						readExponentialHistogramDataPoint(it, dp)
+						histogram.DataPoints = append(histogram.DataPoints, dp)
+					})
+				case "aggregationTemporality", "aggregation_temporality":
+					histogram.AggregationTemporality = readAggregationTemporality(it)
+				default:
+					it.Skip()
+				}
+			})
+			m.Data = &otlpmetrics.Metric_ExponentialHistogram{ExponentialHistogram: histogram}
+		case "summary":
+			summary := &otlpmetrics.Summary{}
+			it.ReadObject(func(it *json.Iterator, field string) {
+				switch field {
+				case "dataPoints", "data_points":
+					it.ReadArray(func(it *json.Iterator) {
+						dp := &otlpmetrics.SummaryDataPoint{}
+						readSummaryDataPoint(it, dp)
+						summary.DataPoints = append(summary.DataPoints, dp)
+					})
+				default:
+					it.Skip()
+				}
+			})
+			m.Data = &otlpmetrics.Metric_Summary{Summary: summary}
+		default:
+			it.Skip()
+		}
+	})
+}
+
+func readAggregationTemporality(it *json.Iterator) otlpmetrics.AggregationTemporality {
+	return otlpmetrics.AggregationTemporality(it.ReadEnum(otlpmetrics.AggregationTemporality_value))
+}
+
+func readNumberDataPoints(it *json.Iterator, dps []*otlpmetrics.NumberDataPoint) []*otlpmetrics.NumberDataPoint {
+	it.ReadArray(func(it *json.Iterator) {
+		dp := &otlpmetrics.NumberDataPoint{}
+		it.ReadObject(func(it *json.Iterator, field string) {
+			switch field {
+			case "attributes":
+				dp.Attributes = json.ReadAttributes(it, dp.Attributes)
+			case "startTimeUnixNano", "start_time_unix_nano":
+				dp.StartTimeUnixNano = it.ReadUint64()
+			case "timeUnixNano", "time_unix_nano":
+				dp.TimeUnixNano = it.ReadUint64()
+			case "asDouble", "as_double":
+				dp.Value = &otlpmetrics.NumberDataPoint_AsDouble{AsDouble: it.ReadFloat64()}
+			case "asInt", "as_int":
+				dp.Value = &otlpmetrics.NumberDataPoint_AsInt{AsInt: it.ReadInt64()}
+			case "exemplars":
+				dp.Exemplars = readExemplars(it, dp.Exemplars)
+			case "flags":
+				dp.Flags = it.ReadUint32()
+			default:
+				it.Skip()
+			}
+		})
+		dps = append(dps, dp)
+	})
+	return dps
+}
+
+func readHistogramDataPoint(it *json.Iterator, dp *otlpmetrics.HistogramDataPoint) {
+	it.ReadObject(func(it *json.Iterator, field string) {
+		switch field {
+		case "attributes":
+			dp.Attributes = json.ReadAttributes(it, dp.Attributes)
+		case "startTimeUnixNano", "start_time_unix_nano":
+			dp.StartTimeUnixNano = it.ReadUint64()
+		case "timeUnixNano", "time_unix_nano":
+			dp.TimeUnixNano = it.ReadUint64()
+		case "count":
+			dp.Count = it.ReadUint64()
+		case "sum":
+			dp.Sum_ = &otlpmetrics.HistogramDataPoint_Sum{Sum: it.ReadFloat64()}
+		case "bucketCounts", "bucket_counts":
+			dp.BucketCounts = readUint64s(it, dp.BucketCounts)
+		case "explicitBounds", "explicit_bounds":
+			it.ReadArray(func(it *json.Iterator) {
+				dp.ExplicitBounds = append(dp.ExplicitBounds, it.ReadFloat64())
+			})
+		case "exemplars":
+			dp.Exemplars = readExemplars(it, dp.Exemplars)
+		case "flags":
+			dp.Flags = it.ReadUint32()
+		default:
+			it.Skip()
+		}
+	})
+}
+
+func readExponentialHistogramDataPoint(it *json.Iterator, dp *otlpmetrics.ExponentialHistogramDataPoint) {
+	it.ReadObject(func(it *json.Iterator, field string) {
+		switch field {
+		case "attributes":
+			dp.Attributes = json.ReadAttributes(it, dp.Attributes)
+		case "startTimeUnixNano", "start_time_unix_nano":
+			dp.StartTimeUnixNano = it.ReadUint64()
+		case "timeUnixNano", "time_unix_nano":
+			dp.TimeUnixNano = it.ReadUint64()
+		case "count":
+			dp.Count = it.ReadUint64()
+		case "sum":
+			dp.Sum = it.ReadFloat64()
+		case "scale":
+			dp.Scale = it.ReadInt32()
+		case "zeroCount", "zero_count":
+			dp.ZeroCount = it.ReadUint64()
+		case "positive":
+			readBuckets(it, &dp.Positive)
+		case "negative":
+			readBuckets(it, &dp.Negative)
+		case "flags":
+			dp.Flags = it.ReadUint32()
+		case "exemplars":
+			dp.Exemplars = readExemplars(it, dp.Exemplars)
+		default:
+			it.Skip()
+		}
+	})
+}
+
+func readBuckets(it *json.Iterator, b *otlpmetrics.ExponentialHistogramDataPoint_Buckets) {
+	it.ReadObject(func(it *json.Iterator, field string) {
+		switch field {
+		case "offset":
+			b.Offset = it.ReadInt32()
+		case "bucketCounts", "bucket_counts":
+			b.BucketCounts = readUint64s(it, b.BucketCounts)
+		default:
+			it.Skip()
+		}
+	})
+}
+
+func readSummaryDataPoint(it *json.Iterator, dp *otlpmetrics.SummaryDataPoint) {
+	it.ReadObject(func(it *json.Iterator, field string) {
+		switch field {
+		case "attributes":
+			dp.Attributes = json.ReadAttributes(it, dp.Attributes)
+		case "startTimeUnixNano", "start_time_unix_nano":
+			dp.StartTimeUnixNano = it.ReadUint64()
+		case "timeUnixNano", "time_unix_nano":
+			dp.TimeUnixNano = it.ReadUint64()
+		case "count":
+			dp.Count = it.ReadUint64()
+		case "sum":
+			dp.Sum = it.ReadFloat64()
+		case "quantileValues", "quantile_values":
+			it.ReadArray(func(it *json.Iterator) {
+				qv := &otlpmetrics.SummaryDataPoint_ValueAtQuantile{}
+				it.ReadObject(func(it *json.Iterator, field string) {
+					switch field {
+					case "quantile":
+						qv.Quantile = it.ReadFloat64()
+					case "value":
+						qv.Value = it.ReadFloat64()
+					default:
+						it.Skip()
+					}
+				})
+				dp.QuantileValues = append(dp.QuantileValues, qv)
+			})
+		case "flags":
+			dp.Flags = it.ReadUint32()
+		default:
+			it.Skip()
+		}
+	})
+}
+
+func readExemplars(it *json.Iterator, exemplars []otlpmetrics.Exemplar) []otlpmetrics.Exemplar {
+	it.ReadArray(func(it *json.Iterator) {
+		exemplars = append(exemplars, otlpmetrics.Exemplar{})
+		e := &exemplars[len(exemplars)-1]
+		it.ReadObject(func(it *json.Iterator, field string) {
+			switch field {
+			case "filteredAttributes", "filtered_attributes":
+				e.FilteredAttributes = json.ReadAttributes(it, e.FilteredAttributes)
+			case "timeUnixNano", "time_unix_nano":
+				e.TimeUnixNano = it.ReadUint64()
+			case "asDouble", "as_double":
+				e.Value = &otlpmetrics.Exemplar_AsDouble{AsDouble: it.ReadFloat64()}
+			case "asInt", "as_int":
+				e.Value = &otlpmetrics.Exemplar_AsInt{AsInt: it.ReadInt64()}
+			case "spanId", "span_id":
+				e.SpanId = json.ReadSpanID(it)
+			case "traceId", "trace_id":
+				e.TraceId = json.ReadTraceID(it)
+			default:
+				it.Skip()
+			}
+		})
+	})
+	return exemplars
+}
+
+func readUint64s(it *json.Iterator, vs []uint64) []uint64 {
+	it.ReadArray(func(it *json.Iterator) {
+		vs = append(vs, it.ReadUint64())
+	})
+	return vs
+}
@@ -15,9 +15,17 @@
 package pmetric
 
 import (
+	"bytes"
+	"math"
 	"testing"
 
+	"github.com/gogo/protobuf/jsonpb"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 var metricsOTLP = func() Metrics {
@@ -31,19 +39,97 @@ var metricsOTLP = func() Metrics {
 	return md
 }()
 
+// metricsFull sets all the fields of the metrics, with a metric of each type.
+var metricsFull = func() Metrics {
+	md := NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.SetSchemaUrl("https://opentelemetry.io/schemas/1.0.0")
+	rm.Resource().Attributes().UpsertString("host.name", "testHost")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.SetSchemaUrl("https://opentelemetry.io/schemas/1.1.0")
+	sm.Scope().SetName("name")
+	sm.Scope().SetVersion("version")
+
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("gauge")
+	gauge.SetDescription("description")
+	gauge.SetUnit("1")
+	gauge.SetDataType(MetricDataTypeGauge)
+	ndp := gauge.Gauge().DataPoints().AppendEmpty()
+	ndp.Attributes().InsertString("key", "value")
+	ndp.SetStartTimestamp(1650000000000000000)
+	ndp.SetTimestamp(1650000000000000001)
+	ndp.SetDoubleVal(0)
+	ndp.SetFlags(NewMetricDataPointFlags(MetricDataPointFlagNoRecordedValue))
+	exemplar := ndp.Exemplars().AppendEmpty()
+	exemplar.FilteredAttributes().InsertString("key", "value")
+	exemplar.SetTimestamp(1650000000000000002)
+	exemplar.SetIntVal(math.MaxInt64)
+	exemplar.SetTraceID(pcommon.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	exemplar.SetSpanID(pcommon.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("sum")
+	sum.SetDataType(MetricDataTypeSum)
+	sum.Sum().SetAggregationTemporality(MetricAggregationTemporalityCumulative)
+	sum.Sum().SetIsMonotonic(true)
+	sum.Sum().DataPoints().AppendEmpty().SetIntVal(-1)
+
+	histogram := sm.Metrics().AppendEmpty()
+	histogram.SetName("histogram")
+	histogram.SetDataType(MetricDataTypeHistogram)
+	histogram.Histogram().SetAggregationTemporality(MetricAggregationTemporalityDelta)
+	hdp := histogram.Histogram().DataPoints().AppendEmpty()
+	hdp.SetCount(3)
+	hdp.SetSum(1e-7)
+	hdp.SetBucketCounts([]uint64{1, 0, 2})
+	hdp.SetExplicitBounds([]float64{-1.5, 1e21})
+	hdp.Exemplars().AppendEmpty().SetDoubleVal(1.5)
+	histogram.Histogram().DataPoints().AppendEmpty()
+
+	exponentialHistogram := sm.Metrics().AppendEmpty()
+	exponentialHistogram.SetName("exponential_histogram")
+	exponentialHistogram.SetDataType(MetricDataTypeExponentialHistogram)
+	exponentialHistogram.ExponentialHistogram().SetAggregationTemporality(MetricAggregationTemporalityDelta)
+	edp := exponentialHistogram.ExponentialHistogram().DataPoints().AppendEmpty()
+	edp.SetCount(5)
+	edp.SetSum(10.5)
+	edp.SetScale(-2)
+	edp.SetZeroCount(1)
+	edp.Positive().SetOffset(-3)
+	edp.Positive().SetBucketCounts([]uint64{1, 2})
+	edp.Negative().SetBucketCounts([]uint64{1})
+
+	summary := sm.Metrics().AppendEmpty()
+	summary.SetName("summary")
+	summary.SetDataType(MetricDataTypeSummary)
+	sdp := summary.Summary().DataPoints().AppendEmpty()
+	sdp.SetCount(2)
+	sdp.SetSum(math.Inf(1))
+	qv := sdp.QuantileValues().AppendEmpty()
+	qv.SetQuantile(0.5)
+	qv.SetValue(2.5)
+	sdp.QuantileValues().AppendEmpty()
+
+	sm.Metrics().AppendEmpty()
+	return md
+}()
+
 var metricsJSON = `{"resourceMetrics":[{"resource":{"attributes":[{"key":"host.name","value":{"stringValue":"testHost"}}]},"scopeMetrics":[{"scope":{"name":"name","version":"version"},"metrics":[{"name":"testMetric"}]}]}]}`
 
 func TestMetricsJSON(t *testing.T) {
-	encoder := NewJSONMarshaler()
-	jsonBuf, err := encoder.MarshalMetrics(metricsOTLP)
-	assert.NoError(t, err)
+	for _, md := range []Metrics{metricsOTLP, metricsFull, NewMetrics()} {
+		encoder := NewJSONMarshaler()
+		jsonBuf, err := encoder.MarshalMetrics(md)
+		assert.NoError(t, err)
 
-	decoder := NewJSONUnmarshaler()
-	var got interface{}
-	got, err = decoder.UnmarshalMetrics(jsonBuf)
-	assert.NoError(t, err)
+		decoder := NewJSONUnmarshaler()
+		var got interface{}
+		got, err = decoder.UnmarshalMetrics(jsonBuf)
+		assert.NoError(t, err)
 
-	assert.EqualValues(t, metricsOTLP, got)
+		assert.EqualValues(t, md, got)
+	}
 }
 
 func TestMetricsJSON_Marshal(t *testing.T) {
@@ -53,6 +139,54 @@ func TestMetricsJSON_Marshal(t *testing.T) {
 	assert.Equal(t, metricsJSON, string(jsonBuf))
 }
 
+func TestMetricsJSON_Full(t *testing.T) {
+	jsonBuf, err := NewJSONMarshaler().MarshalMetrics(metricsFull)
+	require.NoError(t, err)
+	assert.Contains(t, string(jsonBuf), `"asDouble":0,"exemplars":[{"filteredAttributes":[{"key":"key","value":{"stringValue":"value"}}],"timeUnixNano":"1650000000000000002","asInt":"9223372036854775807","spanId":"0102030405060708","traceId":"0102030405060708090a0b0c0d0e0f10"}],"flags":1`)
+	assert.Contains(t, string(jsonBuf), `"aggregationTemporality":2,"isMonotonic":true`)
+	assert.Contains(t, string(jsonBuf), `"count":"3","sum":1e-7,"bucketCounts":["1","0","2"],"explicitBounds":[-1.5,1e+21]`)
+	assert.Contains(t, string(jsonBuf), `"scale":-2,"zeroCount":"1","positive":{"offset":-3,"bucketCounts":["1","2"]},"negative":{"bucketCounts":["1"]}`)
+	assert.Contains(t, string(jsonBuf), `"sum":"Infinity","quantileValues":[{"quantile":0.5,"value":2.5},{}]`)
+
+	// The output can be read by jsonpb, and the output of jsonpb can be read back.
+	var pb otlpmetrics.MetricsData
+	require.NoError(t, jsonpb.Unmarshal(bytes.NewReader(jsonBuf), &pb))
+	assert.EqualValues(t, metricsFull, internal.MetricsFromProto(pb))
+
+	var buf bytes.Buffer
+	want := internal.MetricsToProto(metricsFull)
+	require.NoError(t, (&jsonpb.Marshaler{}).Marshal(&buf, &want))
+	got, err := NewJSONUnmarshaler().UnmarshalMetrics(buf.Bytes())
+	require.NoError(t, err)
+	assert.EqualValues(t, metricsFull, got)
+}
+
+func TestMetricsJSON_Unmarshal(t *testing.T) {
+	jsonBuf := `{"resource_metrics":[{"scope_metrics":[{"metrics":[{
+		"name": "sum",
+		"sum": {
+			"data_points": [{"as_int": 10, "time_unix_nano": 1650000000000000000}],
+			"aggregation_temporality": "AGGREGATION_TEMPORALITY_CUMULATIVE",
+			"is_monotonic": true
+		}
+	}]}]}]}`
+	md, err := NewJSONUnmarshaler().UnmarshalMetrics([]byte(jsonBuf))
+	require.NoError(t, err)
+
+	want := NewMetrics()
+	m := want.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("sum")
+	m.SetDataType(MetricDataTypeSum)
+	m.Sum().SetAggregationTemporality(MetricAggregationTemporalityCumulative)
+	m.Sum().SetIsMonotonic(true)
+	dp := m.Sum().DataPoints().AppendEmpty()
+	dp.SetIntVal(10)
+	dp.SetTimestamp(1650000000000000000)
+	assert.EqualValues(t, want, md)
+}
+
+// TestMetricsNil checks that the metrics using the data types removed from OTLP are read
+// without their data, as the unknown fields are ignored.
 func TestMetricsNil(t *testing.T) {
 	jsonBuf := `{
 "resourceMetrics": [
@@ -160,10 +294,69 @@ func TestMetricsNil(t *testing.T) {
 	}
 ]
 }`
-	decoder := NewJSONUnmarshaler()
-	var got interface{}
-	got, err := decoder.UnmarshalMetrics([]byte(jsonBuf))
-	assert.Error(t, err)
+	md, err := NewJSONUnmarshaler().UnmarshalMetrics([]byte(jsonBuf))
+	require.NoError(t, err)
+
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	assert.Equal(t, 4, md.ResourceMetrics().At(0).Resource().Attributes().Len())
+	require.Equal(t, 1, md.ResourceMetrics().At(0).ScopeMetrics().Len())
+	sm := md.ResourceMetrics().At(0).ScopeMetrics().At(0)
+	assert.Equal(t, "example-meter", sm.Scope().Name())
+	require.Equal(t, 3, sm.Metrics().Len())
+	assert.Equal(t, "metric_name", sm.Metrics().At(0).Name())
+	assert.Equal(t, MetricDataTypeNone, sm.Metrics().At(0).DataType())
+}
 
-	assert.EqualValues(t, Metrics{}, got)
+func TestMetricsJSON_UnmarshalError(t *testing.T) {
+	for _, jsonBuf := range []string{
+		`{"resourceMetrics":[{"scopeMetrics":[{"metrics":[{"sum":{"dataPoints":[{"asInt":"1.5"}]}}]}]}]}`,
+		`{"resourceMetrics":[{"scopeMetrics":[{"metrics":[{"sum":{"dataPoints":[{"asDouble":"one"}]}}]}]}]}`,
+		`{"resourceMetrics":[{"scopeMetrics":[{"metrics":[{"sum":{"aggregationTemporality":"DELTA"}}]}]}]}`,
+		`{"resourceMetrics":[{"scopeMetrics":[{"metrics":[{"histogram":{"dataPoints":[{"bucketCounts":[-1]}]}}]}]}]}`,
+		`{"resourceMetrics":[{"scopeMetrics":[{"metrics":[{"exponentialHistogram":{"dataPoints":[{"scale":2147483648}]}}]}]}]}`,
+	} {
+		_, err := NewJSONUnmarshaler().UnmarshalMetrics([]byte(jsonBuf))
+		assert.Error(t, err, jsonBuf)
+	}
+}
+
+func BenchmarkMetricsJSON_Marshal(b *testing.B) {
+	b.Run("pdata", func(b *testing.B) {
+		encoder := NewJSONMarshaler()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, err := encoder.MarshalMetrics(metricsFull)
+			require.NoError(b, err)
+		}
+	})
+	b.Run("jsonpb", func(b *testing.B) {
+		encoder := &jsonpb.Marshaler{}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var buf bytes.Buffer
+			pb := internal.MetricsToProto(metricsFull)
+			require.NoError(b, encoder.Marshal(&buf, &pb))
+		}
+	})
+}
+
+func BenchmarkMetricsJSON_Unmarshal(b *testing.B) {
+	jsonBuf, err := NewJSONMarshaler().MarshalMetrics(metricsFull)
+	require.NoError(b, err)
+	b.Run("pdata", func(b *testing.B) {
+		decoder := NewJSONUnmarshaler()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, err := decoder.UnmarshalMetrics(jsonBuf)
+			require.NoError(b, err)
+		}
+	})
+	b.Run("jsonpb", func(b *testing.B) {
+		decoder := &jsonpb.Unmarshaler{}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var pb otlpmetrics.MetricsData
+			require.NoError(b, decoder.Unmarshal(bytes.NewReader(jsonBuf), &pb))
+		}
+	})
 }
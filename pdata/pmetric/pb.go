@@ -34,11 +34,17 @@ func newPbMarshaler() *pbMarshaler {
 var _ Sizer = (*pbMarshaler)(nil)
 
 func (e *pbMarshaler) MarshalMetrics(md Metrics) ([]byte, error) {
+	if buf, ok := encodedBytes(md); ok {
+		return buf, nil
+	}
 	pb := internal.MetricsToProto(md)
 	return pb.Marshal()
 }
 
 func (e *pbMarshaler) MetricsSize(md Metrics) int {
+	if buf, ok := encodedBytes(md); ok {
+		return len(buf)
+	}
 	pb := internal.MetricsToProto(md)
 	return pb.Size()
 }
@@ -59,3 +65,10 @@ func (d *pbUnmarshaler) UnmarshalMetrics(buf []byte) (Metrics, error) {
 	err := pb.Unmarshal(buf)
 	return internal.MetricsFromProto(pb), err
 }
+
+// encodedBytes returns the received bytes of md that were not decoded yet,
+// the encoding of a request being the same as the one of MetricsData.
+func encodedBytes(md Metrics) ([]byte, bool) {
+	_, encoded, _ := internal.MetricsToEncodedOtlp(md)
+	return encoded.Bytes()
+}
@@ -20,15 +20,20 @@ import (
 
 	"github.com/gogo/protobuf/jsonpb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
 	"go.opentelemetry.io/collector/pdata/internal/otlp"
+	"go.opentelemetry.io/collector/pdata/internal/otlpwire"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
 var jsonMarshaler = &jsonpb.Marshaler{}
 var jsonUnmarshaler = &jsonpb.Unmarshaler{}
+var metricsJSONMarshaler = pmetric.NewJSONMarshaler()
+var metricsJSONUnmarshaler = pmetric.NewJSONUnmarshaler()
 
 // Response represents the response for gRPC/HTTP client/server.
 type Response struct {
@@ -68,55 +73,86 @@ func (mr Response) UnmarshalJSON(data []byte) error {
 // It's a wrapper for pmetric.Metrics data.
 type Request struct {
 	orig *otlpcollectormetrics.ExportMetricsServiceRequest
+	// encoded, if not nil, holds the received bytes of lazily decoded requests.
+	encoded *internal.EncodedOrig
+	// shared tracks the resources shared with other pmetric.Metrics.
+	shared *internal.SharedResourceMetrics
 }
 
 // NewRequest returns an empty Request.
 func NewRequest() Request {
-	return Request{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{}}
+	return Request{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{}, shared: &internal.SharedResourceMetrics{}}
+}
+
+// NewLazyRequest returns an empty Request that decodes the metrics lazily:
+// UnmarshalProto only validates and keeps the given bytes, which must not be modified afterwards,
+// and they are decoded the first time the content of the Metrics is accessed.
+// Until then, counting items and cloning the Metrics do not decode them, and marshaling them
+// to protobuf, including with Client, returns the received bytes.
+// The request is decoded as a whole: once any of its content is accessed, marshaling re-encodes
+// all of it, even the resources that were not modified.
+func NewLazyRequest() Request {
+	return Request{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{}, encoded: &internal.EncodedOrig{}, shared: &internal.SharedResourceMetrics{}}
 }
 
 // NewRequestFromMetrics returns a Request from pmetric.Metrics.
 // Because Request is a wrapper for pmetric.Metrics,
 // any changes to the provided Metrics struct will be reflected in the Request and vice versa.
 func NewRequestFromMetrics(m pmetric.Metrics) Request {
-	return Request{orig: internal.MetricsToOtlp(m)}
+	orig, encoded, shared := internal.MetricsToEncodedOtlp(m)
+	return Request{orig: orig, encoded: encoded, shared: shared}
 }
 
 // MarshalProto marshals Request into proto bytes.
 func (mr Request) MarshalProto() ([]byte, error) {
+	if buf, ok := mr.encoded.Bytes(); ok {
+		return buf, nil
+	}
 	return mr.orig.Marshal()
 }
 
 // UnmarshalProto unmarshalls Request from proto bytes.
 func (mr Request) UnmarshalProto(data []byte) error {
-	return mr.orig.Unmarshal(data)
+	if mr.encoded != nil {
+		mr.encoded.Discard()
+		*mr.orig = otlpcollectormetrics.ExportMetricsServiceRequest{}
+		// Invalid data is decoded right away to return the error.
+		if otlpwire.ValidMetrics(data) {
+			mr.encoded.Set(data)
+			return nil
+		}
+	}
+	if err := mr.orig.Unmarshal(data); err != nil {
+		return err
+	}
+	otlp.InstrumentationLibraryMetricsToScope(mr.orig.ResourceMetrics)
+	return nil
 }
 
 // MarshalJSON marshals Request into JSON bytes.
 func (mr Request) MarshalJSON() ([]byte, error) {
-	var buf bytes.Buffer
-	if err := jsonMarshaler.Marshal(&buf, mr.orig); err != nil {
-		return nil, err
-	}
-	return buf.Bytes(), nil
+	return metricsJSONMarshaler.MarshalMetrics(mr.Metrics())
 }
 
 // UnmarshalJSON unmarshalls Request from JSON bytes.
 func (mr Request) UnmarshalJSON(data []byte) error {
-	if err := jsonUnmarshaler.Unmarshal(bytes.NewReader(data), mr.orig); err != nil {
+	md, err := metricsJSONUnmarshaler.UnmarshalMetrics(data)
+	if err != nil {
 		return err
 	}
-	otlp.InstrumentationLibraryMetricsToScope(mr.orig.ResourceMetrics)
+	mr.encoded.Discard()
+	*mr.orig = *internal.MetricsToOtlp(md)
 	return nil
 }
 
 // Deprecated: [v0.50.0] Use NewRequestFromMetrics instead.
 func (mr Request) SetMetrics(ld pmetric.Metrics) {
+	mr.encoded.Discard()
 	*mr.orig = *internal.MetricsToOtlp(ld)
 }
 
 func (mr Request) Metrics() pmetric.Metrics {
-	return internal.MetricsFromOtlp(mr.orig)
+	return internal.MetricsFromEncodedOtlp(mr.orig, mr.encoded, mr.shared)
 }
 
 // Client is the client API for OTLP-GRPC Metrics service.
@@ -132,14 +168,22 @@ type Client interface {
 
 type metricsClient struct {
 	rawClient otlpcollectormetrics.MetricsServiceClient
+	cc        *grpc.ClientConn
 }
 
 // NewClient returns a new Client connected using the given connection.
 func NewClient(cc *grpc.ClientConn) Client {
-	return &metricsClient{rawClient: otlpcollectormetrics.NewMetricsServiceClient(cc)}
+	return &metricsClient{rawClient: otlpcollectormetrics.NewMetricsServiceClient(cc), cc: cc}
 }
 
 func (c *metricsClient) Export(ctx context.Context, request Request, opts ...grpc.CallOption) (Response, error) {
+	if buf, ok := request.encoded.Bytes(); ok {
+		rsp := &otlpcollectormetrics.ExportMetricsServiceResponse{}
+		if err := c.cc.Invoke(ctx, exportMethod, &otlpwire.RawMessage{Buf: buf}, rsp, opts...); err != nil {
+			return Response{}, err
+		}
+		return Response{orig: rsp}, nil
+	}
 	rsp, err := c.rawClient.Export(ctx, request.orig, opts...)
 	return Response{orig: rsp}, err
 }
@@ -158,12 +202,57 @@ func RegisterServer(s *grpc.Server, srv Server) {
 	otlpcollectormetrics.RegisterMetricsServiceServer(s, &rawMetricsServer{srv: srv})
 }
 
+// RegisterLazyServer registers the Server to the grpc.Server, like RegisterServer,
+// but the requests given to the Server are lazily decoded, see NewLazyRequest.
+func RegisterLazyServer(s *grpc.Server, srv Server) {
+	s.RegisterService(&lazyServiceDesc, srv)
+}
+
+const exportMethod = "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export"
+
+var lazyServiceDesc = grpc.ServiceDesc{
+	ServiceName: "opentelemetry.proto.collector.metrics.v1.MetricsService",
+	HandlerType: (*Server)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Export",
+			Handler:    lazyExportHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "opentelemetry/proto/collector/metrics/v1/metrics_service.proto",
+}
+
+func lazyExportHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := &otlpwire.RawMessage{}
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	request := NewLazyRequest()
+	if err := request.UnmarshalProto(in.Buf); err != nil {
+		// Same error as the one returned by grpc when the generated code fails to decode.
+		return nil, status.Errorf(codes.Internal, "grpc: error unmarshalling request: %v", err)
+	}
+	export := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		rsp, err := srv.(Server).Export(ctx, request)
+		return rsp.orig, err
+	}
+	if interceptor == nil {
+		return export(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: exportMethod,
+	}
+	return interceptor(ctx, in, info, export)
+}
+
 type rawMetricsServer struct {
 	srv Server
 }
 
 func (s rawMetricsServer) Export(ctx context.Context, request *otlpcollectormetrics.ExportMetricsServiceRequest) (*otlpcollectormetrics.ExportMetricsServiceResponse, error) {
 	otlp.InstrumentationLibraryMetricsToScope(request.ResourceMetrics)
-	rsp, err := s.srv.Export(ctx, Request{orig: request})
+	rsp, err := s.srv.Export(ctx, Request{orig: request, shared: &internal.SharedResourceMetrics{}})
 	return rsp.orig, err
 }
@@ -33,6 +33,8 @@ import (
 
 	v1 "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
 	"go.opentelemetry.io/collector/pdata/internal/otlp"
+	"go.opentelemetry.io/collector/pdata/internal/otlpwire"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
@@ -46,10 +48,8 @@ var metricsRequestJSON = []byte(`
 	{
 		"resourceMetrics": [
 			{
-				"resource": {},
 				"scopeMetrics": [
 					{
-						"scope": {},
 						"metrics": [
 							{
 								"name": "test_metric"
@@ -314,3 +314,187 @@ func generateMetricsRequestWithInstrumentationLibrary() Request {
 	mr.orig.ResourceMetrics[0].ScopeMetrics = []*v1.ScopeMetrics{}
 	return mr
 }
+
+func TestLazyRequest(t *testing.T) {
+	buf, err := generateMetricsRequest().MarshalProto()
+	require.NoError(t, err)
+
+	req := NewLazyRequest()
+	require.NoError(t, req.UnmarshalProto(buf))
+	td := req.Metrics()
+	assert.Equal(t, 1, td.DataPointCount())
+	clone := td.Clone()
+	got, err := req.MarshalProto()
+	require.NoError(t, err)
+	assert.Equal(t, buf, got)
+	got, err = pmetric.NewProtoMarshaler().MarshalMetrics(clone)
+	require.NoError(t, err)
+	assert.Equal(t, buf, got)
+	_, pending := req.encoded.Bytes()
+	assert.True(t, pending)
+
+	assert.Equal(t, "test_metric", td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+	_, pending = req.encoded.Bytes()
+	assert.False(t, pending)
+	assert.Equal(t, generateMetricsRequest().Metrics(), req.Metrics())
+	assert.Equal(t, generateMetricsRequest().Metrics().ResourceMetrics(), clone.ResourceMetrics())
+	td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).SetName("changed")
+	got, err = req.MarshalProto()
+	require.NoError(t, err)
+	assert.NotEqual(t, buf, got)
+}
+
+func TestLazyRequestDecodedOnError(t *testing.T) {
+	req := NewLazyRequest()
+	assert.Equal(t, NewRequest().UnmarshalProto([]byte{0xff}), req.UnmarshalProto([]byte{0xff}))
+
+	buf, err := generateMetricsRequestWithInstrumentationLibrary().MarshalProto()
+	require.NoError(t, err)
+	require.NoError(t, req.UnmarshalProto(buf))
+	_, pending := req.encoded.Bytes()
+	assert.False(t, pending)
+	assert.Equal(t, generateMetricsRequest().Metrics(), req.Metrics())
+}
+
+type fakeLazyServer struct {
+	t *testing.T
+}
+
+func (s fakeLazyServer) Export(_ context.Context, req Request) (Response, error) {
+	td := req.Metrics()
+	assert.Equal(s.t, 1, td.DataPointCount())
+	_, pending := req.encoded.Bytes()
+	assert.True(s.t, pending)
+	td.ResourceMetrics()
+	assert.Equal(s.t, generateMetricsRequest().Metrics(), req.Metrics())
+	return NewResponse(), nil
+}
+
+func TestGrpcLazy(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	RegisterLazyServer(s, &fakeLazyServer{t: t})
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, s.Serve(lis))
+	}()
+	t.Cleanup(func() {
+		s.Stop()
+		wg.Wait()
+	})
+
+	cc, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock())
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, cc.Close())
+	})
+
+	metricClient := NewClient(cc)
+	resp, err := metricClient.Export(context.Background(), generateMetricsRequest())
+	assert.NoError(t, err)
+	assert.Equal(t, NewResponse(), resp)
+
+	err = cc.Invoke(context.Background(), exportMethod, &otlpwire.RawMessage{Buf: []byte{0xff}}, NewResponse().orig)
+	st, okSt := status.FromError(err)
+	require.True(t, okSt)
+	assert.Equal(t, codes.Internal, st.Code())
+}
+
+func TestGrpcLazyClient(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	RegisterServer(s, &fakeMetricsServer{t: t})
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, s.Serve(lis))
+	}()
+	t.Cleanup(func() {
+		s.Stop()
+		wg.Wait()
+	})
+
+	cc, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock())
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, cc.Close())
+	})
+
+	buf, err := generateMetricsRequest().MarshalProto()
+	require.NoError(t, err)
+	req := NewLazyRequest()
+	require.NoError(t, req.UnmarshalProto(buf))
+
+	metricClient := NewClient(cc)
+	resp, err := metricClient.Export(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, NewResponse(), resp)
+	_, pending := req.encoded.Bytes()
+	assert.True(t, pending)
+}
+
+func BenchmarkRequestPassThrough(b *testing.B) {
+	buf, err := generateLargeMetricsRequest().MarshalProto()
+	require.NoError(b, err)
+
+	b.Run("eager", func(b *testing.B) {
+		benchmarkPassThrough(b, buf, NewRequest)
+	})
+	b.Run("lazy", func(b *testing.B) {
+		benchmarkPassThrough(b, buf, NewLazyRequest)
+	})
+}
+
+func benchmarkPassThrough(b *testing.B, buf []byte, newRequest func() Request) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		req := newRequest()
+		if err := req.UnmarshalProto(buf); err != nil {
+			b.Fatal(err)
+		}
+		if req.Metrics().DataPointCount() == 0 {
+			b.Fatal("no data points")
+		}
+		if _, err := req.MarshalProto(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func generateLargeMetricsRequest() Request {
+	md := pmetric.NewMetrics()
+	for i := 0; i < 10; i++ {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().InsertString("service.name", "service")
+		rm.Resource().Attributes().InsertInt("resource.index", int64(i))
+		ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+		for j := 0; j < 10; j++ {
+			m := ms.AppendEmpty()
+			m.SetName("metric")
+			m.SetDataType(pmetric.MetricDataTypeSum)
+			m.Sum().SetIsMonotonic(true)
+			m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+			dp := m.Sum().DataPoints().AppendEmpty()
+			dp.SetStartTimestamp(pcommon.Timestamp(1000))
+			dp.SetTimestamp(pcommon.Timestamp(2000))
+			dp.SetIntVal(int64(j))
+			dp.Attributes().InsertString("http.method", "GET")
+			dp.Attributes().InsertInt("http.status_code", 200)
+		}
+	}
+	return NewRequestFromMetrics(md)
+}
@@ -15,12 +15,9 @@
 package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
 
 import (
-	"bytes"
-
-	"github.com/gogo/protobuf/jsonpb"
-
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+	"go.opentelemetry.io/collector/pdata/internal/json"
 	"go.opentelemetry.io/collector/pdata/internal/otlp"
 )
 
@@ -29,39 +26,292 @@ func NewJSONMarshaler() Marshaler {
 	return newJSONMarshaler()
 }
 
-type jsonMarshaler struct {
-	delegate jsonpb.Marshaler
-}
+type jsonMarshaler struct{}
 
 func newJSONMarshaler() *jsonMarshaler {
-	return &jsonMarshaler{delegate: jsonpb.Marshaler{}}
+	return &jsonMarshaler{}
 }
 
 func (e *jsonMarshaler) MarshalTraces(td Traces) ([]byte, error) {
-	buf := bytes.Buffer{}
 	pb := internal.TracesToProto(td)
-	err := e.delegate.Marshal(&buf, &pb)
-	return buf.Bytes(), err
+	s := json.NewStream(256 * td.SpanCount())
+	s.WriteObjectStart()
+	if len(pb.ResourceSpans) > 0 {
+		s.WriteObjectField("resourceSpans")
+		s.WriteArrayStart()
+		for _, rs := range pb.ResourceSpans {
+			s.WriteArrayElement()
+			writeResourceSpans(s, rs)
+		}
+		s.WriteArrayEnd()
+	}
+	s.WriteObjectEnd()
+	return s.Buffer(), nil
+}
+
+func writeResourceSpans(s *json.Stream, rs *otlptrace.ResourceSpans) {
+	s.WriteObjectStart()
+	json.WriteResource(s, &rs.Resource)
+	if len(rs.ScopeSpans) > 0 {
+		s.WriteObjectField("scopeSpans")
+		s.WriteArrayStart()
+		for _, ss := range rs.ScopeSpans {
+			s.WriteArrayElement()
+			writeScopeSpans(s, ss)
+		}
+		s.WriteArrayEnd()
+	}
+	s.WriteStringField("schemaUrl", rs.SchemaUrl)
+	s.WriteObjectEnd()
+}
+
+func writeScopeSpans(s *json.Stream, ss *otlptrace.ScopeSpans) {
+	s.WriteObjectStart()
+	json.WriteScope(s, &ss.Scope)
+	if len(ss.Spans) > 0 {
+		s.WriteObjectField("spans")
+		s.WriteArrayStart()
+		for _, span := range ss.Spans {
+			s.WriteArrayElement()
+			writeSpan(s, span)
+		}
+		s.WriteArrayEnd()
+	}
+	s.WriteStringField("schemaUrl", ss.SchemaUrl)
+	s.WriteObjectEnd()
 }
 
-type jsonUnmarshaler struct {
-	delegate jsonpb.Unmarshaler
+func writeSpan(s *json.Stream, span *otlptrace.Span) {
+	s.WriteObjectStart()
+	json.WriteTraceID(s, "traceId", span.TraceId)
+	json.WriteSpanID(s, "spanId", span.SpanId)
+	s.WriteStringField("traceState", span.TraceState)
+	json.WriteSpanID(s, "parentSpanId", span.ParentSpanId)
+	s.WriteStringField("name", span.Name)
+	s.WriteInt32Field("kind", int32(span.Kind))
+	s.WriteUint64Field("startTimeUnixNano", span.StartTimeUnixNano)
+	s.WriteUint64Field("endTimeUnixNano", span.EndTimeUnixNano)
+	json.WriteAttributes(s, "attributes", span.Attributes)
+	s.WriteUint32Field("droppedAttributesCount", span.DroppedAttributesCount)
+	if len(span.Events) > 0 {
+		s.WriteObjectField("events")
+		s.WriteArrayStart()
+		for _, event := range span.Events {
+			s.WriteArrayElement()
+			s.WriteObjectStart()
+			s.WriteUint64Field("timeUnixNano", event.TimeUnixNano)
+			s.WriteStringField("name", event.Name)
+			json.WriteAttributes(s, "attributes", event.Attributes)
+			s.WriteUint32Field("droppedAttributesCount", event.DroppedAttributesCount)
+			s.WriteObjectEnd()
+		}
+		s.WriteArrayEnd()
+	}
+	s.WriteUint32Field("droppedEventsCount", span.DroppedEventsCount)
+	if len(span.Links) > 0 {
+		s.WriteObjectField("links")
+		s.WriteArrayStart()
+		for _, link := range span.Links {
+			s.WriteArrayElement()
+			s.WriteObjectStart()
+			json.WriteTraceID(s, "traceId", link.TraceId)
+			json.WriteSpanID(s, "spanId", link.SpanId)
+			s.WriteStringField("traceState", link.TraceState)
+			json.WriteAttributes(s, "attributes", link.Attributes)
+			s.WriteUint32Field("droppedAttributesCount", link.DroppedAttributesCount)
+			s.WriteObjectEnd()
+		}
+		s.WriteArrayEnd()
+	}
+	s.WriteUint32Field("droppedLinksCount", span.DroppedLinksCount)
+	if span.Status.Message != "" || span.Status.Code != 0 {
+		s.WriteObjectField("status")
+		s.WriteObjectStart()
+		s.WriteStringField("message", span.Status.Message)
+		s.WriteInt32Field("code", int32(span.Status.Code))
+		s.WriteObjectEnd()
+	}
+	s.WriteObjectEnd()
 }
 
+type jsonUnmarshaler struct{}
+
 // NewJSONUnmarshaler returns a model.Unmarshaler. Unmarshals from OTLP json bytes.
 func NewJSONUnmarshaler() Unmarshaler {
 	return newJSONUnmarshaler()
 }
 
 func newJSONUnmarshaler() *jsonUnmarshaler {
-	return &jsonUnmarshaler{delegate: jsonpb.Unmarshaler{}}
+	return &jsonUnmarshaler{}
 }
 
 func (d *jsonUnmarshaler) UnmarshalTraces(buf []byte) (Traces, error) {
+	it := json.NewIterator(buf)
 	td := otlptrace.TracesData{}
-	if err := d.delegate.Unmarshal(bytes.NewReader(buf), &td); err != nil {
+	it.ReadObject(func(it *json.Iterator, field string) {
+		switch field {
+		case "resourceSpans", "resource_spans":
+			it.ReadArray(func(it *json.Iterator) {
+				rs := &otlptrace.ResourceSpans{}
+				readResourceSpans(it, rs)
+				td.ResourceSpans = append(td.ResourceSpans, rs)
+			})
+		default:
+			it.Skip()
+		}
+	})
+	it.ReadEOF()
+	if err := it.Error(); err != nil {
 		return Traces{}, err
 	}
 	otlp.InstrumentationLibrarySpansToScope(td.ResourceSpans)
 	return internal.TracesFromProto(td), nil
 }
+
+func readResourceSpans(it *json.Iterator, rs *otlptrace.ResourceSpans) {
+	it.ReadObject(func(it *json.Iterator, field string) {
+		switch field {
+		case "resource":
+			json.ReadResource(it, &rs.Resource)
+		case "scopeSpans", "scope_spans":
+			it.ReadArray(func(it *json.Iterator) {
+				ss := &otlptrace.ScopeSpans{}
+				it.ReadObject(func(it *json.Iterator, field string) {
+					switch field {
+					case "scope":
+						json.ReadScope(it, &ss.Scope)
+					case "spans":
+						ss.Spans = readSpans(it, ss.Spans)
+					case "schemaUrl", "schema_url":
+						ss.SchemaUrl = it.ReadString()
+					default:
+						it.Skip()
+					}
+				})
+				rs.ScopeSpans = append(rs.ScopeSpans, ss)
+			})
+		case "instrumentationLibrarySpans", "instrumentation_library_spans":
+			it.ReadArray(func(it *json.Iterator) {
+				ils := &otlptrace.InstrumentationLibrarySpans{}
+				it.ReadObject(func(it *json.Iterator, field string) {
+					switch field {
+					case "instrumentationLibrary", "instrumentation_library":
+						json.ReadInstrumentationLibrary(it, &ils.InstrumentationLibrary)
+					case "spans":
+						ils.Spans = readSpans(it, ils.Spans)
+					case "schemaUrl", "schema_url":
+						ils.SchemaUrl = it.ReadString()
+					default:
+						it.Skip()
+					}
+				})
+				rs.InstrumentationLibrarySpans = append(rs.InstrumentationLibrarySpans, ils)
+			})
+		case "schemaUrl", "schema_url":
+			rs.SchemaUrl = it.ReadString()
+		default:
+			it.Skip()
+		}
+	})
+}
+
+func readSpans(it *json.Iterator, spans []*otlptrace.Span) []*otlptrace.Span {
+	it.ReadArray(func(it *json.Iterator) {
+		span := &otlptrace.Span{}
+		readSpan(it, span)
+		spans = append(spans, span)
+	})
+	return spans
+}
+
+func readSpan(it *json.Iterator, span *otlptrace.Span) {
+	it.ReadObject(func(it *json.Iterator, field string) {
+		switch field {
+		case "traceId", "trace_id":
+			span.TraceId = json.ReadTraceID(it)
+		case "spanId", "span_id":
+			span.SpanId = json.ReadSpanID(it)
+		case "traceState", "trace_state":
+			span.TraceState = it.ReadString()
+		case "parentSpanId", "parent_span_id":
+			span.ParentSpanId = json.ReadSpanID(it)
+		case "name":
+			span.Name = it.ReadString()
+		case "kind":
+			span.Kind = otlptrace.Span_SpanKind(it.ReadEnum(otlptrace.Span_SpanKind_value))
+		case "startTimeUnixNano", "start_time_unix_nano":
+			span.StartTimeUnixNano = it.ReadUint64()
+		case "endTimeUnixNano", "end_time_unix_nano":
+			span.EndTimeUnixNano = it.ReadUint64()
+		case "attributes":
+			span.Attributes = json.ReadAttributes(it, span.Attributes)
+		case "droppedAttributesCount", "dropped_attributes_count":
+			span.DroppedAttributesCount = it.ReadUint32()
+		case "events":
+			it.ReadArray(func(it *json.Iterator) {
+				event := &otlptrace.Span_Event{}
+				readSpanEvent(it, event)
+				span.Events = append(span.Events, event)
+			})
+		case "droppedEventsCount", "dropped_events_count":
+			span.DroppedEventsCount = it.ReadUint32()
+		case "links":
+			it.ReadArray(func(it *json.Iterator) {
+				link := &otlptrace.Span_Link{}
+				readSpanLink(it, link)
+				span.Links = append(span.Links, link)
+			})
+		case "droppedLinksCount", "dropped_links_count":
+			span.DroppedLinksCount = it.ReadUint32()
+		case "status":
+			it.ReadObject(func(it *json.Iterator, field string) {
+				switch field {
+				case "message":
+					span.Status.Message = it.ReadString()
+				case "code":
+					span.Status.Code = otlptrace.Status_StatusCode(it.ReadEnum(otlptrace.Status_StatusCode_value))
+				default:
+					it.Skip()
+				}
+			})
+		default:
+			it.Skip()
+		}
+	})
+}
+
+func readSpanEvent(it *json.Iterator, event *otlptrace.Span_Event) {
+	it.ReadObject(func(it *json.Iterator, field string) {
+		switch field {
+		case "timeUnixNano", "time_unix_nano":
+			event.TimeUnixNano = it.ReadUint64()
+		case "name":
+			event.Name = it.ReadString()
+		case "attributes":
+			event.Attributes = json.ReadAttributes(it, event.Attributes)
+		case "droppedAttributesCount", "dropped_attributes_count":
+			event.DroppedAttributesCount = it.ReadUint32()
+		default:
+			it.Skip()
+		}
+	})
+}
+
+func readSpanLink(it *json.Iterator, link *otlptrace.Span_Link) {
+	it.ReadObject(func(it *json.Iterator, field string) {
+		switch field {
+		case "traceId", "trace_id":
+			link.TraceId = json.ReadTraceID(it)
+		case "spanId", "span_id":
+			link.SpanId = json.ReadSpanID(it)
+		case "traceState", "trace_state":
+			link.TraceState = it.ReadString()
+		case "attributes":
+			link.Attributes = json.ReadAttributes(it, link.Attributes)
+		case "droppedAttributesCount", "dropped_attributes_count":
+			link.DroppedAttributesCount = it.ReadUint32()
+		default:
+			it.Skip()
+		}
+	})
+}
@@ -15,9 +15,17 @@
 package ptrace
 
 import (
+	"bytes"
+	"math"
 	"testing"
 
+	"github.com/gogo/protobuf/jsonpb"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 var tracesOTLP = func() Traces {
@@ -31,19 +39,79 @@ var tracesOTLP = func() Traces {
 	return td
 }()
 
-var tracesJSON = `{"resourceSpans":[{"resource":{"attributes":[{"key":"host.name","value":{"stringValue":"testHost"}}]},"scopeSpans":[{"scope":{"name":"name","version":"version"},"spans":[{"traceId":"","spanId":"","parentSpanId":"","name":"testSpan","status":{}}]}]}]}`
+var tracesJSON = `{"resourceSpans":[{"resource":{"attributes":[{"key":"host.name","value":{"stringValue":"testHost"}}]},"scopeSpans":[{"scope":{"name":"name","version":"version"},"spans":[{"name":"testSpan"}]}]}]}`
+
+// tracesFull sets all the fields of the traces.
+var tracesFull = func() Traces {
+	td := NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl("https://opentelemetry.io/schemas/1.0.0")
+	rs.Resource().Attributes().UpsertString("host.name", "testHost")
+	rs.Resource().SetDroppedAttributesCount(1)
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.SetSchemaUrl("https://opentelemetry.io/schemas/1.1.0")
+	ss.Scope().SetName("name")
+	ss.Scope().SetVersion("version")
+
+	span := ss.Spans().AppendEmpty()
+	span.SetTraceID(pcommon.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	span.SetSpanID(pcommon.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	span.SetParentSpanID(pcommon.NewSpanID([8]byte{8, 7, 6, 5, 4, 3, 2, 1}))
+	span.SetTraceState("congo=t61rcWkgMzE")
+	span.SetName("testSpan \"quoted\"\né ")
+	span.SetKind(SpanKindServer)
+	span.SetStartTimestamp(1650000000000000000)
+	span.SetEndTimestamp(math.MaxUint64)
+	attrs := span.Attributes()
+	attrs.InsertString("string", "value")
+	attrs.InsertInt("int", math.MinInt64)
+	attrs.InsertDouble("double", 1.5)
+	attrs.InsertDouble("inf", math.Inf(-1))
+	attrs.InsertBool("bool", true)
+	attrs.InsertBytes("bytes", []byte{0, 1, 2, 255})
+	attrs.InsertNull("empty")
+	slice := pcommon.NewValueSlice()
+	slice.SliceVal().AppendEmpty().SetIntVal(1)
+	slice.SliceVal().AppendEmpty()
+	slice.SliceVal().AppendEmpty().SetStringVal("two")
+	attrs.Insert("slice", slice)
+	m := pcommon.NewValueMap()
+	m.MapVal().InsertBool("nested", false)
+	attrs.Insert("map", m)
+	span.SetDroppedAttributesCount(2)
+	event := span.Events().AppendEmpty()
+	event.SetTimestamp(1650000000000000001)
+	event.SetName("event")
+	event.Attributes().InsertString("key", "value")
+	event.SetDroppedAttributesCount(3)
+	span.SetDroppedEventsCount(4)
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(pcommon.NewTraceID([16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}))
+	link.SetSpanID(pcommon.NewSpanID([8]byte{1, 1, 1, 1, 1, 1, 1, 1}))
+	link.SetTraceState("state")
+	link.Attributes().InsertString("key", "value")
+	link.SetDroppedAttributesCount(5)
+	span.SetDroppedLinksCount(6)
+	span.Status().SetCode(StatusCodeError)
+	span.Status().SetMessage("error")
+
+	ss.Spans().AppendEmpty()
+	return td
+}()
 
 func TestTracesJSON(t *testing.T) {
-	encoder := NewJSONMarshaler()
-	jsonBuf, err := encoder.MarshalTraces(tracesOTLP)
-	assert.NoError(t, err)
+	for _, td := range []Traces{tracesOTLP, tracesFull, NewTraces()} {
+		encoder := NewJSONMarshaler()
+		jsonBuf, err := encoder.MarshalTraces(td)
+		assert.NoError(t, err)
 
-	decoder := NewJSONUnmarshaler()
-	var got interface{}
-	got, err = decoder.UnmarshalTraces(jsonBuf)
-	assert.NoError(t, err)
+		decoder := NewJSONUnmarshaler()
+		var got interface{}
+		got, err = decoder.UnmarshalTraces(jsonBuf)
+		assert.NoError(t, err)
 
-	assert.EqualValues(t, tracesOTLP, got)
+		assert.EqualValues(t, td, got)
+	}
 }
 
 func TestTracesJSON_Marshal(t *testing.T) {
@@ -52,3 +120,129 @@ func TestTracesJSON_Marshal(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, tracesJSON, string(jsonBuf))
 }
+
+func TestTracesJSON_Full(t *testing.T) {
+	jsonBuf, err := NewJSONMarshaler().MarshalTraces(tracesFull)
+	require.NoError(t, err)
+	assert.Contains(t, string(jsonBuf), `"traceId":"0102030405060708090a0b0c0d0e0f10"`)
+	assert.Contains(t, string(jsonBuf), `"kind":2`)
+	assert.Contains(t, string(jsonBuf), `"endTimeUnixNano":"18446744073709551615"`)
+	assert.Contains(t, string(jsonBuf), `{"key":"int","value":{"intValue":"-9223372036854775808"}}`)
+	assert.Contains(t, string(jsonBuf), `{"key":"inf","value":{"doubleValue":"-Infinity"}}`)
+	assert.Contains(t, string(jsonBuf), `{"key":"bytes","value":{"bytesValue":"AAEC/w=="}}`)
+	assert.Contains(t, string(jsonBuf), `{"key":"empty"}`)
+	assert.Contains(t, string(jsonBuf), `{"arrayValue":{"values":[{"intValue":"1"},{},{"stringValue":"two"}]}}`)
+	assert.Contains(t, string(jsonBuf), `"status":{"message":"error","code":2}`)
+
+	// The output can be read by jsonpb, and the output of jsonpb can be read back.
+	var pb otlptrace.TracesData
+	require.NoError(t, jsonpb.Unmarshal(bytes.NewReader(jsonBuf), &pb))
+	assert.EqualValues(t, tracesFull, internal.TracesFromProto(pb))
+
+	var buf bytes.Buffer
+	want := internal.TracesToProto(tracesFull)
+	require.NoError(t, (&jsonpb.Marshaler{}).Marshal(&buf, &want))
+	got, err := NewJSONUnmarshaler().UnmarshalTraces(buf.Bytes())
+	require.NoError(t, err)
+	assert.EqualValues(t, tracesFull, got)
+}
+
+func TestTracesJSON_Unmarshal(t *testing.T) {
+	// snake_case names, enum names, 64 bits integers as numbers, unknown fields and
+	// deprecated instrumentation library.
+	jsonBuf := `{
+	"resource_spans": [{
+		"resource": {"attributes": [{"key": "host.name", "value": {"string_value": "testHost"}}]},
+		"instrumentation_library_spans": [{
+			"instrumentation_library": {"name": "name", "version": "version"},
+			"spans": [{
+				"trace_id": "0102030405060708090a0b0c0d0e0f10",
+				"span_id": "0102030405060708",
+				"name": "testSpan",
+				"kind": "SPAN_KIND_CLIENT",
+				"start_time_unix_nano": 1650000000000000000,
+				"end_time_unix_nano": null,
+				"unknown": {"nested": [1, "two", true, null]},
+				"attributes": [{"key": "int", "value": {"int_value": 10}}],
+				"status": {"code": "STATUS_CODE_OK"}
+			}]
+		}]
+	}]
+}`
+	td, err := NewJSONUnmarshaler().UnmarshalTraces([]byte(jsonBuf))
+	require.NoError(t, err)
+
+	want := NewTraces()
+	rs := want.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().UpsertString("host.name", "testHost")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("name")
+	ss.Scope().SetVersion("version")
+	span := ss.Spans().AppendEmpty()
+	span.SetTraceID(pcommon.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	span.SetSpanID(pcommon.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	span.SetName("testSpan")
+	span.SetKind(SpanKindClient)
+	span.SetStartTimestamp(1650000000000000000)
+	span.Attributes().InsertInt("int", 10)
+	span.Status().SetCode(StatusCodeOk)
+	assert.EqualValues(t, want, td)
+}
+
+func TestTracesJSON_UnmarshalError(t *testing.T) {
+	for _, jsonBuf := range []string{
+		``,
+		`[]`,
+		`{"resourceSpans":[}`,
+		`{"resourceSpans":[]}{}`,
+		`{"resourceSpans":[{"scopeSpans":[{"spans":[{"traceId":"0102"}]}]}]}`,
+		`{"resourceSpans":[{"scopeSpans":[{"spans":[{"spanId":"zz02030405060708"}]}]}]}`,
+		`{"resourceSpans":[{"scopeSpans":[{"spans":[{"kind":"SPAN_KIND_UNKNOWN"}]}]}]}`,
+		`{"resourceSpans":[{"scopeSpans":[{"spans":[{"startTimeUnixNano":"-1"}]}]}]}`,
+		`{"resourceSpans":[{"scopeSpans":[{"spans":[{"name":"unterminated}]}]}]}`,
+	} {
+		_, err := NewJSONUnmarshaler().UnmarshalTraces([]byte(jsonBuf))
+		assert.Error(t, err, jsonBuf)
+	}
+}
+
+func BenchmarkTracesJSON_Marshal(b *testing.B) {
+	b.Run("pdata", func(b *testing.B) {
+		encoder := NewJSONMarshaler()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, err := encoder.MarshalTraces(tracesFull)
+			require.NoError(b, err)
+		}
+	})
+	b.Run("jsonpb", func(b *testing.B) {
+		encoder := &jsonpb.Marshaler{}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var buf bytes.Buffer
+			pb := internal.TracesToProto(tracesFull)
+			require.NoError(b, encoder.Marshal(&buf, &pb))
+		}
+	})
+}
+
+func BenchmarkTracesJSON_Unmarshal(b *testing.B) {
+	jsonBuf, err := NewJSONMarshaler().MarshalTraces(tracesFull)
+	require.NoError(b, err)
+	b.Run("pdata", func(b *testing.B) {
+		decoder := NewJSONUnmarshaler()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, err := decoder.UnmarshalTraces(jsonBuf)
+			require.NoError(b, err)
+		}
+	})
+	b.Run("jsonpb", func(b *testing.B) {
+		decoder := &jsonpb.Unmarshaler{}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var pb otlptrace.TracesData
+			require.NoError(b, decoder.Unmarshal(bytes.NewReader(jsonBuf), &pb))
+		}
+	})
+}
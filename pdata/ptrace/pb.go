@@ -34,11 +34,17 @@ func newPbMarshaler() *pbMarshaler {
 var _ Sizer = (*pbMarshaler)(nil)
 
 func (e *pbMarshaler) MarshalTraces(td Traces) ([]byte, error) {
+	if buf, ok := encodedBytes(td); ok {
+		return buf, nil
+	}
 	pb := internal.TracesToProto(td)
 	return pb.Marshal()
 }
 
 func (e *pbMarshaler) TracesSize(td Traces) int {
+	if buf, ok := encodedBytes(td); ok {
+		return len(buf)
+	}
 	pb := internal.TracesToProto(td)
 	return pb.Size()
 }
@@ -59,3 +65,10 @@ func (d *pbUnmarshaler) UnmarshalTraces(buf []byte) (Traces, error) {
 	err := pb.Unmarshal(buf)
 	return internal.TracesFromProto(pb), err
 }
+
+// encodedBytes returns the received bytes of td that were not decoded yet,
+// the encoding of a request being the same as the one of TracesData.
+func encodedBytes(td Traces) ([]byte, bool) {
+	_, encoded, _ := internal.TracesToEncodedOtlp(td)
+	return encoded.Bytes()
+}
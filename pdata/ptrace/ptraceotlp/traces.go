@@ -20,15 +20,20 @@ import (
 
 	"github.com/gogo/protobuf/jsonpb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
 	"go.opentelemetry.io/collector/pdata/internal/otlp"
+	"go.opentelemetry.io/collector/pdata/internal/otlpwire"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
 var jsonMarshaler = &jsonpb.Marshaler{}
 var jsonUnmarshaler = &jsonpb.Unmarshaler{}
+var tracesJSONMarshaler = ptrace.NewJSONMarshaler()
+var tracesJSONUnmarshaler = ptrace.NewJSONUnmarshaler()
 
 // Response represents the response for gRPC/HTTP client/server.
 type Response struct {
@@ -68,27 +73,55 @@ func (tr Response) UnmarshalJSON(data []byte) error {
 // It's a wrapper for ptrace.Traces data.
 type Request struct {
 	orig *otlpcollectortrace.ExportTraceServiceRequest
+	// encoded, if not nil, holds the received bytes of lazily decoded requests.
+	encoded *internal.EncodedOrig
+	// shared tracks the resources shared with other ptrace.Traces.
+	shared *internal.SharedResourceSpans
 }
 
 // NewRequest returns an empty Request.
 func NewRequest() Request {
-	return Request{orig: &otlpcollectortrace.ExportTraceServiceRequest{}}
+	return Request{orig: &otlpcollectortrace.ExportTraceServiceRequest{}, shared: &internal.SharedResourceSpans{}}
+}
+
+// NewLazyRequest returns an empty Request that decodes the traces lazily:
+// UnmarshalProto only validates and keeps the given bytes, which must not be modified afterwards,
+// and they are decoded the first time the content of the Traces is accessed.
+// Until then, counting items and cloning the Traces do not decode them, and marshaling them
+// to protobuf, including with Client, returns the received bytes.
+// The request is decoded as a whole: once any of its content is accessed, marshaling re-encodes
+// all of it, even the resources that were not modified.
+func NewLazyRequest() Request {
+	return Request{orig: &otlpcollectortrace.ExportTraceServiceRequest{}, encoded: &internal.EncodedOrig{}, shared: &internal.SharedResourceSpans{}}
 }
 
 // NewRequestFromTraces returns a Request from ptrace.Traces.
 // Because Request is a wrapper for ptrace.Traces,
 // any changes to the provided Traces struct will be reflected in the Request and vice versa.
 func NewRequestFromTraces(t ptrace.Traces) Request {
-	return Request{orig: internal.TracesToOtlp(t)}
+	orig, encoded, shared := internal.TracesToEncodedOtlp(t)
+	return Request{orig: orig, encoded: encoded, shared: shared}
 }
 
 // MarshalProto marshals Request into proto bytes.
 func (tr Request) MarshalProto() ([]byte, error) {
+	if buf, ok := tr.encoded.Bytes(); ok {
+		return buf, nil
+	}
 	return tr.orig.Marshal()
 }
 
 // UnmarshalProto unmarshalls Request from proto bytes.
 func (tr Request) UnmarshalProto(data []byte) error {
+	if tr.encoded != nil {
+		tr.encoded.Discard()
+		*tr.orig = otlpcollectortrace.ExportTraceServiceRequest{}
+		// Invalid data is decoded right away to return the error.
+		if otlpwire.ValidTraces(data) {
+			tr.encoded.Set(data)
+			return nil
+		}
+	}
 	if err := tr.orig.Unmarshal(data); err != nil {
 		return err
 	}
@@ -98,29 +131,28 @@ func (tr Request) UnmarshalProto(data []byte) error {
 
 // MarshalJSON marshals Request into JSON bytes.
 func (tr Request) MarshalJSON() ([]byte, error) {
-	var buf bytes.Buffer
-	if err := jsonMarshaler.Marshal(&buf, tr.orig); err != nil {
-		return nil, err
-	}
-	return buf.Bytes(), nil
+	return tracesJSONMarshaler.MarshalTraces(tr.Traces())
 }
 
 // UnmarshalJSON unmarshalls Request from JSON bytes.
 func (tr Request) UnmarshalJSON(data []byte) error {
-	if err := jsonUnmarshaler.Unmarshal(bytes.NewReader(data), tr.orig); err != nil {
+	td, err := tracesJSONUnmarshaler.UnmarshalTraces(data)
+	if err != nil {
 		return err
 	}
-	otlp.InstrumentationLibrarySpansToScope(tr.orig.ResourceSpans)
+	tr.encoded.Discard()
+	*tr.orig = *internal.TracesToOtlp(td)
 	return nil
 }
 
 // Deprecated: [v0.50.0] Use NewRequestFromTraces instead.
 func (tr Request) SetTraces(td ptrace.Traces) {
+	tr.encoded.Discard()
 	*tr.orig = *internal.TracesToOtlp(td)
 }
 
 func (tr Request) Traces() ptrace.Traces {
-	return internal.TracesFromOtlp(tr.orig)
+	return internal.TracesFromEncodedOtlp(tr.orig, tr.encoded, tr.shared)
 }
 
 // Client is the client API for OTLP-GRPC Traces service.
@@ -136,15 +168,23 @@ type Client interface {
 
 type tracesClient struct {
 	rawClient otlpcollectortrace.TraceServiceClient
+	cc        *grpc.ClientConn
 }
 
 // NewClient returns a new Client connected using the given connection.
 func NewClient(cc *grpc.ClientConn) Client {
-	return &tracesClient{rawClient: otlpcollectortrace.NewTraceServiceClient(cc)}
+	return &tracesClient{rawClient: otlpcollectortrace.NewTraceServiceClient(cc), cc: cc}
 }
 
 // Export implements the Client interface.
 func (c *tracesClient) Export(ctx context.Context, request Request, opts ...grpc.CallOption) (Response, error) {
+	if buf, ok := request.encoded.Bytes(); ok {
+		rsp := &otlpcollectortrace.ExportTraceServiceResponse{}
+		if err := c.cc.Invoke(ctx, exportMethod, &otlpwire.RawMessage{Buf: buf}, rsp, opts...); err != nil {
+			return Response{}, err
+		}
+		return Response{orig: rsp}, nil
+	}
 	rsp, err := c.rawClient.Export(ctx, request.orig, opts...)
 	return Response{orig: rsp}, err
 }
@@ -163,12 +203,57 @@ func RegisterServer(s *grpc.Server, srv Server) {
 	otlpcollectortrace.RegisterTraceServiceServer(s, &rawTracesServer{srv: srv})
 }
 
+// RegisterLazyServer registers the Server to the grpc.Server, like RegisterServer,
+// but the requests given to the Server are lazily decoded, see NewLazyRequest.
+func RegisterLazyServer(s *grpc.Server, srv Server) {
+	s.RegisterService(&lazyServiceDesc, srv)
+}
+
+const exportMethod = "/opentelemetry.proto.collector.trace.v1.TraceService/Export"
+
+var lazyServiceDesc = grpc.ServiceDesc{
+	ServiceName: "opentelemetry.proto.collector.trace.v1.TraceService",
+	HandlerType: (*Server)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Export",
+			Handler:    lazyExportHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "opentelemetry/proto/collector/trace/v1/trace_service.proto",
+}
+
+func lazyExportHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := &otlpwire.RawMessage{}
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	request := NewLazyRequest()
+	if err := request.UnmarshalProto(in.Buf); err != nil {
+		// Same error as the one returned by grpc when the generated code fails to decode.
+		return nil, status.Errorf(codes.Internal, "grpc: error unmarshalling request: %v", err)
+	}
+	export := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		rsp, err := srv.(Server).Export(ctx, request)
+		return rsp.orig, err
+	}
+	if interceptor == nil {
+		return export(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: exportMethod,
+	}
+	return interceptor(ctx, in, info, export)
+}
+
 type rawTracesServer struct {
 	srv Server
 }
 
 func (s rawTracesServer) Export(ctx context.Context, request *otlpcollectortrace.ExportTraceServiceRequest) (*otlpcollectortrace.ExportTraceServiceResponse, error) {
 	otlp.InstrumentationLibrarySpansToScope(request.ResourceSpans)
-	rsp, err := s.srv.Export(ctx, Request{orig: request})
+	rsp, err := s.srv.Export(ctx, Request{orig: request, shared: &internal.SharedResourceSpans{}})
 	return rsp.orig, err
 }
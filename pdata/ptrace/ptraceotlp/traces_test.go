@@ -33,6 +33,8 @@ import (
 
 	v1 "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
 	"go.opentelemetry.io/collector/pdata/internal/otlp"
+	"go.opentelemetry.io/collector/pdata/internal/otlpwire"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
@@ -46,17 +48,11 @@ var tracesRequestJSON = []byte(`
 	{
 		"resourceSpans": [
 			{
-				"resource": {},
 				"scopeSpans": [
 					{
-						"scope": {},
 						"spans": [
 							{
-								"traceId": "",
-								"spanId":"",
-								"parentSpanId":"",
-								"name": "test_span",
-								"status": {}
+								"name": "test_span"
 							}
 						]
 					}
@@ -328,3 +324,186 @@ func generateTracesRequestWithInstrumentationLibrary() Request {
 	tr.orig.ResourceSpans[0].ScopeSpans = []*v1.ScopeSpans{}
 	return tr
 }
+
+func TestLazyRequest(t *testing.T) {
+	buf, err := generateTracesRequest().MarshalProto()
+	require.NoError(t, err)
+
+	req := NewLazyRequest()
+	require.NoError(t, req.UnmarshalProto(buf))
+	td := req.Traces()
+	assert.Equal(t, 1, td.SpanCount())
+	clone := td.Clone()
+	got, err := req.MarshalProto()
+	require.NoError(t, err)
+	assert.Equal(t, buf, got)
+	got, err = ptrace.NewProtoMarshaler().MarshalTraces(clone)
+	require.NoError(t, err)
+	assert.Equal(t, buf, got)
+	_, pending := req.encoded.Bytes()
+	assert.True(t, pending)
+
+	assert.Equal(t, "test_span", td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+	_, pending = req.encoded.Bytes()
+	assert.False(t, pending)
+	assert.Equal(t, generateTracesRequest().Traces(), req.Traces())
+	assert.Equal(t, generateTracesRequest().Traces().ResourceSpans(), clone.ResourceSpans())
+	td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("changed")
+	got, err = req.MarshalProto()
+	require.NoError(t, err)
+	assert.NotEqual(t, buf, got)
+}
+
+func TestLazyRequestDecodedOnError(t *testing.T) {
+	req := NewLazyRequest()
+	assert.Equal(t, NewRequest().UnmarshalProto([]byte{0xff}), req.UnmarshalProto([]byte{0xff}))
+
+	buf, err := generateTracesRequestWithInstrumentationLibrary().MarshalProto()
+	require.NoError(t, err)
+	require.NoError(t, req.UnmarshalProto(buf))
+	_, pending := req.encoded.Bytes()
+	assert.False(t, pending)
+	assert.Equal(t, generateTracesRequest().Traces(), req.Traces())
+}
+
+type fakeLazyServer struct {
+	t *testing.T
+}
+
+func (s fakeLazyServer) Export(_ context.Context, req Request) (Response, error) {
+	td := req.Traces()
+	assert.Equal(s.t, 1, td.SpanCount())
+	_, pending := req.encoded.Bytes()
+	assert.True(s.t, pending)
+	td.ResourceSpans()
+	assert.Equal(s.t, generateTracesRequest().Traces(), req.Traces())
+	return NewResponse(), nil
+}
+
+func TestGrpcLazy(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	RegisterLazyServer(s, &fakeLazyServer{t: t})
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, s.Serve(lis))
+	}()
+	t.Cleanup(func() {
+		s.Stop()
+		wg.Wait()
+	})
+
+	cc, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock())
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, cc.Close())
+	})
+
+	traceClient := NewClient(cc)
+	resp, err := traceClient.Export(context.Background(), generateTracesRequest())
+	assert.NoError(t, err)
+	assert.Equal(t, NewResponse(), resp)
+
+	err = cc.Invoke(context.Background(), exportMethod, &otlpwire.RawMessage{Buf: []byte{0xff}}, NewResponse().orig)
+	st, okSt := status.FromError(err)
+	require.True(t, okSt)
+	assert.Equal(t, codes.Internal, st.Code())
+}
+
+func TestGrpcLazyClient(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	RegisterServer(s, &fakeTracesServer{t: t})
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, s.Serve(lis))
+	}()
+	t.Cleanup(func() {
+		s.Stop()
+		wg.Wait()
+	})
+
+	cc, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock())
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, cc.Close())
+	})
+
+	buf, err := generateTracesRequest().MarshalProto()
+	require.NoError(t, err)
+	req := NewLazyRequest()
+	require.NoError(t, req.UnmarshalProto(buf))
+
+	traceClient := NewClient(cc)
+	resp, err := traceClient.Export(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, NewResponse(), resp)
+	_, pending := req.encoded.Bytes()
+	assert.True(t, pending)
+}
+
+func BenchmarkRequestPassThrough(b *testing.B) {
+	buf, err := generateLargeTracesRequest().MarshalProto()
+	require.NoError(b, err)
+
+	b.Run("eager", func(b *testing.B) {
+		benchmarkPassThrough(b, buf, NewRequest)
+	})
+	b.Run("lazy", func(b *testing.B) {
+		benchmarkPassThrough(b, buf, NewLazyRequest)
+	})
+}
+
+func benchmarkPassThrough(b *testing.B, buf []byte, newRequest func() Request) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		req := newRequest()
+		if err := req.UnmarshalProto(buf); err != nil {
+			b.Fatal(err)
+		}
+		if req.Traces().SpanCount() == 0 {
+			b.Fatal("no spans")
+		}
+		if _, err := req.MarshalProto(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func generateLargeTracesRequest() Request {
+	td := ptrace.NewTraces()
+	for i := 0; i < 10; i++ {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().InsertString("service.name", "service")
+		rs.Resource().Attributes().InsertInt("resource.index", int64(i))
+		spans := rs.ScopeSpans().AppendEmpty().Spans()
+		for j := 0; j < 10; j++ {
+			span := spans.AppendEmpty()
+			span.SetName("operation")
+			span.SetTraceID(pcommon.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+			span.SetSpanID(pcommon.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, byte(j)}))
+			span.SetKind(ptrace.SpanKindServer)
+			span.SetStartTimestamp(pcommon.Timestamp(1000))
+			span.SetEndTimestamp(pcommon.Timestamp(2000))
+			span.Attributes().InsertString("http.method", "GET")
+			span.Attributes().InsertInt("http.status_code", 200)
+			span.Events().AppendEmpty().SetName("event")
+		}
+	}
+	return NewRequestFromTraces(td)
+}
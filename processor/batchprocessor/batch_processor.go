@@ -22,11 +22,14 @@ import (
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -55,6 +58,7 @@ type batchProcessor struct {
 	goroutines sync.WaitGroup
 
 	telemetryLevel configtelemetry.Level
+	recorder       *obsreportconfig.Recorder
 }
 
 type batch interface {
@@ -84,6 +88,8 @@ func newBatchProcessor(set component.ProcessorCreateSettings, cfg *Config, batch
 		logger:         set.Logger,
 		exportCtx:      exportCtx,
 		telemetryLevel: telemetryLevel,
+		recorder: obsreportconfig.NewRecorder(set.MeterProvider, "go.opentelemetry.io/collector/processor/batchprocessor", MetricViews(),
+			attribute.String(obsmetrics.ProcessorKey, cfg.ID().String())),
 
 		sendBatchSize:    int(cfg.SendBatchSize),
 		sendBatchMaxSize: int(cfg.SendBatchMaxSize),
@@ -176,10 +182,10 @@ func (bp *batchProcessor) resetTimer() {
 
 func (bp *batchProcessor) sendItems(triggerMeasure *stats.Int64Measure) {
 	// Add that it came form the trace pipeline?
-	stats.Record(bp.exportCtx, triggerMeasure.M(1), statBatchSendSize.M(int64(bp.batch.itemCount())))
+	bp.recorder.Record(bp.exportCtx, nil, triggerMeasure.M(1), statBatchSendSize.M(int64(bp.batch.itemCount())))
 
 	if bp.telemetryLevel == configtelemetry.LevelDetailed {
-		stats.Record(bp.exportCtx, statBatchSendSizeBytes.M(int64(bp.batch.size())))
+		bp.recorder.Record(bp.exportCtx, nil, statBatchSendSizeBytes.M(int64(bp.batch.size())))
 	}
 
 	if err := bp.batch.export(bp.exportCtx, bp.sendBatchMaxSize); err != nil {
@@ -240,6 +246,12 @@ func (bt *batchTraces) add(item interface{}) {
 		return
 	}
 
+	if bt.spanCount == 0 {
+		// Keep the first item as is, data received still encoded is then not decoded to be moved.
+		bt.traceData = td
+		bt.spanCount = newSpanCount
+		return
+	}
 	bt.spanCount += newSpanCount
 	td.ResourceSpans().MoveAndAppendTo(bt.traceData.ResourceSpans())
 }
@@ -304,6 +316,12 @@ func (bm *batchMetrics) add(item interface{}) {
 	if newDataPointCount == 0 {
 		return
 	}
+	if bm.dataPointCount == 0 {
+		// Keep the first item as is, data received still encoded is then not decoded to be moved.
+		bm.metricData = md
+		bm.dataPointCount = newDataPointCount
+		return
+	}
 	bm.dataPointCount += newDataPointCount
 	md.ResourceMetrics().MoveAndAppendTo(bm.metricData.ResourceMetrics())
 }
@@ -347,6 +365,12 @@ func (bl *batchLogs) add(item interface{}) {
 	if newLogsCount == 0 {
 		return
 	}
+	if bl.logCount == 0 {
+		// Keep the first item as is, data received still encoded is then not decoded to be moved.
+		bl.logData = ld
+		bl.logCount = newLogsCount
+		return
+	}
 	bl.logCount += newLogsCount
 	ld.ResourceLogs().MoveAndAppendTo(bl.logData.ResourceLogs())
 }
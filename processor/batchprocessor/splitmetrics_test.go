@@ -364,3 +364,20 @@ func BenchmarkSplitMetrics(b *testing.B) {
 		}
 	}
 }
+
+// metricDPC calculates the total number of data points in the pmetric.Metric.
+func metricDPC(ms pmetric.Metric) int {
+	switch ms.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		return ms.Gauge().DataPoints().Len()
+	case pmetric.MetricDataTypeSum:
+		return ms.Sum().DataPoints().Len()
+	case pmetric.MetricDataTypeHistogram:
+		return ms.Histogram().DataPoints().Len()
+	case pmetric.MetricDataTypeExponentialHistogram:
+		return ms.ExponentialHistogram().DataPoints().Len()
+	case pmetric.MetricDataTypeSummary:
+		return ms.Summary().DataPoints().Len()
+	}
+	return 0
+}
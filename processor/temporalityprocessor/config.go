@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package temporalityprocessor provides a processor converting the aggregation temporality
+// of the sums, histograms and exponential histograms of metrics.
+package temporalityprocessor // import "go.opentelemetry.io/collector/processor/temporalityprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	deltaTemporality      = "delta"
+	cumulativeTemporality = "cumulative"
+)
+
+// Config defines configuration for the temporality processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// AggregationTemporality is the temporality the metrics are converted to, "delta" or "cumulative".
+	AggregationTemporality string `mapstructure:"aggregation_temporality"`
+
+	// MaxStaleness is the time after which a time series that did not receive any point is forgotten,
+	// 0 meaning that time series are never forgotten. Default value is 5 minutes.
+	MaxStaleness time.Duration `mapstructure:"max_staleness"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if _, err := cfg.temporality(); err != nil {
+		return err
+	}
+	if cfg.MaxStaleness < 0 {
+		return errors.New("max_staleness must not be negative")
+	}
+	return nil
+}
+
+func (cfg *Config) temporality() (pmetric.MetricAggregationTemporality, error) {
+	switch cfg.AggregationTemporality {
+	case deltaTemporality:
+		return pmetric.MetricAggregationTemporalityDelta, nil
+	case cumulativeTemporality:
+		return pmetric.MetricAggregationTemporalityCumulative, nil
+	}
+	return pmetric.MetricAggregationTemporalityUnspecified,
+		fmt.Errorf("aggregation_temporality must be %q or %q, got %q", deltaTemporality, cumulativeTemporality, cfg.AggregationTemporality)
+}
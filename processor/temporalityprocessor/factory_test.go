@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temporalityprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configtest.CheckConfigStruct(cfg))
+}
+
+func TestCreateProcessor(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	creationSet := componenttest.NewNopProcessorCreateSettings()
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), creationSet, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.NotNil(t, mp)
+	assert.True(t, mp.Capabilities().MutatesData)
+	assert.NoError(t, mp.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, mp.Shutdown(context.Background()))
+
+	tp, err := factory.CreateTracesProcessor(context.Background(), creationSet, cfg, consumertest.NewNop())
+	assert.Error(t, err)
+	assert.Nil(t, tp)
+
+	cfg.(*Config).AggregationTemporality = "unknown"
+	mp, err = factory.CreateMetricsProcessor(context.Background(), creationSet, cfg, consumertest.NewNop())
+	assert.Error(t, err)
+	assert.Nil(t, mp)
+}
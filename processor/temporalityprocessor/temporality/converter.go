@@ -0,0 +1,599 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package temporality converts the aggregation temporality of metrics.
+package temporality // import "go.opentelemetry.io/collector/processor/temporalityprocessor/temporality"
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// Converter converts the sums, histograms and exponential histograms of metrics to a target
+// aggregation temporality. It keeps the last cumulative value, or the running total, of each
+// time series, identified by its resource, scope, metric and attributes. The time series are looked
+// up by the hashes of their identity, which is then compared so that colliding hashes do not mix them.
+//
+// A Converter is safe for concurrent use.
+type Converter struct {
+	target pmetric.MetricAggregationTemporality
+	ttl    time.Duration
+	// created is the time the Converter was created at, to recognize the time series started since then.
+	created pcommon.Timestamp
+	now     func() time.Time
+
+	mu sync.Mutex
+	// series holds the time series whose identities have the same hashes.
+	series    map[seriesKey][]*series
+	lastSweep time.Time
+}
+
+// hashAttributes returns the hash of the attributes of a point, it is replaced by the tests to make them collide.
+var hashAttributes = pcommon.Map.Hash
+
+// seriesKey is the hashed identity of a time series.
+type seriesKey struct {
+	resource   uint64
+	scope      uint64
+	name       string
+	unit       string
+	dataType   pmetric.MetricDataType
+	monotonic  bool
+	attributes uint64
+}
+
+// seriesID is the identity of a time series that is not part of its seriesKey.
+type seriesID struct {
+	resource   pcommon.Resource
+	scope      pcommon.InstrumentationScope
+	attributes pcommon.Map
+}
+
+// copy returns a copy of id, to be kept by a time series.
+func (id seriesID) copy() seriesID {
+	cp := seriesID{resource: pcommon.NewResource(), scope: pcommon.NewInstrumentationScope(), attributes: pcommon.NewMap()}
+	id.resource.CopyTo(cp.resource)
+	id.scope.CopyTo(cp.scope)
+	id.attributes.CopyTo(cp.attributes)
+	return cp
+}
+
+// equal returns true if id and other identify the same time series, regardless of the order of the attributes.
+func (id seriesID) equal(other seriesID) bool {
+	return id.resource.DroppedAttributesCount() == other.resource.DroppedAttributesCount() &&
+		equalAttributes(id.resource.Attributes(), other.resource.Attributes()) &&
+		id.scope.Name() == other.scope.Name() &&
+		id.scope.Version() == other.scope.Version() &&
+		equalAttributes(id.attributes, other.attributes)
+}
+
+// equalAttributes returns true if a and b hold the same attributes, regardless of their order.
+func equalAttributes(a, b pcommon.Map) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	equal := true
+	a.Range(func(k string, v pcommon.Value) bool {
+		bv, ok := b.Get(k)
+		equal = ok && v.Equal(bv)
+		return equal
+	})
+	return equal
+}
+
+// series is the state of a time series.
+type series struct {
+	id seriesID
+	// seen is the last time a point of the time series was received, to forget the stale ones.
+	seen time.Time
+	// start and last are the start time and the time of the last point of the time series.
+	start pcommon.Timestamp
+	last  pcommon.Timestamp
+	// The last cumulative value, or the running total, of the time series, without attributes and exemplars.
+	number       pmetric.NumberDataPoint
+	histogram    pmetric.HistogramDataPoint
+	expHistogram pmetric.ExponentialHistogramDataPoint
+}
+
+// NewConverter returns a Converter to the target temporality, delta or cumulative, that forgets the
+// time series that did not receive any point for ttl, or never if ttl is 0.
+func NewConverter(target pmetric.MetricAggregationTemporality, ttl time.Duration) (*Converter, error) {
+	if target != pmetric.MetricAggregationTemporalityDelta && target != pmetric.MetricAggregationTemporalityCumulative {
+		return nil, fmt.Errorf("unsupported target aggregation temporality %v", target)
+	}
+	if ttl < 0 {
+		return nil, fmt.Errorf("negative ttl %v", ttl)
+	}
+	now := time.Now()
+	return &Converter{
+		target:    target,
+		ttl:       ttl,
+		created:   pcommon.NewTimestampFromTime(now),
+		now:       time.Now,
+		series:    map[seriesKey][]*series{},
+		lastSweep: now,
+	}, nil
+}
+
+// Convert converts the metrics of md in place. The points that cannot be converted are removed,
+// along with the metrics, scopes and resources that they leave empty:
+//   - converting to delta, the first point of a time series is only kept if it started after the
+//     Converter was created, as what it counts may otherwise have been reported already;
+//   - converting to delta, a point whose value type or buckets differ from the previous one is removed;
+//   - points that are not more recent than the previous point of their time series, or that
+//     overlap with it for delta points, are removed.
+//
+// A cumulative point with a new start time, or whose values decreased for monotonic sums and
+// histograms, resets its time series. The points flagged with no recorded value are kept as is.
+func (c *Converter) Convert(md pmetric.Metrics) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	c.removeStale(now)
+
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		sms := rm.ScopeMetrics()
+		if sms.Len() == 0 {
+			return false
+		}
+		resource := rm.Resource().Hash()
+		sms.RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			ms := sm.Metrics()
+			if ms.Len() == 0 {
+				return false
+			}
+			scope := sm.Scope().Hash()
+			id := seriesID{resource: rm.Resource(), scope: sm.Scope()}
+			ms.RemoveIf(func(m pmetric.Metric) bool {
+				key := seriesKey{resource: resource, scope: scope, name: m.Name(), unit: m.Unit(), dataType: m.DataType()}
+				return c.convertMetric(key, id, m, now)
+			})
+			return ms.Len() == 0
+		})
+		return sms.Len() == 0
+	})
+}
+
+// removeStale forgets the time series that did not receive any point for the ttl,
+// checking them at most once per ttl.
+func (c *Converter) removeStale(now time.Time) {
+	if c.ttl == 0 || now.Sub(c.lastSweep) < c.ttl {
+		return
+	}
+	for key, ss := range c.series {
+		fresh := ss[:0]
+		for _, s := range ss {
+			if now.Sub(s.seen) < c.ttl {
+				fresh = append(fresh, s)
+			}
+		}
+		if len(fresh) == 0 {
+			delete(c.series, key)
+			continue
+		}
+		for i := len(fresh); i < len(ss); i++ {
+			ss[i] = nil
+		}
+		c.series[key] = fresh
+	}
+	c.lastSweep = now
+}
+
+// converts returns true if the metrics of the given temporality must be converted.
+func (c *Converter) converts(temporality pmetric.MetricAggregationTemporality) bool {
+	return temporality != c.target && temporality != pmetric.MetricAggregationTemporalityUnspecified
+}
+
+// seriesOf returns the time series of a point, creating it if needed.
+func (c *Converter) seriesOf(key seriesKey, id seriesID, attributes pcommon.Map, now time.Time) *series {
+	key.attributes = hashAttributes(attributes)
+	id.attributes = attributes
+	for _, s := range c.series[key] {
+		if s.id.equal(id) {
+			s.seen = now
+			return s
+		}
+	}
+	s := &series{id: id.copy(), seen: now}
+	c.series[key] = append(c.series[key], s)
+	return s
+}
+
+// convertMetric converts the points of m, and returns true if it removed all of them.
+func (c *Converter) convertMetric(key seriesKey, id seriesID, m pmetric.Metric, now time.Time) bool {
+	noRecordedValue := func(flags pmetric.MetricDataPointFlags) bool {
+		return flags.HasFlag(pmetric.MetricDataPointFlagNoRecordedValue)
+	}
+	switch m.DataType() {
+	case pmetric.MetricDataTypeSum:
+		sum := m.Sum()
+		if !c.converts(sum.AggregationTemporality()) {
+			return false
+		}
+		sum.SetAggregationTemporality(c.target)
+		key.monotonic = sum.IsMonotonic()
+		dps := sum.DataPoints()
+		if dps.Len() == 0 {
+			return false
+		}
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+			if noRecordedValue(dp.Flags()) {
+				return false
+			}
+			s := c.seriesOf(key, id, dp.Attributes(), now)
+			if c.target == pmetric.MetricAggregationTemporalityDelta {
+				return !c.numberToDelta(s, dp, key.monotonic)
+			}
+			return !c.numberToCumulative(s, dp)
+		})
+		return dps.Len() == 0
+	case pmetric.MetricDataTypeHistogram:
+		histogram := m.Histogram()
+		if !c.converts(histogram.AggregationTemporality()) {
+			return false
+		}
+		histogram.SetAggregationTemporality(c.target)
+		dps := histogram.DataPoints()
+		if dps.Len() == 0 {
+			return false
+		}
+		dps.RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+			if noRecordedValue(dp.Flags()) {
+				return false
+			}
+			s := c.seriesOf(key, id, dp.Attributes(), now)
+			if c.target == pmetric.MetricAggregationTemporalityDelta {
+				return !c.histogramToDelta(s, dp)
+			}
+			return !c.histogramToCumulative(s, dp)
+		})
+		return dps.Len() == 0
+	case pmetric.MetricDataTypeExponentialHistogram:
+		histogram := m.ExponentialHistogram()
+		if !c.converts(histogram.AggregationTemporality()) {
+			return false
+		}
+		histogram.SetAggregationTemporality(c.target)
+		dps := histogram.DataPoints()
+		if dps.Len() == 0 {
+			return false
+		}
+		dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+			if noRecordedValue(dp.Flags()) {
+				return false
+			}
+			s := c.seriesOf(key, id, dp.Attributes(), now)
+			if c.target == pmetric.MetricAggregationTemporalityDelta {
+				return !c.expHistogramToDelta(s, dp)
+			}
+			return !c.expHistogramToCumulative(s, dp)
+		})
+		return dps.Len() == 0
+	}
+	return false
+}
+
+// step is how a point is converted.
+type step int
+
+const (
+	// dropStep removes the point, without updating its time series.
+	dropStep step = iota
+	// recordStep removes the point, but records it as the last one of its time series.
+	recordStep
+	// startStep keeps the point as is, and starts its time series with it.
+	startStep
+	// continueStep subtracts the previous cumulative value from the point, or adds it to the running total.
+	continueStep
+)
+
+// cumulativeStep returns how a cumulative point of s, from start to ts, is converted to delta.
+func (c *Converter) cumulativeStep(s *series, start, ts pcommon.Timestamp) step {
+	switch {
+	case s.last == 0:
+		if start != 0 && start >= c.created {
+			return startStep
+		}
+		return recordStep
+	case ts <= s.last:
+		return dropStep
+	case start != 0 && start != s.start:
+		return startStep
+	}
+	return continueStep
+}
+
+// deltaStep returns how a delta point of s, from start to ts, is converted to cumulative.
+func (c *Converter) deltaStep(s *series, start, ts pcommon.Timestamp) step {
+	switch {
+	case s.last == 0:
+		return startStep
+	case ts <= s.last || (start != 0 && start < s.last):
+		return dropStep
+	}
+	return continueStep
+}
+
+// numberToDelta converts a point of a cumulative sum, and returns false if it is removed.
+func (c *Converter) numberToDelta(s *series, dp pmetric.NumberDataPoint, monotonic bool) bool {
+	st := c.cumulativeStep(s, dp.StartTimestamp(), dp.Timestamp())
+	if st == dropStep {
+		return false
+	}
+	prev, last := s.number, s.last
+	s.number = copyNumber(dp)
+	s.start, s.last = dp.StartTimestamp(), dp.Timestamp()
+	if st == continueStep && prev.ValueType() != dp.ValueType() {
+		st = recordStep
+	}
+	if st != continueStep {
+		return st == startStep
+	}
+
+	dp.SetStartTimestamp(last)
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		if !monotonic || dp.IntVal() >= prev.IntVal() {
+			dp.SetIntVal(dp.IntVal() - prev.IntVal())
+		}
+	case pmetric.NumberDataPointValueTypeDouble:
+		if !monotonic || dp.DoubleVal() >= prev.DoubleVal() {
+			dp.SetDoubleVal(dp.DoubleVal() - prev.DoubleVal())
+		}
+	}
+	return true
+}
+
+// numberToCumulative converts a point of a delta sum, and returns false if it is removed.
+func (c *Converter) numberToCumulative(s *series, dp pmetric.NumberDataPoint) bool {
+	st := c.deltaStep(s, dp.StartTimestamp(), dp.Timestamp())
+	if st == dropStep {
+		return false
+	}
+	if st == continueStep && s.number.ValueType() == dp.ValueType() {
+		dp.SetStartTimestamp(s.start)
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			dp.SetIntVal(dp.IntVal() + s.number.IntVal())
+		case pmetric.NumberDataPointValueTypeDouble:
+			dp.SetDoubleVal(dp.DoubleVal() + s.number.DoubleVal())
+		}
+	} else {
+		s.start = dp.StartTimestamp()
+	}
+	s.number = copyNumber(dp)
+	s.last = dp.Timestamp()
+	return true
+}
+
+// histogramToDelta converts a point of a cumulative histogram, and returns false if it is removed.
+func (c *Converter) histogramToDelta(s *series, dp pmetric.HistogramDataPoint) bool {
+	st := c.cumulativeStep(s, dp.StartTimestamp(), dp.Timestamp())
+	if st == dropStep {
+		return false
+	}
+	prev, last := s.histogram, s.last
+	s.histogram = copyHistogram(dp)
+	s.start, s.last = dp.StartTimestamp(), dp.Timestamp()
+	if st == continueStep && !equalBounds(prev.ExplicitBounds(), dp.ExplicitBounds()) {
+		st = recordStep
+	}
+	if st != continueStep {
+		return st == startStep
+	}
+
+	dp.SetStartTimestamp(last)
+	counts, ok := subtractCounts(dp.BucketCounts(), prev.BucketCounts())
+	if !ok || dp.Count() < prev.Count() {
+		// The histogram was reset since the previous point.
+		return true
+	}
+	dp.SetBucketCounts(counts)
+	dp.SetCount(dp.Count() - prev.Count())
+	if dp.HasSum() && prev.HasSum() {
+		dp.SetSum(dp.Sum() - prev.Sum())
+	}
+	return true
+}
+
+// histogramToCumulative converts a point of a delta histogram, and returns false if it is removed.
+func (c *Converter) histogramToCumulative(s *series, dp pmetric.HistogramDataPoint) bool {
+	st := c.deltaStep(s, dp.StartTimestamp(), dp.Timestamp())
+	if st == dropStep {
+		return false
+	}
+	total := s.histogram
+	if st == continueStep && equalBounds(total.ExplicitBounds(), dp.ExplicitBounds()) && len(total.BucketCounts()) == len(dp.BucketCounts()) {
+		dp.SetStartTimestamp(s.start)
+		counts := make([]uint64, len(dp.BucketCounts()))
+		for i, count := range dp.BucketCounts() {
+			counts[i] = count + total.BucketCounts()[i]
+		}
+		dp.SetBucketCounts(counts)
+		dp.SetCount(dp.Count() + total.Count())
+		if dp.HasSum() {
+			dp.SetSum(dp.Sum() + total.Sum())
+		}
+	} else {
+		s.start = dp.StartTimestamp()
+	}
+	s.histogram = copyHistogram(dp)
+	s.last = dp.Timestamp()
+	return true
+}
+
+// expHistogramToDelta converts a point of a cumulative exponential histogram, and returns false if it is removed.
+func (c *Converter) expHistogramToDelta(s *series, dp pmetric.ExponentialHistogramDataPoint) bool {
+	st := c.cumulativeStep(s, dp.StartTimestamp(), dp.Timestamp())
+	if st == dropStep {
+		return false
+	}
+	prev, last := s.expHistogram, s.last
+	s.expHistogram = copyExpHistogram(dp)
+	s.start, s.last = dp.StartTimestamp(), dp.Timestamp()
+	if st != continueStep {
+		return st == startStep
+	}
+
+	dp.SetStartTimestamp(last)
+	// The scale of a cumulative exponential histogram may be reduced from one point to the next.
+	scale := dp.Scale()
+	if prev.Scale() < scale {
+		scale = prev.Scale()
+	}
+	positiveOffset, positive, positiveOk := subtractBuckets(dp.Positive(), dp.Scale()-scale, prev.Positive(), prev.Scale()-scale)
+	negativeOffset, negative, negativeOk := subtractBuckets(dp.Negative(), dp.Scale()-scale, prev.Negative(), prev.Scale()-scale)
+	if !positiveOk || !negativeOk || dp.ZeroCount() < prev.ZeroCount() || dp.Count() < prev.Count() {
+		// The histogram was reset since the previous point.
+		return true
+	}
+	dp.SetScale(scale)
+	dp.Positive().SetOffset(positiveOffset)
+	dp.Positive().SetBucketCounts(positive)
+	dp.Negative().SetOffset(negativeOffset)
+	dp.Negative().SetBucketCounts(negative)
+	dp.SetZeroCount(dp.ZeroCount() - prev.ZeroCount())
+	dp.SetCount(dp.Count() - prev.Count())
+	dp.SetSum(dp.Sum() - prev.Sum())
+	return true
+}
+
+// expHistogramToCumulative converts a point of a delta exponential histogram, and returns false if it is removed.
+// The running total is downscaled as needed to fit in 160 buckets.
+func (c *Converter) expHistogramToCumulative(s *series, dp pmetric.ExponentialHistogramDataPoint) bool {
+	st := c.deltaStep(s, dp.StartTimestamp(), dp.Timestamp())
+	if st == dropStep {
+		return false
+	}
+	if st == continueStep {
+		dp.SetStartTimestamp(s.start)
+		total := s.expHistogram
+		total.Merge(dp, 0)
+		dp.SetScale(total.Scale())
+		dp.Positive().SetOffset(total.Positive().Offset())
+		dp.Positive().SetBucketCounts(append([]uint64(nil), total.Positive().BucketCounts()...))
+		dp.Negative().SetOffset(total.Negative().Offset())
+		dp.Negative().SetBucketCounts(append([]uint64(nil), total.Negative().BucketCounts()...))
+		dp.SetZeroCount(total.ZeroCount())
+		dp.SetCount(total.Count())
+		dp.SetSum(total.Sum())
+	} else {
+		s.start = dp.StartTimestamp()
+	}
+	s.expHistogram = copyExpHistogram(dp)
+	s.last = dp.Timestamp()
+	return true
+}
+
+// copyNumber returns a copy of the value of dp.
+func copyNumber(dp pmetric.NumberDataPoint) pmetric.NumberDataPoint {
+	value := pmetric.NewNumberDataPoint()
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		value.SetIntVal(dp.IntVal())
+	case pmetric.NumberDataPointValueTypeDouble:
+		value.SetDoubleVal(dp.DoubleVal())
+	}
+	return value
+}
+
+// copyHistogram returns a copy of the values of dp.
+func copyHistogram(dp pmetric.HistogramDataPoint) pmetric.HistogramDataPoint {
+	value := pmetric.NewHistogramDataPoint()
+	value.SetCount(dp.Count())
+	if dp.HasSum() {
+		value.SetSum(dp.Sum())
+	}
+	value.SetExplicitBounds(append([]float64(nil), dp.ExplicitBounds()...))
+	value.SetBucketCounts(append([]uint64(nil), dp.BucketCounts()...))
+	return value
+}
+
+// copyExpHistogram returns a copy of the values of dp.
+func copyExpHistogram(dp pmetric.ExponentialHistogramDataPoint) pmetric.ExponentialHistogramDataPoint {
+	value := pmetric.NewExponentialHistogramDataPoint()
+	value.SetCount(dp.Count())
+	value.SetSum(dp.Sum())
+	value.SetScale(dp.Scale())
+	value.SetZeroCount(dp.ZeroCount())
+	value.Positive().SetOffset(dp.Positive().Offset())
+	value.Positive().SetBucketCounts(append([]uint64(nil), dp.Positive().BucketCounts()...))
+	value.Negative().SetOffset(dp.Negative().Offset())
+	value.Negative().SetBucketCounts(append([]uint64(nil), dp.Negative().BucketCounts()...))
+	return value
+}
+
+func equalBounds(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// subtractCounts returns the difference of the bucket counts, and false if any is negative.
+func subtractCounts(counts, prev []uint64) ([]uint64, bool) {
+	if len(counts) != len(prev) {
+		return nil, false
+	}
+	delta := make([]uint64, len(counts))
+	for i, c := range counts {
+		if c < prev[i] {
+			return nil, false
+		}
+		delta[i] = c - prev[i]
+	}
+	return delta, true
+}
+
+// downscaledCounts returns the offset and counts of the buckets once their scale is reduced by change.
+func downscaledCounts(b pmetric.Buckets, change int32) (int32, []uint64) {
+	counts := b.BucketCounts()
+	if change == 0 || len(counts) == 0 {
+		return b.Offset() >> change, counts
+	}
+	offset := b.Offset() >> change
+	downscaled := make([]uint64, (b.Offset()+int32(len(counts))-1)>>change-offset+1)
+	for i, c := range counts {
+		downscaled[(b.Offset()+int32(i))>>change-offset] += c
+	}
+	return offset, downscaled
+}
+
+// subtractBuckets returns the difference of the buckets, once their scale is reduced by change
+// and prevChange respectively, and false if any count is negative.
+func subtractBuckets(b pmetric.Buckets, change int32, prev pmetric.Buckets, prevChange int32) (int32, []uint64, bool) {
+	offset, counts := downscaledCounts(b, change)
+	prevOffset, prevCounts := downscaledCounts(prev, prevChange)
+	delta := append([]uint64(nil), counts...)
+	for i, c := range prevCounts {
+		if c == 0 {
+			continue
+		}
+		j := int(prevOffset) + i - int(offset)
+		if j < 0 || j >= len(delta) || delta[j] < c {
+			return 0, nil, false
+		}
+		delta[j] -= c
+	}
+	return offset, delta, true
+}
@@ -0,0 +1,350 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temporality
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newTestConverter(t *testing.T, target pmetric.MetricAggregationTemporality) *Converter {
+	c, err := NewConverter(target, time.Minute)
+	require.NoError(t, err)
+	c.created = 100
+	return c
+}
+
+func newTestMetrics(dataType pmetric.MetricDataType, temporality pmetric.MetricAggregationTemporality) (pmetric.Metrics, pmetric.Metric) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", "test")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("metric")
+	m.SetDataType(dataType)
+	switch dataType {
+	case pmetric.MetricDataTypeSum:
+		m.Sum().SetAggregationTemporality(temporality)
+		m.Sum().SetIsMonotonic(true)
+	case pmetric.MetricDataTypeHistogram:
+		m.Histogram().SetAggregationTemporality(temporality)
+	case pmetric.MetricDataTypeExponentialHistogram:
+		m.ExponentialHistogram().SetAggregationTemporality(temporality)
+	}
+	return md, m
+}
+
+// convertSum converts a single point of a sum, and returns it or false if it was removed.
+func convertSum(t *testing.T, c *Converter, temporality pmetric.MetricAggregationTemporality, start, ts pcommon.Timestamp, value int64) (pmetric.NumberDataPoint, bool) {
+	md, m := newTestMetrics(pmetric.MetricDataTypeSum, temporality)
+	dp := m.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntVal(value)
+	c.Convert(md)
+	if md.ResourceMetrics().Len() == 0 {
+		return pmetric.NumberDataPoint{}, false
+	}
+	m = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, c.target, m.Sum().AggregationTemporality())
+	return m.Sum().DataPoints().At(0), true
+}
+
+func TestNewConverter(t *testing.T) {
+	_, err := NewConverter(pmetric.MetricAggregationTemporalityUnspecified, 0)
+	assert.Error(t, err)
+	_, err = NewConverter(pmetric.MetricAggregationTemporalityDelta, -time.Second)
+	assert.Error(t, err)
+	c, err := NewConverter(pmetric.MetricAggregationTemporalityCumulative, 0)
+	assert.NoError(t, err)
+	assert.NotNil(t, c)
+}
+
+func TestConvertSumToDelta(t *testing.T) {
+	c := newTestConverter(t, pmetric.MetricAggregationTemporalityDelta)
+	cumulative := pmetric.MetricAggregationTemporalityCumulative
+
+	// The first point started before the Converter was created.
+	_, ok := convertSum(t, c, cumulative, 50, 110, 10)
+	assert.False(t, ok)
+
+	dp, ok := convertSum(t, c, cumulative, 50, 120, 15)
+	require.True(t, ok)
+	assert.Equal(t, pcommon.Timestamp(110), dp.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(120), dp.Timestamp())
+	assert.Equal(t, int64(5), dp.IntVal())
+
+	// Out of order.
+	_, ok = convertSum(t, c, cumulative, 50, 115, 13)
+	assert.False(t, ok)
+
+	// The value decreased, the sum was reset.
+	dp, ok = convertSum(t, c, cumulative, 50, 130, 4)
+	require.True(t, ok)
+	assert.Equal(t, pcommon.Timestamp(120), dp.StartTimestamp())
+	assert.Equal(t, int64(4), dp.IntVal())
+
+	// The sum restarted.
+	dp, ok = convertSum(t, c, cumulative, 135, 140, 3)
+	require.True(t, ok)
+	assert.Equal(t, pcommon.Timestamp(135), dp.StartTimestamp())
+	assert.Equal(t, int64(3), dp.IntVal())
+
+	dp, ok = convertSum(t, c, cumulative, 135, 150, 10)
+	require.True(t, ok)
+	assert.Equal(t, pcommon.Timestamp(140), dp.StartTimestamp())
+	assert.Equal(t, int64(7), dp.IntVal())
+}
+
+func TestConvertSumToCumulative(t *testing.T) {
+	c := newTestConverter(t, pmetric.MetricAggregationTemporalityCumulative)
+	delta := pmetric.MetricAggregationTemporalityDelta
+
+	dp, ok := convertSum(t, c, delta, 100, 110, 5)
+	require.True(t, ok)
+	assert.Equal(t, pcommon.Timestamp(100), dp.StartTimestamp())
+	assert.Equal(t, int64(5), dp.IntVal())
+
+	dp, ok = convertSum(t, c, delta, 110, 120, 3)
+	require.True(t, ok)
+	assert.Equal(t, pcommon.Timestamp(100), dp.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(120), dp.Timestamp())
+	assert.Equal(t, int64(8), dp.IntVal())
+
+	// Overlapping with the previous point.
+	_, ok = convertSum(t, c, delta, 115, 125, 1)
+	assert.False(t, ok)
+
+	dp, ok = convertSum(t, c, delta, 130, 140, 2)
+	require.True(t, ok)
+	assert.Equal(t, pcommon.Timestamp(100), dp.StartTimestamp())
+	assert.Equal(t, int64(10), dp.IntVal())
+}
+
+func TestConvertSeries(t *testing.T) {
+	c := newTestConverter(t, pmetric.MetricAggregationTemporalityDelta)
+	md, m := newTestMetrics(pmetric.MetricDataTypeSum, pmetric.MetricAggregationTemporalityCumulative)
+	m.Sum().SetIsMonotonic(false)
+	for i, value := range []float64{10, 20} {
+		dp := m.Sum().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(105)
+		dp.SetTimestamp(110)
+		dp.SetDoubleVal(value)
+		dp.Attributes().InsertInt("index", int64(i))
+	}
+	gauge := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().AppendEmpty()
+	gauge.SetDataType(pmetric.MetricDataTypeGauge)
+	gauge.Gauge().DataPoints().AppendEmpty().SetDoubleVal(1)
+	expected := md.Clone()
+	expected.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+	c.Convert(md)
+	assert.Empty(t, expected.Diff(md))
+
+	// Each point is subtracted from the previous one of its time series, the sum not being monotonic.
+	dps := m.Sum().DataPoints()
+	m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	dps.At(0).SetTimestamp(120)
+	dps.At(0).SetDoubleVal(5)
+	dps.At(1).SetTimestamp(120)
+	dps.At(1).SetDoubleVal(25)
+	c.Convert(md)
+	assert.Equal(t, -5.0, dps.At(0).DoubleVal())
+	assert.Equal(t, 5.0, dps.At(1).DoubleVal())
+	assert.Equal(t, 1.0, gauge.Gauge().DataPoints().At(0).DoubleVal())
+	assert.Len(t, c.series, 2)
+}
+
+func TestConvertSeriesCollision(t *testing.T) {
+	hash := hashAttributes
+	hashAttributes = func(pcommon.Map) uint64 { return 0 }
+	defer func() { hashAttributes = hash }()
+
+	c := newTestConverter(t, pmetric.MetricAggregationTemporalityDelta)
+	md, m := newTestMetrics(pmetric.MetricDataTypeSum, pmetric.MetricAggregationTemporalityCumulative)
+	for i, value := range []int64{10, 20} {
+		dp := m.Sum().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(105)
+		dp.SetTimestamp(110)
+		dp.SetIntVal(value)
+		dp.Attributes().InsertInt("index", int64(i))
+	}
+	c.Convert(md)
+	dps := m.Sum().DataPoints()
+	require.Equal(t, 2, dps.Len())
+
+	// The points whose attributes have the same hash are still subtracted from their own time series.
+	m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	dps.At(0).SetTimestamp(120)
+	dps.At(0).SetIntVal(15)
+	dps.At(1).SetTimestamp(120)
+	dps.At(1).SetIntVal(30)
+	c.Convert(md)
+	require.Equal(t, 2, dps.Len())
+	assert.Equal(t, int64(5), dps.At(0).IntVal())
+	assert.Equal(t, int64(10), dps.At(1).IntVal())
+	require.Len(t, c.series, 1)
+	for _, ss := range c.series {
+		assert.Len(t, ss, 2)
+	}
+}
+
+func TestConvertUnchanged(t *testing.T) {
+	c := newTestConverter(t, pmetric.MetricAggregationTemporalityDelta)
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	ms.AppendEmpty().SetDataType(pmetric.MetricDataTypeSum)
+	ms.At(0).Sum().DataPoints().AppendEmpty().SetIntVal(1)
+	ms.AppendEmpty().SetDataType(pmetric.MetricDataTypeSum)
+	ms.At(1).Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+	ms.At(1).Sum().DataPoints().AppendEmpty().SetIntVal(1)
+	ms.AppendEmpty().SetDataType(pmetric.MetricDataTypeSummary)
+	ms.At(2).Summary().DataPoints().AppendEmpty().SetCount(1)
+	ms.AppendEmpty().SetDataType(pmetric.MetricDataTypeHistogram)
+	ms.At(3).Histogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	dp := ms.At(3).Histogram().DataPoints().AppendEmpty()
+	dp.SetFlags(pmetric.NewMetricDataPointFlags(pmetric.MetricDataPointFlagNoRecordedValue))
+	expected := md.Clone()
+	expected.ResourceMetrics().At(1).ScopeMetrics().At(0).Metrics().At(3).Histogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+	c.Convert(md)
+	assert.Empty(t, expected.Diff(md))
+	// The points with no recorded value do not create time series.
+	assert.Empty(t, c.series)
+}
+
+func TestConvertStale(t *testing.T) {
+	c := newTestConverter(t, pmetric.MetricAggregationTemporalityDelta)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	cumulative := pmetric.MetricAggregationTemporalityCumulative
+
+	_, ok := convertSum(t, c, cumulative, 105, 110, 10)
+	assert.True(t, ok)
+	now = now.Add(50 * time.Second)
+	_, ok = convertSum(t, c, cumulative, 105, 120, 15)
+	assert.True(t, ok)
+
+	// The time series is forgotten after a minute without points.
+	now = now.Add(time.Minute)
+	_, ok = convertSum(t, c, cumulative, 50, 130, 20)
+	assert.False(t, ok)
+	assert.Len(t, c.series, 1)
+	now = now.Add(time.Minute)
+	c.Convert(pmetric.NewMetrics())
+	assert.Len(t, c.series, 0)
+}
+
+func TestConvertHistogram(t *testing.T) {
+	newHistogram := func(temporality pmetric.MetricAggregationTemporality, start, ts pcommon.Timestamp, bounds []float64, counts []uint64) (pmetric.Metrics, pmetric.HistogramDataPoint) {
+		md, m := newTestMetrics(pmetric.MetricDataTypeHistogram, temporality)
+		dp := m.Histogram().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(ts)
+		dp.SetExplicitBounds(bounds)
+		dp.SetBucketCounts(counts)
+		count := uint64(0)
+		for _, c := range counts {
+			count += c
+		}
+		dp.SetCount(count)
+		dp.SetSum(float64(count))
+		return md, dp
+	}
+
+	c := newTestConverter(t, pmetric.MetricAggregationTemporalityDelta)
+	md, _ := newHistogram(pmetric.MetricAggregationTemporalityCumulative, 105, 110, []float64{1, 2}, []uint64{1, 2, 3})
+	c.Convert(md)
+	assert.Equal(t, 1, md.DataPointCount())
+	md, dp := newHistogram(pmetric.MetricAggregationTemporalityCumulative, 105, 120, []float64{1, 2}, []uint64{2, 2, 5})
+	c.Convert(md)
+	assert.Equal(t, pcommon.Timestamp(110), dp.StartTimestamp())
+	assert.Equal(t, []uint64{1, 0, 2}, dp.BucketCounts())
+	assert.Equal(t, uint64(3), dp.Count())
+	assert.Equal(t, 3.0, dp.Sum())
+	// A bucket decreased, the histogram was reset.
+	md, dp = newHistogram(pmetric.MetricAggregationTemporalityCumulative, 105, 130, []float64{1, 2}, []uint64{1, 3, 6})
+	c.Convert(md)
+	assert.Equal(t, pcommon.Timestamp(120), dp.StartTimestamp())
+	assert.Equal(t, []uint64{1, 3, 6}, dp.BucketCounts())
+	// The buckets changed.
+	md, _ = newHistogram(pmetric.MetricAggregationTemporalityCumulative, 105, 140, []float64{1}, []uint64{5, 6})
+	c.Convert(md)
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+
+	c = newTestConverter(t, pmetric.MetricAggregationTemporalityCumulative)
+	md, _ = newHistogram(pmetric.MetricAggregationTemporalityDelta, 100, 110, []float64{1, 2}, []uint64{1, 2, 3})
+	c.Convert(md)
+	md, dp = newHistogram(pmetric.MetricAggregationTemporalityDelta, 110, 120, []float64{1, 2}, []uint64{2, 0, 1})
+	c.Convert(md)
+	assert.Equal(t, pcommon.Timestamp(100), dp.StartTimestamp())
+	assert.Equal(t, []uint64{3, 2, 4}, dp.BucketCounts())
+	assert.Equal(t, uint64(9), dp.Count())
+	assert.Equal(t, 9.0, dp.Sum())
+}
+
+func TestConvertExponentialHistogram(t *testing.T) {
+	newHistogram := func(temporality pmetric.MetricAggregationTemporality, start, ts pcommon.Timestamp, scale int32, offset int32, counts []uint64) (pmetric.Metrics, pmetric.ExponentialHistogramDataPoint) {
+		md, m := newTestMetrics(pmetric.MetricDataTypeExponentialHistogram, temporality)
+		dp := m.ExponentialHistogram().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(ts)
+		dp.SetScale(scale)
+		dp.SetZeroCount(1)
+		dp.Positive().SetOffset(offset)
+		dp.Positive().SetBucketCounts(counts)
+		count := uint64(1)
+		for _, c := range counts {
+			count += c
+		}
+		dp.SetCount(count)
+		return md, dp
+	}
+
+	c := newTestConverter(t, pmetric.MetricAggregationTemporalityDelta)
+	md, _ := newHistogram(pmetric.MetricAggregationTemporalityCumulative, 105, 110, 1, 0, []uint64{1, 1, 1})
+	c.Convert(md)
+	assert.Equal(t, 1, md.DataPointCount())
+	// The scale was reduced.
+	md, dp := newHistogram(pmetric.MetricAggregationTemporalityCumulative, 105, 120, 0, 0, []uint64{3, 4})
+	c.Convert(md)
+	assert.Equal(t, pcommon.Timestamp(110), dp.StartTimestamp())
+	assert.Equal(t, int32(0), dp.Scale())
+	assert.Equal(t, int32(0), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 3}, dp.Positive().BucketCounts())
+	assert.Equal(t, uint64(0), dp.ZeroCount())
+	assert.Equal(t, uint64(4), dp.Count())
+	// A bucket decreased, the histogram was reset.
+	md, dp = newHistogram(pmetric.MetricAggregationTemporalityCumulative, 105, 130, 0, 1, []uint64{5})
+	c.Convert(md)
+	assert.Equal(t, pcommon.Timestamp(120), dp.StartTimestamp())
+	assert.Equal(t, []uint64{5}, dp.Positive().BucketCounts())
+
+	c = newTestConverter(t, pmetric.MetricAggregationTemporalityCumulative)
+	md, _ = newHistogram(pmetric.MetricAggregationTemporalityDelta, 100, 110, 1, 0, []uint64{1, 1})
+	c.Convert(md)
+	md, dp = newHistogram(pmetric.MetricAggregationTemporalityDelta, 110, 120, 0, 1, []uint64{2})
+	c.Convert(md)
+	assert.Equal(t, pcommon.Timestamp(100), dp.StartTimestamp())
+	assert.Equal(t, int32(0), dp.Scale())
+	assert.Equal(t, int32(0), dp.Positive().Offset())
+	assert.Equal(t, []uint64{2, 2}, dp.Positive().BucketCounts())
+	assert.Equal(t, uint64(2), dp.ZeroCount())
+	assert.Equal(t, uint64(6), dp.Count())
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temporalityprocessor // import "go.opentelemetry.io/collector/processor/temporalityprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/temporalityprocessor/temporality"
+)
+
+type temporalityProcessor struct {
+	converter *temporality.Converter
+}
+
+func newTemporalityProcessor(cfg *Config) (*temporalityProcessor, error) {
+	target, err := cfg.temporality()
+	if err != nil {
+		return nil, err
+	}
+	converter, err := temporality.NewConverter(target, cfg.MaxStaleness)
+	if err != nil {
+		return nil, err
+	}
+	return &temporalityProcessor{converter: converter}, nil
+}
+
+func (tp *temporalityProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	if md.ResourceMetrics().Len() == 0 {
+		return md, nil
+	}
+	tp.converter.Convert(md)
+	// Nothing is left once the points that could not be converted are removed.
+	if md.ResourceMetrics().Len() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
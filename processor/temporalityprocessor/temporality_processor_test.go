@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temporalityprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestTemporalityProcessor(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	mp, err := NewFactory().CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), createDefaultConfig(), sink)
+	require.NoError(t, err)
+
+	start := pcommon.NewTimestampFromTime(time.Now())
+	newMetrics := func(ts pcommon.Timestamp, value int64) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("requests")
+		m.SetDataType(pmetric.MetricDataTypeSum)
+		m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		m.Sum().SetIsMonotonic(true)
+		dp := m.Sum().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(ts)
+		dp.SetIntVal(value)
+		return md
+	}
+
+	assert.NoError(t, mp.ConsumeMetrics(context.Background(), newMetrics(start+10, 3)))
+	assert.NoError(t, mp.ConsumeMetrics(context.Background(), newMetrics(start+20, 5)))
+	// Out of order points are removed, and nothing is left to send.
+	assert.NoError(t, mp.ConsumeMetrics(context.Background(), newMetrics(start+15, 4)))
+	assert.NoError(t, mp.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+
+	mds := sink.AllMetrics()
+	require.Len(t, mds, 3)
+	for i, expected := range []int64{3, 2} {
+		sum := mds[i].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum()
+		assert.Equal(t, pmetric.MetricAggregationTemporalityDelta, sum.AggregationTemporality())
+		assert.Equal(t, expected, sum.DataPoints().At(0).IntVal())
+	}
+	assert.Equal(t, 0, mds[2].ResourceMetrics().Len())
+}
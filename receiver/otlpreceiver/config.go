@@ -40,6 +40,9 @@ type Config struct {
 	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 	// Protocols is the configuration for the supported protocols, currently gRPC and HTTP (Proto and JSON).
 	Protocols `mapstructure:"protocols"`
+	// LazyDecoding keeps the received protobuf requests encoded until their content is accessed,
+	// pipelines that only forward the data then re-export the received bytes.
+	LazyDecoding bool `mapstructure:"lazy_decoding"`
 }
 
 var _ config.Receiver = (*Config)(nil)
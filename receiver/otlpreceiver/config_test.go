@@ -42,7 +42,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 12)
+	assert.Equal(t, len(cfg.Receivers), 13)
 
 	assert.Equal(t, cfg.Receivers[config.NewComponentID(typeStr)], factory.CreateDefaultConfig())
 
@@ -205,6 +205,11 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		})
+
+	lazy := factory.CreateDefaultConfig().(*Config)
+	lazy.SetIDName("lazy")
+	lazy.LazyDecoding = true
+	assert.Equal(t, cfg.Receivers[config.NewComponentIDWithName(typeStr, "lazy")], lazy)
 }
 
 func TestFailedLoadConfig(t *testing.T) {
@@ -33,6 +33,7 @@ const (
 
 var (
 	pbEncoder     = &protoEncoder{}
+	lazyPbEncoder = &protoEncoder{lazy: true}
 	jsEncoder     = &jsonEncoder{}
 	jsonMarshaler = &jsonpb.Marshaler{}
 )
@@ -51,22 +52,34 @@ type encoder interface {
 	contentType() string
 }
 
-type protoEncoder struct{}
+type protoEncoder struct {
+	// lazy keeps the received requests encoded until their content is accessed.
+	lazy bool
+}
 
-func (protoEncoder) unmarshalTracesRequest(buf []byte) (ptraceotlp.Request, error) {
+func (e protoEncoder) unmarshalTracesRequest(buf []byte) (ptraceotlp.Request, error) {
 	req := ptraceotlp.NewRequest()
+	if e.lazy {
+		req = ptraceotlp.NewLazyRequest()
+	}
 	err := req.UnmarshalProto(buf)
 	return req, err
 }
 
-func (protoEncoder) unmarshalMetricsRequest(buf []byte) (pmetricotlp.Request, error) {
+func (e protoEncoder) unmarshalMetricsRequest(buf []byte) (pmetricotlp.Request, error) {
 	req := pmetricotlp.NewRequest()
+	if e.lazy {
+		req = pmetricotlp.NewLazyRequest()
+	}
 	err := req.UnmarshalProto(buf)
 	return req, err
 }
 
-func (protoEncoder) unmarshalLogsRequest(buf []byte) (plogotlp.Request, error) {
+func (e protoEncoder) unmarshalLogsRequest(buf []byte) (plogotlp.Request, error) {
 	req := plogotlp.NewRequest()
+	if e.lazy {
+		req = plogotlp.NewLazyRequest()
+	}
 	err := req.UnmarshalProto(buf)
 	return req, err
 }
@@ -113,15 +113,27 @@ func (r *otlpReceiver) startProtocolServers(host component.Host) error {
 		r.serverGRPC = grpc.NewServer(opts...)
 
 		if r.traceReceiver != nil {
-			ptraceotlp.RegisterServer(r.serverGRPC, r.traceReceiver)
+			if r.cfg.LazyDecoding {
+				ptraceotlp.RegisterLazyServer(r.serverGRPC, r.traceReceiver)
+			} else {
+				ptraceotlp.RegisterServer(r.serverGRPC, r.traceReceiver)
+			}
 		}
 
 		if r.metricsReceiver != nil {
-			pmetricotlp.RegisterServer(r.serverGRPC, r.metricsReceiver)
+			if r.cfg.LazyDecoding {
+				pmetricotlp.RegisterLazyServer(r.serverGRPC, r.metricsReceiver)
+			} else {
+				pmetricotlp.RegisterServer(r.serverGRPC, r.metricsReceiver)
+			}
 		}
 
 		if r.logReceiver != nil {
-			plogotlp.RegisterServer(r.serverGRPC, r.logReceiver)
+			if r.cfg.LazyDecoding {
+				plogotlp.RegisterLazyServer(r.serverGRPC, r.logReceiver)
+			} else {
+				plogotlp.RegisterServer(r.serverGRPC, r.logReceiver)
+			}
 		}
 
 		err = r.startGRPCServer(r.cfg.GRPC, host)
@@ -184,7 +196,7 @@ func (r *otlpReceiver) registerTraceConsumer(tc consumer.Traces) error {
 			}
 			switch req.Header.Get("Content-Type") {
 			case pbContentType:
-				handleTraces(resp, req, r.traceReceiver, pbEncoder)
+				handleTraces(resp, req, r.traceReceiver, r.protoEncoder())
 			case jsonContentType:
 				handleTraces(resp, req, r.traceReceiver, jsEncoder)
 			default:
@@ -208,7 +220,7 @@ func (r *otlpReceiver) registerMetricsConsumer(mc consumer.Metrics) error {
 			}
 			switch req.Header.Get("Content-Type") {
 			case pbContentType:
-				handleMetrics(resp, req, r.metricsReceiver, pbEncoder)
+				handleMetrics(resp, req, r.metricsReceiver, r.protoEncoder())
 			case jsonContentType:
 				handleMetrics(resp, req, r.metricsReceiver, jsEncoder)
 			default:
@@ -232,7 +244,7 @@ func (r *otlpReceiver) registerLogsConsumer(lc consumer.Logs) error {
 			}
 			switch req.Header.Get("Content-Type") {
 			case pbContentType:
-				handleLogs(resp, req, r.logReceiver, pbEncoder)
+				handleLogs(resp, req, r.logReceiver, r.protoEncoder())
 			case jsonContentType:
 				handleLogs(resp, req, r.logReceiver, jsEncoder)
 			default:
@@ -243,6 +255,14 @@ func (r *otlpReceiver) registerLogsConsumer(lc consumer.Logs) error {
 	return nil
 }
 
+// protoEncoder returns the encoder of the protobuf requests received over HTTP.
+func (r *otlpReceiver) protoEncoder() encoder {
+	if r.cfg.LazyDecoding {
+		return lazyPbEncoder
+	}
+	return pbEncoder
+}
+
 func handleUnmatchedMethod(resp http.ResponseWriter) {
 	status := http.StatusMethodNotAllowed
 	writeResponse(resp, "text/plain", status, []byte(fmt.Sprintf("%v method not allowed, supported: [POST]", status)))
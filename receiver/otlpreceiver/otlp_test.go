@@ -506,6 +506,51 @@ func testHTTPProtobufRequest(
 	}
 }
 
+func TestLazyDecoding(t *testing.T) {
+	endpointGrpc := testutil.GetAvailableLocalAddress(t)
+	endpointHTTP := testutil.GetAvailableLocalAddress(t)
+
+	sink := new(consumertest.TracesSink)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetIDName(otlpReceiverName)
+	cfg.GRPC.NetAddr.Endpoint = endpointGrpc
+	cfg.HTTP.Endpoint = endpointHTTP
+	cfg.LazyDecoding = true
+	r := newReceiver(t, factory, cfg, sink, nil)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	td := testdata.GenerateTracesOneSpan()
+	traceBytes, err := ptrace.NewProtoMarshaler().MarshalTraces(td)
+	require.NoError(t, err)
+
+	conn, err := grpc.Dial(endpointGrpc, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, exportTraces(conn, td))
+
+	url := fmt.Sprintf("http://%s/v1/traces", endpointHTTP)
+	resp, err := http.DefaultClient.Do(createHTTPProtobufRequest(t, url, "", traceBytes))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = http.DefaultClient.Do(createHTTPProtobufRequest(t, url, "", []byte("invalid")))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	require.Len(t, sink.AllTraces(), 2)
+	for _, received := range sink.AllTraces() {
+		assert.Equal(t, 1, received.SpanCount())
+		buf, err := ptrace.NewProtoMarshaler().MarshalTraces(received)
+		require.NoError(t, err)
+		assert.Equal(t, traceBytes, buf)
+		assert.Equal(t, td.ResourceSpans(), received.ResourceSpans())
+	}
+}
+
 func TestOTLPReceiverInvalidContentEncoding(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -22,20 +22,30 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"reflect"
 	"runtime"
 	"syscall"
 
+	"github.com/google/uuid"
 	"go.opentelemetry.io/contrib/zpages"
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/metric/nonrecording"
+	"go.opentelemetry.io/otel/propagation"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/atomic"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/extension/ballastextension"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	semconv "go.opentelemetry.io/collector/semconv/v1.5.0"
 	"go.opentelemetry.io/collector/service/internal"
+	"go.opentelemetry.io/collector/service/internal/builder"
+	"go.opentelemetry.io/collector/service/internal/telemetryexport"
 	"go.opentelemetry.io/collector/service/internal/telemetrylogs"
 )
 
@@ -80,6 +90,30 @@ type Collector struct {
 	telemetry           component.TelemetrySettings
 	zPagesSpanProcessor *zpages.SpanProcessor
 
+	// instanceID identifies this collector instance in its own telemetry.
+	instanceID string
+
+	// spanExporter, metricsExporter and logsCore push the collector's own telemetry to the
+	// exporters configured in the service telemetry of the running service.
+	spanExporter    *telemetryexport.SpanExporter
+	metricsExporter *telemetryexport.MetricsExporter
+	logsCore        *telemetryexport.LogsCore
+
+	// logLevels are the levels of the logs of the collector and its components, changed at runtime
+	// on config reloads or through the zPages.
+	logLevels *telemetrylogs.Levels
+
+	// sampler decides which of the collector's own spans are sampled, according to the configuration of the
+	// running service.
+	sampler *internal.Sampler
+
+	// propagatorsSet is true if the global propagator was set from the configuration of the running service.
+	propagatorsSet bool
+
+	// ballastSizeBytes is the size of the memory ballast of the running service, excluded from the
+	// process metrics.
+	ballastSizeBytes atomic.Uint64
+
 	service *service
 	state   *atomic.Int32
 
@@ -103,7 +137,21 @@ func New(set CollectorSettings) (*Collector, error) {
 		set.telemetry = collectorTelemetry
 	}
 
+	instanceUUID, _ := uuid.NewRandom()
+	instanceID := instanceUUID.String()
+
+	res := pcommon.NewResource()
+	res.Attributes().UpsertString(semconv.AttributeServiceName, set.BuildInfo.Command)
+	res.Attributes().UpsertString(semconv.AttributeServiceVersion, set.BuildInfo.Version)
+	res.Attributes().UpsertString(semconv.AttributeServiceInstanceID, instanceID)
+
 	return &Collector{
+		instanceID:      instanceID,
+		spanExporter:    telemetryexport.NewSpanExporter(res),
+		metricsExporter: telemetryexport.NewMetricsExporter(res),
+		logsCore:        telemetryexport.NewLogsCore(res, zapcore.DebugLevel), // filtered by logLevels
+		logLevels:       telemetrylogs.NewLevels(),
+		sampler:         internal.NewSampler(),
 		telemetry: component.TelemetrySettings{
 			Logger:         zap.NewNop(), // Set a Nop logger as a place holder until a logger is created based on configuration
 			TracerProvider: trace.NewNoopTracerProvider(),
@@ -161,13 +209,26 @@ LOOP:
 				break LOOP
 			}
 
+			cfg, getErr := col.set.ConfigProvider.Get(ctx, col.set.Factories)
+			if getErr != nil {
+				col.telemetry.Logger.Error("Failed to get the updated config, terminating process", zap.Error(getErr))
+				return multierr.Append(fmt.Errorf("failed to get config: %w", getErr), col.shutdown(ctx))
+			}
+			if onlyLogLevelsChanged(col.service.config, cfg) {
+				col.telemetry.Logger.Info("Config updated, only the log levels changed, apply them")
+				col.logLevels.Configure(cfg.Service.Telemetry.Logs)
+				col.service.config = cfg
+				continue
+			}
+
 			col.telemetry.Logger.Warn("Config updated, restart service")
 			col.setCollectorState(Closing)
 
+			col.detachTelemetryExporters(ctx)
 			if err = col.service.Shutdown(ctx); err != nil {
 				return fmt.Errorf("failed to shutdown the retiring config: %w", err)
 			}
-			if err = col.setupConfigurationComponents(ctx); err != nil {
+			if err = col.setupConfigurationComponents(ctx, cfg); err != nil {
 				return fmt.Errorf("failed to setup configuration components: %w", err)
 			}
 		case err := <-col.asyncErrorChannel:
@@ -189,26 +250,41 @@ LOOP:
 	return col.shutdown(ctx)
 }
 
-// setupConfigurationComponents loads the config and starts the components. If all the steps succeeds it
+// setupConfigurationComponents starts the components of the config. If all the steps succeeds it
 // sets the col.service with the service currently running.
-func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
+func (col *Collector) setupConfigurationComponents(ctx context.Context, cfg *config.Config) error {
 	col.setCollectorState(Starting)
 
-	cfg, err := col.set.ConfigProvider.Get(ctx, col.set.Factories)
-	if err != nil {
-		return fmt.Errorf("failed to get config: %w", err)
-	}
-
+	var err error
 	col.telemetry.MetricsLevel = cfg.Telemetry.Metrics.Level
 
-	if col.telemetry.Logger, err = telemetrylogs.NewLogger(cfg.Service.Telemetry.Logs, col.set.LoggingOptions); err != nil {
+	options := col.set.LoggingOptions
+	if len(cfg.Service.Telemetry.Logs.Exporters) > 0 {
+		options = append(options[:len(options):len(options)], zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(core, col.logsCore)
+		}))
+	}
+	col.logLevels.Configure(cfg.Service.Telemetry.Logs)
+	if col.telemetry.Logger, err = telemetrylogs.NewLogger(cfg.Service.Telemetry.Logs, col.logLevels, options); err != nil {
 		return fmt.Errorf("failed to get logger: %w", err)
 	}
+	col.logConfigWarnings()
+
+	col.sampler.Configure(cfg.Service.Telemetry.Traces)
+	col.configurePropagators(cfg.Service.Telemetry.Traces.Propagators)
 
 	if !col.set.SkipSettingGRPCLogger {
 		telemetrylogs.SetColGRPCLogger(col.telemetry.Logger, cfg.Service.Telemetry.Logs.Level)
 	}
 
+	// TODO: This should be part of the service initialization, which should be responsible to create TelemetrySettings.
+	// For the moment happens here, since it needs the Config and Logger, and must happen before the components
+	// are created since it sets the MeterProvider. It is called once because that is how it is implemented
+	// using sync.Once.
+	if err = col.set.telemetry.init(col, cfg.Service.Telemetry); err != nil {
+		return err
+	}
+
 	col.service, err = newService(&svcSettings{
 		BuildInfo:           col.set.BuildInfo,
 		Factories:           col.set.Factories,
@@ -216,34 +292,111 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 		Telemetry:           col.telemetry,
 		ZPagesSpanProcessor: col.zPagesSpanProcessor,
 		AsyncErrorChannel:   col.asyncErrorChannel,
+		CollectorState:      col.GetState,
+		LogLevels:           col.logLevels,
 	})
 	if err != nil {
 		return err
 	}
 
-	// TODO: This should be part of the service initialization, which should be responsible to create TelemetrySettings.
-	// For the moment happens here, since it needs service.Config and Logger.
-	// It is called once because that is how it is implemented using sync.Once.
-	if err = col.set.telemetry.init(col); err != nil {
-		return err
-	}
-
 	if err = col.service.Start(ctx); err != nil {
 		return err
 	}
+	col.ballastSizeBytes.Store(getBallastSize(col.service.host))
 
+	col.attachTelemetryExporters(col.service.telemetryExporters)
 	return nil
 }
 
+// logConfigWarnings logs the problems of the configuration that do not make it invalid, reported by the
+// default ConfigProvider.
+func (col *Collector) logConfigWarnings() {
+	cp, ok := col.set.ConfigProvider.(*configProvider)
+	if !ok {
+		return
+	}
+	for _, warn := range cp.warnings {
+		col.telemetry.Logger.Warn("Configuration problem", zap.Error(warn))
+	}
+}
+
+// onlyLogLevelsChanged returns whether the new config only changes the levels of the logs of the current one,
+// in which case they can be applied without restarting the service.
+func onlyLogLevelsChanged(current, updated *config.Config) bool {
+	if current == nil || updated == nil {
+		return false
+	}
+	withoutLevels := *updated
+	withoutLevels.Service.Telemetry.Logs.Level = current.Service.Telemetry.Logs.Level
+	withoutLevels.Service.Telemetry.Logs.ComponentLevels = current.Service.Telemetry.Logs.ComponentLevels
+	return reflect.DeepEqual(current, &withoutLevels)
+}
+
+// configurePropagators sets the global propagator to the given propagators. The global propagator is left
+// unchanged if no propagators are given and none were set by a previous configuration.
+func (col *Collector) configurePropagators(names []string) {
+	if len(names) == 0 && !col.propagatorsSet {
+		return
+	}
+	var propagators []propagation.TextMapPropagator
+	for _, name := range names {
+		switch name {
+		case "tracecontext":
+			propagators = append(propagators, propagation.TraceContext{})
+		case "baggage":
+			propagators = append(propagators, propagation.Baggage{})
+		}
+	}
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagators...))
+	col.propagatorsSet = len(names) > 0
+}
+
+// attachTelemetryExporters starts pushing the collector's own telemetry to the given exporters.
+func (col *Collector) attachTelemetryExporters(te *builder.TelemetryExporters) {
+	// Set only the non nil consumers, a typed nil must not be stored in the interfaces.
+	if te.Traces != nil {
+		col.spanExporter.SetConsumer(te.Traces)
+	}
+	if te.Metrics != nil {
+		col.metricsExporter.SetConsumer(te.Metrics)
+	}
+	if te.Logs != nil {
+		col.logsCore.SetConsumer(te.Logs)
+	}
+}
+
+// detachTelemetryExporters flushes the pending telemetry and stops pushing it to the exporters of the
+// current service, it must be called before the service is shut down.
+func (col *Collector) detachTelemetryExporters(ctx context.Context) {
+	if tp, ok := col.telemetry.TracerProvider.(interface{ ForceFlush(context.Context) error }); ok {
+		if err := tp.ForceFlush(ctx); err != nil {
+			col.telemetry.Logger.Warn("Failed to flush the collector's own spans", zap.Error(err))
+		}
+	}
+	if err := col.logsCore.Flush(ctx); err != nil {
+		col.telemetry.Logger.Warn("Failed to flush the collector's own logs", zap.Error(err))
+	}
+	col.spanExporter.SetConsumer(nil)
+	col.metricsExporter.SetConsumer(nil)
+	col.logsCore.SetConsumer(nil)
+}
+
 // Run starts the collector according to the given configuration given, and waits for it to complete.
 // Consecutive calls to Run are not allowed, Run shouldn't be called once a collector is shut down.
 func (col *Collector) Run(ctx context.Context) error {
 	col.zPagesSpanProcessor = zpages.NewSpanProcessor()
 	col.telemetry.TracerProvider = sdktrace.NewTracerProvider(
-		sdktrace.WithSampler(internal.AlwaysRecord()),
-		sdktrace.WithSpanProcessor(col.zPagesSpanProcessor))
+		sdktrace.WithSampler(col.sampler),
+		sdktrace.WithSpanProcessor(col.zPagesSpanProcessor),
+		sdktrace.WithBatcher(col.spanExporter))
+	col.logsCore.Start()
 
-	if err := col.setupConfigurationComponents(ctx); err != nil {
+	cfg, err := col.set.ConfigProvider.Get(ctx, col.set.Factories)
+	if err != nil {
+		col.setCollectorState(Closed)
+		return fmt.Errorf("failed to get config: %w", err)
+	}
+	if err = col.setupConfigurationComponents(ctx, cfg); err != nil {
 		col.setCollectorState(Closed)
 		return err
 	}
@@ -270,6 +423,11 @@ func (col *Collector) shutdown(ctx context.Context) error {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown config provider: %w", err))
 	}
 
+	col.detachTelemetryExporters(ctx)
+	if err := col.logsCore.Shutdown(ctx); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown collector logs export: %w", err))
+	}
+
 	if err := col.service.Shutdown(ctx); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown service: %w", err))
 	}
@@ -30,14 +30,25 @@ import (
 	"github.com/prometheus/common/expfmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc/grpclog"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/mapconverter/overwritepropertiesmapconverter"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/internal/testcomponents"
 	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver"
 	"go.opentelemetry.io/collector/service/featuregate"
 )
 
@@ -81,6 +92,39 @@ func TestCollectorStartAsGoRoutine(t *testing.T) {
 	assert.Equal(t, Closed, col.GetState())
 }
 
+func TestCollectorLogsConfigWarnings(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	cfgSet := newDefaultConfigProviderSettings([]string{
+		filepath.Join("testdata", "otelcol-unused.yaml"),
+		"yaml:service::telemetry::metrics::address: " + testutil.GetAvailableLocalAddress(t),
+	})
+	cfgProvider, err := NewConfigProvider(cfgSet)
+	require.NoError(t, err)
+
+	core, logs := observer.New(zapcore.WarnLevel)
+	col, err := New(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: cfgProvider,
+		LoggingOptions: []zap.Option{zap.WrapCore(func(c zapcore.Core) zapcore.Core { return zapcore.NewTee(c, core) })},
+		telemetry:      newColTelemetry(featuregate.NewRegistry()),
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return Running == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+	col.Shutdown()
+	wg.Wait()
+
+	warnings := logs.FilterMessage("Configuration problem").All()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "exporters.nop/unused: exporter is defined but not used in any pipeline", warnings[0].ContextMap()["error"])
+}
+
 func TestCollectorCancelContext(t *testing.T) {
 	factories, err := testcomponents.NewDefaultFactories()
 	require.NoError(t, err)
@@ -250,6 +294,229 @@ func TestCollectorStartWithOpenTelemetryMetrics(t *testing.T) {
 	testCollectorStartHelper(t, colTel)
 }
 
+func TestCollectorStartWithTelemetryExporters(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	cfgSet := newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-telemetry-export.yaml")})
+	cfgProvider, err := NewConfigProvider(cfgSet)
+	require.NoError(t, err)
+
+	col, err := New(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: cfgProvider,
+		telemetry:      newColTelemetry(featuregate.NewRegistry()),
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+
+	assert.Eventually(t, func() bool {
+		return Running == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+
+	te := col.service.telemetryExporters
+	assert.Len(t, te.Exporters, 1)
+	assert.NotNil(t, te.Traces)
+	assert.NotNil(t, te.Metrics)
+	assert.NotNil(t, te.Logs)
+	assert.True(t, col.logsCore.Enabled(zapcore.InfoLevel))
+	assert.NoError(t, col.logsCore.Flush(context.Background()))
+
+	col.Shutdown()
+	wg.Wait()
+	assert.Equal(t, Closed, col.GetState())
+}
+
+func TestCollectorStartWithTelemetryExportersAndOpenTelemetryMetrics(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	sink := new(consumertest.MetricsSink)
+	nopFactory := factories.Exporters["nop"]
+	factories.Exporters["nop"] = component.NewExporterFactory(
+		"nop",
+		nopFactory.CreateDefaultConfig,
+		component.WithTracesExporter(nopFactory.CreateTracesExporter),
+		component.WithMetricsExporter(func(_ context.Context, set component.ExporterCreateSettings, cfg config.Exporter) (component.MetricsExporter, error) {
+			return exporterhelper.NewMetricsExporter(cfg, set, sink.ConsumeMetrics)
+		}),
+		component.WithLogsExporter(nopFactory.CreateLogsExporter))
+
+	cfgSet := newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-telemetry-export.yaml")})
+	cfgProvider, err := NewConfigProvider(cfgSet)
+	require.NoError(t, err)
+
+	colTel := newColTelemetry(featuregate.NewRegistry())
+	colTel.registry.Apply(map[string]bool{
+		useOtelForInternalMetricsfeatureGateID: true,
+	})
+	col, err := New(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: cfgProvider,
+		telemetry:      colTel,
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return Running == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+
+	// The metrics recorded with OpenTelemetry are pushed to the metrics exporters.
+	require.NotNil(t, colTel.otelReader)
+	require.NoError(t, colTel.otelReader.Push(context.Background()))
+	require.Len(t, sink.AllMetrics(), 1)
+	names := map[string]bool{}
+	metrics := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		names[metrics.At(i).Name()] = true
+	}
+	assert.True(t, names["otelcol_process_uptime"], "pushed metrics: %v", names)
+
+	col.Shutdown()
+	wg.Wait()
+	assert.Equal(t, Closed, col.GetState())
+}
+
+func TestCollectorPropagatesTraceContext(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	factories.Receivers["otlp"] = otlpreceiver.NewFactory()
+	sink := new(consumertest.TracesSink)
+	factories.Exporters["sink"] = component.NewExporterFactory(
+		"sink",
+		func() config.Exporter {
+			cfg := config.NewExporterSettings(config.NewComponentID("sink"))
+			return &cfg
+		},
+		component.WithTracesExporter(func(_ context.Context, set component.ExporterCreateSettings, cfg config.Exporter) (component.TracesExporter, error) {
+			return exporterhelper.NewTracesExporter(cfg, set, sink.ConsumeTraces)
+		}))
+
+	endpoint := testutil.GetAvailableLocalAddress(t)
+	cfgSet := newDefaultConfigProviderSettings([]string{
+		filepath.Join("testdata", "otelcol-telemetry-traces.yaml"),
+		"yaml:receivers::otlp::protocols::http::endpoint: " + endpoint,
+	})
+	cfgProvider, err := NewConfigProvider(cfgSet)
+	require.NoError(t, err)
+
+	col, err := New(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: cfgProvider,
+		telemetry:      newColTelemetry(featuregate.NewRegistry()),
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return Running == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+
+	// Only the traces sampled by the client are sampled by the collector, since the ratio is 0.
+	for _, traceparent := range []string{
+		"00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01",
+		"00-1112131415161718191a1b1c1d1e1f20-0102030405060708-00",
+	} {
+		req, err := http.NewRequest(http.MethodPost, "http://"+endpoint+"/v1/traces",
+			strings.NewReader(`{"resourceSpans":[{"scopeSpans":[{"spans":[{"name":"span"}]}]}]}`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("traceparent", traceparent)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	col.Shutdown()
+	wg.Wait()
+
+	var receiverSpans []ptrace.Span
+	for _, td := range sink.AllTraces() {
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			sss := rss.At(i).ScopeSpans()
+			for j := 0; j < sss.Len(); j++ {
+				spans := sss.At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", spans.At(k).TraceID().HexString())
+					if spans.At(k).Name() == "receiver/otlp/TraceDataReceived" {
+						receiverSpans = append(receiverSpans, spans.At(k))
+					}
+				}
+			}
+		}
+	}
+	assert.Len(t, receiverSpans, 1)
+}
+
+func TestCollectorIdleStopsExportingTelemetry(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	var tracerProvider atomic.Value
+	exported := atomic.NewInt64(0)
+	// Like an exporter failing to connect, every export produces logs and spans.
+	export := func(ctx context.Context) error {
+		exported.Inc()
+		grpclog.Warning("connection refused")
+		if tp, ok := tracerProvider.Load().(trace.TracerProvider); ok {
+			_, span := tp.Tracer("loop").Start(ctx, "export")
+			span.End()
+		}
+		return nil
+	}
+	factories.Exporters["loop"] = component.NewExporterFactory(
+		"loop",
+		func() config.Exporter {
+			cfg := config.NewExporterSettings(config.NewComponentID("loop"))
+			return &cfg
+		},
+		component.WithTracesExporter(func(_ context.Context, set component.ExporterCreateSettings, cfg config.Exporter) (component.TracesExporter, error) {
+			return exporterhelper.NewTracesExporter(cfg, set, func(ctx context.Context, _ ptrace.Traces) error { return export(ctx) })
+		}),
+		component.WithLogsExporter(func(_ context.Context, set component.ExporterCreateSettings, cfg config.Exporter) (component.LogsExporter, error) {
+			return exporterhelper.NewLogsExporter(cfg, set, func(ctx context.Context, _ plog.Logs) error { return export(ctx) })
+		}))
+
+	cfgSet := newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-telemetry-loop.yaml")})
+	cfgProvider, err := NewConfigProvider(cfgSet)
+	require.NoError(t, err)
+
+	col, err := New(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: cfgProvider,
+		telemetry:      newColTelemetry(featuregate.NewRegistry()),
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return Running == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+	tracerProvider.Store(col.telemetry.TracerProvider)
+
+	flush := func() {
+		require.NoError(t, col.telemetry.TracerProvider.(*sdktrace.TracerProvider).ForceFlush(context.Background()))
+		require.NoError(t, col.logsCore.Flush(context.Background()))
+	}
+	// Export the telemetry produced while starting.
+	flush()
+	require.NotZero(t, exported.Load())
+	flush()
+	idle := exported.Load()
+	flush()
+	flush()
+	assert.Equal(t, idle, exported.Load())
+
+	col.Shutdown()
+	wg.Wait()
+}
+
 func TestCollectorShutdownBeforeRun(t *testing.T) {
 	factories, err := testcomponents.NewDefaultFactories()
 	require.NoError(t, err)
@@ -308,7 +575,7 @@ func TestCollectorClosedStateOnStartUpError(t *testing.T) {
 
 type mockColTelemetry struct{}
 
-func (tel *mockColTelemetry) init(*Collector) error {
+func (tel *mockColTelemetry) init(*Collector, config.ServiceTelemetry) error {
 	return nil
 }
 
@@ -362,6 +629,10 @@ func assertZPages(t *testing.T) {
 		"/debug/pipelinez",
 		"/debug/servicez",
 		"/debug/extensionz",
+		"/debug/servicez.json",
+		"/debug/pipelinez.json",
+		"/debug/extensionz.json",
+		"/debug/featurez.json",
 	}
 
 	const defaultZPagesPort = "55679"
@@ -390,3 +661,126 @@ func startCollector(ctx context.Context, t *testing.T, col *Collector) *sync.Wai
 	}()
 	return wg
 }
+
+// reloadConfigProvider is a ConfigProvider returning the config set by the test, updated on Watch.
+type reloadConfigProvider struct {
+	mu      sync.Mutex
+	cfg     *config.Config
+	getErr  error
+	watchCh chan error
+}
+
+func (p *reloadConfigProvider) Get(context.Context, component.Factories) (*config.Config, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.cfg, p.getErr
+}
+
+func (p *reloadConfigProvider) Watch() <-chan error {
+	return p.watchCh
+}
+
+func (p *reloadConfigProvider) Shutdown(context.Context) error {
+	return nil
+}
+
+func (p *reloadConfigProvider) update(cfg *config.Config) {
+	p.mu.Lock()
+	p.cfg = cfg
+	p.mu.Unlock()
+	p.watchCh <- nil
+}
+
+func TestCollectorReloadLogLevels(t *testing.T) {
+	factories, err := testcomponents.NewDefaultFactories()
+	require.NoError(t, err)
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-config.yaml")}))
+	require.NoError(t, err)
+	cfg, err := cfgProvider.Get(context.Background(), factories)
+	require.NoError(t, err)
+
+	provider := &reloadConfigProvider{cfg: cfg, watchCh: make(chan error)}
+	col, err := New(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: provider,
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return Running == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+	srv := col.service
+
+	// Only the log levels change, the service is not restarted.
+	levelsCfg := *cfg
+	levelsCfg.Service.Telemetry.Logs.Level = zapcore.DebugLevel
+	levelsCfg.Service.Telemetry.Logs.ComponentLevels = map[config.ComponentID]zapcore.Level{
+		config.NewComponentID("otlp"): zapcore.ErrorLevel,
+	}
+	provider.update(&levelsCfg)
+	assert.Eventually(t, func() bool {
+		return col.logLevels.Level() == zapcore.DebugLevel
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, levelsCfg.Service.Telemetry.Logs.ComponentLevels, col.logLevels.ComponentLevels())
+
+	col.Shutdown()
+	wg.Wait()
+	assert.Same(t, srv, col.service)
+	assert.Same(t, &levelsCfg, col.service.config)
+}
+
+func TestCollectorReloadGetError(t *testing.T) {
+	factories, err := testcomponents.NewDefaultFactories()
+	require.NoError(t, err)
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-config.yaml")}))
+	require.NoError(t, err)
+	cfg, err := cfgProvider.Get(context.Background(), factories)
+	require.NoError(t, err)
+
+	provider := &reloadConfigProvider{cfg: cfg, watchCh: make(chan error)}
+	col, err := New(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: provider,
+		telemetry:      newColTelemetry(featuregate.NewRegistry()),
+	})
+	require.NoError(t, err)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- col.Run(context.Background())
+	}()
+	assert.Eventually(t, func() bool {
+		return Running == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+
+	provider.mu.Lock()
+	provider.getErr = errors.New("get error")
+	provider.mu.Unlock()
+	provider.watchCh <- nil
+
+	assert.EqualError(t, <-errCh, "failed to get config: get error")
+	assert.Equal(t, Closed, col.GetState())
+}
+
+func TestOnlyLogLevelsChanged(t *testing.T) {
+	factories, err := testcomponents.NewDefaultFactories()
+	require.NoError(t, err)
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-config.yaml")}))
+	require.NoError(t, err)
+	current, err := cfgProvider.Get(context.Background(), factories)
+	require.NoError(t, err)
+	updated, err := cfgProvider.Get(context.Background(), factories)
+	require.NoError(t, err)
+
+	assert.True(t, onlyLogLevelsChanged(current, updated))
+	updated.Service.Telemetry.Logs.Level = zapcore.WarnLevel
+	assert.True(t, onlyLogLevelsChanged(current, updated))
+	updated.Service.Telemetry.Logs.ComponentLevels = map[config.ComponentID]zapcore.Level{config.NewComponentID("batch"): zapcore.DebugLevel}
+	assert.True(t, onlyLogLevelsChanged(current, updated))
+	updated.Service.Telemetry.Logs.Encoding = "json"
+	assert.False(t, onlyLogLevelsChanged(current, updated))
+	assert.False(t, onlyLogLevelsChanged(nil, updated))
+}
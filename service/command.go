@@ -18,7 +18,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"go.opentelemetry.io/collector/config"
-	"go.opentelemetry.io/collector/config/mapconverter/overwritepropertiesmapconverter"
+	"go.opentelemetry.io/collector/config/mapconverter/setmapconverter"
 	"go.opentelemetry.io/collector/service/featuregate"
 )
 
@@ -32,12 +32,7 @@ func NewCommand(set CollectorSettings) *cobra.Command {
 			featuregate.GetRegistry().Apply(gatesList)
 			if set.ConfigProvider == nil {
 				var err error
-				cfgSet := newDefaultConfigProviderSettings(getConfigFlag())
-				// Append the "overwrite properties converter" as the first converter.
-				cfgSet.MapConverters = append(
-					[]config.MapConverterFunc{overwritepropertiesmapconverter.New(getSetFlag())},
-					cfgSet.MapConverters...)
-				set.ConfigProvider, err = NewConfigProvider(cfgSet)
+				set.ConfigProvider, err = NewConfigProvider(newConfigProviderSettingsFromFlags())
 				if err != nil {
 					return err
 				}
@@ -50,6 +45,18 @@ func NewCommand(set CollectorSettings) *cobra.Command {
 		},
 	}
 
-	rootCmd.Flags().AddGoFlagSet(flags())
+	rootCmd.PersistentFlags().AddGoFlagSet(flags())
+	rootCmd.AddCommand(newValidateSubCommand(set), newPrintConfigSubCommand(set), newSchemaSubCommand(set))
 	return rootCmd
 }
+
+// newConfigProviderSettingsFromFlags returns the ConfigProviderSettings configured by the command line flags.
+func newConfigProviderSettingsFromFlags() ConfigProviderSettings {
+	cfgSet := newDefaultConfigProviderSettings(getConfigFlag())
+	cfgSet.ListMergeStrategy = getListMergeFlag()
+	// Append the "set converter" as the first converter.
+	cfgSet.MapConverters = append(
+		[]config.MapConverterFunc{setmapconverter.New(getSetFlag())},
+		cfgSet.MapConverters...)
+	return cfgSet
+}
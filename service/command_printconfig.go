@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"go.opentelemetry.io/collector/service/featuregate"
+	"go.opentelemetry.io/collector/service/internal/configschema"
+)
+
+// redactedValue replaces the values of the sensitive keys in the printed configuration.
+const redactedValue = "[REDACTED]"
+
+// sensitiveKeyRegexp matches the keys whose values are considered secrets.
+var sensitiveKeyRegexp = regexp.MustCompile(`(?i)(password|passwd|secret|token|api[-_]?key|authorization|credentials?|private[-_]?key)`)
+
+// newPrintConfigSubCommand constructs a new cobra.Command that prints the effective configuration.
+func newPrintConfigSubCommand(set CollectorSettings) *cobra.Command {
+	return &cobra.Command{
+		Use:   "print-config",
+		Short: "Prints the effective configuration, with secrets redacted, without running the collector",
+		Long: "Prints the effective configuration, with secrets redacted, without running the collector.\n" +
+			"The configuration is unmarshalled and validated like when running the collector, so the printed\n" +
+			"configuration of every component includes the default values of the settings not configured.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			featuregate.GetRegistry().Apply(gatesList)
+			onWarning := func(warn error) {
+				fmt.Fprintln(cmd.ErrOrStderr(), "warning:", warn)
+			}
+			cfg, err := loadConfig(cmd.Context(), set, onWarning)
+			if err != nil {
+				return err
+			}
+			out, err := yaml.Marshal(redact(configschema.Encode(cfg)))
+			if err != nil {
+				return err
+			}
+			_, err = cmd.OutOrStdout().Write(out)
+			return err
+		},
+	}
+}
+
+// redact returns a copy of the given value where the values of all the sensitive keys are replaced.
+func redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		ret := make(map[string]interface{}, len(v))
+		for k, mv := range v {
+			if mv != nil && sensitiveKeyRegexp.MatchString(k) {
+				if _, isMap := mv.(map[string]interface{}); !isMap {
+					ret[k] = redactedValue
+					continue
+				}
+			}
+			ret[k] = redact(mv)
+		}
+		return ret
+	case []interface{}:
+		ret := make([]interface{}, 0, len(v))
+		for _, sv := range v {
+			ret = append(ret, redact(sv))
+		}
+		return ret
+	default:
+		return v
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"encoding/json"
+
+	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/collector/service/internal/configschema"
+)
+
+// newSchemaSubCommand constructs a new cobra.Command that prints the JSON Schema of the configuration.
+func newSchemaSubCommand(set CollectorSettings) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Prints the JSON Schema of the configuration supported by the components of this collector",
+		Long: "Prints the JSON Schema of the configuration supported by the components of this collector.\n" +
+			"Field descriptions are the doc comments of the configuration structs of the collector core components.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			return enc.Encode(configschema.Generate(set.Factories))
+		},
+	}
+}
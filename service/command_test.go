@@ -15,13 +15,20 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/internal/testcomponents"
 )
@@ -52,6 +59,170 @@ func TestNewCommandInvalidFactories(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestValidateSubCommand(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	out, err := executeSubCommand(t, CollectorSettings{Factories: factories}, "validate", filepath.Join("testdata", "otelcol-nop.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "Configuration is valid.\n", out)
+
+	_, err = executeSubCommand(t, CollectorSettings{Factories: factories}, "validate", filepath.Join("testdata", "otelcol-invalid.yaml"))
+	assert.EqualError(t, err, `invalid configuration: pipeline "traces" references processor "invalid" which does not exist`)
+
+	_, err = executeSubCommand(t, CollectorSettings{Factories: factories}, "validate", filepath.Join("testdata", "otelcol-invalid-multiple.yaml"))
+	assert.EqualError(t, err, "invalid configuration, 2 errors found:\n"+
+		`  * service references extension "invalid" which does not exist`+"\n"+
+		`  * pipeline "traces" references processor "invalid" which does not exist`)
+}
+
+func TestValidateSubCommandShutsDownComponents(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	exp := &testcomponents.ExampleExporterConsumer{}
+	nopFactory := factories.Exporters["nop"]
+	factories.Exporters["nop"] = component.NewExporterFactory("nop", nopFactory.CreateDefaultConfig,
+		component.WithTracesExporter(func(context.Context, component.ExporterCreateSettings, config.Exporter) (component.TracesExporter, error) {
+			return exp, nil
+		}),
+		component.WithMetricsExporter(func(context.Context, component.ExporterCreateSettings, config.Exporter) (component.MetricsExporter, error) {
+			return exp, nil
+		}),
+		component.WithLogsExporter(func(context.Context, component.ExporterCreateSettings, config.Exporter) (component.LogsExporter, error) {
+			return exp, nil
+		}))
+
+	_, err = executeSubCommand(t, CollectorSettings{Factories: factories}, "validate", filepath.Join("testdata", "otelcol-nop.yaml"))
+	require.NoError(t, err)
+	assert.False(t, exp.ExporterStarted)
+	assert.True(t, exp.ExporterShutdown)
+}
+
+func TestValidateSubCommandWarnings(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	out, errOut, err := executeSubCommandWithStderr(t, CollectorSettings{Factories: factories}, "validate", filepath.Join("testdata", "otelcol-unused.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "Configuration is valid.\n", out)
+	assert.Equal(t, "warning: exporters.nop/unused: exporter is defined but not used in any pipeline\n", errOut)
+}
+
+func TestValidateSubCommandConfigProvider(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nop.yaml")}))
+	require.NoError(t, err)
+	_, err = executeSubCommand(t, CollectorSettings{Factories: factories, ConfigProvider: cfgProvider}, "validate", "")
+	assert.NoError(t, err)
+
+	_, err = executeSubCommand(t, CollectorSettings{Factories: factories, ConfigProvider: &errConfigProvider{}}, "validate", "")
+	assert.Error(t, err)
+}
+
+func TestPrintConfigSubCommand(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	factories.Exporters["secret"] = component.NewExporterFactory("secret", func() config.Exporter {
+		return &secretExporterConfig{
+			ExporterSettings: config.NewExporterSettings(config.NewComponentID("secret")),
+			Timeout:          5 * time.Second,
+		}
+	}, component.WithTracesExporter(func(context.Context, component.ExporterCreateSettings, config.Exporter) (component.TracesExporter, error) {
+		return &testcomponents.ExampleExporterConsumer{}, nil
+	}))
+
+	out, err := executeSubCommand(t, CollectorSettings{Factories: factories}, "print-config", filepath.Join("testdata", "otelcol-secrets.yaml"))
+	require.NoError(t, err)
+	printed := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(out), &printed))
+	// The default values of the settings that are not configured are printed.
+	assert.Equal(t, map[interface{}]interface{}{
+		"secret": map[interface{}]interface{}{
+			"headers": map[interface{}]interface{}{
+				"Authorization": "[REDACTED]",
+				"X-Scope":       "tenant",
+			},
+			"password": "[REDACTED]",
+			"timeout":  "5s",
+		},
+	}, printed["exporters"])
+	assert.Equal(t, map[interface{}]interface{}{"nop": map[interface{}]interface{}{}}, printed["receivers"])
+	pipelines := printed["service"].(map[interface{}]interface{})["pipelines"]
+	assert.Equal(t, map[interface{}]interface{}{
+		"traces": map[interface{}]interface{}{
+			"receivers":  []interface{}{"nop"},
+			"processors": nil,
+			"exporters":  []interface{}{"secret"},
+		},
+	}, pipelines)
+
+	// The configuration is validated before being printed.
+	_, err = executeSubCommand(t, CollectorSettings{Factories: factories}, "print-config", filepath.Join("testdata", "otelcol-invalid.yaml"))
+	assert.Error(t, err)
+
+	_, err = executeSubCommand(t, CollectorSettings{ConfigProvider: &errConfigProvider{}}, "print-config", "")
+	assert.Error(t, err)
+}
+
+type secretExporterConfig struct {
+	config.ExporterSettings `mapstructure:",squash"`
+	Password                string            `mapstructure:"password"`
+	Headers                 map[string]string `mapstructure:"headers"`
+	Timeout                 time.Duration     `mapstructure:"timeout"`
+}
+
+func TestSchemaSubCommand(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	out, err := executeSubCommand(t, CollectorSettings{Factories: factories}, "schema", "")
+	require.NoError(t, err)
+	schema := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(out), &schema))
+	assert.Contains(t, schema["properties"], "receivers")
+	assert.Contains(t, schema["properties"], "service")
+}
+
+// executeSubCommand executes the given sub command, using the given config location if not empty, and returns
+// the standard output.
+func executeSubCommand(t *testing.T, set CollectorSettings, subCommand string, location string) (string, error) {
+	out, _, err := executeSubCommandWithStderr(t, set, subCommand, location)
+	return out, err
+}
+
+// executeSubCommandWithStderr is similar to executeSubCommand, but also returns the standard error.
+func executeSubCommandWithStderr(t *testing.T, set CollectorSettings, subCommand string, location string) (string, string, error) {
+	t.Cleanup(func() { configFlag.values = nil })
+	args := []string{subCommand}
+	if location != "" {
+		args = append(args, "--config", location)
+	}
+	cmd := NewCommand(set)
+	cmd.SetArgs(args)
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	errOut := new(bytes.Buffer)
+	cmd.SetErr(errOut)
+	err := cmd.Execute()
+	return out.String(), errOut.String(), err
+}
+
+type errConfigProvider struct{}
+
+func (*errConfigProvider) Get(context.Context, component.Factories) (*config.Config, error) {
+	return nil, errors.New("get error")
+}
+
+func (*errConfigProvider) Watch() <-chan error {
+	return nil
+}
+
+func (*errConfigProvider) Shutdown(context.Context) error {
+	return nil
+}
+
 // badConfigExtensionFactory was created to force error path from factory returning
 // a config not satisfying the validation.
 var badConfigExtensionFactory = component.NewExtensionFactory(
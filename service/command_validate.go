@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/metric/nonrecording"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/internal/configunmarshaler"
+	"go.opentelemetry.io/collector/service/featuregate"
+)
+
+// newValidateSubCommand constructs a new cobra.Command that validates the configuration without running the collector.
+func newValidateSubCommand(set CollectorSettings) *cobra.Command {
+	return &cobra.Command{
+		Use:          "validate",
+		Short:        "Validates the config without running the collector",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			featuregate.GetRegistry().Apply(gatesList)
+			onWarning := func(warn error) {
+				fmt.Fprintln(cmd.ErrOrStderr(), "warning:", warn)
+			}
+			if err := validateConfig(cmd.Context(), set, onWarning); err != nil {
+				return err
+			}
+			_, err := fmt.Fprintln(cmd.OutOrStdout(), "Configuration is valid.")
+			return err
+		},
+	}
+}
+
+// validateConfig resolves, unmarshalls and validates the configuration, then builds (without starting) all the
+// components and pipelines to check that they can be created, and shuts them down. All the validation errors
+// found are returned, and onWarning is called for the problems that do not make the configuration invalid.
+func validateConfig(ctx context.Context, set CollectorSettings, onWarning func(error)) error {
+	cfg, err := loadConfig(ctx, set, onWarning)
+	if err != nil {
+		return err
+	}
+
+	// Building the service creates all the components and connects the pipelines, this verifies that the components
+	// support the data types of the pipelines where they are used. Nothing is started.
+	srv, err := newService(&svcSettings{
+		BuildInfo: set.BuildInfo,
+		Factories: set.Factories,
+		Config:    cfg,
+		Telemetry: component.TelemetrySettings{
+			Logger:         zap.NewNop(),
+			TracerProvider: trace.NewNoopTracerProvider(),
+			MeterProvider:  nonrecording.NewNoopMeterProvider(),
+			MetricsLevel:   cfg.Telemetry.Metrics.Level,
+		},
+		AsyncErrorChannel: make(chan error),
+	})
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	// The components may have acquired resources when created.
+	if err = srv.Shutdown(ctx); err != nil {
+		return fmt.Errorf("cannot shutdown the components: %w", err)
+	}
+	return nil
+}
+
+// loadConfig returns the validated configuration. If no ConfigProvider is set the configuration is loaded using the
+// command line flags, and unmarshalled in strict mode reporting all the errors, otherwise the ConfigProvider is
+// responsible for the validation.
+func loadConfig(ctx context.Context, set CollectorSettings, onWarning func(error)) (*config.Config, error) {
+	if set.ConfigProvider != nil {
+		cfg, err := set.ConfigProvider.Get(ctx, set.Factories)
+		return cfg, multierr.Append(err, set.ConfigProvider.Shutdown(ctx))
+	}
+
+	cfgMap, err := resolveConfigMapFromFlags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := configunmarshaler.NewStrict(onWarning).Unmarshal(cfgMap, set.Factories)
+	if err != nil {
+		return nil, newValidationError(multierr.Errors(err))
+	}
+
+	if err = cfg.ValidateAll(); err != nil {
+		return nil, newValidationError(multierr.Errors(err))
+	}
+	return cfg, nil
+}
+
+// resolveConfigMapFromFlags resolves the config.Map configured by the command line flags.
+func resolveConfigMapFromFlags(ctx context.Context) (*config.Map, error) {
+	cfgSet := newConfigProviderSettingsFromFlags()
+	mr, err := newMapResolver(cfgSet.Locations, cfgSet.MapProviders, cfgSet.MapConverters)
+	if err != nil {
+		return nil, err
+	}
+	mr.listMerge = cfgSet.ListMergeStrategy
+
+	cfgMap, err := mr.Resolve(ctx)
+	if err = multierr.Append(err, mr.Shutdown(ctx)); err != nil {
+		return nil, fmt.Errorf("cannot resolve the configuration: %w", err)
+	}
+	return cfgMap, nil
+}
+
+// newValidationError returns an error that lists every given error on its own line.
+func newValidationError(errs []error) error {
+	if len(errs) == 1 {
+		return fmt.Errorf("invalid configuration: %w", errs[0])
+	}
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, "  * "+err.Error())
+	}
+	return fmt.Errorf("invalid configuration, %d errors found:\n%s", len(errs), strings.Join(msgs, "\n"))
+}
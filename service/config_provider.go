@@ -64,6 +64,9 @@ type ConfigProvider interface {
 type configProvider struct {
 	mapResolver       *mapResolver
 	configUnmarshaler configunmarshaler.ConfigUnmarshaler
+	// warnings are the problems of the last configuration that do not make it invalid, found when it is
+	// unmarshalled in strict mode.
+	warnings []error
 }
 
 // ConfigProviderSettings are the settings to configure the behavior of the ConfigProvider.
@@ -79,6 +82,10 @@ type ConfigProviderSettings struct {
 	// MapConverters is a slice of config.MapConverterFunc.
 	MapConverters []config.MapConverterFunc
 
+	// ListMergeStrategy defines how the configurations retrieved from the Locations are merged.
+	// Defaults to ListMergeReplace.
+	ListMergeStrategy ListMergeStrategy
+
 	// Deprecated: [v0.50.0] because providing custom ConfigUnmarshaler is not necessary since users can wrap/implement
 	// ConfigProvider if needed to change the resulted config. This functionality will be kept for at least 2 minor versions,
 	// and if nobody express a need for it will be removed.
@@ -90,33 +97,37 @@ func newDefaultConfigProviderSettings(locations []string) ConfigProviderSettings
 		Locations:     locations,
 		MapProviders:  makeMapProvidersMap(filemapprovider.New(), envmapprovider.New(), yamlmapprovider.New()),
 		MapConverters: []config.MapConverterFunc{expandmapconverter.New()},
-		Unmarshaler:   configunmarshaler.NewDefault(),
 	}
 }
 
 // NewConfigProvider returns a new ConfigProvider that provides the service configuration:
 // * Initially it resolves the "configuration map":
-//	 * Retrieve the config.Map by merging all retrieved maps from the given `locations` in order.
+//	 * Retrieve the config.Map by merging all retrieved maps from the given `locations` in order,
+//	   after replacing all the "${include:<uri>}" values with the map retrieved from "<uri>".
 // 	 * Then applies all the config.MapConverterFunc in the given order.
-// * Then unmarshalls the config.Map into the service Config.
+// * Then unmarshalls the config.Map into the service Config. Unless a custom Unmarshaler is set, it is unmarshalled
+//   in strict mode reporting all the problems, and the unused components are logged as warnings by the Collector.
 func NewConfigProvider(set ConfigProviderSettings) (ConfigProvider, error) {
 	mr, err := newMapResolver(set.Locations, set.MapProviders, set.MapConverters)
 	if err != nil {
 		return nil, err
 	}
+	mr.listMerge = set.ListMergeStrategy
 
-	unmarshaler := set.Unmarshaler
-	if unmarshaler == nil {
-		unmarshaler = configunmarshaler.NewDefault()
-	}
-
-	return &configProvider{
+	cm := &configProvider{
 		mapResolver:       mr,
-		configUnmarshaler: unmarshaler,
-	}, nil
+		configUnmarshaler: set.Unmarshaler,
+	}
+	if cm.configUnmarshaler == nil {
+		cm.configUnmarshaler = configunmarshaler.NewStrict(func(warn error) {
+			cm.warnings = append(cm.warnings, warn)
+		})
+	}
+	return cm, nil
 }
 
 func (cm *configProvider) Get(ctx context.Context, factories component.Factories) (*config.Config, error) {
+	cm.warnings = nil
 	retMap, err := cm.mapResolver.Resolve(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("cannot resolve the configuration: %w", err)
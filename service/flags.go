@@ -25,6 +25,7 @@ var (
 	// Command-line flag that control the configuration file.
 	configFlag = new(stringArrayValue)
 	setFlag    = new(stringArrayValue)
+	mergeFlag  = new(listMergeValue)
 	gatesList  = featuregate.FlagValue{}
 )
 
@@ -41,16 +42,34 @@ func (s *stringArrayValue) String() string {
 	return "[" + strings.Join(s.values, ", ") + "]"
 }
 
+type listMergeValue struct {
+	strategy ListMergeStrategy
+}
+
+func (l *listMergeValue) Set(val string) error {
+	return l.strategy.UnmarshalText([]byte(val))
+}
+
+func (l *listMergeValue) String() string {
+	return l.strategy.String()
+}
+
 func flags() *flag.FlagSet {
 	flagSet := new(flag.FlagSet)
 
 	flagSet.Var(configFlag, "config", "Locations to the config file(s), note that only a"+
 		" single location can be set per flag entry e.g. `-config=file:/path/to/first --config=file:path/to/second`.")
 
+	flagSet.Var(mergeFlag, "config-list-merge", "Strategy used to merge the config locations, \"replace\" (default)"+
+		" overrides values from previous locations, \"append\" appends the pipelines' components lists and"+
+		" reports any other conflicting key e.g. `--config-list-merge=append`.")
+
 	flagSet.Var(setFlag, "set",
-		"Set arbitrary component config property. The component has to be defined in the config file and the flag"+
-			" has a higher precedence. Array config properties are overridden and maps are joined, note that only a single"+
-			" (first) array property can be set e.g. -set=processors.attributes.actions.key=some_key. Example --set=processors.batch.timeout=2s")
+		"Set arbitrary component config property. The flag has a higher precedence than the config files, and can be"+
+			" repeated. Use \"<path>=<value>\" to set a YAML typed value, where a path element can be followed by"+
+			" \"[<index>]\" to address a list element, and a value starting with \"@\" is read from the given file."+
+			" Use \"-<path>\" to delete a key. Example --set=processors.batch.timeout=2s"+
+			" --set=service.pipelines.traces.exporters[1]=otlp --set=-exporters.logging")
 
 	flagSet.Var(
 		gatesList,
@@ -64,6 +83,10 @@ func getConfigFlag() []string {
 	return configFlag.values
 }
 
+func getListMergeFlag() ListMergeStrategy {
+	return mergeFlag.strategy
+}
+
 func getSetFlag() []string {
 	return setFlag.values
 }
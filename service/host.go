@@ -16,11 +16,14 @@ package service // import "go.opentelemetry.io/collector/service"
 
 import (
 	"go.opentelemetry.io/contrib/zpages"
+	"go.uber.org/atomic"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/service/internal/builder"
 	"go.opentelemetry.io/collector/service/internal/extensions"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/telemetrylogs"
 )
 
 var _ component.Host = (*serviceHost)(nil)
@@ -28,12 +31,24 @@ var _ component.Host = (*serviceHost)(nil)
 type serviceHost struct {
 	asyncErrorChannel   chan error
 	factories           component.Factories
+	buildInfo           component.BuildInfo
 	zPagesSpanProcessor *zpages.SpanProcessor
 
+	// collectorState returns the state of the collector running the service, nil if unknown.
+	collectorState func() State
+
 	builtExporters  builder.Exporters
 	builtReceivers  builder.Receivers
 	builtPipelines  builder.BuiltPipelines
 	builtExtensions extensions.Extensions
+
+	statusReporter *status.Reporter
+
+	// activeTaps is the number of taps being streamed by the tapz page.
+	activeTaps *atomic.Int32
+
+	// logLevels are the levels of the logs of the collector, nil if unknown.
+	logLevels *telemetrylogs.Levels
 }
 
 // ReportFatalError is used to report to the host that the receiver encountered
@@ -43,6 +58,16 @@ func (host *serviceHost) ReportFatalError(err error) {
 	host.asyncErrorChannel <- err
 }
 
+// ReportComponentStatus is a no-op: the components are started with a host identifying them, that
+// reports their status using ReportComponentStatusOf.
+func (host *serviceHost) ReportComponentStatus(*component.StatusEvent) {}
+
+// ReportComponentStatusOf records the status reported by the source component, and notifies the
+// extensions watching the status changes.
+func (host *serviceHost) ReportComponentStatusOf(source *component.InstanceID, event *component.StatusEvent) {
+	host.statusReporter.ReportComponentStatus(source, event)
+}
+
 func (host *serviceHost) GetFactory(kind component.Kind, componentType config.Type) component.Factory {
 	switch kind {
 	case component.KindReceiver:
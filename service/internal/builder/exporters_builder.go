@@ -30,27 +30,37 @@ import (
 // builtExporter is an exporter that is built based on a config. It can have
 // a trace and/or a metrics consumer and have a shutdown function.
 type builtExporter struct {
+	id            config.ComponentID
 	logger        *zap.Logger
 	expByDataType map[config.DataType]component.Exporter
+	// host is the host the exporter was started with, nil if not started.
+	host component.Host
 }
 
 // Start the exporter.
 func (bexp *builtExporter) Start(ctx context.Context, host component.Host) error {
 	var errs error
 	bexp.logger.Info("Exporter is starting...")
+	bexp.host = components.NewHostWrapper(host, &component.InstanceID{ID: bexp.id, Kind: component.KindExporter}, bexp.logger)
+	bexp.host.ReportComponentStatus(component.NewStatusEvent(component.StatusStarting))
 	for _, exporter := range bexp.expByDataType {
-		errs = multierr.Append(errs, exporter.Start(ctx, components.NewHostWrapper(host, bexp.logger)))
+		errs = multierr.Append(errs, exporter.Start(ctx, bexp.host))
 	}
 
 	if errs != nil {
+		bexp.host.ReportComponentStatus(component.NewPermanentErrorEvent(errs))
 		return errs
 	}
+	bexp.host.ReportComponentStatus(component.NewStatusEvent(component.StatusOK))
 	bexp.logger.Info("Exporter started.")
 	return nil
 }
 
 // Shutdown the trace component and the metrics component of an exporter.
 func (bexp *builtExporter) Shutdown(ctx context.Context) error {
+	if bexp.host != nil {
+		bexp.host.ReportComponentStatus(component.NewStatusEvent(component.StatusStopping))
+	}
 	var errs error
 	for _, exporter := range bexp.expByDataType {
 		errs = multierr.Append(errs, exporter.Shutdown(ctx))
@@ -209,6 +219,7 @@ func buildExporter(
 	inputDataTypes dataTypeRequirements,
 ) (*builtExporter, error) {
 	exporter := &builtExporter{
+		id:            cfg.ID(),
 		logger:        set.Logger,
 		expByDataType: make(map[config.DataType]component.Exporter, 3),
 	}
@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder // import "go.opentelemetry.io/collector/service/internal/builder"
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/atomic"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const latencyScopeName = "go.opentelemetry.io/collector/service"
+
+// latencyRecorder records the time spent consuming the data of a pipeline, from a receiver or a processor.
+// The pipeline is added to the tags of the context, to tag the latencies recorded by the next components.
+// The time spent by the next consumers wrapped by wrap*Downstream with the same latencyRecorder, when called
+// with the context of the operation, is excluded, e.g. to record only the time spent by a processor itself.
+type latencyRecorder struct {
+	measure  *stats.Int64Measure
+	mutators []tag.Mutator
+	recorder *obsreportconfig.Recorder
+}
+
+// newLatencyRecorder returns a latencyRecorder for the component with the given key and ID in the pipeline,
+// or nil if the latencies are not recorded at the given level.
+func newLatencyRecorder(level configtelemetry.Level, mp metric.MeterProvider, measure *stats.Int64Measure, tagKey tag.Key, id, pipelineID config.ComponentID) *latencyRecorder {
+	if level != configtelemetry.LevelDetailed {
+		return nil
+	}
+	return &latencyRecorder{
+		measure: measure,
+		mutators: []tag.Mutator{
+			tag.Upsert(tagKey, id.String(), tag.WithTTL(tag.TTLNoPropagation)),
+			tag.Upsert(obsmetrics.TagKeyPipeline, pipelineID.String(), tag.WithTTL(tag.TTLNoPropagation)),
+		},
+		recorder: obsreportconfig.NewRecorder(mp, latencyScopeName, obsreportconfig.AllViews(),
+			attribute.String(tagKey.Name(), id.String()),
+			attribute.String(obsmetrics.PipelineKey, pipelineID.String())),
+	}
+}
+
+// latencyOp is an operation whose latency is recorded.
+type latencyOp struct {
+	start time.Time
+	// downstream is the time, in nanoseconds, spent by the next consumers during the operation.
+	downstream atomic.Int64
+}
+
+// downstreamKey is the context key of the latencyOp of the latencyRecorder.
+type downstreamKey struct {
+	lr *latencyRecorder
+}
+
+// start returns the context, with the pipeline tag, to use to consume the data, and the started operation.
+func (lr *latencyRecorder) start(ctx context.Context) (context.Context, *latencyOp) {
+	op := &latencyOp{start: time.Now()}
+	ctx, _ = tag.New(ctx, lr.mutators[1])
+	return context.WithValue(ctx, downstreamKey{lr: lr}, op), op
+}
+
+// end records the time elapsed since the start of the operation, excluding the time spent downstream.
+func (lr *latencyRecorder) end(ctx context.Context, op *latencyOp) {
+	elapsed := time.Since(op.start) - time.Duration(op.downstream.Load())
+	lr.recorder.Record(ctx, lr.mutators, lr.measure.M(elapsed.Milliseconds()))
+}
+
+// downstream returns a function to call once the next consumer returns, to exclude the time it spent from
+// the operation in the context, if any.
+func (lr *latencyRecorder) downstream(ctx context.Context) func() {
+	op, ok := ctx.Value(downstreamKey{lr: lr}).(*latencyOp)
+	if !ok {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		op.downstream.Add(int64(time.Since(start)))
+	}
+}
+
+type latencyTraces struct {
+	consumer.Traces
+	lr *latencyRecorder
+}
+
+func (lt latencyTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	ctx, op := lt.lr.start(ctx)
+	err := lt.Traces.ConsumeTraces(ctx, td)
+	lt.lr.end(ctx, op)
+	return err
+}
+
+type latencyMetrics struct {
+	consumer.Metrics
+	lr *latencyRecorder
+}
+
+func (lm latencyMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	ctx, op := lm.lr.start(ctx)
+	err := lm.Metrics.ConsumeMetrics(ctx, md)
+	lm.lr.end(ctx, op)
+	return err
+}
+
+type latencyLogs struct {
+	consumer.Logs
+	lr *latencyRecorder
+}
+
+func (ll latencyLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	ctx, op := ll.lr.start(ctx)
+	err := ll.Logs.ConsumeLogs(ctx, ld)
+	ll.lr.end(ctx, op)
+	return err
+}
+
+type downstreamTraces struct {
+	consumer.Traces
+	lr *latencyRecorder
+}
+
+func (dt downstreamTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	defer dt.lr.downstream(ctx)()
+	return dt.Traces.ConsumeTraces(ctx, td)
+}
+
+type downstreamMetrics struct {
+	consumer.Metrics
+	lr *latencyRecorder
+}
+
+func (dm downstreamMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	defer dm.lr.downstream(ctx)()
+	return dm.Metrics.ConsumeMetrics(ctx, md)
+}
+
+type downstreamLogs struct {
+	consumer.Logs
+	lr *latencyRecorder
+}
+
+func (dl downstreamLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	defer dl.lr.downstream(ctx)()
+	return dl.Logs.ConsumeLogs(ctx, ld)
+}
+
+// wrapTracesLatency returns the consumer recording its latencies with the latencyRecorder, if not nil.
+func wrapTracesLatency(tc consumer.Traces, lr *latencyRecorder) consumer.Traces {
+	if lr == nil {
+		return tc
+	}
+	return latencyTraces{Traces: tc, lr: lr}
+}
+
+// wrapMetricsLatency returns the consumer recording its latencies with the latencyRecorder, if not nil.
+func wrapMetricsLatency(mc consumer.Metrics, lr *latencyRecorder) consumer.Metrics {
+	if lr == nil {
+		return mc
+	}
+	return latencyMetrics{Metrics: mc, lr: lr}
+}
+
+// wrapLogsLatency returns the consumer recording its latencies with the latencyRecorder, if not nil.
+func wrapLogsLatency(lc consumer.Logs, lr *latencyRecorder) consumer.Logs {
+	if lr == nil {
+		return lc
+	}
+	return latencyLogs{Logs: lc, lr: lr}
+}
+
+// wrapTracesDownstream returns the next consumer whose time is excluded from the latencies recorded by the
+// latencyRecorder, if not nil.
+func wrapTracesDownstream(tc consumer.Traces, lr *latencyRecorder) consumer.Traces {
+	if lr == nil {
+		return tc
+	}
+	return downstreamTraces{Traces: tc, lr: lr}
+}
+
+// wrapMetricsDownstream returns the next consumer whose time is excluded from the latencies recorded by the
+// latencyRecorder, if not nil.
+func wrapMetricsDownstream(mc consumer.Metrics, lr *latencyRecorder) consumer.Metrics {
+	if lr == nil {
+		return mc
+	}
+	return downstreamMetrics{Metrics: mc, lr: lr}
+}
+
+// wrapLogsDownstream returns the next consumer whose time is excluded from the latencies recorded by the
+// latencyRecorder, if not nil.
+func wrapLogsDownstream(lc consumer.Logs, lr *latencyRecorder) consumer.Logs {
+	if lr == nil {
+		return lc
+	}
+	return downstreamLogs{Logs: lc, lr: lr}
+}
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/internal/testcomponents"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/servicetest"
+)
+
+func TestBuildLatency(t *testing.T) {
+	tests := []struct {
+		level    configtelemetry.Level
+		wantRows bool
+	}{
+		{level: configtelemetry.LevelNormal, wantRows: false},
+		{level: configtelemetry.LevelDetailed, wantRows: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			set, err := obsreporttest.SetupTelemetry()
+			require.NoError(t, err)
+			t.Cleanup(func() { require.NoError(t, set.Shutdown(context.Background())) })
+
+			factories, err := testcomponents.ExampleComponents()
+			require.NoError(t, err)
+			cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "pipelines_builder.yaml"), factories)
+			require.NoError(t, err)
+			cfg.Telemetry.Metrics.Level = tt.level
+
+			exporters, err := BuildExporters(componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo(), cfg, factories.Exporters)
+			require.NoError(t, err)
+			pipelines, err := BuildPipelines(componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo(), cfg, exporters, factories.Processors)
+			require.NoError(t, err)
+			receivers, err := BuildReceivers(componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo(), cfg, pipelines, factories.Receivers)
+			require.NoError(t, err)
+
+			// The receiver is attached to the "traces" and "traces/2" pipelines, both with the processor.
+			producer := receivers[config.NewComponentIDWithName("examplereceiver", "multi")].receiver.(*testcomponents.ExampleReceiverProducer)
+			require.NoError(t, producer.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+
+			receiverRows, err := view.RetrieveData(obsmetrics.ReceiverPrefix + obsmetrics.LatencyKey)
+			require.NoError(t, err)
+			processorRows, err := view.RetrieveData(obsmetrics.ProcessorPrefix + obsmetrics.LatencyKey)
+			require.NoError(t, err)
+			if !tt.wantRows {
+				assert.Empty(t, receiverRows)
+				assert.Empty(t, processorRows)
+				return
+			}
+
+			assertLatencyRows(t, receiverRows, obsmetrics.TagKeyReceiver, "examplereceiver/multi")
+			assertLatencyRows(t, processorRows, obsmetrics.TagKeyProcessor, "exampleprocessor")
+		})
+	}
+}
+
+func TestLatencyExcludesDownstream(t *testing.T) {
+	set, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, set.Shutdown(context.Background())) })
+
+	lr := newLatencyRecorder(configtelemetry.LevelDetailed, nil, obsmetrics.ProcessorLatency, obsmetrics.TagKeyProcessor,
+		config.NewComponentID("exampleprocessor"), config.NewComponentID("traces"))
+	next := wrapTracesDownstream(consumerFunc(func(context.Context, ptrace.Traces) error {
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	}), lr)
+	// The processor calls the next consumer synchronously, then asynchronously like the batch processor.
+	done := make(chan struct{})
+	proc := wrapTracesLatency(consumerFunc(func(ctx context.Context, td ptrace.Traces) error {
+		go func() {
+			assert.NoError(t, next.ConsumeTraces(context.Background(), td))
+			close(done)
+		}()
+		return next.ConsumeTraces(ctx, td)
+	}), lr)
+	require.NoError(t, proc.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	<-done
+
+	rows, err := view.RetrieveData(obsmetrics.ProcessorPrefix + obsmetrics.LatencyKey)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	data := rows[0].Data.(*view.DistributionData)
+	assert.EqualValues(t, 1, data.Count)
+	assert.Less(t, data.Max, float64(100))
+}
+
+type consumerFunc func(context.Context, ptrace.Traces) error
+
+func (f consumerFunc) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return f(ctx, td)
+}
+
+func (f consumerFunc) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
+// assertLatencyRows asserts that the component recorded one latency in each of the "traces" and "traces/2" pipelines.
+func assertLatencyRows(t *testing.T, rows []*view.Row, key tag.Key, id string) {
+	require.Len(t, rows, 2)
+	var pipelines []string
+	for _, row := range rows {
+		value, ok := tagValue(row.Tags, key)
+		require.True(t, ok)
+		assert.Equal(t, id, value)
+		pipeline, ok := tagValue(row.Tags, obsmetrics.TagKeyPipeline)
+		require.True(t, ok)
+		pipelines = append(pipelines, pipeline)
+		assert.EqualValues(t, 1, row.Data.(*view.DistributionData).Count)
+	}
+	sort.Strings(pipelines)
+	assert.Equal(t, []string{"traces", "traces/2"}, pipelines)
+}
+
+func tagValue(tags []tag.Tag, key tag.Key) (string, bool) {
+	for _, t := range tags {
+		if t.Key == key {
+			return t.Value, true
+		}
+	}
+	return "", false
+}
@@ -24,14 +24,17 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/service/internal/components"
 	"go.opentelemetry.io/collector/service/internal/fanoutconsumer"
+	"go.opentelemetry.io/collector/service/internal/tap"
 )
 
 // builtPipeline is a pipeline that is built based on a config.
 // It can have a trace and/or a metrics consumer (the consumer is either the first
 // processor in the pipeline or the exporter if pipeline has no processors).
 type builtPipeline struct {
+	id      config.ComponentID
 	logger  *zap.Logger
 	firstTC consumer.Traces
 	firstMC consumer.Metrics
@@ -44,21 +47,44 @@ type builtPipeline struct {
 	MutatesData bool
 
 	processors []component.Processor
+	// processorHosts are the hosts the processors were started with, nil if not started.
+	processorHosts []component.Host
+
+	// taps are the taps of the points of the pipeline: after its receivers and processors, and before
+	// its exporters.
+	taps map[tap.Point]*tap.Tap
+}
+
+// newTap returns a new tap of the point of the pipeline identified by the kind and ID of the component.
+func newTap(taps map[tap.Point]*tap.Tap, kind component.Kind, id config.ComponentID) *tap.Tap {
+	t := tap.New()
+	taps[tap.Point{Kind: kind, ID: id}] = t
+	return t
 }
 
 // BuiltPipelines is a map of build pipelines created from pipeline configs.
 type BuiltPipelines map[config.ComponentID]*builtPipeline
 
+// GetTap returns the tap of the point of the pipeline, nil if the pipeline or the point does not exist.
+func (bps BuiltPipelines) GetTap(pipelineID config.ComponentID, point tap.Point) *tap.Tap {
+	bp, ok := bps[pipelineID]
+	if !ok {
+		return nil
+	}
+	return bp.taps[point]
+}
+
 func (bps BuiltPipelines) StartProcessors(ctx context.Context, host component.Host) error {
 	for _, bp := range bps {
 		bp.logger.Info("Pipeline is starting...")
-		hostWrapper := components.NewHostWrapper(host, bp.logger)
 		// Start in reverse order, starting from the back of processors pipeline.
 		// This is important so that processors that are earlier in the pipeline and
 		// reference processors that are later in the pipeline do not start sending
 		// data to later pipelines which are not yet started.
+		bp.processorHosts = make([]component.Host, len(bp.processors))
 		for i := len(bp.processors) - 1; i >= 0; i-- {
-			if err := bp.processors[i].Start(ctx, hostWrapper); err != nil {
+			bp.processorHosts[i] = components.NewHostWrapper(host, &component.InstanceID{ID: bp.Config.Processors[i], Kind: component.KindProcessor, PipelineID: bp.id}, bp.logger)
+			if err := components.StartWithStatus(ctx, bp.processors[i], bp.processorHosts[i]); err != nil {
 				return err
 			}
 		}
@@ -71,8 +97,12 @@ func (bps BuiltPipelines) ShutdownProcessors(ctx context.Context) error {
 	var errs error
 	for _, bp := range bps {
 		bp.logger.Info("Pipeline is shutting down...")
-		for _, p := range bp.processors {
-			errs = multierr.Append(errs, p.Shutdown(ctx))
+		for i, p := range bp.processors {
+			var host component.Host
+			if bp.processorHosts != nil {
+				host = bp.processorHosts[i]
+			}
+			errs = multierr.Append(errs, components.ShutdownWithStatus(ctx, p, host))
 		}
 		bp.logger.Info("Pipeline is shutdown.")
 	}
@@ -124,17 +154,19 @@ func (pb *pipelinesBuilder) buildPipeline(ctx context.Context, pipelineID config
 	var mc consumer.Metrics
 	var lc consumer.Logs
 
+	taps := make(map[tap.Point]*tap.Tap)
+
 	// Take into consideration the Capabilities for the exporter as well.
 	mutatesConsumedData := false
 	switch pipelineID.Type() {
 	case config.TracesDataType:
-		tc = pb.buildFanoutExportersTracesConsumer(pipelineCfg.Exporters)
+		tc = pb.buildFanoutExportersTracesConsumer(pipelineCfg.Exporters, taps)
 		mutatesConsumedData = tc.Capabilities().MutatesData
 	case config.MetricsDataType:
-		mc = pb.buildFanoutExportersMetricsConsumer(pipelineCfg.Exporters)
+		mc = pb.buildFanoutExportersMetricsConsumer(pipelineCfg.Exporters, taps)
 		mutatesConsumedData = mc.Capabilities().MutatesData
 	case config.LogsDataType:
-		lc = pb.buildFanoutExportersLogsConsumer(pipelineCfg.Exporters)
+		lc = pb.buildFanoutExportersLogsConsumer(pipelineCfg.Exporters, taps)
 		mutatesConsumedData = lc.Capabilities().MutatesData
 	}
 
@@ -177,7 +209,9 @@ func (pb *pipelinesBuilder) buildPipeline(ctx context.Context, pipelineID config
 		switch pipelineID.Type() {
 		case config.TracesDataType:
 			var proc component.TracesProcessor
-			if proc, err = factory.CreateTracesProcessor(ctx, set, procCfg, tc); err != nil {
+			lr := pb.newProcessorLatencyRecorder(procID, pipelineID)
+			next := wrapTracesDownstream(newTap(taps, component.KindProcessor, procID).Traces(tc), lr)
+			if proc, err = factory.CreateTracesProcessor(ctx, set, procCfg, next); err != nil {
 				return nil, fmt.Errorf("error creating processor %q in pipeline %q: %w", procID, pipelineID, err)
 			}
 			// Check if the factory really created the processor.
@@ -186,10 +220,12 @@ func (pb *pipelinesBuilder) buildPipeline(ctx context.Context, pipelineID config
 			}
 			mutatesConsumedData = mutatesConsumedData || proc.Capabilities().MutatesData
 			processors[i] = proc
-			tc = proc
+			tc = wrapTracesLatency(proc, lr)
 		case config.MetricsDataType:
 			var proc component.MetricsProcessor
-			if proc, err = factory.CreateMetricsProcessor(ctx, set, procCfg, mc); err != nil {
+			lr := pb.newProcessorLatencyRecorder(procID, pipelineID)
+			next := wrapMetricsDownstream(newTap(taps, component.KindProcessor, procID).Metrics(mc), lr)
+			if proc, err = factory.CreateMetricsProcessor(ctx, set, procCfg, next); err != nil {
 				return nil, fmt.Errorf("error creating processor %q in pipeline %q: %w", procID, pipelineID, err)
 			}
 			// Check if the factory really created the processor.
@@ -198,11 +234,13 @@ func (pb *pipelinesBuilder) buildPipeline(ctx context.Context, pipelineID config
 			}
 			mutatesConsumedData = mutatesConsumedData || proc.Capabilities().MutatesData
 			processors[i] = proc
-			mc = proc
+			mc = wrapMetricsLatency(proc, lr)
 
 		case config.LogsDataType:
 			var proc component.LogsProcessor
-			if proc, err = factory.CreateLogsProcessor(ctx, set, procCfg, lc); err != nil {
+			lr := pb.newProcessorLatencyRecorder(procID, pipelineID)
+			next := wrapLogsDownstream(newTap(taps, component.KindProcessor, procID).Logs(lc), lr)
+			if proc, err = factory.CreateLogsProcessor(ctx, set, procCfg, next); err != nil {
 				return nil, fmt.Errorf("error creating processor %q in pipeline %q: %w", procID, pipelineID, err)
 			}
 			// Check if the factory really created the processor.
@@ -211,7 +249,7 @@ func (pb *pipelinesBuilder) buildPipeline(ctx context.Context, pipelineID config
 			}
 			mutatesConsumedData = mutatesConsumedData || proc.Capabilities().MutatesData
 			processors[i] = proc
-			lc = proc
+			lc = wrapLogsLatency(proc, lr)
 
 		default:
 			return nil, fmt.Errorf("error creating processor %q in pipeline %q, data type %s is not supported",
@@ -237,6 +275,7 @@ func (pb *pipelinesBuilder) buildPipeline(ctx context.Context, pipelineID config
 		lc = capabilitiesLogs{Logs: lc, capabilities: consumer.Capabilities{MutatesData: mutatesConsumedData}}
 	}
 	bp := &builtPipeline{
+		id:          pipelineID,
 		logger:      pipelineLogger,
 		firstTC:     tc,
 		firstMC:     mc,
@@ -244,11 +283,19 @@ func (pb *pipelinesBuilder) buildPipeline(ctx context.Context, pipelineID config
 		Config:      pipelineCfg,
 		MutatesData: mutatesConsumedData,
 		processors:  processors,
+		taps:        taps,
 	}
 
 	return bp, nil
 }
 
+// newProcessorLatencyRecorder returns the latencyRecorder of the processor in the pipeline, nil if the
+// latencies are not recorded.
+func (pb *pipelinesBuilder) newProcessorLatencyRecorder(procID, pipelineID config.ComponentID) *latencyRecorder {
+	return newLatencyRecorder(pb.config.Telemetry.Metrics.Level, pb.settings.MeterProvider,
+		obsmetrics.ProcessorLatency, obsmetrics.TagKeyProcessor, procID, pipelineID)
+}
+
 // Converts the list of exporter names to a list of corresponding builtExporters.
 func (pb *pipelinesBuilder) getBuiltExportersByIDs(exporterIDs []config.ComponentID) []*builtExporter {
 	var result []*builtExporter
@@ -260,36 +307,36 @@ func (pb *pipelinesBuilder) getBuiltExportersByIDs(exporterIDs []config.Componen
 	return result
 }
 
-func (pb *pipelinesBuilder) buildFanoutExportersTracesConsumer(exporterIDs []config.ComponentID) consumer.Traces {
+func (pb *pipelinesBuilder) buildFanoutExportersTracesConsumer(exporterIDs []config.ComponentID, taps map[tap.Point]*tap.Tap) consumer.Traces {
 	builtExporters := pb.getBuiltExportersByIDs(exporterIDs)
 
 	var exporters []consumer.Traces
-	for _, builtExp := range builtExporters {
-		exporters = append(exporters, builtExp.getTracesExporter())
+	for i, builtExp := range builtExporters {
+		exporters = append(exporters, newTap(taps, component.KindExporter, exporterIDs[i]).Traces(builtExp.getTracesExporter()))
 	}
 
 	// Create a junction point that fans out to all exporters.
 	return fanoutconsumer.NewTraces(exporters)
 }
 
-func (pb *pipelinesBuilder) buildFanoutExportersMetricsConsumer(exporterIDs []config.ComponentID) consumer.Metrics {
+func (pb *pipelinesBuilder) buildFanoutExportersMetricsConsumer(exporterIDs []config.ComponentID, taps map[tap.Point]*tap.Tap) consumer.Metrics {
 	builtExporters := pb.getBuiltExportersByIDs(exporterIDs)
 
 	var exporters []consumer.Metrics
-	for _, builtExp := range builtExporters {
-		exporters = append(exporters, builtExp.getMetricsExporter())
+	for i, builtExp := range builtExporters {
+		exporters = append(exporters, newTap(taps, component.KindExporter, exporterIDs[i]).Metrics(builtExp.getMetricsExporter()))
 	}
 
 	// Create a junction point that fans out to all exporters.
 	return fanoutconsumer.NewMetrics(exporters)
 }
 
-func (pb *pipelinesBuilder) buildFanoutExportersLogsConsumer(exporterIDs []config.ComponentID) consumer.Logs {
+func (pb *pipelinesBuilder) buildFanoutExportersLogsConsumer(exporterIDs []config.ComponentID, taps map[tap.Point]*tap.Tap) consumer.Logs {
 	builtExporters := pb.getBuiltExportersByIDs(exporterIDs)
 
 	exporters := make([]consumer.Logs, len(builtExporters))
 	for i, builtExp := range builtExporters {
-		exporters[i] = builtExp.getLogsExporter()
+		exporters[i] = newTap(taps, component.KindExporter, exporterIDs[i]).Logs(builtExp.getLogsExporter())
 	}
 
 	// Create a junction point that fans out to all exporters.
@@ -28,6 +28,7 @@ import (
 	"go.opentelemetry.io/collector/internal/testcomponents"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/service/internal/tap"
 	"go.opentelemetry.io/collector/service/servicetest"
 )
 
@@ -231,6 +232,41 @@ func testPipeline(t *testing.T, pipelineID config.ComponentID, exporterIDs []con
 	assert.NoError(t, err)
 }
 
+func TestBuildPipelines_Taps(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "pipelines_builder.yaml"), factories)
+	require.NoError(t, err)
+
+	exporters, err := BuildExporters(componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo(), cfg, factories.Exporters)
+	require.NoError(t, err)
+	pipelines, err := BuildPipelines(componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo(), cfg, exporters, factories.Processors)
+	require.NoError(t, err)
+	receivers, err := BuildReceivers(componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo(), cfg, pipelines, factories.Receivers)
+	require.NoError(t, err)
+
+	pipelineID := config.NewComponentID("traces")
+	points := []tap.Point{
+		{Kind: component.KindReceiver, ID: config.NewComponentIDWithName("examplereceiver", "multi")},
+		{Kind: component.KindProcessor, ID: config.NewComponentID("exampleprocessor")},
+		{Kind: component.KindExporter, ID: config.NewComponentID("exampleexporter")},
+	}
+	var subs []*tap.Subscription
+	for _, point := range points {
+		tp := pipelines.GetTap(pipelineID, point)
+		require.NotNil(t, tp, point)
+		subs = append(subs, tp.Subscribe(tap.Limits{}))
+	}
+	assert.Nil(t, pipelines.GetTap(pipelineID, tap.Point{Kind: component.KindReceiver, ID: config.NewComponentID("unknown")}))
+	assert.Nil(t, pipelines.GetTap(config.NewComponentID("unknown"), points[0]))
+
+	producer := receivers[config.NewComponentIDWithName("examplereceiver", "multi")].receiver.(*testcomponents.ExampleReceiverProducer)
+	require.NoError(t, producer.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	for _, sub := range subs {
+		assert.Len(t, sub.Data(), 1)
+	}
+}
+
 func TestBuildPipelines_NotSupportedDataType(t *testing.T) {
 	factories := createTestFactories()
 
@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/service/internal/components"
 	"go.opentelemetry.io/collector/service/internal/fanoutconsumer"
 )
@@ -35,18 +36,22 @@ var errUnusedReceiver = errors.New("receiver defined but not used by any pipelin
 // builtReceiver is a receiver that is built based on a config. It can have
 // a trace and/or a metrics component.
 type builtReceiver struct {
+	id       config.ComponentID
 	logger   *zap.Logger
 	receiver component.Receiver
+	// host is the host the receiver was started with, nil if not started.
+	host component.Host
 }
 
 // Start starts the receiver.
 func (rcv *builtReceiver) Start(ctx context.Context, host component.Host) error {
-	return rcv.receiver.Start(ctx, components.NewHostWrapper(host, rcv.logger))
+	rcv.host = components.NewHostWrapper(host, &component.InstanceID{ID: rcv.id, Kind: component.KindReceiver}, rcv.logger)
+	return components.StartWithStatus(ctx, rcv.receiver, rcv.host)
 }
 
 // Shutdown stops the receiver.
 func (rcv *builtReceiver) Shutdown(ctx context.Context) error {
-	return rcv.receiver.Shutdown(ctx)
+	return components.ShutdownWithStatus(ctx, rcv.receiver, rcv.host)
 }
 
 // Receivers is a map of receivers created from receiver configs.
@@ -179,15 +184,15 @@ func attachReceiverToPipelines(
 
 	switch dataType {
 	case config.TracesDataType:
-		junction := buildFanoutTraceConsumer(builtPipelines)
+		junction := buildFanoutTraceConsumer(set, id, builtPipelines)
 		createdReceiver, err = factory.CreateTracesReceiver(ctx, set, cfg, junction)
 
 	case config.MetricsDataType:
-		junction := buildFanoutMetricConsumer(builtPipelines)
+		junction := buildFanoutMetricConsumer(set, id, builtPipelines)
 		createdReceiver, err = factory.CreateMetricsReceiver(ctx, set, cfg, junction)
 
 	case config.LogsDataType:
-		junction := buildFanoutLogConsumer(builtPipelines)
+		junction := buildFanoutLogConsumer(set, id, builtPipelines)
 		createdReceiver, err = factory.CreateLogsReceiver(ctx, set, cfg, junction)
 
 	default:
@@ -242,6 +247,7 @@ func (rb *receiversBuilder) buildReceiver(ctx context.Context, set component.Rec
 		return nil, fmt.Errorf("receiver factory not found for: %v", cfg.ID())
 	}
 	rcv := &builtReceiver{
+		id:     id,
 		logger: set.Logger,
 	}
 
@@ -266,28 +272,34 @@ func (rb *receiversBuilder) buildReceiver(ctx context.Context, set component.Rec
 	return rcv, nil
 }
 
-func buildFanoutTraceConsumer(pipelines []*builtPipeline) consumer.Traces {
+// newReceiverLatencyRecorder returns the latencyRecorder of the receiver attached to the pipeline, nil if the
+// latencies are not recorded.
+func newReceiverLatencyRecorder(set component.ReceiverCreateSettings, id, pipelineID config.ComponentID) *latencyRecorder {
+	return newLatencyRecorder(set.MetricsLevel, set.MeterProvider, obsmetrics.ReceiverLatency, obsmetrics.TagKeyReceiver, id, pipelineID)
+}
+
+func buildFanoutTraceConsumer(set component.ReceiverCreateSettings, id config.ComponentID, pipelines []*builtPipeline) consumer.Traces {
 	var pipelineConsumers []consumer.Traces
 	for _, pipeline := range pipelines {
-		pipelineConsumers = append(pipelineConsumers, pipeline.firstTC)
+		pipelineConsumers = append(pipelineConsumers, wrapTracesLatency(newTap(pipeline.taps, component.KindReceiver, id).Traces(pipeline.firstTC), newReceiverLatencyRecorder(set, id, pipeline.id)))
 	}
 	// Create a junction point that fans out to all pipelines.
 	return fanoutconsumer.NewTraces(pipelineConsumers)
 }
 
-func buildFanoutMetricConsumer(pipelines []*builtPipeline) consumer.Metrics {
+func buildFanoutMetricConsumer(set component.ReceiverCreateSettings, id config.ComponentID, pipelines []*builtPipeline) consumer.Metrics {
 	var pipelineConsumers []consumer.Metrics
 	for _, pipeline := range pipelines {
-		pipelineConsumers = append(pipelineConsumers, pipeline.firstMC)
+		pipelineConsumers = append(pipelineConsumers, wrapMetricsLatency(newTap(pipeline.taps, component.KindReceiver, id).Metrics(pipeline.firstMC), newReceiverLatencyRecorder(set, id, pipeline.id)))
 	}
 	// Create a junction point that fans out to all pipelines.
 	return fanoutconsumer.NewMetrics(pipelineConsumers)
 }
 
-func buildFanoutLogConsumer(pipelines []*builtPipeline) consumer.Logs {
+func buildFanoutLogConsumer(set component.ReceiverCreateSettings, id config.ComponentID, pipelines []*builtPipeline) consumer.Logs {
 	var pipelineConsumers []consumer.Logs
 	for _, pipeline := range pipelines {
-		pipelineConsumers = append(pipelineConsumers, pipeline.firstLC)
+		pipelineConsumers = append(pipelineConsumers, wrapLogsLatency(newTap(pipeline.taps, component.KindReceiver, id).Logs(pipeline.firstLC), newReceiverLatencyRecorder(set, id, pipeline.id)))
 	}
 	// Create a junction point that fans out to all pipelines.
 	return fanoutconsumer.NewLogs(pipelineConsumers)
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder // import "go.opentelemetry.io/collector/service/internal/builder"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/metric/nonrecording"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/service/internal/fanoutconsumer"
+)
+
+// TelemetryExporters are the exporters used to push the collector's own telemetry, as configured
+// in the service::telemetry section.
+type TelemetryExporters struct {
+	// Exporters contains all the built exporters, they need to be started and shut down by the caller.
+	Exporters Exporters
+
+	// Traces, Metrics and Logs fan out the telemetry to the configured exporters, and are nil if
+	// no exporter is configured for the respective signal.
+	Traces  consumer.Traces
+	Metrics consumer.Metrics
+	Logs    consumer.Logs
+}
+
+// BuildTelemetryExporters builds the exporters referenced in the service telemetry configuration.
+//
+// These are separate instances from the exporters used in the pipelines, and they do not produce
+// telemetry themselves, otherwise exporting the collector's own telemetry would create more telemetry.
+func BuildTelemetryExporters(
+	buildInfo component.BuildInfo,
+	cfg *config.Config,
+	factories map[config.Type]component.ExporterFactory,
+) (*TelemetryExporters, error) {
+	required := make(exportersRequiredDataTypes)
+	addRequired := func(dataType config.DataType, ids []config.ComponentID) {
+		for _, expID := range ids {
+			if _, ok := required[expID]; !ok {
+				required[expID] = make(dataTypeRequirements)
+			}
+			required[expID][dataType] = config.NewComponentIDWithName(dataType, "telemetry")
+		}
+	}
+	addRequired(config.TracesDataType, cfg.Service.Telemetry.Traces.Exporters)
+	addRequired(config.MetricsDataType, cfg.Service.Telemetry.Metrics.Exporters)
+	addRequired(config.LogsDataType, cfg.Service.Telemetry.Logs.Exporters)
+
+	exporters := make(Exporters, len(required))
+	for expID, dataTypes := range required {
+		expCfg, ok := cfg.Exporters[expID]
+		if !ok {
+			return nil, fmt.Errorf("service telemetry references exporter %q which does not exist", expID)
+		}
+
+		factory, exists := factories[expID.Type()]
+		if !exists || factory == nil {
+			return nil, fmt.Errorf("exporter factory not found for type: %s", expID.Type())
+		}
+
+		set := component.ExporterCreateSettings{
+			TelemetrySettings: component.TelemetrySettings{
+				Logger:         zap.NewNop(),
+				TracerProvider: trace.NewNoopTracerProvider(),
+				MeterProvider:  nonrecording.NewNoopMeterProvider(),
+				MetricsLevel:   configtelemetry.LevelNone,
+			},
+			BuildInfo: buildInfo,
+		}
+
+		exp, err := buildExporter(context.Background(), factory, set, expCfg, dataTypes)
+		if err != nil {
+			return nil, err
+		}
+		exporters[expID] = exp
+	}
+
+	te := &TelemetryExporters{Exporters: exporters}
+	if ids := cfg.Service.Telemetry.Traces.Exporters; len(ids) > 0 {
+		tcs := make([]consumer.Traces, 0, len(ids))
+		for _, expID := range ids {
+			tcs = append(tcs, exporters[expID].getTracesExporter())
+		}
+		te.Traces = fanoutconsumer.NewTraces(tcs)
+	}
+	if ids := cfg.Service.Telemetry.Metrics.Exporters; len(ids) > 0 {
+		mcs := make([]consumer.Metrics, 0, len(ids))
+		for _, expID := range ids {
+			mcs = append(mcs, exporters[expID].getMetricsExporter())
+		}
+		te.Metrics = fanoutconsumer.NewMetrics(mcs)
+	}
+	if ids := cfg.Service.Telemetry.Logs.Exporters; len(ids) > 0 {
+		lcs := make([]consumer.Logs, 0, len(ids))
+		for _, expID := range ids {
+			lcs = append(lcs, exporters[expID].getLogsExporter())
+		}
+		te.Logs = fanoutconsumer.NewLogs(lcs)
+	}
+	return te, nil
+}
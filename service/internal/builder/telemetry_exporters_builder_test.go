@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/internal/testcomponents"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestBuildTelemetryExporters(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+
+	expID := config.NewComponentID("exampleexporter")
+	cfg := &config.Config{
+		Exporters: map[config.ComponentID]config.Exporter{
+			expID: &testcomponents.ExampleExporter{ExporterSettings: config.NewExporterSettings(expID)},
+		},
+		Service: config.Service{
+			Telemetry: config.ServiceTelemetry{
+				Logs:   config.ServiceTelemetryLogs{Exporters: []config.ComponentID{expID}},
+				Traces: config.ServiceTelemetryTraces{Exporters: []config.ComponentID{expID}},
+			},
+		},
+	}
+
+	te, err := BuildTelemetryExporters(component.NewDefaultBuildInfo(), cfg, factories.Exporters)
+	require.NoError(t, err)
+	require.Len(t, te.Exporters, 1)
+	assert.NotNil(t, te.Traces)
+	assert.Nil(t, te.Metrics)
+	assert.NotNil(t, te.Logs)
+
+	require.NoError(t, te.Exporters.StartAll(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, te.Traces.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	require.NoError(t, te.Logs.ConsumeLogs(context.Background(), testdata.GenerateLogsOneLogRecord()))
+
+	bexp := te.Exporters[expID]
+	assert.Len(t, bexp.getTracesExporter().(*testcomponents.ExampleExporterConsumer).Traces, 1)
+	assert.Len(t, bexp.getLogsExporter().(*testcomponents.ExampleExporterConsumer).Logs, 1)
+	assert.Nil(t, bexp.getMetricsExporter())
+	assert.NoError(t, te.Exporters.ShutdownAll(context.Background()))
+}
+
+func TestBuildTelemetryExportersErrors(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+
+	cfg := &config.Config{
+		Exporters: map[config.ComponentID]config.Exporter{
+			config.NewComponentID("unknown"): &testcomponents.ExampleExporter{
+				ExporterSettings: config.NewExporterSettings(config.NewComponentID("unknown")),
+			},
+		},
+	}
+
+	cfg.Service.Telemetry.Metrics.Exporters = []config.ComponentID{config.NewComponentID("missing")}
+	_, err = BuildTelemetryExporters(component.NewDefaultBuildInfo(), cfg, factories.Exporters)
+	assert.EqualError(t, err, `service telemetry references exporter "missing" which does not exist`)
+
+	cfg.Service.Telemetry.Metrics.Exporters = []config.ComponentID{config.NewComponentID("unknown")}
+	_, err = BuildTelemetryExporters(component.NewDefaultBuildInfo(), cfg, factories.Exporters)
+	assert.EqualError(t, err, "exporter factory not found for type: unknown")
+}
+
+func TestBuildTelemetryExportersNone(t *testing.T) {
+	te, err := BuildTelemetryExporters(component.NewDefaultBuildInfo(), &config.Config{}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, te.Exporters)
+	assert.Nil(t, te.Traces)
+	assert.Nil(t, te.Metrics)
+	assert.Nil(t, te.Logs)
+}
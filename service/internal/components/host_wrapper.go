@@ -25,12 +25,14 @@ import (
 // hostWrapper adds behavior on top of the component.Host being passed when starting the built components.
 type hostWrapper struct {
 	component.Host
+	id *component.InstanceID
 	*zap.Logger
 }
 
-func NewHostWrapper(host component.Host, logger *zap.Logger) component.Host {
+func NewHostWrapper(host component.Host, id *component.InstanceID, logger *zap.Logger) component.Host {
 	return &hostWrapper{
 		host,
+		id,
 		logger,
 	}
 }
@@ -38,9 +40,20 @@ func NewHostWrapper(host component.Host, logger *zap.Logger) component.Host {
 func (hw *hostWrapper) ReportFatalError(err error) {
 	// The logger from the built component already identifies the component.
 	hw.Logger.Error("Component fatal error", zap.Error(err))
+	hw.ReportComponentStatus(component.NewPermanentErrorEvent(err))
 	hw.Host.ReportFatalError(err)
 }
 
+// ReportComponentStatus reports the status of the wrapped component, identified by the wrapper,
+// to the host if it is the service host.
+func (hw *hostWrapper) ReportComponentStatus(event *component.StatusEvent) {
+	if statusHost, ok := hw.Host.(interface {
+		ReportComponentStatusOf(source *component.InstanceID, event *component.StatusEvent)
+	}); ok {
+		statusHost.ReportComponentStatusOf(hw.id, event)
+	}
+}
+
 // RegisterZPages is used by zpages extension to register handles from service.
 // When the wrapper is passed to the extension it won't be successful when casting
 // the interface, for the time being expose the interface here.
@@ -18,12 +18,42 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 )
 
 func Test_newHostWrapper(t *testing.T) {
-	hw := NewHostWrapper(componenttest.NewNopHost(), zap.NewNop())
+	hw := NewHostWrapper(componenttest.NewNopHost(), &component.InstanceID{ID: config.NewComponentID("test"), Kind: component.KindReceiver}, zap.NewNop())
 	hw.ReportFatalError(errors.New("test error"))
 }
+
+type statusHost struct {
+	component.Host
+	sources []*component.InstanceID
+	events  []*component.StatusEvent
+}
+
+func (sh *statusHost) ReportComponentStatusOf(source *component.InstanceID, event *component.StatusEvent) {
+	sh.sources = append(sh.sources, source)
+	sh.events = append(sh.events, event)
+}
+
+func TestHostWrapperReportComponentStatus(t *testing.T) {
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	id := &component.InstanceID{ID: config.NewComponentID("test"), Kind: component.KindExporter}
+	hw := NewHostWrapper(host, id, zap.NewNop())
+
+	hw.ReportComponentStatus(component.NewRecoverableErrorEvent(errors.New("recoverable error")))
+	hw.ReportFatalError(errors.New("fatal error"))
+
+	require.Len(t, host.events, 2)
+	assert.Equal(t, []*component.InstanceID{id, id}, host.sources)
+	assert.Equal(t, component.StatusRecoverableError, host.events[0].Status())
+	assert.Equal(t, component.StatusPermanentError, host.events[1].Status())
+	assert.EqualError(t, host.events[1].Err(), "fatal error")
+}
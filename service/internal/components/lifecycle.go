@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package components // import "go.opentelemetry.io/collector/service/internal/components"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// StartWithStatus starts the component with the host, reporting StatusStarting before starting it, then
+// StatusOK, or StatusPermanentError if it fails to start.
+func StartWithStatus(ctx context.Context, comp component.Component, host component.Host) error {
+	host.ReportComponentStatus(component.NewStatusEvent(component.StatusStarting))
+	if err := comp.Start(ctx, host); err != nil {
+		host.ReportComponentStatus(component.NewPermanentErrorEvent(err))
+		return err
+	}
+	host.ReportComponentStatus(component.NewStatusEvent(component.StatusOK))
+	return nil
+}
+
+// ShutdownWithStatus shuts down the component, reporting StatusStopping before if it was started with the
+// host, nil otherwise.
+func ShutdownWithStatus(ctx context.Context, comp component.Component, host component.Host) error {
+	if host != nil {
+		host.ReportComponentStatus(component.NewStatusEvent(component.StatusStopping))
+	}
+	return comp.Shutdown(ctx)
+}
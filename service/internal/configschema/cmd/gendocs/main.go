@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Program gendocs generates the table of the doc comments of the configuration structs of this module,
+// used as the descriptions of the configuration JSON Schema. It is run by go generate from the
+// configschema package directory.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	modulePath = "go.opentelemetry.io/collector"
+	outputFile = "generated_docs.go"
+)
+
+// packageDocs contains the doc comments of the configuration structs of a package, and of their fields.
+type packageDocs struct {
+	types  map[string]string
+	fields map[string]map[string]string
+}
+
+func check(e error) {
+	if e != nil {
+		panic(e)
+	}
+}
+
+func main() {
+	root, err := moduleRoot()
+	check(err)
+	docs := map[string]*packageDocs{}
+	check(filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return err
+		}
+		if path != root && (d.Name() == "testdata" || strings.HasPrefix(d.Name(), ".") || isFile(filepath.Join(path, "go.mod"))) {
+			// Nested modules are not part of this module.
+			return filepath.SkipDir
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		pkgPath := modulePath
+		if rel != "." {
+			pkgPath += "/" + filepath.ToSlash(rel)
+		}
+		pkg, err := parsePackageDocs(path)
+		if err != nil {
+			return err
+		}
+		if len(pkg.types) != 0 || len(pkg.fields) != 0 {
+			docs[pkgPath] = pkg
+		}
+		return nil
+	}))
+	check(ioutil.WriteFile(outputFile, generate(docs), 0600))
+}
+
+// moduleRoot returns the directory of this module, the first parent directory with a go.mod.
+func moduleRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for !isFile(filepath.Join(dir, "go.mod")) {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("go.mod not found")
+		}
+		dir = parent
+	}
+	return dir, nil
+}
+
+func isFile(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && !fi.IsDir()
+}
+
+// parsePackageDocs returns the doc comments of the structs of the package in dir having at least one field
+// with a mapstructure tag, the configuration structs.
+func parsePackageDocs(dir string) (*packageDocs, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &packageDocs{types: map[string]string{}, fields: map[string]map[string]string{}}
+	for _, p := range pkgs {
+		if p.Name == "main" {
+			continue
+		}
+		for _, f := range p.Files {
+			ast.Inspect(f, func(n ast.Node) bool {
+				gd, ok := n.(*ast.GenDecl)
+				if !ok || gd.Tok != token.TYPE {
+					return true
+				}
+				for _, spec := range gd.Specs {
+					ts := spec.(*ast.TypeSpec)
+					st, ok := ts.Type.(*ast.StructType)
+					if !ok || !isConfigStruct(st) {
+						continue
+					}
+					typeComment := ts.Doc
+					if typeComment == nil && len(gd.Specs) == 1 {
+						typeComment = gd.Doc
+					}
+					if doc := cleanDoc(typeComment); doc != "" {
+						ret.types[ts.Name.Name] = doc
+					}
+					fields := map[string]string{}
+					for _, field := range st.Fields.List {
+						comment := field.Doc
+						if comment == nil {
+							comment = field.Comment
+						}
+						doc := cleanDoc(comment)
+						if doc == "" {
+							continue
+						}
+						for _, name := range field.Names {
+							if name.IsExported() {
+								fields[name.Name] = doc
+							}
+						}
+					}
+					if len(fields) != 0 {
+						ret.fields[ts.Name.Name] = fields
+					}
+				}
+				return false
+			})
+		}
+	}
+	return ret, nil
+}
+
+func isConfigStruct(st *ast.StructType) bool {
+	for _, field := range st.Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+		if _, ok := reflect.StructTag(tag).Lookup("mapstructure"); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanDoc returns the comment as a single paragraph of text.
+func cleanDoc(cg *ast.CommentGroup) string {
+	if cg == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(cg.Text()), " ")
+}
+
+func generate(docs map[string]*packageDocs) []byte {
+	var b bytes.Buffer
+	header, err := ioutil.ReadFile(filepath.Join("cmd", "gendocs", "main.go"))
+	check(err)
+	// Same license header as this file.
+	b.Write(header[:bytes.Index(header, []byte("\n\n"))+2])
+	b.WriteString("// Code generated by \"cmd/gendocs\" from the doc comments of the configuration structs. DO NOT EDIT.\n")
+	b.WriteString("// To regenerate run \"go generate\" in the configschema package directory.\n\n")
+	b.WriteString("package configschema // import \"go.opentelemetry.io/collector/service/internal/configschema\"\n\n")
+	b.WriteString("var generatedDocs = map[string]*packageDocs{\n")
+	for _, pkgPath := range sortedKeys(docs) {
+		pkg := docs[pkgPath]
+		fmt.Fprintf(&b, "%q: {\n", pkgPath)
+		b.WriteString("types: map[string]string{\n")
+		for _, name := range sortedKeys(pkg.types) {
+			fmt.Fprintf(&b, "%q: %q,\n", name, pkg.types[name])
+		}
+		b.WriteString("},\n")
+		b.WriteString("fields: map[string]map[string]string{\n")
+		for _, name := range sortedKeys(pkg.fields) {
+			fmt.Fprintf(&b, "%q: {\n", name)
+			for _, field := range sortedKeys(pkg.fields[name]) {
+				fmt.Fprintf(&b, "%q: %q,\n", field, pkg.fields[name][field])
+			}
+			b.WriteString("},\n")
+		}
+		b.WriteString("},\n")
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n")
+	formatted, err := format.Source(b.Bytes())
+	check(err)
+	return formatted
+}
+
+func sortedKeys(m interface{}) []string {
+	keys := reflect.ValueOf(m).MapKeys()
+	ret := make([]string, 0, len(keys))
+	for _, k := range keys {
+		ret = append(ret, k.String())
+	}
+	sort.Strings(ret)
+	return ret
+}
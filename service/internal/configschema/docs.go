@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configschema // import "go.opentelemetry.io/collector/service/internal/configschema"
+
+import (
+	"reflect"
+)
+
+//go:generate go run ./cmd/gendocs
+
+// docs contains the doc comments of the configuration structs, generated from the Go source code of this module.
+// The descriptions of the configuration structs of other modules are empty.
+type docs struct {
+	// packages contains the doc comments by package path.
+	packages map[string]*packageDocs
+}
+
+// packageDocs contains the doc comments of the configuration structs of a package, and of their fields.
+type packageDocs struct {
+	types  map[string]string
+	fields map[string]map[string]string
+}
+
+func newDocs() *docs {
+	return &docs{packages: generatedDocs}
+}
+
+// typeDoc returns the doc comment of the given named type.
+func (d *docs) typeDoc(t reflect.Type) string {
+	if pkg := d.packages[t.PkgPath()]; pkg != nil {
+		return pkg.types[t.Name()]
+	}
+	return ""
+}
+
+// fieldDoc returns the doc comment of the given field of the named struct type.
+func (d *docs) fieldDoc(t reflect.Type, field string) string {
+	if pkg := d.packages[t.PkgPath()]; pkg != nil {
+		return pkg.fields[t.Name()][field]
+	}
+	return ""
+}
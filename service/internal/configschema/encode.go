@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configschema // import "go.opentelemetry.io/collector/service/internal/configschema"
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// Encode returns the given configuration as the map it would be unmarshalled from, including the
+// default values of the components configurations, so it can be printed as the effective configuration.
+func Encode(cfg *config.Config) map[string]interface{} {
+	ret := map[string]interface{}{
+		"receivers":  encodeValue(reflect.ValueOf(cfg.Receivers)),
+		"processors": encodeValue(reflect.ValueOf(cfg.Processors)),
+		"exporters":  encodeValue(reflect.ValueOf(cfg.Exporters)),
+		"extensions": encodeValue(reflect.ValueOf(cfg.Extensions)),
+		"service":    encodeValue(reflect.ValueOf(cfg.Service)),
+	}
+	for k, v := range ret {
+		if m, ok := v.(map[string]interface{}); !ok || len(m) == 0 {
+			// The components sections not configured.
+			delete(ret, k)
+		}
+	}
+	return ret
+}
+
+// encodeValue returns the value unmarshalled into v, using the keys of the mapstructure tags.
+func encodeValue(v reflect.Value) interface{} {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+
+	t := v.Type()
+	if t == durationType {
+		return v.Interface().(time.Duration).String()
+	}
+	if t.Implements(textMarshalerType) {
+		if text, err := v.Interface().(encoding.TextMarshaler).MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok && reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		// Unmarshalled from its string representation, like the component IDs.
+		return s.String()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		ret := map[string]interface{}{}
+		encodeStruct(v, ret)
+		return ret
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		ret := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			ret[fmt.Sprint(iter.Key().Interface())] = encodeValue(iter.Value())
+		}
+		return ret
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		if t.Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes())
+		}
+		ret := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			ret = append(ret, encodeValue(v.Index(i)))
+		}
+		return ret
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return nil
+	}
+	return v.Interface()
+}
+
+// encodeStruct adds all the fields of the struct value to ret, the fields of the squashed embedded structs
+// are added as fields of the parent struct.
+func encodeStruct(v reflect.Value, ret map[string]interface{}) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, squash, skip := fieldName(f)
+		if skip || f.Type.Kind() == reflect.Func || f.Type.Kind() == reflect.Chan {
+			// Functions and channels can only be set programmatically.
+			continue
+		}
+		fv := v.Field(i)
+		if squash {
+			for (fv.Kind() == reflect.Ptr || fv.Kind() == reflect.Interface) && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				encodeStruct(fv, ret)
+			}
+			continue
+		}
+		ret[name] = encodeValue(fv)
+	}
+}
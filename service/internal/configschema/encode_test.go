@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/processor/batchprocessor"
+)
+
+func TestEncode(t *testing.T) {
+	batchID := config.NewComponentIDWithName("batch", "1")
+	batchCfg := batchprocessor.NewFactory().CreateDefaultConfig().(*batchprocessor.Config)
+	batchCfg.SetIDName("1")
+	batchCfg.SendBatchSize = 10
+	cfg := &config.Config{
+		Processors: map[config.ComponentID]config.Processor{batchID: batchCfg},
+		Service: config.Service{
+			Pipelines: map[config.ComponentID]*config.Pipeline{
+				config.NewComponentID("traces"): {Processors: []config.ComponentID{batchID}},
+			},
+		},
+	}
+
+	encoded := Encode(cfg)
+	assert.NotContains(t, encoded, "receivers")
+	assert.Equal(t, map[string]interface{}{
+		"batch/1": map[string]interface{}{
+			"timeout":             "200ms",
+			"send_batch_size":     uint32(10),
+			"send_batch_max_size": uint32(0),
+		},
+	}, encoded["processors"])
+	pipelines := encoded["service"].(map[string]interface{})["pipelines"]
+	assert.Equal(t, map[string]interface{}{
+		"traces": map[string]interface{}{
+			"receivers":  nil,
+			"processors": []interface{}{"batch/1"},
+			"exporters":  nil,
+		},
+	}, pipelines)
+}
@@ -0,0 +1,370 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by "cmd/gendocs" from the doc comments of the configuration structs. DO NOT EDIT.
+// To regenerate run "go generate" in the configschema package directory.
+
+package configschema // import "go.opentelemetry.io/collector/service/internal/configschema"
+
+var generatedDocs = map[string]*packageDocs{
+	"go.opentelemetry.io/collector/config": {
+		types: map[string]string{
+			"ComponentID":                    "ComponentID represents the identity for a component. It combines two values: * type - the Type of the component. * name - the name of that component. The component ComponentID (combination type + name) is unique for a given component.Kind.",
+			"ExporterSettings":               "ExporterSettings defines common settings for a component.Exporter configuration. Specific exporters can embed this struct and extend it with more fields if needed. It is highly recommended to \"override\" the Validate() function. When embedded in the exporter config, it must be with `mapstructure:\",squash\"` tag.",
+			"ExtensionSettings":              "ExtensionSettings defines common settings for a component.Extension configuration. Specific processors can embed this struct and extend it with more fields if needed. It is highly recommended to \"override\" the Validate() function. When embedded in the extension config, it must be with `mapstructure:\",squash\"` tag.",
+			"Pipeline":                       "Pipeline defines a single pipeline.",
+			"ProcessorSettings":              "ProcessorSettings defines common settings for a component.Processor configuration. Specific processors can embed this struct and extend it with more fields if needed. It is highly recommended to \"override\" the Validate() function. When embedded in the processor config it must be with `mapstructure:\",squash\"` tag.",
+			"ReceiverSettings":               "ReceiverSettings defines common settings for a component.Receiver configuration. Specific receivers can embed this struct and extend it with more fields if needed. It is highly recommended to \"override\" the Validate() function. When embedded in the receiver config it must be with `mapstructure:\",squash\"` tag.",
+			"Service":                        "Service defines the configurable components of the service.",
+			"ServiceTelemetry":               "ServiceTelemetry defines the configurable settings for service telemetry.",
+			"ServiceTelemetryLogs":           "ServiceTelemetryLogs defines the configurable settings for service telemetry logs. This MUST be compatible with zap.Config. Cannot use directly zap.Config because the collector uses mapstructure and not yaml tags.",
+			"ServiceTelemetryMetrics":        "ServiceTelemetryMetrics exposes the common Telemetry configuration for one component. Experimental: *NOTE* this structure is subject to change or removal in the future.",
+			"ServiceTelemetryTraces":         "ServiceTelemetryTraces defines the configurable settings for service telemetry traces. Experimental: *NOTE* this structure is subject to change or removal in the future.",
+			"ServiceTelemetryTracesSampling": "ServiceTelemetryTracesSampling defines the sampling of the collector's own traces. Experimental: *NOTE* this structure is subject to change or removal in the future.",
+		},
+		fields: map[string]map[string]string{
+			"Service": {
+				"Extensions": "Extensions are the ordered list of extensions configured for the service.",
+				"Pipelines":  "Pipelines are the set of data pipelines configured for the service.",
+				"Telemetry":  "Telemetry is the configuration for collector's own telemetry.",
+			},
+			"ServiceTelemetryLogs": {
+				"ComponentLevels":   "ComponentLevels overrides the Level for the logs of the components with the given IDs, e.g. \"debug\" for the \"otlp\" exporter to debug it without the logs of the other components. The levels can be changed without restarting the components, by reloading the config.",
+				"Development":       "Development puts the logger in development mode, which changes the behavior of DPanicLevel and takes stacktraces more liberally. (default = false)",
+				"DisableCaller":     "DisableCaller stops annotating logs with the calling function's file name and line number. By default, all logs are annotated. (default = false)",
+				"DisableStacktrace": "DisableStacktrace completely disables automatic stacktrace capturing. By default, stacktraces are captured for WarnLevel and above logs in development and ErrorLevel and above in production. (default = false)",
+				"Encoding":          "Encoding sets the logger's encoding. Example values are \"json\", \"console\".",
+				"ErrorOutputPaths":  "ErrorOutputPaths is a list of URLs or file paths to write zap internal logger errors to. The URLs could only be with \"file\" schema or without schema. The URLs with \"file\" schema must use absolute paths. The URLs without schema are treated as local file paths. \"stdout\" and \"stderr\" are interpreted as os.Stdout and os.Stderr. see details at Open in zap/writer.go. Note that this setting only affects the zap internal logger errors. (default = [\"stderr\"])",
+				"Exporters":         "Exporters is the list of exporters, defined in the exporters section, used to push the collector's own logs, e.g. an \"otlp\" exporter to send them to an OTLP endpoint. By default, logs are not pushed.",
+				"InitialFields":     "InitialFields is a collection of fields to add to the root logger. Example: initial_fields: foo: \"bar\" By default, there is no initial field.",
+				"Level":             "Level is the minimum enabled logging level. (default = \"INFO\")",
+				"OutputPaths":       "OutputPaths is a list of URLs or file paths to write logging output to. The URLs could only be with \"file\" schema or without schema. The URLs with \"file\" schema must be an absolute path. The URLs without schema are treated as local file paths. \"stdout\" and \"stderr\" are interpreted as os.Stdout and os.Stderr. see details at Open in zap/writer.go. (default = [\"stderr\"])",
+			},
+			"ServiceTelemetryMetrics": {
+				"Address":   "Address is the [address]:port that metrics exposition should be bound to.",
+				"Exporters": "Exporters is the list of exporters, defined in the exporters section, used to periodically push the collector's own metrics, e.g. an \"otlp\" exporter to send them to an OTLP endpoint. By default, metrics are only exposed for scraping on the Address.",
+				"Level":     "Level is the level of telemetry metrics, the possible values are: - \"none\" indicates that no telemetry data should be collected; - \"basic\" is the recommended and covers the basics of the service telemetry. - \"normal\" adds some other indicators on top of basic. - \"detailed\" adds dimensions and views to the previous levels.",
+			},
+			"ServiceTelemetryTraces": {
+				"Exporters":   "Exporters is the list of exporters, defined in the exporters section, used to push the collector's own spans, e.g. an \"otlp\" exporter to send them to an OTLP endpoint. By default, spans are only visible in the zpages extension.",
+				"Propagators": "Propagators is the list of context propagators, \"tracecontext\" (W3C trace context) or \"baggage\" (W3C baggage), set as the global propagator of the process, e.g. to trace the data through tiers of collectors from the incoming requests to the receivers' spans and from the exporters' spans to the outgoing requests. By default, the global propagator is not changed.",
+				"Sampling":    "Sampling configures which of the collector's own traces are sampled, and so pushed to the Exporters. Spans not sampled are still recorded, and visible in the zpages extension. No span is sampled without Exporters.",
+			},
+			"ServiceTelemetryTracesSampling": {
+				"ParentBased": "ParentBased, if true, makes the spans with a parent, e.g. propagated by the W3C trace context headers of an incoming OTLP request, follow the sampling decision of their parent. The Ratio then applies only to the root spans. Defaults to true.",
+				"Ratio":       "Ratio is the ratio, between 0 and 1, of the traces that are sampled. Defaults to 1, all traces are sampled.",
+			},
+		},
+	},
+	"go.opentelemetry.io/collector/config/configauth": {
+		types: map[string]string{
+			"Authentication": "Authentication defines the auth settings for the receiver.",
+		},
+		fields: map[string]map[string]string{
+			"Authentication": {
+				"AuthenticatorID": "AuthenticatorID specifies the name of the extension to use in order to authenticate the incoming data point.",
+			},
+		},
+	},
+	"go.opentelemetry.io/collector/config/configgrpc": {
+		types: map[string]string{
+			"GRPCClientSettings":         "GRPCClientSettings defines common settings for a gRPC client configuration.",
+			"GRPCServerSettings":         "GRPCServerSettings defines common settings for a gRPC server configuration.",
+			"KeepaliveClientConfig":      "KeepaliveClientConfig exposes the keepalive.ClientParameters to be used by the exporter. Refer to the original data-structure for the meaning of each parameter: https://godoc.org/google.golang.org/grpc/keepalive#ClientParameters",
+			"KeepaliveEnforcementPolicy": "KeepaliveEnforcementPolicy allow configuration of the keepalive.EnforcementPolicy. The same default values as keepalive.EnforcementPolicy are applicable and get applied by the server. See https://godoc.org/google.golang.org/grpc/keepalive#EnforcementPolicy for details.",
+			"KeepaliveServerConfig":      "KeepaliveServerConfig is the configuration for keepalive.",
+			"KeepaliveServerParameters":  "KeepaliveServerParameters allow configuration of the keepalive.ServerParameters. The same default values as keepalive.ServerParameters are applicable and get applied by the server. See https://godoc.org/google.golang.org/grpc/keepalive#ServerParameters for details.",
+		},
+		fields: map[string]map[string]string{
+			"GRPCClientSettings": {
+				"Auth":            "Auth configuration for outgoing RPCs.",
+				"BalancerName":    "Sets the balancer in grpclb_policy to discover the servers. Default is pick_first. https://github.com/grpc/grpc-go/blob/master/examples/features/load_balancing/README.md",
+				"Compression":     "The compression key for supported compression types within collector.",
+				"Endpoint":        "The target to which the exporter is going to send traces or metrics, using the gRPC protocol. The valid syntax is described at https://github.com/grpc/grpc/blob/master/doc/naming.md.",
+				"Headers":         "The headers associated with gRPC requests.",
+				"Keepalive":       "The keepalive parameters for gRPC client. See grpc.WithKeepaliveParams. (https://godoc.org/google.golang.org/grpc#WithKeepaliveParams).",
+				"ReadBufferSize":  "ReadBufferSize for gRPC client. See grpc.WithReadBufferSize. (https://godoc.org/google.golang.org/grpc#WithReadBufferSize).",
+				"TLSSetting":      "TLSSetting struct exposes TLS client configuration.",
+				"WaitForReady":    "WaitForReady parameter configures client to wait for ready state before sending data. (https://github.com/grpc/grpc/blob/master/doc/wait-for-ready.md)",
+				"WriteBufferSize": "WriteBufferSize for gRPC gRPC. See grpc.WithWriteBufferSize. (https://godoc.org/google.golang.org/grpc#WithWriteBufferSize).",
+			},
+			"GRPCServerSettings": {
+				"Auth":                 "Auth for this receiver",
+				"IncludeMetadata":      "Include propagates the incoming connection's metadata to downstream consumers. Experimental: *NOTE* this option is subject to change or removal in the future.",
+				"Keepalive":            "Keepalive anchor for all the settings related to keepalive.",
+				"MaxConcurrentStreams": "MaxConcurrentStreams sets the limit on the number of concurrent streams to each ServerTransport. It has effect only for streaming RPCs.",
+				"MaxRecvMsgSizeMiB":    "MaxRecvMsgSizeMiB sets the maximum size (in MiB) of messages accepted by the server.",
+				"NetAddr":              "Server net.Addr config. For transport only \"tcp\" and \"unix\" are valid options.",
+				"ReadBufferSize":       "ReadBufferSize for gRPC server. See grpc.ReadBufferSize. (https://godoc.org/google.golang.org/grpc#ReadBufferSize).",
+				"TLSSetting":           "Configures the protocol to use TLS. The default value is nil, which will cause the protocol to not use TLS.",
+				"WriteBufferSize":      "WriteBufferSize for gRPC server. See grpc.WriteBufferSize. (https://godoc.org/google.golang.org/grpc#WriteBufferSize).",
+			},
+		},
+	},
+	"go.opentelemetry.io/collector/config/confighttp": {
+		types: map[string]string{
+			"CORSSettings":       "CORSSettings configures a receiver for HTTP cross-origin resource sharing (CORS). See the underlying https://github.com/rs/cors package for details.",
+			"HTTPClientSettings": "HTTPClientSettings defines settings for creating an HTTP client.",
+			"HTTPServerSettings": "HTTPServerSettings defines settings for creating an HTTP server.",
+		},
+		fields: map[string]map[string]string{
+			"CORSSettings": {
+				"AllowedHeaders": "AllowedHeaders sets what headers will be allowed in CORS requests. The Accept, Accept-Language, Content-Type, and Content-Language headers are implicitly allowed. If no headers are listed, X-Requested-With will also be accepted by default. Include \"*\" to allow any request header.",
+				"AllowedOrigins": "AllowedOrigins sets the allowed values of the Origin header for HTTP/JSON requests to an OTLP receiver. An origin may contain a wildcard (*) to replace 0 or more characters (e.g., \"http://*.domain.com\", or \"*\" to allow any origin).",
+				"MaxAge":         "MaxAge sets the value of the Access-Control-Max-Age response header. Set it to the number of seconds that browsers should cache a CORS preflight response for.",
+			},
+			"HTTPClientSettings": {
+				"Auth":                "Auth configuration for outgoing HTTP calls.",
+				"Compression":         "The compression key for supported compression types within collector.",
+				"CustomRoundTripper":  "Custom Round Tripper to allow for individual components to intercept HTTP requests",
+				"Endpoint":            "The target URL to send data to (e.g.: http://some.url:9411/v1/traces).",
+				"Headers":             "Additional headers attached to each HTTP request sent by the client. Existing header values are overwritten if collision happens.",
+				"IdleConnTimeout":     "IdleConnTimeout is the maximum amount of time a connection will remain open before closing itself. There's an already set value, and we want to override it only if an explicit value provided",
+				"MaxConnsPerHost":     "MaxConnsPerHost limits the total number of connections per host, including connections in the dialing, active, and idle states. There's an already set value, and we want to override it only if an explicit value provided",
+				"MaxIdleConns":        "MaxIdleConns is used to set a limit to the maximum idle HTTP connections the client can keep open. There's an already set value, and we want to override it only if an explicit value provided",
+				"MaxIdleConnsPerHost": "MaxIdleConnsPerHost is used to set a limit to the maximum idle HTTP connections the host can keep open. There's an already set value, and we want to override it only if an explicit value provided",
+				"ReadBufferSize":      "ReadBufferSize for HTTP client. See http.Transport.ReadBufferSize.",
+				"TLSSetting":          "TLSSetting struct exposes TLS client configuration.",
+				"Timeout":             "Timeout parameter configures `http.Client.Timeout`.",
+				"WriteBufferSize":     "WriteBufferSize for HTTP client. See http.Transport.WriteBufferSize.",
+			},
+			"HTTPServerSettings": {
+				"Auth":               "Auth for this receiver",
+				"CORS":               "CORS configures the server for HTTP cross-origin resource sharing (CORS).",
+				"Endpoint":           "Endpoint configures the listening address for the server.",
+				"IncludeMetadata":    "IncludeMetadata propagates the client metadata from the incoming requests to the downstream consumers Experimental: *NOTE* this option is subject to change or removal in the future.",
+				"MaxRequestBodySize": "MaxRequestBodySize sets the maximum request body size in bytes",
+				"TLSSetting":         "TLSSetting struct exposes TLS client configuration.",
+			},
+		},
+	},
+	"go.opentelemetry.io/collector/config/confignet": {
+		types: map[string]string{
+			"NetAddr": "NetAddr represents a network endpoint address.",
+			"TCPAddr": "TCPAddr represents a TCP endpoint address.",
+		},
+		fields: map[string]map[string]string{
+			"NetAddr": {
+				"Endpoint":  "Endpoint configures the address for this network connection. For TCP and UDP networks, the address has the form \"host:port\". The host must be a literal IP address, or a host name that can be resolved to IP addresses. The port must be a literal port number or a service name. If the host is a literal IPv6 address it must be enclosed in square brackets, as in \"[2001:db8::1]:80\" or \"[fe80::1%zone]:80\". The zone specifies the scope of the literal IPv6 address as defined in RFC 4007.",
+				"Transport": "Transport to use. Known protocols are \"tcp\", \"tcp4\" (IPv4-only), \"tcp6\" (IPv6-only), \"udp\", \"udp4\" (IPv4-only), \"udp6\" (IPv6-only), \"ip\", \"ip4\" (IPv4-only), \"ip6\" (IPv6-only), \"unix\", \"unixgram\" and \"unixpacket\".",
+			},
+			"TCPAddr": {
+				"Endpoint": "Endpoint configures the address for this network connection. The address has the form \"host:port\". The host must be a literal IP address, or a host name that can be resolved to IP addresses. The port must be a literal port number or a service name. If the host is a literal IPv6 address it must be enclosed in square brackets, as in \"[2001:db8::1]:80\" or \"[fe80::1%zone]:80\". The zone specifies the scope of the literal IPv6 address as defined in RFC 4007.",
+			},
+		},
+	},
+	"go.opentelemetry.io/collector/config/configtls": {
+		types: map[string]string{
+			"TLSClientSetting": "TLSClientSetting contains TLS configurations that are specific to client connections in addition to the common configurations. This should be used by components configuring TLS client connections.",
+			"TLSServerSetting": "TLSServerSetting contains TLS configurations that are specific to server connections in addition to the common configurations. This should be used by components configuring TLS server connections.",
+			"TLSSetting":       "TLSSetting exposes the common client and server TLS configurations. Note: Since there isn't anything specific to a server connection. Components with server connections should use TLSSetting.",
+		},
+		fields: map[string]map[string]string{
+			"TLSClientSetting": {
+				"Insecure":           "In gRPC when set to true, this is used to disable the client transport security. See https://godoc.org/google.golang.org/grpc#WithInsecure. In HTTP, this disables verifying the server's certificate chain and host name (InsecureSkipVerify in the tls Config). Please refer to https://godoc.org/crypto/tls#Config for more information. (optional, default false)",
+				"InsecureSkipVerify": "InsecureSkipVerify will enable TLS but not verify the certificate.",
+				"ServerName":         "ServerName requested by client for virtual hosting. This sets the ServerName in the TLSConfig. Please refer to https://godoc.org/crypto/tls#Config for more information. (optional)",
+			},
+			"TLSServerSetting": {
+				"ClientCAFile": "Path to the TLS cert to use by the server to verify a client certificate. (optional) This sets the ClientCAs and ClientAuth to RequireAndVerifyClientCert in the TLSConfig. Please refer to https://godoc.org/crypto/tls#Config for more information. (optional)",
+			},
+			"TLSSetting": {
+				"CAFile":         "Path to the CA cert. For a client this verifies the server certificate. For a server this verifies client certificates. If empty uses system root CA. (optional)",
+				"CertFile":       "Path to the TLS cert to use for TLS required connections. (optional)",
+				"KeyFile":        "Path to the TLS key to use for TLS required connections. (optional)",
+				"MaxVersion":     "MaxVersion sets the maximum TLS version that is acceptable. If not set, TLS 1.3 is used. (optional)",
+				"MinVersion":     "MinVersion sets the minimum TLS version that is acceptable. If not set, TLS 1.0 is used. (optional)",
+				"ReloadInterval": "ReloadInterval specifies the duration after which the certificate will be reloaded If not set, it will never be reloaded (optional)",
+			},
+		},
+	},
+	"go.opentelemetry.io/collector/config/experimental/config": {
+		types: map[string]string{
+			"SourceSettings": "SourceSettings defines common settings of a Source configuration. Specific config sources can embed this struct and extend it with more fields if needed. When embedded it must be with `mapstructure:\",squash\"` tag.",
+		},
+		fields: map[string]map[string]string{},
+	},
+	"go.opentelemetry.io/collector/exporter/exporterhelper": {
+		types: map[string]string{
+			"QueueSettings":   "QueueSettings defines configuration for queueing batches before sending to the consumerSender.",
+			"RetrySettings":   "RetrySettings defines configuration for retrying batches in case of export failure. The current supported strategy is exponential backoff.",
+			"TimeoutSettings": "TimeoutSettings for timeout. The timeout applies to individual attempts to send data to the backend.",
+		},
+		fields: map[string]map[string]string{
+			"QueueSettings": {
+				"Enabled":      "Enabled indicates whether to not enqueue batches before sending to the consumerSender.",
+				"NumConsumers": "NumConsumers is the number of consumers from the queue.",
+				"QueueSize":    "QueueSize is the maximum number of batches allowed in queue at a given time.",
+			},
+			"RetrySettings": {
+				"Enabled":         "Enabled indicates whether to not retry sending batches in case of export failure.",
+				"InitialInterval": "InitialInterval the time to wait after the first failure before retrying.",
+				"MaxElapsedTime":  "MaxElapsedTime is the maximum amount of time (including retries) spent trying to send a request/batch. Once this value is reached, the data is discarded.",
+				"MaxInterval":     "MaxInterval is the upper bound on backoff interval. Once this value is reached the delay between consecutive retries will always be `MaxInterval`.",
+			},
+			"TimeoutSettings": {
+				"Timeout": "Timeout is the timeout for every attempt to send data to the backend.",
+			},
+		},
+	},
+	"go.opentelemetry.io/collector/exporter/loggingexporter": {
+		types: map[string]string{
+			"Config": "Config defines configuration for logging exporter.",
+		},
+		fields: map[string]map[string]string{
+			"Config": {
+				"ExcludeAttributes":  "ExcludeAttributes are attributes that are not printed.",
+				"Format":             "Format defines how the data is printed; options are text, json (OTLP JSON), and summary (one line per span, data point or log record).",
+				"IncludeAttributes":  "IncludeAttributes, if not empty, are the only attributes printed.",
+				"LogLevel":           "LogLevel defines log level of the logging exporter; options are debug, info, warn, error.",
+				"Output":             "Output defines where the data is printed; options are logger (the exporter logger, at debug level), stdout, stderr and file.",
+				"Path":               "Path is the file the data is appended to when Output is file.",
+				"SamplingInitial":    "SamplingInitial defines how many samples are initially logged during each second.",
+				"SamplingThereafter": "SamplingThereafter defines the sampling rate after the initial samples are logged.",
+			},
+		},
+	},
+	"go.opentelemetry.io/collector/exporter/otlpexporter": {
+		types: map[string]string{
+			"Config": "Config defines configuration for OpenCensus exporter.",
+		},
+		fields: map[string]map[string]string{},
+	},
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter": {
+		types: map[string]string{
+			"Config": "Config defines configuration for OTLP/HTTP exporter.",
+		},
+		fields: map[string]map[string]string{
+			"Config": {
+				"LogsEndpoint":    "The URL to send logs to. If omitted the Endpoint + \"/v1/logs\" will be used.",
+				"MetricsEndpoint": "The URL to send metrics to. If omitted the Endpoint + \"/v1/metrics\" will be used.",
+				"TracesEndpoint":  "The URL to send traces to. If omitted the Endpoint + \"/v1/traces\" will be used.",
+			},
+		},
+	},
+	"go.opentelemetry.io/collector/extension/ballastextension": {
+		types: map[string]string{
+			"Config": "Config has the configuration for the ballast extension.",
+		},
+		fields: map[string]map[string]string{
+			"Config": {
+				"SizeInPercentage": "SizeInPercentage is the maximum amount of memory ballast, in %, targeted to be allocated. The fixed memory settings SizeMiB has a higher precedence.",
+				"SizeMiB":          "SizeMiB is the size, in MiB, of the memory ballast to be created for this process.",
+			},
+		},
+	},
+	"go.opentelemetry.io/collector/extension/healthextension": {
+		types: map[string]string{
+			"Config": "Config has the configuration for the extension serving the health of the collector.",
+		},
+		fields: map[string]map[string]string{
+			"Config": {
+				"TCPAddr": "TCPAddr is the address and port in which the health will be served. Use localhost:<port> to make it available only locally, or \":<port>\" to make it available on all network interfaces.",
+			},
+		},
+	},
+	"go.opentelemetry.io/collector/extension/pprofextension": {
+		types: map[string]string{
+			"Config":       "Config has the configuration for the extension enabling the golang net/http/pprof (Performance Profiler) extension.",
+			"DumpSettings": "DumpSettings configures the periodic dump of the CPU and heap profiles to files.",
+		},
+		fields: map[string]map[string]string{
+			"Config": {
+				"BlockProfileFraction": "BlockProfileFraction is the fraction of blocking events that are profiled. A value <= 0 disables profiling. See https://golang.org/pkg/runtime/#SetBlockProfileRate for details.",
+				"Dump":                 "Dump configures the periodic dump of the CPU and heap profiles to files.",
+				"MutexProfileFraction": "MutexProfileFraction is the fraction of mutex contention events that are profiled. A value <= 0 disables profiling. See https://golang.org/pkg/runtime/#SetMutexProfileFraction for details.",
+				"TCPAddr":              "TCPAddr is the address and port in which the pprof will be listening to. Use localhost:<port> to make it available only locally, or \":<port>\" to make it available on all network interfaces.",
+			},
+			"DumpSettings": {
+				"CPUDuration": "CPUDuration is the duration of the CPU profiles, shorter than the interval.",
+				"Directory":   "Directory is the directory the profiles are written to. The profiles are not dumped if empty.",
+				"Interval":    "Interval is the time between two dumps.",
+				"MaxFiles":    "MaxFiles is the number of profiles of each kind kept in the directory, the oldest ones are removed. All the profiles are kept if 0.",
+			},
+		},
+	},
+	"go.opentelemetry.io/collector/extension/zpagesextension": {
+		types: map[string]string{
+			"Config": "Config has the configuration for the extension enabling the zPages extension.",
+		},
+		fields: map[string]map[string]string{
+			"Config": {
+				"TCPAddr": "TCPAddr is the address and port in which the zPages will be listening to. Use localhost:<port> to make it available only locally, or \":<port>\" to make it available on all network interfaces.",
+			},
+		},
+	},
+	"go.opentelemetry.io/collector/internal/testcomponents": {
+		types: map[string]string{
+			"ExampleExporter":     "ExampleExporter is for testing purposes. We are defining an example config and factory for \"exampleexporter\" exporter type.",
+			"ExampleExtensionCfg": "ExampleExtensionCfg is for testing purposes. We are defining an example config and factory for \"exampleextension\" extension type.",
+			"ExampleProcessorCfg": "ExampleProcessorCfg is for testing purposes. We are defining an example config and factory for \"exampleprocessor\" processor type.",
+			"ExampleReceiver":     "ExampleReceiver is for testing purposes. We are defining an example config and factory for \"examplereceiver\" receiver type.",
+		},
+		fields: map[string]map[string]string{},
+	},
+	"go.opentelemetry.io/collector/processor/batchprocessor": {
+		types: map[string]string{
+			"Config": "Config defines configuration for batch processor.",
+		},
+		fields: map[string]map[string]string{
+			"Config": {
+				"SendBatchMaxSize": "SendBatchMaxSize is the maximum size of a batch. It must be larger than SendBatchSize. Larger batches are split into smaller units. Default value is 0, that means no maximum size.",
+				"SendBatchSize":    "SendBatchSize is the size of a batch which after hit, will trigger it to be sent.",
+				"Timeout":          "Timeout sets the time after which a batch will be sent regardless of size.",
+			},
+		},
+	},
+	"go.opentelemetry.io/collector/processor/memorylimiterprocessor": {
+		types: map[string]string{
+			"Config": "Config defines configuration for memory memoryLimiter processor.",
+		},
+		fields: map[string]map[string]string{
+			"Config": {
+				"CheckInterval":         "CheckInterval is the time between measurements of memory usage for the purposes of avoiding going over the limits. Defaults to zero, so no checks will be performed.",
+				"MemoryLimitMiB":        "MemoryLimitMiB is the maximum amount of memory, in MiB, targeted to be allocated by the process.",
+				"MemoryLimitPercentage": "MemoryLimitPercentage is the maximum amount of memory, in %, targeted to be allocated by the process. The fixed memory settings MemoryLimitMiB has a higher precedence.",
+				"MemorySpikeLimitMiB":   "MemorySpikeLimitMiB is the maximum, in MiB, spike expected between the measurements of memory usage.",
+				"MemorySpikePercentage": "MemorySpikePercentage is the maximum, in percents against the total memory, spike expected between the measurements of memory usage.",
+			},
+		},
+	},
+	"go.opentelemetry.io/collector/processor/temporalityprocessor": {
+		types: map[string]string{
+			"Config": "Config defines configuration for the temporality processor.",
+		},
+		fields: map[string]map[string]string{
+			"Config": {
+				"AggregationTemporality": "AggregationTemporality is the temporality the metrics are converted to, \"delta\" or \"cumulative\".",
+				"MaxStaleness":           "MaxStaleness is the time after which a time series that did not receive any point is forgotten, 0 meaning that time series are never forgotten. Default value is 5 minutes.",
+			},
+		},
+	},
+	"go.opentelemetry.io/collector/receiver/otlpreceiver": {
+		types: map[string]string{
+			"Config":    "Config defines configuration for OTLP receiver.",
+			"Protocols": "Protocols is the configuration for the supported protocols.",
+		},
+		fields: map[string]map[string]string{
+			"Config": {
+				"LazyDecoding": "LazyDecoding keeps the received protobuf requests encoded until their content is accessed, pipelines that only forward the data then re-export the received bytes.",
+			},
+		},
+	},
+	"go.opentelemetry.io/collector/receiver/scraperhelper": {
+		types: map[string]string{
+			"ScraperControllerSettings": "ScraperControllerSettings defines common settings for a scraper controller configuration. Scraper controller receivers can embed this struct, instead of config.ReceiverSettings, and extend it with more fields if needed.",
+		},
+		fields: map[string]map[string]string{},
+	},
+}
@@ -0,0 +1,328 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configschema generates a JSON Schema for the collector configuration, based on the default
+// configuration of the registered component factories, and encodes the unmarshalled configurations.
+package configschema // import "go.opentelemetry.io/collector/service/internal/configschema"
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+)
+
+// Schema is a JSON Schema document, or a sub-schema of it.
+type Schema = map[string]interface{}
+
+// draft is the JSON Schema version used by the generated schemas.
+const draft = "http://json-schema.org/draft-07/schema#"
+
+// durationPattern matches the strings accepted by time.ParseDuration.
+const durationPattern = `^[-+]?([0-9]*(\.[0-9]*)?(ns|us|µs|ms|s|m|h))+$|^0$`
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// enums contains the allowed values of the known configuration types that are unmarshalled from a set of strings.
+var enums = map[reflect.Type][]interface{}{
+	reflect.TypeOf(configcompression.CompressionType("")): {
+		string(configcompression.Gzip), string(configcompression.Zlib), string(configcompression.Deflate),
+		string(configcompression.Snappy), string(configcompression.Zstd), "none", ""},
+	reflect.TypeOf(configtelemetry.Level(0)): {
+		configtelemetry.LevelNone.String(), configtelemetry.LevelBasic.String(),
+		configtelemetry.LevelNormal.String(), configtelemetry.LevelDetailed.String()},
+	reflect.TypeOf(zapcore.Level(0)): {
+		zapcore.DebugLevel.String(), zapcore.InfoLevel.String(), zapcore.WarnLevel.String(), zapcore.ErrorLevel.String(),
+		zapcore.DPanicLevel.String(), zapcore.PanicLevel.String(), zapcore.FatalLevel.String()},
+}
+
+// Generate returns the JSON Schema of the collector configuration for the given factories.
+// The schema of every component is generated from its default configuration, and the field
+// descriptions are the doc comments of the configuration structs of this module, generated by go generate.
+func Generate(factories component.Factories) Schema {
+	g := newGenerator()
+	return Schema{
+		"$schema":     draft,
+		"title":       "OpenTelemetry Collector configuration",
+		"type":        "object",
+		"description": "Configuration of the OpenTelemetry Collector.",
+		"properties": Schema{
+			"receivers":  g.components(receiversDefaults(factories.Receivers)),
+			"processors": g.components(processorsDefaults(factories.Processors)),
+			"exporters":  g.components(exportersDefaults(factories.Exporters)),
+			"extensions": g.components(extensionsDefaults(factories.Extensions)),
+			"service":    g.value(reflect.ValueOf(config.Service{})),
+		},
+		"additionalProperties": false,
+	}
+}
+
+func receiversDefaults(factories map[config.Type]component.ReceiverFactory) map[config.Type]interface{} {
+	ret := make(map[config.Type]interface{}, len(factories))
+	for typ, f := range factories {
+		ret[typ] = f.CreateDefaultConfig()
+	}
+	return ret
+}
+
+func processorsDefaults(factories map[config.Type]component.ProcessorFactory) map[config.Type]interface{} {
+	ret := make(map[config.Type]interface{}, len(factories))
+	for typ, f := range factories {
+		ret[typ] = f.CreateDefaultConfig()
+	}
+	return ret
+}
+
+func exportersDefaults(factories map[config.Type]component.ExporterFactory) map[config.Type]interface{} {
+	ret := make(map[config.Type]interface{}, len(factories))
+	for typ, f := range factories {
+		ret[typ] = f.CreateDefaultConfig()
+	}
+	return ret
+}
+
+func extensionsDefaults(factories map[config.Type]component.ExtensionFactory) map[config.Type]interface{} {
+	ret := make(map[config.Type]interface{}, len(factories))
+	for typ, f := range factories {
+		ret[typ] = f.CreateDefaultConfig()
+	}
+	return ret
+}
+
+type generator struct {
+	docs *docs
+	// visiting contains the struct types being generated, used to stop on recursive types.
+	visiting map[reflect.Type]bool
+}
+
+func newGenerator() *generator {
+	return &generator{docs: newDocs(), visiting: map[reflect.Type]bool{}}
+}
+
+// components returns the schema of a components section, where every key is a component ID
+// ("type" or "type/name") and the value is the configuration of the component of that type.
+func (g *generator) components(defaults map[config.Type]interface{}) Schema {
+	types := make([]string, 0, len(defaults))
+	for typ := range defaults {
+		types = append(types, string(typ))
+	}
+	sort.Strings(types)
+
+	patterns := Schema{}
+	for _, typ := range types {
+		cfgSchema := g.value(reflect.ValueOf(defaults[config.Type(typ)]))
+		// An empty value means that the default configuration is used.
+		patterns["^"+regexp.QuoteMeta(typ)+"(/.+)?$"] = Schema{"anyOf": []interface{}{cfgSchema, Schema{"type": "null"}}}
+	}
+	return Schema{
+		"type":                 "object",
+		"patternProperties":    patterns,
+		"additionalProperties": false,
+	}
+}
+
+// value returns the schema for the type of the given value, v is used to fill the default values.
+func (g *generator) value(v reflect.Value) Schema {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return g.typ(v.Type())
+		}
+		v = v.Elem()
+	}
+
+	s := g.typ(v.Type())
+	switch v.Kind() {
+	case reflect.Struct:
+		if props, ok := s["properties"].(Schema); ok {
+			g.structDefaults(v, props)
+		}
+	case reflect.Bool, reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		if !v.IsZero() {
+			s["default"] = defaultValue(v)
+		}
+	}
+	return s
+}
+
+// structDefaults sets the default values of all the properties from the given struct value.
+func (g *generator) structDefaults(v reflect.Value, props Schema) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, squash, skip := fieldName(f)
+		if skip {
+			continue
+		}
+		fv := v.Field(i)
+		if squash {
+			for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				g.structDefaults(fv, props)
+			}
+			continue
+		}
+		if fs, ok := props[name].(Schema); ok {
+			withDefaults := g.value(fv)
+			if d, ok := fs["description"]; ok {
+				withDefaults["description"] = d
+			}
+			props[name] = withDefaults
+		}
+	}
+}
+
+// typ returns the schema for the given type, without default values.
+func (g *generator) typ(t reflect.Type) Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if values, ok := enums[t]; ok {
+		return Schema{"type": "string", "enum": values}
+	}
+	if t == durationType {
+		return Schema{"type": "string", "pattern": durationPattern}
+	}
+	if reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return Schema{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return Schema{"type": "string"}
+		}
+		return Schema{"type": "array", "items": g.typ(t.Elem())}
+	case reflect.Map:
+		return Schema{"type": "object", "additionalProperties": g.typ(t.Elem())}
+	case reflect.Struct:
+		return g.structType(t)
+	}
+	// Interfaces and any other types accept any value.
+	return Schema{}
+}
+
+func (g *generator) structType(t reflect.Type) Schema {
+	if g.visiting[t] {
+		return Schema{"type": "object"}
+	}
+	g.visiting[t] = true
+	defer delete(g.visiting, t)
+
+	props := Schema{}
+	g.structFields(t, props)
+	s := Schema{"type": "object", "properties": props, "additionalProperties": false}
+	if doc := g.docs.typeDoc(t); doc != "" {
+		s["description"] = doc
+	}
+	return s
+}
+
+// structFields adds the schema of all the fields of the struct type to props, the fields of the squashed
+// embedded structs are added as fields of the parent struct.
+func (g *generator) structFields(t reflect.Type, props Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, squash, skip := fieldName(f)
+		if skip {
+			continue
+		}
+		if squash {
+			ft := f.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.structFields(ft, props)
+			}
+			continue
+		}
+		fs := g.typ(f.Type)
+		if doc := g.docs.fieldDoc(t, f.Name); doc != "" {
+			fs["description"] = doc
+		}
+		props[name] = fs
+	}
+}
+
+// fieldName returns the configuration key of the given struct field, following the same rules as mapstructure.
+func fieldName(f reflect.StructField) (name string, squash bool, skip bool) {
+	if f.PkgPath != "" && !f.Anonymous {
+		// Unexported field.
+		return "", false, true
+	}
+	tag := f.Tag.Get("mapstructure")
+	if tag == "-" {
+		return "", false, true
+	}
+	parts := strings.Split(tag, ",")
+	for _, opt := range parts[1:] {
+		if opt == "squash" {
+			return "", true, false
+		}
+	}
+	if parts[0] != "" {
+		return parts[0], false, false
+	}
+	if f.PkgPath != "" {
+		// Unexported embedded struct that is not squashed.
+		return "", false, true
+	}
+	return strings.ToLower(f.Name), false, false
+}
+
+func defaultValue(v reflect.Value) interface{} {
+	if v.Type() == durationType {
+		return v.Interface().(time.Duration).String()
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok && enums[v.Type()] != nil {
+		return s.String()
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.String:
+		return v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	}
+	return v.Float()
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/extension/zpagesextension"
+	"go.opentelemetry.io/collector/processor/batchprocessor"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver"
+)
+
+func TestGenerate(t *testing.T) {
+	receivers, err := component.MakeReceiverFactoryMap(otlpreceiver.NewFactory())
+	require.NoError(t, err)
+	processors, err := component.MakeProcessorFactoryMap(batchprocessor.NewFactory())
+	require.NoError(t, err)
+	exporters, err := component.MakeExporterFactoryMap(otlpexporter.NewFactory())
+	require.NoError(t, err)
+	extensions, err := component.MakeExtensionFactoryMap(zpagesextension.NewFactory())
+	require.NoError(t, err)
+
+	schema := Generate(component.Factories{
+		Receivers:  receivers,
+		Processors: processors,
+		Exporters:  exporters,
+		Extensions: extensions,
+	})
+
+	// Make sure the schema is valid JSON and use generic types for the assertions.
+	data, err := json.Marshal(schema)
+	require.NoError(t, err)
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	assert.Equal(t, draft, doc["$schema"])
+
+	batch := lookup(t, doc, "properties", "processors", "patternProperties", "^batch(/.+)?$", "anyOf", 0, "properties")
+	assert.Equal(t, map[string]interface{}{
+		"type":        "string",
+		"pattern":     durationPattern,
+		"default":     "200ms",
+		"description": "Timeout sets the time after which a batch will be sent regardless of size.",
+	}, batch["timeout"])
+	assert.Equal(t, "integer", lookup(t, batch, "send_batch_size")["type"])
+	assert.EqualValues(t, 8192, lookup(t, batch, "send_batch_size")["default"])
+
+	otlp := lookup(t, doc, "properties", "exporters", "patternProperties", "^otlp(/.+)?$", "anyOf", 0, "properties")
+	assert.Equal(t, []interface{}{"gzip", "zlib", "deflate", "snappy", "zstd", "none", ""}, lookup(t, otlp, "compression")["enum"])
+	assert.Equal(t, "string", lookup(t, otlp, "endpoint")["type"])
+	assert.Equal(t, "boolean", lookup(t, otlp, "tls", "properties", "insecure")["type"])
+	assert.Equal(t, "object", lookup(t, otlp, "headers")["type"])
+	assert.Contains(t, otlp, "sending_queue")
+	assert.Contains(t, otlp, "retry_on_failure")
+	assert.NotContains(t, otlp, "exportersettings")
+
+	protocols := lookup(t, doc, "properties", "receivers", "patternProperties", "^otlp(/.+)?$", "anyOf", 0, "properties", "protocols", "properties")
+	assert.Equal(t, "0.0.0.0:4317", lookup(t, protocols, "grpc", "properties", "endpoint")["default"])
+
+	telemetry := lookup(t, doc, "properties", "service", "properties", "telemetry", "properties")
+	assert.Equal(t, []interface{}{"none", "basic", "normal", "detailed"}, lookup(t, telemetry, "metrics", "properties", "level")["enum"])
+	assert.Equal(t, "array", lookup(t, doc, "properties", "service", "properties", "extensions")["type"])
+}
+
+// lookup returns the schema at the given path of keys (or indexes for arrays), failing the test if not found.
+func lookup(t *testing.T, doc map[string]interface{}, path ...interface{}) map[string]interface{} {
+	var cur interface{} = doc
+	for _, p := range path {
+		switch key := p.(type) {
+		case string:
+			m, ok := cur.(map[string]interface{})
+			require.True(t, ok, "not an object at %v", p)
+			cur = m[key]
+		case int:
+			a, ok := cur.([]interface{})
+			require.True(t, ok, "not an array at %v", p)
+			cur = a[key]
+		}
+	}
+	ret, ok := cur.(map[string]interface{})
+	require.True(t, ok, "not an object at the end of %v", path)
+	return ret
+}
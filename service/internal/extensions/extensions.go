@@ -29,14 +29,18 @@ import (
 // builtExtension is an extension that is built based on a config. It can have
 // a start function and have a shutdown function.
 type builtExtension struct {
+	id        config.ComponentID
 	logger    *zap.Logger
 	extension component.Extension
+	// host is the host the extension was started with, nil if not started.
+	host component.Host
 }
 
 // Start the extension.
 func (ext *builtExtension) Start(ctx context.Context, host component.Host) error {
 	ext.logger.Info("Extension is starting...")
-	if err := ext.extension.Start(ctx, components.NewHostWrapper(host, ext.logger)); err != nil {
+	ext.host = components.NewHostWrapper(host, &component.InstanceID{ID: ext.id, Kind: component.KindExtension}, ext.logger)
+	if err := components.StartWithStatus(ctx, ext.extension, ext.host); err != nil {
 		return err
 	}
 	ext.logger.Info("Extension started.")
@@ -45,7 +49,7 @@ func (ext *builtExtension) Start(ctx context.Context, host component.Host) error
 
 // Shutdown the extension.
 func (ext *builtExtension) Shutdown(ctx context.Context) error {
-	return ext.extension.Shutdown(ctx)
+	return components.ShutdownWithStatus(ctx, ext.extension, ext.host)
 }
 
 var _ component.Extension = (*builtExtension)(nil)
@@ -101,6 +105,15 @@ func (exts Extensions) NotifyPipelineNotReady() error {
 	return errs
 }
 
+// ComponentStatusChanged notifies the extensions implementing component.StatusWatcher of the status change.
+func (exts Extensions) ComponentStatusChanged(source *component.InstanceID, event *component.StatusEvent) {
+	for _, ext := range exts {
+		if sw, ok := ext.extension.(component.StatusWatcher); ok {
+			sw.ComponentStatusChanged(source, event)
+		}
+	}
+}
+
 func (exts Extensions) ToMap() map[config.ComponentID]component.Extension {
 	result := make(map[config.ComponentID]component.Extension, len(exts))
 	for extID, v := range exts {
@@ -152,6 +165,7 @@ func Build(
 
 func buildExtension(ctx context.Context, factory component.ExtensionFactory, creationSet component.ExtensionCreateSettings, cfg config.Extension) (*builtExtension, error) {
 	ext := &builtExtension{
+		id:     cfg.ID(),
 		logger: creationSet.Logger,
 	}
 
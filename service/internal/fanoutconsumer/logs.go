@@ -27,8 +27,9 @@ import (
 
 // NewLogs wraps multiple log consumers in a single one.
 // It fanouts the incoming data to all the consumers, and does smart routing:
-//  * Clones only to the consumer that needs to mutate the data.
-//  * If all consumers needs to mutate the data one will get the original data.
+//  * Shares only to the consumers that need to mutate the data, the shared resources
+//    being copied only when they are modified, see Share.
+//  * The consumers that do not mutate the data get the original data.
 func NewLogs(lcs []consumer.Logs) consumer.Logs {
 	if len(lcs) == 1 {
 		// Don't wrap if no need to do it.
@@ -36,22 +37,16 @@ func NewLogs(lcs []consumer.Logs) consumer.Logs {
 	}
 	var pass []consumer.Logs
 	var clone []consumer.Logs
-	for i := 0; i < len(lcs)-1; i++ {
-		if !lcs[i].Capabilities().MutatesData {
-			pass = append(pass, lcs[i])
+	// Never give the original data to a mutating consumer, the shared data of the others being read from it.
+	// Never share the same data between a mutating and a non-mutating consumer either since the
+	// non-mutating consumer may process data async and the mutating consumer may change the data before that.
+	for _, lc := range lcs {
+		if !lc.Capabilities().MutatesData {
+			pass = append(pass, lc)
 		} else {
-			clone = append(clone, lcs[i])
+			clone = append(clone, lc)
 		}
 	}
-	// Give the original data to the last consumer if no other read-only consumer,
-	// otherwise put it in the right bucket. Never share the same data between
-	// a mutating and a non-mutating consumer since the non-mutating consumer may process
-	// data async and the mutating consumer may change the data before that.
-	if len(pass) == 0 || !lcs[len(lcs)-1].Capabilities().MutatesData {
-		pass = append(pass, lcs[len(lcs)-1])
-	} else {
-		clone = append(clone, lcs[len(lcs)-1])
-	}
 	return &logsConsumer{pass: pass, clone: clone}
 }
 
@@ -67,11 +62,10 @@ func (lsc *logsConsumer) Capabilities() consumer.Capabilities {
 // ConsumeLogs exports the plog.Logs to all consumers wrapped by the current one.
 func (lsc *logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 	var errs error
-	// Initially pass to clone exporter to avoid the case where the optimization of sending
-	// the incoming data to a mutating consumer is used that may change the incoming data before
-	// cloning.
+	// The mutating consumers get a copy sharing the resources of the original data, reading it
+	// does not modify it so that it can be passed to the other consumers as is.
 	for _, lc := range lsc.clone {
-		errs = multierr.Append(errs, lc.ConsumeLogs(ctx, ld.Clone()))
+		errs = multierr.Append(errs, lc.ConsumeLogs(ctx, ld.Share()))
 	}
 	for _, lc := range lsc.pass {
 		errs = multierr.Append(errs, lc.ConsumeLogs(ctx, ld))
@@ -20,10 +20,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/plog"
 )
 
 func TestLogsNotMultiplexing(t *testing.T) {
@@ -84,19 +86,18 @@ func TestLogsMultiplexingMutating(t *testing.T) {
 
 	assert.True(t, ld != p1.AllLogs()[0])
 	assert.True(t, ld != p1.AllLogs()[1])
-	assert.EqualValues(t, ld, p1.AllLogs()[0])
-	assert.EqualValues(t, ld, p1.AllLogs()[1])
+	assert.True(t, ld.Equal(p1.AllLogs()[0]))
+	assert.True(t, ld.Equal(p1.AllLogs()[1]))
 
 	assert.True(t, ld != p2.AllLogs()[0])
 	assert.True(t, ld != p2.AllLogs()[1])
-	assert.EqualValues(t, ld, p2.AllLogs()[0])
-	assert.EqualValues(t, ld, p2.AllLogs()[1])
+	assert.True(t, ld.Equal(p2.AllLogs()[0]))
+	assert.True(t, ld.Equal(p2.AllLogs()[1]))
 
-	// For this consumer, will receive the initial data.
-	assert.True(t, ld == p3.AllLogs()[0])
-	assert.True(t, ld == p3.AllLogs()[1])
-	assert.EqualValues(t, ld, p3.AllLogs()[0])
-	assert.EqualValues(t, ld, p3.AllLogs()[1])
+	assert.True(t, ld != p3.AllLogs()[0])
+	assert.True(t, ld != p3.AllLogs()[1])
+	assert.True(t, ld.Equal(p3.AllLogs()[0]))
+	assert.True(t, ld.Equal(p3.AllLogs()[1]))
 }
 
 func TestLogsMultiplexingMixLastMutating(t *testing.T) {
@@ -118,8 +119,8 @@ func TestLogsMultiplexingMixLastMutating(t *testing.T) {
 
 	assert.True(t, ld != p1.AllLogs()[0])
 	assert.True(t, ld != p1.AllLogs()[1])
-	assert.EqualValues(t, ld, p1.AllLogs()[0])
-	assert.EqualValues(t, ld, p1.AllLogs()[1])
+	assert.True(t, ld.Equal(p1.AllLogs()[0]))
+	assert.True(t, ld.Equal(p1.AllLogs()[1]))
 
 	// For this consumer, will receive the initial data.
 	assert.True(t, ld == p2.AllLogs()[0])
@@ -130,8 +131,8 @@ func TestLogsMultiplexingMixLastMutating(t *testing.T) {
 	// For this consumer, will clone the initial data.
 	assert.True(t, ld != p3.AllLogs()[0])
 	assert.True(t, ld != p3.AllLogs()[1])
-	assert.EqualValues(t, ld, p3.AllLogs()[0])
-	assert.EqualValues(t, ld, p3.AllLogs()[1])
+	assert.True(t, ld.Equal(p3.AllLogs()[0]))
+	assert.True(t, ld.Equal(p3.AllLogs()[1]))
 }
 
 func TestLogsMultiplexingMixLastNonMutating(t *testing.T) {
@@ -153,13 +154,13 @@ func TestLogsMultiplexingMixLastNonMutating(t *testing.T) {
 
 	assert.True(t, ld != p1.AllLogs()[0])
 	assert.True(t, ld != p1.AllLogs()[1])
-	assert.EqualValues(t, ld, p1.AllLogs()[0])
-	assert.EqualValues(t, ld, p1.AllLogs()[1])
+	assert.True(t, ld.Equal(p1.AllLogs()[0]))
+	assert.True(t, ld.Equal(p1.AllLogs()[1]))
 
 	assert.True(t, ld != p2.AllLogs()[0])
 	assert.True(t, ld != p2.AllLogs()[1])
-	assert.EqualValues(t, ld, p2.AllLogs()[0])
-	assert.EqualValues(t, ld, p2.AllLogs()[1])
+	assert.True(t, ld.Equal(p2.AllLogs()[0]))
+	assert.True(t, ld.Equal(p2.AllLogs()[1]))
 
 	// For this consumer, will receive the initial data.
 	assert.True(t, ld == p3.AllLogs()[0])
@@ -186,6 +187,49 @@ func TestLogsWhenErrors(t *testing.T) {
 	assert.EqualValues(t, ld, p3.AllLogs()[1])
 }
 
+func TestLogsMultiplexingShared(t *testing.T) {
+	p1 := new(consumertest.LogsSink)
+	p2 := new(consumertest.LogsSink)
+	p3 := &mutatingLogsSink{LogsSink: new(consumertest.LogsSink)}
+
+	tfc := NewLogs([]consumer.Logs{p1, p2, p3})
+	ld := testdata.GenerateLogsOneLogRecord()
+	assert.NoError(t, tfc.ConsumeLogs(context.Background(), ld))
+
+	// The read-only consumers get the original data, the mutating one a copy sharing its resources.
+	assert.True(t, ld == p1.AllLogs()[0])
+	assert.True(t, ld == p2.AllLogs()[0])
+	assert.True(t, ld != p3.AllLogs()[0])
+	assert.True(t, ld.Equal(p3.AllLogs()[0]))
+	assert.False(t, ld.IsShared())
+	assert.True(t, p3.AllLogs()[0].IsShared())
+
+	// Modifying the data of the mutating consumer does not affect the original data.
+	p3.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().UpsertString("mutated", "true")
+	_, ok := ld.ResourceLogs().At(0).Resource().Attributes().Get("mutated")
+	assert.False(t, ok)
+}
+
+func TestLogsMultiplexingAllMutating(t *testing.T) {
+	p1 := &mutatingLogsSink{LogsSink: new(consumertest.LogsSink)}
+	mutate, err := consumer.NewLogs(func(_ context.Context, ld plog.Logs) error {
+		ld.ResourceLogs().At(0).Resource().Attributes().UpsertString("k", "mutated")
+		return nil
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+	require.NoError(t, err)
+
+	tfc := NewLogs([]consumer.Logs{p1, mutate, mutate})
+	ld := testdata.GenerateLogsOneLogRecord()
+	ld.ResourceLogs().At(0).Resource().Attributes().UpsertString("k", "orig")
+	assert.NoError(t, tfc.ConsumeLogs(context.Background(), ld))
+
+	// Every mutating consumer gets its own copy, modifying it does not affect the original data nor the other consumers.
+	v, _ := p1.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().Get("k")
+	assert.Equal(t, "orig", v.StringVal())
+	v, _ = ld.ResourceLogs().At(0).Resource().Attributes().Get("k")
+	assert.Equal(t, "orig", v.StringVal())
+}
+
 type mutatingLogsSink struct {
 	*consumertest.LogsSink
 }
@@ -25,8 +25,9 @@ import (
 
 // NewMetrics wraps multiple metrics consumers in a single one.
 // It fanouts the incoming data to all the consumers, and does smart routing:
-//  * Clones only to the consumer that needs to mutate the data.
-//  * If all consumers needs to mutate the data one will get the original data.
+//  * Shares only to the consumers that need to mutate the data, the shared resources
+//    being copied only when they are modified, see Share.
+//  * The consumers that do not mutate the data get the original data.
 func NewMetrics(mcs []consumer.Metrics) consumer.Metrics {
 	if len(mcs) == 1 {
 		// Don't wrap if no need to do it.
@@ -34,22 +35,16 @@ func NewMetrics(mcs []consumer.Metrics) consumer.Metrics {
 	}
 	var pass []consumer.Metrics
 	var clone []consumer.Metrics
-	for i := 0; i < len(mcs)-1; i++ {
-		if !mcs[i].Capabilities().MutatesData {
-			pass = append(pass, mcs[i])
+	// Never give the original data to a mutating consumer, the shared data of the others being read from it.
+	// Never share the same data between a mutating and a non-mutating consumer either since the
+	// non-mutating consumer may process data async and the mutating consumer may change the data before that.
+	for _, mc := range mcs {
+		if !mc.Capabilities().MutatesData {
+			pass = append(pass, mc)
 		} else {
-			clone = append(clone, mcs[i])
+			clone = append(clone, mc)
 		}
 	}
-	// Give the original data to the last consumer if no other read-only consumer,
-	// otherwise put it in the right bucket. Never share the same data between
-	// a mutating and a non-mutating consumer since the non-mutating consumer may process
-	// data async and the mutating consumer may change the data before that.
-	if len(pass) == 0 || !mcs[len(mcs)-1].Capabilities().MutatesData {
-		pass = append(pass, mcs[len(mcs)-1])
-	} else {
-		clone = append(clone, mcs[len(mcs)-1])
-	}
 	return &metricsConsumer{pass: pass, clone: clone}
 }
 
@@ -65,11 +60,10 @@ func (msc *metricsConsumer) Capabilities() consumer.Capabilities {
 // ConsumeMetrics exports the pmetric.Metrics to all consumers wrapped by the current one.
 func (msc *metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
 	var errs error
-	// Initially pass to clone exporter to avoid the case where the optimization of sending
-	// the incoming data to a mutating consumer is used that may change the incoming data before
-	// cloning.
+	// The mutating consumers get a copy sharing the resources of the original data, reading it
+	// does not modify it so that it can be passed to the other consumers as is.
 	for _, mc := range msc.clone {
-		errs = multierr.Append(errs, mc.ConsumeMetrics(ctx, md.Clone()))
+		errs = multierr.Append(errs, mc.ConsumeMetrics(ctx, md.Share()))
 	}
 	for _, mc := range msc.pass {
 		errs = multierr.Append(errs, mc.ConsumeMetrics(ctx, md))
@@ -20,10 +20,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
 func TestMetricsNotMultiplexing(t *testing.T) {
@@ -84,19 +86,18 @@ func TestMetricsMultiplexingMutating(t *testing.T) {
 
 	assert.True(t, md != p1.AllMetrics()[0])
 	assert.True(t, md != p1.AllMetrics()[1])
-	assert.EqualValues(t, md, p1.AllMetrics()[0])
-	assert.EqualValues(t, md, p1.AllMetrics()[1])
+	assert.True(t, md.Equal(p1.AllMetrics()[0]))
+	assert.True(t, md.Equal(p1.AllMetrics()[1]))
 
 	assert.True(t, md != p2.AllMetrics()[0])
 	assert.True(t, md != p2.AllMetrics()[1])
-	assert.EqualValues(t, md, p2.AllMetrics()[0])
-	assert.EqualValues(t, md, p2.AllMetrics()[1])
+	assert.True(t, md.Equal(p2.AllMetrics()[0]))
+	assert.True(t, md.Equal(p2.AllMetrics()[1]))
 
-	// For this consumer, will receive the initial data.
-	assert.True(t, md == p3.AllMetrics()[0])
-	assert.True(t, md == p3.AllMetrics()[1])
-	assert.EqualValues(t, md, p3.AllMetrics()[0])
-	assert.EqualValues(t, md, p3.AllMetrics()[1])
+	assert.True(t, md != p3.AllMetrics()[0])
+	assert.True(t, md != p3.AllMetrics()[1])
+	assert.True(t, md.Equal(p3.AllMetrics()[0]))
+	assert.True(t, md.Equal(p3.AllMetrics()[1]))
 }
 
 func TestMetricsMultiplexingMixLastMutating(t *testing.T) {
@@ -118,8 +119,8 @@ func TestMetricsMultiplexingMixLastMutating(t *testing.T) {
 
 	assert.True(t, md != p1.AllMetrics()[0])
 	assert.True(t, md != p1.AllMetrics()[1])
-	assert.EqualValues(t, md, p1.AllMetrics()[0])
-	assert.EqualValues(t, md, p1.AllMetrics()[1])
+	assert.True(t, md.Equal(p1.AllMetrics()[0]))
+	assert.True(t, md.Equal(p1.AllMetrics()[1]))
 
 	// For this consumer, will receive the initial data.
 	assert.True(t, md == p2.AllMetrics()[0])
@@ -130,8 +131,8 @@ func TestMetricsMultiplexingMixLastMutating(t *testing.T) {
 	// For this consumer, will clone the initial data.
 	assert.True(t, md != p3.AllMetrics()[0])
 	assert.True(t, md != p3.AllMetrics()[1])
-	assert.EqualValues(t, md, p3.AllMetrics()[0])
-	assert.EqualValues(t, md, p3.AllMetrics()[1])
+	assert.True(t, md.Equal(p3.AllMetrics()[0]))
+	assert.True(t, md.Equal(p3.AllMetrics()[1]))
 }
 
 func TestMetricsMultiplexingMixLastNonMutating(t *testing.T) {
@@ -153,13 +154,13 @@ func TestMetricsMultiplexingMixLastNonMutating(t *testing.T) {
 
 	assert.True(t, md != p1.AllMetrics()[0])
 	assert.True(t, md != p1.AllMetrics()[1])
-	assert.EqualValues(t, md, p1.AllMetrics()[0])
-	assert.EqualValues(t, md, p1.AllMetrics()[1])
+	assert.True(t, md.Equal(p1.AllMetrics()[0]))
+	assert.True(t, md.Equal(p1.AllMetrics()[1]))
 
 	assert.True(t, md != p2.AllMetrics()[0])
 	assert.True(t, md != p2.AllMetrics()[1])
-	assert.EqualValues(t, md, p2.AllMetrics()[0])
-	assert.EqualValues(t, md, p2.AllMetrics()[1])
+	assert.True(t, md.Equal(p2.AllMetrics()[0]))
+	assert.True(t, md.Equal(p2.AllMetrics()[1]))
 
 	// For this consumer, will receive the initial data.
 	assert.True(t, md == p3.AllMetrics()[0])
@@ -186,6 +187,49 @@ func TestMetricsWhenErrors(t *testing.T) {
 	assert.EqualValues(t, md, p3.AllMetrics()[1])
 }
 
+func TestMetricsMultiplexingShared(t *testing.T) {
+	p1 := new(consumertest.MetricsSink)
+	p2 := new(consumertest.MetricsSink)
+	p3 := &mutatingMetricsSink{MetricsSink: new(consumertest.MetricsSink)}
+
+	tfc := NewMetrics([]consumer.Metrics{p1, p2, p3})
+	md := testdata.GenerateMetricsOneMetric()
+	assert.NoError(t, tfc.ConsumeMetrics(context.Background(), md))
+
+	// The read-only consumers get the original data, the mutating one a copy sharing its resources.
+	assert.True(t, md == p1.AllMetrics()[0])
+	assert.True(t, md == p2.AllMetrics()[0])
+	assert.True(t, md != p3.AllMetrics()[0])
+	assert.True(t, md.Equal(p3.AllMetrics()[0]))
+	assert.False(t, md.IsShared())
+	assert.True(t, p3.AllMetrics()[0].IsShared())
+
+	// Modifying the data of the mutating consumer does not affect the original data.
+	p3.AllMetrics()[0].ResourceMetrics().At(0).Resource().Attributes().UpsertString("mutated", "true")
+	_, ok := md.ResourceMetrics().At(0).Resource().Attributes().Get("mutated")
+	assert.False(t, ok)
+}
+
+func TestMetricsMultiplexingAllMutating(t *testing.T) {
+	p1 := &mutatingMetricsSink{MetricsSink: new(consumertest.MetricsSink)}
+	mutate, err := consumer.NewMetrics(func(_ context.Context, md pmetric.Metrics) error {
+		md.ResourceMetrics().At(0).Resource().Attributes().UpsertString("k", "mutated")
+		return nil
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+	require.NoError(t, err)
+
+	tfc := NewMetrics([]consumer.Metrics{p1, mutate, mutate})
+	md := testdata.GenerateMetricsOneMetric()
+	md.ResourceMetrics().At(0).Resource().Attributes().UpsertString("k", "orig")
+	assert.NoError(t, tfc.ConsumeMetrics(context.Background(), md))
+
+	// Every mutating consumer gets its own copy, modifying it does not affect the original data nor the other consumers.
+	v, _ := p1.AllMetrics()[0].ResourceMetrics().At(0).Resource().Attributes().Get("k")
+	assert.Equal(t, "orig", v.StringVal())
+	v, _ = md.ResourceMetrics().At(0).Resource().Attributes().Get("k")
+	assert.Equal(t, "orig", v.StringVal())
+}
+
 type mutatingMetricsSink struct {
 	*consumertest.MetricsSink
 }
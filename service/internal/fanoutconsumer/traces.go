@@ -25,8 +25,9 @@ import (
 
 // NewTraces wraps multiple trace consumers in a single one.
 // It fanouts the incoming data to all the consumers, and does smart routing:
-//  * Clones only to the consumer that needs to mutate the data.
-//  * If all consumers needs to mutate the data one will get the original data.
+//  * Shares only to the consumers that need to mutate the data, the shared resources
+//    being copied only when they are modified, see Share.
+//  * The consumers that do not mutate the data get the original data.
 func NewTraces(tcs []consumer.Traces) consumer.Traces {
 	if len(tcs) == 1 {
 		// Don't wrap if no need to do it.
@@ -34,22 +35,16 @@ func NewTraces(tcs []consumer.Traces) consumer.Traces {
 	}
 	var pass []consumer.Traces
 	var clone []consumer.Traces
-	for i := 0; i < len(tcs)-1; i++ {
-		if !tcs[i].Capabilities().MutatesData {
-			pass = append(pass, tcs[i])
+	// Never give the original data to a mutating consumer, the shared data of the others being read from it.
+	// Never share the same data between a mutating and a non-mutating consumer either since the
+	// non-mutating consumer may process data async and the mutating consumer may change the data before that.
+	for _, tc := range tcs {
+		if !tc.Capabilities().MutatesData {
+			pass = append(pass, tc)
 		} else {
-			clone = append(clone, tcs[i])
+			clone = append(clone, tc)
 		}
 	}
-	// Give the original data to the last consumer if no other read-only consumer,
-	// otherwise put it in the right bucket. Never share the same data between
-	// a mutating and a non-mutating consumer since the non-mutating consumer may process
-	// data async and the mutating consumer may change the data before that.
-	if len(pass) == 0 || !tcs[len(tcs)-1].Capabilities().MutatesData {
-		pass = append(pass, tcs[len(tcs)-1])
-	} else {
-		clone = append(clone, tcs[len(tcs)-1])
-	}
 	return &tracesConsumer{pass: pass, clone: clone}
 }
 
@@ -65,11 +60,10 @@ func (tsc *tracesConsumer) Capabilities() consumer.Capabilities {
 // ConsumeTraces exports the ptrace.Traces to all consumers wrapped by the current one.
 func (tsc *tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
 	var errs error
-	// Initially pass to clone exporter to avoid the case where the optimization of sending
-	// the incoming data to a mutating consumer is used that may change the incoming data before
-	// cloning.
+	// The mutating consumers get a copy sharing the resources of the original data, reading it
+	// does not modify it so that it can be passed to the other consumers as is.
 	for _, tc := range tsc.clone {
-		errs = multierr.Append(errs, tc.ConsumeTraces(ctx, td.Clone()))
+		errs = multierr.Append(errs, tc.ConsumeTraces(ctx, td.Share()))
 	}
 	for _, tc := range tsc.pass {
 		errs = multierr.Append(errs, tc.ConsumeTraces(ctx, td))
@@ -20,10 +20,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
 func TestTracesNotMultiplexing(t *testing.T) {
@@ -84,19 +86,18 @@ func TestTracesMultiplexingMutating(t *testing.T) {
 
 	assert.True(t, td != p1.AllTraces()[0])
 	assert.True(t, td != p1.AllTraces()[1])
-	assert.EqualValues(t, td, p1.AllTraces()[0])
-	assert.EqualValues(t, td, p1.AllTraces()[1])
+	assert.True(t, td.Equal(p1.AllTraces()[0]))
+	assert.True(t, td.Equal(p1.AllTraces()[1]))
 
 	assert.True(t, td != p2.AllTraces()[0])
 	assert.True(t, td != p2.AllTraces()[1])
-	assert.EqualValues(t, td, p2.AllTraces()[0])
-	assert.EqualValues(t, td, p2.AllTraces()[1])
+	assert.True(t, td.Equal(p2.AllTraces()[0]))
+	assert.True(t, td.Equal(p2.AllTraces()[1]))
 
-	// For this consumer, will receive the initial data.
-	assert.True(t, td == p3.AllTraces()[0])
-	assert.True(t, td == p3.AllTraces()[1])
-	assert.EqualValues(t, td, p3.AllTraces()[0])
-	assert.EqualValues(t, td, p3.AllTraces()[1])
+	assert.True(t, td != p3.AllTraces()[0])
+	assert.True(t, td != p3.AllTraces()[1])
+	assert.True(t, td.Equal(p3.AllTraces()[0]))
+	assert.True(t, td.Equal(p3.AllTraces()[1]))
 }
 
 func TestTracesMultiplexingMixLastMutating(t *testing.T) {
@@ -118,8 +119,8 @@ func TestTracesMultiplexingMixLastMutating(t *testing.T) {
 
 	assert.True(t, td != p1.AllTraces()[0])
 	assert.True(t, td != p1.AllTraces()[1])
-	assert.EqualValues(t, td, p1.AllTraces()[0])
-	assert.EqualValues(t, td, p1.AllTraces()[1])
+	assert.True(t, td.Equal(p1.AllTraces()[0]))
+	assert.True(t, td.Equal(p1.AllTraces()[1]))
 
 	// For this consumer, will receive the initial data.
 	assert.True(t, td == p2.AllTraces()[0])
@@ -130,8 +131,8 @@ func TestTracesMultiplexingMixLastMutating(t *testing.T) {
 	// For this consumer, will clone the initial data.
 	assert.True(t, td != p3.AllTraces()[0])
 	assert.True(t, td != p3.AllTraces()[1])
-	assert.EqualValues(t, td, p3.AllTraces()[0])
-	assert.EqualValues(t, td, p3.AllTraces()[1])
+	assert.True(t, td.Equal(p3.AllTraces()[0]))
+	assert.True(t, td.Equal(p3.AllTraces()[1]))
 }
 
 func TestTracesMultiplexingMixLastNonMutating(t *testing.T) {
@@ -153,13 +154,13 @@ func TestTracesMultiplexingMixLastNonMutating(t *testing.T) {
 
 	assert.True(t, td != p1.AllTraces()[0])
 	assert.True(t, td != p1.AllTraces()[1])
-	assert.EqualValues(t, td, p1.AllTraces()[0])
-	assert.EqualValues(t, td, p1.AllTraces()[1])
+	assert.True(t, td.Equal(p1.AllTraces()[0]))
+	assert.True(t, td.Equal(p1.AllTraces()[1]))
 
 	assert.True(t, td != p2.AllTraces()[0])
 	assert.True(t, td != p2.AllTraces()[1])
-	assert.EqualValues(t, td, p2.AllTraces()[0])
-	assert.EqualValues(t, td, p2.AllTraces()[1])
+	assert.True(t, td.Equal(p2.AllTraces()[0]))
+	assert.True(t, td.Equal(p2.AllTraces()[1]))
 
 	// For this consumer, will receive the initial data.
 	assert.True(t, td == p3.AllTraces()[0])
@@ -186,6 +187,49 @@ func TestTracesWhenErrors(t *testing.T) {
 	assert.EqualValues(t, td, p3.AllTraces()[1])
 }
 
+func TestTracesMultiplexingShared(t *testing.T) {
+	p1 := new(consumertest.TracesSink)
+	p2 := new(consumertest.TracesSink)
+	p3 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+
+	tfc := NewTraces([]consumer.Traces{p1, p2, p3})
+	td := testdata.GenerateTracesOneSpan()
+	assert.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+
+	// The read-only consumers get the original data, the mutating one a copy sharing its resources.
+	assert.True(t, td == p1.AllTraces()[0])
+	assert.True(t, td == p2.AllTraces()[0])
+	assert.True(t, td != p3.AllTraces()[0])
+	assert.True(t, td.Equal(p3.AllTraces()[0]))
+	assert.False(t, td.IsShared())
+	assert.True(t, p3.AllTraces()[0].IsShared())
+
+	// Modifying the data of the mutating consumer does not affect the original data.
+	p3.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().UpsertString("mutated", "true")
+	_, ok := td.ResourceSpans().At(0).Resource().Attributes().Get("mutated")
+	assert.False(t, ok)
+}
+
+func TestTracesMultiplexingAllMutating(t *testing.T) {
+	p1 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+	mutate, err := consumer.NewTraces(func(_ context.Context, td ptrace.Traces) error {
+		td.ResourceSpans().At(0).Resource().Attributes().UpsertString("k", "mutated")
+		return nil
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+	require.NoError(t, err)
+
+	tfc := NewTraces([]consumer.Traces{p1, mutate, mutate})
+	td := testdata.GenerateTracesOneSpan()
+	td.ResourceSpans().At(0).Resource().Attributes().UpsertString("k", "orig")
+	assert.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+
+	// Every mutating consumer gets its own copy, modifying it does not affect the original data nor the other consumers.
+	v, _ := p1.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().Get("k")
+	assert.Equal(t, "orig", v.StringVal())
+	v, _ = td.ResourceSpans().At(0).Resource().Attributes().Get("k")
+	assert.Equal(t, "orig", v.StringVal())
+}
+
 type mutatingTracesSink struct {
 	*consumertest.TracesSink
 }
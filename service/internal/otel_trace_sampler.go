@@ -15,25 +15,72 @@
 package internal // import "go.opentelemetry.io/collector/service/internal"
 
 import (
+	"sync/atomic"
+
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/internal/telemetryexport"
 )
 
-type recordSampler struct{}
+// Sampler records all the spans, so they are visible in zpages, but samples, i.e. exports, only the spans
+// sampled according to the configured sampling. The sampling can be configured while the TracerProvider
+// using the Sampler is in use, e.g. when the configuration is reloaded.
+type Sampler struct {
+	// sampler holds a samplerHolder, since an atomic.Value requires all the values to have the same type.
+	sampler atomic.Value
+}
+
+type samplerHolder struct {
+	sdktrace.Sampler
+}
+
+// NewSampler returns a Sampler that records all the spans, and only samples the spans whose parent is
+// sampled until configured.
+func NewSampler() *Sampler {
+	s := &Sampler{}
+	s.sampler.Store(samplerHolder{parentSampler()})
+	return s
+}
+
+// parentSampler keeps the decision of the parent, and does not sample the root spans, so that the
+// sampling decisions propagated to the downstream services are not changed.
+func parentSampler() sdktrace.Sampler {
+	return sdktrace.ParentBased(sdktrace.NeverSample())
+}
 
-func (r recordSampler) ShouldSample(parameters sdktrace.SamplingParameters) sdktrace.SamplingResult {
-	return sdktrace.SamplingResult{Decision: sdktrace.RecordOnly}
+// Configure sets the sampling used for the spans started after the call. If the given configuration has
+// no exporters, only the decision of the parent is kept, since the sampled spans would not be exported.
+func (s *Sampler) Configure(cfg config.ServiceTelemetryTraces) {
+	if len(cfg.Exporters) == 0 {
+		s.sampler.Store(samplerHolder{parentSampler()})
+		return
+	}
+	sampler := sdktrace.TraceIDRatioBased(cfg.Sampling.Ratio)
+	if cfg.Sampling.ParentBased {
+		sampler = sdktrace.ParentBased(sampler)
+	}
+	s.sampler.Store(samplerHolder{sampler})
 }
 
-func (r recordSampler) Description() string {
-	return "Always record sampler"
+// ShouldSample implements sdktrace.Sampler. The spans started while pushing the collector's own telemetry
+// are dropped, since exporting them would start more spans.
+func (s *Sampler) ShouldSample(parameters sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if parameters.ParentContext != nil && telemetryexport.IsSelfExport(parameters.ParentContext) {
+		return sdktrace.SamplingResult{
+			Decision:   sdktrace.Drop,
+			Tracestate: trace.SpanContextFromContext(parameters.ParentContext).TraceState(),
+		}
+	}
+	result := s.sampler.Load().(samplerHolder).ShouldSample(parameters)
+	if result.Decision == sdktrace.Drop {
+		result.Decision = sdktrace.RecordOnly
+	}
+	return result
 }
 
-func AlwaysRecord() sdktrace.Sampler {
-	rs := &recordSampler{}
-	return sdktrace.ParentBased(
-		rs,
-		sdktrace.WithRemoteParentSampled(sdktrace.AlwaysSample()),
-		sdktrace.WithRemoteParentNotSampled(rs),
-		sdktrace.WithLocalParentSampled(sdktrace.AlwaysSample()),
-		sdktrace.WithRemoteParentSampled(rs))
+// Description implements sdktrace.Sampler.
+func (s *Sampler) Description() string {
+	return "AlwaysRecord{" + s.sampler.Load().(samplerHolder).Description() + "}"
 }
@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/internal/telemetryexport"
+)
+
+func TestSampler(t *testing.T) {
+	traceID := trace.TraceID{1, 2, 3}
+	sampledParent := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}))
+	notSampledParent := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  trace.SpanID{1},
+		Remote:  true,
+	}))
+
+	tests := []struct {
+		name       string
+		configure  func(s *Sampler)
+		ctx        context.Context
+		wantSample bool
+	}{
+		{
+			name:       "not_configured",
+			configure:  func(*Sampler) {},
+			ctx:        sampledParent,
+			wantSample: true,
+		},
+		{
+			name:      "not_configured_root",
+			configure: func(*Sampler) {},
+			ctx:       context.Background(),
+		},
+		{
+			name: "no_exporters_sampled",
+			configure: func(s *Sampler) {
+				s.Configure(config.ServiceTelemetryTraces{Sampling: config.ServiceTelemetryTracesSampling{Ratio: 1}})
+			},
+			ctx:        sampledParent,
+			wantSample: true,
+		},
+		{
+			name: "no_exporters_not_sampled",
+			configure: func(s *Sampler) {
+				s.Configure(config.ServiceTelemetryTraces{Sampling: config.ServiceTelemetryTracesSampling{Ratio: 1}})
+			},
+			ctx: notSampledParent,
+		},
+		{
+			name: "no_exporters_root",
+			configure: func(s *Sampler) {
+				s.Configure(config.ServiceTelemetryTraces{Sampling: config.ServiceTelemetryTracesSampling{Ratio: 1}})
+			},
+			ctx: context.Background(),
+		},
+		{
+			name:       "all",
+			configure:  func(s *Sampler) { s.Configure(tracesConfig(config.ServiceTelemetryTracesSampling{Ratio: 1})) },
+			ctx:        notSampledParent,
+			wantSample: true,
+		},
+		{
+			name:      "none",
+			configure: func(s *Sampler) { s.Configure(tracesConfig(config.ServiceTelemetryTracesSampling{Ratio: 0})) },
+			ctx:       sampledParent,
+		},
+		{
+			name: "parent_based_sampled",
+			configure: func(s *Sampler) {
+				s.Configure(tracesConfig(config.ServiceTelemetryTracesSampling{Ratio: 0, ParentBased: true}))
+			},
+			ctx:        sampledParent,
+			wantSample: true,
+		},
+		{
+			name: "parent_based_not_sampled",
+			configure: func(s *Sampler) {
+				s.Configure(tracesConfig(config.ServiceTelemetryTracesSampling{Ratio: 1, ParentBased: true}))
+			},
+			ctx: notSampledParent,
+		},
+		{
+			name: "parent_based_root",
+			configure: func(s *Sampler) {
+				s.Configure(tracesConfig(config.ServiceTelemetryTracesSampling{Ratio: 1, ParentBased: true}))
+			},
+			ctx:        context.Background(),
+			wantSample: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSampler()
+			tt.configure(s)
+			result := s.ShouldSample(sdktrace.SamplingParameters{ParentContext: tt.ctx, TraceID: traceID, Name: "span"})
+			if tt.wantSample {
+				assert.Equal(t, sdktrace.RecordAndSample, result.Decision)
+			} else {
+				assert.Equal(t, sdktrace.RecordOnly, result.Decision)
+			}
+		})
+	}
+}
+
+func TestSamplerDropsSelfExport(t *testing.T) {
+	s := NewSampler()
+	s.Configure(tracesConfig(config.ServiceTelemetryTracesSampling{Ratio: 1}))
+	ctx := telemetryexport.ContextWithSelfExport(context.Background())
+	result := s.ShouldSample(sdktrace.SamplingParameters{ParentContext: ctx, TraceID: trace.TraceID{1}, Name: "span"})
+	assert.Equal(t, sdktrace.Drop, result.Decision)
+}
+
+// tracesConfig returns a traces configuration with an exporter and the given sampling.
+func tracesConfig(sampling config.ServiceTelemetryTracesSampling) config.ServiceTelemetryTraces {
+	return config.ServiceTelemetryTraces{
+		Exporters: []config.ComponentID{config.NewComponentID("nop")},
+		Sampling:  sampling,
+	}
+}
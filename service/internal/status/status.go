@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package status keeps the status reported by the components and notifies the watchers of the changes.
+package status // import "go.opentelemetry.io/collector/service/internal/status"
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Reporter keeps the last status reported by each component and notifies the watchers of the changes.
+type Reporter struct {
+	mu       sync.RWMutex
+	statuses map[component.InstanceID]*component.StatusEvent
+	watchers []component.StatusWatcher
+}
+
+// NewReporter returns a new Reporter without watchers.
+func NewReporter() *Reporter {
+	return &Reporter{
+		statuses: make(map[component.InstanceID]*component.StatusEvent),
+	}
+}
+
+// AddWatcher adds a watcher notified of the status changes reported after the call.
+func (r *Reporter) AddWatcher(watcher component.StatusWatcher) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.watchers = append(r.watchers, watcher)
+}
+
+// ReportComponentStatus records the status reported by the source component, and notifies the watchers
+// unless the component is still OK.
+func (r *Reporter) ReportComponentStatus(source *component.InstanceID, event *component.StatusEvent) {
+	r.mu.Lock()
+	last, ok := r.statuses[*source]
+	r.statuses[*source] = event
+	watchers := r.watchers
+	r.mu.Unlock()
+
+	if ok && last.Status() == component.StatusOK && event.Status() == component.StatusOK {
+		return
+	}
+	for _, watcher := range watchers {
+		watcher.ComponentStatusChanged(source, event)
+	}
+}
+
+// AggregateStatus returns the most severe of the last statuses reported by the components, e.g. of the
+// components of a pipeline, or nil if none of them reported a status.
+func (r *Reporter) AggregateStatus(ids []component.InstanceID) *component.StatusEvent {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var ret *component.StatusEvent
+	for _, id := range ids {
+		event, ok := r.statuses[id]
+		if !ok {
+			continue
+		}
+		if ret == nil || severity(event.Status()) > severity(ret.Status()) {
+			ret = event
+		}
+	}
+	return ret
+}
+
+// severity ranks the statuses from the least to the most severe.
+func severity(status component.Status) int {
+	switch status {
+	case component.StatusOK:
+		return 0
+	case component.StatusStarting:
+		return 1
+	case component.StatusStopping:
+		return 2
+	case component.StatusRecoverableError:
+		return 3
+	case component.StatusPermanentError:
+		return 4
+	}
+	return -1
+}
+
+// Statuses returns the last status reported by each component.
+func (r *Reporter) Statuses() map[component.InstanceID]*component.StatusEvent {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ret := make(map[component.InstanceID]*component.StatusEvent, len(r.statuses))
+	for id, event := range r.statuses {
+		ret[id] = event
+	}
+	return ret
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+type recordingWatcher struct {
+	sources []component.InstanceID
+	events  []*component.StatusEvent
+}
+
+func (rw *recordingWatcher) ComponentStatusChanged(source *component.InstanceID, event *component.StatusEvent) {
+	rw.sources = append(rw.sources, *source)
+	rw.events = append(rw.events, event)
+}
+
+func TestReporter(t *testing.T) {
+	r := NewReporter()
+	watcher := &recordingWatcher{}
+	r.AddWatcher(watcher)
+
+	exporter := &component.InstanceID{ID: config.NewComponentID("otlp"), Kind: component.KindExporter}
+	receiver := &component.InstanceID{ID: config.NewComponentID("otlp"), Kind: component.KindReceiver}
+
+	r.ReportComponentStatus(exporter, component.NewStatusEvent(component.StatusOK))
+	// The exporter is still OK, the watchers are not notified.
+	r.ReportComponentStatus(exporter, component.NewStatusEvent(component.StatusOK))
+	r.ReportComponentStatus(receiver, component.NewRecoverableErrorEvent(errors.New("error")))
+	r.ReportComponentStatus(receiver, component.NewRecoverableErrorEvent(errors.New("other error")))
+	r.ReportComponentStatus(receiver, component.NewStatusEvent(component.StatusOK))
+
+	require.Len(t, watcher.events, 4)
+	assert.Equal(t, []component.InstanceID{*exporter, *receiver, *receiver, *receiver}, watcher.sources)
+	assert.Equal(t, component.StatusOK, watcher.events[0].Status())
+	assert.EqualError(t, watcher.events[2].Err(), "other error")
+	assert.Equal(t, component.StatusOK, watcher.events[3].Status())
+
+	statuses := r.Statuses()
+	require.Len(t, statuses, 2)
+	assert.Equal(t, component.StatusOK, statuses[*exporter].Status())
+	assert.Equal(t, component.StatusOK, statuses[*receiver].Status())
+}
+
+func TestReporterAggregateStatus(t *testing.T) {
+	r := NewReporter()
+	receiver := component.InstanceID{ID: config.NewComponentID("otlp"), Kind: component.KindReceiver}
+	processor := component.InstanceID{ID: config.NewComponentID("batch"), Kind: component.KindProcessor, PipelineID: config.NewComponentID("traces")}
+	otherProcessor := component.InstanceID{ID: config.NewComponentID("batch"), Kind: component.KindProcessor, PipelineID: config.NewComponentID("metrics")}
+	exporter := component.InstanceID{ID: config.NewComponentID("otlp"), Kind: component.KindExporter}
+	pipeline := []component.InstanceID{receiver, processor, exporter}
+
+	assert.Nil(t, r.AggregateStatus(pipeline))
+
+	r.ReportComponentStatus(&receiver, component.NewStatusEvent(component.StatusOK))
+	r.ReportComponentStatus(&processor, component.NewStatusEvent(component.StatusStarting))
+	assert.Equal(t, component.StatusStarting, r.AggregateStatus(pipeline).Status())
+
+	r.ReportComponentStatus(&processor, component.NewStatusEvent(component.StatusOK))
+	r.ReportComponentStatus(&otherProcessor, component.NewPermanentErrorEvent(errors.New("other pipeline")))
+	r.ReportComponentStatus(&exporter, component.NewStatusEvent(component.StatusOK))
+	assert.Equal(t, component.StatusOK, r.AggregateStatus(pipeline).Status())
+
+	r.ReportComponentStatus(&exporter, component.NewRecoverableErrorEvent(errors.New("unavailable")))
+	r.ReportComponentStatus(&receiver, component.NewStatusEvent(component.StatusStopping))
+	event := r.AggregateStatus(pipeline)
+	assert.Equal(t, component.StatusRecoverableError, event.Status())
+	assert.EqualError(t, event.Err(), "unavailable")
+}
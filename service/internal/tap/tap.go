@@ -0,0 +1,231 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tap streams a sampled copy of the data passing through the points of the pipelines,
+// encoded as OTLP JSON, to the subscribers debugging them.
+package tap // import "go.opentelemetry.io/collector/service/internal/tap"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	tracesMarshaler  = ptrace.NewJSONMarshaler()
+	metricsMarshaler = pmetric.NewJSONMarshaler()
+	logsMarshaler    = plog.NewJSONMarshaler()
+
+	// The size of the data encoded as OTLP protobuf is a lower bound of its size encoded as OTLP JSON.
+	tracesSizer  = ptrace.NewProtoMarshaler().(ptrace.Sizer)
+	metricsSizer = pmetric.NewProtoMarshaler().(pmetric.Sizer)
+	logsSizer    = plog.NewProtoMarshaler().(plog.Sizer)
+)
+
+// Point identifies a point of a pipeline that can be tapped: after a receiver, after a processor or
+// before an exporter.
+type Point struct {
+	Kind component.Kind
+	ID   config.ComponentID
+}
+
+// Limits bound the data sent to a subscription.
+type Limits struct {
+	// Interval is the minimum time between two messages, the data consumed in between is not sent.
+	Interval time.Duration
+	// MaxSize is the maximum size in bytes of a message, bigger messages are dropped.
+	MaxSize int
+}
+
+// Subscription receives the data tapped by a Tap, within its limits.
+type Subscription struct {
+	limits  Limits
+	ch      chan []byte
+	next    time.Time
+	dropped *atomic.Int64
+}
+
+// Data returns the channel receiving the tapped data, encoded as OTLP JSON.
+func (s *Subscription) Data() <-chan []byte {
+	return s.ch
+}
+
+// Dropped returns the number of messages dropped, because they were too big or not consumed in time.
+func (s *Subscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Tap sends a copy of the data consumed at a point of a pipeline to its subscriptions.
+// The data is only encoded when a subscription is due to receive it.
+type Tap struct {
+	active *atomic.Bool
+
+	mu   sync.Mutex
+	subs map[*Subscription]struct{}
+}
+
+// New returns a Tap without subscriptions.
+func New() *Tap {
+	return &Tap{
+		active: atomic.NewBool(false),
+		subs:   make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe returns a new subscription to the data, within the given limits.
+func (t *Tap) Subscribe(limits Limits) *Subscription {
+	s := &Subscription{
+		limits:  limits,
+		ch:      make(chan []byte, 1),
+		dropped: atomic.NewInt64(0),
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.subs[s] = struct{}{}
+	t.active.Store(true)
+	return s
+}
+
+// Unsubscribe stops sending data to the subscription.
+func (t *Tap) Unsubscribe(s *Subscription) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.subs, s)
+	t.active.Store(len(t.subs) > 0)
+}
+
+// publish sends the data returned by marshal to the subscriptions due to receive it. The data is
+// marshaled outside the lock, and only if its size, as returned by size, is within the limits of
+// at least one of the due subscriptions.
+func (t *Tap) publish(size func() int, marshal func() ([]byte, error)) {
+	due := t.due()
+	if len(due) == 0 {
+		return
+	}
+
+	n := size()
+	fit := due[:0]
+	for _, s := range due {
+		if s.limits.MaxSize > 0 && n > s.limits.MaxSize {
+			s.dropped.Inc()
+			continue
+		}
+		fit = append(fit, s)
+	}
+	if len(fit) == 0 {
+		return
+	}
+
+	buf, err := marshal()
+	if err != nil {
+		return
+	}
+	for _, s := range fit {
+		if s.limits.MaxSize > 0 && len(buf) > s.limits.MaxSize {
+			s.dropped.Inc()
+			continue
+		}
+		select {
+		case s.ch <- buf:
+		default:
+			s.dropped.Inc()
+		}
+	}
+}
+
+// due returns the subscriptions due to receive the data, and schedules their next message.
+func (t *Tap) due() []*Subscription {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	var due []*Subscription
+	for s := range t.subs {
+		if !now.Before(s.next) {
+			s.next = now.Add(s.limits.Interval)
+			due = append(due, s)
+		}
+	}
+	return due
+}
+
+// Traces returns a consumer tapping the data before sending it to the next consumer.
+func (t *Tap) Traces(next consumer.Traces) consumer.Traces {
+	return tapTraces{Traces: next, tap: t}
+}
+
+// Metrics returns a consumer tapping the data before sending it to the next consumer.
+func (t *Tap) Metrics(next consumer.Metrics) consumer.Metrics {
+	return tapMetrics{Metrics: next, tap: t}
+}
+
+// Logs returns a consumer tapping the data before sending it to the next consumer.
+func (t *Tap) Logs(next consumer.Logs) consumer.Logs {
+	return tapLogs{Logs: next, tap: t}
+}
+
+type tapTraces struct {
+	consumer.Traces
+	tap *Tap
+}
+
+func (tt tapTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	// The data is encoded before being consumed, since the next consumers may mutate it.
+	if tt.tap.active.Load() {
+		tt.tap.publish(
+			func() int { return tracesSizer.TracesSize(td) },
+			func() ([]byte, error) { return tracesMarshaler.MarshalTraces(td) },
+		)
+	}
+	return tt.Traces.ConsumeTraces(ctx, td)
+}
+
+type tapMetrics struct {
+	consumer.Metrics
+	tap *Tap
+}
+
+func (tm tapMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if tm.tap.active.Load() {
+		tm.tap.publish(
+			func() int { return metricsSizer.MetricsSize(md) },
+			func() ([]byte, error) { return metricsMarshaler.MarshalMetrics(md) },
+		)
+	}
+	return tm.Metrics.ConsumeMetrics(ctx, md)
+}
+
+type tapLogs struct {
+	consumer.Logs
+	tap *Tap
+}
+
+func (tl tapLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if tl.tap.active.Load() {
+		tl.tap.publish(
+			func() int { return logsSizer.LogsSize(ld) },
+			func() ([]byte, error) { return logsMarshaler.MarshalLogs(ld) },
+		)
+	}
+	return tl.Logs.ConsumeLogs(ctx, ld)
+}
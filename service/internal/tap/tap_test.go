@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestTapTraces(t *testing.T) {
+	tp := New()
+	sink := new(consumertest.TracesSink)
+	tc := tp.Traces(sink)
+	td := testdata.GenerateTracesOneSpan()
+
+	// Nothing is sent without subscriptions.
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, 1, sink.SpanCount())
+
+	sub := tp.Subscribe(Limits{Interval: time.Hour})
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	buf := <-sub.Data()
+	got, err := ptrace.NewJSONUnmarshaler().UnmarshalTraces(buf)
+	require.NoError(t, err)
+	assert.Equal(t, td, got)
+
+	// The data consumed during the interval is not sent.
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	assert.Len(t, sub.Data(), 0)
+	assert.Zero(t, sub.Dropped())
+	assert.Equal(t, 3, sink.SpanCount())
+
+	tp.Unsubscribe(sub)
+	assert.False(t, tp.active.Load())
+}
+
+func TestTapMetrics(t *testing.T) {
+	tp := New()
+	sink := new(consumertest.MetricsSink)
+	mc := tp.Metrics(sink)
+	md := testdata.GenerateMetricsOneMetric()
+
+	sub := tp.Subscribe(Limits{})
+	require.NoError(t, mc.ConsumeMetrics(context.Background(), md))
+	got, err := pmetric.NewJSONUnmarshaler().UnmarshalMetrics(<-sub.Data())
+	require.NoError(t, err)
+	assert.Equal(t, md, got)
+	assert.Len(t, sink.AllMetrics(), 1)
+}
+
+func TestTapLogs(t *testing.T) {
+	tp := New()
+	sink := new(consumertest.LogsSink)
+	lc := tp.Logs(sink)
+	ld := testdata.GenerateLogsOneLogRecord()
+
+	sub := tp.Subscribe(Limits{})
+	require.NoError(t, lc.ConsumeLogs(context.Background(), ld))
+	got, err := plog.NewJSONUnmarshaler().UnmarshalLogs(<-sub.Data())
+	require.NoError(t, err)
+	assert.Equal(t, ld, got)
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestTapDropped(t *testing.T) {
+	tp := New()
+	tc := tp.Traces(consumertest.NewNop())
+	td := testdata.GenerateTracesOneSpan()
+
+	// Too big.
+	small := tp.Subscribe(Limits{MaxSize: 1})
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	assert.Len(t, small.Data(), 0)
+	assert.EqualValues(t, 1, small.Dropped())
+	tp.Unsubscribe(small)
+
+	// Not consumed in time.
+	slow := tp.Subscribe(Limits{})
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	assert.Len(t, slow.Data(), 1)
+	assert.EqualValues(t, 1, slow.Dropped())
+}
+
+func TestTapTooBigNotMarshaled(t *testing.T) {
+	tp := New()
+	small := tp.Subscribe(Limits{MaxSize: 10})
+	marshaled := 0
+	marshal := func() ([]byte, error) {
+		marshaled++
+		return make([]byte, 5), nil
+	}
+
+	// The data bigger than the limits of all the subscriptions is not marshaled.
+	tp.publish(func() int { return 20 }, marshal)
+	assert.Zero(t, marshaled)
+	assert.EqualValues(t, 1, small.Dropped())
+
+	// The data is marshaled once for all the subscriptions it fits in.
+	big := tp.Subscribe(Limits{})
+	tp.publish(func() int { return 20 }, marshal)
+	assert.Equal(t, 1, marshaled)
+	assert.EqualValues(t, 2, small.Dropped())
+	assert.Len(t, big.Data(), 1)
+}
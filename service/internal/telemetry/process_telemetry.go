@@ -23,15 +23,19 @@ import (
 	"github.com/shirou/gopsutil/v3/process"
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/unit"
 )
 
 // ProcessMetricsViews is a struct that contains views related to process metrics (cpu, mem, etc)
 type ProcessMetricsViews struct {
-	prevTimeUnixNano int64
-	ballastSizeBytes uint64
-	views            []*view.View
-	done             chan struct{}
-	proc             *process.Process
+	startTimeUnixNano int64
+	prevTimeUnixNano  int64
+	getBallastSize    func() uint64
+	views             []*view.View
+	done              chan struct{}
+	proc              *process.Process
 }
 
 var mUptime = stats.Float64(
@@ -107,13 +111,16 @@ var viewRSSMemory = &view.View{
 }
 
 // NewProcessMetricsViews creates a new set of ProcessMetrics (mem, cpu) that can be used to measure
-// basic information about this process.
-func NewProcessMetricsViews(ballastSizeBytes uint64) (*ProcessMetricsViews, error) {
+// basic information about this process. The size of the memory ballast, returned by getBallastSize
+// every time the metrics are updated, is excluded from the heap metrics.
+func NewProcessMetricsViews(getBallastSize func() uint64) (*ProcessMetricsViews, error) {
+	now := time.Now().UnixNano()
 	pmv := &ProcessMetricsViews{
-		prevTimeUnixNano: time.Now().UnixNano(),
-		views:            []*view.View{viewProcessUptime, viewAllocMem, viewTotalAllocMem, viewSysMem, viewCPUSeconds, viewRSSMemory},
-		ballastSizeBytes: ballastSizeBytes,
-		done:             make(chan struct{}),
+		startTimeUnixNano: now,
+		prevTimeUnixNano:  now,
+		views:             []*view.View{viewProcessUptime, viewAllocMem, viewTotalAllocMem, viewSysMem, viewCPUSeconds, viewRSSMemory},
+		getBallastSize:    getBallastSize,
+		done:              make(chan struct{}),
 	}
 
 	pid := os.Getpid()
@@ -127,9 +134,11 @@ func NewProcessMetricsViews(ballastSizeBytes uint64) (*ProcessMetricsViews, erro
 	return pmv, nil
 }
 
-// StartCollection starts a ticker'd goroutine that will update the PMV measurements every 5 seconds
+// StartCollection starts a ticker'd goroutine that will update the PMV measurements immediately and
+// every 5 seconds after
 func (pmv *ProcessMetricsViews) StartCollection() {
 	go func() {
+		pmv.updateViews()
 		ticker := time.NewTicker(5 * time.Second)
 		defer ticker.Stop()
 		for {
@@ -153,6 +162,63 @@ func (pmv *ProcessMetricsViews) StopCollection() {
 	close(pmv.done)
 }
 
+// RegisterInstruments creates OpenTelemetry asynchronous instruments, with the same names as the views,
+// that observe the process metrics every time the meter is collected. It replaces StartCollection
+// when the internal metrics are recorded using OpenTelemetry.
+func (pmv *ProcessMetricsViews) RegisterInstruments(meter metric.Meter) error {
+	uptime, err := meter.AsyncFloat64().Counter(mUptime.Name(),
+		instrument.WithDescription(mUptime.Description()), instrument.WithUnit(unit.Unit(mUptime.Unit())))
+	if err != nil {
+		return err
+	}
+	allocMem, err := meter.AsyncInt64().Gauge(mRuntimeAllocMem.Name(),
+		instrument.WithDescription(mRuntimeAllocMem.Description()), instrument.WithUnit(unit.Bytes))
+	if err != nil {
+		return err
+	}
+	totalAllocMem, err := meter.AsyncInt64().Gauge(mRuntimeTotalAllocMem.Name(),
+		instrument.WithDescription(mRuntimeTotalAllocMem.Description()), instrument.WithUnit(unit.Bytes))
+	if err != nil {
+		return err
+	}
+	sysMem, err := meter.AsyncInt64().Gauge(mRuntimeSysMem.Name(),
+		instrument.WithDescription(mRuntimeSysMem.Description()), instrument.WithUnit(unit.Bytes))
+	if err != nil {
+		return err
+	}
+	cpuSeconds, err := meter.AsyncFloat64().Gauge(mCPUSeconds.Name(),
+		instrument.WithDescription(mCPUSeconds.Description()), instrument.WithUnit(unit.Unit(mCPUSeconds.Unit())))
+	if err != nil {
+		return err
+	}
+	rssMemory, err := meter.AsyncInt64().Gauge(mRSSMemory.Name(),
+		instrument.WithDescription(mRSSMemory.Description()), instrument.WithUnit(unit.Bytes))
+	if err != nil {
+		return err
+	}
+
+	return meter.RegisterCallback(
+		[]instrument.Asynchronous{uptime, allocMem, totalAllocMem, sysMem, cpuSeconds, rssMemory},
+		func(ctx context.Context) {
+			uptime.Observe(ctx, float64(time.Now().UnixNano()-pmv.startTimeUnixNano)/1e9)
+
+			ms := &runtime.MemStats{}
+			pmv.readMemStats(ms)
+			allocMem.Observe(ctx, int64(ms.Alloc))
+			totalAllocMem.Observe(ctx, int64(ms.TotalAlloc))
+			sysMem.Observe(ctx, int64(ms.Sys))
+
+			if pmv.proc != nil {
+				if times, err := pmv.proc.Times(); err == nil {
+					cpuSeconds.Observe(ctx, times.Total())
+				}
+				if mem, err := pmv.proc.MemoryInfo(); err == nil {
+					rssMemory.Observe(ctx, int64(mem.RSS))
+				}
+			}
+		})
+}
+
 func (pmv *ProcessMetricsViews) updateViews() {
 	now := time.Now().UnixNano()
 	stats.Record(context.Background(), mUptime.M(float64(now-pmv.prevTimeUnixNano)/1e9))
@@ -176,10 +242,10 @@ func (pmv *ProcessMetricsViews) updateViews() {
 
 func (pmv *ProcessMetricsViews) readMemStats(ms *runtime.MemStats) {
 	runtime.ReadMemStats(ms)
-	if pmv.ballastSizeBytes > 0 {
-		ms.Alloc -= pmv.ballastSizeBytes
-		ms.HeapAlloc -= pmv.ballastSizeBytes
-		ms.HeapSys -= pmv.ballastSizeBytes
-		ms.HeapInuse -= pmv.ballastSizeBytes
+	if ballastSizeBytes := pmv.getBallastSize(); ballastSizeBytes > 0 {
+		ms.Alloc -= ballastSizeBytes
+		ms.HeapAlloc -= ballastSizeBytes
+		ms.HeapSys -= ballastSizeBytes
+		ms.HeapInuse -= ballastSizeBytes
 	}
 }
@@ -24,8 +24,7 @@ import (
 )
 
 func TestProcessTelemetry(t *testing.T) {
-	const ballastSizeBytes uint64 = 0
-	pmv, err := NewProcessMetricsViews(ballastSizeBytes)
+	pmv, err := NewProcessMetricsViews(func() uint64 { return 0 })
 	require.NoError(t, err)
 	assert.NotNil(t, pmv)
 
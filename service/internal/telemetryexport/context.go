@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryexport // import "go.opentelemetry.io/collector/service/internal/telemetryexport"
+
+import (
+	"context"
+)
+
+type selfExportKey struct{}
+
+// ContextWithSelfExport returns a copy of ctx marked as pushing the collector's own telemetry.
+func ContextWithSelfExport(ctx context.Context) context.Context {
+	return context.WithValue(ctx, selfExportKey{}, true)
+}
+
+// IsSelfExport returns whether ctx is used to push the collector's own telemetry. The telemetry
+// produced under such a context must be dropped, otherwise exporting the collector's own telemetry
+// would produce more telemetry to export, and an idle collector would never stop exporting.
+func IsSelfExport(ctx context.Context) bool {
+	selfExport, _ := ctx.Value(selfExportKey{}).(bool)
+	return selfExport
+}
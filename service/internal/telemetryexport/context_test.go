@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryexport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/internal/telemetrylogs"
+)
+
+func TestIsSelfExport(t *testing.T) {
+	assert.False(t, IsSelfExport(context.Background()))
+	assert.True(t, IsSelfExport(ContextWithSelfExport(context.Background())))
+}
+
+func TestPushedWithSelfExportContext(t *testing.T) {
+	var pushed []bool
+	spanExp := NewSpanExporter(testResource())
+	tc, err := consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error {
+		pushed = append(pushed, IsSelfExport(ctx))
+		return nil
+	})
+	require.NoError(t, err)
+	spanExp.SetConsumer(tc)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(spanExp))
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.End()
+
+	core := NewLogsCore(testResource(), zapcore.InfoLevel)
+	lc, err := consumer.NewLogs(func(ctx context.Context, _ plog.Logs) error {
+		pushed = append(pushed, IsSelfExport(ctx))
+		return nil
+	})
+	require.NoError(t, err)
+	core.SetConsumer(lc)
+	zap.New(core).Info("record")
+	require.NoError(t, core.Flush(context.Background()))
+
+	assert.Equal(t, []bool{true, true}, pushed)
+}
+
+func TestLogsCoreDropsGRPCLogs(t *testing.T) {
+	var pushed int
+	core := NewLogsCore(testResource(), zapcore.InfoLevel)
+	lc, err := consumer.NewLogs(func(_ context.Context, ld plog.Logs) error {
+		pushed += ld.LogRecordCount()
+		return nil
+	})
+	require.NoError(t, err)
+	core.SetConsumer(lc)
+	logger := zap.New(core)
+	logger.With(zap.Bool(telemetrylogs.GRPCLogKey, true)).Warn("connection refused")
+	logger.Info("record")
+	require.NoError(t, core.Flush(context.Background()))
+	assert.Equal(t, 1, pushed)
+}
@@ -0,0 +1,261 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryexport // import "go.opentelemetry.io/collector/service/internal/telemetryexport"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/service/internal/telemetrylogs"
+)
+
+const (
+	// defaultLogsFlushInterval is the interval at which the buffered log records are pushed.
+	defaultLogsFlushInterval = time.Second
+	// maxBufferedLogRecords is the maximum number of log records buffered between two pushes, the
+	// records written when the buffer is full are dropped.
+	maxBufferedLogRecords = 4096
+)
+
+// LogsCore is a zapcore.Core that converts the log entries to plog.Logs and pushes them, in batches,
+// to the consumer set using SetConsumer. Entries are dropped while no consumer is set.
+//
+// Records are pushed asynchronously, so components used by the consumer can safely log using
+// a logger that writes to this core.
+type LogsCore struct {
+	*logsBuffer
+	fields []zapcore.Field
+}
+
+var _ zapcore.Core = (*LogsCore)(nil)
+
+type logsBuffer struct {
+	resource      pcommon.Resource
+	enabler       zapcore.LevelEnabler
+	flushInterval time.Duration
+
+	mu      sync.Mutex
+	next    consumer.Logs
+	records plog.LogRecordSlice
+	stopCh  chan struct{}
+	doneCh  chan struct{}
+}
+
+// NewLogsCore returns a new LogsCore that accepts entries enabled by the given enabler, the exported
+// records are associated with the given resource.
+func NewLogsCore(resource pcommon.Resource, enabler zapcore.LevelEnabler) *LogsCore {
+	return &LogsCore{logsBuffer: &logsBuffer{
+		resource:      resource,
+		enabler:       enabler,
+		flushInterval: defaultLogsFlushInterval,
+		records:       plog.NewLogRecordSlice(),
+	}}
+}
+
+// SetConsumer sets the consumer where the records are pushed, nil stops pushing records.
+func (lc *LogsCore) SetConsumer(next consumer.Logs) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.next = next
+	if next == nil {
+		lc.records = plog.NewLogRecordSlice()
+	}
+}
+
+// Start starts pushing the buffered records periodically.
+func (lc *LogsCore) Start() {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	if lc.stopCh != nil {
+		return
+	}
+	lc.stopCh = make(chan struct{})
+	lc.doneCh = make(chan struct{})
+	go lc.run(lc.stopCh, lc.doneCh)
+}
+
+// Shutdown stops the periodic pushes and pushes the records buffered so far.
+func (lc *LogsCore) Shutdown(ctx context.Context) error {
+	lc.mu.Lock()
+	stopCh, doneCh := lc.stopCh, lc.doneCh
+	lc.stopCh, lc.doneCh = nil, nil
+	lc.mu.Unlock()
+	if stopCh == nil {
+		return nil
+	}
+	close(stopCh)
+	<-doneCh
+	return lc.Flush(ctx)
+}
+
+// Flush pushes the records buffered so far.
+func (lc *LogsCore) Flush(ctx context.Context) error {
+	return lc.flush(ctx)
+}
+
+func (lb *logsBuffer) run(stopCh, doneCh chan struct{}) {
+	defer close(doneCh)
+	ticker := time.NewTicker(lb.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// Errors are ignored, they cannot be logged without creating new records.
+			_ = lb.flush(context.Background())
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+func (lb *logsBuffer) flush(ctx context.Context) error {
+	lb.mu.Lock()
+	next, records := lb.next, lb.records
+	lb.records = plog.NewLogRecordSlice()
+	lb.mu.Unlock()
+
+	if next == nil || records.Len() == 0 {
+		return nil
+	}
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	lb.resource.CopyTo(rl.Resource())
+	records.MoveAndAppendTo(rl.ScopeLogs().AppendEmpty().LogRecords())
+	return next.ConsumeLogs(ContextWithSelfExport(ctx), ld)
+}
+
+// Enabled implements zapcore.LevelEnabler.
+func (lc *LogsCore) Enabled(level zapcore.Level) bool {
+	return lc.enabler.Enabled(level)
+}
+
+// With implements zapcore.Core. The logs of the gRPC framework are not pushed: they cannot be attributed
+// to a context, and include the logs of the connections of the exporters pushing the collector's own
+// telemetry, so pushing them would produce more logs to push.
+func (lc *LogsCore) With(fields []zapcore.Field) zapcore.Core {
+	for _, f := range fields {
+		if f.Key == telemetrylogs.GRPCLogKey {
+			return zapcore.NewNopCore()
+		}
+	}
+	all := make([]zapcore.Field, 0, len(lc.fields)+len(fields))
+	all = append(all, lc.fields...)
+	all = append(all, fields...)
+	return &LogsCore{logsBuffer: lc.logsBuffer, fields: all}
+}
+
+// Check implements zapcore.Core.
+func (lc *LogsCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if lc.Enabled(entry.Level) {
+		return ce.AddCore(entry, lc)
+	}
+	return ce
+}
+
+// Write implements zapcore.Core.
+func (lc *LogsCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	if lc.next == nil || lc.records.Len() >= maxBufferedLogRecords {
+		return nil
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range lc.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	lr := lc.records.AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(entry.Time))
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+	lr.SetSeverityNumber(severityNumber(entry.Level))
+	lr.SetSeverityText(entry.Level.CapitalString())
+	lr.Body().SetStringVal(entry.Message)
+
+	raw := make(map[string]interface{}, len(enc.Fields))
+	for k, v := range enc.Fields {
+		raw[k] = rawValue(v)
+	}
+	attrs := lr.Attributes()
+	pcommon.NewMapFromRaw(raw).CopyTo(attrs)
+	if entry.LoggerName != "" {
+		attrs.UpsertString("logger", entry.LoggerName)
+	}
+	if entry.Caller.Defined {
+		attrs.UpsertString("caller", entry.Caller.TrimmedPath())
+	}
+	if entry.Stack != "" {
+		attrs.UpsertString("stacktrace", entry.Stack)
+	}
+	return nil
+}
+
+// Sync implements zapcore.Core.
+func (lc *LogsCore) Sync() error {
+	return nil
+}
+
+func severityNumber(level zapcore.Level) plog.SeverityNumber {
+	switch level {
+	case zapcore.DebugLevel:
+		return plog.SeverityNumberDEBUG
+	case zapcore.InfoLevel:
+		return plog.SeverityNumberINFO
+	case zapcore.WarnLevel:
+		return plog.SeverityNumberWARN
+	case zapcore.ErrorLevel:
+		return plog.SeverityNumberERROR
+	case zapcore.DPanicLevel, zapcore.PanicLevel:
+		return plog.SeverityNumberFATAL
+	case zapcore.FatalLevel:
+		return plog.SeverityNumberFATAL4
+	}
+	return plog.SeverityNumberUNDEFINED
+}
+
+// rawValue converts the values produced by zapcore.MapObjectEncoder to the types supported by pcommon.
+func rawValue(v interface{}) interface{} {
+	switch tv := v.(type) {
+	case nil, string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, []byte:
+		return tv
+	case time.Duration:
+		return tv.String()
+	case time.Time:
+		return tv.Format(time.RFC3339Nano)
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(tv))
+		for k, e := range tv {
+			m[k] = rawValue(e)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(tv))
+		for i, e := range tv {
+			s[i] = rawValue(e)
+		}
+		return s
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryexport
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestLogsCore(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	core := NewLogsCore(testResource(), zapcore.InfoLevel)
+	logger := zap.New(core).Named("exporter").With(zap.String("kind", "exporter"))
+
+	// Records are dropped while no consumer is set.
+	logger.Info("dropped")
+	require.NoError(t, core.Flush(context.Background()))
+	assert.Equal(t, 0, sink.LogRecordCount())
+
+	core.SetConsumer(sink)
+	logger.Debug("disabled")
+	logger.Warn("Exporting failed", zap.Error(errors.New("connection refused")), zap.Duration("interval", time.Second), zap.Int("items", 3))
+	assert.Equal(t, 0, sink.LogRecordCount())
+
+	require.NoError(t, core.Flush(context.Background()))
+	require.Len(t, sink.AllLogs(), 1)
+
+	rl := sink.AllLogs()[0].ResourceLogs().At(0)
+	assert.Equal(t, testResource(), rl.Resource())
+	records := rl.ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 1, records.Len())
+	lr := records.At(0)
+	assert.Equal(t, "Exporting failed", lr.Body().StringVal())
+	assert.Equal(t, plog.SeverityNumberWARN, lr.SeverityNumber())
+	assert.Equal(t, "WARN", lr.SeverityText())
+	assert.NotZero(t, lr.Timestamp())
+	assert.Equal(t, map[string]interface{}{
+		"kind":     "exporter",
+		"error":    "connection refused",
+		"interval": "1s",
+		"items":    int64(3),
+		"logger":   "exporter",
+	}, lr.Attributes().AsRaw())
+}
+
+func TestLogsCoreStartShutdown(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	core := NewLogsCore(testResource(), zapcore.InfoLevel)
+	core.flushInterval = time.Millisecond
+	core.SetConsumer(sink)
+	core.Start()
+	logger := zap.New(core)
+
+	logger.Info("first")
+	assert.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 5*time.Second, time.Millisecond)
+
+	logger.Info("second")
+	require.NoError(t, core.Shutdown(context.Background()))
+	assert.Equal(t, 2, sink.LogRecordCount())
+	require.NoError(t, core.Shutdown(context.Background()))
+}
@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryexport // import "go.opentelemetry.io/collector/service/internal/telemetryexport"
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/metric/metricexport"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// metricsNamespace is the prefix added to all metric names, the same used for the metrics exposed
+// on the Prometheus endpoint.
+const metricsNamespace = "otelcol"
+
+// MetricsExporter is a metricexport.Exporter that converts the OpenCensus metrics to pmetric.Metrics
+// and pushes them to the consumer set using SetConsumer. Metrics are dropped while no consumer is set.
+type MetricsExporter struct {
+	resource pcommon.Resource
+
+	mu   sync.RWMutex
+	next consumer.Metrics
+}
+
+var _ metricexport.Exporter = (*MetricsExporter)(nil)
+
+// NewMetricsExporter returns a new MetricsExporter, the exported metrics are associated with the given resource.
+func NewMetricsExporter(resource pcommon.Resource) *MetricsExporter {
+	return &MetricsExporter{resource: resource}
+}
+
+// SetConsumer sets the consumer where the metrics are pushed, nil stops pushing metrics.
+func (me *MetricsExporter) SetConsumer(next consumer.Metrics) {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	me.next = next
+}
+
+// ExportMetrics implements metricexport.Exporter.
+func (me *MetricsExporter) ExportMetrics(ctx context.Context, metrics []*metricdata.Metric) error {
+	me.mu.RLock()
+	defer me.mu.RUnlock()
+	if me.next == nil || len(metrics) == 0 {
+		return nil
+	}
+	return me.next.ConsumeMetrics(ContextWithSelfExport(ctx), metricsToPdata(metrics, me.resource))
+}
+
+func metricsToPdata(metrics []*metricdata.Metric, resource pcommon.Resource) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	resource.CopyTo(rm.Resource())
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(metricsNamespace)
+
+	dest := sm.Metrics()
+	dest.EnsureCapacity(len(metrics))
+	for _, metric := range metrics {
+		if metric == nil {
+			continue
+		}
+		metricToPdata(metric, dest)
+	}
+	return md
+}
+
+func metricToPdata(metric *metricdata.Metric, dest pmetric.MetricSlice) {
+	desc := metric.Descriptor
+	m := pmetric.NewMetric()
+	m.SetName(metricsNamespace + "_" + sanitizeName(desc.Name))
+	m.SetDescription(desc.Description)
+	m.SetUnit(string(desc.Unit))
+
+	switch desc.Type {
+	case metricdata.TypeGaugeInt64, metricdata.TypeGaugeFloat64:
+		m.SetDataType(pmetric.MetricDataTypeGauge)
+		fillNumberDataPoints(metric, m.Gauge().DataPoints())
+	case metricdata.TypeCumulativeInt64, metricdata.TypeCumulativeFloat64:
+		m.SetDataType(pmetric.MetricDataTypeSum)
+		m.Sum().SetIsMonotonic(true)
+		m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		fillNumberDataPoints(metric, m.Sum().DataPoints())
+	case metricdata.TypeCumulativeDistribution:
+		m.SetDataType(pmetric.MetricDataTypeHistogram)
+		m.Histogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		fillHistogramDataPoints(metric, m.Histogram().DataPoints())
+	default:
+		// Gauge distributions and summaries are not produced by the collector's own views.
+		return
+	}
+	m.MoveTo(dest.AppendEmpty())
+}
+
+func fillNumberDataPoints(metric *metricdata.Metric, dest pmetric.NumberDataPointSlice) {
+	for _, ts := range metric.TimeSeries {
+		for _, point := range ts.Points {
+			dp := dest.AppendEmpty()
+			fillLabels(metric.Descriptor.LabelKeys, ts.LabelValues, dp.Attributes())
+			setTimestamps(ts.StartTime, point.Time, dp.SetStartTimestamp, dp.SetTimestamp)
+			switch v := point.Value.(type) {
+			case int64:
+				dp.SetIntVal(v)
+			case float64:
+				dp.SetDoubleVal(v)
+			}
+		}
+	}
+}
+
+func fillHistogramDataPoints(metric *metricdata.Metric, dest pmetric.HistogramDataPointSlice) {
+	for _, ts := range metric.TimeSeries {
+		for _, point := range ts.Points {
+			dist, ok := point.Value.(*metricdata.Distribution)
+			if !ok {
+				continue
+			}
+			dp := dest.AppendEmpty()
+			fillLabels(metric.Descriptor.LabelKeys, ts.LabelValues, dp.Attributes())
+			setTimestamps(ts.StartTime, point.Time, dp.SetStartTimestamp, dp.SetTimestamp)
+			dp.SetCount(uint64(dist.Count))
+			dp.SetSum(dist.Sum)
+			if dist.BucketOptions != nil {
+				dp.SetExplicitBounds(dist.BucketOptions.Bounds)
+			}
+			counts := make([]uint64, len(dist.Buckets))
+			for i, bucket := range dist.Buckets {
+				counts[i] = uint64(bucket.Count)
+			}
+			dp.SetBucketCounts(counts)
+		}
+	}
+}
+
+func fillLabels(keys []metricdata.LabelKey, values []metricdata.LabelValue, dest pcommon.Map) {
+	dest.EnsureCapacity(len(keys))
+	for i, key := range keys {
+		if i >= len(values) || !values[i].Present {
+			continue
+		}
+		dest.UpsertString(key.Key, values[i].Value)
+	}
+}
+
+func setTimestamps(start, ts time.Time, setStart, setTs func(pcommon.Timestamp)) {
+	if !start.IsZero() {
+		setStart(pcommon.NewTimestampFromTime(start))
+	}
+	setTs(pcommon.NewTimestampFromTime(ts))
+}
+
+// sanitizeName replaces all the characters that are not valid in a Prometheus metric name with "_",
+// so that the pushed metrics have the same names as the ones exposed on the Prometheus endpoint.
+func sanitizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) || unicode.IsLetter(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryexport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestMetricsExporter(t *testing.T) {
+	start := time.Unix(100, 0)
+	now := time.Unix(200, 0)
+	labelKeys := []metricdata.LabelKey{{Key: "receiver"}, {Key: "transport"}}
+	labelValues := []metricdata.LabelValue{metricdata.NewLabelValue("otlp"), {}}
+	metrics := []*metricdata.Metric{
+		{
+			Descriptor: metricdata.Descriptor{Name: "receiver/accepted_spans", Description: "Accepted spans", Unit: metricdata.UnitDimensionless, Type: metricdata.TypeCumulativeInt64, LabelKeys: labelKeys},
+			TimeSeries: []*metricdata.TimeSeries{{LabelValues: labelValues, StartTime: start, Points: []metricdata.Point{metricdata.NewInt64Point(now, 10)}}},
+		},
+		{
+			Descriptor: metricdata.Descriptor{Name: "process/memory/rss", Type: metricdata.TypeGaugeFloat64},
+			TimeSeries: []*metricdata.TimeSeries{{Points: []metricdata.Point{metricdata.NewFloat64Point(now, 1.5)}}},
+		},
+		{
+			Descriptor: metricdata.Descriptor{Name: "processor/batch/batch_send_size", Type: metricdata.TypeCumulativeDistribution},
+			TimeSeries: []*metricdata.TimeSeries{{StartTime: start, Points: []metricdata.Point{metricdata.NewDistributionPoint(now, &metricdata.Distribution{
+				Count:         3,
+				Sum:           30,
+				BucketOptions: &metricdata.BucketOptions{Bounds: []float64{5, 15}},
+				Buckets:       []metricdata.Bucket{{Count: 1}, {Count: 1}, {Count: 1}},
+			})}}},
+		},
+		{
+			Descriptor: metricdata.Descriptor{Name: "summary", Type: metricdata.TypeSummary},
+		},
+	}
+
+	sink := new(consumertest.MetricsSink)
+	exp := NewMetricsExporter(testResource())
+
+	// Metrics are dropped while no consumer is set.
+	require.NoError(t, exp.ExportMetrics(context.Background(), metrics))
+	assert.Equal(t, 0, sink.DataPointCount())
+
+	exp.SetConsumer(sink)
+	require.NoError(t, exp.ExportMetrics(context.Background(), metrics))
+	require.Len(t, sink.AllMetrics(), 1)
+
+	rm := sink.AllMetrics()[0].ResourceMetrics().At(0)
+	assert.Equal(t, testResource(), rm.Resource())
+	ms := rm.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 3, ms.Len())
+
+	sum := ms.At(0)
+	assert.Equal(t, "otelcol_receiver_accepted_spans", sum.Name())
+	assert.Equal(t, "Accepted spans", sum.Description())
+	assert.Equal(t, "1", sum.Unit())
+	assert.Equal(t, pmetric.MetricDataTypeSum, sum.DataType())
+	assert.True(t, sum.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, sum.Sum().AggregationTemporality())
+	sdp := sum.Sum().DataPoints().At(0)
+	assert.Equal(t, int64(10), sdp.IntVal())
+	assert.Equal(t, pcommon.NewTimestampFromTime(start), sdp.StartTimestamp())
+	assert.Equal(t, pcommon.NewTimestampFromTime(now), sdp.Timestamp())
+	assert.Equal(t, map[string]interface{}{"receiver": "otlp"}, sdp.Attributes().AsRaw())
+
+	gauge := ms.At(1)
+	assert.Equal(t, "otelcol_process_memory_rss", gauge.Name())
+	assert.Equal(t, pmetric.MetricDataTypeGauge, gauge.DataType())
+	assert.Equal(t, 1.5, gauge.Gauge().DataPoints().At(0).DoubleVal())
+	assert.Equal(t, pcommon.Timestamp(0), gauge.Gauge().DataPoints().At(0).StartTimestamp())
+
+	hist := ms.At(2)
+	assert.Equal(t, "otelcol_processor_batch_batch_send_size", hist.Name())
+	assert.Equal(t, pmetric.MetricDataTypeHistogram, hist.DataType())
+	hdp := hist.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(3), hdp.Count())
+	assert.Equal(t, 30.0, hdp.Sum())
+	assert.Equal(t, []float64{5, 15}, hdp.ExplicitBounds())
+	assert.Equal(t, []uint64{1, 1, 1}, hdp.BucketCounts())
+
+	exp.SetConsumer(nil)
+	require.NoError(t, exp.ExportMetrics(context.Background(), metrics))
+	assert.Len(t, sink.AllMetrics(), 1)
+}
@@ -0,0 +1,216 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryexport // import "go.opentelemetry.io/collector/service/internal/telemetryexport"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	"go.opentelemetry.io/otel/sdk/metric/export"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/number"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// OpenTelemetryReader periodically collects the metrics of an OpenTelemetry SDK controller and pushes them
+// using a MetricsExporter, like metricexport.IntervalReader does for the OpenCensus metrics.
+//
+// The controller is collected on demand, so it can also be collected by a pull exporter, e.g. Prometheus.
+type OpenTelemetryReader struct {
+	controller *controller.Controller
+	exporter   *MetricsExporter
+	interval   time.Duration
+
+	mu     sync.Mutex
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// NewOpenTelemetryReader returns a new OpenTelemetryReader pushing the metrics of c to exporter every interval.
+func NewOpenTelemetryReader(c *controller.Controller, exporter *MetricsExporter, interval time.Duration) *OpenTelemetryReader {
+	return &OpenTelemetryReader{controller: c, exporter: exporter, interval: interval}
+}
+
+// Start starts pushing the metrics periodically.
+func (r *OpenTelemetryReader) Start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopCh != nil {
+		return
+	}
+	r.stopCh = make(chan struct{})
+	r.doneCh = make(chan struct{})
+	go r.run(r.stopCh, r.doneCh)
+}
+
+// Stop stops the periodic pushes.
+func (r *OpenTelemetryReader) Stop() {
+	r.mu.Lock()
+	stopCh, doneCh := r.stopCh, r.doneCh
+	r.stopCh, r.doneCh = nil, nil
+	r.mu.Unlock()
+	if stopCh == nil {
+		return
+	}
+	close(stopCh)
+	<-doneCh
+}
+
+// Push collects the metrics of the controller and pushes them.
+func (r *OpenTelemetryReader) Push(ctx context.Context) error {
+	if err := r.controller.Collect(ctx); err != nil {
+		return err
+	}
+	return r.exporter.exportOpenTelemetry(ctx, r.controller)
+}
+
+func (r *OpenTelemetryReader) run(stopCh, doneCh chan struct{}) {
+	defer close(doneCh)
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// Errors are ignored, as the ones of the OpenCensus reader.
+			_ = r.Push(context.Background())
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// exportOpenTelemetry converts the metrics of the reader to pmetric.Metrics and pushes them to the consumer.
+func (me *MetricsExporter) exportOpenTelemetry(ctx context.Context, reader export.InstrumentationLibraryReader) error {
+	me.mu.RLock()
+	defer me.mu.RUnlock()
+	if me.next == nil {
+		return nil
+	}
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	me.resource.CopyTo(rm.Resource())
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(metricsNamespace)
+	metrics := map[string]pmetric.Metric{}
+	err := reader.ForEach(func(_ instrumentation.Library, r export.Reader) error {
+		return r.ForEach(aggregation.CumulativeTemporalitySelector(), func(record export.Record) error {
+			recordToPdata(record, metrics, sm.Metrics())
+			return nil
+		})
+	})
+	if err != nil || sm.Metrics().Len() == 0 {
+		return err
+	}
+	return me.next.ConsumeMetrics(ContextWithSelfExport(ctx), md)
+}
+
+// recordToPdata adds the data point of the record to the metric with the same name in metrics, or to a
+// new metric appended to dest.
+func recordToPdata(record export.Record, metrics map[string]pmetric.Metric, dest pmetric.MetricSlice) {
+	desc := record.Descriptor()
+	name := metricsNamespace + "_" + sanitizeName(desc.Name())
+	m, ok := metrics[name]
+	if !ok {
+		m = pmetric.NewMetric()
+		m.SetName(name)
+		m.SetDescription(desc.Description())
+		m.SetUnit(string(desc.Unit()))
+	}
+
+	switch agg := record.Aggregation().(type) {
+	case aggregation.Histogram:
+		if !ok {
+			m.SetDataType(pmetric.MetricDataTypeHistogram)
+			m.Histogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		}
+		count, err := agg.Count()
+		if err != nil {
+			return
+		}
+		sum, err := agg.Sum()
+		if err != nil {
+			return
+		}
+		buckets, err := agg.Histogram()
+		if err != nil {
+			return
+		}
+		dp := m.Histogram().DataPoints().AppendEmpty()
+		fillAttributes(record, dp.Attributes())
+		setTimestamps(record.StartTime(), record.EndTime(), dp.SetStartTimestamp, dp.SetTimestamp)
+		dp.SetCount(count)
+		dp.SetSum(sum.CoerceToFloat64(desc.NumberKind()))
+		dp.SetExplicitBounds(buckets.Boundaries)
+		dp.SetBucketCounts(buckets.Counts)
+	case aggregation.LastValue:
+		if !ok {
+			m.SetDataType(pmetric.MetricDataTypeGauge)
+		}
+		value, ts, err := agg.LastValue()
+		if err != nil {
+			return
+		}
+		dp := m.Gauge().DataPoints().AppendEmpty()
+		fillAttributes(record, dp.Attributes())
+		setTimestamps(time.Time{}, ts, dp.SetStartTimestamp, dp.SetTimestamp)
+		setNumber(value, desc.NumberKind(), dp)
+	case aggregation.Sum:
+		if !ok {
+			m.SetDataType(pmetric.MetricDataTypeSum)
+			m.Sum().SetIsMonotonic(desc.InstrumentKind().Monotonic())
+			m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		}
+		value, err := agg.Sum()
+		if err != nil {
+			return
+		}
+		dp := m.Sum().DataPoints().AppendEmpty()
+		fillAttributes(record, dp.Attributes())
+		setTimestamps(record.StartTime(), record.EndTime(), dp.SetStartTimestamp, dp.SetTimestamp)
+		setNumber(value, desc.NumberKind(), dp)
+	default:
+		// Other aggregations are not selected for the collector's own instruments.
+		return
+	}
+
+	if !ok {
+		m.MoveTo(dest.AppendEmpty())
+		metrics[name] = dest.At(dest.Len() - 1)
+	}
+}
+
+func fillAttributes(record export.Record, dest pcommon.Map) {
+	attrs := record.Attributes()
+	dest.EnsureCapacity(attrs.Len())
+	iter := attrs.Iter()
+	for iter.Next() {
+		kv := iter.Attribute()
+		dest.UpsertString(string(kv.Key), kv.Value.Emit())
+	}
+}
+
+func setNumber(value number.Number, kind number.Kind, dp pmetric.NumberDataPoint) {
+	if kind == number.Int64Kind {
+		dp.SetIntVal(value.AsInt64())
+		return
+	}
+	dp.SetDoubleVal(value.CoerceToFloat64(kind))
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryexport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestOpenTelemetryReader(t *testing.T) {
+	c := controller.New(
+		processor.NewFactory(
+			simple.NewWithHistogramDistribution(histogram.WithExplicitBoundaries([]float64{5, 15})),
+			aggregation.CumulativeTemporalitySelector(),
+			processor.WithMemory(true),
+		),
+		controller.WithCollectPeriod(0),
+	)
+	meter := c.Meter("go.opentelemetry.io/collector/obsreport")
+	ctx := context.Background()
+
+	accepted, err := meter.SyncInt64().Counter("receiver/accepted_spans", instrument.WithDescription("Accepted spans"))
+	require.NoError(t, err)
+	accepted.Add(ctx, 10, attribute.String("receiver", "otlp"))
+	accepted.Add(ctx, 5, attribute.String("receiver", "jaeger"))
+	queue, err := meter.SyncInt64().UpDownCounter("exporter/queue_size")
+	require.NoError(t, err)
+	queue.Add(ctx, -2)
+	sizes, err := meter.SyncFloat64().Histogram("processor/batch/batch_send_size")
+	require.NoError(t, err)
+	for _, v := range []float64{1, 10, 20} {
+		sizes.Record(ctx, v)
+	}
+	rss, err := meter.AsyncFloat64().Gauge("process/memory/rss")
+	require.NoError(t, err)
+	require.NoError(t, meter.RegisterCallback([]instrument.Asynchronous{rss}, func(ctx context.Context) {
+		rss.Observe(ctx, 1.5)
+	}))
+
+	sink := new(consumertest.MetricsSink)
+	exp := NewMetricsExporter(testResource())
+	reader := NewOpenTelemetryReader(c, exp, time.Millisecond)
+
+	// Metrics are dropped while no consumer is set.
+	require.NoError(t, reader.Push(ctx))
+	assert.Len(t, sink.AllMetrics(), 0)
+
+	exp.SetConsumer(sink)
+	require.NoError(t, reader.Push(ctx))
+	require.Len(t, sink.AllMetrics(), 1)
+	rm := sink.AllMetrics()[0].ResourceMetrics().At(0)
+	assert.Equal(t, testResource(), rm.Resource())
+	sm := rm.ScopeMetrics().At(0)
+	assert.Equal(t, metricsNamespace, sm.Scope().Name())
+	metrics := map[string]pmetric.Metric{}
+	for i := 0; i < sm.Metrics().Len(); i++ {
+		metrics[sm.Metrics().At(i).Name()] = sm.Metrics().At(i)
+	}
+	require.Len(t, metrics, 4)
+
+	m := metrics["otelcol_receiver_accepted_spans"]
+	assert.Equal(t, "Accepted spans", m.Description())
+	require.Equal(t, pmetric.MetricDataTypeSum, m.DataType())
+	assert.True(t, m.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+	byReceiver := map[string]int64{}
+	for i := 0; i < m.Sum().DataPoints().Len(); i++ {
+		dp := m.Sum().DataPoints().At(i)
+		receiver, _ := dp.Attributes().Get("receiver")
+		byReceiver[receiver.StringVal()] = dp.IntVal()
+		assert.NotZero(t, dp.StartTimestamp())
+	}
+	assert.Equal(t, map[string]int64{"otlp": 10, "jaeger": 5}, byReceiver)
+
+	m = metrics["otelcol_exporter_queue_size"]
+	require.Equal(t, pmetric.MetricDataTypeSum, m.DataType())
+	assert.False(t, m.Sum().IsMonotonic())
+	assert.Equal(t, int64(-2), m.Sum().DataPoints().At(0).IntVal())
+
+	m = metrics["otelcol_processor_batch_batch_send_size"]
+	require.Equal(t, pmetric.MetricDataTypeHistogram, m.DataType())
+	hdp := m.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(3), hdp.Count())
+	assert.Equal(t, 31.0, hdp.Sum())
+	assert.Equal(t, []float64{5, 15}, hdp.ExplicitBounds())
+	assert.Equal(t, []uint64{1, 1, 1}, hdp.BucketCounts())
+
+	m = metrics["otelcol_process_memory_rss"]
+	require.Equal(t, pmetric.MetricDataTypeGauge, m.DataType())
+	assert.Equal(t, 1.5, m.Gauge().DataPoints().At(0).DoubleVal())
+
+	// The metrics are pushed periodically once started.
+	reader.Start()
+	assert.Eventually(t, func() bool { return len(sink.AllMetrics()) > 1 }, 5*time.Second, time.Millisecond)
+	reader.Stop()
+	require.NoError(t, c.Stop(ctx))
+}
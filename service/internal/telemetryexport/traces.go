@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryexport // import "go.opentelemetry.io/collector/service/internal/telemetryexport"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// SpanExporter is a sdktrace.SpanExporter that converts the spans to ptrace.Traces and pushes them
+// to the consumer set using SetConsumer. Spans are dropped while no consumer is set.
+type SpanExporter struct {
+	resource pcommon.Resource
+
+	mu   sync.RWMutex
+	next consumer.Traces
+}
+
+var _ sdktrace.SpanExporter = (*SpanExporter)(nil)
+
+// NewSpanExporter returns a new SpanExporter, the exported spans are associated with the given resource.
+func NewSpanExporter(resource pcommon.Resource) *SpanExporter {
+	return &SpanExporter{resource: resource}
+}
+
+// SetConsumer sets the consumer where the spans are pushed, nil stops pushing spans.
+func (se *SpanExporter) SetConsumer(next consumer.Traces) {
+	se.mu.Lock()
+	defer se.mu.Unlock()
+	se.next = next
+}
+
+// ExportSpans implements sdktrace.SpanExporter.
+func (se *SpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	se.mu.RLock()
+	defer se.mu.RUnlock()
+	if se.next == nil || len(spans) == 0 {
+		return nil
+	}
+	return se.next.ConsumeTraces(ContextWithSelfExport(ctx), spansToTraces(spans, se.resource))
+}
+
+// Shutdown implements sdktrace.SpanExporter.
+func (se *SpanExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func spansToTraces(spans []sdktrace.ReadOnlySpan, resource pcommon.Resource) ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	resource.CopyTo(rs.Resource())
+
+	scopes := map[string]ptrace.ScopeSpans{}
+	for _, span := range spans {
+		lib := span.InstrumentationLibrary()
+		ss, ok := scopes[lib.Name+"/"+lib.Version]
+		if !ok {
+			ss = rs.ScopeSpans().AppendEmpty()
+			ss.Scope().SetName(lib.Name)
+			ss.Scope().SetVersion(lib.Version)
+			scopes[lib.Name+"/"+lib.Version] = ss
+		}
+		spanToPdata(span, ss.Spans().AppendEmpty())
+	}
+	return td
+}
+
+func spanToPdata(span sdktrace.ReadOnlySpan, dest ptrace.Span) {
+	sc := span.SpanContext()
+	dest.SetTraceID(pcommon.NewTraceID(sc.TraceID()))
+	dest.SetSpanID(pcommon.NewSpanID(sc.SpanID()))
+	dest.SetTraceState(ptrace.TraceState(sc.TraceState().String()))
+	if span.Parent().HasSpanID() {
+		dest.SetParentSpanID(pcommon.NewSpanID(span.Parent().SpanID()))
+	}
+	dest.SetName(span.Name())
+	dest.SetKind(spanKind(span.SpanKind()))
+	dest.SetStartTimestamp(pcommon.NewTimestampFromTime(span.StartTime()))
+	dest.SetEndTimestamp(pcommon.NewTimestampFromTime(span.EndTime()))
+	attributesToMap(span.Attributes(), dest.Attributes())
+	dest.SetDroppedAttributesCount(uint32(span.DroppedAttributes()))
+
+	for _, ev := range span.Events() {
+		event := dest.Events().AppendEmpty()
+		event.SetName(ev.Name)
+		event.SetTimestamp(pcommon.NewTimestampFromTime(ev.Time))
+		attributesToMap(ev.Attributes, event.Attributes())
+		event.SetDroppedAttributesCount(uint32(ev.DroppedAttributeCount))
+	}
+	dest.SetDroppedEventsCount(uint32(span.DroppedEvents()))
+
+	for _, l := range span.Links() {
+		link := dest.Links().AppendEmpty()
+		link.SetTraceID(pcommon.NewTraceID(l.SpanContext.TraceID()))
+		link.SetSpanID(pcommon.NewSpanID(l.SpanContext.SpanID()))
+		link.SetTraceState(ptrace.TraceState(l.SpanContext.TraceState().String()))
+		attributesToMap(l.Attributes, link.Attributes())
+		link.SetDroppedAttributesCount(uint32(l.DroppedAttributeCount))
+	}
+	dest.SetDroppedLinksCount(uint32(span.DroppedLinks()))
+
+	switch span.Status().Code {
+	case codes.Ok:
+		dest.Status().SetCode(ptrace.StatusCodeOk)
+	case codes.Error:
+		dest.Status().SetCode(ptrace.StatusCodeError)
+	}
+	dest.Status().SetMessage(span.Status().Description)
+}
+
+func spanKind(kind trace.SpanKind) ptrace.SpanKind {
+	switch kind {
+	case trace.SpanKindInternal:
+		return ptrace.SpanKindInternal
+	case trace.SpanKindServer:
+		return ptrace.SpanKindServer
+	case trace.SpanKindClient:
+		return ptrace.SpanKindClient
+	case trace.SpanKindProducer:
+		return ptrace.SpanKindProducer
+	case trace.SpanKindConsumer:
+		return ptrace.SpanKindConsumer
+	}
+	return ptrace.SpanKindUnspecified
+}
+
+func attributesToMap(attrs []attribute.KeyValue, dest pcommon.Map) {
+	dest.EnsureCapacity(len(attrs))
+	for _, kv := range attrs {
+		key := string(kv.Key)
+		switch kv.Value.Type() {
+		case attribute.BOOL:
+			dest.UpsertBool(key, kv.Value.AsBool())
+		case attribute.INT64:
+			dest.UpsertInt(key, kv.Value.AsInt64())
+		case attribute.FLOAT64:
+			dest.UpsertDouble(key, kv.Value.AsFloat64())
+		case attribute.BOOLSLICE:
+			av := pcommon.NewValueSlice()
+			for _, b := range kv.Value.AsBoolSlice() {
+				av.SliceVal().AppendEmpty().SetBoolVal(b)
+			}
+			dest.Upsert(key, av)
+		case attribute.INT64SLICE:
+			av := pcommon.NewValueSlice()
+			for _, i := range kv.Value.AsInt64Slice() {
+				av.SliceVal().AppendEmpty().SetIntVal(i)
+			}
+			dest.Upsert(key, av)
+		case attribute.FLOAT64SLICE:
+			av := pcommon.NewValueSlice()
+			for _, f := range kv.Value.AsFloat64Slice() {
+				av.SliceVal().AppendEmpty().SetDoubleVal(f)
+			}
+			dest.Upsert(key, av)
+		case attribute.STRINGSLICE:
+			av := pcommon.NewValueSlice()
+			for _, str := range kv.Value.AsStringSlice() {
+				av.SliceVal().AppendEmpty().SetStringVal(str)
+			}
+			dest.Upsert(key, av)
+		default:
+			dest.UpsertString(key, kv.Value.Emit())
+		}
+	}
+}
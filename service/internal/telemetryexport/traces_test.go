@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryexport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func testResource() pcommon.Resource {
+	res := pcommon.NewResource()
+	res.Attributes().UpsertString("service.name", "otelcol")
+	return res
+}
+
+func TestSpanExporter(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	exp := NewSpanExporter(testResource())
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	tracer := tp.Tracer("go.opentelemetry.io/collector/receiver/otlpreceiver")
+
+	// Spans are dropped while no consumer is set.
+	_, span := tracer.Start(context.Background(), "dropped")
+	span.End()
+	assert.Equal(t, 0, sink.SpanCount())
+
+	exp.SetConsumer(sink)
+	ctx, parent := tracer.Start(context.Background(), "parent", trace.WithSpanKind(trace.SpanKindServer))
+	_, child := tracer.Start(ctx, "child",
+		trace.WithAttributes(attribute.String("str", "val"), attribute.Int64("int", 1), attribute.Bool("bool", true), attribute.Float64("double", 1.5)),
+		trace.WithLinks(trace.Link{SpanContext: parent.SpanContext(), Attributes: []attribute.KeyValue{attribute.String("link", "val")}}))
+	child.AddEvent("event", trace.WithAttributes(attribute.StringSlice("slice", []string{"a", "b"})))
+	child.SetStatus(codes.Error, "failed")
+	child.End()
+	parent.End()
+	require.NoError(t, tp.Shutdown(context.Background()))
+
+	require.Len(t, sink.AllTraces(), 2)
+
+	td := sink.AllTraces()[0]
+	rs := td.ResourceSpans().At(0)
+	assert.Equal(t, testResource(), rs.Resource())
+	ss := rs.ScopeSpans().At(0)
+	assert.Equal(t, "go.opentelemetry.io/collector/receiver/otlpreceiver", ss.Scope().Name())
+
+	got := ss.Spans().At(0)
+	assert.Equal(t, "child", got.Name())
+	assert.Equal(t, ptrace.SpanKindInternal, got.Kind())
+	assert.Equal(t, pcommon.NewTraceID(child.SpanContext().TraceID()), got.TraceID())
+	assert.Equal(t, pcommon.NewSpanID(child.SpanContext().SpanID()), got.SpanID())
+	assert.Equal(t, pcommon.NewSpanID(parent.SpanContext().SpanID()), got.ParentSpanID())
+	assert.Equal(t, map[string]interface{}{"str": "val", "int": int64(1), "bool": true, "double": 1.5}, got.Attributes().AsRaw())
+	assert.Equal(t, ptrace.StatusCodeError, got.Status().Code())
+	assert.Equal(t, "failed", got.Status().Message())
+	require.Equal(t, 1, got.Events().Len())
+	assert.Equal(t, "event", got.Events().At(0).Name())
+	assert.Equal(t, map[string]interface{}{"slice": []interface{}{"a", "b"}}, got.Events().At(0).Attributes().AsRaw())
+	require.Equal(t, 1, got.Links().Len())
+	assert.Equal(t, pcommon.NewSpanID(parent.SpanContext().SpanID()), got.Links().At(0).SpanID())
+	assert.True(t, got.EndTimestamp() >= got.StartTimestamp())
+
+	gotParent := sink.AllTraces()[1].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, "parent", gotParent.Name())
+	assert.Equal(t, ptrace.SpanKindServer, gotParent.Kind())
+	assert.True(t, gotParent.ParentSpanID().IsEmpty())
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetrylogs // import "go.opentelemetry.io/collector/service/internal/telemetrylogs"
+
+import (
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/internal/components"
+)
+
+// Levels are the minimum enabled levels of the logs of the collector, and the overrides for the logs of
+// some of its components. They can be changed at runtime, without rebuilding the loggers.
+type Levels struct {
+	level zap.AtomicLevel
+	// components holds the map[config.ComponentID]zapcore.Level of the overrides, replaced as a whole
+	// on changes so it can be read without locking.
+	components atomic.Value
+}
+
+// NewLevels returns Levels enabling the logs at the info level, without overrides.
+func NewLevels() *Levels {
+	l := &Levels{level: zap.NewAtomicLevel()}
+	l.components.Store(map[config.ComponentID]zapcore.Level{})
+	return l
+}
+
+// Configure sets the levels from the configuration of the logs.
+func (l *Levels) Configure(cfg config.ServiceTelemetryLogs) {
+	l.SetLevel(cfg.Level)
+	l.SetComponentLevels(cfg.ComponentLevels)
+}
+
+// Level returns the minimum enabled level of the logs.
+func (l *Levels) Level() zapcore.Level {
+	return l.level.Level()
+}
+
+// SetLevel sets the minimum enabled level of the logs.
+func (l *Levels) SetLevel(level zapcore.Level) {
+	l.level.SetLevel(level)
+}
+
+// ComponentLevels returns a copy of the overrides of the level of the logs of the components.
+func (l *Levels) ComponentLevels() map[config.ComponentID]zapcore.Level {
+	current := l.components.Load().(map[config.ComponentID]zapcore.Level)
+	levels := make(map[config.ComponentID]zapcore.Level, len(current))
+	for id, level := range current {
+		levels[id] = level
+	}
+	return levels
+}
+
+// SetComponentLevels replaces the overrides of the level of the logs of the components.
+func (l *Levels) SetComponentLevels(levels map[config.ComponentID]zapcore.Level) {
+	copied := make(map[config.ComponentID]zapcore.Level, len(levels))
+	for id, level := range levels {
+		copied[id] = level
+	}
+	l.components.Store(copied)
+}
+
+// componentLevel returns the minimum enabled level of the logs of the component, the level of the logs if the
+// component has no override or the id is nil.
+func (l *Levels) componentLevel(id *config.ComponentID) zapcore.Level {
+	if id != nil {
+		if level, ok := l.components.Load().(map[config.ComponentID]zapcore.Level)[*id]; ok {
+			return level
+		}
+	}
+	return l.level.Level()
+}
+
+// wrapCore returns a core filtering the entries written to the given core according to the levels.
+func (l *Levels) wrapCore(core zapcore.Core) zapcore.Core {
+	return &levelCore{Core: core, levels: l}
+}
+
+// levelCore filters the entries according to the Levels. The logger of a component is identified by the
+// fields added with With by the service, of its kind and name.
+type levelCore struct {
+	zapcore.Core
+	levels *Levels
+
+	kind string
+	// id is the ID of the component logging, nil if not a component.
+	id *config.ComponentID
+}
+
+// Level returns the minimum enabled level of the entries.
+func (c *levelCore) Level() zapcore.Level {
+	return c.levels.componentLevel(c.id)
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return c.Level().Enabled(level)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.Core = c.Core.With(fields)
+	for _, f := range fields {
+		if f.Type != zapcore.StringType {
+			continue
+		}
+		switch f.Key {
+		case components.ZapKindKey:
+			clone.kind = f.String
+		case components.ZapNameKey:
+			if !isComponentKind(clone.kind) {
+				continue
+			}
+			if id, err := config.NewComponentIDFromString(f.String); err == nil {
+				clone.id = &id
+			}
+		}
+	}
+	return &clone
+}
+
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+func isComponentKind(kind string) bool {
+	switch kind {
+	case components.ZapKindReceiver, components.ZapKindProcessor, components.ZapKindLogExporter, components.ZapKindExtension:
+		return true
+	}
+	return false
+}
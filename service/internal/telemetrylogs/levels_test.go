@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetrylogs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/internal/components"
+)
+
+func TestLevels(t *testing.T) {
+	levels := NewLevels()
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(levels.wrapCore(core))
+
+	receiverLogger := logger.With(zap.String(components.ZapKindKey, components.ZapKindReceiver), zap.String(components.ZapNameKey, "otlp"))
+	// The exporters builder adds the kind and the name with separate calls.
+	exporterLogger := logger.With(zap.String(components.ZapKindKey, components.ZapKindLogExporter)).With(zap.String(components.ZapNameKey, "otlp/2"))
+	pipelineLogger := logger.With(zap.String(components.ZapKindKey, components.ZapKindPipeline), zap.String(components.ZapNameKey, "otlp"))
+
+	logAll := func() {
+		logger.Debug("service")
+		receiverLogger.Debug("receiver")
+		exporterLogger.Info("exporter")
+		pipelineLogger.Debug("pipeline")
+	}
+	messages := func() []string {
+		var msgs []string
+		for _, entry := range logs.TakeAll() {
+			msgs = append(msgs, entry.Message)
+		}
+		return msgs
+	}
+
+	logAll()
+	assert.Equal(t, []string{"exporter"}, messages())
+
+	levels.Configure(config.ServiceTelemetryLogs{
+		Level: zapcore.InfoLevel,
+		ComponentLevels: map[config.ComponentID]zapcore.Level{
+			config.NewComponentID("otlp"):              zapcore.DebugLevel,
+			config.NewComponentIDWithName("otlp", "2"): zapcore.WarnLevel,
+		},
+	})
+	logAll()
+	assert.Equal(t, []string{"receiver"}, messages())
+
+	levels.SetLevel(zapcore.DebugLevel)
+	levels.SetComponentLevels(nil)
+	logAll()
+	assert.Equal(t, []string{"service", "receiver", "exporter", "pipeline"}, messages())
+	assert.Empty(t, levels.ComponentLevels())
+}
+
+func TestLevelsComponentLevelsCopied(t *testing.T) {
+	levels := NewLevels()
+	overrides := map[config.ComponentID]zapcore.Level{config.NewComponentID("otlp"): zapcore.DebugLevel}
+	levels.SetComponentLevels(overrides)
+	overrides[config.NewComponentID("batch")] = zapcore.DebugLevel
+
+	got := levels.ComponentLevels()
+	require.Len(t, got, 1)
+	got[config.NewComponentID("batch")] = zapcore.DebugLevel
+	assert.Len(t, levels.ComponentLevels(), 1)
+}
@@ -23,10 +23,16 @@ import (
 	"go.opentelemetry.io/collector/config"
 )
 
-func NewLogger(cfg config.ServiceTelemetryLogs, options []zap.Option) (*zap.Logger, error) {
+// GRPCLogKey is the key of the field added to the logs of the gRPC framework.
+const GRPCLogKey = "grpc_log"
+
+// NewLogger returns the logger configured by cfg, enabling the logs according to the levels rather than
+// the level of the configuration, so they can be changed at runtime.
+func NewLogger(cfg config.ServiceTelemetryLogs, levels *Levels, options []zap.Option) (*zap.Logger, error) {
 	// Copied from NewProductionConfig.
 	zapCfg := &zap.Config{
-		Level:       zap.NewAtomicLevelAt(cfg.Level),
+		// The entries are filtered by the levels, wrapping the core.
+		Level:       zap.NewAtomicLevelAt(zapcore.DebugLevel),
 		Development: cfg.Development,
 		Sampling: &zap.SamplingConfig{
 			Initial:    100,
@@ -46,7 +52,8 @@ func NewLogger(cfg config.ServiceTelemetryLogs, options []zap.Option) (*zap.Logg
 		zapCfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 	}
 
-	logger, err := zapCfg.Build(options...)
+	// Wrap the core last, to filter the entries before the cores added by the options.
+	logger, err := zapCfg.Build(append(options[:len(options):len(options)], zap.WrapCore(levels.wrapCore))...)
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +77,7 @@ func SetColGRPCLogger(baseLogger *zap.Logger, loglevel zapcore.Level) *zapgrpc.L
 		if err != nil {
 			c = core
 		}
-		return c.With([]zapcore.Field{zap.Bool("grpc_log", true)})
+		return c.With([]zapcore.Field{zap.Bool(GRPCLogKey, true)})
 	})))
 
 	grpclog.SetLoggerV2(logger)
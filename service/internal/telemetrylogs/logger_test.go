@@ -74,7 +74,9 @@ func TestGRPCLogger(t *testing.T) {
 			})
 
 			// create new collector zap logger
-			logger, err := NewLogger(test.cfg, []zap.Option{hook})
+			levels := NewLevels()
+			levels.Configure(test.cfg)
+			logger, err := NewLogger(test.cfg, levels, []zap.Option{hook})
 			assert.NoError(t, err)
 
 			// create colGRPCLogger
@@ -82,6 +82,7 @@ type SummaryExtensionsTableData struct {
 type SummaryExtensionsTableRowData struct {
 	FullName string
 	Enabled  bool
+	Status   string
 }
 
 // WriteHTMLExtensionsSummaryTable writes the summary table for one component type (receivers, processors, exporters).
@@ -105,6 +106,7 @@ type SummaryPipelinesTableRowData struct {
 	Receivers   []string
 	Processors  []string
 	Exporters   []string
+	Status      string
 }
 
 // WriteHTMLPipelinesSummaryTable writes the summary table for one component type (receivers, processors, exporters).
@@ -67,6 +67,7 @@ func TestNoCrash(t *testing.T) {
 				Receivers:   []string{"oc"},
 				Processors:  []string{"nop"},
 				Exporters:   []string{"oc"},
+				Status:      "OK",
 			}},
 		})
 	})
@@ -74,6 +75,7 @@ func TestNoCrash(t *testing.T) {
 		WriteHTMLExtensionsSummaryTable(buf, SummaryExtensionsTableData{
 			Rows: []SummaryExtensionsTableRowData{{
 				FullName: "test",
+				Status:   "OK",
 			}},
 		})
 	})
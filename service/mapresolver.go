@@ -17,11 +17,17 @@ package service // import "go.opentelemetry.io/collector/service"
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
 	"go.uber.org/multierr"
+	"gopkg.in/yaml.v3"
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/experimental/configsource"
@@ -31,11 +37,53 @@ import (
 // https://tools.ietf.org/id/draft-kerwin-file-scheme-07.html#syntax
 var driverLetterRegexp = regexp.MustCompile("^[A-z]:")
 
+// includeRegexp matches a value that must be replaced with the configuration retrieved from the referenced URI,
+// e.g. "${include:file:pipelines/traces.yaml}".
+var includeRegexp = regexp.MustCompile(`^\$\{include:(.+)\}$`)
+
+// ListMergeStrategy defines how lists are combined when the same key is retrieved from multiple locations.
+type ListMergeStrategy int
+
+const (
+	// ListMergeReplace replaces the lists retrieved from previous locations. Any value retrieved from a location
+	// overrides the value retrieved from previous locations. This is the default strategy.
+	ListMergeReplace ListMergeStrategy = iota
+	// ListMergeAppend appends the components referenced by the "receivers", "processors" and "exporters" lists of
+	// every pipeline (ignoring duplicates) to the lists retrieved from previous locations. Any other key defined
+	// with different values in multiple locations is reported as a conflict.
+	ListMergeAppend
+)
+
+// String returns the name of the strategy as used in the command line flags.
+func (s ListMergeStrategy) String() string {
+	switch s {
+	case ListMergeReplace:
+		return "replace"
+	case ListMergeAppend:
+		return "append"
+	}
+	return fmt.Sprintf("ListMergeStrategy(%d)", int(s))
+}
+
+// UnmarshalText unmarshalls text to a ListMergeStrategy.
+func (s *ListMergeStrategy) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "replace", "":
+		*s = ListMergeReplace
+	case "append":
+		*s = ListMergeAppend
+	default:
+		return fmt.Errorf("unknown list merge strategy %q, supported values are \"replace\" and \"append\"", string(text))
+	}
+	return nil
+}
+
 // mapResolver resolves a configuration as a config.Map.
 type mapResolver struct {
 	uris          []string
 	mapProviders  map[string]config.MapProvider
 	mapConverters []config.MapConverterFunc
+	listMerge     ListMergeStrategy
 
 	sync.Mutex
 	closers []config.CloseFunc
@@ -45,8 +93,10 @@ type mapResolver struct {
 // newMapResolver returns a new mapResolver that resolves configuration from multiple URIs.
 //
 // To resolve a configuration the following steps will happen:
-//   1. Retrieves individual configurations from all given "URIs", and merge them in the retrieve order.
-//   2. Once the config.Map is merged, apply the converters in the given order.
+//   1. Retrieves individual configurations from all given "URIs", replacing every "${include:<uri>}" value
+//      with the configuration retrieved from "<uri>".
+//   2. Merge the retrieved configurations in the retrieve order, using the configured ListMergeStrategy.
+//   3. Once the config.Map is merged, apply the converters in the given order.
 //
 // After the configuration was resolved the `mapResolver` can be used as a single point to watch for updates in
 // the configuration data retrieved via the config providers used to process the "initial" configuration and to generate
@@ -99,32 +149,25 @@ func (mr *mapResolver) Resolve(ctx context.Context) (*config.Map, error) {
 
 	// Retrieves individual configurations from all URIs in the given order, and merge them in retMap.
 	retMap := config.NewMap()
+	merged := map[string]interface{}{}
+	origins := map[string]string{}
 	for _, uri := range mr.uris {
-		// For backwards compatibility:
-		// - empty url scheme means "file".
-		// - "^[A-z]:" also means "file"
-		scheme := "file"
-		if idx := strings.Index(uri, ":"); idx != -1 && !driverLetterRegexp.MatchString(uri) {
-			scheme = uri[:idx]
-		} else {
-			uri = scheme + ":" + uri
-		}
-		p, ok := mr.mapProviders[scheme]
-		if !ok {
-			return nil, fmt.Errorf("scheme %q is not supported for uri %q", scheme, uri)
-		}
-		ret, err := p.Retrieve(ctx, uri, mr.onChange)
+		data, dataOrigins, err := mr.retrieve(ctx, uri, nil)
 		if err != nil {
 			return nil, err
 		}
-		retCfgMap, err := ret.AsMap()
-		if err != nil {
-			return nil, err
+		if mr.listMerge == ListMergeAppend {
+			if err = mergeAppend(merged, origins, data, dataOrigins, ""); err != nil {
+				return nil, err
+			}
+			continue
 		}
-		if err = retMap.Merge(retCfgMap); err != nil {
+		if err = retMap.Merge(config.NewMapFromStringMap(data)); err != nil {
 			return nil, err
 		}
-		mr.closers = append(mr.closers, ret.Close)
+	}
+	if mr.listMerge == ListMergeAppend {
+		retMap = config.NewMapFromStringMap(merged)
 	}
 
 	// Apply the converters in the given order.
@@ -164,6 +207,96 @@ func (mr *mapResolver) Shutdown(ctx context.Context) error {
 	return errs
 }
 
+// retrieve returns the configuration retrieved from the given uri, with all the includes resolved, and the uri
+// where every (flattened) key was defined. The "visited" slice contains the chain of uris including this one,
+// and it is used to detect include cycles.
+func (mr *mapResolver) retrieve(ctx context.Context, uri string, visited []string) (map[string]interface{}, map[string]string, error) {
+	// For backwards compatibility:
+	// - empty url scheme means "file".
+	// - "^[A-z]:" also means "file"
+	scheme := "file"
+	if idx := strings.Index(uri, ":"); idx != -1 && !driverLetterRegexp.MatchString(uri) {
+		scheme = uri[:idx]
+	} else {
+		uri = scheme + ":" + uri
+	}
+	for _, v := range visited {
+		if v == uri {
+			return nil, nil, fmt.Errorf("include cycle detected: %s -> %s", strings.Join(visited, " -> "), uri)
+		}
+	}
+	p, ok := mr.mapProviders[scheme]
+	if !ok {
+		return nil, nil, fmt.Errorf("scheme %q is not supported for uri %q", scheme, uri)
+	}
+	ret, err := p.Retrieve(ctx, uri, mr.onChange)
+	if err != nil {
+		return nil, nil, err
+	}
+	mr.closers = append(mr.closers, ret.Close)
+	retCfgMap, err := ret.AsMap()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := retCfgMap.ToStringMap()
+	origins := map[string]string{}
+	if err = mr.resolveIncludes(ctx, data, origins, "", uri, append(visited, uri)); err != nil {
+		return nil, nil, err
+	}
+	return data, origins, nil
+}
+
+// resolveIncludes replaces in place all the "${include:<uri>}" values from data, and records in origins the uri
+// where every key was defined. Relative file paths are resolved relative to the directory of the including file.
+func (mr *mapResolver) resolveIncludes(ctx context.Context, data map[string]interface{}, origins map[string]string, prefix string, uri string, visited []string) error {
+	for k, v := range data {
+		resolved, err := mr.resolveValue(ctx, v, origins, prefix+k, uri, visited)
+		if err != nil {
+			return err
+		}
+		data[k] = resolved
+	}
+	return nil
+}
+
+// resolveValue returns the value v of the given key with all its "${include:<uri>}" values resolved, recursing
+// into maps and lists, and records in origins the uri where the key, or its sub-keys, were defined.
+func (mr *mapResolver) resolveValue(ctx context.Context, v interface{}, origins map[string]string, key string, uri string, visited []string) (interface{}, error) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return val, mr.resolveIncludes(ctx, val, origins, key+config.KeyDelimiter, uri, visited)
+	case []interface{}:
+		// Lists are merged as a whole, so their elements have the origin of the list.
+		origins[key] = uri
+		for i, elem := range val {
+			resolved, err := mr.resolveValue(ctx, elem, map[string]string{}, key+config.KeyDelimiter+strconv.Itoa(i), uri, visited)
+			if err != nil {
+				return nil, err
+			}
+			val[i] = resolved
+		}
+		return val, nil
+	case string:
+		match := includeRegexp.FindStringSubmatch(val)
+		if match == nil {
+			origins[key] = uri
+			return val, nil
+		}
+		included, includedOrigins, err := mr.retrieve(ctx, includeURI(uri, match[1]), visited)
+		if err != nil {
+			return nil, fmt.Errorf("cannot include %q in key %q from %s: %w", match[1], key, describeOrigin(uri, key), err)
+		}
+		for ik, iuri := range includedOrigins {
+			origins[key+config.KeyDelimiter+ik] = iuri
+		}
+		return included, nil
+	default:
+		origins[key] = uri
+		return v, nil
+	}
+}
+
 func (mr *mapResolver) onChange(event *config.ChangeEvent) {
 	// TODO: Remove check for configsource.ErrSessionClosed when providers updated to not call onChange when closed.
 	if event.Error != configsource.ErrSessionClosed {
@@ -186,3 +319,157 @@ func makeMapProvidersMap(providers ...config.MapProvider) map[string]config.MapP
 	}
 	return ret
 }
+
+// includeURI returns the uri of an included configuration. A "file" uri, or an uri without scheme, with a relative
+// path is resolved relative to the directory of the "file" uri that includes it.
+func includeURI(parent string, included string) string {
+	if idx := strings.Index(included, ":"); idx != -1 && !driverLetterRegexp.MatchString(included) && included[:idx] != "file" {
+		return included
+	}
+	path := strings.TrimPrefix(included, "file:")
+	if filepath.IsAbs(path) || driverLetterRegexp.MatchString(path) || !strings.HasPrefix(parent, "file:") {
+		return "file:" + path
+	}
+	return "file:" + filepath.Join(filepath.Dir(strings.TrimPrefix(parent, "file:")), path)
+}
+
+// pipelineListRegexp matches the keys of the lists of components referenced by a pipeline.
+var pipelineListRegexp = regexp.MustCompile("^service" + config.KeyDelimiter + "pipelines" + config.KeyDelimiter +
+	"[^:]+" + config.KeyDelimiter + "(receivers|processors|exporters)$")
+
+// mergeAppend merges src into dst using the ListMergeAppend strategy, and returns an error that names the
+// locations of both definitions for any conflicting key.
+func mergeAppend(dst map[string]interface{}, dstOrigins map[string]string, src map[string]interface{}, srcOrigins map[string]string, prefix string) error {
+	// Iterate in order to always report the same conflict first.
+	keys := make([]string, 0, len(src))
+	for k := range src {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var errs error
+	for _, k := range keys {
+		key := prefix + k
+		srcVal := src[k]
+		dstVal, ok := dst[k]
+		if !ok {
+			dst[k] = srcVal
+			copyOrigins(dstOrigins, srcOrigins, key)
+			continue
+		}
+
+		dstMap, dstIsMap := dstVal.(map[string]interface{})
+		srcMap, srcIsMap := srcVal.(map[string]interface{})
+		switch {
+		case dstIsMap && srcIsMap:
+			errs = multierr.Append(errs, mergeAppend(dstMap, dstOrigins, srcMap, srcOrigins, key+config.KeyDelimiter))
+		case dstVal == nil || srcVal == nil:
+			// An empty value (e.g. "otlp:" for a component with the default config) does not conflict with anything.
+			if dstVal == nil {
+				dst[k] = srcVal
+				copyOrigins(dstOrigins, srcOrigins, key)
+			}
+		case pipelineListRegexp.MatchString(key):
+			dstList, dstOk := dstVal.([]interface{})
+			srcList, srcOk := srcVal.([]interface{})
+			if !dstOk || !srcOk {
+				errs = multierr.Append(errs, conflictError(key, dstOrigins, srcOrigins))
+				continue
+			}
+			dst[k] = appendUnique(dstList, srcList)
+		case !reflect.DeepEqual(dstVal, srcVal):
+			errs = multierr.Append(errs, conflictError(key, dstOrigins, srcOrigins))
+		}
+	}
+	return errs
+}
+
+func appendUnique(dst []interface{}, src []interface{}) []interface{} {
+	ret := make([]interface{}, 0, len(dst)+len(src))
+	ret = append(ret, dst...)
+	for _, sv := range src {
+		found := false
+		for _, dv := range ret {
+			if reflect.DeepEqual(dv, sv) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			ret = append(ret, sv)
+		}
+	}
+	return ret
+}
+
+// copyOrigins copies the origins of the given key and all its sub-keys.
+func copyOrigins(dst map[string]string, src map[string]string, key string) {
+	for k, uri := range src {
+		if k == key || strings.HasPrefix(k, key+config.KeyDelimiter) {
+			dst[k] = uri
+		}
+	}
+}
+
+// findOrigin returns the uri where the given key, or the first of its sub-keys, was defined.
+func findOrigin(origins map[string]string, key string) string {
+	if uri, ok := origins[key]; ok {
+		return uri
+	}
+	subKeys := make([]string, 0)
+	for k := range origins {
+		if strings.HasPrefix(k, key+config.KeyDelimiter) {
+			subKeys = append(subKeys, k)
+		}
+	}
+	if len(subKeys) == 0 {
+		return ""
+	}
+	sort.Strings(subKeys)
+	return origins[subKeys[0]]
+}
+
+func conflictError(key string, dstOrigins map[string]string, srcOrigins map[string]string) error {
+	return fmt.Errorf("conflicting values for key %q defined in %s and %s",
+		strings.ReplaceAll(key, config.KeyDelimiter, "."),
+		describeOrigin(findOrigin(dstOrigins, key), key),
+		describeOrigin(findOrigin(srcOrigins, key), key))
+}
+
+// describeOrigin returns the uri, followed by the line where the key is defined for "file" uris if available.
+func describeOrigin(uri string, key string) string {
+	if !strings.HasPrefix(uri, "file:") {
+		return uri
+	}
+	content, err := ioutil.ReadFile(filepath.Clean(strings.TrimPrefix(uri, "file:")))
+	if err != nil {
+		return uri
+	}
+	var root yaml.Node
+	if err = yaml.Unmarshal(content, &root); err != nil || len(root.Content) == 0 {
+		return uri
+	}
+	node := root.Content[0]
+	line := 0
+	for _, part := range strings.Split(key, config.KeyDelimiter) {
+		if node.Kind != yaml.MappingNode {
+			break
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == part {
+				line = node.Content[i].Line
+				next = node.Content[i+1]
+				break
+			}
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	if line == 0 {
+		return uri
+	}
+	return fmt.Sprintf("%s:%d", uri, line)
+}
@@ -302,3 +302,93 @@ func TestMapResolverShutdownClosesWatch(t *testing.T) {
 	assert.NoError(t, resolver.Shutdown(context.Background()))
 	watcherWG.Wait()
 }
+
+func TestMapResolverIncludes(t *testing.T) {
+	resolver, err := newMapResolver(
+		[]string{filepath.Join("testdata", "includes", "main.yaml")},
+		makeMapProvidersMap(filemapprovider.New()), nil)
+	require.NoError(t, err)
+	cfgMap, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"nop"}, cfgMap.Get("service::pipelines::traces::receivers"))
+	assert.Equal(t, []interface{}{"nop"}, cfgMap.Get("service::pipelines::traces::processors"))
+	assert.Equal(t, []interface{}{"nop"}, cfgMap.Get("service::pipelines::traces::exporters"))
+	assert.NoError(t, resolver.Shutdown(context.Background()))
+}
+
+func TestMapResolverIncludesInList(t *testing.T) {
+	resolver, err := newMapResolver(
+		[]string{filepath.Join("testdata", "includes", "list.yaml")},
+		makeMapProvidersMap(filemapprovider.New()), nil)
+	require.NoError(t, err)
+	cfgMap, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "errors", "severity": "error"},
+		map[string]interface{}{"name": "inline"},
+	}, cfgMap.Get("processors::filter::rules"))
+	assert.NoError(t, resolver.Shutdown(context.Background()))
+}
+
+func TestMapResolverIncludeCycle(t *testing.T) {
+	resolver, err := newMapResolver(
+		[]string{filepath.Join("testdata", "includes", "cycle.yaml")},
+		makeMapProvidersMap(filemapprovider.New()), nil)
+	require.NoError(t, err)
+	_, err = resolver.Resolve(context.Background())
+	assert.ErrorContains(t, err, "include cycle detected")
+}
+
+func TestMapResolverListMergeAppend(t *testing.T) {
+	resolver, err := newMapResolver(
+		[]string{filepath.Join("testdata", "otelcol-nop.yaml"), filepath.Join("testdata", "includes", "team-a.yaml")},
+		makeMapProvidersMap(filemapprovider.New()), nil)
+	require.NoError(t, err)
+	resolver.listMerge = ListMergeAppend
+	cfgMap, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"nop"}, cfgMap.Get("service::pipelines::traces::receivers"))
+	assert.Equal(t, []interface{}{"nop", "nop/a"}, cfgMap.Get("service::pipelines::traces::exporters"))
+	assert.Equal(t, []interface{}{"nop"}, cfgMap.Get("service::pipelines::metrics::exporters"))
+	assert.True(t, cfgMap.IsSet("exporters::nop/a"))
+}
+
+func TestMapResolverListMergeReplace(t *testing.T) {
+	resolver, err := newMapResolver(
+		[]string{filepath.Join("testdata", "includes", "team-a.yaml"), filepath.Join("testdata", "includes", "team-b.yaml")},
+		makeMapProvidersMap(filemapprovider.New()), nil)
+	require.NoError(t, err)
+	cfgMap, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"nop", "nop/b"}, cfgMap.Get("service::pipelines::traces::exporters"))
+	assert.Equal(t, "info", cfgMap.Get("service::telemetry::logs::level"))
+}
+
+func TestMapResolverListMergeAppendConflict(t *testing.T) {
+	teamA := filepath.Join("testdata", "includes", "team-a.yaml")
+	teamB := filepath.Join("testdata", "includes", "team-b.yaml")
+	resolver, err := newMapResolver([]string{teamA, teamB}, makeMapProvidersMap(filemapprovider.New()), nil)
+	require.NoError(t, err)
+	resolver.listMerge = ListMergeAppend
+	_, err = resolver.Resolve(context.Background())
+	assert.EqualError(t, err, `conflicting values for key "service.telemetry.logs.level" defined in file:`+teamA+`:7 and file:`+teamB+`:7`)
+}
+
+func TestListMergeStrategyUnmarshalText(t *testing.T) {
+	var s ListMergeStrategy
+	require.NoError(t, s.UnmarshalText([]byte("append")))
+	assert.Equal(t, ListMergeAppend, s)
+	assert.Equal(t, "append", s.String())
+	require.NoError(t, s.UnmarshalText([]byte("replace")))
+	assert.Equal(t, ListMergeReplace, s)
+	assert.Equal(t, "replace", s.String())
+	assert.Error(t, s.UnmarshalText([]byte("unknown")))
+}
+
+func TestIncludeURI(t *testing.T) {
+	assert.Equal(t, "file:"+filepath.Join("dir", "other.yaml"), includeURI("file:dir/main.yaml", "other.yaml"))
+	assert.Equal(t, "file:"+filepath.Join("dir", "other.yaml"), includeURI("file:dir/main.yaml", "file:other.yaml"))
+	assert.Equal(t, "file:/abs/other.yaml", includeURI("file:dir/main.yaml", "/abs/other.yaml"))
+	assert.Equal(t, "env:CONFIG", includeURI("file:dir/main.yaml", "env:CONFIG"))
+	assert.Equal(t, "file:other.yaml", includeURI("env:CONFIG", "other.yaml"))
+}
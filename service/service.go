@@ -18,12 +18,14 @@ import (
 	"context"
 	"fmt"
 
+	"go.uber.org/atomic"
 	"go.uber.org/multierr"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/service/internal/builder"
 	"go.opentelemetry.io/collector/service/internal/extensions"
+	"go.opentelemetry.io/collector/service/internal/status"
 )
 
 // service represents the implementation of a component.Host.
@@ -32,6 +34,10 @@ type service struct {
 	config    *config.Config
 	telemetry component.TelemetrySettings
 	host      *serviceHost
+
+	// telemetryExporters push the collector's own telemetry. They are started right after the extensions
+	// and shut down right before them, so most of the telemetry produced by the pipelines is pushed.
+	telemetryExporters *builder.TelemetryExporters
 }
 
 func newService(set *svcSettings) (*service, error) {
@@ -41,15 +47,25 @@ func newService(set *svcSettings) (*service, error) {
 		telemetry: set.Telemetry,
 		host: &serviceHost{
 			factories:           set.Factories,
+			buildInfo:           set.BuildInfo,
 			zPagesSpanProcessor: set.ZPagesSpanProcessor,
 			asyncErrorChannel:   set.AsyncErrorChannel,
+			collectorState:      set.CollectorState,
+			statusReporter:      status.NewReporter(),
+			activeTaps:          atomic.NewInt32(0),
+			logLevels:           set.LogLevels,
 		},
 	}
 
 	var err error
+	if srv.telemetryExporters, err = builder.BuildTelemetryExporters(srv.buildInfo, srv.config, srv.host.factories.Exporters); err != nil {
+		return nil, fmt.Errorf("cannot build telemetry exporters: %w", err)
+	}
+
 	if srv.host.builtExtensions, err = extensions.Build(srv.telemetry, srv.buildInfo, srv.config, srv.host.factories.Extensions); err != nil {
 		return nil, fmt.Errorf("cannot build extensions: %w", err)
 	}
+	srv.host.statusReporter.AddWatcher(srv.host.builtExtensions)
 
 	// Pipeline is built backwards, starting from exporters, so that we create objects
 	// which are referenced before objects which reference them.
@@ -78,6 +94,11 @@ func (srv *service) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to start extensions: %w", err)
 	}
 
+	// Telemetry exporters are started after extensions, since they may depend on them (e.g. authenticators).
+	if err := srv.telemetryExporters.Exporters.StartAll(ctx, srv.host); err != nil {
+		return fmt.Errorf("cannot start telemetry exporters: %w", err)
+	}
+
 	srv.telemetry.Logger.Info("Starting exporters...")
 	if err := srv.host.builtExporters.StartAll(ctx, srv.host); err != nil {
 		return fmt.Errorf("cannot start exporters: %w", err)
@@ -123,6 +144,11 @@ func (srv *service) Shutdown(ctx context.Context) error {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown exporters: %w", err))
 	}
 
+	// Telemetry exporters are shut down before extensions, since they may depend on them.
+	if err := srv.telemetryExporters.Exporters.ShutdownAll(ctx); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown telemetry exporters: %w", err))
+	}
+
 	srv.telemetry.Logger.Info("Stopping extensions...")
 	if err := srv.host.builtExtensions.ShutdownAll(ctx); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown extensions: %w", err))
@@ -20,6 +20,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/internal/telemetrylogs"
 )
 
 // svcSettings holds configuration for building a new service.
@@ -41,6 +42,12 @@ type svcSettings struct {
 
 	// AsyncErrorChannel is the channel that is used to report fatal errors.
 	AsyncErrorChannel chan error
+
+	// CollectorState returns the state of the collector running the service, optional.
+	CollectorState func() State
+
+	// LogLevels are the levels of the logs of the collector, changed by the zPages, optional.
+	LogLevels *telemetrylogs.Levels
 }
 
 // CollectorSettings holds configuration for creating a new Collector.
@@ -19,19 +19,26 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 	"unicode"
 
 	"contrib.go.opencensus.io/exporter/prometheus"
-	"github.com/google/uuid"
+	promclient "github.com/prometheus/client_golang/prometheus"
+	"go.opencensus.io/metric/metricexport"
 	"go.opencensus.io/stats/view"
 	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/lastvalue"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/sum"
 	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
 	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
 	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
-	selector "go.opentelemetry.io/otel/sdk/metric/selector/simple"
+	"go.opentelemetry.io/otel/sdk/metric/sdkapi"
+	"go.opentelemetry.io/otel/sdk/resource"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/version"
@@ -39,6 +46,7 @@ import (
 	semconv "go.opentelemetry.io/collector/semconv/v1.5.0"
 	"go.opentelemetry.io/collector/service/featuregate"
 	telemetry2 "go.opentelemetry.io/collector/service/internal/telemetry"
+	"go.opentelemetry.io/collector/service/internal/telemetryexport"
 )
 
 // collectorTelemetry is collector's own telemetry.
@@ -47,17 +55,22 @@ var collectorTelemetry collectorTelemetryExporter = newColTelemetry(featuregate.
 // AddCollectorVersionTag indicates if the collector version tag should be added to all telemetry metrics
 const AddCollectorVersionTag = true
 
+// metricsPushInterval is the interval at which the collector's own metrics are pushed to the configured exporters.
+const metricsPushInterval = 30 * time.Second
+
 const (
 	zapKeyTelemetryAddress = "address"
 	zapKeyTelemetryLevel   = "level"
 
 	// useOtelForInternalMetricsfeatureGateID is the feature gate ID that controls whether the collector uses open
 	// telemetry for internal metrics.
-	useOtelForInternalMetricsfeatureGateID = "telemetry.useOtelForInternalMetrics"
+	useOtelForInternalMetricsfeatureGateID = obsreportconfig.UseOtelForInternalMetricsfeatureGateID
 )
 
 type collectorTelemetryExporter interface {
-	init(col *Collector) error
+	// init initializes the telemetry using the given configuration, it must be called before the components
+	// are created since they get the MeterProvider set by it.
+	init(col *Collector, cfg config.ServiceTelemetry) error
 	shutdown() error
 }
 
@@ -65,6 +78,9 @@ type colTelemetry struct {
 	registry   *featuregate.Registry
 	views      []*view.View
 	server     *http.Server
+	reader     *metricexport.IntervalReader
+	otelReader *telemetryexport.OpenTelemetryReader
+	pmv        *telemetry2.ProcessMetricsViews
 	doInitOnce sync.Once
 }
 
@@ -77,11 +93,11 @@ func newColTelemetry(registry *featuregate.Registry) *colTelemetry {
 	return &colTelemetry{registry: registry}
 }
 
-func (tel *colTelemetry) init(col *Collector) error {
+func (tel *colTelemetry) init(col *Collector, cfg config.ServiceTelemetry) error {
 	var err error
 	tel.doInitOnce.Do(
 		func() {
-			err = tel.initOnce(col)
+			err = tel.initOnce(col, cfg)
 		},
 	)
 	if err != nil {
@@ -90,14 +106,15 @@ func (tel *colTelemetry) init(col *Collector) error {
 	return nil
 }
 
-func (tel *colTelemetry) initOnce(col *Collector) error {
+func (tel *colTelemetry) initOnce(col *Collector, cfg config.ServiceTelemetry) error {
 	logger := col.telemetry.Logger
-	cfg := col.service.config.Telemetry
+	useOtel := tel.registry.IsEnabled(useOtelForInternalMetricsfeatureGateID)
+	obsreportconfig.SetUseOtelForInternalMetrics(useOtel)
 
 	level := cfg.Metrics.Level
 	metricsAddr := cfg.Metrics.Address
 
-	if level == configtelemetry.LevelNone || metricsAddr == "" {
+	if level == configtelemetry.LevelNone || (metricsAddr == "" && len(cfg.Metrics.Exporters) == 0) {
 		logger.Info(
 			"Skipping telemetry setup.",
 			zap.String(zapKeyTelemetryAddress, metricsAddr),
@@ -108,22 +125,39 @@ func (tel *colTelemetry) initOnce(col *Collector) error {
 
 	logger.Info("Setting up own telemetry...")
 
-	instanceUUID, _ := uuid.NewRandom()
-	instanceID := instanceUUID.String()
+	instanceID := col.instanceID
+
+	var err error
+	if tel.pmv, err = telemetry2.NewProcessMetricsViews(col.ballastSizeBytes.Load); err != nil {
+		return err
+	}
 
 	var pe http.Handler
-	if tel.registry.IsEnabled(useOtelForInternalMetricsfeatureGateID) {
-		otelHandler, err := tel.initOpenTelemetry(col)
+	if useOtel {
+		otelHandler, err := tel.initOpenTelemetry(col, cfg, instanceID)
 		if err != nil {
 			return err
 		}
 		pe = otelHandler
 	} else {
-		ocHandler, err := tel.initOpenCensus(col, instanceID)
+		ocHandler, err := tel.initOpenCensus(cfg, instanceID)
 		if err != nil {
 			return err
 		}
 		pe = ocHandler
+
+		// The reader always runs, the metrics are dropped while the running service has no metrics exporters.
+		if tel.reader, err = metricexport.NewIntervalReader(metricexport.NewReader(), col.metricsExporter); err != nil {
+			return err
+		}
+		tel.reader.ReportingInterval = metricsPushInterval
+		if err = tel.reader.Start(); err != nil {
+			return err
+		}
+	}
+
+	if metricsAddr == "" {
+		return nil
 	}
 
 	logger.Info(
@@ -152,24 +186,19 @@ func (tel *colTelemetry) initOnce(col *Collector) error {
 	return nil
 }
 
-func (tel *colTelemetry) initOpenCensus(col *Collector, instanceID string) (http.Handler, error) {
-	processMetricsViews, err := telemetry2.NewProcessMetricsViews(getBallastSize(col.service.host))
-	if err != nil {
-		return nil, err
-	}
-
+func (tel *colTelemetry) initOpenCensus(cfg config.ServiceTelemetry, instanceID string) (http.Handler, error) {
 	var views []*view.View
-	obsMetrics := obsreportconfig.Configure(col.service.config.Telemetry.Metrics.Level)
+	obsMetrics := obsreportconfig.Configure(cfg.Metrics.Level)
 	views = append(views, batchprocessor.MetricViews()...)
 	views = append(views, obsMetrics.Views...)
-	views = append(views, processMetricsViews.Views()...)
+	views = append(views, tel.pmv.Views()...)
 
 	tel.views = views
-	if err = view.Register(views...); err != nil {
+	if err := view.Register(views...); err != nil {
 		return nil, err
 	}
 
-	processMetricsViews.StartCollection()
+	tel.pmv.StartCollection()
 
 	// Until we can use a generic metrics exporter, default to Prometheus.
 	opts := prometheus.Options{
@@ -193,28 +222,63 @@ func (tel *colTelemetry) initOpenCensus(col *Collector, instanceID string) (http
 	return pe, nil
 }
 
-func (tel *colTelemetry) initOpenTelemetry(col *Collector) (http.Handler, error) {
-	config := otelprometheus.Config{}
+func (tel *colTelemetry) initOpenTelemetry(col *Collector, cfg config.ServiceTelemetry, instanceID string) (http.Handler, error) {
+	// The Prometheus output must be the same as the one of the OpenCensus exporter, so the namespace and the
+	// labels are added by the registerer, not by the resource.
+	constLabels := promclient.Labels{sanitizePrometheusKey(semconv.AttributeServiceInstanceID): instanceID}
+	if AddCollectorVersionTag {
+		constLabels[sanitizePrometheusKey(semconv.AttributeServiceVersion)] = version.Version
+	}
+	registry := promclient.NewRegistry()
+	promCfg := otelprometheus.Config{
+		Registry:   registry,
+		Registerer: promclient.WrapRegistererWith(constLabels, promclient.WrapRegistererWithPrefix("otelcol_", registry)),
+	}
+
+	obsreportconfig.Configure(cfg.Metrics.Level)
+	var views []*view.View
+	views = append(views, batchprocessor.MetricViews()...)
+	views = append(views, obsreportconfig.AllViews()...)
+
 	c := controller.New(
 		processor.NewFactory(
-			selector.NewWithHistogramDistribution(
-				histogram.WithExplicitBoundaries(config.DefaultHistogramBoundaries),
-			),
+			newViewsAggregatorSelector(views),
 			aggregation.CumulativeTemporalitySelector(),
 			processor.WithMemory(true),
 		),
+		controller.WithResource(resource.Empty()),
+		// Collect on every scrape, as the OpenCensus exporter does.
+		controller.WithCollectPeriod(0),
 	)
 
-	pe, err := otelprometheus.New(config, c)
+	pe, err := otelprometheus.New(promCfg, c)
 	if err != nil {
 		return nil, err
 	}
 
+	if err = tel.pmv.RegisterInstruments(pe.MeterProvider().Meter("go.opentelemetry.io/collector/service")); err != nil {
+		return nil, err
+	}
+
+	// The reader always runs, the metrics are dropped while the running service has no metrics exporters.
+	tel.otelReader = telemetryexport.NewOpenTelemetryReader(c, col.metricsExporter, metricsPushInterval)
+	tel.otelReader.Start()
+
 	col.telemetry.MeterProvider = pe.MeterProvider()
-	return pe, err
+	return pe, nil
 }
 
 func (tel *colTelemetry) shutdown() error {
+	obsreportconfig.SetUseOtelForInternalMetrics(false)
+	if tel.reader != nil {
+		tel.reader.Stop()
+	}
+	if tel.otelReader != nil {
+		tel.otelReader.Stop()
+	}
+	if tel.pmv != nil && len(tel.views) > 0 {
+		tel.pmv.StopCollection()
+	}
 	view.Unregister(tel.views...)
 
 	if tel.server != nil {
@@ -233,3 +297,43 @@ func sanitizePrometheusKey(str string) string {
 	}
 	return strings.Map(runeFilterMap, str)
 }
+
+// viewsAggregatorSelector selects the same aggregations for the OpenTelemetry instruments as the OpenCensus
+// views with the same names, in particular the histograms have the same boundaries as the distributions.
+type viewsAggregatorSelector struct {
+	boundaries map[string][]float64
+}
+
+func newViewsAggregatorSelector(views []*view.View) *viewsAggregatorSelector {
+	vas := &viewsAggregatorSelector{boundaries: make(map[string][]float64)}
+	for _, v := range views {
+		if v.Aggregation.Type == view.AggTypeDistribution {
+			vas.boundaries[v.Name] = v.Aggregation.Buckets
+		}
+	}
+	return vas
+}
+
+func (vas *viewsAggregatorSelector) AggregatorFor(desc *sdkapi.Descriptor, aggPtrs ...*aggregator.Aggregator) {
+	switch desc.InstrumentKind() {
+	case sdkapi.GaugeObserverInstrumentKind:
+		aggs := lastvalue.New(len(aggPtrs))
+		for i := range aggPtrs {
+			*aggPtrs[i] = &aggs[i]
+		}
+	case sdkapi.HistogramInstrumentKind:
+		var opts []histogram.Option
+		if boundaries, ok := vas.boundaries[desc.Name()]; ok {
+			opts = append(opts, histogram.WithExplicitBoundaries(boundaries))
+		}
+		aggs := histogram.New(len(aggPtrs), desc, opts...)
+		for i := range aggPtrs {
+			*aggPtrs[i] = &aggs[i]
+		}
+	default:
+		aggs := sum.New(len(aggPtrs))
+		for i := range aggPtrs {
+			*aggPtrs[i] = &aggs[i]
+		}
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/batchprocessor"
+	"go.opentelemetry.io/collector/service/featuregate"
+)
+
+// parityName is the name of all the components used by TestInternalMetricsParity, only the metrics of
+// these components are compared since other tests may have reported metrics of other components.
+const parityName = "parity"
+
+// componentLabels are the labels identifying the components reporting the metrics.
+var componentLabels = map[string]bool{"receiver": true, "scraper": true, "processor": true, "exporter": true}
+
+func TestInternalMetricsParity(t *testing.T) {
+	ocMetrics := scrapeInternalMetrics(t, false)
+	otelMetrics := scrapeInternalMetrics(t, true)
+
+	// Check that all the kinds of metrics are covered.
+	for _, name := range []string{
+		"otelcol_receiver_accepted_spans",
+		"otelcol_receiver_refused_spans",
+		"otelcol_scraper_scraped_metric_points",
+		"otelcol_exporter_sent_spans",
+		"otelcol_exporter_send_failed_spans",
+		"otelcol_exporter_send_failed_requests",
+		"otelcol_exporter_queue_size",
+		"otelcol_exporter_enqueue_failed_spans",
+		"otelcol_exporter_send_latency",
+		"otelcol_exporter_queue_latency",
+		"otelcol_processor_accepted_spans",
+		"otelcol_processor_batch_batch_send_size",
+		"otelcol_processor_batch_batch_send_size_bytes",
+		"otelcol_processor_batch_batch_size_trigger_send",
+		"otelcol_process_uptime",
+		"otelcol_process_runtime_heap_alloc_bytes",
+		"otelcol_process_memory_rss",
+	} {
+		assert.Contains(t, ocMetrics, name)
+	}
+
+	assert.Equal(t, ocMetrics, otelMetrics)
+	for _, name := range []string{"otelcol_exporter_send_latency", "otelcol_exporter_queue_latency"} {
+		for _, series := range otelMetrics[name] {
+			assert.Contains(t, series, "pipeline=traces")
+		}
+	}
+}
+
+// scrapeInternalMetrics records the same operations through all the internal metrics instrumentations,
+// using either OpenCensus or OpenTelemetry, and returns the scraped Prometheus metrics in a comparable form.
+func scrapeInternalMetrics(t *testing.T, useOtel bool) map[string][]string {
+	registry := featuregate.NewRegistry()
+	tel := newColTelemetry(registry)
+	registry.Apply(map[string]bool{useOtelForInternalMetricsfeatureGateID: useOtel})
+
+	col, err := New(CollectorSettings{ConfigProvider: &errConfigProvider{}, telemetry: tel})
+	require.NoError(t, err)
+	metricsAddr := testutil.GetAvailableLocalAddress(t)
+	require.NoError(t, tel.init(col, config.ServiceTelemetry{
+		Metrics: config.ServiceTelemetryMetrics{Level: configtelemetry.LevelDetailed, Address: metricsAddr},
+	}))
+	defer func() {
+		assert.NoError(t, tel.shutdown())
+	}()
+
+	set := col.telemetry
+	set.MetricsLevel = configtelemetry.LevelDetailed
+	recordInternalMetrics(t, set)
+
+	var metrics map[string][]string
+	require.Eventually(t, func() bool {
+		metrics, err = scrapeMetrics(metricsAddr)
+		// The OpenCensus process metrics are recorded asynchronously.
+		return err == nil && len(metrics["otelcol_process_uptime"]) > 0
+	}, 2*time.Second, 50*time.Millisecond)
+	return metrics
+}
+
+func recordInternalMetrics(t *testing.T, set component.TelemetrySettings) {
+	ctx := context.Background()
+	id := config.NewComponentIDWithName("nop", parityName)
+	buildInfo := component.NewDefaultBuildInfo()
+
+	rec := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             id,
+		Transport:              "grpc",
+		ReceiverCreateSettings: component.ReceiverCreateSettings{TelemetrySettings: set, BuildInfo: buildInfo},
+	})
+	rec.EndTracesOp(rec.StartTracesOp(ctx), "otlp", 7, nil)
+	rec.EndTracesOp(rec.StartTracesOp(ctx), "otlp", 3, errors.New("refused"))
+
+	scraper := obsreport.NewScraper(obsreport.ScraperSettings{
+		ReceiverID:             id,
+		Scraper:                id,
+		ReceiverCreateSettings: component.ReceiverCreateSettings{TelemetrySettings: set, BuildInfo: buildInfo},
+	})
+	scraper.EndMetricsOp(scraper.StartMetricsOp(ctx), 5, nil)
+
+	exp := obsreport.NewExporter(obsreport.ExporterSettings{
+		Level:                  set.MetricsLevel,
+		ExporterID:             id,
+		ExporterCreateSettings: component.ExporterCreateSettings{TelemetrySettings: set, BuildInfo: buildInfo},
+	})
+	// The exporters' latencies are tagged with the pipeline in the context.
+	pipelineCtx, err := tag.New(ctx, tag.Upsert(obsmetrics.TagKeyPipeline, "traces"))
+	require.NoError(t, err)
+	exp.EndTracesOp(exp.StartTracesOp(pipelineCtx), 4, nil)
+	exp.EndTracesOp(exp.StartTracesOp(pipelineCtx), 2, errors.New("failed"))
+
+	proc := obsreport.NewProcessor(obsreport.ProcessorSettings{
+		Level:                   set.MetricsLevel,
+		ProcessorID:             id,
+		ProcessorCreateSettings: component.ProcessorCreateSettings{TelemetrySettings: set, BuildInfo: buildInfo},
+	})
+	proc.TracesAccepted(ctx, 6)
+
+	expCfg := config.NewExporterSettings(id)
+	te, err := exporterhelper.NewTracesExporter(&expCfg, component.ExporterCreateSettings{TelemetrySettings: set, BuildInfo: buildInfo},
+		func(context.Context, ptrace.Traces) error { return nil },
+		exporterhelper.WithQueue(exporterhelper.NewDefaultQueueSettings()))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(ctx, componenttest.NewNopHost()))
+	require.NoError(t, te.ConsumeTraces(pipelineCtx, testdata.GenerateTracesOneSpan()))
+	require.NoError(t, te.Shutdown(ctx))
+
+	factory := batchprocessor.NewFactory()
+	batchCfg := factory.CreateDefaultConfig().(*batchprocessor.Config)
+	batchCfg.SetIDName(parityName)
+	batchCfg.SendBatchSize = 1
+	var tp component.TracesProcessor
+	tp, err = factory.CreateTracesProcessor(ctx, component.ProcessorCreateSettings{TelemetrySettings: set, BuildInfo: buildInfo},
+		batchCfg, consumer.Traces(consumertest.NewNop()))
+	require.NoError(t, err)
+	require.NoError(t, tp.Start(ctx, componenttest.NewNopHost()))
+	require.NoError(t, tp.ConsumeTraces(ctx, testdata.GenerateTracesOneSpan()))
+	require.NoError(t, tp.Shutdown(ctx))
+}
+
+// scrapeMetrics scrapes the Prometheus metrics, and returns for every metric family the sorted list of its
+// series, every one of them formatted with its labels and values. The values of the process metrics
+// are not included since they change between scrapes.
+func scrapeMetrics(metricsAddr string) (map[string][]string, error) {
+	resp, err := http.Get("http://" + metricsAddr + "/metrics")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var parser expfmt.TextParser
+	parsed, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := make(map[string][]string)
+	for name, family := range parsed {
+		isProcess := strings.HasPrefix(name, "otelcol_process_")
+		for _, m := range family.Metric {
+			var labels []string
+			fromOtherComponent := false
+			for _, l := range m.Label {
+				// The instance ID is different for every collector.
+				if l.GetName() == "service_instance_id" {
+					continue
+				}
+				if componentLabels[l.GetName()] && !strings.HasSuffix(l.GetValue(), parityName) {
+					fromOtherComponent = true
+				}
+				labels = append(labels, l.GetName()+"="+l.GetValue())
+			}
+			if fromOtherComponent {
+				continue
+			}
+			series := family.GetType().String() + "{" + strings.Join(labels, ",") + "}"
+			if !isProcess {
+				series += " " + formatValue(m)
+			}
+			metrics[name] = append(metrics[name], series)
+		}
+		sort.Strings(metrics[name])
+	}
+	return metrics, nil
+}
+
+func formatValue(m *io_prometheus_client.Metric) string {
+	switch {
+	case m.Counter != nil:
+		return fmt.Sprint(m.Counter.GetValue())
+	case m.Gauge != nil:
+		return fmt.Sprint(m.Gauge.GetValue())
+	case m.Histogram != nil:
+		buckets := make([]string, 0, len(m.Histogram.Bucket))
+		for _, b := range m.Histogram.Bucket {
+			buckets = append(buckets, fmt.Sprintf("%v:%v", b.GetUpperBound(), b.GetCumulativeCount()))
+		}
+		return fmt.Sprintf("count=%v sum=%v buckets=[%s]", m.Histogram.GetSampleCount(), m.Histogram.GetSampleSum(), strings.Join(buckets, " "))
+	}
+	return ""
+}
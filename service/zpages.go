@@ -15,15 +15,24 @@
 package service // import "go.opentelemetry.io/collector/service"
 
 import (
+	"encoding/json"
+	"fmt"
+	"log"
 	"net/http"
+	"net/url"
 	"path"
 	"sort"
+	"strconv"
+	"time"
 
 	otelzpages "go.opentelemetry.io/contrib/zpages"
+	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/internal/version"
 	"go.opentelemetry.io/collector/service/featuregate"
+	"go.opentelemetry.io/collector/service/internal/tap"
 	"go.opentelemetry.io/collector/service/internal/zpages"
 )
 
@@ -33,11 +42,30 @@ const (
 	pipelinezPath  = "pipelinez"
 	extensionzPath = "extensionz"
 	featurezPath   = "featurez"
+	tapzPath       = "tapz"
+	loglevelzPath  = "loglevelz"
+
+	// jsonSuffix is appended to the path of the pages to get their data as JSON.
+	jsonSuffix = ".json"
 
 	zPipelineName  = "zpipelinename"
 	zComponentName = "zcomponentname"
 	zComponentKind = "zcomponentkind"
 	zExtensionName = "zextensionname"
+	zTapInterval   = "interval"
+	zTapMaxSize    = "maxsize"
+	zTapDuration   = "duration"
+)
+
+// Limits of the taps streamed by the tapz page, to bound their impact on the pipelines.
+const (
+	maxActiveTaps      = 4
+	defaultTapInterval = time.Second
+	minTapInterval     = 100 * time.Millisecond
+	defaultTapMaxSize  = 64 * 1024
+	maxTapMaxSize      = 1024 * 1024
+	defaultTapDuration = time.Minute
+	maxTapDuration     = 10 * time.Minute
 )
 
 func (host *serviceHost) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
@@ -45,9 +73,16 @@ func (host *serviceHost) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
 	mux.HandleFunc(path.Join(pathPrefix, servicezPath), host.handleServicezRequest)
 	mux.HandleFunc(path.Join(pathPrefix, pipelinezPath), host.handlePipelinezRequest)
 	mux.HandleFunc(path.Join(pathPrefix, featurezPath), handleFeaturezRequest)
-	mux.HandleFunc(path.Join(pathPrefix, extensionzPath), func(w http.ResponseWriter, r *http.Request) {
-		handleExtensionzRequest(host, w, r)
-	})
+	mux.HandleFunc(path.Join(pathPrefix, extensionzPath), host.handleExtensionzRequest)
+	mux.HandleFunc(path.Join(pathPrefix, tapzPath), host.handleTapzRequest)
+	if host.logLevels != nil {
+		mux.HandleFunc(path.Join(pathPrefix, loglevelzPath), host.handleLoglevelzRequest)
+	}
+
+	mux.HandleFunc(path.Join(pathPrefix, servicezPath+jsonSuffix), host.handleServicezJSONRequest)
+	mux.HandleFunc(path.Join(pathPrefix, pipelinezPath+jsonSuffix), host.handlePipelinezJSONRequest)
+	mux.HandleFunc(path.Join(pathPrefix, featurezPath+jsonSuffix), handleFeaturezJSONRequest)
+	mux.HandleFunc(path.Join(pathPrefix, extensionzPath+jsonSuffix), host.handleExtensionzJSONRequest)
 }
 
 func (host *serviceHost) handleServicezRequest(w http.ResponseWriter, r *http.Request) {
@@ -89,7 +124,10 @@ func (host *serviceHost) handlePipelinezRequest(w http.ResponseWriter, r *http.R
 		zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
 			Name: componentKind + ": " + fullName,
 		})
-		// TODO: Add config + status info.
+		if id, ok := pipelineComponentInstanceID(pipelineName, componentName, componentKind); ok {
+			zpages.WriteHTMLPropertiesTable(w, host.getStatusTableData(id))
+		}
+		// TODO: Add config info.
 	}
 	zpages.WriteHTMLPageFooter(w)
 }
@@ -119,6 +157,7 @@ func (host *serviceHost) getPipelinesSummaryTableData() zpages.SummaryPipelinesT
 			Receivers:   recvs,
 			Processors:  procs,
 			Exporters:   exps,
+			Status:      statusString(host.statusReporter.AggregateStatus(pipelineInstanceIDs(c, p.Config))),
 		}
 		data.Rows = append(data.Rows, row)
 	}
@@ -129,28 +168,34 @@ func (host *serviceHost) getPipelinesSummaryTableData() zpages.SummaryPipelinesT
 	return data
 }
 
-func handleExtensionzRequest(host component.Host, w http.ResponseWriter, r *http.Request) {
+func (host *serviceHost) handleExtensionzRequest(w http.ResponseWriter, r *http.Request) {
 	extensionName := r.URL.Query().Get(zExtensionName)
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Extensions"})
-	zpages.WriteHTMLExtensionsSummaryTable(w, getExtensionsSummaryTableData(host))
+	zpages.WriteHTMLExtensionsSummaryTable(w, host.getExtensionsSummaryTableData())
 	if extensionName != "" {
 		zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
 			Name: extensionName,
 		})
-		// TODO: Add config + status info.
+		if id, err := config.NewComponentIDFromString(extensionName); err == nil {
+			zpages.WriteHTMLPropertiesTable(w, host.getStatusTableData(component.InstanceID{ID: id, Kind: component.KindExtension}))
+		}
+		// TODO: Add config info.
 	}
 	zpages.WriteHTMLPageFooter(w)
 }
 
-func getExtensionsSummaryTableData(host component.Host) zpages.SummaryExtensionsTableData {
+func (host *serviceHost) getExtensionsSummaryTableData() zpages.SummaryExtensionsTableData {
 	data := zpages.SummaryExtensionsTableData{}
 
 	extensions := host.GetExtensions()
 	data.Rows = make([]zpages.SummaryExtensionsTableRowData, 0, len(extensions))
 	for c := range extensions {
-		row := zpages.SummaryExtensionsTableRowData{FullName: c.String()}
+		row := zpages.SummaryExtensionsTableRowData{
+			FullName: c.String(),
+			Status:   statusString(host.statusReporter.AggregateStatus([]component.InstanceID{{ID: c, Kind: component.KindExtension}})),
+		}
 		data.Rows = append(data.Rows, row)
 	}
 
@@ -160,6 +205,166 @@ func getExtensionsSummaryTableData(host component.Host) zpages.SummaryExtensions
 	return data
 }
 
+// getStatusTableData returns the last status reported by the component.
+func (host *serviceHost) getStatusTableData(id component.InstanceID) zpages.PropertiesTableData {
+	data := zpages.PropertiesTableData{Name: "Status"}
+	event := host.statusReporter.AggregateStatus([]component.InstanceID{id})
+	data.Properties = append(data.Properties, [2]string{"Status", statusString(event)})
+	if event == nil {
+		return data
+	}
+	if event.Err() != nil {
+		data.Properties = append(data.Properties, [2]string{"Error", event.Err().Error()})
+	}
+	data.Properties = append(data.Properties, [2]string{"Timestamp", event.Timestamp().String()})
+	return data
+}
+
+// pipelineInstanceIDs returns the IDs of the components of the pipeline.
+func pipelineInstanceIDs(pipelineID config.ComponentID, pipeline *config.Pipeline) []component.InstanceID {
+	ids := make([]component.InstanceID, 0, len(pipeline.Receivers)+len(pipeline.Processors)+len(pipeline.Exporters))
+	for _, recvID := range pipeline.Receivers {
+		ids = append(ids, component.InstanceID{ID: recvID, Kind: component.KindReceiver})
+	}
+	for _, procID := range pipeline.Processors {
+		ids = append(ids, component.InstanceID{ID: procID, Kind: component.KindProcessor, PipelineID: pipelineID})
+	}
+	for _, expID := range pipeline.Exporters {
+		ids = append(ids, component.InstanceID{ID: expID, Kind: component.KindExporter})
+	}
+	return ids
+}
+
+// pipelineComponentInstanceID returns the ID of the component of the pipeline given by the zPages parameters.
+func pipelineComponentInstanceID(pipelineName, componentName, componentKind string) (component.InstanceID, bool) {
+	compID, err := config.NewComponentIDFromString(componentName)
+	if err != nil {
+		return component.InstanceID{}, false
+	}
+	switch componentKind {
+	case "receiver":
+		return component.InstanceID{ID: compID, Kind: component.KindReceiver}, true
+	case "processor":
+		pipelineID, err := config.NewComponentIDFromString(pipelineName)
+		if err != nil {
+			return component.InstanceID{}, false
+		}
+		return component.InstanceID{ID: compID, Kind: component.KindProcessor, PipelineID: pipelineID}, true
+	case "exporter":
+		return component.InstanceID{ID: compID, Kind: component.KindExporter}, true
+	}
+	return component.InstanceID{}, false
+}
+
+// statusString returns the string representation of the status of the event, "Unknown" if nil.
+func statusString(event *component.StatusEvent) string {
+	if event == nil {
+		return "Unknown"
+	}
+	return event.Status().String()
+}
+
+// handleTapzRequest streams, as server-sent events, a sampled copy of the data passing through a point of a
+// pipeline: after a receiver, after a processor or before an exporter. The data is encoded as OTLP JSON.
+func (host *serviceHost) handleTapzRequest(w http.ResponseWriter, r *http.Request) {
+	qValues := r.URL.Query()
+	pipelineName := qValues.Get(zPipelineName)
+	id, ok := pipelineComponentInstanceID(pipelineName, qValues.Get(zComponentName), qValues.Get(zComponentKind))
+	if !ok {
+		http.Error(w, "invalid pipeline, component name or kind", http.StatusBadRequest)
+		return
+	}
+	pipelineID, err := config.NewComponentIDFromString(pipelineName)
+	if err != nil {
+		http.Error(w, "invalid pipeline: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	limits, duration, err := parseTapLimits(qValues)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	t := host.builtPipelines.GetTap(pipelineID, tap.Point{Kind: id.Kind, ID: id.ID})
+	if t == nil {
+		http.Error(w, "no such component in the pipeline", http.StatusNotFound)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	if host.activeTaps.Inc() > maxActiveTaps {
+		host.activeTaps.Dec()
+		http.Error(w, "too many active taps", http.StatusTooManyRequests)
+		return
+	}
+	defer host.activeTaps.Dec()
+
+	sub := t.Subscribe(limits)
+	defer t.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	for {
+		select {
+		case buf := <-sub.Data():
+			if _, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", pipelineID.Type(), buf); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-timer.C:
+			_, _ = fmt.Fprintf(w, "event: end\ndata: {\"dropped\":%d}\n\n", sub.Dropped())
+			flusher.Flush()
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// parseTapLimits returns the limits and the duration of the tap requested, within the limits of the tapz page.
+func parseTapLimits(qValues url.Values) (tap.Limits, time.Duration, error) {
+	limits := tap.Limits{Interval: defaultTapInterval, MaxSize: defaultTapMaxSize}
+	duration := defaultTapDuration
+	if v := qValues.Get(zTapInterval); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil {
+			return limits, duration, fmt.Errorf("invalid %s: %w", zTapInterval, err)
+		}
+		if interval < minTapInterval {
+			interval = minTapInterval
+		}
+		limits.Interval = interval
+	}
+	if v := qValues.Get(zTapMaxSize); v != "" {
+		maxSize, err := strconv.Atoi(v)
+		if err != nil || maxSize <= 0 {
+			return limits, duration, fmt.Errorf("invalid %s: %q", zTapMaxSize, v)
+		}
+		if maxSize > maxTapMaxSize {
+			maxSize = maxTapMaxSize
+		}
+		limits.MaxSize = maxSize
+	}
+	if v := qValues.Get(zTapDuration); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return limits, duration, fmt.Errorf("invalid %s: %q", zTapDuration, v)
+		}
+		if d > maxTapDuration {
+			d = maxTapDuration
+		}
+		duration = d
+	}
+	return limits, duration, nil
+}
+
 func handleFeaturezRequest(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Feature Gates"})
@@ -179,3 +384,174 @@ func getFeaturesTableData() zpages.FeatureGateTableData {
 
 	return data
 }
+
+// servicezJSON is the JSON representation of the servicez page.
+type servicezJSON struct {
+	BuildInfo buildInfoJSON     `json:"buildInfo"`
+	Runtime   map[string]string `json:"runtime"`
+	State     string            `json:"state,omitempty"`
+}
+
+type buildInfoJSON struct {
+	Command     string `json:"command"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+// pipelinezJSON is the JSON representation of the pipelinez page.
+type pipelinezJSON struct {
+	Pipelines []pipelineJSON `json:"pipelines"`
+}
+
+type pipelineJSON struct {
+	ID          string   `json:"id"`
+	DataType    string   `json:"dataType"`
+	MutatesData bool     `json:"mutatesData"`
+	Receivers   []string `json:"receivers"`
+	Processors  []string `json:"processors"`
+	Exporters   []string `json:"exporters"`
+	Status      string   `json:"status"`
+}
+
+// extensionzJSON is the JSON representation of the extensionz page.
+type extensionzJSON struct {
+	Extensions []extensionJSON `json:"extensions"`
+}
+
+type extensionJSON struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+// featurezJSON is the JSON representation of the featurez page.
+type featurezJSON struct {
+	FeatureGates []featureGateJSON `json:"featureGates"`
+}
+
+type featureGateJSON struct {
+	ID          string `json:"id"`
+	Enabled     bool   `json:"enabled"`
+	Description string `json:"description"`
+}
+
+func (host *serviceHost) handleServicezJSONRequest(w http.ResponseWriter, r *http.Request) {
+	data := servicezJSON{
+		BuildInfo: buildInfoJSON{
+			Command:     host.buildInfo.Command,
+			Description: host.buildInfo.Description,
+			Version:     host.buildInfo.Version,
+		},
+		Runtime: make(map[string]string),
+	}
+	for _, prop := range version.RuntimeVar() {
+		data.Runtime[prop[0]] = prop[1]
+	}
+	if host.collectorState != nil {
+		data.State = host.collectorState().String()
+	}
+	writeJSON(w, data)
+}
+
+func (host *serviceHost) handlePipelinezJSONRequest(w http.ResponseWriter, r *http.Request) {
+	rows := host.getPipelinesSummaryTableData().Rows
+	data := pipelinezJSON{Pipelines: make([]pipelineJSON, 0, len(rows))}
+	for _, row := range rows {
+		data.Pipelines = append(data.Pipelines, pipelineJSON{
+			ID:          row.FullName,
+			DataType:    row.InputType,
+			MutatesData: row.MutatesData,
+			Receivers:   nonNil(row.Receivers),
+			Processors:  nonNil(row.Processors),
+			Exporters:   nonNil(row.Exporters),
+			Status:      row.Status,
+		})
+	}
+	writeJSON(w, data)
+}
+
+func (host *serviceHost) handleExtensionzJSONRequest(w http.ResponseWriter, r *http.Request) {
+	rows := host.getExtensionsSummaryTableData().Rows
+	data := extensionzJSON{Extensions: make([]extensionJSON, 0, len(rows))}
+	for _, row := range rows {
+		data.Extensions = append(data.Extensions, extensionJSON{ID: row.FullName, Status: row.Status})
+	}
+	writeJSON(w, data)
+}
+
+func handleFeaturezJSONRequest(w http.ResponseWriter, r *http.Request) {
+	rows := getFeaturesTableData().Rows
+	data := featurezJSON{FeatureGates: make([]featureGateJSON, 0, len(rows))}
+	for _, row := range rows {
+		data.FeatureGates = append(data.FeatureGates, featureGateJSON{
+			ID:          row.ID,
+			Enabled:     row.Enabled,
+			Description: row.Description,
+		})
+	}
+	writeJSON(w, data)
+}
+
+// loglevelzJSON is the JSON representation of the levels of the logs of the loglevelz page.
+type loglevelzJSON struct {
+	Level           *zapcore.Level           `json:"level,omitempty"`
+	ComponentLevels map[string]zapcore.Level `json:"componentLevels,omitempty"`
+}
+
+// handleLoglevelzRequest returns the levels of the logs of the collector and its components, or changes them
+// on PUT requests. The levels omitted in the request are not changed, the overrides of the components are
+// replaced as a whole.
+func (host *serviceHost) handleLoglevelzRequest(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var data loglevelzJSON
+		if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+			http.Error(w, "invalid levels: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		var componentLevels map[config.ComponentID]zapcore.Level
+		if data.ComponentLevels != nil {
+			componentLevels = make(map[config.ComponentID]zapcore.Level, len(data.ComponentLevels))
+			for name, level := range data.ComponentLevels {
+				id, err := config.NewComponentIDFromString(name)
+				if err != nil {
+					http.Error(w, "invalid component: "+err.Error(), http.StatusBadRequest)
+					return
+				}
+				componentLevels[id] = level
+			}
+		}
+		if data.Level != nil {
+			host.logLevels.SetLevel(*data.Level)
+		}
+		if componentLevels != nil {
+			host.logLevels.SetComponentLevels(componentLevels)
+		}
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	level := host.logLevels.Level()
+	data := loglevelzJSON{Level: &level, ComponentLevels: make(map[string]zapcore.Level)}
+	for id, level := range host.logLevels.ComponentLevels() {
+		data.ComponentLevels[id.String()] = level
+	}
+	writeJSON(w, data)
+}
+
+// nonNil returns the given slice, or an empty one if nil, so it is encoded as an empty JSON array.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func writeJSON(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("zpages: encoding JSON: %v", err)
+	}
+}
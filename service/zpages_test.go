@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/internal/tap"
+	"go.opentelemetry.io/collector/service/internal/telemetrylogs"
+)
+
+func TestZPagesJSON(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	srv := createExampleService(t, factories)
+	srv.host.collectorState = func() State { return Running }
+
+	assert.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+
+	mux := http.NewServeMux()
+	srv.host.RegisterZPages(mux, "/debug")
+
+	var servicez servicezJSON
+	getZPageJSON(t, mux, "/debug/servicez.json", &servicez)
+	assert.Equal(t, srv.buildInfo.Command, servicez.BuildInfo.Command)
+	assert.Equal(t, srv.buildInfo.Version, servicez.BuildInfo.Version)
+	assert.Contains(t, servicez.Runtime, "GoVersion")
+	assert.Contains(t, servicez.Runtime, "Uptime")
+	assert.Equal(t, "Running", servicez.State)
+
+	var pipelinez pipelinezJSON
+	getZPageJSON(t, mux, "/debug/pipelinez.json", &pipelinez)
+	require.Len(t, pipelinez.Pipelines, 3)
+	assert.Equal(t, pipelineJSON{
+		ID:         "logs",
+		DataType:   "logs",
+		Receivers:  []string{"nop"},
+		Processors: []string{"nop"},
+		Exporters:  []string{"nop"},
+		Status:     "OK",
+	}, pipelinez.Pipelines[0])
+	assert.Equal(t, "metrics", pipelinez.Pipelines[1].ID)
+	assert.Equal(t, "traces", pipelinez.Pipelines[2].ID)
+
+	var extensionz extensionzJSON
+	getZPageJSON(t, mux, "/debug/extensionz.json", &extensionz)
+	assert.Equal(t, []extensionJSON{{ID: "nop", Status: "OK"}}, extensionz.Extensions)
+
+	var featurez featurezJSON
+	getZPageJSON(t, mux, "/debug/featurez.json", &featurez)
+	assert.NotNil(t, featurez.FeatureGates)
+}
+
+func TestZPagesTap(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	srv := createExampleService(t, factories)
+
+	assert.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+
+	mux := http.NewServeMux()
+	srv.host.RegisterZPages(mux, "/debug")
+
+	tests := []struct {
+		name     string
+		query    string
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "invalid_kind",
+			query:    "zpipelinename=traces&zcomponentkind=extension&zcomponentname=nop",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid_interval",
+			query:    "zpipelinename=traces&zcomponentkind=receiver&zcomponentname=nop&interval=1",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unknown_component",
+			query:    "zpipelinename=traces&zcomponentkind=receiver&zcomponentname=unknown",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "unknown_pipeline",
+			query:    "zpipelinename=traces/2&zcomponentkind=exporter&zcomponentname=nop",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "stream",
+			query:    "zpipelinename=traces&zcomponentkind=processor&zcomponentname=nop&duration=10ms",
+			wantCode: http.StatusOK,
+			wantBody: "event: end\ndata: {\"dropped\":0}\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/tapz?"+tt.query, nil))
+			assert.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+				assert.Equal(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+	assert.Zero(t, srv.host.activeTaps.Load())
+}
+
+func TestZPagesLogLevels(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	srv := createExampleService(t, factories)
+	srv.host.logLevels = telemetrylogs.NewLevels()
+
+	mux := http.NewServeMux()
+	srv.host.RegisterZPages(mux, "/debug")
+
+	var levels map[string]interface{}
+	getZPageJSON(t, mux, "/debug/loglevelz", &levels)
+	assert.Equal(t, map[string]interface{}{"level": "info"}, levels)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/debug/loglevelz",
+		strings.NewReader(`{"level":"warn","componentLevels":{"nop":"debug"}}`)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"level":"warn","componentLevels":{"nop":"debug"}}`, rec.Body.String())
+	assert.Equal(t, zapcore.WarnLevel, srv.host.logLevels.Level())
+	assert.Equal(t, map[config.ComponentID]zapcore.Level{config.NewComponentID("nop"): zapcore.DebugLevel}, srv.host.logLevels.ComponentLevels())
+
+	// The omitted levels are not changed.
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/debug/loglevelz", strings.NewReader(`{"level":"error"}`)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"level":"error","componentLevels":{"nop":"debug"}}`, rec.Body.String())
+
+	for _, body := range []string{`{"level":"unknown"}`, `{"componentLevels":{"/":"debug"}}`, `not json`} {
+		rec = httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/debug/loglevelz", strings.NewReader(body)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+	assert.Equal(t, zapcore.ErrorLevel, srv.host.logLevels.Level())
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/debug/loglevelz", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestParseTapLimits(t *testing.T) {
+	limits, duration, err := parseTapLimits(url.Values{})
+	require.NoError(t, err)
+	assert.Equal(t, tap.Limits{Interval: defaultTapInterval, MaxSize: defaultTapMaxSize}, limits)
+	assert.Equal(t, defaultTapDuration, duration)
+
+	limits, duration, err = parseTapLimits(url.Values{
+		zTapInterval: []string{"1ms"},
+		zTapMaxSize:  []string{"1000000000"},
+		zTapDuration: []string{"24h"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, tap.Limits{Interval: minTapInterval, MaxSize: maxTapMaxSize}, limits)
+	assert.Equal(t, maxTapDuration, duration)
+
+	_, _, err = parseTapLimits(url.Values{zTapMaxSize: []string{"-1"}})
+	assert.Error(t, err)
+	_, _, err = parseTapLimits(url.Values{zTapDuration: []string{"0s"}})
+	assert.Error(t, err)
+}
+
+func getZPageJSON(t *testing.T, handler http.Handler, path string, data interface{}) {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), data))
+}